@@ -42,6 +42,7 @@ func TestConnectGreeterTransportAndStreamingMatrix(t *testing.T) {
 		assertMessageBroadcast(t, ctx, "server", []string{"broadcast[0]:server", "broadcast[1]:server"})
 		assertMessageChat(t, ctx, "bidi-message", "chat:bidi-message")
 		assertMessageChatConversation(t, ctx, []string{"ada-message", "grace-message"}, []string{"chat:ada-message", "chat:grace-message"})
+		assertDynamicMessageCalls(t)
 	})
 
 	t.Run("connect_remote", func(t *testing.T) {
@@ -59,6 +60,70 @@ func TestConnectGreeterTransportAndStreamingMatrix(t *testing.T) {
 	})
 }
 
+// panickingGreeter panics in SayHello and Collect to check that the cgo
+// exports and stream goroutines contain it.
+type panickingGreeter struct {
+	backend.Greeter
+}
+
+func (panickingGreeter) SayHello(context.Context, *rpcruntime.RpcString, *rpcruntime.RpcString) (string, error) {
+	panic("say hello exploded")
+}
+
+func (panickingGreeter) Collect(context.Context, greeterv1.GreeterCollectNativeClientStream) (string, error) {
+	panic("collect exploded")
+}
+
+func TestConnectGreeterRecoversServerPanics(t *testing.T) {
+	var reports []*rpcruntime.PanicError
+	rpcruntime.ConfigurePanicReports(rpcruntime.PanicReportConfig{
+		Stacks:  true,
+		OnPanic: func(panicErr *rpcruntime.PanicError) { reports = append(reports, panicErr) },
+	})
+	t.Cleanup(func() { rpcruntime.ConfigurePanicReports(rpcruntime.PanicReportConfig{}) })
+	if err := greeterv1.RegisterGreeterGoNativeServer(panickingGreeter{}); err != nil {
+		t.Fatalf("RegisterGreeterGoNativeServer() error = %v", err)
+	}
+	t.Cleanup(func() { registerNativeServer(t) })
+
+	input := nativeInput("panic", "local")
+	var messagePtr uintptr
+	var messageLen int32
+	errID := callGreeterSayHelloNativeUnary(input.namePtr(), input.nameLen(), 0, input.cityPtr(), input.cityLen(), 0, &messagePtr, &messageLen)
+	assertPanicError(t, errID, "say hello exploded")
+
+	handle, errID := greeterNativeCollectStart()
+	if errID != 0 {
+		t.Fatalf("greeterNativeCollectStart() error id = %d", errID)
+	}
+	errID = greeterNativeCollectFinish(handle, &messagePtr, &messageLen)
+	assertPanicError(t, errID, "collect exploded")
+
+	if len(reports) != 2 {
+		t.Fatalf("panic reports = %d, want 2", len(reports))
+	}
+	if reports[0].Where != "rpccgoNativeGreeterv1GreeterSayHello" || reports[1].Where != "examples.connect.greeter.v1.Greeter.Collect handler" {
+		t.Fatalf("panic report locations = %q, %q", reports[0].Where, reports[1].Where)
+	}
+	if !strings.Contains(reports[0].Stack, "panickingGreeter.SayHello") {
+		t.Fatalf("panic report stack does not name the panicking method:\n%s", reports[0].Stack)
+	}
+}
+
+func assertPanicError(t *testing.T, errID int32, want string) {
+	t.Helper()
+	if errID <= 0 {
+		t.Fatalf("error id = %d, want a stored panic error", errID)
+	}
+	statusErr, ok := rpcruntime.TakeError(rpcruntime.ErrorID(errID))
+	if !ok {
+		t.Fatalf("error id %d was not stored", errID)
+	}
+	if statusErr.Code != rpcruntime.ErrorCodeInternal || !strings.Contains(statusErr.Message, want) {
+		t.Fatalf("panic error = %v %q, want Internal containing %q", statusErr.Code, statusErr.Message, want)
+	}
+}
+
 func registerNativeServer(t *testing.T) {
 	t.Helper()
 	if err := greeterv1.RegisterGreeterGoNativeServer(backend.Greeter{}); err != nil {
@@ -428,6 +493,71 @@ func assertMessageChatConversation(t *testing.T, ctx context.Context, names []st
 	}
 }
 
+func assertDynamicMessageCalls(t *testing.T) {
+	t.Helper()
+
+	const service = "examples.connect.greeter.v1.Greeter"
+	var messagePtr uintptr
+	var messageLen int32
+	request := messageRequestBytes(t, "dynamic", "local")
+	if errID := dynamicInvoke(service, "SayHello", request, &messagePtr, &messageLen); errID != 0 {
+		t.Fatalf("rpccgoInvoke(SayHello) error id = %d: %s", errID, cgoErrorText(errID))
+	}
+	assertMessageOutput(t, messagePtr, messageLen, "hello dynamic from local")
+	if errID := dynamicInvoke(service, "Missing", request, &messagePtr, &messageLen); errID == 0 {
+		t.Fatal("rpccgoInvoke(Missing) error id = 0, want unknown method error")
+	}
+
+	collect, errID := dynamicStreamStart("", service+".Collect", nil)
+	if errID != 0 {
+		t.Fatalf("rpccgoStreamStart(Collect) error id = %d: %s", errID, cgoErrorText(errID))
+	}
+	for _, name := range []string{"a", "b"} {
+		request := messageRequestBytes(t, name, "remote")
+		if errID := dynamicStreamSend(collect, request); errID != 0 {
+			t.Fatalf("rpccgoStreamSend(Collect) error id = %d: %s", errID, cgoErrorText(errID))
+		}
+	}
+	if errID := dynamicStreamFinish(collect, &messagePtr, &messageLen); errID != 0 {
+		t.Fatalf("rpccgoStreamFinish(Collect) error id = %d: %s", errID, cgoErrorText(errID))
+	}
+	assertMessageOutput(t, messagePtr, messageLen, "collect:a,b")
+
+	request = messageRequestBytes(t, "x", "remote")
+	broadcast, errID := dynamicStreamStart(service, "Broadcast", request)
+	if errID != 0 {
+		t.Fatalf("rpccgoStreamStart(Broadcast) error id = %d: %s", errID, cgoErrorText(errID))
+	}
+	if errID := dynamicStreamRecv(broadcast, &messagePtr, &messageLen); errID != 0 {
+		t.Fatalf("rpccgoStreamRecv(Broadcast) error id = %d: %s", errID, cgoErrorText(errID))
+	}
+	assertMessageOutput(t, messagePtr, messageLen, "broadcast[0]:x")
+	if errID := dynamicStreamSend(broadcast, request); errID == 0 {
+		t.Fatal("rpccgoStreamSend(Broadcast) error id = 0, want unsupported operation error")
+	}
+	if errID := dynamicStreamCancel(broadcast); errID != 0 {
+		t.Fatalf("rpccgoStreamCancel(Broadcast) error id = %d: %s", errID, cgoErrorText(errID))
+	}
+
+	chat, errID := dynamicStreamStart(service, "Chat", nil)
+	if errID != 0 {
+		t.Fatalf("rpccgoStreamStart(Chat) error id = %d: %s", errID, cgoErrorText(errID))
+	}
+	if errID := dynamicStreamSend(chat, request); errID != 0 {
+		t.Fatalf("rpccgoStreamSend(Chat) error id = %d: %s", errID, cgoErrorText(errID))
+	}
+	if errID := dynamicStreamRecv(chat, &messagePtr, &messageLen); errID != 0 {
+		t.Fatalf("rpccgoStreamRecv(Chat) error id = %d: %s", errID, cgoErrorText(errID))
+	}
+	assertMessageOutput(t, messagePtr, messageLen, "chat:x")
+	if errID := dynamicStreamCloseSend(chat); errID != 0 {
+		t.Fatalf("rpccgoStreamCloseSend(Chat) error id = %d: %s", errID, cgoErrorText(errID))
+	}
+	if errID := dynamicStreamFinish(chat, &messagePtr, &messageLen); errID != 0 || messagePtr != 0 {
+		t.Fatalf("rpccgoStreamFinish(Chat) error id = %d, ptr = %d", errID, messagePtr)
+	}
+}
+
 func cgoErrorText(errorID int32) string {
 	data, ptr, ok := rpcruntime.TakeErrorText(rpcruntime.ErrorID(errorID))
 	if !ok {
@@ -451,7 +581,7 @@ func nativeInput(name, city string) nativeInputArgs {
 	}
 }
 
-func (a nativeInputArgs) namePtr() uintptr {
+func (a nativeInputArgs) namePtr() unsafe.Pointer {
 	return bytesPtr(a.name)
 }
 
@@ -459,7 +589,7 @@ func (a nativeInputArgs) nameLen() int32 {
 	return int32(len(a.name))
 }
 
-func (a nativeInputArgs) cityPtr() uintptr {
+func (a nativeInputArgs) cityPtr() unsafe.Pointer {
 	return bytesPtr(a.city)
 }
 
@@ -476,8 +606,8 @@ func messageRequestBytes(t *testing.T, name, city string) []byte {
 	return data
 }
 
-func bytesPtr(data []byte) uintptr {
-	return uintptr(unsafe.Pointer(unsafe.SliceData(data)))
+func bytesPtr(data []byte) unsafe.Pointer {
+	return unsafe.Pointer(unsafe.SliceData(data))
 }
 
 func assertNativeOutput(t *testing.T, ptr uintptr, length int32, want string) {
@@ -23,6 +23,7 @@ import (
 	fmt "fmt"
 	io "io"
 	rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"
+	unsafe "unsafe"
 )
 
 // rpccgo message direct generated file for Greeter cgo message client
@@ -30,8 +31,24 @@ import (
 // rpccgoMsgGreeterv1GreeterSayHello invokes the message unary client entrypoint for examples.connect.greeter.v1.Greeter.SayHello.
 //
 //export rpccgoMsgGreeterv1GreeterSayHello
-func rpccgoMsgGreeterv1GreeterSayHello(requestPtr C.uintptr_t, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
-	ctx := context.Background()
+func rpccgoMsgGreeterv1GreeterSayHello(requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	return rpccgoMsgGreeterv1GreeterSayHelloWithContext(context.Background(), requestPtr, requestLen, responsePtr, responseLen)
+}
+
+// rpccgoMsgGreeterv1GreeterSayHelloWithOptions is rpccgoMsgGreeterv1GreeterSayHello bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgGreeterv1GreeterSayHelloWithOptions
+func rpccgoMsgGreeterv1GreeterSayHelloWithOptions(options C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgGreeterv1GreeterSayHelloWithContext(ctx, requestPtr, requestLen, responsePtr, responseLen)
+}
+
+func rpccgoMsgGreeterv1GreeterSayHelloWithContext(ctx context.Context, requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgGreeterv1GreeterSayHello", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if responsePtr != nil {
 		*responsePtr = 0
 	}
@@ -58,11 +75,79 @@ func rpccgoMsgGreeterv1GreeterSayHello(requestPtr C.uintptr_t, requestLen C.int3
 	return 0
 }
 
+// rpccgoMsgGreeterv1GreeterSayHelloInto is rpccgoMsgGreeterv1GreeterSayHello marshaling the response into the caller buffer at bufferPtr instead of pinned memory, so there is nothing to release. responseLen receives the encoded length, or the length needed together with RPCCGO_ERR_BUFFER_TOO_SMALL; retrying repeats the call.
+//
+//export rpccgoMsgGreeterv1GreeterSayHelloInto
+func rpccgoMsgGreeterv1GreeterSayHelloInto(requestPtr unsafe.Pointer, requestLen C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, responseLen *C.int32_t) C.int32_t {
+	return rpccgoMsgGreeterv1GreeterSayHelloIntoWithContext(context.Background(), requestPtr, requestLen, bufferPtr, bufferCap, responseLen)
+}
+
+// rpccgoMsgGreeterv1GreeterSayHelloIntoWithOptions is rpccgoMsgGreeterv1GreeterSayHelloInto bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgGreeterv1GreeterSayHelloIntoWithOptions
+func rpccgoMsgGreeterv1GreeterSayHelloIntoWithOptions(options C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgGreeterv1GreeterSayHelloIntoWithContext(ctx, requestPtr, requestLen, bufferPtr, bufferCap, responseLen)
+}
+
+func rpccgoMsgGreeterv1GreeterSayHelloIntoWithContext(ctx context.Context, requestPtr unsafe.Pointer, requestLen C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgGreeterv1GreeterSayHelloInto", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
+	if responseLen != nil {
+		*responseLen = 0
+	}
+	if responseLen == nil {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: message client output pointer is nil")))
+	}
+	buffer, bufferErr := rpcruntime.NewOutputBuffer(bufferPtr, int32(bufferCap))
+	if bufferErr != nil {
+		return C.int32_t(rpcruntime.StoreError(bufferErr))
+	}
+	req := &proto.SayHelloRequest{}
+	if err := rpcruntime.DecodeMessage(uintptr(requestPtr), int32(requestLen), req); err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: message request decode failed: %w", err)))
+	}
+	resp, err := proto.InvokeGreeterMessageSayHello(ctx, req)
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	_, encodeErr := buffer.PutMessage(resp)
+	if length, err := buffer.Len(); err == nil {
+		*responseLen = C.int32_t(length)
+	}
+	if encodeErr != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: message response encode failed: %w", encodeErr)))
+	}
+	return 0
+}
+
 // rpccgoMsgGreeterv1GreeterCollectStart starts the message client-streaming client entrypoint for examples.connect.greeter.v1.Greeter.Collect.
 //
 //export rpccgoMsgGreeterv1GreeterCollectStart
 func rpccgoMsgGreeterv1GreeterCollectStart(handle *C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgGreeterv1GreeterCollectStartWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgGreeterv1GreeterCollectStartWithOptions is rpccgoMsgGreeterv1GreeterCollectStart bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgGreeterv1GreeterCollectStartWithOptions
+func rpccgoMsgGreeterv1GreeterCollectStartWithOptions(options C.int32_t, handle *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	status := rpccgoMsgGreeterv1GreeterCollectStartWithContext(ctx, handle)
+	if status != 0 {
+		cancel()
+	}
+	return status
+}
+
+func rpccgoMsgGreeterv1GreeterCollectStartWithContext(ctx context.Context, handle *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgGreeterv1GreeterCollectStart", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if handle != nil {
 		*handle = 0
 	}
@@ -80,8 +165,24 @@ func rpccgoMsgGreeterv1GreeterCollectStart(handle *C.int32_t) C.int32_t {
 // rpccgoMsgGreeterv1GreeterCollectSend sends a message request to the client-streaming client entrypoint for examples.connect.greeter.v1.Greeter.Collect.
 //
 //export rpccgoMsgGreeterv1GreeterCollectSend
-func rpccgoMsgGreeterv1GreeterCollectSend(handle C.int32_t, requestPtr C.uintptr_t, requestLen C.int32_t) C.int32_t {
-	ctx := context.Background()
+func rpccgoMsgGreeterv1GreeterCollectSend(handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) C.int32_t {
+	return rpccgoMsgGreeterv1GreeterCollectSendWithContext(context.Background(), handle, requestPtr, requestLen)
+}
+
+// rpccgoMsgGreeterv1GreeterCollectSendWithOptions is rpccgoMsgGreeterv1GreeterCollectSend bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgGreeterv1GreeterCollectSendWithOptions
+func rpccgoMsgGreeterv1GreeterCollectSendWithOptions(options C.int32_t, handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgGreeterv1GreeterCollectSendWithContext(ctx, handle, requestPtr, requestLen)
+}
+
+func rpccgoMsgGreeterv1GreeterCollectSendWithContext(ctx context.Context, handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgGreeterv1GreeterCollectSend", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	req := &proto.SayHelloRequest{}
 	if err := rpcruntime.DecodeMessage(uintptr(requestPtr), int32(requestLen), req); err != nil {
@@ -97,7 +198,23 @@ func rpccgoMsgGreeterv1GreeterCollectSend(handle C.int32_t, requestPtr C.uintptr
 //
 //export rpccgoMsgGreeterv1GreeterCollectFinish
 func rpccgoMsgGreeterv1GreeterCollectFinish(handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgGreeterv1GreeterCollectFinishWithContext(context.Background(), handle, responsePtr, responseLen)
+}
+
+// rpccgoMsgGreeterv1GreeterCollectFinishWithOptions is rpccgoMsgGreeterv1GreeterCollectFinish bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgGreeterv1GreeterCollectFinishWithOptions
+func rpccgoMsgGreeterv1GreeterCollectFinishWithOptions(options C.int32_t, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgGreeterv1GreeterCollectFinishWithContext(ctx, handle, responsePtr, responseLen)
+}
+
+func rpccgoMsgGreeterv1GreeterCollectFinishWithContext(ctx context.Context, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgGreeterv1GreeterCollectFinish", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if responsePtr != nil {
 		*responsePtr = 0
 	}
@@ -125,7 +242,23 @@ func rpccgoMsgGreeterv1GreeterCollectFinish(handle C.int32_t, responsePtr *C.uin
 //
 //export rpccgoMsgGreeterv1GreeterCollectCancel
 func rpccgoMsgGreeterv1GreeterCollectCancel(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgGreeterv1GreeterCollectCancelWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgGreeterv1GreeterCollectCancelWithOptions is rpccgoMsgGreeterv1GreeterCollectCancel bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgGreeterv1GreeterCollectCancelWithOptions
+func rpccgoMsgGreeterv1GreeterCollectCancelWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgGreeterv1GreeterCollectCancelWithContext(ctx, handle)
+}
+
+func rpccgoMsgGreeterv1GreeterCollectCancelWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgGreeterv1GreeterCollectCancel", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	err := proto.GreeterMessageCollectCancel(ctx, rpcruntime.StreamHandle(handleValue))
 	if err != nil {
@@ -137,8 +270,27 @@ func rpccgoMsgGreeterv1GreeterCollectCancel(handle C.int32_t) C.int32_t {
 // rpccgoMsgGreeterv1GreeterBroadcastStart starts the message server-streaming client entrypoint for examples.connect.greeter.v1.Greeter.Broadcast.
 //
 //export rpccgoMsgGreeterv1GreeterBroadcastStart
-func rpccgoMsgGreeterv1GreeterBroadcastStart(requestPtr C.uintptr_t, requestLen C.int32_t, handle *C.int32_t, onRecv C.GreeterRpccgoMessageOnRecvCallback, onDone C.GreeterRpccgoMessageOnDoneCallback) C.int32_t {
-	ctx := context.Background()
+func rpccgoMsgGreeterv1GreeterBroadcastStart(requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t, onRecv C.GreeterRpccgoMessageOnRecvCallback, onDone C.GreeterRpccgoMessageOnDoneCallback) C.int32_t {
+	return rpccgoMsgGreeterv1GreeterBroadcastStartWithContext(context.Background(), requestPtr, requestLen, handle, onRecv, onDone)
+}
+
+// rpccgoMsgGreeterv1GreeterBroadcastStartWithOptions is rpccgoMsgGreeterv1GreeterBroadcastStart bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgGreeterv1GreeterBroadcastStartWithOptions
+func rpccgoMsgGreeterv1GreeterBroadcastStartWithOptions(options C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t, onRecv C.GreeterRpccgoMessageOnRecvCallback, onDone C.GreeterRpccgoMessageOnDoneCallback) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	status := rpccgoMsgGreeterv1GreeterBroadcastStartWithContext(ctx, requestPtr, requestLen, handle, onRecv, onDone)
+	if status != 0 {
+		cancel()
+	}
+	return status
+}
+
+func rpccgoMsgGreeterv1GreeterBroadcastStartWithContext(ctx context.Context, requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t, onRecv C.GreeterRpccgoMessageOnRecvCallback, onDone C.GreeterRpccgoMessageOnDoneCallback) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgGreeterv1GreeterBroadcastStart", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if handle != nil {
 		*handle = 0
 	}
@@ -171,6 +323,12 @@ func rpccgoMsgGreeterv1GreeterBroadcastStart(requestPtr C.uintptr_t, requestLen
 			return C.int32_t(rpcruntime.StoreError(err))
 		}
 		go func() {
+			defer rpcruntime.RecoverPanic("examples.connect.greeter.v1.Greeter.Broadcast callback receive", func(err error) {
+				if callbackState.BeginDoneCallback() {
+					C.callGreeterRpccgoMessageOnDoneCallback(onDone, C.int32_t(int32(handleValue)), C.int32_t(int32(rpcruntime.StoreError(err))))
+					callbackState.EndDoneCallback()
+				}
+			})
 			for {
 				resp, err := source.Recv(context.Background())
 				if err != nil {
@@ -217,7 +375,23 @@ func rpccgoMsgGreeterv1GreeterBroadcastStart(requestPtr C.uintptr_t, requestLen
 //
 //export rpccgoMsgGreeterv1GreeterBroadcastRecv
 func rpccgoMsgGreeterv1GreeterBroadcastRecv(handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgGreeterv1GreeterBroadcastRecvWithContext(context.Background(), handle, responsePtr, responseLen)
+}
+
+// rpccgoMsgGreeterv1GreeterBroadcastRecvWithOptions is rpccgoMsgGreeterv1GreeterBroadcastRecv bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgGreeterv1GreeterBroadcastRecvWithOptions
+func rpccgoMsgGreeterv1GreeterBroadcastRecvWithOptions(options C.int32_t, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgGreeterv1GreeterBroadcastRecvWithContext(ctx, handle, responsePtr, responseLen)
+}
+
+func rpccgoMsgGreeterv1GreeterBroadcastRecvWithContext(ctx context.Context, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgGreeterv1GreeterBroadcastRecv", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if responsePtr != nil {
 		*responsePtr = 0
 	}
@@ -248,7 +422,23 @@ func rpccgoMsgGreeterv1GreeterBroadcastRecv(handle C.int32_t, responsePtr *C.uin
 //
 //export rpccgoMsgGreeterv1GreeterBroadcastCancel
 func rpccgoMsgGreeterv1GreeterBroadcastCancel(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgGreeterv1GreeterBroadcastCancelWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgGreeterv1GreeterBroadcastCancelWithOptions is rpccgoMsgGreeterv1GreeterBroadcastCancel bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgGreeterv1GreeterBroadcastCancelWithOptions
+func rpccgoMsgGreeterv1GreeterBroadcastCancelWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgGreeterv1GreeterBroadcastCancelWithContext(ctx, handle)
+}
+
+func rpccgoMsgGreeterv1GreeterBroadcastCancelWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgGreeterv1GreeterBroadcastCancel", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	callbackState, _ := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))
 	if callbackState != nil {
@@ -268,7 +458,23 @@ func rpccgoMsgGreeterv1GreeterBroadcastCancel(handle C.int32_t) C.int32_t {
 //
 //export rpccgoMsgGreeterv1GreeterBroadcastClose
 func rpccgoMsgGreeterv1GreeterBroadcastClose(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgGreeterv1GreeterBroadcastCloseWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgGreeterv1GreeterBroadcastCloseWithOptions is rpccgoMsgGreeterv1GreeterBroadcastClose bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgGreeterv1GreeterBroadcastCloseWithOptions
+func rpccgoMsgGreeterv1GreeterBroadcastCloseWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgGreeterv1GreeterBroadcastCloseWithContext(ctx, handle)
+}
+
+func rpccgoMsgGreeterv1GreeterBroadcastCloseWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgGreeterv1GreeterBroadcastClose", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	callbackState, err := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))
 	if err != nil {
@@ -286,7 +492,26 @@ func rpccgoMsgGreeterv1GreeterBroadcastClose(handle C.int32_t) C.int32_t {
 //
 //export rpccgoMsgGreeterv1GreeterChatStart
 func rpccgoMsgGreeterv1GreeterChatStart(handle *C.int32_t, onRecv C.GreeterRpccgoMessageOnRecvCallback, onDone C.GreeterRpccgoMessageOnDoneCallback) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgGreeterv1GreeterChatStartWithContext(context.Background(), handle, onRecv, onDone)
+}
+
+// rpccgoMsgGreeterv1GreeterChatStartWithOptions is rpccgoMsgGreeterv1GreeterChatStart bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgGreeterv1GreeterChatStartWithOptions
+func rpccgoMsgGreeterv1GreeterChatStartWithOptions(options C.int32_t, handle *C.int32_t, onRecv C.GreeterRpccgoMessageOnRecvCallback, onDone C.GreeterRpccgoMessageOnDoneCallback) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	status := rpccgoMsgGreeterv1GreeterChatStartWithContext(ctx, handle, onRecv, onDone)
+	if status != 0 {
+		cancel()
+	}
+	return status
+}
+
+func rpccgoMsgGreeterv1GreeterChatStartWithContext(ctx context.Context, handle *C.int32_t, onRecv C.GreeterRpccgoMessageOnRecvCallback, onDone C.GreeterRpccgoMessageOnDoneCallback) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgGreeterv1GreeterChatStart", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if handle != nil {
 		*handle = 0
 	}
@@ -315,6 +540,12 @@ func rpccgoMsgGreeterv1GreeterChatStart(handle *C.int32_t, onRecv C.GreeterRpccg
 			return C.int32_t(rpcruntime.StoreError(err))
 		}
 		go func() {
+			defer rpcruntime.RecoverPanic("examples.connect.greeter.v1.Greeter.Chat callback receive", func(err error) {
+				if callbackState.BeginDoneCallback() {
+					C.callGreeterRpccgoMessageOnDoneCallback(onDone, C.int32_t(int32(handleValue)), C.int32_t(int32(rpcruntime.StoreError(err))))
+					callbackState.EndDoneCallback()
+				}
+			})
 			for {
 				resp, err := source.Recv(context.Background())
 				if err != nil {
@@ -360,8 +591,24 @@ func rpccgoMsgGreeterv1GreeterChatStart(handle *C.int32_t, onRecv C.GreeterRpccg
 // rpccgoMsgGreeterv1GreeterChatSend sends a message request to the bidi-streaming client entrypoint for examples.connect.greeter.v1.Greeter.Chat.
 //
 //export rpccgoMsgGreeterv1GreeterChatSend
-func rpccgoMsgGreeterv1GreeterChatSend(handle C.int32_t, requestPtr C.uintptr_t, requestLen C.int32_t) C.int32_t {
-	ctx := context.Background()
+func rpccgoMsgGreeterv1GreeterChatSend(handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) C.int32_t {
+	return rpccgoMsgGreeterv1GreeterChatSendWithContext(context.Background(), handle, requestPtr, requestLen)
+}
+
+// rpccgoMsgGreeterv1GreeterChatSendWithOptions is rpccgoMsgGreeterv1GreeterChatSend bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgGreeterv1GreeterChatSendWithOptions
+func rpccgoMsgGreeterv1GreeterChatSendWithOptions(options C.int32_t, handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgGreeterv1GreeterChatSendWithContext(ctx, handle, requestPtr, requestLen)
+}
+
+func rpccgoMsgGreeterv1GreeterChatSendWithContext(ctx context.Context, handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgGreeterv1GreeterChatSend", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	req := &proto.SayHelloRequest{}
 	if err := rpcruntime.DecodeMessage(uintptr(requestPtr), int32(requestLen), req); err != nil {
@@ -377,7 +624,23 @@ func rpccgoMsgGreeterv1GreeterChatSend(handle C.int32_t, requestPtr C.uintptr_t,
 //
 //export rpccgoMsgGreeterv1GreeterChatRecv
 func rpccgoMsgGreeterv1GreeterChatRecv(handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgGreeterv1GreeterChatRecvWithContext(context.Background(), handle, responsePtr, responseLen)
+}
+
+// rpccgoMsgGreeterv1GreeterChatRecvWithOptions is rpccgoMsgGreeterv1GreeterChatRecv bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgGreeterv1GreeterChatRecvWithOptions
+func rpccgoMsgGreeterv1GreeterChatRecvWithOptions(options C.int32_t, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgGreeterv1GreeterChatRecvWithContext(ctx, handle, responsePtr, responseLen)
+}
+
+func rpccgoMsgGreeterv1GreeterChatRecvWithContext(ctx context.Context, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgGreeterv1GreeterChatRecv", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if responsePtr != nil {
 		*responsePtr = 0
 	}
@@ -408,7 +671,23 @@ func rpccgoMsgGreeterv1GreeterChatRecv(handle C.int32_t, responsePtr *C.uintptr_
 //
 //export rpccgoMsgGreeterv1GreeterChatCloseSend
 func rpccgoMsgGreeterv1GreeterChatCloseSend(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgGreeterv1GreeterChatCloseSendWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgGreeterv1GreeterChatCloseSendWithOptions is rpccgoMsgGreeterv1GreeterChatCloseSend bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgGreeterv1GreeterChatCloseSendWithOptions
+func rpccgoMsgGreeterv1GreeterChatCloseSendWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgGreeterv1GreeterChatCloseSendWithContext(ctx, handle)
+}
+
+func rpccgoMsgGreeterv1GreeterChatCloseSendWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgGreeterv1GreeterChatCloseSend", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	err := proto.GreeterMessageChatCloseSend(ctx, rpcruntime.StreamHandle(handleValue))
 	if err != nil {
@@ -421,7 +700,23 @@ func rpccgoMsgGreeterv1GreeterChatCloseSend(handle C.int32_t) C.int32_t {
 //
 //export rpccgoMsgGreeterv1GreeterChatFinish
 func rpccgoMsgGreeterv1GreeterChatFinish(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgGreeterv1GreeterChatFinishWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgGreeterv1GreeterChatFinishWithOptions is rpccgoMsgGreeterv1GreeterChatFinish bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgGreeterv1GreeterChatFinishWithOptions
+func rpccgoMsgGreeterv1GreeterChatFinishWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgGreeterv1GreeterChatFinishWithContext(ctx, handle)
+}
+
+func rpccgoMsgGreeterv1GreeterChatFinishWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgGreeterv1GreeterChatFinish", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	callbackState, _ := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))
 	if callbackState != nil {
@@ -441,7 +736,23 @@ func rpccgoMsgGreeterv1GreeterChatFinish(handle C.int32_t) C.int32_t {
 //
 //export rpccgoMsgGreeterv1GreeterChatCancel
 func rpccgoMsgGreeterv1GreeterChatCancel(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgGreeterv1GreeterChatCancelWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgGreeterv1GreeterChatCancelWithOptions is rpccgoMsgGreeterv1GreeterChatCancel bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgGreeterv1GreeterChatCancelWithOptions
+func rpccgoMsgGreeterv1GreeterChatCancelWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgGreeterv1GreeterChatCancelWithContext(ctx, handle)
+}
+
+func rpccgoMsgGreeterv1GreeterChatCancelWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgGreeterv1GreeterChatCancel", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	callbackState, _ := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))
 	if callbackState != nil {
@@ -461,7 +772,23 @@ func rpccgoMsgGreeterv1GreeterChatCancel(handle C.int32_t) C.int32_t {
 //
 //export rpccgoMsgGreeterv1GreeterChatClose
 func rpccgoMsgGreeterv1GreeterChatClose(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgGreeterv1GreeterChatCloseWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgGreeterv1GreeterChatCloseWithOptions is rpccgoMsgGreeterv1GreeterChatClose bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgGreeterv1GreeterChatCloseWithOptions
+func rpccgoMsgGreeterv1GreeterChatCloseWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgGreeterv1GreeterChatCloseWithContext(ctx, handle)
+}
+
+func rpccgoMsgGreeterv1GreeterChatCloseWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgGreeterv1GreeterChatClose", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	callbackState, err := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))
 	if err != nil {
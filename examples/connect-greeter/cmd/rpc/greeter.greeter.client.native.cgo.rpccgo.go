@@ -37,17 +37,17 @@ import (
 var greeterNativeClientUnsupportedField = errors.New("rpccgo: native unary client field codec is not implemented")
 var greeterNativeClientStreamHandleInvalid = errors.New("rpccgo: native client stream handle is invalid")
 
-func decodeGreeterSayHelloNativeUnaryRequest(NamePtr uintptr, NameLen int32, NameOwnership int32, CityPtr uintptr, CityLen int32, CityOwnership int32) (*rpcruntime.RpcString, *rpcruntime.RpcString, error) {
+func decodeGreeterSayHelloNativeUnaryRequest(NamePtr unsafe.Pointer, NameLen int32, NameOwnership int32, CityPtr unsafe.Pointer, CityLen int32, CityOwnership int32) (*rpcruntime.RpcString, *rpcruntime.RpcString, error) {
 	var decoded rpcruntime.NativeReleaseStack
 	if _, err := rpcruntime.LengthFromInt32(NameLen); err != nil {
 		return nil, nil, errors.Join(fmt.Errorf("examples.connect.greeter.v1.SayHelloRequest.name: %w", err), decoded.Release())
 	}
 	var nameValue *rpcruntime.RpcString
-	if NamePtr == 0 || NameLen == 0 {
+	if NamePtr == nil || NameLen == 0 {
 		nameValue = rpcruntime.EmptyRpcString()
 	} else {
 		var decodeErr error
-		nameValue, decodeErr = rpcruntime.NewRpcStringChecked((*byte)(unsafe.Pointer(NamePtr)), NameLen, NameOwnership > 0)
+		nameValue, decodeErr = rpcruntime.NewRpcStringChecked((*byte)(NamePtr), NameLen, NameOwnership > 0)
 		if decodeErr != nil {
 			return nil, nil, errors.Join(fmt.Errorf("examples.connect.greeter.v1.SayHelloRequest.name: %w", decodeErr), decoded.Release())
 		}
@@ -57,11 +57,11 @@ func decodeGreeterSayHelloNativeUnaryRequest(NamePtr uintptr, NameLen int32, Nam
 		return nil, nil, errors.Join(fmt.Errorf("examples.connect.greeter.v1.SayHelloRequest.city: %w", err), decoded.Release())
 	}
 	var cityValue *rpcruntime.RpcString
-	if CityPtr == 0 || CityLen == 0 {
+	if CityPtr == nil || CityLen == 0 {
 		cityValue = rpcruntime.EmptyRpcString()
 	} else {
 		var decodeErr error
-		cityValue, decodeErr = rpcruntime.NewRpcStringChecked((*byte)(unsafe.Pointer(CityPtr)), CityLen, CityOwnership > 0)
+		cityValue, decodeErr = rpcruntime.NewRpcStringChecked((*byte)(CityPtr), CityLen, CityOwnership > 0)
 		if decodeErr != nil {
 			return nil, nil, errors.Join(fmt.Errorf("examples.connect.greeter.v1.SayHelloRequest.city: %w", decodeErr), decoded.Release())
 		}
@@ -99,11 +99,44 @@ func encodeGreeterSayHelloNativeUnaryResponse(messageResult string, outMessagePt
 	return nil
 }
 
+func encodeGreeterSayHelloNativeUnaryResponseInto(messageResult string, buffer *rpcruntime.OutputBuffer, outMessagePtr *uintptr, outMessageLen *int32) error {
+	if err := validateGreeterSayHelloNativeUnaryResponse(outMessagePtr, outMessageLen); err != nil {
+		return err
+	}
+	messageLenValue, err := rpcruntime.LengthToInt32(len(messageResult))
+	if err != nil {
+		return err
+	}
+	messagePtrValue := buffer.PutString(messageResult)
+	if err := buffer.Err(); err != nil {
+		return err
+	}
+	*outMessagePtr = messagePtrValue
+	*outMessageLen = messageLenValue
+	return nil
+}
+
 // rpccgoNativeGreeterv1GreeterSayHello invokes the native unary client entrypoint for examples.connect.greeter.v1.Greeter.SayHello.
 //
 //export rpccgoNativeGreeterv1GreeterSayHello
-func rpccgoNativeGreeterv1GreeterSayHello(NamePtr C.uintptr_t, NameLen C.int32_t, NameOwnership C.int32_t, CityPtr C.uintptr_t, CityLen C.int32_t, CityOwnership C.int32_t, outMessagePtr *C.uintptr_t, outMessageLen *C.int32_t, outMessageOwnership *C.int32_t) C.int32_t {
-	ctx := context.Background()
+func rpccgoNativeGreeterv1GreeterSayHello(NamePtr unsafe.Pointer, NameLen C.int32_t, NameOwnership C.int32_t, CityPtr unsafe.Pointer, CityLen C.int32_t, CityOwnership C.int32_t, outMessagePtr *C.uintptr_t, outMessageLen *C.int32_t, outMessageOwnership *C.int32_t) C.int32_t {
+	return rpccgoNativeGreeterv1GreeterSayHelloWithContext(context.Background(), NamePtr, NameLen, NameOwnership, CityPtr, CityLen, CityOwnership, outMessagePtr, outMessageLen, outMessageOwnership)
+}
+
+// rpccgoNativeGreeterv1GreeterSayHelloWithOptions is rpccgoNativeGreeterv1GreeterSayHello bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoNativeGreeterv1GreeterSayHelloWithOptions
+func rpccgoNativeGreeterv1GreeterSayHelloWithOptions(options C.int32_t, NamePtr unsafe.Pointer, NameLen C.int32_t, NameOwnership C.int32_t, CityPtr unsafe.Pointer, CityLen C.int32_t, CityOwnership C.int32_t, outMessagePtr *C.uintptr_t, outMessageLen *C.int32_t, outMessageOwnership *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoNativeGreeterv1GreeterSayHelloWithContext(ctx, NamePtr, NameLen, NameOwnership, CityPtr, CityLen, CityOwnership, outMessagePtr, outMessageLen, outMessageOwnership)
+}
+
+func rpccgoNativeGreeterv1GreeterSayHelloWithContext(ctx context.Context, NamePtr unsafe.Pointer, NameLen C.int32_t, NameOwnership C.int32_t, CityPtr unsafe.Pointer, CityLen C.int32_t, CityOwnership C.int32_t, outMessagePtr *C.uintptr_t, outMessageLen *C.int32_t, outMessageOwnership *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoNativeGreeterv1GreeterSayHello", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if outMessagePtr != nil {
 		*outMessagePtr = 0
 	}
@@ -122,7 +155,7 @@ func rpccgoNativeGreeterv1GreeterSayHello(NamePtr C.uintptr_t, NameLen C.int32_t
 	if err := validateGreeterSayHelloNativeUnaryResponse((*uintptr)(unsafe.Pointer(outMessagePtr)), (*int32)(unsafe.Pointer(outMessageLen))); err != nil {
 		return C.int32_t(rpcruntime.StoreError(err))
 	}
-	nameValue, cityValue, err := decodeGreeterSayHelloNativeUnaryRequest(uintptr(NamePtr), int32(NameLen), int32(NameOwnership), uintptr(CityPtr), int32(CityLen), int32(CityOwnership))
+	nameValue, cityValue, err := decodeGreeterSayHelloNativeUnaryRequest(NamePtr, int32(NameLen), int32(NameOwnership), CityPtr, int32(CityLen), int32(CityOwnership))
 	if err != nil {
 		return C.int32_t(rpcruntime.StoreError(err))
 	}
@@ -139,17 +172,87 @@ func rpccgoNativeGreeterv1GreeterSayHello(NamePtr C.uintptr_t, NameLen C.int32_t
 	return 0
 }
 
-func decodeGreeterCollectNativeClientStreamRequest(NamePtr uintptr, NameLen int32, NameOwnership int32, CityPtr uintptr, CityLen int32, CityOwnership int32) (*rpcruntime.RpcString, *rpcruntime.RpcString, error) {
+// rpccgoNativeGreeterv1GreeterSayHelloInto is rpccgoNativeGreeterv1GreeterSayHello writing variable-length outputs into the caller buffer at bufferPtr instead of pinned memory; output pointers point into that buffer and need no release. bufferLen receives the bytes used, or the bytes needed together with RPCCGO_ERR_BUFFER_TOO_SMALL; retrying repeats the call, and inputs passed with ownership 1 are released even then, so a retry must pass fresh ones.
+//
+//export rpccgoNativeGreeterv1GreeterSayHelloInto
+func rpccgoNativeGreeterv1GreeterSayHelloInto(NamePtr unsafe.Pointer, NameLen C.int32_t, NameOwnership C.int32_t, CityPtr unsafe.Pointer, CityLen C.int32_t, CityOwnership C.int32_t, outMessagePtr *C.uintptr_t, outMessageLen *C.int32_t, outMessageOwnership *C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, bufferLen *C.int32_t) C.int32_t {
+	return rpccgoNativeGreeterv1GreeterSayHelloIntoWithContext(context.Background(), NamePtr, NameLen, NameOwnership, CityPtr, CityLen, CityOwnership, outMessagePtr, outMessageLen, outMessageOwnership, bufferPtr, bufferCap, bufferLen)
+}
+
+// rpccgoNativeGreeterv1GreeterSayHelloIntoWithOptions is rpccgoNativeGreeterv1GreeterSayHelloInto bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoNativeGreeterv1GreeterSayHelloIntoWithOptions
+func rpccgoNativeGreeterv1GreeterSayHelloIntoWithOptions(options C.int32_t, NamePtr unsafe.Pointer, NameLen C.int32_t, NameOwnership C.int32_t, CityPtr unsafe.Pointer, CityLen C.int32_t, CityOwnership C.int32_t, outMessagePtr *C.uintptr_t, outMessageLen *C.int32_t, outMessageOwnership *C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, bufferLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoNativeGreeterv1GreeterSayHelloIntoWithContext(ctx, NamePtr, NameLen, NameOwnership, CityPtr, CityLen, CityOwnership, outMessagePtr, outMessageLen, outMessageOwnership, bufferPtr, bufferCap, bufferLen)
+}
+
+func rpccgoNativeGreeterv1GreeterSayHelloIntoWithContext(ctx context.Context, NamePtr unsafe.Pointer, NameLen C.int32_t, NameOwnership C.int32_t, CityPtr unsafe.Pointer, CityLen C.int32_t, CityOwnership C.int32_t, outMessagePtr *C.uintptr_t, outMessageLen *C.int32_t, outMessageOwnership *C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, bufferLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoNativeGreeterv1GreeterSayHelloInto", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
+	if outMessagePtr != nil {
+		*outMessagePtr = 0
+	}
+	if outMessageLen != nil {
+		*outMessageLen = 0
+	}
+	if outMessageOwnership != nil {
+		*outMessageOwnership = 0
+	}
+	if outMessagePtr == nil {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: native client output pointer is nil")))
+	}
+	if outMessageLen == nil {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: native client output pointer is nil")))
+	}
+	if bufferLen != nil {
+		*bufferLen = 0
+	}
+	if bufferLen == nil {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: native client output pointer is nil")))
+	}
+	buffer, bufferErr := rpcruntime.NewOutputBuffer(bufferPtr, int32(bufferCap))
+	if bufferErr != nil {
+		return C.int32_t(rpcruntime.StoreError(bufferErr))
+	}
+	if err := validateGreeterSayHelloNativeUnaryResponse((*uintptr)(unsafe.Pointer(outMessagePtr)), (*int32)(unsafe.Pointer(outMessageLen))); err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	nameValue, cityValue, err := decodeGreeterSayHelloNativeUnaryRequest(NamePtr, int32(NameLen), int32(NameOwnership), CityPtr, int32(CityLen), int32(CityOwnership))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	messageResult, err := proto.InvokeGreeterNativeSayHello(ctx, nameValue, cityValue)
+	if cleanupErr := errors.Join(nameValue.Release(), cityValue.Release()); cleanupErr != nil {
+		err = errors.Join(err, cleanupErr)
+	}
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	encodeErr := encodeGreeterSayHelloNativeUnaryResponseInto(messageResult, buffer, (*uintptr)(unsafe.Pointer(outMessagePtr)), (*int32)(unsafe.Pointer(outMessageLen)))
+	if length, err := buffer.Len(); err == nil {
+		*bufferLen = C.int32_t(length)
+	}
+	if encodeErr != nil {
+		return C.int32_t(rpcruntime.StoreError(encodeErr))
+	}
+	return 0
+}
+
+func decodeGreeterCollectNativeClientStreamRequest(NamePtr unsafe.Pointer, NameLen int32, NameOwnership int32, CityPtr unsafe.Pointer, CityLen int32, CityOwnership int32) (*rpcruntime.RpcString, *rpcruntime.RpcString, error) {
 	var decoded rpcruntime.NativeReleaseStack
 	if _, err := rpcruntime.LengthFromInt32(NameLen); err != nil {
 		return nil, nil, errors.Join(fmt.Errorf("examples.connect.greeter.v1.SayHelloRequest.name: %w", err), decoded.Release())
 	}
 	var nameValue *rpcruntime.RpcString
-	if NamePtr == 0 || NameLen == 0 {
+	if NamePtr == nil || NameLen == 0 {
 		nameValue = rpcruntime.EmptyRpcString()
 	} else {
 		var decodeErr error
-		nameValue, decodeErr = rpcruntime.NewRpcStringChecked((*byte)(unsafe.Pointer(NamePtr)), NameLen, NameOwnership > 0)
+		nameValue, decodeErr = rpcruntime.NewRpcStringChecked((*byte)(NamePtr), NameLen, NameOwnership > 0)
 		if decodeErr != nil {
 			return nil, nil, errors.Join(fmt.Errorf("examples.connect.greeter.v1.SayHelloRequest.name: %w", decodeErr), decoded.Release())
 		}
@@ -159,11 +262,11 @@ func decodeGreeterCollectNativeClientStreamRequest(NamePtr uintptr, NameLen int3
 		return nil, nil, errors.Join(fmt.Errorf("examples.connect.greeter.v1.SayHelloRequest.city: %w", err), decoded.Release())
 	}
 	var cityValue *rpcruntime.RpcString
-	if CityPtr == 0 || CityLen == 0 {
+	if CityPtr == nil || CityLen == 0 {
 		cityValue = rpcruntime.EmptyRpcString()
 	} else {
 		var decodeErr error
-		cityValue, decodeErr = rpcruntime.NewRpcStringChecked((*byte)(unsafe.Pointer(CityPtr)), CityLen, CityOwnership > 0)
+		cityValue, decodeErr = rpcruntime.NewRpcStringChecked((*byte)(CityPtr), CityLen, CityOwnership > 0)
 		if decodeErr != nil {
 			return nil, nil, errors.Join(fmt.Errorf("examples.connect.greeter.v1.SayHelloRequest.city: %w", decodeErr), decoded.Release())
 		}
@@ -205,7 +308,26 @@ func encodeGreeterCollectNativeClientStreamResponse(messageResult string, outMes
 //
 //export rpccgoNativeGreeterv1GreeterCollectStart
 func rpccgoNativeGreeterv1GreeterCollectStart(stream *C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoNativeGreeterv1GreeterCollectStartWithContext(context.Background(), stream)
+}
+
+// rpccgoNativeGreeterv1GreeterCollectStartWithOptions is rpccgoNativeGreeterv1GreeterCollectStart bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoNativeGreeterv1GreeterCollectStartWithOptions
+func rpccgoNativeGreeterv1GreeterCollectStartWithOptions(options C.int32_t, stream *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	status := rpccgoNativeGreeterv1GreeterCollectStartWithContext(ctx, stream)
+	if status != 0 {
+		cancel()
+	}
+	return status
+}
+
+func rpccgoNativeGreeterv1GreeterCollectStartWithContext(ctx context.Context, stream *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoNativeGreeterv1GreeterCollectStart", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if stream != nil {
 		*stream = 0
 	}
@@ -223,11 +345,27 @@ func rpccgoNativeGreeterv1GreeterCollectStart(stream *C.int32_t) C.int32_t {
 // rpccgoNativeGreeterv1GreeterCollectSend sends native request values to the client-streaming client entrypoint for examples.connect.greeter.v1.Greeter.Collect.
 //
 //export rpccgoNativeGreeterv1GreeterCollectSend
-func rpccgoNativeGreeterv1GreeterCollectSend(stream C.int32_t, NamePtr C.uintptr_t, NameLen C.int32_t, NameOwnership C.int32_t, CityPtr C.uintptr_t, CityLen C.int32_t, CityOwnership C.int32_t) C.int32_t {
-	ctx := context.Background()
+func rpccgoNativeGreeterv1GreeterCollectSend(stream C.int32_t, NamePtr unsafe.Pointer, NameLen C.int32_t, NameOwnership C.int32_t, CityPtr unsafe.Pointer, CityLen C.int32_t, CityOwnership C.int32_t) C.int32_t {
+	return rpccgoNativeGreeterv1GreeterCollectSendWithContext(context.Background(), stream, NamePtr, NameLen, NameOwnership, CityPtr, CityLen, CityOwnership)
+}
+
+// rpccgoNativeGreeterv1GreeterCollectSendWithOptions is rpccgoNativeGreeterv1GreeterCollectSend bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoNativeGreeterv1GreeterCollectSendWithOptions
+func rpccgoNativeGreeterv1GreeterCollectSendWithOptions(options C.int32_t, stream C.int32_t, NamePtr unsafe.Pointer, NameLen C.int32_t, NameOwnership C.int32_t, CityPtr unsafe.Pointer, CityLen C.int32_t, CityOwnership C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoNativeGreeterv1GreeterCollectSendWithContext(ctx, stream, NamePtr, NameLen, NameOwnership, CityPtr, CityLen, CityOwnership)
+}
+
+func rpccgoNativeGreeterv1GreeterCollectSendWithContext(ctx context.Context, stream C.int32_t, NamePtr unsafe.Pointer, NameLen C.int32_t, NameOwnership C.int32_t, CityPtr unsafe.Pointer, CityLen C.int32_t, CityOwnership C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoNativeGreeterv1GreeterCollectSend", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handle := int32(stream)
 	var err error
-	nameValue, cityValue, err := decodeGreeterCollectNativeClientStreamRequest(uintptr(NamePtr), int32(NameLen), int32(NameOwnership), uintptr(CityPtr), int32(CityLen), int32(CityOwnership))
+	nameValue, cityValue, err := decodeGreeterCollectNativeClientStreamRequest(NamePtr, int32(NameLen), int32(NameOwnership), CityPtr, int32(CityLen), int32(CityOwnership))
 	if err != nil {
 		return C.int32_t(rpcruntime.StoreError(err))
 	}
@@ -245,7 +383,23 @@ func rpccgoNativeGreeterv1GreeterCollectSend(stream C.int32_t, NamePtr C.uintptr
 //
 //export rpccgoNativeGreeterv1GreeterCollectFinish
 func rpccgoNativeGreeterv1GreeterCollectFinish(stream C.int32_t, outMessagePtr *C.uintptr_t, outMessageLen *C.int32_t, outMessageOwnership *C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoNativeGreeterv1GreeterCollectFinishWithContext(context.Background(), stream, outMessagePtr, outMessageLen, outMessageOwnership)
+}
+
+// rpccgoNativeGreeterv1GreeterCollectFinishWithOptions is rpccgoNativeGreeterv1GreeterCollectFinish bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoNativeGreeterv1GreeterCollectFinishWithOptions
+func rpccgoNativeGreeterv1GreeterCollectFinishWithOptions(options C.int32_t, stream C.int32_t, outMessagePtr *C.uintptr_t, outMessageLen *C.int32_t, outMessageOwnership *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoNativeGreeterv1GreeterCollectFinishWithContext(ctx, stream, outMessagePtr, outMessageLen, outMessageOwnership)
+}
+
+func rpccgoNativeGreeterv1GreeterCollectFinishWithContext(ctx context.Context, stream C.int32_t, outMessagePtr *C.uintptr_t, outMessageLen *C.int32_t, outMessageOwnership *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoNativeGreeterv1GreeterCollectFinish", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if outMessagePtr != nil {
 		*outMessagePtr = 0
 	}
@@ -281,7 +435,23 @@ func rpccgoNativeGreeterv1GreeterCollectFinish(stream C.int32_t, outMessagePtr *
 //
 //export rpccgoNativeGreeterv1GreeterCollectCancel
 func rpccgoNativeGreeterv1GreeterCollectCancel(stream C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoNativeGreeterv1GreeterCollectCancelWithContext(context.Background(), stream)
+}
+
+// rpccgoNativeGreeterv1GreeterCollectCancelWithOptions is rpccgoNativeGreeterv1GreeterCollectCancel bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoNativeGreeterv1GreeterCollectCancelWithOptions
+func rpccgoNativeGreeterv1GreeterCollectCancelWithOptions(options C.int32_t, stream C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoNativeGreeterv1GreeterCollectCancelWithContext(ctx, stream)
+}
+
+func rpccgoNativeGreeterv1GreeterCollectCancelWithContext(ctx context.Context, stream C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoNativeGreeterv1GreeterCollectCancel", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handle := int32(stream)
 	var err error
 	err = proto.GreeterNativeCollectCancel(ctx, rpcruntime.StreamHandle(handle))
@@ -291,17 +461,17 @@ func rpccgoNativeGreeterv1GreeterCollectCancel(stream C.int32_t) C.int32_t {
 	return 0
 }
 
-func decodeGreeterBroadcastNativeServerStreamRequest(NamePtr uintptr, NameLen int32, NameOwnership int32, CityPtr uintptr, CityLen int32, CityOwnership int32) (*rpcruntime.RpcString, *rpcruntime.RpcString, error) {
+func decodeGreeterBroadcastNativeServerStreamRequest(NamePtr unsafe.Pointer, NameLen int32, NameOwnership int32, CityPtr unsafe.Pointer, CityLen int32, CityOwnership int32) (*rpcruntime.RpcString, *rpcruntime.RpcString, error) {
 	var decoded rpcruntime.NativeReleaseStack
 	if _, err := rpcruntime.LengthFromInt32(NameLen); err != nil {
 		return nil, nil, errors.Join(fmt.Errorf("examples.connect.greeter.v1.SayHelloRequest.name: %w", err), decoded.Release())
 	}
 	var nameValue *rpcruntime.RpcString
-	if NamePtr == 0 || NameLen == 0 {
+	if NamePtr == nil || NameLen == 0 {
 		nameValue = rpcruntime.EmptyRpcString()
 	} else {
 		var decodeErr error
-		nameValue, decodeErr = rpcruntime.NewRpcStringChecked((*byte)(unsafe.Pointer(NamePtr)), NameLen, NameOwnership > 0)
+		nameValue, decodeErr = rpcruntime.NewRpcStringChecked((*byte)(NamePtr), NameLen, NameOwnership > 0)
 		if decodeErr != nil {
 			return nil, nil, errors.Join(fmt.Errorf("examples.connect.greeter.v1.SayHelloRequest.name: %w", decodeErr), decoded.Release())
 		}
@@ -311,11 +481,11 @@ func decodeGreeterBroadcastNativeServerStreamRequest(NamePtr uintptr, NameLen in
 		return nil, nil, errors.Join(fmt.Errorf("examples.connect.greeter.v1.SayHelloRequest.city: %w", err), decoded.Release())
 	}
 	var cityValue *rpcruntime.RpcString
-	if CityPtr == 0 || CityLen == 0 {
+	if CityPtr == nil || CityLen == 0 {
 		cityValue = rpcruntime.EmptyRpcString()
 	} else {
 		var decodeErr error
-		cityValue, decodeErr = rpcruntime.NewRpcStringChecked((*byte)(unsafe.Pointer(CityPtr)), CityLen, CityOwnership > 0)
+		cityValue, decodeErr = rpcruntime.NewRpcStringChecked((*byte)(CityPtr), CityLen, CityOwnership > 0)
 		if decodeErr != nil {
 			return nil, nil, errors.Join(fmt.Errorf("examples.connect.greeter.v1.SayHelloRequest.city: %w", decodeErr), decoded.Release())
 		}
@@ -356,8 +526,27 @@ func encodeGreeterBroadcastNativeServerStreamResponse(messageResult string, outM
 // rpccgoNativeGreeterv1GreeterBroadcastStart starts the native server-streaming client entrypoint for examples.connect.greeter.v1.Greeter.Broadcast.
 //
 //export rpccgoNativeGreeterv1GreeterBroadcastStart
-func rpccgoNativeGreeterv1GreeterBroadcastStart(NamePtr C.uintptr_t, NameLen C.int32_t, NameOwnership C.int32_t, CityPtr C.uintptr_t, CityLen C.int32_t, CityOwnership C.int32_t, stream *C.int32_t, onRecv C.GreeterBroadcastCGONativeOnRecvCallback, onDone C.RpccgoNativeOnDoneCallback) C.int32_t {
-	ctx := context.Background()
+func rpccgoNativeGreeterv1GreeterBroadcastStart(NamePtr unsafe.Pointer, NameLen C.int32_t, NameOwnership C.int32_t, CityPtr unsafe.Pointer, CityLen C.int32_t, CityOwnership C.int32_t, stream *C.int32_t, onRecv C.GreeterBroadcastCGONativeOnRecvCallback, onDone C.RpccgoNativeOnDoneCallback) C.int32_t {
+	return rpccgoNativeGreeterv1GreeterBroadcastStartWithContext(context.Background(), NamePtr, NameLen, NameOwnership, CityPtr, CityLen, CityOwnership, stream, onRecv, onDone)
+}
+
+// rpccgoNativeGreeterv1GreeterBroadcastStartWithOptions is rpccgoNativeGreeterv1GreeterBroadcastStart bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoNativeGreeterv1GreeterBroadcastStartWithOptions
+func rpccgoNativeGreeterv1GreeterBroadcastStartWithOptions(options C.int32_t, NamePtr unsafe.Pointer, NameLen C.int32_t, NameOwnership C.int32_t, CityPtr unsafe.Pointer, CityLen C.int32_t, CityOwnership C.int32_t, stream *C.int32_t, onRecv C.GreeterBroadcastCGONativeOnRecvCallback, onDone C.RpccgoNativeOnDoneCallback) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	status := rpccgoNativeGreeterv1GreeterBroadcastStartWithContext(ctx, NamePtr, NameLen, NameOwnership, CityPtr, CityLen, CityOwnership, stream, onRecv, onDone)
+	if status != 0 {
+		cancel()
+	}
+	return status
+}
+
+func rpccgoNativeGreeterv1GreeterBroadcastStartWithContext(ctx context.Context, NamePtr unsafe.Pointer, NameLen C.int32_t, NameOwnership C.int32_t, CityPtr unsafe.Pointer, CityLen C.int32_t, CityOwnership C.int32_t, stream *C.int32_t, onRecv C.GreeterBroadcastCGONativeOnRecvCallback, onDone C.RpccgoNativeOnDoneCallback) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoNativeGreeterv1GreeterBroadcastStart", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if stream != nil {
 		*stream = 0
 	}
@@ -365,7 +554,7 @@ func rpccgoNativeGreeterv1GreeterBroadcastStart(NamePtr C.uintptr_t, NameLen C.i
 		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: native client handle pointer is nil")))
 	}
 	var err error
-	nameValue, cityValue, err := decodeGreeterBroadcastNativeServerStreamRequest(uintptr(NamePtr), int32(NameLen), int32(NameOwnership), uintptr(CityPtr), int32(CityLen), int32(CityOwnership))
+	nameValue, cityValue, err := decodeGreeterBroadcastNativeServerStreamRequest(NamePtr, int32(NameLen), int32(NameOwnership), CityPtr, int32(CityLen), int32(CityOwnership))
 	if err != nil {
 		return C.int32_t(rpcruntime.StoreError(err))
 	}
@@ -394,6 +583,12 @@ func rpccgoNativeGreeterv1GreeterBroadcastStart(NamePtr C.uintptr_t, NameLen C.i
 			return C.int32_t(rpcruntime.StoreError(err))
 		}
 		go func() {
+			defer rpcruntime.RecoverPanic("examples.connect.greeter.v1.Greeter.Broadcast callback receive", func(err error) {
+				if callbackState.BeginDoneCallback() {
+					C.callRpccgoNativeOnDoneCallback(onDone, C.int32_t(int32(handle)), C.int32_t(int32(rpcruntime.StoreError(err))))
+					callbackState.EndDoneCallback()
+				}
+			})
 			releaseCallbackOutputs := func(outMessagePtr uintptr) {
 				if outMessagePtr != 0 {
 					rpcruntime.Release(outMessagePtr)
@@ -446,7 +641,23 @@ func rpccgoNativeGreeterv1GreeterBroadcastStart(NamePtr C.uintptr_t, NameLen C.i
 //
 //export rpccgoNativeGreeterv1GreeterBroadcastRecv
 func rpccgoNativeGreeterv1GreeterBroadcastRecv(stream C.int32_t, outMessagePtr *C.uintptr_t, outMessageLen *C.int32_t, outMessageOwnership *C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoNativeGreeterv1GreeterBroadcastRecvWithContext(context.Background(), stream, outMessagePtr, outMessageLen, outMessageOwnership)
+}
+
+// rpccgoNativeGreeterv1GreeterBroadcastRecvWithOptions is rpccgoNativeGreeterv1GreeterBroadcastRecv bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoNativeGreeterv1GreeterBroadcastRecvWithOptions
+func rpccgoNativeGreeterv1GreeterBroadcastRecvWithOptions(options C.int32_t, stream C.int32_t, outMessagePtr *C.uintptr_t, outMessageLen *C.int32_t, outMessageOwnership *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoNativeGreeterv1GreeterBroadcastRecvWithContext(ctx, stream, outMessagePtr, outMessageLen, outMessageOwnership)
+}
+
+func rpccgoNativeGreeterv1GreeterBroadcastRecvWithContext(ctx context.Context, stream C.int32_t, outMessagePtr *C.uintptr_t, outMessageLen *C.int32_t, outMessageOwnership *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoNativeGreeterv1GreeterBroadcastRecv", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if outMessagePtr != nil {
 		*outMessagePtr = 0
 	}
@@ -485,7 +696,23 @@ func rpccgoNativeGreeterv1GreeterBroadcastRecv(stream C.int32_t, outMessagePtr *
 //
 //export rpccgoNativeGreeterv1GreeterBroadcastCancel
 func rpccgoNativeGreeterv1GreeterBroadcastCancel(stream C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoNativeGreeterv1GreeterBroadcastCancelWithContext(context.Background(), stream)
+}
+
+// rpccgoNativeGreeterv1GreeterBroadcastCancelWithOptions is rpccgoNativeGreeterv1GreeterBroadcastCancel bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoNativeGreeterv1GreeterBroadcastCancelWithOptions
+func rpccgoNativeGreeterv1GreeterBroadcastCancelWithOptions(options C.int32_t, stream C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoNativeGreeterv1GreeterBroadcastCancelWithContext(ctx, stream)
+}
+
+func rpccgoNativeGreeterv1GreeterBroadcastCancelWithContext(ctx context.Context, stream C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoNativeGreeterv1GreeterBroadcastCancel", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handle := int32(stream)
 	var err error
 	callbackState, _ := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handle))
@@ -506,7 +733,23 @@ func rpccgoNativeGreeterv1GreeterBroadcastCancel(stream C.int32_t) C.int32_t {
 //
 //export rpccgoNativeGreeterv1GreeterBroadcastClose
 func rpccgoNativeGreeterv1GreeterBroadcastClose(stream C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoNativeGreeterv1GreeterBroadcastCloseWithContext(context.Background(), stream)
+}
+
+// rpccgoNativeGreeterv1GreeterBroadcastCloseWithOptions is rpccgoNativeGreeterv1GreeterBroadcastClose bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoNativeGreeterv1GreeterBroadcastCloseWithOptions
+func rpccgoNativeGreeterv1GreeterBroadcastCloseWithOptions(options C.int32_t, stream C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoNativeGreeterv1GreeterBroadcastCloseWithContext(ctx, stream)
+}
+
+func rpccgoNativeGreeterv1GreeterBroadcastCloseWithContext(ctx context.Context, stream C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoNativeGreeterv1GreeterBroadcastClose", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handle := int32(stream)
 	callbackState, err := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handle))
 	if err != nil {
@@ -520,17 +763,17 @@ func rpccgoNativeGreeterv1GreeterBroadcastClose(stream C.int32_t) C.int32_t {
 	return 0
 }
 
-func decodeGreeterChatNativeBidiStreamRequest(NamePtr uintptr, NameLen int32, NameOwnership int32, CityPtr uintptr, CityLen int32, CityOwnership int32) (*rpcruntime.RpcString, *rpcruntime.RpcString, error) {
+func decodeGreeterChatNativeBidiStreamRequest(NamePtr unsafe.Pointer, NameLen int32, NameOwnership int32, CityPtr unsafe.Pointer, CityLen int32, CityOwnership int32) (*rpcruntime.RpcString, *rpcruntime.RpcString, error) {
 	var decoded rpcruntime.NativeReleaseStack
 	if _, err := rpcruntime.LengthFromInt32(NameLen); err != nil {
 		return nil, nil, errors.Join(fmt.Errorf("examples.connect.greeter.v1.SayHelloRequest.name: %w", err), decoded.Release())
 	}
 	var nameValue *rpcruntime.RpcString
-	if NamePtr == 0 || NameLen == 0 {
+	if NamePtr == nil || NameLen == 0 {
 		nameValue = rpcruntime.EmptyRpcString()
 	} else {
 		var decodeErr error
-		nameValue, decodeErr = rpcruntime.NewRpcStringChecked((*byte)(unsafe.Pointer(NamePtr)), NameLen, NameOwnership > 0)
+		nameValue, decodeErr = rpcruntime.NewRpcStringChecked((*byte)(NamePtr), NameLen, NameOwnership > 0)
 		if decodeErr != nil {
 			return nil, nil, errors.Join(fmt.Errorf("examples.connect.greeter.v1.SayHelloRequest.name: %w", decodeErr), decoded.Release())
 		}
@@ -540,11 +783,11 @@ func decodeGreeterChatNativeBidiStreamRequest(NamePtr uintptr, NameLen int32, Na
 		return nil, nil, errors.Join(fmt.Errorf("examples.connect.greeter.v1.SayHelloRequest.city: %w", err), decoded.Release())
 	}
 	var cityValue *rpcruntime.RpcString
-	if CityPtr == 0 || CityLen == 0 {
+	if CityPtr == nil || CityLen == 0 {
 		cityValue = rpcruntime.EmptyRpcString()
 	} else {
 		var decodeErr error
-		cityValue, decodeErr = rpcruntime.NewRpcStringChecked((*byte)(unsafe.Pointer(CityPtr)), CityLen, CityOwnership > 0)
+		cityValue, decodeErr = rpcruntime.NewRpcStringChecked((*byte)(CityPtr), CityLen, CityOwnership > 0)
 		if decodeErr != nil {
 			return nil, nil, errors.Join(fmt.Errorf("examples.connect.greeter.v1.SayHelloRequest.city: %w", decodeErr), decoded.Release())
 		}
@@ -586,7 +829,26 @@ func encodeGreeterChatNativeBidiStreamResponse(messageResult string, outMessageP
 //
 //export rpccgoNativeGreeterv1GreeterChatStart
 func rpccgoNativeGreeterv1GreeterChatStart(stream *C.int32_t, onRecv C.GreeterChatCGONativeOnRecvCallback, onDone C.RpccgoNativeOnDoneCallback) C.int32_t {
-	ctx := context.Background()
+	return rpccgoNativeGreeterv1GreeterChatStartWithContext(context.Background(), stream, onRecv, onDone)
+}
+
+// rpccgoNativeGreeterv1GreeterChatStartWithOptions is rpccgoNativeGreeterv1GreeterChatStart bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoNativeGreeterv1GreeterChatStartWithOptions
+func rpccgoNativeGreeterv1GreeterChatStartWithOptions(options C.int32_t, stream *C.int32_t, onRecv C.GreeterChatCGONativeOnRecvCallback, onDone C.RpccgoNativeOnDoneCallback) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	status := rpccgoNativeGreeterv1GreeterChatStartWithContext(ctx, stream, onRecv, onDone)
+	if status != 0 {
+		cancel()
+	}
+	return status
+}
+
+func rpccgoNativeGreeterv1GreeterChatStartWithContext(ctx context.Context, stream *C.int32_t, onRecv C.GreeterChatCGONativeOnRecvCallback, onDone C.RpccgoNativeOnDoneCallback) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoNativeGreeterv1GreeterChatStart", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if stream != nil {
 		*stream = 0
 	}
@@ -615,6 +877,12 @@ func rpccgoNativeGreeterv1GreeterChatStart(stream *C.int32_t, onRecv C.GreeterCh
 			return C.int32_t(rpcruntime.StoreError(err))
 		}
 		go func() {
+			defer rpcruntime.RecoverPanic("examples.connect.greeter.v1.Greeter.Chat callback receive", func(err error) {
+				if callbackState.BeginDoneCallback() {
+					C.callRpccgoNativeOnDoneCallback(onDone, C.int32_t(int32(handle)), C.int32_t(int32(rpcruntime.StoreError(err))))
+					callbackState.EndDoneCallback()
+				}
+			})
 			releaseCallbackOutputs := func(outMessagePtr uintptr) {
 				if outMessagePtr != 0 {
 					rpcruntime.Release(outMessagePtr)
@@ -666,11 +934,27 @@ func rpccgoNativeGreeterv1GreeterChatStart(stream *C.int32_t, onRecv C.GreeterCh
 // rpccgoNativeGreeterv1GreeterChatSend sends native request values to the bidi-streaming client entrypoint for examples.connect.greeter.v1.Greeter.Chat.
 //
 //export rpccgoNativeGreeterv1GreeterChatSend
-func rpccgoNativeGreeterv1GreeterChatSend(stream C.int32_t, NamePtr C.uintptr_t, NameLen C.int32_t, NameOwnership C.int32_t, CityPtr C.uintptr_t, CityLen C.int32_t, CityOwnership C.int32_t) C.int32_t {
-	ctx := context.Background()
+func rpccgoNativeGreeterv1GreeterChatSend(stream C.int32_t, NamePtr unsafe.Pointer, NameLen C.int32_t, NameOwnership C.int32_t, CityPtr unsafe.Pointer, CityLen C.int32_t, CityOwnership C.int32_t) C.int32_t {
+	return rpccgoNativeGreeterv1GreeterChatSendWithContext(context.Background(), stream, NamePtr, NameLen, NameOwnership, CityPtr, CityLen, CityOwnership)
+}
+
+// rpccgoNativeGreeterv1GreeterChatSendWithOptions is rpccgoNativeGreeterv1GreeterChatSend bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoNativeGreeterv1GreeterChatSendWithOptions
+func rpccgoNativeGreeterv1GreeterChatSendWithOptions(options C.int32_t, stream C.int32_t, NamePtr unsafe.Pointer, NameLen C.int32_t, NameOwnership C.int32_t, CityPtr unsafe.Pointer, CityLen C.int32_t, CityOwnership C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoNativeGreeterv1GreeterChatSendWithContext(ctx, stream, NamePtr, NameLen, NameOwnership, CityPtr, CityLen, CityOwnership)
+}
+
+func rpccgoNativeGreeterv1GreeterChatSendWithContext(ctx context.Context, stream C.int32_t, NamePtr unsafe.Pointer, NameLen C.int32_t, NameOwnership C.int32_t, CityPtr unsafe.Pointer, CityLen C.int32_t, CityOwnership C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoNativeGreeterv1GreeterChatSend", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handle := int32(stream)
 	var err error
-	nameValue, cityValue, err := decodeGreeterChatNativeBidiStreamRequest(uintptr(NamePtr), int32(NameLen), int32(NameOwnership), uintptr(CityPtr), int32(CityLen), int32(CityOwnership))
+	nameValue, cityValue, err := decodeGreeterChatNativeBidiStreamRequest(NamePtr, int32(NameLen), int32(NameOwnership), CityPtr, int32(CityLen), int32(CityOwnership))
 	if err != nil {
 		return C.int32_t(rpcruntime.StoreError(err))
 	}
@@ -688,7 +972,23 @@ func rpccgoNativeGreeterv1GreeterChatSend(stream C.int32_t, NamePtr C.uintptr_t,
 //
 //export rpccgoNativeGreeterv1GreeterChatRecv
 func rpccgoNativeGreeterv1GreeterChatRecv(stream C.int32_t, outMessagePtr *C.uintptr_t, outMessageLen *C.int32_t, outMessageOwnership *C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoNativeGreeterv1GreeterChatRecvWithContext(context.Background(), stream, outMessagePtr, outMessageLen, outMessageOwnership)
+}
+
+// rpccgoNativeGreeterv1GreeterChatRecvWithOptions is rpccgoNativeGreeterv1GreeterChatRecv bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoNativeGreeterv1GreeterChatRecvWithOptions
+func rpccgoNativeGreeterv1GreeterChatRecvWithOptions(options C.int32_t, stream C.int32_t, outMessagePtr *C.uintptr_t, outMessageLen *C.int32_t, outMessageOwnership *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoNativeGreeterv1GreeterChatRecvWithContext(ctx, stream, outMessagePtr, outMessageLen, outMessageOwnership)
+}
+
+func rpccgoNativeGreeterv1GreeterChatRecvWithContext(ctx context.Context, stream C.int32_t, outMessagePtr *C.uintptr_t, outMessageLen *C.int32_t, outMessageOwnership *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoNativeGreeterv1GreeterChatRecv", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if outMessagePtr != nil {
 		*outMessagePtr = 0
 	}
@@ -727,7 +1027,23 @@ func rpccgoNativeGreeterv1GreeterChatRecv(stream C.int32_t, outMessagePtr *C.uin
 //
 //export rpccgoNativeGreeterv1GreeterChatCloseSend
 func rpccgoNativeGreeterv1GreeterChatCloseSend(stream C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoNativeGreeterv1GreeterChatCloseSendWithContext(context.Background(), stream)
+}
+
+// rpccgoNativeGreeterv1GreeterChatCloseSendWithOptions is rpccgoNativeGreeterv1GreeterChatCloseSend bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoNativeGreeterv1GreeterChatCloseSendWithOptions
+func rpccgoNativeGreeterv1GreeterChatCloseSendWithOptions(options C.int32_t, stream C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoNativeGreeterv1GreeterChatCloseSendWithContext(ctx, stream)
+}
+
+func rpccgoNativeGreeterv1GreeterChatCloseSendWithContext(ctx context.Context, stream C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoNativeGreeterv1GreeterChatCloseSend", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handle := int32(stream)
 	var err error
 	err = proto.GreeterNativeChatCloseSend(ctx, rpcruntime.StreamHandle(handle))
@@ -741,7 +1057,23 @@ func rpccgoNativeGreeterv1GreeterChatCloseSend(stream C.int32_t) C.int32_t {
 //
 //export rpccgoNativeGreeterv1GreeterChatFinish
 func rpccgoNativeGreeterv1GreeterChatFinish(stream C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoNativeGreeterv1GreeterChatFinishWithContext(context.Background(), stream)
+}
+
+// rpccgoNativeGreeterv1GreeterChatFinishWithOptions is rpccgoNativeGreeterv1GreeterChatFinish bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoNativeGreeterv1GreeterChatFinishWithOptions
+func rpccgoNativeGreeterv1GreeterChatFinishWithOptions(options C.int32_t, stream C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoNativeGreeterv1GreeterChatFinishWithContext(ctx, stream)
+}
+
+func rpccgoNativeGreeterv1GreeterChatFinishWithContext(ctx context.Context, stream C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoNativeGreeterv1GreeterChatFinish", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handle := int32(stream)
 	var err error
 	callbackState, _ := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handle))
@@ -762,7 +1094,23 @@ func rpccgoNativeGreeterv1GreeterChatFinish(stream C.int32_t) C.int32_t {
 //
 //export rpccgoNativeGreeterv1GreeterChatCancel
 func rpccgoNativeGreeterv1GreeterChatCancel(stream C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoNativeGreeterv1GreeterChatCancelWithContext(context.Background(), stream)
+}
+
+// rpccgoNativeGreeterv1GreeterChatCancelWithOptions is rpccgoNativeGreeterv1GreeterChatCancel bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoNativeGreeterv1GreeterChatCancelWithOptions
+func rpccgoNativeGreeterv1GreeterChatCancelWithOptions(options C.int32_t, stream C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoNativeGreeterv1GreeterChatCancelWithContext(ctx, stream)
+}
+
+func rpccgoNativeGreeterv1GreeterChatCancelWithContext(ctx context.Context, stream C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoNativeGreeterv1GreeterChatCancel", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handle := int32(stream)
 	var err error
 	callbackState, _ := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handle))
@@ -783,7 +1131,23 @@ func rpccgoNativeGreeterv1GreeterChatCancel(stream C.int32_t) C.int32_t {
 //
 //export rpccgoNativeGreeterv1GreeterChatClose
 func rpccgoNativeGreeterv1GreeterChatClose(stream C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoNativeGreeterv1GreeterChatCloseWithContext(context.Background(), stream)
+}
+
+// rpccgoNativeGreeterv1GreeterChatCloseWithOptions is rpccgoNativeGreeterv1GreeterChatClose bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoNativeGreeterv1GreeterChatCloseWithOptions
+func rpccgoNativeGreeterv1GreeterChatCloseWithOptions(options C.int32_t, stream C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoNativeGreeterv1GreeterChatCloseWithContext(ctx, stream)
+}
+
+func rpccgoNativeGreeterv1GreeterChatCloseWithContext(ctx context.Context, stream C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoNativeGreeterv1GreeterChatClose", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handle := int32(stream)
 	callbackState, err := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handle))
 	if err != nil {
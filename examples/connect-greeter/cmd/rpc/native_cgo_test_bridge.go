@@ -5,12 +5,14 @@ package main
 */
 import "C"
 
+import "unsafe"
+
 // Go test files cannot import C, so these helpers keep C export coverage in Go tests.
-func callGreeterSayHelloNativeUnary(namePtr uintptr, nameLen int32, nameOwnership int32, cityPtr uintptr, cityLen int32, cityOwnership int32, outMessagePtr *uintptr, outMessageLen *int32) int32 {
+func callGreeterSayHelloNativeUnary(namePtr unsafe.Pointer, nameLen int32, nameOwnership int32, cityPtr unsafe.Pointer, cityLen int32, cityOwnership int32, outMessagePtr *uintptr, outMessageLen *int32) int32 {
 	var messagePtr C.uintptr_t
 	var messageLen C.int32_t
 	var messageOwnership C.int32_t
-	errID := rpccgoNativeGreeterv1GreeterSayHello(C.uintptr_t(namePtr), C.int32_t(nameLen), C.int32_t(nameOwnership), C.uintptr_t(cityPtr), C.int32_t(cityLen), C.int32_t(cityOwnership), &messagePtr, &messageLen, &messageOwnership)
+	errID := rpccgoNativeGreeterv1GreeterSayHello(namePtr, C.int32_t(nameLen), C.int32_t(nameOwnership), cityPtr, C.int32_t(cityLen), C.int32_t(cityOwnership), &messagePtr, &messageLen, &messageOwnership)
 	*outMessagePtr = uintptr(messagePtr)
 	*outMessageLen = int32(messageLen)
 	return int32(errID)
@@ -22,8 +24,8 @@ func greeterNativeCollectStart() (int32, int32) {
 	return int32(stream), int32(errID)
 }
 
-func greeterNativeCollectSend(stream int32, namePtr uintptr, nameLen int32, nameOwnership int32, cityPtr uintptr, cityLen int32, cityOwnership int32) int32 {
-	return int32(rpccgoNativeGreeterv1GreeterCollectSend(C.int32_t(stream), C.uintptr_t(namePtr), C.int32_t(nameLen), C.int32_t(nameOwnership), C.uintptr_t(cityPtr), C.int32_t(cityLen), C.int32_t(cityOwnership)))
+func greeterNativeCollectSend(stream int32, namePtr unsafe.Pointer, nameLen int32, nameOwnership int32, cityPtr unsafe.Pointer, cityLen int32, cityOwnership int32) int32 {
+	return int32(rpccgoNativeGreeterv1GreeterCollectSend(C.int32_t(stream), namePtr, C.int32_t(nameLen), C.int32_t(nameOwnership), cityPtr, C.int32_t(cityLen), C.int32_t(cityOwnership)))
 }
 
 func greeterNativeCollectFinish(stream int32, outMessagePtr *uintptr, outMessageLen *int32) int32 {
@@ -36,9 +38,9 @@ func greeterNativeCollectFinish(stream int32, outMessagePtr *uintptr, outMessage
 	return int32(errID)
 }
 
-func greeterNativeBroadcastStart(namePtr uintptr, nameLen int32, nameOwnership int32, cityPtr uintptr, cityLen int32, cityOwnership int32) (int32, int32) {
+func greeterNativeBroadcastStart(namePtr unsafe.Pointer, nameLen int32, nameOwnership int32, cityPtr unsafe.Pointer, cityLen int32, cityOwnership int32) (int32, int32) {
 	var stream C.int32_t
-	errID := rpccgoNativeGreeterv1GreeterBroadcastStart(C.uintptr_t(namePtr), C.int32_t(nameLen), C.int32_t(nameOwnership), C.uintptr_t(cityPtr), C.int32_t(cityLen), C.int32_t(cityOwnership), &stream, nil, nil)
+	errID := rpccgoNativeGreeterv1GreeterBroadcastStart(namePtr, C.int32_t(nameLen), C.int32_t(nameOwnership), cityPtr, C.int32_t(cityLen), C.int32_t(cityOwnership), &stream, nil, nil)
 	return int32(stream), int32(errID)
 }
 
@@ -58,8 +60,8 @@ func greeterNativeChatStart() (int32, int32) {
 	return int32(stream), int32(errID)
 }
 
-func greeterNativeChatSend(stream int32, namePtr uintptr, nameLen int32, nameOwnership int32, cityPtr uintptr, cityLen int32, cityOwnership int32) int32 {
-	return int32(rpccgoNativeGreeterv1GreeterChatSend(C.int32_t(stream), C.uintptr_t(namePtr), C.int32_t(nameLen), C.int32_t(nameOwnership), C.uintptr_t(cityPtr), C.int32_t(cityLen), C.int32_t(cityOwnership)))
+func greeterNativeChatSend(stream int32, namePtr unsafe.Pointer, nameLen int32, nameOwnership int32, cityPtr unsafe.Pointer, cityLen int32, cityOwnership int32) int32 {
+	return int32(rpccgoNativeGreeterv1GreeterChatSend(C.int32_t(stream), namePtr, C.int32_t(nameLen), C.int32_t(nameOwnership), cityPtr, C.int32_t(cityLen), C.int32_t(cityOwnership)))
 }
 
 func greeterNativeChatRecv(stream int32, outMessagePtr *uintptr, outMessageLen *int32) int32 {
@@ -80,10 +82,10 @@ func greeterNativeChatFinish(stream int32) int32 {
 	return int32(rpccgoNativeGreeterv1GreeterChatFinish(C.int32_t(stream)))
 }
 
-func callGreeterSayHelloMessageUnary(requestPtr uintptr, requestLen int32, outMessagePtr *uintptr, outMessageLen *int32) int32 {
+func callGreeterSayHelloMessageUnary(requestPtr unsafe.Pointer, requestLen int32, outMessagePtr *uintptr, outMessageLen *int32) int32 {
 	var messagePtr C.uintptr_t
 	var messageLen C.int32_t
-	errID := rpccgoMsgGreeterv1GreeterSayHello(C.uintptr_t(requestPtr), C.int32_t(requestLen), &messagePtr, &messageLen)
+	errID := rpccgoMsgGreeterv1GreeterSayHello(requestPtr, C.int32_t(requestLen), &messagePtr, &messageLen)
 	*outMessagePtr = uintptr(messagePtr)
 	*outMessageLen = int32(messageLen)
 	return int32(errID)
@@ -95,8 +97,8 @@ func greeterMessageCollectStart() (int32, int32) {
 	return int32(stream), int32(errID)
 }
 
-func greeterMessageCollectSend(stream int32, requestPtr uintptr, requestLen int32) int32 {
-	return int32(rpccgoMsgGreeterv1GreeterCollectSend(C.int32_t(stream), C.uintptr_t(requestPtr), C.int32_t(requestLen)))
+func greeterMessageCollectSend(stream int32, requestPtr unsafe.Pointer, requestLen int32) int32 {
+	return int32(rpccgoMsgGreeterv1GreeterCollectSend(C.int32_t(stream), requestPtr, C.int32_t(requestLen)))
 }
 
 func greeterMessageCollectFinish(stream int32, outMessagePtr *uintptr, outMessageLen *int32) int32 {
@@ -108,9 +110,9 @@ func greeterMessageCollectFinish(stream int32, outMessagePtr *uintptr, outMessag
 	return int32(errID)
 }
 
-func greeterMessageBroadcastStart(requestPtr uintptr, requestLen int32) (int32, int32) {
+func greeterMessageBroadcastStart(requestPtr unsafe.Pointer, requestLen int32) (int32, int32) {
 	var stream C.int32_t
-	errID := rpccgoMsgGreeterv1GreeterBroadcastStart(C.uintptr_t(requestPtr), C.int32_t(requestLen), &stream, nil, nil)
+	errID := rpccgoMsgGreeterv1GreeterBroadcastStart(requestPtr, C.int32_t(requestLen), &stream, nil, nil)
 	return int32(stream), int32(errID)
 }
 
@@ -129,8 +131,8 @@ func greeterMessageChatStart() (int32, int32) {
 	return int32(stream), int32(errID)
 }
 
-func greeterMessageChatSend(stream int32, requestPtr uintptr, requestLen int32) int32 {
-	return int32(rpccgoMsgGreeterv1GreeterChatSend(C.int32_t(stream), C.uintptr_t(requestPtr), C.int32_t(requestLen)))
+func greeterMessageChatSend(stream int32, requestPtr unsafe.Pointer, requestLen int32) int32 {
+	return int32(rpccgoMsgGreeterv1GreeterChatSend(C.int32_t(stream), requestPtr, C.int32_t(requestLen)))
 }
 
 func greeterMessageChatRecv(stream int32, outMessagePtr *uintptr, outMessageLen *int32) int32 {
@@ -149,3 +151,57 @@ func greeterMessageChatCloseSend(stream int32) int32 {
 func greeterMessageChatFinish(stream int32) int32 {
 	return int32(rpccgoMsgGreeterv1GreeterChatFinish(C.int32_t(stream)))
 }
+
+func dynamicInvoke(service, method string, request []byte, outMessagePtr *uintptr, outMessageLen *int32) int32 {
+	var messagePtr C.uintptr_t
+	var messageLen C.int32_t
+	serviceBytes, methodBytes := []byte(service), []byte(method)
+	errID := rpccgoInvoke(bytesCharPtr(serviceBytes), C.int32_t(len(serviceBytes)), bytesCharPtr(methodBytes), C.int32_t(len(methodBytes)), unsafe.Pointer(unsafe.SliceData(request)), C.int32_t(len(request)), &messagePtr, &messageLen)
+	*outMessagePtr = uintptr(messagePtr)
+	*outMessageLen = int32(messageLen)
+	return int32(errID)
+}
+
+func dynamicStreamStart(service, method string, request []byte) (int32, int32) {
+	var stream C.int32_t
+	serviceBytes, methodBytes := []byte(service), []byte(method)
+	errID := rpccgoStreamStart(bytesCharPtr(serviceBytes), C.int32_t(len(serviceBytes)), bytesCharPtr(methodBytes), C.int32_t(len(methodBytes)), unsafe.Pointer(unsafe.SliceData(request)), C.int32_t(len(request)), &stream)
+	return int32(stream), int32(errID)
+}
+
+func dynamicStreamSend(stream int32, request []byte) int32 {
+	return int32(rpccgoStreamSend(C.int32_t(stream), unsafe.Pointer(unsafe.SliceData(request)), C.int32_t(len(request))))
+}
+
+func dynamicStreamRecv(stream int32, outMessagePtr *uintptr, outMessageLen *int32) int32 {
+	var messagePtr C.uintptr_t
+	var messageLen C.int32_t
+	errID := rpccgoStreamRecv(C.int32_t(stream), &messagePtr, &messageLen)
+	*outMessagePtr = uintptr(messagePtr)
+	*outMessageLen = int32(messageLen)
+	return int32(errID)
+}
+
+func dynamicStreamCloseSend(stream int32) int32 {
+	return int32(rpccgoStreamCloseSend(C.int32_t(stream)))
+}
+
+func dynamicStreamFinish(stream int32, outMessagePtr *uintptr, outMessageLen *int32) int32 {
+	var messagePtr C.uintptr_t
+	var messageLen C.int32_t
+	errID := rpccgoStreamFinish(C.int32_t(stream), &messagePtr, &messageLen)
+	*outMessagePtr = uintptr(messagePtr)
+	*outMessageLen = int32(messageLen)
+	return int32(errID)
+}
+
+func dynamicStreamCancel(stream int32) int32 {
+	return int32(rpccgoStreamCancel(C.int32_t(stream)))
+}
+
+func bytesCharPtr(data []byte) *C.char {
+	if len(data) == 0 {
+		return nil
+	}
+	return (*C.char)(unsafe.Pointer(&data[0]))
+}
@@ -6,19 +6,102 @@ package main
 
 /*
 #include <stdint.h>
+#include <string.h>
+
+#define RPCCGO_ERR_DEADLINE_EXCEEDED (-2)
+#define RPCCGO_ERR_BUFFER_TOO_SMALL (-3)
+#define RPCCGO_TRACEPARENT_LEN 55
+
+#define RPCCGO_CODE_OK 0
+#define RPCCGO_CODE_CANCELED 1
+#define RPCCGO_CODE_UNKNOWN 2
+#define RPCCGO_CODE_INVALID_ARGUMENT 3
+#define RPCCGO_CODE_DEADLINE_EXCEEDED 4
+#define RPCCGO_CODE_NOT_FOUND 5
+#define RPCCGO_CODE_ALREADY_EXISTS 6
+#define RPCCGO_CODE_PERMISSION_DENIED 7
+#define RPCCGO_CODE_RESOURCE_EXHAUSTED 8
+#define RPCCGO_CODE_FAILED_PRECONDITION 9
+#define RPCCGO_CODE_ABORTED 10
+#define RPCCGO_CODE_OUT_OF_RANGE 11
+#define RPCCGO_CODE_UNIMPLEMENTED 12
+#define RPCCGO_CODE_INTERNAL 13
+#define RPCCGO_CODE_UNAVAILABLE 14
+#define RPCCGO_CODE_DATA_LOSS 15
+#define RPCCGO_CODE_UNAUTHENTICATED 16
+
+#define RPCCGO_SERVER_KIND_INVALID 0
+#define RPCCGO_SERVER_KIND_GO_NATIVE 1
+#define RPCCGO_SERVER_KIND_CGO_NATIVE 2
+#define RPCCGO_SERVER_KIND_CGO_MESSAGE 3
+#define RPCCGO_SERVER_KIND_CONNECT 4
+#define RPCCGO_SERVER_KIND_GRPC 5
+#define RPCCGO_SERVER_KIND_CONNECT_REMOTE 6
+#define RPCCGO_SERVER_KIND_GRPC_REMOTE 7
+
+#define RPCCGO_HEALTH_UNKNOWN 0
+#define RPCCGO_HEALTH_SERVING 1
+#define RPCCGO_HEALTH_NOT_SERVING 2
+#define RPCCGO_METHOD_STREAMING_UNARY 0
+#define RPCCGO_METHOD_STREAMING_CLIENT 1
+#define RPCCGO_METHOD_STREAMING_SERVER 2
+#define RPCCGO_METHOD_STREAMING_BIDI 3
+
+// One element of a repeated string, bytes or message native field.
+typedef struct {
+uintptr_t ptr;
+int32_t len;
+int32_t ownership;
+} rpccgo_buffer;
 
 typedef void (*rpccgo_free_callback)(void*);
+typedef void (*rpccgo_registration_event_callback)(int32_t watch, uintptr_t service_id_ptr, int32_t service_id_len, int32_t old_kind, int32_t new_kind, int32_t cleared, uint64_t seq);
+typedef void (*rpccgo_registration_done_callback)(int32_t watch);
+typedef void (*rpccgo_health_callback)(int32_t watch, uintptr_t service_id_ptr, int32_t service_id_len, int32_t status, int32_t kind);
+typedef void (*rpccgo_panic_callback)(uintptr_t text_ptr, int32_t text_len);
 
 static inline void rpccgo_call_free_callback(rpccgo_free_callback callback, void* ptr) {
 callback(ptr);
 }
+
+static inline void rpccgo_call_registration_event_callback(rpccgo_registration_event_callback callback, int32_t watch, uintptr_t service_id_ptr, int32_t service_id_len, int32_t old_kind, int32_t new_kind, int32_t cleared, uint64_t seq) {
+callback(watch, service_id_ptr, service_id_len, old_kind, new_kind, cleared, seq);
+}
+
+static inline void rpccgo_call_registration_done_callback(rpccgo_registration_done_callback callback, int32_t watch) {
+callback(watch);
+}
+
+static inline void rpccgo_call_health_callback(rpccgo_health_callback callback, int32_t watch, uintptr_t service_id_ptr, int32_t service_id_len, int32_t status, int32_t kind) {
+callback(watch, service_id_ptr, service_id_len, status, kind);
+}
+
+static inline void rpccgo_call_panic_callback(rpccgo_panic_callback callback, uintptr_t text_ptr, int32_t text_len) {
+callback(text_ptr, text_len);
+}
+
+static _Thread_local char rpccgo_callback_trace_parent[RPCCGO_TRACEPARENT_LEN];
+static _Thread_local int32_t rpccgo_callback_trace_parent_len;
+
+static inline int32_t rpccgo_get_callback_trace_parent(char* out) {
+memcpy(out, rpccgo_callback_trace_parent, rpccgo_callback_trace_parent_len);
+return rpccgo_callback_trace_parent_len;
+}
+
+static inline void rpccgo_set_callback_trace_parent(const char* value, int32_t len) {
+memcpy(rpccgo_callback_trace_parent, value, len);
+rpccgo_callback_trace_parent_len = len;
+}
 */
 import "C"
 
 import (
+	context "context"
 	errors "errors"
 	fmt "fmt"
 	rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"
+	goruntime "runtime"
+	time "time"
 	unsafe "unsafe"
 )
 
@@ -73,6 +156,111 @@ func rpccgoTakeErrorText(errID C.int32_t, textPtr *C.uintptr_t, textLen *C.int32
 	return 0
 }
 
+// rpccgoMetricsSnapshot encodes the runtime metrics as a rpccgo.runtime.v1.MetricsSnapshot protobuf message. A non-zero pointer must be released with rpccgoRelease.
+//
+//export rpccgoMetricsSnapshot
+func rpccgoMetricsSnapshot(snapshotPtr *C.uintptr_t, snapshotLen *C.int32_t) C.int32_t {
+	if snapshotPtr == nil || snapshotLen == nil {
+		return -1
+	}
+	goPtr, goLen, err := rpcruntime.EncodePinnedMetricsSnapshot()
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	*snapshotPtr = C.uintptr_t(goPtr)
+	*snapshotLen = C.int32_t(goLen)
+	return 0
+}
+
+// rpccgoStoreErrorCode stores C error text with a canonical RPC status code and returns its error id.
+//
+//export rpccgoStoreErrorCode
+func rpccgoStoreErrorCode(code C.int32_t, text *C.char, textLen C.int32_t) C.int32_t {
+	length, err := rpcruntime.LengthFromInt32(int32(textLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: cgo error text: %w", err)))
+	}
+	if text == nil && length != 0 {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: cgo error text pointer is nil")))
+	}
+	var data []byte
+	if length != 0 {
+		data = unsafe.Slice((*byte)(unsafe.Pointer(text)), length)
+	}
+	return C.int32_t(rpcruntime.StoreError(rpcruntime.NewStatusError(rpcruntime.ErrorCode(code), string(data))))
+}
+
+// rpccgoStoreErrorStatus stores an encoded google.rpc.Status from C in the Go error registry and returns its error id.
+//
+//export rpccgoStoreErrorStatus
+func rpccgoStoreErrorStatus(statusPtr unsafe.Pointer, statusLen C.int32_t) C.int32_t {
+	statusErr, err := rpcruntime.DecodeStatusError(uintptr(statusPtr), int32(statusLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: cgo error status decode failed: %w", err)))
+	}
+	return C.int32_t(rpcruntime.StoreError(statusErr))
+}
+
+// rpccgoTakeErrorCode takes the canonical RPC status code and error text for a C caller and releases the error id.
+//
+//export rpccgoTakeErrorCode
+func rpccgoTakeErrorCode(errID C.int32_t, code *C.int32_t, textPtr *C.uintptr_t, textLen *C.int32_t) C.int32_t {
+	if code == nil || textPtr == nil || textLen == nil {
+		return -1
+	}
+	var goCode int32
+	var goPtr uintptr
+	var goLen int32
+	status := rpcruntime.TakeErrorCodeForExport(int32(errID), &goCode, &goPtr, &goLen)
+	if status != 0 {
+		return C.int32_t(status)
+	}
+	*code = C.int32_t(goCode)
+	*textPtr = C.uintptr_t(goPtr)
+	*textLen = C.int32_t(goLen)
+	return 0
+}
+
+// rpccgoTakeErrorStatus takes the stored error as encoded google.rpc.Status bytes for a C caller and releases the error id.
+//
+//export rpccgoTakeErrorStatus
+func rpccgoTakeErrorStatus(errID C.int32_t, statusPtr *C.uintptr_t, statusLen *C.int32_t) C.int32_t {
+	if statusPtr == nil || statusLen == nil {
+		return -1
+	}
+	var goPtr uintptr
+	var goLen int32
+	status := rpcruntime.TakeErrorStatusForExport(int32(errID), &goPtr, &goLen)
+	if status != 0 {
+		return C.int32_t(status)
+	}
+	*statusPtr = C.uintptr_t(goPtr)
+	*statusLen = C.int32_t(goLen)
+	return 0
+}
+
+// rpccgoDiscardError releases an error id without reading it. It returns -1 when the id is unknown, already taken or expired.
+//
+//export rpccgoDiscardError
+func rpccgoDiscardError(errID C.int32_t) C.int32_t {
+	if !rpcruntime.DiscardError(rpcruntime.ErrorID(errID)) {
+		return -1
+	}
+	return 0
+}
+
+// rpccgoErrorStoreConfigure sets how long error ids stay readable and how many are kept at once; storing past capacity drops the oldest id. Non-positive values keep the defaults of 3000 ms and 4096 ids. A non-zero explicitRelease keeps every error id until it is taken or released with rpccgoDiscardError, ignoring ttlMs.
+//
+//export rpccgoErrorStoreConfigure
+func rpccgoErrorStoreConfigure(ttlMs C.int64_t, capacity C.int32_t, explicitRelease C.int32_t) C.int32_t {
+	rpcruntime.ConfigureErrorStore(rpcruntime.ErrorStoreConfig{
+		TTL:             time.Duration(ttlMs) * time.Millisecond,
+		Capacity:        int(capacity),
+		ExplicitRelease: explicitRelease != 0,
+	})
+	return 0
+}
+
 // rpccgoRelease releases memory previously handed to C through rpccgo ABI helpers.
 //
 //export rpccgoRelease
@@ -82,3 +270,946 @@ func rpccgoRelease(ptr C.uintptr_t) C.int32_t {
 	}
 	return 0
 }
+
+// rpccgoPinTrackingConfigure turns the pinned memory leak detector on or off. While enabled, every buffer handed to C records the Go function that pinned it, its size and pin time; a non-zero stacks also records the Go stack. Tracking walks the stack on every pin and is meant for debugging and tests.
+//
+//export rpccgoPinTrackingConfigure
+func rpccgoPinTrackingConfigure(enabled C.int32_t, stacks C.int32_t) C.int32_t {
+	rpcruntime.ConfigurePinTracking(rpcruntime.PinTrackingConfig{
+		Enabled: enabled != 0,
+		Stacks:  stacks != 0,
+	})
+	return 0
+}
+
+// rpccgoPinnedReport encodes the tracked buffers pinned at least olderThanMs milliseconds ago and not yet released with rpccgoRelease as a rpccgo.runtime.v1.PinnedBufferList protobuf message. A non-zero pointer must be released with rpccgoRelease.
+//
+//export rpccgoPinnedReport
+func rpccgoPinnedReport(olderThanMs C.int64_t, reportPtr *C.uintptr_t, reportLen *C.int32_t) C.int32_t {
+	if reportPtr == nil || reportLen == nil {
+		return -1
+	}
+	goPtr, goLen, err := rpcruntime.EncodePinnedOutstandingPins(time.Duration(olderThanMs) * time.Millisecond)
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	*reportPtr = C.uintptr_t(goPtr)
+	*reportLen = C.int32_t(goLen)
+	return 0
+}
+
+// rpccgoPanicReportsConfigure configures how panics recovered at cgo exports, callback receive loops and stream handler goroutines are reported. A recovered panic becomes an error with RPCCGO_CODE_INTERNAL; a non-zero stacks appends the Go stack to its text. A non-nil onPanic receives the same text on the panicking thread before the error is returned; a non-zero text pointer must be released with rpccgoRelease. A nil onPanic stops reporting.
+//
+//export rpccgoPanicReportsConfigure
+func rpccgoPanicReportsConfigure(stacks C.int32_t, onPanic C.rpccgo_panic_callback) C.int32_t {
+	config := rpcruntime.PanicReportConfig{Stacks: stacks != 0}
+	if onPanic != nil {
+		config.OnPanic = func(panicErr *rpcruntime.PanicError) {
+			text := panicErr.Error()
+			_, ptr, err := rpcruntime.PinString(text)
+			if err != nil {
+				return
+			}
+			C.rpccgo_call_panic_callback(onPanic, C.uintptr_t(ptr), C.int32_t(len(text)))
+		}
+	}
+	rpcruntime.ConfigurePanicReports(config)
+	return 0
+}
+
+// rpccgoStreamSessionsList encodes the active stream sessions as a rpccgo.runtime.v1.StreamSessionList protobuf message. A non-zero pointer must be released with rpccgoRelease.
+//
+//export rpccgoStreamSessionsList
+func rpccgoStreamSessionsList(listPtr *C.uintptr_t, listLen *C.int32_t) C.int32_t {
+	if listPtr == nil || listLen == nil {
+		return -1
+	}
+	goPtr, goLen, err := rpcruntime.EncodePinnedStreamSessions()
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	*listPtr = C.uintptr_t(goPtr)
+	*listLen = C.int32_t(goLen)
+	return 0
+}
+
+// rpccgoStreamSessionCancel cancels an active stream session of any method and releases its handle. A callback receive stream still delivers onDone.
+//
+//export rpccgoStreamSessionCancel
+func rpccgoStreamSessionCancel(stream C.int32_t) C.int32_t {
+	if err := rpcruntime.CancelStreamSession(context.Background(), rpcruntime.StreamHandle(stream)); err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	return 0
+}
+
+// rpccgoStreamSessionsCancelIdle cancels every stream session without activity for at least idleMs milliseconds. canceled receives the number of sessions canceled.
+//
+//export rpccgoStreamSessionsCancelIdle
+func rpccgoStreamSessionsCancelIdle(idleMs C.int64_t, canceled *C.int32_t) C.int32_t {
+	handles, err := rpcruntime.CancelIdleStreamSessions(context.Background(), time.Duration(idleMs)*time.Millisecond)
+	if canceled != nil {
+		*canceled = C.int32_t(len(handles))
+	}
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	return 0
+}
+
+// rpccgoStreamSessionsSetIdleTimeout starts a background reaper that cancels stream sessions idle for at least timeoutMs milliseconds. A non-positive timeoutMs stops it.
+//
+//export rpccgoStreamSessionsSetIdleTimeout
+func rpccgoStreamSessionsSetIdleTimeout(timeoutMs C.int64_t) C.int32_t {
+	rpcruntime.SetStreamIdleTimeout(time.Duration(timeoutMs) * time.Millisecond)
+	return 0
+}
+
+// rpccgoStreamBufferingSet overrides the generated queue sizes and send mode of the in-process streams of a method, named by its full protobuf name. A non-zero pipelinedSend lets Send return once the value is queued instead of waiting for the peer. Streams started afterwards use the override.
+//
+//export rpccgoStreamBufferingSet
+func rpccgoStreamBufferingSet(method *C.char, methodLen C.int32_t, requestBuffer C.int32_t, responseBuffer C.int32_t, pipelinedSend C.int32_t) C.int32_t {
+	length, err := rpcruntime.LengthFromInt32(int32(methodLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: stream buffering method: %w", err)))
+	}
+	if method == nil || length == 0 {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: stream buffering method is empty")))
+	}
+	name := string(unsafe.Slice((*byte)(unsafe.Pointer(method)), length))
+	err = rpcruntime.SetStreamBuffering(name, rpcruntime.StreamBuffering{
+		RequestBuffer:  int(requestBuffer),
+		ResponseBuffer: int(responseBuffer),
+		PipelinedSend:  pipelinedSend != 0,
+	})
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	return 0
+}
+
+// rpccgoStreamBufferingClear removes the stream buffering override of a method, restoring the generated buffering for streams started afterwards.
+//
+//export rpccgoStreamBufferingClear
+func rpccgoStreamBufferingClear(method *C.char, methodLen C.int32_t) C.int32_t {
+	length, err := rpcruntime.LengthFromInt32(int32(methodLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: stream buffering method: %w", err)))
+	}
+	if method == nil || length == 0 {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: stream buffering method is empty")))
+	}
+	name := string(unsafe.Slice((*byte)(unsafe.Pointer(method)), length))
+	rpcruntime.ClearStreamBuffering(name)
+	return 0
+}
+
+// rpccgoConcurrencyLimitSet bounds the unary invokes and active stream sessions of a method, named by its full protobuf name, or of every method of a service when method is empty. Zero leaves a kind of call unlimited. An excess call waits up to queueTimeoutMs milliseconds for a slot, then fails with RPCCGO_CODE_RESOURCE_EXHAUSTED; zero fails it fast.
+//
+//export rpccgoConcurrencyLimitSet
+func rpccgoConcurrencyLimitSet(serviceID *C.char, serviceIDLen C.int32_t, method *C.char, methodLen C.int32_t, maxConcurrentUnary C.int32_t, maxActiveStreams C.int32_t, queueTimeoutMs C.int64_t) C.int32_t {
+	serviceIDLength, err := rpcruntime.LengthFromInt32(int32(serviceIDLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: concurrency limit service id: %w", err)))
+	}
+	methodLength, err := rpcruntime.LengthFromInt32(int32(methodLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: concurrency limit method: %w", err)))
+	}
+	if (serviceID == nil && serviceIDLength != 0) || (method == nil && methodLength != 0) {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: concurrency limit name pointer is nil")))
+	}
+	var scope rpcruntime.ConcurrencyScope
+	if serviceIDLength != 0 {
+		scope.ServiceID = rpcruntime.ServiceID(unsafe.Slice((*byte)(unsafe.Pointer(serviceID)), serviceIDLength))
+	}
+	if methodLength != 0 {
+		scope.Method = string(unsafe.Slice((*byte)(unsafe.Pointer(method)), methodLength))
+	}
+	err = rpcruntime.SetConcurrencyLimit(scope, rpcruntime.ConcurrencyLimit{
+		MaxConcurrentUnary: int(maxConcurrentUnary),
+		MaxActiveStreams:   int(maxActiveStreams),
+		QueueTimeout:       time.Duration(queueTimeoutMs) * time.Millisecond,
+	})
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	return 0
+}
+
+// rpccgoConcurrencyLimitClear removes the concurrency limit of a method, or of a service when method is empty. Queued calls proceed.
+//
+//export rpccgoConcurrencyLimitClear
+func rpccgoConcurrencyLimitClear(serviceID *C.char, serviceIDLen C.int32_t, method *C.char, methodLen C.int32_t) C.int32_t {
+	serviceIDLength, err := rpcruntime.LengthFromInt32(int32(serviceIDLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: concurrency limit service id: %w", err)))
+	}
+	methodLength, err := rpcruntime.LengthFromInt32(int32(methodLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: concurrency limit method: %w", err)))
+	}
+	if (serviceID == nil && serviceIDLength != 0) || (method == nil && methodLength != 0) {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: concurrency limit name pointer is nil")))
+	}
+	var scope rpcruntime.ConcurrencyScope
+	if serviceIDLength != 0 {
+		scope.ServiceID = rpcruntime.ServiceID(unsafe.Slice((*byte)(unsafe.Pointer(serviceID)), serviceIDLength))
+	}
+	if methodLength != 0 {
+		scope.Method = string(unsafe.Slice((*byte)(unsafe.Pointer(method)), methodLength))
+	}
+	rpcruntime.ClearConcurrencyLimit(scope)
+	return 0
+}
+
+// rpccgoConcurrencySnapshot encodes the configured concurrency limits with their active and queued calls as a rpccgo.runtime.v1.ConcurrencySnapshot protobuf message. A non-zero pointer must be released with rpccgoRelease.
+//
+//export rpccgoConcurrencySnapshot
+func rpccgoConcurrencySnapshot(snapshotPtr *C.uintptr_t, snapshotLen *C.int32_t) C.int32_t {
+	if snapshotPtr == nil || snapshotLen == nil {
+		return -1
+	}
+	goPtr, goLen, err := rpcruntime.EncodePinnedConcurrencySnapshot()
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	*snapshotPtr = C.uintptr_t(goPtr)
+	*snapshotLen = C.int32_t(goLen)
+	return 0
+}
+
+// rpccgoShutdown rejects new unary invokes and stream Starts, cancels every stream session and waits up to timeoutMs milliseconds for in-flight calls and callback receive onDone deliveries. A non-positive timeoutMs waits rpcruntime.DefaultShutdownTimeout (5s). The error text lists what was still pending.
+//
+//export rpccgoShutdown
+func rpccgoShutdown(timeoutMs C.int64_t) C.int32_t {
+	timeout := rpcruntime.DefaultShutdownTimeout
+	if timeoutMs > 0 {
+		timeout = time.Duration(timeoutMs) * time.Millisecond
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := rpcruntime.Shutdown(ctx); err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	return 0
+}
+
+// rpccgoRegistrationWatch delivers every replace or clear of the registered server of a service to onEvent, in order and on a Go-owned thread. An empty service id watches every service. Kinds are RPCCGO_SERVER_KIND_* values of the first route target; a non-zero service id pointer must be released with rpccgoRelease. onDone runs once after rpccgoRegistrationUnwatch and no callback runs after it.
+//
+//export rpccgoRegistrationWatch
+func rpccgoRegistrationWatch(serviceID *C.char, serviceIDLen C.int32_t, watch *C.int32_t, onEvent C.rpccgo_registration_event_callback, onDone C.rpccgo_registration_done_callback) C.int32_t {
+	if watch == nil {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: registration watch handle pointer is nil")))
+	}
+	*watch = 0
+	if onEvent == nil || onDone == nil {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: registration watch callbacks are nil")))
+	}
+	length, err := rpcruntime.LengthFromInt32(int32(serviceIDLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: registration watch service id: %w", err)))
+	}
+	if serviceID == nil && length != 0 {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: registration watch service id pointer is nil")))
+	}
+	var id string
+	if length != 0 {
+		id = string(unsafe.Slice((*byte)(unsafe.Pointer(serviceID)), length))
+	}
+	onWatchEvent := func(handle rpcruntime.RegistrationWatchHandle, event rpcruntime.RegistrationEvent) {
+		defer rpcruntime.RecoverPanic("rpccgoRegistrationWatch event", func(error) {})
+		_, ptr, err := rpcruntime.PinString(string(event.ServiceID))
+		if err != nil {
+			return
+		}
+		cleared := C.int32_t(0)
+		if event.Cleared {
+			cleared = 1
+		}
+		C.rpccgo_call_registration_event_callback(onEvent, C.int32_t(handle), C.uintptr_t(ptr), C.int32_t(len(event.ServiceID)), C.int32_t(event.OldKind), C.int32_t(event.NewKind), cleared, C.uint64_t(event.Seq))
+	}
+	onWatchDone := func(handle rpcruntime.RegistrationWatchHandle) {
+		defer rpcruntime.RecoverPanic("rpccgoRegistrationWatch done", func(error) {})
+		C.rpccgo_call_registration_done_callback(onDone, C.int32_t(handle))
+	}
+	handle, err := rpcruntime.NewRegistrationWatch(rpcruntime.ServiceID(id), onWatchEvent, onWatchDone)
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	*watch = C.int32_t(handle)
+	return 0
+}
+
+// rpccgoRegistrationUnwatch cancels a registration watch. Events already queued are still delivered before onDone.
+//
+//export rpccgoRegistrationUnwatch
+func rpccgoRegistrationUnwatch(watch C.int32_t) C.int32_t {
+	if err := rpcruntime.CancelRegistrationWatch(rpcruntime.RegistrationWatchHandle(watch)); err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	return 0
+}
+
+// rpccgoHealthCheck reports whether a service of the default registry has a registered server. status receives RPCCGO_HEALTH_SERVING while a server is registered, unless it reported otherwise with rpccgoHealthReport, and RPCCGO_HEALTH_NOT_SERVING without one; kind receives the RPCCGO_SERVER_KIND_* value of the first route target. An empty service id reports the runtime as a whole.
+//
+//export rpccgoHealthCheck
+func rpccgoHealthCheck(serviceID *C.char, serviceIDLen C.int32_t, status *C.int32_t, kind *C.int32_t) C.int32_t {
+	if status == nil || kind == nil {
+		return -1
+	}
+	length, err := rpcruntime.LengthFromInt32(int32(serviceIDLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: health service id: %w", err)))
+	}
+	if serviceID == nil && length != 0 {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: health service id pointer is nil")))
+	}
+	var id string
+	if length != 0 {
+		id = string(unsafe.Slice((*byte)(unsafe.Pointer(serviceID)), length))
+	}
+	health := rpcruntime.CheckHealth(rpcruntime.ServiceID(id))
+	*status = C.int32_t(health.Status)
+	*kind = C.int32_t(health.Kind)
+	return 0
+}
+
+// rpccgoHealthReport lets the server registered for a service report its own health as RPCCGO_HEALTH_SERVING or RPCCGO_HEALTH_NOT_SERVING. RPCCGO_HEALTH_UNKNOWN drops the report. A report lasts until the service is registered again or cleared.
+//
+//export rpccgoHealthReport
+func rpccgoHealthReport(serviceID *C.char, serviceIDLen C.int32_t, status C.int32_t) C.int32_t {
+	length, err := rpcruntime.LengthFromInt32(int32(serviceIDLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: health service id: %w", err)))
+	}
+	if serviceID == nil && length != 0 {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: health service id pointer is nil")))
+	}
+	var id string
+	if length != 0 {
+		id = string(unsafe.Slice((*byte)(unsafe.Pointer(serviceID)), length))
+	}
+	if err := rpcruntime.ReportHealth(rpcruntime.ServiceID(id), rpcruntime.HealthStatus(status)); err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	return 0
+}
+
+// rpccgoHealthWatch delivers the health of a service, then every change of it, to onHealth in order and on a Go-owned thread. An empty service id watches every service without an initial delivery. A non-zero service id pointer must be released with rpccgoRelease. Cancel the watch with rpccgoRegistrationUnwatch; onDone runs once after it and no callback runs after onDone.
+//
+//export rpccgoHealthWatch
+func rpccgoHealthWatch(serviceID *C.char, serviceIDLen C.int32_t, watch *C.int32_t, onHealth C.rpccgo_health_callback, onDone C.rpccgo_registration_done_callback) C.int32_t {
+	if watch == nil {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: health watch handle pointer is nil")))
+	}
+	*watch = 0
+	if onHealth == nil || onDone == nil {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: health watch callbacks are nil")))
+	}
+	length, err := rpcruntime.LengthFromInt32(int32(serviceIDLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: health service id: %w", err)))
+	}
+	if serviceID == nil && length != 0 {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: health service id pointer is nil")))
+	}
+	var id string
+	if length != 0 {
+		id = string(unsafe.Slice((*byte)(unsafe.Pointer(serviceID)), length))
+	}
+	onWatchHealth := func(handle rpcruntime.RegistrationWatchHandle, health rpcruntime.ServiceHealth) {
+		defer rpcruntime.RecoverPanic("rpccgoHealthWatch health", func(error) {})
+		_, ptr, err := rpcruntime.PinString(string(health.ServiceID))
+		if err != nil {
+			return
+		}
+		C.rpccgo_call_health_callback(onHealth, C.int32_t(handle), C.uintptr_t(ptr), C.int32_t(len(health.ServiceID)), C.int32_t(health.Status), C.int32_t(health.Kind))
+	}
+	onWatchDone := func(handle rpcruntime.RegistrationWatchHandle) {
+		defer rpcruntime.RecoverPanic("rpccgoHealthWatch done", func(error) {})
+		C.rpccgo_call_registration_done_callback(onDone, C.int32_t(handle))
+	}
+	handle, err := rpcruntime.NewHealthWatch(rpcruntime.ServiceID(id), onWatchHealth, onWatchDone)
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	*watch = C.int32_t(handle)
+	return 0
+}
+
+// rpccgoReflectionServices encodes every service generated into the library, with its .proto file, generated contracts and method count, as a rpccgo.runtime.v1.ServiceList protobuf message. A non-zero pointer must be released with rpccgoRelease.
+//
+//export rpccgoReflectionServices
+func rpccgoReflectionServices(servicesPtr *C.uintptr_t, servicesLen *C.int32_t) C.int32_t {
+	if servicesPtr == nil || servicesLen == nil {
+		return -1
+	}
+	goPtr, goLen, err := rpcruntime.EncodePinnedServiceList()
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	*servicesPtr = C.uintptr_t(goPtr)
+	*servicesLen = C.int32_t(goLen)
+	return 0
+}
+
+// rpccgoReflectionMethods encodes the methods of a generated service, with their RPCCGO_METHOD_STREAMING_* shape and request and response message names, as a rpccgo.runtime.v1.MethodList protobuf message. A non-zero pointer must be released with rpccgoRelease.
+//
+//export rpccgoReflectionMethods
+func rpccgoReflectionMethods(serviceID *C.char, serviceIDLen C.int32_t, methodsPtr *C.uintptr_t, methodsLen *C.int32_t) C.int32_t {
+	if methodsPtr == nil || methodsLen == nil {
+		return -1
+	}
+	length, err := rpcruntime.LengthFromInt32(int32(serviceIDLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: reflection service id: %w", err)))
+	}
+	if serviceID == nil && length != 0 {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: reflection service id pointer is nil")))
+	}
+	var id string
+	if length != 0 {
+		id = string(unsafe.Slice((*byte)(unsafe.Pointer(serviceID)), length))
+	}
+	goPtr, goLen, err := rpcruntime.EncodePinnedMethodList(rpcruntime.ServiceID(id))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	*methodsPtr = C.uintptr_t(goPtr)
+	*methodsLen = C.int32_t(goLen)
+	return 0
+}
+
+// rpccgoReflectionFileDescriptorSet serializes a google.protobuf.FileDescriptorSet holding the .proto file of a generated service and every file it imports, dependencies first. An empty service id covers every generated service. A non-zero pointer must be released with rpccgoRelease.
+//
+//export rpccgoReflectionFileDescriptorSet
+func rpccgoReflectionFileDescriptorSet(serviceID *C.char, serviceIDLen C.int32_t, setPtr *C.uintptr_t, setLen *C.int32_t) C.int32_t {
+	if setPtr == nil || setLen == nil {
+		return -1
+	}
+	length, err := rpcruntime.LengthFromInt32(int32(serviceIDLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: reflection service id: %w", err)))
+	}
+	if serviceID == nil && length != 0 {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: reflection service id pointer is nil")))
+	}
+	var id string
+	if length != 0 {
+		id = string(unsafe.Slice((*byte)(unsafe.Pointer(serviceID)), length))
+	}
+	goPtr, goLen, err := rpcruntime.EncodePinnedFileDescriptorSet(rpcruntime.ServiceID(id))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	*setPtr = C.uintptr_t(goPtr)
+	*setLen = C.int32_t(goLen)
+	return 0
+}
+
+// rpccgoInvoke invokes a unary method named at run time with a protobuf encoded request. method is the method name within the service or its protobuf full name. A non-zero response pointer must be released with rpccgoRelease.
+//
+//export rpccgoInvoke
+func rpccgoInvoke(serviceID *C.char, serviceIDLen C.int32_t, method *C.char, methodLen C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	return rpccgoInvokeWithContext(context.Background(), serviceID, serviceIDLen, method, methodLen, requestPtr, requestLen, responsePtr, responseLen)
+}
+
+// rpccgoInvokeWithOptions is rpccgoInvoke bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoInvokeWithOptions
+func rpccgoInvokeWithOptions(options C.int32_t, serviceID *C.char, serviceIDLen C.int32_t, method *C.char, methodLen C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoInvokeWithContext(ctx, serviceID, serviceIDLen, method, methodLen, requestPtr, requestLen, responsePtr, responseLen)
+}
+
+func rpccgoInvokeWithContext(ctx context.Context, serviceID *C.char, serviceIDLen C.int32_t, method *C.char, methodLen C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoInvoke", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
+	if responsePtr == nil || responseLen == nil {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: dynamic response output pointer is nil")))
+	}
+	*responsePtr = 0
+	*responseLen = 0
+	serviceIDLength, err := rpcruntime.LengthFromInt32(int32(serviceIDLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: dynamic service id: %w", err)))
+	}
+	methodLength, err := rpcruntime.LengthFromInt32(int32(methodLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: dynamic method: %w", err)))
+	}
+	if (serviceID == nil && serviceIDLength != 0) || (method == nil && methodLength != 0) {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: dynamic method name pointer is nil")))
+	}
+	var id rpcruntime.ServiceID
+	if serviceIDLength != 0 {
+		id = rpcruntime.ServiceID(unsafe.Slice((*byte)(unsafe.Pointer(serviceID)), serviceIDLength))
+	}
+	var name string
+	if methodLength != 0 {
+		name = string(unsafe.Slice((*byte)(unsafe.Pointer(method)), methodLength))
+	}
+	request, err := rpcruntime.BorrowBytes(requestPtr, int32(requestLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: dynamic request: %w", err)))
+	}
+	resp, err := rpcruntime.InvokeDynamic(ctx, id, name, request)
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	goLen, err := rpcruntime.LengthToInt32(len(resp))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	goPtr, err := rpcruntime.PinBytes(resp)
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	*responsePtr = C.uintptr_t(goPtr)
+	*responseLen = C.int32_t(goLen)
+	return 0
+}
+
+// rpccgoStreamStart starts a streaming method named at run time and returns its stream handle. The request is the protobuf encoded request of a server-streaming method and is ignored otherwise. Continue the stream with the generic stream exports or the per-method exports.
+//
+//export rpccgoStreamStart
+func rpccgoStreamStart(serviceID *C.char, serviceIDLen C.int32_t, method *C.char, methodLen C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t) C.int32_t {
+	return rpccgoStreamStartWithContext(context.Background(), serviceID, serviceIDLen, method, methodLen, requestPtr, requestLen, handle)
+}
+
+// rpccgoStreamStartWithOptions is rpccgoStreamStart bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoStreamStartWithOptions
+func rpccgoStreamStartWithOptions(options C.int32_t, serviceID *C.char, serviceIDLen C.int32_t, method *C.char, methodLen C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	status := rpccgoStreamStartWithContext(ctx, serviceID, serviceIDLen, method, methodLen, requestPtr, requestLen, handle)
+	if status != 0 {
+		cancel()
+	}
+	return status
+}
+
+func rpccgoStreamStartWithContext(ctx context.Context, serviceID *C.char, serviceIDLen C.int32_t, method *C.char, methodLen C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoStreamStart", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
+	if handle == nil {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: dynamic stream handle pointer is nil")))
+	}
+	*handle = 0
+	serviceIDLength, err := rpcruntime.LengthFromInt32(int32(serviceIDLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: dynamic service id: %w", err)))
+	}
+	methodLength, err := rpcruntime.LengthFromInt32(int32(methodLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: dynamic method: %w", err)))
+	}
+	if (serviceID == nil && serviceIDLength != 0) || (method == nil && methodLength != 0) {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: dynamic method name pointer is nil")))
+	}
+	var id rpcruntime.ServiceID
+	if serviceIDLength != 0 {
+		id = rpcruntime.ServiceID(unsafe.Slice((*byte)(unsafe.Pointer(serviceID)), serviceIDLength))
+	}
+	var name string
+	if methodLength != 0 {
+		name = string(unsafe.Slice((*byte)(unsafe.Pointer(method)), methodLength))
+	}
+	request, err := rpcruntime.BorrowBytes(requestPtr, int32(requestLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: dynamic request: %w", err)))
+	}
+	started, err := rpcruntime.StartDynamicStream(ctx, id, name, request)
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	*handle = C.int32_t(started)
+	return 0
+}
+
+// rpccgoStreamSend sends a protobuf encoded request on a client or bidi message stream.
+//
+//export rpccgoStreamSend
+func rpccgoStreamSend(handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) C.int32_t {
+	return rpccgoStreamSendWithContext(context.Background(), handle, requestPtr, requestLen)
+}
+
+// rpccgoStreamSendWithOptions is rpccgoStreamSend bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoStreamSendWithOptions
+func rpccgoStreamSendWithOptions(options C.int32_t, handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoStreamSendWithContext(ctx, handle, requestPtr, requestLen)
+}
+
+func rpccgoStreamSendWithContext(ctx context.Context, handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoStreamSend", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
+	request, err := rpcruntime.BorrowBytes(requestPtr, int32(requestLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: dynamic request: %w", err)))
+	}
+	if err := rpcruntime.SendDynamicStream(ctx, rpcruntime.StreamHandle(handle), request); err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	return 0
+}
+
+// rpccgoStreamRecv receives the next protobuf encoded response of a server or bidi message stream. The end of the stream is an error with RPCCGO_CODE_OUT_OF_RANGE. A non-zero response pointer must be released with rpccgoRelease.
+//
+//export rpccgoStreamRecv
+func rpccgoStreamRecv(handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	return rpccgoStreamRecvWithContext(context.Background(), handle, responsePtr, responseLen)
+}
+
+// rpccgoStreamRecvWithOptions is rpccgoStreamRecv bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoStreamRecvWithOptions
+func rpccgoStreamRecvWithOptions(options C.int32_t, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoStreamRecvWithContext(ctx, handle, responsePtr, responseLen)
+}
+
+func rpccgoStreamRecvWithContext(ctx context.Context, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoStreamRecv", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
+	if responsePtr == nil || responseLen == nil {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: dynamic response output pointer is nil")))
+	}
+	*responsePtr = 0
+	*responseLen = 0
+	resp, err := rpcruntime.RecvDynamicStream(ctx, rpcruntime.StreamHandle(handle))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	goLen, err := rpcruntime.LengthToInt32(len(resp))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	goPtr, err := rpcruntime.PinBytes(resp)
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	*responsePtr = C.uintptr_t(goPtr)
+	*responseLen = C.int32_t(goLen)
+	return 0
+}
+
+// rpccgoStreamCloseSend closes the send side of a bidi message stream.
+//
+//export rpccgoStreamCloseSend
+func rpccgoStreamCloseSend(handle C.int32_t) C.int32_t {
+	return rpccgoStreamCloseSendWithContext(context.Background(), handle)
+}
+
+// rpccgoStreamCloseSendWithOptions is rpccgoStreamCloseSend bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoStreamCloseSendWithOptions
+func rpccgoStreamCloseSendWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoStreamCloseSendWithContext(ctx, handle)
+}
+
+func rpccgoStreamCloseSendWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoStreamCloseSend", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
+	if err := rpcruntime.CloseSendDynamicStream(ctx, rpcruntime.StreamHandle(handle)); err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	return 0
+}
+
+// rpccgoStreamFinish finishes a client or bidi message stream and releases its handle. A client stream writes its protobuf encoded response; a bidi stream writes an empty response. A non-zero response pointer must be released with rpccgoRelease.
+//
+//export rpccgoStreamFinish
+func rpccgoStreamFinish(handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	return rpccgoStreamFinishWithContext(context.Background(), handle, responsePtr, responseLen)
+}
+
+// rpccgoStreamFinishWithOptions is rpccgoStreamFinish bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoStreamFinishWithOptions
+func rpccgoStreamFinishWithOptions(options C.int32_t, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoStreamFinishWithContext(ctx, handle, responsePtr, responseLen)
+}
+
+func rpccgoStreamFinishWithContext(ctx context.Context, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoStreamFinish", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
+	if responsePtr == nil || responseLen == nil {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: dynamic response output pointer is nil")))
+	}
+	*responsePtr = 0
+	*responseLen = 0
+	resp, err := rpcruntime.FinishDynamicStream(ctx, rpcruntime.StreamHandle(handle))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	goLen, err := rpcruntime.LengthToInt32(len(resp))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	goPtr, err := rpcruntime.PinBytes(resp)
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	*responsePtr = C.uintptr_t(goPtr)
+	*responseLen = C.int32_t(goLen)
+	return 0
+}
+
+// rpccgoStreamCancel cancels a message stream and releases its handle.
+//
+//export rpccgoStreamCancel
+func rpccgoStreamCancel(handle C.int32_t) C.int32_t {
+	return rpccgoStreamCancelWithContext(context.Background(), handle)
+}
+
+// rpccgoStreamCancelWithOptions is rpccgoStreamCancel bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoStreamCancelWithOptions
+func rpccgoStreamCancelWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoStreamCancelWithContext(ctx, handle)
+}
+
+func rpccgoStreamCancelWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoStreamCancel", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
+	if err := rpcruntime.CancelDynamicStream(ctx, rpcruntime.StreamHandle(handle)); err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	return 0
+}
+
+// rpccgoServerRegistryNew creates an empty server registry isolated from the default one and returns its handle. Select it for calls with rpccgoCallOptionsSetRegistry.
+//
+//export rpccgoServerRegistryNew
+func rpccgoServerRegistryNew(registry *C.int32_t) C.int32_t {
+	if registry == nil {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: server registry handle pointer is nil")))
+	}
+	*registry = 0
+	handle, err := rpcruntime.NewServerRegistryHandle(rpcruntime.NewServerRegistry())
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	*registry = C.int32_t(handle)
+	return 0
+}
+
+// rpccgoServerRegistryCopyRoute copies the servers registered for a service in the default registry into a server registry handle, replacing its route. C servers registered through the generated Register exports reach a scoped registry this way.
+//
+//export rpccgoServerRegistryCopyRoute
+func rpccgoServerRegistryCopyRoute(registry C.int32_t, serviceID *C.char, serviceIDLen C.int32_t) C.int32_t {
+	target, err := rpcruntime.LoadServerRegistryHandle(rpcruntime.ServerRegistryHandle(registry))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	length, err := rpcruntime.LengthFromInt32(int32(serviceIDLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: server registry service id: %w", err)))
+	}
+	if serviceID == nil && length != 0 {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: server registry service id pointer is nil")))
+	}
+	var id string
+	if length != 0 {
+		id = string(unsafe.Slice((*byte)(unsafe.Pointer(serviceID)), length))
+	}
+	route, err := rpcruntime.LoadServerRoute(rpcruntime.ServiceID(id))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	if err := target.RegisterRoute(rpcruntime.ServiceID(id), route); err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	return 0
+}
+
+// rpccgoServerRegistryRelease releases a server registry handle. Call options that selected the registry keep using it.
+//
+//export rpccgoServerRegistryRelease
+func rpccgoServerRegistryRelease(registry C.int32_t) C.int32_t {
+	if err := rpcruntime.ReleaseServerRegistryHandle(rpcruntime.ServerRegistryHandle(registry)); err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	return 0
+}
+
+// rpccgoCallOptionsNew creates a call options handle for the WithOptions client exports. A positive timeoutMs bounds each call made with the handle.
+//
+//export rpccgoCallOptionsNew
+func rpccgoCallOptionsNew(timeoutMs C.int64_t, options *C.int32_t) C.int32_t {
+	if options == nil {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: call options handle pointer is nil")))
+	}
+	*options = 0
+	handle, err := rpcruntime.NewCallOptions(time.Duration(timeoutMs) * time.Millisecond)
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	*options = C.int32_t(handle)
+	return 0
+}
+
+// rpccgoCallOptionsSetDeadline sets an absolute Unix millisecond deadline shared by later calls made with a call options handle. Zero clears it.
+//
+//export rpccgoCallOptionsSetDeadline
+func rpccgoCallOptionsSetDeadline(options C.int32_t, deadlineUnixMs C.int64_t) C.int32_t {
+	var deadline time.Time
+	if deadlineUnixMs != 0 {
+		deadline = time.UnixMilli(int64(deadlineUnixMs))
+	}
+	if err := rpcruntime.SetCallOptionsDeadline(rpcruntime.CallOptionsHandle(options), deadline); err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	return 0
+}
+
+// rpccgoCallOptionsSetMetadata sets the encoded request metadata sent by later calls made with a call options handle. An empty payload clears it.
+//
+//export rpccgoCallOptionsSetMetadata
+func rpccgoCallOptionsSetMetadata(options C.int32_t, metadata *C.char, metadataLen C.int32_t) C.int32_t {
+	length, err := rpcruntime.LengthFromInt32(int32(metadataLen))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: cgo metadata: %w", err)))
+	}
+	if metadata == nil && length != 0 {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: cgo metadata pointer is nil")))
+	}
+	var data []byte
+	if length != 0 {
+		data = unsafe.Slice((*byte)(unsafe.Pointer(metadata)), length)
+	}
+	md, err := rpcruntime.DecodeMetadata(data)
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	if err := rpcruntime.SetCallOptionsMetadata(rpcruntime.CallOptionsHandle(options), md); err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	return 0
+}
+
+// rpccgoCallOptionsSetRegistry routes later calls made with a call options handle, and the stream operations on streams they start, through a server registry handle. Zero selects the default registry.
+//
+//export rpccgoCallOptionsSetRegistry
+func rpccgoCallOptionsSetRegistry(options C.int32_t, registry C.int32_t) C.int32_t {
+	var target *rpcruntime.ServerRegistry
+	if registry != 0 {
+		var err error
+		target, err = rpcruntime.LoadServerRegistryHandle(rpcruntime.ServerRegistryHandle(registry))
+		if err != nil {
+			return C.int32_t(rpcruntime.StoreError(err))
+		}
+	}
+	if err := rpcruntime.SetCallOptionsServerRegistry(rpcruntime.CallOptionsHandle(options), target); err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	return 0
+}
+
+// rpccgoCallOptionsTakeResponseMetadata takes the encoded response headers and trailers of the most recent call made with a call options handle. Non-zero pointers must be released with rpccgoRelease.
+//
+//export rpccgoCallOptionsTakeResponseMetadata
+func rpccgoCallOptionsTakeResponseMetadata(options C.int32_t, headerPtr *C.uintptr_t, headerLen *C.int32_t, trailerPtr *C.uintptr_t, trailerLen *C.int32_t) C.int32_t {
+	if headerPtr == nil || headerLen == nil || trailerPtr == nil || trailerLen == nil {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: response metadata output pointer is nil")))
+	}
+	*headerPtr, *headerLen, *trailerPtr, *trailerLen = 0, 0, 0, 0
+	header, trailer, err := rpcruntime.TakeCallOptionsResponseMetadata(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	goHeaderPtr, goHeaderLen, err := rpcruntime.EncodePinnedMetadata(header)
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	goTrailerPtr, goTrailerLen, err := rpcruntime.EncodePinnedMetadata(trailer)
+	if err != nil {
+		rpcruntime.Release(goHeaderPtr)
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	*headerPtr = C.uintptr_t(goHeaderPtr)
+	*headerLen = C.int32_t(goHeaderLen)
+	*trailerPtr = C.uintptr_t(goTrailerPtr)
+	*trailerLen = C.int32_t(goTrailerLen)
+	return 0
+}
+
+// rpccgoCallOptionsCancel cancels every in-flight call and stream started with a call options handle.
+//
+//export rpccgoCallOptionsCancel
+func rpccgoCallOptionsCancel(options C.int32_t) C.int32_t {
+	if err := rpcruntime.CancelCallOptions(rpcruntime.CallOptionsHandle(options)); err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	return 0
+}
+
+// rpccgoCallbackTraceParent copies the W3C traceparent of the Go call that invoked the current cgo server Unary or stream Start callback. traceParentLen receives 0 when the call has no trace context; the value is copied only when bufLen is at least RPCCGO_TRACEPARENT_LEN.
+//
+//export rpccgoCallbackTraceParent
+func rpccgoCallbackTraceParent(buf *C.char, bufLen C.int32_t, traceParentLen *C.int32_t) C.int32_t {
+	if traceParentLen == nil {
+		return -1
+	}
+	var value [C.RPCCGO_TRACEPARENT_LEN]byte
+	length := C.rpccgo_get_callback_trace_parent((*C.char)(unsafe.Pointer(&value[0])))
+	*traceParentLen = length
+	if length != 0 && buf != nil && bufLen >= length {
+		copy(unsafe.Slice((*byte)(unsafe.Pointer(buf)), int(length)), value[:length])
+	}
+	return 0
+}
+
+// rpccgoEnterCallbackTrace exposes the trace parent of ctx to rpccgoCallbackTraceParent on the
+// calling thread until the returned leave func runs.
+func rpccgoEnterCallbackTrace(ctx context.Context) func() {
+	sc := rpcruntime.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return func() {}
+	}
+	value := []byte(sc.TraceParent())
+	goruntime.LockOSThread()
+	var previous [C.RPCCGO_TRACEPARENT_LEN]byte
+	previousLen := C.rpccgo_get_callback_trace_parent((*C.char)(unsafe.Pointer(&previous[0])))
+	C.rpccgo_set_callback_trace_parent((*C.char)(unsafe.Pointer(&value[0])), C.int32_t(len(value)))
+	return func() {
+		C.rpccgo_set_callback_trace_parent((*C.char)(unsafe.Pointer(&previous[0])), previousLen)
+		goruntime.UnlockOSThread()
+	}
+}
+
+// rpccgoCallOptionsRelease cancels and releases a call options handle.
+//
+//export rpccgoCallOptionsRelease
+func rpccgoCallOptionsRelease(options C.int32_t) C.int32_t {
+	if err := rpcruntime.ReleaseCallOptions(rpcruntime.CallOptionsHandle(options)); err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	return 0
+}
@@ -25,28 +25,82 @@ var GreeterNativeServerUnavailableErr = errors.New("rpccgo: native server is una
 // GreeterMessageServerUnavailableErr is returned when a message server registration is missing or invalid.
 var GreeterMessageServerUnavailableErr = errors.New("rpccgo: message server is unavailable")
 
+func init() {
+	rpcruntime.RegisterServiceManifest(rpcruntime.ServiceManifest{
+		ServiceID:        greeterServiceID,
+		ProtoFile:        "greeter.proto",
+		Native:           true,
+		MessageTransport: rpcruntime.ServerKindConnect,
+		Methods: []rpcruntime.MethodManifest{
+			{Name: "SayHello", FullName: "examples.connect.greeter.v1.Greeter.SayHello", Streaming: rpcruntime.MethodStreamingUnary, RequestType: "examples.connect.greeter.v1.SayHelloRequest", ResponseType: "examples.connect.greeter.v1.SayHelloResponse"},
+			{Name: "Collect", FullName: "examples.connect.greeter.v1.Greeter.Collect", Streaming: rpcruntime.MethodStreamingClient, RequestType: "examples.connect.greeter.v1.SayHelloRequest", ResponseType: "examples.connect.greeter.v1.SayHelloResponse"},
+			{Name: "Broadcast", FullName: "examples.connect.greeter.v1.Greeter.Broadcast", Streaming: rpcruntime.MethodStreamingServer, RequestType: "examples.connect.greeter.v1.SayHelloRequest", ResponseType: "examples.connect.greeter.v1.SayHelloResponse"},
+			{Name: "Chat", FullName: "examples.connect.greeter.v1.Greeter.Chat", Streaming: rpcruntime.MethodStreamingBidi, RequestType: "examples.connect.greeter.v1.SayHelloRequest", ResponseType: "examples.connect.greeter.v1.SayHelloResponse"},
+		},
+	})
+	rpcruntime.RegisterDynamicMethod("examples.connect.greeter.v1.Greeter.SayHello", rpcruntime.DynamicUnary(InvokeGreeterMessageSayHello))
+	rpcruntime.RegisterDynamicMethod("examples.connect.greeter.v1.Greeter.Collect", rpcruntime.DynamicClientStream(GreeterMessageCollectStart, GreeterMessageCollectSend, GreeterMessageCollectFinish, GreeterMessageCollectCancel))
+	rpcruntime.RegisterDynamicMethod("examples.connect.greeter.v1.Greeter.Broadcast", rpcruntime.DynamicServerStream(GreeterMessageBroadcastStart, GreeterMessageBroadcastRecv, GreeterMessageBroadcastCancel))
+	rpcruntime.RegisterDynamicMethod("examples.connect.greeter.v1.Greeter.Chat", rpcruntime.DynamicBidiStream(GreeterMessageChatStart, GreeterMessageChatSend, GreeterMessageChatRecv, GreeterMessageChatCloseSend, GreeterMessageChatFinish, GreeterMessageChatCancel))
+}
+
 // ClearGreeterServer clears the current registered server for this service.
 func ClearGreeterServer() error {
-	return rpcruntime.ClearServer(greeterServiceID)
+	return ClearGreeterServerIn(rpcruntime.DefaultServerRegistry())
+}
+
+// ClearGreeterServerIn clears the current registered server for this service in registry.
+func ClearGreeterServerIn(registry *rpcruntime.ServerRegistry) error {
+	return registry.Clear(greeterServiceID)
 }
 
-// LoadGreeterRegisteredServer loads the current registered server record for this service.
+// LoadGreeterRegisteredServer loads the current registered server record for this service. With a multi-server route it returns the first target.
 func LoadGreeterRegisteredServer() (rpcruntime.RegisteredServer, error) {
-	return rpcruntime.LoadServer(greeterServiceID)
+	return LoadGreeterRegisteredServerIn(rpcruntime.DefaultServerRegistry())
+}
+
+// LoadGreeterRegisteredServerIn loads the current registered server record for this service from registry.
+func LoadGreeterRegisteredServerIn(registry *rpcruntime.ServerRegistry) (rpcruntime.RegisteredServer, error) {
+	return registry.Load(greeterServiceID)
+}
+
+// RegisterGreeterServerRoute replaces the registered servers for this service with an ordered or weighted route. Targets are records loaded after the generated Register helpers.
+func RegisterGreeterServerRoute(route rpcruntime.ServerRoute) error {
+	return RegisterGreeterServerRouteIn(rpcruntime.DefaultServerRegistry(), route)
+}
+
+// RegisterGreeterServerRouteIn replaces the registered servers for this service in registry with an ordered or weighted route.
+func RegisterGreeterServerRouteIn(registry *rpcruntime.ServerRegistry, route rpcruntime.ServerRoute) error {
+	return registry.RegisterRoute(greeterServiceID, route)
+}
+
+// LoadGreeterServerRoute loads the registered server route for this service.
+func LoadGreeterServerRoute() (rpcruntime.ServerRoute, error) {
+	return LoadGreeterServerRouteIn(rpcruntime.DefaultServerRegistry())
+}
+
+// LoadGreeterServerRouteIn loads the registered server route for this service from registry.
+func LoadGreeterServerRouteIn(registry *rpcruntime.ServerRegistry) (rpcruntime.ServerRoute, error) {
+	return registry.LoadRoute(greeterServiceID)
 }
 
 // RegisterGreeterConnectHandler registers the supplied connect handler server as the current server for this service.
 func RegisterGreeterConnectHandler(handler GreeterHandler) error {
+	return RegisterGreeterConnectHandlerIn(rpcruntime.DefaultServerRegistry(), handler)
+}
+
+// RegisterGreeterConnectHandlerIn registers the supplied connect handler server as the current server for this service in registry.
+func RegisterGreeterConnectHandlerIn(registry *rpcruntime.ServerRegistry, handler GreeterHandler) error {
 	if handler == nil {
-		_ = rpcruntime.ClearServer(greeterServiceID)
+		_ = registry.Clear(greeterServiceID)
 		return GreeterMessageServerUnavailableErr
 	}
-	err := rpcruntime.RegisterServer(greeterServiceID, rpcruntime.RegisteredServer{
+	err := registry.Register(greeterServiceID, rpcruntime.RegisteredServer{
 		Kind:   rpcruntime.ServerKindConnect,
 		Server: handler,
 	})
 	if err != nil {
-		_ = rpcruntime.ClearServer(greeterServiceID)
+		_ = registry.Clear(greeterServiceID)
 		return err
 	}
 	return nil
@@ -54,16 +108,21 @@ func RegisterGreeterConnectHandler(handler GreeterHandler) error {
 
 // RegisterGreeterConnectRemoteServer registers the supplied connect remote server as the current server for this service.
 func RegisterGreeterConnectRemoteServer(client GreeterClient) error {
+	return RegisterGreeterConnectRemoteServerIn(rpcruntime.DefaultServerRegistry(), client)
+}
+
+// RegisterGreeterConnectRemoteServerIn registers the supplied connect remote server as the current server for this service in registry.
+func RegisterGreeterConnectRemoteServerIn(registry *rpcruntime.ServerRegistry, client GreeterClient) error {
 	if client == nil {
-		_ = rpcruntime.ClearServer(greeterServiceID)
+		_ = registry.Clear(greeterServiceID)
 		return GreeterMessageServerUnavailableErr
 	}
-	err := rpcruntime.RegisterServer(greeterServiceID, rpcruntime.RegisteredServer{
+	err := registry.Register(greeterServiceID, rpcruntime.RegisteredServer{
 		Kind:   rpcruntime.ServerKindConnectRemote,
 		Server: client,
 	})
 	if err != nil {
-		_ = rpcruntime.ClearServer(greeterServiceID)
+		_ = registry.Clear(greeterServiceID)
 		return err
 	}
 	return nil
@@ -71,11 +130,13 @@ func RegisterGreeterConnectRemoteServer(client GreeterClient) error {
 
 func newgreeterCollectConnectDirectMessageStreamSession(ctx context.Context, handler GreeterHandler) rpcruntime.ClientStreamingClient[*SayHelloRequest, *SayHelloResponse] {
 	client, stream, streamCtx := rpcruntime.NewClientStreaming[*SayHelloRequest, *SayHelloResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:        "examples.connect.greeter.v1.Greeter.Collect",
 		RequestBuffer: 16,
 		StreamClosed:  errors.New("rpccgo: message stream is closed"),
 		NilRequest:    errors.New("rpccgo: message request is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.connect.greeter.v1.Greeter.Collect handler", func(err error) { stream.Complete(nil, err) })
 		conn := &rpcruntime.ConnectStreamingHandlerConn{ReceiveFunc: func(message any) error {
 			target, ok := message.(*SayHelloRequest)
 			if !ok || target == nil {
@@ -88,7 +149,9 @@ func newgreeterCollectConnectDirectMessageStreamSession(ctx context.Context, han
 			*target = *req
 			return nil
 		}}
-		resp, err := handler.Collect(streamCtx, rpcruntime.NewConnectClientStream[SayHelloRequest](conn))
+		handlerCtx, finishMetadata := rpcruntime.ConnectStreamingHandlerContext(streamCtx, conn)
+		resp, err := handler.Collect(handlerCtx, rpcruntime.NewConnectClientStream[SayHelloRequest](conn))
+		finishMetadata()
 		stream.Complete(resp, err)
 	}()
 	return client
@@ -98,46 +161,55 @@ func newgreeterCollectConnectRemoteMessageStreamSession(ctx context.Context, cli
 	Collect(context.Context) (*connect.ClientStreamForClientSimple[SayHelloRequest, SayHelloResponse], error)
 }) (*greeterCollectConnectRemoteMessageStreamSession, error) {
 	streamCtx, cancel := context.WithCancel(ctx)
-	stream, err := client.Collect(streamCtx)
+	callCtx, finishMetadata := rpcruntime.ConnectClientContext(streamCtx)
+	stream, err := client.Collect(callCtx)
 	if err != nil {
 		cancel()
 		return nil, err
 	}
-	return &greeterCollectConnectRemoteMessageStreamSession{stream: stream, cancel: cancel}, nil
+	return &greeterCollectConnectRemoteMessageStreamSession{stream: stream, cancel: cancel, finishMetadata: finishMetadata}, nil
 }
 
 type greeterCollectConnectRemoteMessageStreamSession struct {
-	stream *connect.ClientStreamForClientSimple[SayHelloRequest, SayHelloResponse]
-	cancel context.CancelFunc
+	stream         *connect.ClientStreamForClientSimple[SayHelloRequest, SayHelloResponse]
+	cancel         context.CancelFunc
+	finishMetadata func()
 }
 
 func (s *greeterCollectConnectRemoteMessageStreamSession) Send(ctx context.Context, req *SayHelloRequest) error {
-	_ = ctx
 	if req == nil {
 		return errors.New("rpccgo: message request is nil")
 	}
 	if s == nil || s.stream == nil {
 		return errors.New("rpccgo: connect remote client stream is nil")
 	}
-	return s.stream.Send(req)
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
+	return rpcruntime.ContextError(ctx, s.stream.Send(req))
 }
 
 func (s *greeterCollectConnectRemoteMessageStreamSession) Finish(ctx context.Context) (*SayHelloResponse, error) {
-	_ = ctx
 	if s == nil {
 		return nil, errors.New("rpccgo: connect remote client stream is nil")
 	}
 	if s.stream == nil {
 		return nil, errors.New("rpccgo: connect remote client stream is nil")
 	}
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
 	defer func() {
 		if s.cancel != nil {
 			s.cancel()
 		}
 	}()
 	resp, err := s.stream.CloseAndReceive()
+	s.finishMetadata()
 	if err != nil {
-		return nil, err
+		return nil, rpcruntime.ContextError(ctx, err)
 	}
 	if resp == nil {
 		return nil, errors.New("rpccgo: message response is nil")
@@ -180,11 +252,13 @@ func newgreeterBroadcastConnectDirectMessageStreamSession(ctx context.Context, h
 		return nil, errors.New("rpccgo: message request is nil")
 	}
 	client, stream, streamCtx := rpcruntime.NewServerStreaming[*SayHelloResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:         "examples.connect.greeter.v1.Greeter.Broadcast",
 		ResponseBuffer: 1,
 		StreamClosed:   errors.New("rpccgo: message stream is closed"),
 		NilResponse:    errors.New("rpccgo: message response is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.connect.greeter.v1.Greeter.Broadcast handler", func(err error) { stream.Complete(err) })
 		conn := &rpcruntime.ConnectStreamingHandlerConn{SendFunc: func(message any) error {
 			resp, ok := message.(*SayHelloResponse)
 			if !ok || resp == nil {
@@ -192,7 +266,10 @@ func newgreeterBroadcastConnectDirectMessageStreamSession(ctx context.Context, h
 			}
 			return stream.Send(streamCtx, resp)
 		}}
-		stream.Complete(handler.Broadcast(streamCtx, req, rpcruntime.NewConnectServerStream[SayHelloResponse](conn)))
+		handlerCtx, finishMetadata := rpcruntime.ConnectStreamingHandlerContext(streamCtx, conn)
+		err := handler.Broadcast(handlerCtx, req, rpcruntime.NewConnectServerStream[SayHelloResponse](conn))
+		finishMetadata()
+		stream.Complete(err)
 	}()
 	return client, nil
 }
@@ -204,30 +281,36 @@ func newgreeterBroadcastConnectRemoteMessageStreamSession(ctx context.Context, c
 		return nil, errors.New("rpccgo: message request is nil")
 	}
 	streamCtx, cancel := context.WithCancel(ctx)
-	stream, err := client.Broadcast(streamCtx, req)
+	callCtx, finishMetadata := rpcruntime.ConnectClientContext(streamCtx)
+	stream, err := client.Broadcast(callCtx, req)
 	if err != nil {
 		cancel()
 		return nil, err
 	}
-	return &greeterBroadcastConnectRemoteMessageStreamSession{stream: stream, cancel: cancel}, nil
+	return &greeterBroadcastConnectRemoteMessageStreamSession{stream: stream, cancel: cancel, finishMetadata: finishMetadata}, nil
 }
 
 type greeterBroadcastConnectRemoteMessageStreamSession struct {
-	stream *connect.ServerStreamForClient[SayHelloResponse]
-	cancel context.CancelFunc
+	stream         *connect.ServerStreamForClient[SayHelloResponse]
+	cancel         context.CancelFunc
+	finishMetadata func()
 }
 
 func (s *greeterBroadcastConnectRemoteMessageStreamSession) Recv(ctx context.Context) (*SayHelloResponse, error) {
-	_ = ctx
 	if s == nil {
 		return nil, errors.New("rpccgo: connect remote server stream is nil")
 	}
 	if s.stream == nil {
 		return nil, errors.New("rpccgo: connect remote server stream is nil")
 	}
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
 	if !s.stream.Receive() {
+		s.finishMetadata()
 		if err := s.stream.Err(); err != nil {
-			return nil, err
+			return nil, rpcruntime.ContextError(ctx, err)
 		}
 		return nil, io.EOF
 	}
@@ -262,6 +345,7 @@ func (s *greeterBroadcastConnectRemoteMessageStreamSession) Cancel(ctx context.C
 
 func newgreeterChatConnectDirectMessageStreamSession(ctx context.Context, handler GreeterHandler) rpcruntime.BidiStreamingClient[*SayHelloRequest, *SayHelloResponse] {
 	client, stream, streamCtx := rpcruntime.NewBidiStreaming[*SayHelloRequest, *SayHelloResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:         "examples.connect.greeter.v1.Greeter.Chat",
 		RequestBuffer:  16,
 		ResponseBuffer: 1,
 		StreamClosed:   errors.New("rpccgo: message stream is closed"),
@@ -269,6 +353,7 @@ func newgreeterChatConnectDirectMessageStreamSession(ctx context.Context, handle
 		NilResponse:    errors.New("rpccgo: message response is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.connect.greeter.v1.Greeter.Chat handler", func(err error) { stream.Complete(err) })
 		conn := &rpcruntime.ConnectStreamingHandlerConn{
 			ReceiveFunc: func(message any) error {
 				target, ok := message.(*SayHelloRequest)
@@ -290,7 +375,10 @@ func newgreeterChatConnectDirectMessageStreamSession(ctx context.Context, handle
 				return stream.Send(streamCtx, resp)
 			},
 		}
-		stream.Complete(handler.Chat(streamCtx, rpcruntime.NewConnectBidiStream[SayHelloRequest, SayHelloResponse](conn)))
+		handlerCtx, finishMetadata := rpcruntime.ConnectStreamingHandlerContext(streamCtx, conn)
+		err := handler.Chat(handlerCtx, rpcruntime.NewConnectBidiStream[SayHelloRequest, SayHelloResponse](conn))
+		finishMetadata()
+		stream.Complete(err)
 	}()
 	return client
 }
@@ -299,38 +387,47 @@ func newgreeterChatConnectRemoteMessageStreamSession(ctx context.Context, client
 	Chat(context.Context) (*connect.BidiStreamForClientSimple[SayHelloRequest, SayHelloResponse], error)
 }) (*greeterChatConnectRemoteMessageStreamSession, error) {
 	streamCtx, cancel := context.WithCancel(ctx)
-	stream, err := client.Chat(streamCtx)
+	callCtx, finishMetadata := rpcruntime.ConnectClientContext(streamCtx)
+	stream, err := client.Chat(callCtx)
 	if err != nil {
 		cancel()
 		return nil, err
 	}
-	return &greeterChatConnectRemoteMessageStreamSession{stream: stream, cancel: cancel}, nil
+	return &greeterChatConnectRemoteMessageStreamSession{stream: stream, cancel: cancel, finishMetadata: finishMetadata}, nil
 }
 
 type greeterChatConnectRemoteMessageStreamSession struct {
-	stream *connect.BidiStreamForClientSimple[SayHelloRequest, SayHelloResponse]
-	cancel context.CancelFunc
+	stream         *connect.BidiStreamForClientSimple[SayHelloRequest, SayHelloResponse]
+	cancel         context.CancelFunc
+	finishMetadata func()
 }
 
 func (s *greeterChatConnectRemoteMessageStreamSession) Send(ctx context.Context, req *SayHelloRequest) error {
-	_ = ctx
 	if req == nil {
 		return errors.New("rpccgo: message request is nil")
 	}
 	if s == nil || s.stream == nil {
 		return errors.New("rpccgo: connect remote bidi stream is nil")
 	}
-	return s.stream.Send(req)
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
+	return rpcruntime.ContextError(ctx, s.stream.Send(req))
 }
 
 func (s *greeterChatConnectRemoteMessageStreamSession) Recv(ctx context.Context) (*SayHelloResponse, error) {
-	_ = ctx
 	if s == nil || s.stream == nil {
 		return nil, errors.New("rpccgo: connect remote bidi stream is nil")
 	}
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
 	resp, err := s.stream.Receive()
 	if err != nil {
-		return nil, err
+		s.finishMetadata()
+		return nil, rpcruntime.ContextError(ctx, err)
 	}
 	if resp == nil {
 		return nil, errors.New("rpccgo: message response is nil")
@@ -368,12 +465,26 @@ func (s *greeterChatConnectRemoteMessageStreamSession) CloseSend(ctx context.Con
 	return s.stream.CloseRequest()
 }
 
-// InvokeGreeterNativeSayHello invokes the current registered server using the native contract for SayHello.
+// InvokeGreeterNativeSayHello invokes the server selected by the service route using the native contract for SayHello.
 func InvokeGreeterNativeSayHello(ctx context.Context, name *rpcruntime.RpcString, city *rpcruntime.RpcString) (string, error) {
-	registered, err := rpcruntime.LoadServer(greeterServiceID)
+	var messageResult string
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteUnary(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.SayHello",
+		Contract:  rpcruntime.CallContractNative,
+		Operation: rpcruntime.CallOperationUnary,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		messageResult, callErr = invokeGreeterNativeSayHello(ctx, registered, name, city)
+		return callErr
+	})
 	if err != nil {
 		return "", err
 	}
+	return messageResult, nil
+}
+
+func invokeGreeterNativeSayHello(ctx context.Context, registered rpcruntime.RegisteredServer, name *rpcruntime.RpcString, city *rpcruntime.RpcString) (string, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindGoNative:
 		server, ok := registered.Server.(GreeterNativeServer)
@@ -411,7 +522,9 @@ func InvokeGreeterNativeSayHello(ctx context.Context, name *rpcruntime.RpcString
 			return "", err
 		}
 		var messageResp *SayHelloResponse
-		messageResp, err = server.SayHello(ctx, messageReq)
+		callCtx, finishMetadata := rpcruntime.ConnectHandlerContext(ctx, "/examples.connect.greeter.v1.Greeter/SayHello")
+		messageResp, err = server.SayHello(callCtx, messageReq)
+		finishMetadata()
 		if err != nil {
 			return "", err
 		}
@@ -433,7 +546,9 @@ func InvokeGreeterNativeSayHello(ctx context.Context, name *rpcruntime.RpcString
 			return "", err
 		}
 		var messageResp *SayHelloResponse
-		messageResp, err = server.SayHello(ctx, messageReq)
+		callCtx, finishMetadata := rpcruntime.ConnectClientContext(ctx)
+		messageResp, err = server.SayHello(callCtx, messageReq)
+		finishMetadata()
 		if err != nil {
 			return "", err
 		}
@@ -446,19 +561,33 @@ func InvokeGreeterNativeSayHello(ctx context.Context, name *rpcruntime.RpcString
 		}
 		return convertGreeterSayHelloMessageToNativeResponse(messageResp)
 	default:
-		return "", err
+		return "", fmt.Errorf("rpccgo: Greeter registered server kind %d is unsupported for native calls", registered.Kind)
 	}
 }
 
-// InvokeGreeterMessageSayHello invokes the current registered server using the message contract for SayHello.
+// InvokeGreeterMessageSayHello invokes the server selected by the service route using the message contract for SayHello.
 func InvokeGreeterMessageSayHello(ctx context.Context, req *SayHelloRequest) (*SayHelloResponse, error) {
 	if req == nil {
 		return nil, errors.New("rpccgo: message request is nil")
 	}
-	registered, err := rpcruntime.LoadServer(greeterServiceID)
+	var resp *SayHelloResponse
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteUnary(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.SayHello",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationUnary,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		resp, callErr = invokeGreeterMessageSayHello(ctx, registered, req)
+		return callErr
+	})
 	if err != nil {
 		return nil, err
 	}
+	return resp, nil
+}
+
+func invokeGreeterMessageSayHello(ctx context.Context, registered rpcruntime.RegisteredServer, req *SayHelloRequest) (*SayHelloResponse, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindGoNative:
 		server, ok := registered.Server.(GreeterNativeServer)
@@ -508,7 +637,9 @@ func InvokeGreeterMessageSayHello(ctx context.Context, req *SayHelloRequest) (*S
 		if !ok {
 			return nil, fmt.Errorf("rpccgo: Greeter connect handler registered server has invalid type")
 		}
-		messageResp, err := server.SayHello(ctx, req)
+		callCtx, finishMetadata := rpcruntime.ConnectHandlerContext(ctx, "/examples.connect.greeter.v1.Greeter/SayHello")
+		messageResp, err := server.SayHello(callCtx, req)
+		finishMetadata()
 		if err != nil {
 			return nil, err
 		}
@@ -521,7 +652,9 @@ func InvokeGreeterMessageSayHello(ctx context.Context, req *SayHelloRequest) (*S
 		if !ok {
 			return nil, fmt.Errorf("rpccgo: Greeter connect remote registered server has invalid type")
 		}
-		messageResp, err := server.SayHello(ctx, req)
+		callCtx, finishMetadata := rpcruntime.ConnectClientContext(ctx)
+		messageResp, err := server.SayHello(callCtx, req)
+		finishMetadata()
 		if err != nil {
 			return nil, err
 		}
@@ -534,12 +667,26 @@ func InvokeGreeterMessageSayHello(ctx context.Context, req *SayHelloRequest) (*S
 	}
 }
 
-// GreeterNativeCollectStart starts a native contract stream for Collect on the current registered server.
+// GreeterNativeCollectStart starts a native contract stream for Collect on the server selected by the service route.
 func GreeterNativeCollectStart(ctx context.Context) (rpcruntime.StreamHandle, error) {
-	registered, err := rpcruntime.LoadServer(greeterServiceID)
+	var streamHandle rpcruntime.StreamHandle
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Collect",
+		Contract:  rpcruntime.CallContractNative,
+		Operation: rpcruntime.CallOperationStart,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		streamHandle, callErr = greeterNativeCollectStart(ctx, registered)
+		return callErr
+	})
 	if err != nil {
 		return 0, err
 	}
+	return streamHandle, nil
+}
+
+func greeterNativeCollectStart(ctx context.Context, registered rpcruntime.RegisteredServer) (rpcruntime.StreamHandle, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindGoNative:
 		server, ok := registered.Server.(GreeterNativeServer)
@@ -550,7 +697,7 @@ func GreeterNativeCollectStart(ctx context.Context) (rpcruntime.StreamHandle, er
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGoNative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGoNative, source)
 	case rpcruntime.ServerKindCGONative:
 		server, ok := registered.Server.(GreeterNativeServer)
 		if !ok {
@@ -560,7 +707,7 @@ func GreeterNativeCollectStart(ctx context.Context) (rpcruntime.StreamHandle, er
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGONative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGONative, source)
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(GreeterCGOMessageServer)
 		if !ok {
@@ -570,14 +717,14 @@ func GreeterNativeCollectStart(ctx context.Context) (rpcruntime.StreamHandle, er
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)
 	case rpcruntime.ServerKindConnect:
 		server, ok := registered.Server.(GreeterHandler)
 		if !ok {
 			return 0, fmt.Errorf("rpccgo: Greeter connect handler registered server has invalid type")
 		}
 		source := newgreeterCollectConnectDirectMessageStreamSession(ctx, server)
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnect, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnect, source)
 	case rpcruntime.ServerKindConnectRemote:
 		server, ok := registered.Server.(GreeterClient)
 		if !ok {
@@ -587,18 +734,32 @@ func GreeterNativeCollectStart(ctx context.Context) (rpcruntime.StreamHandle, er
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnectRemote, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnectRemote, source)
 	default:
 		return 0, fmt.Errorf("rpccgo: Greeter registered server kind %d is unsupported for native stream starts", registered.Kind)
 	}
 }
 
-// GreeterMessageCollectStart starts a message contract stream for Collect on the current registered server.
+// GreeterMessageCollectStart starts a message contract stream for Collect on the server selected by the service route.
 func GreeterMessageCollectStart(ctx context.Context) (rpcruntime.StreamHandle, error) {
-	registered, err := rpcruntime.LoadServer(greeterServiceID)
+	var streamHandle rpcruntime.StreamHandle
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Collect",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationStart,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		streamHandle, callErr = greeterMessageCollectStart(ctx, registered)
+		return callErr
+	})
 	if err != nil {
 		return 0, err
 	}
+	return streamHandle, nil
+}
+
+func greeterMessageCollectStart(ctx context.Context, registered rpcruntime.RegisteredServer) (rpcruntime.StreamHandle, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindGoNative:
 		server, ok := registered.Server.(GreeterNativeServer)
@@ -609,7 +770,7 @@ func GreeterMessageCollectStart(ctx context.Context) (rpcruntime.StreamHandle, e
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGoNative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGoNative, source)
 	case rpcruntime.ServerKindCGONative:
 		server, ok := registered.Server.(GreeterNativeServer)
 		if !ok {
@@ -619,7 +780,7 @@ func GreeterMessageCollectStart(ctx context.Context) (rpcruntime.StreamHandle, e
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGONative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGONative, source)
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(GreeterCGOMessageServer)
 		if !ok {
@@ -629,14 +790,14 @@ func GreeterMessageCollectStart(ctx context.Context) (rpcruntime.StreamHandle, e
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)
 	case rpcruntime.ServerKindConnect:
 		server, ok := registered.Server.(GreeterHandler)
 		if !ok {
 			return 0, fmt.Errorf("rpccgo: Greeter connect handler registered server has invalid type")
 		}
 		source := newgreeterCollectConnectDirectMessageStreamSession(ctx, server)
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnect, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnect, source)
 	case rpcruntime.ServerKindConnectRemote:
 		server, ok := registered.Server.(GreeterClient)
 		if !ok {
@@ -646,18 +807,32 @@ func GreeterMessageCollectStart(ctx context.Context) (rpcruntime.StreamHandle, e
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnectRemote, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnectRemote, source)
 	default:
 		return 0, fmt.Errorf("rpccgo: Greeter registered server kind %d is unsupported for message stream starts", registered.Kind)
 	}
 }
 
-// GreeterNativeBroadcastStart starts a native contract stream for Broadcast on the current registered server.
+// GreeterNativeBroadcastStart starts a native contract stream for Broadcast on the server selected by the service route.
 func GreeterNativeBroadcastStart(ctx context.Context, name *rpcruntime.RpcString, city *rpcruntime.RpcString) (rpcruntime.StreamHandle, error) {
-	registered, err := rpcruntime.LoadServer(greeterServiceID)
+	var streamHandle rpcruntime.StreamHandle
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Broadcast",
+		Contract:  rpcruntime.CallContractNative,
+		Operation: rpcruntime.CallOperationStart,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		streamHandle, callErr = greeterNativeBroadcastStart(ctx, registered, name, city)
+		return callErr
+	})
 	if err != nil {
 		return 0, err
 	}
+	return streamHandle, nil
+}
+
+func greeterNativeBroadcastStart(ctx context.Context, registered rpcruntime.RegisteredServer, name *rpcruntime.RpcString, city *rpcruntime.RpcString) (rpcruntime.StreamHandle, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindGoNative:
 		server, ok := registered.Server.(GreeterNativeServer)
@@ -668,7 +843,7 @@ func GreeterNativeBroadcastStart(ctx context.Context, name *rpcruntime.RpcString
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGoNative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGoNative, source)
 	case rpcruntime.ServerKindCGONative:
 		server, ok := registered.Server.(GreeterNativeServer)
 		if !ok {
@@ -678,7 +853,7 @@ func GreeterNativeBroadcastStart(ctx context.Context, name *rpcruntime.RpcString
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGONative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGONative, source)
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(GreeterCGOMessageServer)
 		if !ok {
@@ -692,7 +867,7 @@ func GreeterNativeBroadcastStart(ctx context.Context, name *rpcruntime.RpcString
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)
 	case rpcruntime.ServerKindConnect:
 		server, ok := registered.Server.(GreeterHandler)
 		if !ok {
@@ -706,7 +881,7 @@ func GreeterNativeBroadcastStart(ctx context.Context, name *rpcruntime.RpcString
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnect, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnect, source)
 	case rpcruntime.ServerKindConnectRemote:
 		server, ok := registered.Server.(GreeterClient)
 		if !ok {
@@ -720,21 +895,35 @@ func GreeterNativeBroadcastStart(ctx context.Context, name *rpcruntime.RpcString
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnectRemote, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnectRemote, source)
 	default:
 		return 0, fmt.Errorf("rpccgo: Greeter registered server kind %d is unsupported for native stream starts", registered.Kind)
 	}
 }
 
-// GreeterMessageBroadcastStart starts a message contract stream for Broadcast on the current registered server.
+// GreeterMessageBroadcastStart starts a message contract stream for Broadcast on the server selected by the service route.
 func GreeterMessageBroadcastStart(ctx context.Context, req *SayHelloRequest) (rpcruntime.StreamHandle, error) {
 	if req == nil {
 		return 0, errors.New("rpccgo: message request is nil")
 	}
-	registered, err := rpcruntime.LoadServer(greeterServiceID)
+	var streamHandle rpcruntime.StreamHandle
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Broadcast",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationStart,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		streamHandle, callErr = greeterMessageBroadcastStart(ctx, registered, req)
+		return callErr
+	})
 	if err != nil {
 		return 0, err
 	}
+	return streamHandle, nil
+}
+
+func greeterMessageBroadcastStart(ctx context.Context, registered rpcruntime.RegisteredServer, req *SayHelloRequest) (rpcruntime.StreamHandle, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindGoNative:
 		server, ok := registered.Server.(GreeterNativeServer)
@@ -750,7 +939,7 @@ func GreeterMessageBroadcastStart(ctx context.Context, req *SayHelloRequest) (rp
 			return 0, err
 		}
 		goruntime.KeepAlive(reqOwner)
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGoNative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGoNative, source)
 	case rpcruntime.ServerKindCGONative:
 		server, ok := registered.Server.(GreeterNativeServer)
 		if !ok {
@@ -765,7 +954,7 @@ func GreeterMessageBroadcastStart(ctx context.Context, req *SayHelloRequest) (rp
 			return 0, err
 		}
 		goruntime.KeepAlive(reqOwner)
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGONative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGONative, source)
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(GreeterCGOMessageServer)
 		if !ok {
@@ -775,7 +964,7 @@ func GreeterMessageBroadcastStart(ctx context.Context, req *SayHelloRequest) (rp
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)
 	case rpcruntime.ServerKindConnect:
 		server, ok := registered.Server.(GreeterHandler)
 		if !ok {
@@ -785,7 +974,7 @@ func GreeterMessageBroadcastStart(ctx context.Context, req *SayHelloRequest) (rp
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnect, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnect, source)
 	case rpcruntime.ServerKindConnectRemote:
 		server, ok := registered.Server.(GreeterClient)
 		if !ok {
@@ -795,18 +984,32 @@ func GreeterMessageBroadcastStart(ctx context.Context, req *SayHelloRequest) (rp
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnectRemote, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnectRemote, source)
 	default:
 		return 0, fmt.Errorf("rpccgo: Greeter registered server kind %d is unsupported for message stream starts", registered.Kind)
 	}
 }
 
-// GreeterNativeChatStart starts a native contract stream for Chat on the current registered server.
+// GreeterNativeChatStart starts a native contract stream for Chat on the server selected by the service route.
 func GreeterNativeChatStart(ctx context.Context) (rpcruntime.StreamHandle, error) {
-	registered, err := rpcruntime.LoadServer(greeterServiceID)
+	var streamHandle rpcruntime.StreamHandle
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Chat",
+		Contract:  rpcruntime.CallContractNative,
+		Operation: rpcruntime.CallOperationStart,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		streamHandle, callErr = greeterNativeChatStart(ctx, registered)
+		return callErr
+	})
 	if err != nil {
 		return 0, err
 	}
+	return streamHandle, nil
+}
+
+func greeterNativeChatStart(ctx context.Context, registered rpcruntime.RegisteredServer) (rpcruntime.StreamHandle, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindGoNative:
 		server, ok := registered.Server.(GreeterNativeServer)
@@ -817,7 +1020,7 @@ func GreeterNativeChatStart(ctx context.Context) (rpcruntime.StreamHandle, error
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGoNative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGoNative, source)
 	case rpcruntime.ServerKindCGONative:
 		server, ok := registered.Server.(GreeterNativeServer)
 		if !ok {
@@ -827,7 +1030,7 @@ func GreeterNativeChatStart(ctx context.Context) (rpcruntime.StreamHandle, error
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGONative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGONative, source)
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(GreeterCGOMessageServer)
 		if !ok {
@@ -837,14 +1040,14 @@ func GreeterNativeChatStart(ctx context.Context) (rpcruntime.StreamHandle, error
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)
 	case rpcruntime.ServerKindConnect:
 		server, ok := registered.Server.(GreeterHandler)
 		if !ok {
 			return 0, fmt.Errorf("rpccgo: Greeter connect handler registered server has invalid type")
 		}
 		source := newgreeterChatConnectDirectMessageStreamSession(ctx, server)
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnect, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnect, source)
 	case rpcruntime.ServerKindConnectRemote:
 		server, ok := registered.Server.(GreeterClient)
 		if !ok {
@@ -854,18 +1057,32 @@ func GreeterNativeChatStart(ctx context.Context) (rpcruntime.StreamHandle, error
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnectRemote, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnectRemote, source)
 	default:
 		return 0, fmt.Errorf("rpccgo: Greeter registered server kind %d is unsupported for native stream starts", registered.Kind)
 	}
 }
 
-// GreeterMessageChatStart starts a message contract stream for Chat on the current registered server.
+// GreeterMessageChatStart starts a message contract stream for Chat on the server selected by the service route.
 func GreeterMessageChatStart(ctx context.Context) (rpcruntime.StreamHandle, error) {
-	registered, err := rpcruntime.LoadServer(greeterServiceID)
+	var streamHandle rpcruntime.StreamHandle
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Chat",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationStart,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		streamHandle, callErr = greeterMessageChatStart(ctx, registered)
+		return callErr
+	})
 	if err != nil {
 		return 0, err
 	}
+	return streamHandle, nil
+}
+
+func greeterMessageChatStart(ctx context.Context, registered rpcruntime.RegisteredServer) (rpcruntime.StreamHandle, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindGoNative:
 		server, ok := registered.Server.(GreeterNativeServer)
@@ -876,7 +1093,7 @@ func GreeterMessageChatStart(ctx context.Context) (rpcruntime.StreamHandle, erro
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGoNative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGoNative, source)
 	case rpcruntime.ServerKindCGONative:
 		server, ok := registered.Server.(GreeterNativeServer)
 		if !ok {
@@ -886,7 +1103,7 @@ func GreeterMessageChatStart(ctx context.Context) (rpcruntime.StreamHandle, erro
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGONative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGONative, source)
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(GreeterCGOMessageServer)
 		if !ok {
@@ -896,14 +1113,14 @@ func GreeterMessageChatStart(ctx context.Context) (rpcruntime.StreamHandle, erro
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)
 	case rpcruntime.ServerKindConnect:
 		server, ok := registered.Server.(GreeterHandler)
 		if !ok {
 			return 0, fmt.Errorf("rpccgo: Greeter connect handler registered server has invalid type")
 		}
 		source := newgreeterChatConnectDirectMessageStreamSession(ctx, server)
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnect, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnect, source)
 	case rpcruntime.ServerKindConnectRemote:
 		server, ok := registered.Server.(GreeterClient)
 		if !ok {
@@ -913,7 +1130,7 @@ func GreeterMessageChatStart(ctx context.Context) (rpcruntime.StreamHandle, erro
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnectRemote, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnectRemote, source)
 	default:
 		return 0, fmt.Errorf("rpccgo: Greeter registered server kind %d is unsupported for message stream starts", registered.Kind)
 	}
@@ -32,10 +32,23 @@ func GreeterMessageCollectSend(ctx context.Context, handle rpcruntime.StreamHand
 	if req == nil {
 		return errors.New("rpccgo: message request is nil")
 	}
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Collect",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationSend,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return greeterMessageCollectSend(ctx, handle, entry, req)
+	})
+}
+
+func greeterMessageCollectSend(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession, req *SayHelloRequest) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindGoNative:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[GreeterCollectNativeStreamRequest, GreeterCollectNativeStreamResponse])
@@ -86,10 +99,30 @@ func GreeterMessageCollectSend(ctx context.Context, handle rpcruntime.StreamHand
 
 // GreeterMessageCollectFinish finishes an active message Collect stream and releases its handle.
 func GreeterMessageCollectFinish(ctx context.Context, handle rpcruntime.StreamHandle) (*SayHelloResponse, error) {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return nil, err
 	}
+	var resp *SayHelloResponse
+	err = rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Collect",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationFinish,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		var callErr error
+		resp, callErr = greeterMessageCollectFinish(ctx, handle, entry)
+		return callErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func greeterMessageCollectFinish(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) (*SayHelloResponse, error) {
 	switch entry.Kind {
 	case rpcruntime.ServerKindGoNative:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[GreeterCollectNativeStreamRequest, GreeterCollectNativeStreamResponse])
@@ -172,10 +205,23 @@ func GreeterMessageCollectFinish(ctx context.Context, handle rpcruntime.StreamHa
 
 // GreeterMessageCollectCancel cancels an active message Collect stream and releases its handle.
 func GreeterMessageCollectCancel(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Collect",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationCancel,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return greeterMessageCollectCancel(ctx, handle, entry)
+	})
+}
+
+func greeterMessageCollectCancel(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindGoNative:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[GreeterCollectNativeStreamRequest, GreeterCollectNativeStreamResponse])
@@ -185,7 +231,7 @@ func GreeterMessageCollectCancel(ctx context.Context, handle rpcruntime.StreamHa
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindCGONative:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[GreeterCollectNativeStreamRequest, GreeterCollectNativeStreamResponse])
@@ -195,7 +241,7 @@ func GreeterMessageCollectCancel(ctx context.Context, handle rpcruntime.StreamHa
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[*SayHelloRequest, *SayHelloResponse])
@@ -205,7 +251,7 @@ func GreeterMessageCollectCancel(ctx context.Context, handle rpcruntime.StreamHa
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnect:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[*SayHelloRequest, *SayHelloResponse])
@@ -215,7 +261,7 @@ func GreeterMessageCollectCancel(ctx context.Context, handle rpcruntime.StreamHa
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnectRemote:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[*SayHelloRequest, *SayHelloResponse])
@@ -225,7 +271,7 @@ func GreeterMessageCollectCancel(ctx context.Context, handle rpcruntime.StreamHa
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	default:
 		return fmt.Errorf("rpccgo: Greeter message stream session kind %d is unsupported", entry.Kind)
@@ -234,10 +280,30 @@ func GreeterMessageCollectCancel(ctx context.Context, handle rpcruntime.StreamHa
 
 // GreeterMessageBroadcastRecv receives a message response from an active Broadcast stream.
 func GreeterMessageBroadcastRecv(ctx context.Context, handle rpcruntime.StreamHandle) (*SayHelloResponse, error) {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
+	if err != nil {
+		return nil, err
+	}
+	var resp *SayHelloResponse
+	err = rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Broadcast",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationRecv,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		var callErr error
+		resp, callErr = greeterMessageBroadcastRecv(ctx, handle, entry)
+		return callErr
+	})
 	if err != nil {
 		return nil, err
 	}
+	return resp, nil
+}
+
+func greeterMessageBroadcastRecv(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) (*SayHelloResponse, error) {
 	switch entry.Kind {
 	case rpcruntime.ServerKindGoNative:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[GreeterBroadcastNativeStreamResponse])
@@ -350,10 +416,23 @@ func GreeterMessageBroadcastRecv(ctx context.Context, handle rpcruntime.StreamHa
 
 // GreeterMessageBroadcastCancel cancels an active message Broadcast stream and releases its handle.
 func GreeterMessageBroadcastCancel(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Broadcast",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationCancel,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return greeterMessageBroadcastCancel(ctx, handle, entry)
+	})
+}
+
+func greeterMessageBroadcastCancel(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindGoNative:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[GreeterBroadcastNativeStreamResponse])
@@ -363,7 +442,7 @@ func GreeterMessageBroadcastCancel(ctx context.Context, handle rpcruntime.Stream
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindCGONative:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[GreeterBroadcastNativeStreamResponse])
@@ -373,7 +452,7 @@ func GreeterMessageBroadcastCancel(ctx context.Context, handle rpcruntime.Stream
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*SayHelloResponse])
@@ -383,7 +462,7 @@ func GreeterMessageBroadcastCancel(ctx context.Context, handle rpcruntime.Stream
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnect:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*SayHelloResponse])
@@ -393,7 +472,7 @@ func GreeterMessageBroadcastCancel(ctx context.Context, handle rpcruntime.Stream
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnectRemote:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*SayHelloResponse])
@@ -403,7 +482,7 @@ func GreeterMessageBroadcastCancel(ctx context.Context, handle rpcruntime.Stream
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	default:
 		return fmt.Errorf("rpccgo: Greeter message stream session kind %d is unsupported", entry.Kind)
@@ -415,10 +494,23 @@ func GreeterMessageChatSend(ctx context.Context, handle rpcruntime.StreamHandle,
 	if req == nil {
 		return errors.New("rpccgo: message request is nil")
 	}
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Chat",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationSend,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return greeterMessageChatSend(ctx, handle, entry, req)
+	})
+}
+
+func greeterMessageChatSend(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession, req *SayHelloRequest) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindGoNative:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[GreeterChatNativeStreamRequest, GreeterChatNativeStreamResponse])
@@ -469,10 +561,30 @@ func GreeterMessageChatSend(ctx context.Context, handle rpcruntime.StreamHandle,
 
 // GreeterMessageChatRecv receives a message response from an active Chat stream.
 func GreeterMessageChatRecv(ctx context.Context, handle rpcruntime.StreamHandle) (*SayHelloResponse, error) {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
+	if err != nil {
+		return nil, err
+	}
+	var resp *SayHelloResponse
+	err = rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Chat",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationRecv,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		var callErr error
+		resp, callErr = greeterMessageChatRecv(ctx, handle, entry)
+		return callErr
+	})
 	if err != nil {
 		return nil, err
 	}
+	return resp, nil
+}
+
+func greeterMessageChatRecv(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) (*SayHelloResponse, error) {
 	switch entry.Kind {
 	case rpcruntime.ServerKindGoNative:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[GreeterChatNativeStreamRequest, GreeterChatNativeStreamResponse])
@@ -540,10 +652,23 @@ func GreeterMessageChatRecv(ctx context.Context, handle rpcruntime.StreamHandle)
 
 // GreeterMessageChatCloseSend closes the message send side of an active Chat stream.
 func GreeterMessageChatCloseSend(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Chat",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationCloseSend,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return greeterMessageChatCloseSend(ctx, handle, entry)
+	})
+}
+
+func greeterMessageChatCloseSend(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindGoNative:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[GreeterChatNativeStreamRequest, GreeterChatNativeStreamResponse])
@@ -582,10 +707,23 @@ func GreeterMessageChatCloseSend(ctx context.Context, handle rpcruntime.StreamHa
 
 // GreeterMessageChatFinish finishes an active message Chat stream and releases its handle.
 func GreeterMessageChatFinish(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Chat",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationFinish,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return greeterMessageChatFinish(ctx, handle, entry)
+	})
+}
+
+func greeterMessageChatFinish(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindGoNative:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[GreeterChatNativeStreamRequest, GreeterChatNativeStreamResponse])
@@ -595,7 +733,7 @@ func GreeterMessageChatFinish(ctx context.Context, handle rpcruntime.StreamHandl
 		if err := source.Finish(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindCGONative:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[GreeterChatNativeStreamRequest, GreeterChatNativeStreamResponse])
@@ -605,7 +743,7 @@ func GreeterMessageChatFinish(ctx context.Context, handle rpcruntime.StreamHandl
 		if err := source.Finish(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*SayHelloRequest, *SayHelloResponse])
@@ -615,7 +753,7 @@ func GreeterMessageChatFinish(ctx context.Context, handle rpcruntime.StreamHandl
 		if err := source.Finish(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnect:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*SayHelloRequest, *SayHelloResponse])
@@ -625,7 +763,7 @@ func GreeterMessageChatFinish(ctx context.Context, handle rpcruntime.StreamHandl
 		if err := source.Finish(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnectRemote:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*SayHelloRequest, *SayHelloResponse])
@@ -635,7 +773,7 @@ func GreeterMessageChatFinish(ctx context.Context, handle rpcruntime.StreamHandl
 		if err := source.Finish(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	default:
 		return fmt.Errorf("rpccgo: Greeter message stream session kind %d is unsupported", entry.Kind)
@@ -644,10 +782,23 @@ func GreeterMessageChatFinish(ctx context.Context, handle rpcruntime.StreamHandl
 
 // GreeterMessageChatCancel cancels an active message Chat stream and releases its handle.
 func GreeterMessageChatCancel(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Chat",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationCancel,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return greeterMessageChatCancel(ctx, handle, entry)
+	})
+}
+
+func greeterMessageChatCancel(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindGoNative:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[GreeterChatNativeStreamRequest, GreeterChatNativeStreamResponse])
@@ -657,7 +808,7 @@ func GreeterMessageChatCancel(ctx context.Context, handle rpcruntime.StreamHandl
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindCGONative:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[GreeterChatNativeStreamRequest, GreeterChatNativeStreamResponse])
@@ -667,7 +818,7 @@ func GreeterMessageChatCancel(ctx context.Context, handle rpcruntime.StreamHandl
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*SayHelloRequest, *SayHelloResponse])
@@ -677,7 +828,7 @@ func GreeterMessageChatCancel(ctx context.Context, handle rpcruntime.StreamHandl
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnect:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*SayHelloRequest, *SayHelloResponse])
@@ -687,7 +838,7 @@ func GreeterMessageChatCancel(ctx context.Context, handle rpcruntime.StreamHandl
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnectRemote:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*SayHelloRequest, *SayHelloResponse])
@@ -697,7 +848,7 @@ func GreeterMessageChatCancel(ctx context.Context, handle rpcruntime.StreamHandl
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	default:
 		return fmt.Errorf("rpccgo: Greeter message stream session kind %d is unsupported", entry.Kind)
@@ -729,25 +880,30 @@ func (UnimplementedGreeterCGOMessageServer) Chat(ctx context.Context, stream rpc
 
 // RegisterGreeterCGOMessageServer registers a cgo message server as the current server for Greeter.
 func RegisterGreeterCGOMessageServer(server GreeterCGOMessageServer) error {
+	return RegisterGreeterCGOMessageServerIn(rpcruntime.DefaultServerRegistry(), server)
+}
+
+// RegisterGreeterCGOMessageServerIn registers a cgo message server as the current server for Greeter in registry.
+func RegisterGreeterCGOMessageServerIn(registry *rpcruntime.ServerRegistry, server GreeterCGOMessageServer) error {
 	if server == nil {
-		_ = registerGreeterCGOMessageServer(server)
+		_ = registerGreeterCGOMessageServerIn(registry, server)
 		return errors.New("rpccgo: Greeter cgo message server is nil")
 	}
-	return registerGreeterCGOMessageServer(server)
+	return registerGreeterCGOMessageServerIn(registry, server)
 }
 
-// registerGreeterCGOMessageServer registers the supplied cgo message server as the current server for this service.
-func registerGreeterCGOMessageServer(server GreeterCGOMessageServer) error {
+// registerGreeterCGOMessageServerIn registers the supplied cgo message server as the current server for this service in registry.
+func registerGreeterCGOMessageServerIn(registry *rpcruntime.ServerRegistry, server GreeterCGOMessageServer) error {
 	if server == nil {
-		_ = rpcruntime.ClearServer(greeterServiceID)
+		_ = registry.Clear(greeterServiceID)
 		return GreeterMessageServerUnavailableErr
 	}
-	err := rpcruntime.RegisterServer(greeterServiceID, rpcruntime.RegisteredServer{
+	err := registry.Register(greeterServiceID, rpcruntime.RegisteredServer{
 		Kind:   rpcruntime.ServerKindCGOMessage,
 		Server: server,
 	})
 	if err != nil {
-		_ = rpcruntime.ClearServer(greeterServiceID)
+		_ = registry.Clear(greeterServiceID)
 		return err
 	}
 	return nil
@@ -755,11 +911,13 @@ func registerGreeterCGOMessageServer(server GreeterCGOMessageServer) error {
 
 func greeterCollectCGOMessageStart(ctx context.Context, server GreeterCGOMessageServer) (rpcruntime.ClientStreamingClient[*SayHelloRequest, *SayHelloResponse], error) {
 	client, stream, streamCtx := rpcruntime.NewClientStreaming[*SayHelloRequest, *SayHelloResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:        "examples.connect.greeter.v1.Greeter.Collect",
 		RequestBuffer: 16,
 		StreamClosed:  errors.New("rpccgo: message stream is closed"),
 		NilRequest:    errors.New("rpccgo: message request is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.connect.greeter.v1.Greeter.Collect handler", func(err error) { stream.Complete(nil, err) })
 		resp, err := server.Collect(streamCtx, stream)
 		stream.Complete(resp, err)
 	}()
@@ -776,11 +934,13 @@ func greeterBroadcastCGOMessageStart(ctx context.Context, server GreeterCGOMessa
 		return direct.BroadcastStart(ctx, req)
 	}
 	client, stream, streamCtx := rpcruntime.NewServerStreaming[*SayHelloResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:         "examples.connect.greeter.v1.Greeter.Broadcast",
 		ResponseBuffer: 1,
 		StreamClosed:   errors.New("rpccgo: message stream is closed"),
 		NilResponse:    errors.New("rpccgo: message response is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.connect.greeter.v1.Greeter.Broadcast handler", func(err error) { stream.Complete(err) })
 		err := server.Broadcast(streamCtx, req, stream)
 		stream.Complete(err)
 	}()
@@ -789,6 +949,7 @@ func greeterBroadcastCGOMessageStart(ctx context.Context, server GreeterCGOMessa
 
 func greeterChatCGOMessageStart(ctx context.Context, server GreeterCGOMessageServer) (rpcruntime.BidiStreamingClient[*SayHelloRequest, *SayHelloResponse], error) {
 	client, stream, streamCtx := rpcruntime.NewBidiStreaming[*SayHelloRequest, *SayHelloResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:         "examples.connect.greeter.v1.Greeter.Chat",
 		RequestBuffer:  16,
 		ResponseBuffer: 1,
 		StreamClosed:   errors.New("rpccgo: message stream is closed"),
@@ -796,6 +957,7 @@ func greeterChatCGOMessageStart(ctx context.Context, server GreeterCGOMessageSer
 		NilResponse:    errors.New("rpccgo: message response is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.connect.greeter.v1.Greeter.Chat handler", func(err error) { stream.Complete(err) })
 		err := server.Chat(streamCtx, stream)
 		stream.Complete(err)
 	}()
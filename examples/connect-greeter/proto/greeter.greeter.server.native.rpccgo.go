@@ -116,10 +116,23 @@ func (s *greeterChatGoNativeBidiStreamingServer) Send(ctx context.Context, messa
 
 // GreeterNativeCollectSend sends native request values on an active Collect stream.
 func GreeterNativeCollectSend(ctx context.Context, handle rpcruntime.StreamHandle, name *rpcruntime.RpcString, city *rpcruntime.RpcString) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Collect",
+		Contract:  rpcruntime.CallContractNative,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationSend,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return greeterNativeCollectSend(ctx, handle, entry, name, city)
+	})
+}
+
+func greeterNativeCollectSend(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession, name *rpcruntime.RpcString, city *rpcruntime.RpcString) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindGoNative:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[GreeterCollectNativeStreamRequest, GreeterCollectNativeStreamResponse])
@@ -170,10 +183,30 @@ func GreeterNativeCollectSend(ctx context.Context, handle rpcruntime.StreamHandl
 
 // GreeterNativeCollectFinish finishes an active native Collect stream and releases its handle.
 func GreeterNativeCollectFinish(ctx context.Context, handle rpcruntime.StreamHandle) (string, error) {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return "", rpcruntime.ErrStreamInvalidHandle
 	}
+	var messageResult string
+	err = rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Collect",
+		Contract:  rpcruntime.CallContractNative,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationFinish,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		var callErr error
+		messageResult, callErr = greeterNativeCollectFinish(ctx, handle, entry)
+		return callErr
+	})
+	if err != nil {
+		return "", err
+	}
+	return messageResult, nil
+}
+
+func greeterNativeCollectFinish(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) (string, error) {
 	switch entry.Kind {
 	case rpcruntime.ServerKindGoNative:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[GreeterCollectNativeStreamRequest, GreeterCollectNativeStreamResponse])
@@ -241,16 +274,29 @@ func GreeterNativeCollectFinish(ctx context.Context, handle rpcruntime.StreamHan
 		}
 		return convertGreeterCollectMessageToNativeResponse(messageResp)
 	default:
-		return "", err
+		return "", fmt.Errorf("rpccgo: Greeter native stream session kind %d is unsupported", entry.Kind)
 	}
 }
 
 // GreeterNativeCollectCancel cancels an active native Collect stream and releases its handle.
 func GreeterNativeCollectCancel(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Collect",
+		Contract:  rpcruntime.CallContractNative,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationCancel,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return greeterNativeCollectCancel(ctx, handle, entry)
+	})
+}
+
+func greeterNativeCollectCancel(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindGoNative:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[GreeterCollectNativeStreamRequest, GreeterCollectNativeStreamResponse])
@@ -260,7 +306,7 @@ func GreeterNativeCollectCancel(ctx context.Context, handle rpcruntime.StreamHan
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindCGONative:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[GreeterCollectNativeStreamRequest, GreeterCollectNativeStreamResponse])
@@ -270,7 +316,7 @@ func GreeterNativeCollectCancel(ctx context.Context, handle rpcruntime.StreamHan
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[*SayHelloRequest, *SayHelloResponse])
@@ -280,7 +326,7 @@ func GreeterNativeCollectCancel(ctx context.Context, handle rpcruntime.StreamHan
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnect:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[*SayHelloRequest, *SayHelloResponse])
@@ -290,7 +336,7 @@ func GreeterNativeCollectCancel(ctx context.Context, handle rpcruntime.StreamHan
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnectRemote:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[*SayHelloRequest, *SayHelloResponse])
@@ -300,7 +346,7 @@ func GreeterNativeCollectCancel(ctx context.Context, handle rpcruntime.StreamHan
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	default:
 		return fmt.Errorf("rpccgo: Greeter native stream session kind %d is unsupported", entry.Kind)
@@ -309,10 +355,30 @@ func GreeterNativeCollectCancel(ctx context.Context, handle rpcruntime.StreamHan
 
 // GreeterNativeBroadcastRecv receives native response values from an active Broadcast stream.
 func GreeterNativeBroadcastRecv(ctx context.Context, handle rpcruntime.StreamHandle) (string, error) {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return "", rpcruntime.ErrStreamInvalidHandle
 	}
+	var messageResult string
+	err = rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Broadcast",
+		Contract:  rpcruntime.CallContractNative,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationRecv,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		var callErr error
+		messageResult, callErr = greeterNativeBroadcastRecv(ctx, handle, entry)
+		return callErr
+	})
+	if err != nil {
+		return "", err
+	}
+	return messageResult, nil
+}
+
+func greeterNativeBroadcastRecv(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) (string, error) {
 	switch entry.Kind {
 	case rpcruntime.ServerKindGoNative:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[GreeterBroadcastNativeStreamResponse])
@@ -410,16 +476,29 @@ func GreeterNativeBroadcastRecv(ctx context.Context, handle rpcruntime.StreamHan
 		}
 		return convertGreeterBroadcastMessageToNativeResponse(messageResp)
 	default:
-		return "", err
+		return "", fmt.Errorf("rpccgo: Greeter native stream session kind %d is unsupported", entry.Kind)
 	}
 }
 
 // GreeterNativeBroadcastCancel cancels an active native Broadcast stream and releases its handle.
 func GreeterNativeBroadcastCancel(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Broadcast",
+		Contract:  rpcruntime.CallContractNative,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationCancel,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return greeterNativeBroadcastCancel(ctx, handle, entry)
+	})
+}
+
+func greeterNativeBroadcastCancel(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindGoNative:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[GreeterBroadcastNativeStreamResponse])
@@ -429,7 +508,7 @@ func GreeterNativeBroadcastCancel(ctx context.Context, handle rpcruntime.StreamH
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindCGONative:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[GreeterBroadcastNativeStreamResponse])
@@ -439,7 +518,7 @@ func GreeterNativeBroadcastCancel(ctx context.Context, handle rpcruntime.StreamH
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*SayHelloResponse])
@@ -449,7 +528,7 @@ func GreeterNativeBroadcastCancel(ctx context.Context, handle rpcruntime.StreamH
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnect:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*SayHelloResponse])
@@ -459,7 +538,7 @@ func GreeterNativeBroadcastCancel(ctx context.Context, handle rpcruntime.StreamH
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnectRemote:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*SayHelloResponse])
@@ -469,7 +548,7 @@ func GreeterNativeBroadcastCancel(ctx context.Context, handle rpcruntime.StreamH
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	default:
 		return fmt.Errorf("rpccgo: Greeter native stream session kind %d is unsupported", entry.Kind)
@@ -478,10 +557,23 @@ func GreeterNativeBroadcastCancel(ctx context.Context, handle rpcruntime.StreamH
 
 // GreeterNativeChatSend sends native request values on an active Chat stream.
 func GreeterNativeChatSend(ctx context.Context, handle rpcruntime.StreamHandle, name *rpcruntime.RpcString, city *rpcruntime.RpcString) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Chat",
+		Contract:  rpcruntime.CallContractNative,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationSend,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return greeterNativeChatSend(ctx, handle, entry, name, city)
+	})
+}
+
+func greeterNativeChatSend(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession, name *rpcruntime.RpcString, city *rpcruntime.RpcString) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindGoNative:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[GreeterChatNativeStreamRequest, GreeterChatNativeStreamResponse])
@@ -532,10 +624,30 @@ func GreeterNativeChatSend(ctx context.Context, handle rpcruntime.StreamHandle,
 
 // GreeterNativeChatRecv receives native response values from an active Chat stream.
 func GreeterNativeChatRecv(ctx context.Context, handle rpcruntime.StreamHandle) (string, error) {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return "", rpcruntime.ErrStreamInvalidHandle
 	}
+	var messageResult string
+	err = rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Chat",
+		Contract:  rpcruntime.CallContractNative,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationRecv,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		var callErr error
+		messageResult, callErr = greeterNativeChatRecv(ctx, handle, entry)
+		return callErr
+	})
+	if err != nil {
+		return "", err
+	}
+	return messageResult, nil
+}
+
+func greeterNativeChatRecv(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) (string, error) {
 	switch entry.Kind {
 	case rpcruntime.ServerKindGoNative:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[GreeterChatNativeStreamRequest, GreeterChatNativeStreamResponse])
@@ -588,16 +700,29 @@ func GreeterNativeChatRecv(ctx context.Context, handle rpcruntime.StreamHandle)
 		}
 		return convertGreeterChatMessageToNativeResponse(messageResp)
 	default:
-		return "", err
+		return "", fmt.Errorf("rpccgo: Greeter native stream session kind %d is unsupported", entry.Kind)
 	}
 }
 
 // GreeterNativeChatCloseSend closes the native send side of an active Chat stream.
 func GreeterNativeChatCloseSend(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Chat",
+		Contract:  rpcruntime.CallContractNative,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationCloseSend,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return greeterNativeChatCloseSend(ctx, handle, entry)
+	})
+}
+
+func greeterNativeChatCloseSend(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindGoNative:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[GreeterChatNativeStreamRequest, GreeterChatNativeStreamResponse])
@@ -636,10 +761,23 @@ func GreeterNativeChatCloseSend(ctx context.Context, handle rpcruntime.StreamHan
 
 // GreeterNativeChatFinish finishes an active native Chat stream and releases its handle.
 func GreeterNativeChatFinish(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Chat",
+		Contract:  rpcruntime.CallContractNative,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationFinish,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return greeterNativeChatFinish(ctx, handle, entry)
+	})
+}
+
+func greeterNativeChatFinish(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindGoNative:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[GreeterChatNativeStreamRequest, GreeterChatNativeStreamResponse])
@@ -649,7 +787,7 @@ func GreeterNativeChatFinish(ctx context.Context, handle rpcruntime.StreamHandle
 		if err := source.Finish(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindCGONative:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[GreeterChatNativeStreamRequest, GreeterChatNativeStreamResponse])
@@ -659,7 +797,7 @@ func GreeterNativeChatFinish(ctx context.Context, handle rpcruntime.StreamHandle
 		if err := source.Finish(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*SayHelloRequest, *SayHelloResponse])
@@ -669,7 +807,7 @@ func GreeterNativeChatFinish(ctx context.Context, handle rpcruntime.StreamHandle
 		if err := source.Finish(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnect:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*SayHelloRequest, *SayHelloResponse])
@@ -679,7 +817,7 @@ func GreeterNativeChatFinish(ctx context.Context, handle rpcruntime.StreamHandle
 		if err := source.Finish(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnectRemote:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*SayHelloRequest, *SayHelloResponse])
@@ -689,7 +827,7 @@ func GreeterNativeChatFinish(ctx context.Context, handle rpcruntime.StreamHandle
 		if err := source.Finish(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	default:
 		return fmt.Errorf("rpccgo: Greeter native stream session kind %d is unsupported", entry.Kind)
@@ -698,10 +836,23 @@ func GreeterNativeChatFinish(ctx context.Context, handle rpcruntime.StreamHandle
 
 // GreeterNativeChatCancel cancels an active native Chat stream and releases its handle.
 func GreeterNativeChatCancel(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.connect.greeter.v1.Greeter.Chat",
+		Contract:  rpcruntime.CallContractNative,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationCancel,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return greeterNativeChatCancel(ctx, handle, entry)
+	})
+}
+
+func greeterNativeChatCancel(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindGoNative:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[GreeterChatNativeStreamRequest, GreeterChatNativeStreamResponse])
@@ -711,7 +862,7 @@ func GreeterNativeChatCancel(ctx context.Context, handle rpcruntime.StreamHandle
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindCGONative:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[GreeterChatNativeStreamRequest, GreeterChatNativeStreamResponse])
@@ -721,7 +872,7 @@ func GreeterNativeChatCancel(ctx context.Context, handle rpcruntime.StreamHandle
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*SayHelloRequest, *SayHelloResponse])
@@ -731,7 +882,7 @@ func GreeterNativeChatCancel(ctx context.Context, handle rpcruntime.StreamHandle
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnect:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*SayHelloRequest, *SayHelloResponse])
@@ -741,7 +892,7 @@ func GreeterNativeChatCancel(ctx context.Context, handle rpcruntime.StreamHandle
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnectRemote:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*SayHelloRequest, *SayHelloResponse])
@@ -751,7 +902,7 @@ func GreeterNativeChatCancel(ctx context.Context, handle rpcruntime.StreamHandle
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	default:
 		return fmt.Errorf("rpccgo: Greeter native stream session kind %d is unsupported", entry.Kind)
@@ -783,25 +934,30 @@ func (UnimplementedGreeterNativeServer) Chat(ctx context.Context, stream Greeter
 
 // RegisterGreeterGoNativeServer registers a Go native server as the current server for Greeter.
 func RegisterGreeterGoNativeServer(server GreeterNativeServer) error {
+	return RegisterGreeterGoNativeServerIn(rpcruntime.DefaultServerRegistry(), server)
+}
+
+// RegisterGreeterGoNativeServerIn registers a Go native server as the current server for Greeter in registry.
+func RegisterGreeterGoNativeServerIn(registry *rpcruntime.ServerRegistry, server GreeterNativeServer) error {
 	if server == nil {
-		_ = registerGreeterGoNativeServer(server)
+		_ = registerGreeterGoNativeServerIn(registry, server)
 		return errors.New("rpccgo: Greeter go native server is nil")
 	}
-	return registerGreeterGoNativeServer(server)
+	return registerGreeterGoNativeServerIn(registry, server)
 }
 
-// registerGreeterGoNativeServer registers the supplied go native server as the current server for this service.
-func registerGreeterGoNativeServer(server GreeterNativeServer) error {
+// registerGreeterGoNativeServerIn registers the supplied go native server as the current server for this service in registry.
+func registerGreeterGoNativeServerIn(registry *rpcruntime.ServerRegistry, server GreeterNativeServer) error {
 	if server == nil {
-		_ = rpcruntime.ClearServer(greeterServiceID)
+		_ = registry.Clear(greeterServiceID)
 		return GreeterNativeServerUnavailableErr
 	}
-	err := rpcruntime.RegisterServer(greeterServiceID, rpcruntime.RegisteredServer{
+	err := registry.Register(greeterServiceID, rpcruntime.RegisteredServer{
 		Kind:   rpcruntime.ServerKindGoNative,
 		Server: server,
 	})
 	if err != nil {
-		_ = rpcruntime.ClearServer(greeterServiceID)
+		_ = registry.Clear(greeterServiceID)
 		return err
 	}
 	return nil
@@ -809,16 +965,21 @@ func registerGreeterGoNativeServer(server GreeterNativeServer) error {
 
 // RegisterGreeterCGONativeServer registers the supplied cgo native server as the current server for this service.
 func RegisterGreeterCGONativeServer(server GreeterNativeServer) error {
+	return RegisterGreeterCGONativeServerIn(rpcruntime.DefaultServerRegistry(), server)
+}
+
+// RegisterGreeterCGONativeServerIn registers the supplied cgo native server as the current server for this service in registry.
+func RegisterGreeterCGONativeServerIn(registry *rpcruntime.ServerRegistry, server GreeterNativeServer) error {
 	if server == nil {
-		_ = rpcruntime.ClearServer(greeterServiceID)
+		_ = registry.Clear(greeterServiceID)
 		return GreeterNativeServerUnavailableErr
 	}
-	err := rpcruntime.RegisterServer(greeterServiceID, rpcruntime.RegisteredServer{
+	err := registry.Register(greeterServiceID, rpcruntime.RegisteredServer{
 		Kind:   rpcruntime.ServerKindCGONative,
 		Server: server,
 	})
 	if err != nil {
-		_ = rpcruntime.ClearServer(greeterServiceID)
+		_ = registry.Clear(greeterServiceID)
 		return err
 	}
 	return nil
@@ -826,11 +987,14 @@ func RegisterGreeterCGONativeServer(server GreeterNativeServer) error {
 
 func greeterCollectGoNativeStart(ctx context.Context, server GreeterNativeServer) (rpcruntime.ClientStreamingClient[GreeterCollectNativeStreamRequest, GreeterCollectNativeStreamResponse], error) {
 	client, stream, streamCtx := rpcruntime.NewClientStreaming[GreeterCollectNativeStreamRequest, GreeterCollectNativeStreamResponse](ctx, rpcruntime.LocalStreamOptions{
-		RequestBuffer: 16,
-		StreamClosed:  greeterNativeStreamClosed,
+		Method:           "examples.connect.greeter.v1.Greeter.Collect",
+		RequestBuffer:    16,
+		BorrowedRequests: true,
+		StreamClosed:     greeterNativeStreamClosed,
 	})
 	serverStream := &greeterCollectGoNativeClientStreamingServer{stream: stream}
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.connect.greeter.v1.Greeter.Collect handler", func(err error) { stream.Complete(GreeterCollectNativeStreamResponse{}, err) })
 		message, err := server.Collect(streamCtx, serverStream)
 		stream.Complete(GreeterCollectNativeStreamResponse{Message: message}, err)
 	}()
@@ -839,11 +1003,13 @@ func greeterCollectGoNativeStart(ctx context.Context, server GreeterNativeServer
 
 func greeterBroadcastGoNativeStart(ctx context.Context, server GreeterNativeServer, name *rpcruntime.RpcString, city *rpcruntime.RpcString) (rpcruntime.ServerStreamingClient[GreeterBroadcastNativeStreamResponse], error) {
 	client, stream, streamCtx := rpcruntime.NewServerStreaming[GreeterBroadcastNativeStreamResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:         "examples.connect.greeter.v1.Greeter.Broadcast",
 		ResponseBuffer: 1,
 		StreamClosed:   greeterNativeStreamClosed,
 	})
 	serverStream := &greeterBroadcastGoNativeServerStreamingServer{stream: stream}
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.connect.greeter.v1.Greeter.Broadcast handler", func(err error) { stream.Complete(err) })
 		err := server.Broadcast(streamCtx, name, city, serverStream)
 		stream.Complete(err)
 	}()
@@ -852,12 +1018,15 @@ func greeterBroadcastGoNativeStart(ctx context.Context, server GreeterNativeServ
 
 func greeterChatGoNativeStart(ctx context.Context, server GreeterNativeServer) (rpcruntime.BidiStreamingClient[GreeterChatNativeStreamRequest, GreeterChatNativeStreamResponse], error) {
 	client, stream, streamCtx := rpcruntime.NewBidiStreaming[GreeterChatNativeStreamRequest, GreeterChatNativeStreamResponse](ctx, rpcruntime.LocalStreamOptions{
-		RequestBuffer:  16,
-		ResponseBuffer: 1,
-		StreamClosed:   greeterNativeStreamClosed,
+		Method:           "examples.connect.greeter.v1.Greeter.Chat",
+		RequestBuffer:    16,
+		ResponseBuffer:   1,
+		BorrowedRequests: true,
+		StreamClosed:     greeterNativeStreamClosed,
 	})
 	serverStream := &greeterChatGoNativeBidiStreamingServer{stream: stream}
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.connect.greeter.v1.Greeter.Chat handler", func(err error) { stream.Complete(err) })
 		err := server.Chat(streamCtx, serverStream)
 		stream.Complete(err)
 	}()
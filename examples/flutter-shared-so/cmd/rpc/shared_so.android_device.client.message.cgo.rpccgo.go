@@ -23,6 +23,7 @@ import (
 	fmt "fmt"
 	io "io"
 	rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"
+	unsafe "unsafe"
 )
 
 // rpccgo message direct generated file for AndroidDevice cgo message client
@@ -30,8 +31,24 @@ import (
 // rpccgoMsgFluttersharedv1AndroidDeviceSetTorch invokes the message unary client entrypoint for examples.flutter.sharedso.v1.AndroidDevice.SetTorch.
 //
 //export rpccgoMsgFluttersharedv1AndroidDeviceSetTorch
-func rpccgoMsgFluttersharedv1AndroidDeviceSetTorch(requestPtr C.uintptr_t, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
-	ctx := context.Background()
+func rpccgoMsgFluttersharedv1AndroidDeviceSetTorch(requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	return rpccgoMsgFluttersharedv1AndroidDeviceSetTorchWithContext(context.Background(), requestPtr, requestLen, responsePtr, responseLen)
+}
+
+// rpccgoMsgFluttersharedv1AndroidDeviceSetTorchWithOptions is rpccgoMsgFluttersharedv1AndroidDeviceSetTorch bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1AndroidDeviceSetTorchWithOptions
+func rpccgoMsgFluttersharedv1AndroidDeviceSetTorchWithOptions(options C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1AndroidDeviceSetTorchWithContext(ctx, requestPtr, requestLen, responsePtr, responseLen)
+}
+
+func rpccgoMsgFluttersharedv1AndroidDeviceSetTorchWithContext(ctx context.Context, requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1AndroidDeviceSetTorch", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if responsePtr != nil {
 		*responsePtr = 0
 	}
@@ -58,11 +75,79 @@ func rpccgoMsgFluttersharedv1AndroidDeviceSetTorch(requestPtr C.uintptr_t, reque
 	return 0
 }
 
+// rpccgoMsgFluttersharedv1AndroidDeviceSetTorchInto is rpccgoMsgFluttersharedv1AndroidDeviceSetTorch marshaling the response into the caller buffer at bufferPtr instead of pinned memory, so there is nothing to release. responseLen receives the encoded length, or the length needed together with RPCCGO_ERR_BUFFER_TOO_SMALL; retrying repeats the call.
+//
+//export rpccgoMsgFluttersharedv1AndroidDeviceSetTorchInto
+func rpccgoMsgFluttersharedv1AndroidDeviceSetTorchInto(requestPtr unsafe.Pointer, requestLen C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, responseLen *C.int32_t) C.int32_t {
+	return rpccgoMsgFluttersharedv1AndroidDeviceSetTorchIntoWithContext(context.Background(), requestPtr, requestLen, bufferPtr, bufferCap, responseLen)
+}
+
+// rpccgoMsgFluttersharedv1AndroidDeviceSetTorchIntoWithOptions is rpccgoMsgFluttersharedv1AndroidDeviceSetTorchInto bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1AndroidDeviceSetTorchIntoWithOptions
+func rpccgoMsgFluttersharedv1AndroidDeviceSetTorchIntoWithOptions(options C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1AndroidDeviceSetTorchIntoWithContext(ctx, requestPtr, requestLen, bufferPtr, bufferCap, responseLen)
+}
+
+func rpccgoMsgFluttersharedv1AndroidDeviceSetTorchIntoWithContext(ctx context.Context, requestPtr unsafe.Pointer, requestLen C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1AndroidDeviceSetTorchInto", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
+	if responseLen != nil {
+		*responseLen = 0
+	}
+	if responseLen == nil {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: message client output pointer is nil")))
+	}
+	buffer, bufferErr := rpcruntime.NewOutputBuffer(bufferPtr, int32(bufferCap))
+	if bufferErr != nil {
+		return C.int32_t(rpcruntime.StoreError(bufferErr))
+	}
+	req := &proto.SetTorchRequest{}
+	if err := rpcruntime.DecodeMessage(uintptr(requestPtr), int32(requestLen), req); err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: message request decode failed: %w", err)))
+	}
+	resp, err := proto.InvokeAndroidDeviceMessageSetTorch(ctx, req)
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	_, encodeErr := buffer.PutMessage(resp)
+	if length, err := buffer.Len(); err == nil {
+		*responseLen = C.int32_t(length)
+	}
+	if encodeErr != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: message response encode failed: %w", encodeErr)))
+	}
+	return 0
+}
+
 // rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoStart starts the message server-streaming client entrypoint for examples.flutter.sharedso.v1.AndroidDevice.WatchAndroidEcho.
 //
 //export rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoStart
-func rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoStart(requestPtr C.uintptr_t, requestLen C.int32_t, handle *C.int32_t, onRecv C.AndroidDeviceRpccgoMessageOnRecvCallback, onDone C.AndroidDeviceRpccgoMessageOnDoneCallback) C.int32_t {
-	ctx := context.Background()
+func rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoStart(requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t, onRecv C.AndroidDeviceRpccgoMessageOnRecvCallback, onDone C.AndroidDeviceRpccgoMessageOnDoneCallback) C.int32_t {
+	return rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoStartWithContext(context.Background(), requestPtr, requestLen, handle, onRecv, onDone)
+}
+
+// rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoStartWithOptions is rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoStart bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoStartWithOptions
+func rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoStartWithOptions(options C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t, onRecv C.AndroidDeviceRpccgoMessageOnRecvCallback, onDone C.AndroidDeviceRpccgoMessageOnDoneCallback) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	status := rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoStartWithContext(ctx, requestPtr, requestLen, handle, onRecv, onDone)
+	if status != 0 {
+		cancel()
+	}
+	return status
+}
+
+func rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoStartWithContext(ctx context.Context, requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t, onRecv C.AndroidDeviceRpccgoMessageOnRecvCallback, onDone C.AndroidDeviceRpccgoMessageOnDoneCallback) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoStart", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if handle != nil {
 		*handle = 0
 	}
@@ -95,6 +180,12 @@ func rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoStart(requestPtr C.uin
 			return C.int32_t(rpcruntime.StoreError(err))
 		}
 		go func() {
+			defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.AndroidDevice.WatchAndroidEcho callback receive", func(err error) {
+				if callbackState.BeginDoneCallback() {
+					C.callAndroidDeviceRpccgoMessageOnDoneCallback(onDone, C.int32_t(int32(handleValue)), C.int32_t(int32(rpcruntime.StoreError(err))))
+					callbackState.EndDoneCallback()
+				}
+			})
 			for {
 				resp, err := source.Recv(context.Background())
 				if err != nil {
@@ -141,7 +232,23 @@ func rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoStart(requestPtr C.uin
 //
 //export rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoRecv
 func rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoRecv(handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoRecvWithContext(context.Background(), handle, responsePtr, responseLen)
+}
+
+// rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoRecvWithOptions is rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoRecv bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoRecvWithOptions
+func rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoRecvWithOptions(options C.int32_t, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoRecvWithContext(ctx, handle, responsePtr, responseLen)
+}
+
+func rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoRecvWithContext(ctx context.Context, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoRecv", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if responsePtr != nil {
 		*responsePtr = 0
 	}
@@ -172,7 +279,23 @@ func rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoRecv(handle C.int32_t,
 //
 //export rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoCancel
 func rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoCancel(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoCancelWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoCancelWithOptions is rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoCancel bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoCancelWithOptions
+func rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoCancelWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoCancelWithContext(ctx, handle)
+}
+
+func rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoCancelWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoCancel", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	callbackState, _ := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))
 	if callbackState != nil {
@@ -192,7 +315,23 @@ func rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoCancel(handle C.int32_
 //
 //export rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoClose
 func rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoClose(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoCloseWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoCloseWithOptions is rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoClose bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoCloseWithOptions
+func rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoCloseWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoCloseWithContext(ctx, handle)
+}
+
+func rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoCloseWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoClose", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	callbackState, err := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))
 	if err != nil {
@@ -210,7 +349,26 @@ func rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoClose(handle C.int32_t
 //
 //export rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoStart
 func rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoStart(handle *C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoStartWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoStartWithOptions is rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoStart bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoStartWithOptions
+func rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoStartWithOptions(options C.int32_t, handle *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	status := rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoStartWithContext(ctx, handle)
+	if status != 0 {
+		cancel()
+	}
+	return status
+}
+
+func rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoStartWithContext(ctx context.Context, handle *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoStart", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if handle != nil {
 		*handle = 0
 	}
@@ -228,8 +386,24 @@ func rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoStart(handle *C.int3
 // rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoSend sends a message request to the client-streaming client entrypoint for examples.flutter.sharedso.v1.AndroidDevice.CollectAndroidEcho.
 //
 //export rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoSend
-func rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoSend(handle C.int32_t, requestPtr C.uintptr_t, requestLen C.int32_t) C.int32_t {
-	ctx := context.Background()
+func rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoSend(handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) C.int32_t {
+	return rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoSendWithContext(context.Background(), handle, requestPtr, requestLen)
+}
+
+// rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoSendWithOptions is rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoSend bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoSendWithOptions
+func rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoSendWithOptions(options C.int32_t, handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoSendWithContext(ctx, handle, requestPtr, requestLen)
+}
+
+func rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoSendWithContext(ctx context.Context, handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoSend", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	req := &proto.AndroidEchoRequest{}
 	if err := rpcruntime.DecodeMessage(uintptr(requestPtr), int32(requestLen), req); err != nil {
@@ -245,7 +419,23 @@ func rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoSend(handle C.int32_
 //
 //export rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoFinish
 func rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoFinish(handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoFinishWithContext(context.Background(), handle, responsePtr, responseLen)
+}
+
+// rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoFinishWithOptions is rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoFinish bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoFinishWithOptions
+func rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoFinishWithOptions(options C.int32_t, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoFinishWithContext(ctx, handle, responsePtr, responseLen)
+}
+
+func rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoFinishWithContext(ctx context.Context, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoFinish", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if responsePtr != nil {
 		*responsePtr = 0
 	}
@@ -273,7 +463,23 @@ func rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoFinish(handle C.int3
 //
 //export rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoCancel
 func rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoCancel(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoCancelWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoCancelWithOptions is rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoCancel bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoCancelWithOptions
+func rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoCancelWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoCancelWithContext(ctx, handle)
+}
+
+func rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoCancelWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoCancel", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	err := proto.AndroidDeviceMessageCollectAndroidEchoCancel(ctx, rpcruntime.StreamHandle(handleValue))
 	if err != nil {
@@ -286,7 +492,26 @@ func rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoCancel(handle C.int3
 //
 //export rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoStart
 func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoStart(handle *C.int32_t, onRecv C.AndroidDeviceRpccgoMessageOnRecvCallback, onDone C.AndroidDeviceRpccgoMessageOnDoneCallback) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoStartWithContext(context.Background(), handle, onRecv, onDone)
+}
+
+// rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoStartWithOptions is rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoStart bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoStartWithOptions
+func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoStartWithOptions(options C.int32_t, handle *C.int32_t, onRecv C.AndroidDeviceRpccgoMessageOnRecvCallback, onDone C.AndroidDeviceRpccgoMessageOnDoneCallback) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	status := rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoStartWithContext(ctx, handle, onRecv, onDone)
+	if status != 0 {
+		cancel()
+	}
+	return status
+}
+
+func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoStartWithContext(ctx context.Context, handle *C.int32_t, onRecv C.AndroidDeviceRpccgoMessageOnRecvCallback, onDone C.AndroidDeviceRpccgoMessageOnDoneCallback) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoStart", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if handle != nil {
 		*handle = 0
 	}
@@ -315,6 +540,12 @@ func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoStart(handle *C.int32_t
 			return C.int32_t(rpcruntime.StoreError(err))
 		}
 		go func() {
+			defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.AndroidDevice.ChatAndroidEcho callback receive", func(err error) {
+				if callbackState.BeginDoneCallback() {
+					C.callAndroidDeviceRpccgoMessageOnDoneCallback(onDone, C.int32_t(int32(handleValue)), C.int32_t(int32(rpcruntime.StoreError(err))))
+					callbackState.EndDoneCallback()
+				}
+			})
 			for {
 				resp, err := source.Recv(context.Background())
 				if err != nil {
@@ -360,8 +591,24 @@ func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoStart(handle *C.int32_t
 // rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoSend sends a message request to the bidi-streaming client entrypoint for examples.flutter.sharedso.v1.AndroidDevice.ChatAndroidEcho.
 //
 //export rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoSend
-func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoSend(handle C.int32_t, requestPtr C.uintptr_t, requestLen C.int32_t) C.int32_t {
-	ctx := context.Background()
+func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoSend(handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) C.int32_t {
+	return rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoSendWithContext(context.Background(), handle, requestPtr, requestLen)
+}
+
+// rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoSendWithOptions is rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoSend bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoSendWithOptions
+func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoSendWithOptions(options C.int32_t, handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoSendWithContext(ctx, handle, requestPtr, requestLen)
+}
+
+func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoSendWithContext(ctx context.Context, handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoSend", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	req := &proto.AndroidEchoRequest{}
 	if err := rpcruntime.DecodeMessage(uintptr(requestPtr), int32(requestLen), req); err != nil {
@@ -377,7 +624,23 @@ func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoSend(handle C.int32_t,
 //
 //export rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoRecv
 func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoRecv(handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoRecvWithContext(context.Background(), handle, responsePtr, responseLen)
+}
+
+// rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoRecvWithOptions is rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoRecv bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoRecvWithOptions
+func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoRecvWithOptions(options C.int32_t, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoRecvWithContext(ctx, handle, responsePtr, responseLen)
+}
+
+func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoRecvWithContext(ctx context.Context, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoRecv", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if responsePtr != nil {
 		*responsePtr = 0
 	}
@@ -408,7 +671,23 @@ func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoRecv(handle C.int32_t,
 //
 //export rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCloseSend
 func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCloseSend(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCloseSendWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCloseSendWithOptions is rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCloseSend bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCloseSendWithOptions
+func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCloseSendWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCloseSendWithContext(ctx, handle)
+}
+
+func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCloseSendWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCloseSend", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	err := proto.AndroidDeviceMessageChatAndroidEchoCloseSend(ctx, rpcruntime.StreamHandle(handleValue))
 	if err != nil {
@@ -421,7 +700,23 @@ func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCloseSend(handle C.int3
 //
 //export rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoFinish
 func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoFinish(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoFinishWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoFinishWithOptions is rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoFinish bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoFinishWithOptions
+func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoFinishWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoFinishWithContext(ctx, handle)
+}
+
+func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoFinishWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoFinish", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	callbackState, _ := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))
 	if callbackState != nil {
@@ -441,7 +736,23 @@ func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoFinish(handle C.int32_t
 //
 //export rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCancel
 func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCancel(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCancelWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCancelWithOptions is rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCancel bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCancelWithOptions
+func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCancelWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCancelWithContext(ctx, handle)
+}
+
+func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCancelWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCancel", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	callbackState, _ := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))
 	if callbackState != nil {
@@ -461,7 +772,23 @@ func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCancel(handle C.int32_t
 //
 //export rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoClose
 func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoClose(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCloseWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCloseWithOptions is rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoClose bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCloseWithOptions
+func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCloseWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCloseWithContext(ctx, handle)
+}
+
+func rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoCloseWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoClose", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	callbackState, err := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))
 	if err != nil {
@@ -153,7 +153,9 @@ func (a *androidDeviceCGOMessageAdapter) SetTorch(ctx context.Context, req *prot
 	}
 	var responsePtr C.uintptr_t
 	var responseLen C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callAndroidDeviceSetTorchCGOMessageUnary(callback, C.uintptr_t(requestPtr), C.int32_t(requestLen), &responsePtr, &responseLen))
+	leaveTrace()
 	if errID != 0 {
 		return nil, androidDeviceCGOMessageServerError(errID)
 	}
@@ -189,7 +191,9 @@ func (a *androidDeviceCGOMessageAdapter) WatchAndroidEchoStart(ctx context.Conte
 		return nil, err
 	}
 	var stream C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callAndroidDeviceWatchAndroidEchoCGOMessageServerStreamStart(a.watchAndroidEchoStart, C.uintptr_t(requestPtr), C.int32_t(requestLen), &stream))
+	leaveTrace()
 	if errID != 0 {
 		return nil, androidDeviceCGOMessageServerError(errID)
 	}
@@ -275,7 +279,9 @@ func (a *androidDeviceCGOMessageAdapter) CollectAndroidEchoStart(ctx context.Con
 		return nil, errors.New("rpccgo: AndroidDevice.CollectAndroidEcho cgo message server method is not implemented")
 	}
 	var stream C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callAndroidDeviceCollectAndroidEchoCGOMessageClientStreamStart(a.collectAndroidEchoStart, &stream))
+	leaveTrace()
 	if errID != 0 {
 		return nil, androidDeviceCGOMessageServerError(errID)
 	}
@@ -365,7 +371,9 @@ func (a *androidDeviceCGOMessageAdapter) ChatAndroidEchoStart(ctx context.Contex
 		return nil, errors.New("rpccgo: AndroidDevice.ChatAndroidEcho cgo message server method is not implemented")
 	}
 	var stream C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callAndroidDeviceChatAndroidEchoCGOMessageBidiStreamStart(a.chatAndroidEchoStart, &stream))
+	leaveTrace()
 	if errID != 0 {
 		return nil, androidDeviceCGOMessageServerError(errID)
 	}
@@ -724,15 +732,12 @@ func androidDeviceCGOMessageServerError(errID int32) error {
 	if errID == 0 {
 		return nil
 	}
-	text, ptr, ok := rpcruntime.TakeErrorText(rpcruntime.ErrorID(errID))
+	statusErr, ok := rpcruntime.TakeError(rpcruntime.ErrorID(errID))
 	if ok {
-		if ptr != 0 {
-			defer rpcruntime.Release(ptr)
-		}
-		if string(text) == io.EOF.Error() {
+		if statusErr.Message == io.EOF.Error() {
 			return io.EOF
 		}
-		return errors.New(string(text))
+		return rpcruntime.ConnectError(statusErr)
 	}
 	return fmt.Errorf("rpccgo: cgo message server callback returned unknown error id %d", errID)
 }
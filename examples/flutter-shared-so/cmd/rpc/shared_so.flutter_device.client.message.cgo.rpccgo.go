@@ -23,6 +23,7 @@ import (
 	fmt "fmt"
 	io "io"
 	rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"
+	unsafe "unsafe"
 )
 
 // rpccgo message direct generated file for FlutterDevice cgo message client
@@ -30,8 +31,24 @@ import (
 // rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutter invokes the message unary client entrypoint for examples.flutter.sharedso.v1.FlutterDevice.DescribeFlutter.
 //
 //export rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutter
-func rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutter(requestPtr C.uintptr_t, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
-	ctx := context.Background()
+func rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutter(requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	return rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutterWithContext(context.Background(), requestPtr, requestLen, responsePtr, responseLen)
+}
+
+// rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutterWithOptions is rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutter bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutterWithOptions
+func rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutterWithOptions(options C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutterWithContext(ctx, requestPtr, requestLen, responsePtr, responseLen)
+}
+
+func rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutterWithContext(ctx context.Context, requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutter", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if responsePtr != nil {
 		*responsePtr = 0
 	}
@@ -58,11 +75,79 @@ func rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutter(requestPtr C.uintptr_t
 	return 0
 }
 
+// rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutterInto is rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutter marshaling the response into the caller buffer at bufferPtr instead of pinned memory, so there is nothing to release. responseLen receives the encoded length, or the length needed together with RPCCGO_ERR_BUFFER_TOO_SMALL; retrying repeats the call.
+//
+//export rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutterInto
+func rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutterInto(requestPtr unsafe.Pointer, requestLen C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, responseLen *C.int32_t) C.int32_t {
+	return rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutterIntoWithContext(context.Background(), requestPtr, requestLen, bufferPtr, bufferCap, responseLen)
+}
+
+// rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutterIntoWithOptions is rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutterInto bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutterIntoWithOptions
+func rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutterIntoWithOptions(options C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutterIntoWithContext(ctx, requestPtr, requestLen, bufferPtr, bufferCap, responseLen)
+}
+
+func rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutterIntoWithContext(ctx context.Context, requestPtr unsafe.Pointer, requestLen C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1FlutterDeviceDescribeFlutterInto", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
+	if responseLen != nil {
+		*responseLen = 0
+	}
+	if responseLen == nil {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: message client output pointer is nil")))
+	}
+	buffer, bufferErr := rpcruntime.NewOutputBuffer(bufferPtr, int32(bufferCap))
+	if bufferErr != nil {
+		return C.int32_t(rpcruntime.StoreError(bufferErr))
+	}
+	req := &proto.FlutterEchoRequest{}
+	if err := rpcruntime.DecodeMessage(uintptr(requestPtr), int32(requestLen), req); err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: message request decode failed: %w", err)))
+	}
+	resp, err := proto.InvokeFlutterDeviceMessageDescribeFlutter(ctx, req)
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	_, encodeErr := buffer.PutMessage(resp)
+	if length, err := buffer.Len(); err == nil {
+		*responseLen = C.int32_t(length)
+	}
+	if encodeErr != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: message response encode failed: %w", encodeErr)))
+	}
+	return 0
+}
+
 // rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoStart starts the message server-streaming client entrypoint for examples.flutter.sharedso.v1.FlutterDevice.WatchFlutterEcho.
 //
 //export rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoStart
-func rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoStart(requestPtr C.uintptr_t, requestLen C.int32_t, handle *C.int32_t, onRecv C.FlutterDeviceRpccgoMessageOnRecvCallback, onDone C.FlutterDeviceRpccgoMessageOnDoneCallback) C.int32_t {
-	ctx := context.Background()
+func rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoStart(requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t, onRecv C.FlutterDeviceRpccgoMessageOnRecvCallback, onDone C.FlutterDeviceRpccgoMessageOnDoneCallback) C.int32_t {
+	return rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoStartWithContext(context.Background(), requestPtr, requestLen, handle, onRecv, onDone)
+}
+
+// rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoStartWithOptions is rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoStart bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoStartWithOptions
+func rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoStartWithOptions(options C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t, onRecv C.FlutterDeviceRpccgoMessageOnRecvCallback, onDone C.FlutterDeviceRpccgoMessageOnDoneCallback) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	status := rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoStartWithContext(ctx, requestPtr, requestLen, handle, onRecv, onDone)
+	if status != 0 {
+		cancel()
+	}
+	return status
+}
+
+func rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoStartWithContext(ctx context.Context, requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t, onRecv C.FlutterDeviceRpccgoMessageOnRecvCallback, onDone C.FlutterDeviceRpccgoMessageOnDoneCallback) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoStart", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if handle != nil {
 		*handle = 0
 	}
@@ -95,6 +180,12 @@ func rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoStart(requestPtr C.uin
 			return C.int32_t(rpcruntime.StoreError(err))
 		}
 		go func() {
+			defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.FlutterDevice.WatchFlutterEcho callback receive", func(err error) {
+				if callbackState.BeginDoneCallback() {
+					C.callFlutterDeviceRpccgoMessageOnDoneCallback(onDone, C.int32_t(int32(handleValue)), C.int32_t(int32(rpcruntime.StoreError(err))))
+					callbackState.EndDoneCallback()
+				}
+			})
 			for {
 				resp, err := source.Recv(context.Background())
 				if err != nil {
@@ -141,7 +232,23 @@ func rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoStart(requestPtr C.uin
 //
 //export rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoRecv
 func rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoRecv(handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoRecvWithContext(context.Background(), handle, responsePtr, responseLen)
+}
+
+// rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoRecvWithOptions is rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoRecv bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoRecvWithOptions
+func rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoRecvWithOptions(options C.int32_t, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoRecvWithContext(ctx, handle, responsePtr, responseLen)
+}
+
+func rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoRecvWithContext(ctx context.Context, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoRecv", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if responsePtr != nil {
 		*responsePtr = 0
 	}
@@ -172,7 +279,23 @@ func rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoRecv(handle C.int32_t,
 //
 //export rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoCancel
 func rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoCancel(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoCancelWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoCancelWithOptions is rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoCancel bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoCancelWithOptions
+func rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoCancelWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoCancelWithContext(ctx, handle)
+}
+
+func rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoCancelWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoCancel", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	callbackState, _ := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))
 	if callbackState != nil {
@@ -192,7 +315,23 @@ func rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoCancel(handle C.int32_
 //
 //export rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoClose
 func rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoClose(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoCloseWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoCloseWithOptions is rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoClose bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoCloseWithOptions
+func rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoCloseWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoCloseWithContext(ctx, handle)
+}
+
+func rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoCloseWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1FlutterDeviceWatchFlutterEchoClose", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	callbackState, err := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))
 	if err != nil {
@@ -119,7 +119,9 @@ func (a *flutterDeviceCGOMessageAdapter) DescribeFlutter(ctx context.Context, re
 	}
 	var responsePtr C.uintptr_t
 	var responseLen C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callFlutterDeviceDescribeFlutterCGOMessageUnary(callback, C.uintptr_t(requestPtr), C.int32_t(requestLen), &responsePtr, &responseLen))
+	leaveTrace()
 	if errID != 0 {
 		return nil, flutterDeviceCGOMessageServerError(errID)
 	}
@@ -155,7 +157,9 @@ func (a *flutterDeviceCGOMessageAdapter) WatchFlutterEchoStart(ctx context.Conte
 		return nil, err
 	}
 	var stream C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callFlutterDeviceWatchFlutterEchoCGOMessageServerStreamStart(a.watchFlutterEchoStart, C.uintptr_t(requestPtr), C.int32_t(requestLen), &stream))
+	leaveTrace()
 	if errID != 0 {
 		return nil, flutterDeviceCGOMessageServerError(errID)
 	}
@@ -337,15 +341,12 @@ func flutterDeviceCGOMessageServerError(errID int32) error {
 	if errID == 0 {
 		return nil
 	}
-	text, ptr, ok := rpcruntime.TakeErrorText(rpcruntime.ErrorID(errID))
+	statusErr, ok := rpcruntime.TakeError(rpcruntime.ErrorID(errID))
 	if ok {
-		if ptr != 0 {
-			defer rpcruntime.Release(ptr)
-		}
-		if string(text) == io.EOF.Error() {
+		if statusErr.Message == io.EOF.Error() {
 			return io.EOF
 		}
-		return errors.New(string(text))
+		return rpcruntime.ConnectError(statusErr)
 	}
 	return fmt.Errorf("rpccgo: cgo message server callback returned unknown error id %d", errID)
 }
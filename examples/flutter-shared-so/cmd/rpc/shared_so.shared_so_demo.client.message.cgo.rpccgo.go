@@ -23,6 +23,7 @@ import (
 	fmt "fmt"
 	io "io"
 	rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"
+	unsafe "unsafe"
 )
 
 // rpccgo message direct generated file for SharedSoDemo cgo message client
@@ -30,8 +31,24 @@ import (
 // rpccgoMsgFluttersharedv1SharedSoDemoComposeGreeting invokes the message unary client entrypoint for examples.flutter.sharedso.v1.SharedSoDemo.ComposeGreeting.
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoComposeGreeting
-func rpccgoMsgFluttersharedv1SharedSoDemoComposeGreeting(requestPtr C.uintptr_t, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
-	ctx := context.Background()
+func rpccgoMsgFluttersharedv1SharedSoDemoComposeGreeting(requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	return rpccgoMsgFluttersharedv1SharedSoDemoComposeGreetingWithContext(context.Background(), requestPtr, requestLen, responsePtr, responseLen)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoComposeGreetingWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoComposeGreeting bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoComposeGreetingWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoComposeGreetingWithOptions(options C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoComposeGreetingWithContext(ctx, requestPtr, requestLen, responsePtr, responseLen)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoComposeGreetingWithContext(ctx context.Context, requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoComposeGreeting", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if responsePtr != nil {
 		*responsePtr = 0
 	}
@@ -58,11 +75,76 @@ func rpccgoMsgFluttersharedv1SharedSoDemoComposeGreeting(requestPtr C.uintptr_t,
 	return 0
 }
 
+// rpccgoMsgFluttersharedv1SharedSoDemoComposeGreetingInto is rpccgoMsgFluttersharedv1SharedSoDemoComposeGreeting marshaling the response into the caller buffer at bufferPtr instead of pinned memory, so there is nothing to release. responseLen receives the encoded length, or the length needed together with RPCCGO_ERR_BUFFER_TOO_SMALL; retrying repeats the call.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoComposeGreetingInto
+func rpccgoMsgFluttersharedv1SharedSoDemoComposeGreetingInto(requestPtr unsafe.Pointer, requestLen C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, responseLen *C.int32_t) C.int32_t {
+	return rpccgoMsgFluttersharedv1SharedSoDemoComposeGreetingIntoWithContext(context.Background(), requestPtr, requestLen, bufferPtr, bufferCap, responseLen)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoComposeGreetingIntoWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoComposeGreetingInto bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoComposeGreetingIntoWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoComposeGreetingIntoWithOptions(options C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoComposeGreetingIntoWithContext(ctx, requestPtr, requestLen, bufferPtr, bufferCap, responseLen)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoComposeGreetingIntoWithContext(ctx context.Context, requestPtr unsafe.Pointer, requestLen C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoComposeGreetingInto", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
+	if responseLen != nil {
+		*responseLen = 0
+	}
+	if responseLen == nil {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: message client output pointer is nil")))
+	}
+	buffer, bufferErr := rpcruntime.NewOutputBuffer(bufferPtr, int32(bufferCap))
+	if bufferErr != nil {
+		return C.int32_t(rpcruntime.StoreError(bufferErr))
+	}
+	req := &proto.ComposeGreetingRequest{}
+	if err := rpcruntime.DecodeMessage(uintptr(requestPtr), int32(requestLen), req); err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: message request decode failed: %w", err)))
+	}
+	resp, err := proto.InvokeSharedSoDemoMessageComposeGreeting(ctx, req)
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	_, encodeErr := buffer.PutMessage(resp)
+	if length, err := buffer.Len(); err == nil {
+		*responseLen = C.int32_t(length)
+	}
+	if encodeErr != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: message response encode failed: %w", encodeErr)))
+	}
+	return 0
+}
+
 // rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeState invokes the message unary client entrypoint for examples.flutter.sharedso.v1.SharedSoDemo.IncrementRuntimeState.
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeState
-func rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeState(requestPtr C.uintptr_t, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
-	ctx := context.Background()
+func rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeState(requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	return rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeStateWithContext(context.Background(), requestPtr, requestLen, responsePtr, responseLen)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeStateWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeState bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeStateWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeStateWithOptions(options C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeStateWithContext(ctx, requestPtr, requestLen, responsePtr, responseLen)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeStateWithContext(ctx context.Context, requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeState", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if responsePtr != nil {
 		*responsePtr = 0
 	}
@@ -89,11 +171,76 @@ func rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeState(requestPtr C.uint
 	return 0
 }
 
+// rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeStateInto is rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeState marshaling the response into the caller buffer at bufferPtr instead of pinned memory, so there is nothing to release. responseLen receives the encoded length, or the length needed together with RPCCGO_ERR_BUFFER_TOO_SMALL; retrying repeats the call.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeStateInto
+func rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeStateInto(requestPtr unsafe.Pointer, requestLen C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, responseLen *C.int32_t) C.int32_t {
+	return rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeStateIntoWithContext(context.Background(), requestPtr, requestLen, bufferPtr, bufferCap, responseLen)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeStateIntoWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeStateInto bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeStateIntoWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeStateIntoWithOptions(options C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeStateIntoWithContext(ctx, requestPtr, requestLen, bufferPtr, bufferCap, responseLen)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeStateIntoWithContext(ctx context.Context, requestPtr unsafe.Pointer, requestLen C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeStateInto", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
+	if responseLen != nil {
+		*responseLen = 0
+	}
+	if responseLen == nil {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: message client output pointer is nil")))
+	}
+	buffer, bufferErr := rpcruntime.NewOutputBuffer(bufferPtr, int32(bufferCap))
+	if bufferErr != nil {
+		return C.int32_t(rpcruntime.StoreError(bufferErr))
+	}
+	req := &proto.IncrementRuntimeStateRequest{}
+	if err := rpcruntime.DecodeMessage(uintptr(requestPtr), int32(requestLen), req); err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: message request decode failed: %w", err)))
+	}
+	resp, err := proto.InvokeSharedSoDemoMessageIncrementRuntimeState(ctx, req)
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	_, encodeErr := buffer.PutMessage(resp)
+	if length, err := buffer.Len(); err == nil {
+		*responseLen = C.int32_t(length)
+	}
+	if encodeErr != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: message response encode failed: %w", encodeErr)))
+	}
+	return 0
+}
+
 // rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeState invokes the message unary client entrypoint for examples.flutter.sharedso.v1.SharedSoDemo.ReadRuntimeState.
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeState
-func rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeState(requestPtr C.uintptr_t, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
-	ctx := context.Background()
+func rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeState(requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	return rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeStateWithContext(context.Background(), requestPtr, requestLen, responsePtr, responseLen)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeStateWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeState bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeStateWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeStateWithOptions(options C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeStateWithContext(ctx, requestPtr, requestLen, responsePtr, responseLen)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeStateWithContext(ctx context.Context, requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeState", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if responsePtr != nil {
 		*responsePtr = 0
 	}
@@ -120,11 +267,79 @@ func rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeState(requestPtr C.uintptr_t
 	return 0
 }
 
+// rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeStateInto is rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeState marshaling the response into the caller buffer at bufferPtr instead of pinned memory, so there is nothing to release. responseLen receives the encoded length, or the length needed together with RPCCGO_ERR_BUFFER_TOO_SMALL; retrying repeats the call.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeStateInto
+func rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeStateInto(requestPtr unsafe.Pointer, requestLen C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, responseLen *C.int32_t) C.int32_t {
+	return rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeStateIntoWithContext(context.Background(), requestPtr, requestLen, bufferPtr, bufferCap, responseLen)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeStateIntoWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeStateInto bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeStateIntoWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeStateIntoWithOptions(options C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeStateIntoWithContext(ctx, requestPtr, requestLen, bufferPtr, bufferCap, responseLen)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeStateIntoWithContext(ctx context.Context, requestPtr unsafe.Pointer, requestLen C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeStateInto", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
+	if responseLen != nil {
+		*responseLen = 0
+	}
+	if responseLen == nil {
+		return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: message client output pointer is nil")))
+	}
+	buffer, bufferErr := rpcruntime.NewOutputBuffer(bufferPtr, int32(bufferCap))
+	if bufferErr != nil {
+		return C.int32_t(rpcruntime.StoreError(bufferErr))
+	}
+	req := &proto.ReadRuntimeStateRequest{}
+	if err := rpcruntime.DecodeMessage(uintptr(requestPtr), int32(requestLen), req); err != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: message request decode failed: %w", err)))
+	}
+	resp, err := proto.InvokeSharedSoDemoMessageReadRuntimeState(ctx, req)
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	_, encodeErr := buffer.PutMessage(resp)
+	if length, err := buffer.Len(); err == nil {
+		*responseLen = C.int32_t(length)
+	}
+	if encodeErr != nil {
+		return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: message response encode failed: %w", encodeErr)))
+	}
+	return 0
+}
+
 // rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateStart starts the message server-streaming client entrypoint for examples.flutter.sharedso.v1.SharedSoDemo.WatchRuntimeState.
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateStart
-func rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateStart(requestPtr C.uintptr_t, requestLen C.int32_t, handle *C.int32_t, onRecv C.SharedSoDemoRpccgoMessageOnRecvCallback, onDone C.SharedSoDemoRpccgoMessageOnDoneCallback) C.int32_t {
-	ctx := context.Background()
+func rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateStart(requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t, onRecv C.SharedSoDemoRpccgoMessageOnRecvCallback, onDone C.SharedSoDemoRpccgoMessageOnDoneCallback) C.int32_t {
+	return rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateStartWithContext(context.Background(), requestPtr, requestLen, handle, onRecv, onDone)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateStartWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateStart bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateStartWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateStartWithOptions(options C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t, onRecv C.SharedSoDemoRpccgoMessageOnRecvCallback, onDone C.SharedSoDemoRpccgoMessageOnDoneCallback) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	status := rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateStartWithContext(ctx, requestPtr, requestLen, handle, onRecv, onDone)
+	if status != 0 {
+		cancel()
+	}
+	return status
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateStartWithContext(ctx context.Context, requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t, onRecv C.SharedSoDemoRpccgoMessageOnRecvCallback, onDone C.SharedSoDemoRpccgoMessageOnDoneCallback) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateStart", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if handle != nil {
 		*handle = 0
 	}
@@ -157,6 +372,12 @@ func rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateStart(requestPtr C.uin
 			return C.int32_t(rpcruntime.StoreError(err))
 		}
 		go func() {
+			defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.SharedSoDemo.WatchRuntimeState callback receive", func(err error) {
+				if callbackState.BeginDoneCallback() {
+					C.callSharedSoDemoRpccgoMessageOnDoneCallback(onDone, C.int32_t(int32(handleValue)), C.int32_t(int32(rpcruntime.StoreError(err))))
+					callbackState.EndDoneCallback()
+				}
+			})
 			for {
 				resp, err := source.Recv(context.Background())
 				if err != nil {
@@ -203,7 +424,23 @@ func rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateStart(requestPtr C.uin
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateRecv
 func rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateRecv(handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateRecvWithContext(context.Background(), handle, responsePtr, responseLen)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateRecvWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateRecv bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateRecvWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateRecvWithOptions(options C.int32_t, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateRecvWithContext(ctx, handle, responsePtr, responseLen)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateRecvWithContext(ctx context.Context, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateRecv", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if responsePtr != nil {
 		*responsePtr = 0
 	}
@@ -234,7 +471,23 @@ func rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateRecv(handle C.int32_t,
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateCancel
 func rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateCancel(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateCancelWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateCancelWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateCancel bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateCancelWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateCancelWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateCancelWithContext(ctx, handle)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateCancelWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateCancel", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	callbackState, _ := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))
 	if callbackState != nil {
@@ -254,7 +507,23 @@ func rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateCancel(handle C.int32_
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateClose
 func rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateClose(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateCloseWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateCloseWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateClose bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateCloseWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateCloseWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateCloseWithContext(ctx, handle)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateCloseWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateClose", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	callbackState, err := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))
 	if err != nil {
@@ -272,7 +541,26 @@ func rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateClose(handle C.int32_t
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateStart
 func rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateStart(handle *C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateStartWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateStartWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateStart bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateStartWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateStartWithOptions(options C.int32_t, handle *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	status := rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateStartWithContext(ctx, handle)
+	if status != 0 {
+		cancel()
+	}
+	return status
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateStartWithContext(ctx context.Context, handle *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateStart", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if handle != nil {
 		*handle = 0
 	}
@@ -290,8 +578,24 @@ func rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateStart(handle *C.int3
 // rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateSend sends a message request to the client-streaming client entrypoint for examples.flutter.sharedso.v1.SharedSoDemo.CollectRuntimeState.
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateSend
-func rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateSend(handle C.int32_t, requestPtr C.uintptr_t, requestLen C.int32_t) C.int32_t {
-	ctx := context.Background()
+func rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateSend(handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) C.int32_t {
+	return rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateSendWithContext(context.Background(), handle, requestPtr, requestLen)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateSendWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateSend bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateSendWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateSendWithOptions(options C.int32_t, handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateSendWithContext(ctx, handle, requestPtr, requestLen)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateSendWithContext(ctx context.Context, handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateSend", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	req := &proto.IncrementRuntimeStateRequest{}
 	if err := rpcruntime.DecodeMessage(uintptr(requestPtr), int32(requestLen), req); err != nil {
@@ -307,7 +611,23 @@ func rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateSend(handle C.int32_
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateFinish
 func rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateFinish(handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateFinishWithContext(context.Background(), handle, responsePtr, responseLen)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateFinishWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateFinish bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateFinishWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateFinishWithOptions(options C.int32_t, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateFinishWithContext(ctx, handle, responsePtr, responseLen)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateFinishWithContext(ctx context.Context, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateFinish", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if responsePtr != nil {
 		*responsePtr = 0
 	}
@@ -335,7 +655,23 @@ func rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateFinish(handle C.int3
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateCancel
 func rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateCancel(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateCancelWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateCancelWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateCancel bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateCancelWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateCancelWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateCancelWithContext(ctx, handle)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateCancelWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateCancel", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	err := proto.SharedSoDemoMessageCollectRuntimeStateCancel(ctx, rpcruntime.StreamHandle(handleValue))
 	if err != nil {
@@ -347,8 +683,27 @@ func rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateCancel(handle C.int3
 // rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateStart starts the message server-streaming client entrypoint for examples.flutter.sharedso.v1.SharedSoDemo.StreamRuntimeState.
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateStart
-func rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateStart(requestPtr C.uintptr_t, requestLen C.int32_t, handle *C.int32_t, onRecv C.SharedSoDemoRpccgoMessageOnRecvCallback, onDone C.SharedSoDemoRpccgoMessageOnDoneCallback) C.int32_t {
-	ctx := context.Background()
+func rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateStart(requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t, onRecv C.SharedSoDemoRpccgoMessageOnRecvCallback, onDone C.SharedSoDemoRpccgoMessageOnDoneCallback) C.int32_t {
+	return rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateStartWithContext(context.Background(), requestPtr, requestLen, handle, onRecv, onDone)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateStartWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateStart bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateStartWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateStartWithOptions(options C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t, onRecv C.SharedSoDemoRpccgoMessageOnRecvCallback, onDone C.SharedSoDemoRpccgoMessageOnDoneCallback) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	status := rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateStartWithContext(ctx, requestPtr, requestLen, handle, onRecv, onDone)
+	if status != 0 {
+		cancel()
+	}
+	return status
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateStartWithContext(ctx context.Context, requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t, onRecv C.SharedSoDemoRpccgoMessageOnRecvCallback, onDone C.SharedSoDemoRpccgoMessageOnDoneCallback) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateStart", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if handle != nil {
 		*handle = 0
 	}
@@ -381,6 +736,12 @@ func rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateStart(requestPtr C.ui
 			return C.int32_t(rpcruntime.StoreError(err))
 		}
 		go func() {
+			defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.SharedSoDemo.StreamRuntimeState callback receive", func(err error) {
+				if callbackState.BeginDoneCallback() {
+					C.callSharedSoDemoRpccgoMessageOnDoneCallback(onDone, C.int32_t(int32(handleValue)), C.int32_t(int32(rpcruntime.StoreError(err))))
+					callbackState.EndDoneCallback()
+				}
+			})
 			for {
 				resp, err := source.Recv(context.Background())
 				if err != nil {
@@ -427,7 +788,23 @@ func rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateStart(requestPtr C.ui
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateRecv
 func rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateRecv(handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateRecvWithContext(context.Background(), handle, responsePtr, responseLen)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateRecvWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateRecv bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateRecvWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateRecvWithOptions(options C.int32_t, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateRecvWithContext(ctx, handle, responsePtr, responseLen)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateRecvWithContext(ctx context.Context, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateRecv", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if responsePtr != nil {
 		*responsePtr = 0
 	}
@@ -458,7 +835,23 @@ func rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateRecv(handle C.int32_t
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateCancel
 func rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateCancel(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateCancelWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateCancelWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateCancel bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateCancelWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateCancelWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateCancelWithContext(ctx, handle)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateCancelWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateCancel", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	callbackState, _ := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))
 	if callbackState != nil {
@@ -478,7 +871,23 @@ func rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateCancel(handle C.int32
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateClose
 func rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateClose(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateCloseWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateCloseWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateClose bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateCloseWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateCloseWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateCloseWithContext(ctx, handle)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateCloseWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateClose", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	callbackState, err := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))
 	if err != nil {
@@ -496,7 +905,26 @@ func rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateClose(handle C.int32_
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateStart
 func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateStart(handle *C.int32_t, onRecv C.SharedSoDemoRpccgoMessageOnRecvCallback, onDone C.SharedSoDemoRpccgoMessageOnDoneCallback) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateStartWithContext(context.Background(), handle, onRecv, onDone)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateStartWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateStart bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateStartWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateStartWithOptions(options C.int32_t, handle *C.int32_t, onRecv C.SharedSoDemoRpccgoMessageOnRecvCallback, onDone C.SharedSoDemoRpccgoMessageOnDoneCallback) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	status := rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateStartWithContext(ctx, handle, onRecv, onDone)
+	if status != 0 {
+		cancel()
+	}
+	return status
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateStartWithContext(ctx context.Context, handle *C.int32_t, onRecv C.SharedSoDemoRpccgoMessageOnRecvCallback, onDone C.SharedSoDemoRpccgoMessageOnDoneCallback) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateStart", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if handle != nil {
 		*handle = 0
 	}
@@ -525,6 +953,12 @@ func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateStart(handle *C.int32_t
 			return C.int32_t(rpcruntime.StoreError(err))
 		}
 		go func() {
+			defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.SharedSoDemo.ChatRuntimeState callback receive", func(err error) {
+				if callbackState.BeginDoneCallback() {
+					C.callSharedSoDemoRpccgoMessageOnDoneCallback(onDone, C.int32_t(int32(handleValue)), C.int32_t(int32(rpcruntime.StoreError(err))))
+					callbackState.EndDoneCallback()
+				}
+			})
 			for {
 				resp, err := source.Recv(context.Background())
 				if err != nil {
@@ -570,8 +1004,24 @@ func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateStart(handle *C.int32_t
 // rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateSend sends a message request to the bidi-streaming client entrypoint for examples.flutter.sharedso.v1.SharedSoDemo.ChatRuntimeState.
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateSend
-func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateSend(handle C.int32_t, requestPtr C.uintptr_t, requestLen C.int32_t) C.int32_t {
-	ctx := context.Background()
+func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateSend(handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) C.int32_t {
+	return rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateSendWithContext(context.Background(), handle, requestPtr, requestLen)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateSendWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateSend bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateSendWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateSendWithOptions(options C.int32_t, handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateSendWithContext(ctx, handle, requestPtr, requestLen)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateSendWithContext(ctx context.Context, handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateSend", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	req := &proto.IncrementRuntimeStateRequest{}
 	if err := rpcruntime.DecodeMessage(uintptr(requestPtr), int32(requestLen), req); err != nil {
@@ -587,7 +1037,23 @@ func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateSend(handle C.int32_t,
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateRecv
 func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateRecv(handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateRecvWithContext(context.Background(), handle, responsePtr, responseLen)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateRecvWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateRecv bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateRecvWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateRecvWithOptions(options C.int32_t, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateRecvWithContext(ctx, handle, responsePtr, responseLen)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateRecvWithContext(ctx context.Context, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateRecv", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	if responsePtr != nil {
 		*responsePtr = 0
 	}
@@ -618,7 +1084,23 @@ func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateRecv(handle C.int32_t,
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCloseSend
 func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCloseSend(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCloseSendWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCloseSendWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCloseSend bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCloseSendWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCloseSendWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCloseSendWithContext(ctx, handle)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCloseSendWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCloseSend", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	err := proto.SharedSoDemoMessageChatRuntimeStateCloseSend(ctx, rpcruntime.StreamHandle(handleValue))
 	if err != nil {
@@ -631,7 +1113,23 @@ func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCloseSend(handle C.int3
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateFinish
 func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateFinish(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateFinishWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateFinishWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateFinish bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateFinishWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateFinishWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateFinishWithContext(ctx, handle)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateFinishWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateFinish", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	callbackState, _ := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))
 	if callbackState != nil {
@@ -651,7 +1149,23 @@ func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateFinish(handle C.int32_t
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCancel
 func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCancel(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCancelWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCancelWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCancel bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCancelWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCancelWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCancelWithContext(ctx, handle)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCancelWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCancel", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	callbackState, _ := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))
 	if callbackState != nil {
@@ -671,7 +1185,23 @@ func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCancel(handle C.int32_t
 //
 //export rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateClose
 func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateClose(handle C.int32_t) C.int32_t {
-	ctx := context.Background()
+	return rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCloseWithContext(context.Background(), handle)
+}
+
+// rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCloseWithOptions is rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateClose bounded by the deadline and cancel token of a call options handle.
+//
+//export rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCloseWithOptions
+func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCloseWithOptions(options C.int32_t, handle C.int32_t) C.int32_t {
+	ctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))
+	if err != nil {
+		return C.int32_t(rpcruntime.StoreError(err))
+	}
+	defer cancel()
+	return rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCloseWithContext(ctx, handle)
+}
+
+func rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateCloseWithContext(ctx context.Context, handle C.int32_t) (errID C.int32_t) {
+	defer rpcruntime.RecoverPanic("rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateClose", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
 	handleValue := int32(handle)
 	callbackState, err := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))
 	if err != nil {
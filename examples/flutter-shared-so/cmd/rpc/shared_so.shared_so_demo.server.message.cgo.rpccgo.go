@@ -181,7 +181,9 @@ func (a *sharedSoDemoCGOMessageAdapter) ComposeGreeting(ctx context.Context, req
 	}
 	var responsePtr C.uintptr_t
 	var responseLen C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callSharedSoDemoComposeGreetingCGOMessageUnary(callback, C.uintptr_t(requestPtr), C.int32_t(requestLen), &responsePtr, &responseLen))
+	leaveTrace()
 	if errID != 0 {
 		return nil, sharedSoDemoCGOMessageServerError(errID)
 	}
@@ -219,7 +221,9 @@ func (a *sharedSoDemoCGOMessageAdapter) IncrementRuntimeState(ctx context.Contex
 	}
 	var responsePtr C.uintptr_t
 	var responseLen C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callSharedSoDemoIncrementRuntimeStateCGOMessageUnary(callback, C.uintptr_t(requestPtr), C.int32_t(requestLen), &responsePtr, &responseLen))
+	leaveTrace()
 	if errID != 0 {
 		return nil, sharedSoDemoCGOMessageServerError(errID)
 	}
@@ -257,7 +261,9 @@ func (a *sharedSoDemoCGOMessageAdapter) ReadRuntimeState(ctx context.Context, re
 	}
 	var responsePtr C.uintptr_t
 	var responseLen C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callSharedSoDemoReadRuntimeStateCGOMessageUnary(callback, C.uintptr_t(requestPtr), C.int32_t(requestLen), &responsePtr, &responseLen))
+	leaveTrace()
 	if errID != 0 {
 		return nil, sharedSoDemoCGOMessageServerError(errID)
 	}
@@ -293,7 +299,9 @@ func (a *sharedSoDemoCGOMessageAdapter) WatchRuntimeStateStart(ctx context.Conte
 		return nil, err
 	}
 	var stream C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callSharedSoDemoWatchRuntimeStateCGOMessageServerStreamStart(a.watchRuntimeStateStart, C.uintptr_t(requestPtr), C.int32_t(requestLen), &stream))
+	leaveTrace()
 	if errID != 0 {
 		return nil, sharedSoDemoCGOMessageServerError(errID)
 	}
@@ -379,7 +387,9 @@ func (a *sharedSoDemoCGOMessageAdapter) CollectRuntimeStateStart(ctx context.Con
 		return nil, errors.New("rpccgo: SharedSoDemo.CollectRuntimeState cgo message server method is not implemented")
 	}
 	var stream C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callSharedSoDemoCollectRuntimeStateCGOMessageClientStreamStart(a.collectRuntimeStateStart, &stream))
+	leaveTrace()
 	if errID != 0 {
 		return nil, sharedSoDemoCGOMessageServerError(errID)
 	}
@@ -486,7 +496,9 @@ func (a *sharedSoDemoCGOMessageAdapter) StreamRuntimeStateStart(ctx context.Cont
 		return nil, err
 	}
 	var stream C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callSharedSoDemoStreamRuntimeStateCGOMessageServerStreamStart(a.streamRuntimeStateStart, C.uintptr_t(requestPtr), C.int32_t(requestLen), &stream))
+	leaveTrace()
 	if errID != 0 {
 		return nil, sharedSoDemoCGOMessageServerError(errID)
 	}
@@ -572,7 +584,9 @@ func (a *sharedSoDemoCGOMessageAdapter) ChatRuntimeStateStart(ctx context.Contex
 		return nil, errors.New("rpccgo: SharedSoDemo.ChatRuntimeState cgo message server method is not implemented")
 	}
 	var stream C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callSharedSoDemoChatRuntimeStateCGOMessageBidiStreamStart(a.chatRuntimeStateStart, &stream))
+	leaveTrace()
 	if errID != 0 {
 		return nil, sharedSoDemoCGOMessageServerError(errID)
 	}
@@ -1024,15 +1038,12 @@ func sharedSoDemoCGOMessageServerError(errID int32) error {
 	if errID == 0 {
 		return nil
 	}
-	text, ptr, ok := rpcruntime.TakeErrorText(rpcruntime.ErrorID(errID))
+	statusErr, ok := rpcruntime.TakeError(rpcruntime.ErrorID(errID))
 	if ok {
-		if ptr != 0 {
-			defer rpcruntime.Release(ptr)
-		}
-		if string(text) == io.EOF.Error() {
+		if statusErr.Message == io.EOF.Error() {
 			return io.EOF
 		}
-		return errors.New(string(text))
+		return rpcruntime.ConnectError(statusErr)
 	}
 	return fmt.Errorf("rpccgo: cgo message server callback returned unknown error id %d", errID)
 }
@@ -3,6 +3,8 @@
 
 #include "rpccgo.jni.h"
 
+#include <map>
+#include <mutex>
 #include <utility>
 
 JavaVM* javaVM = nullptr;
@@ -117,9 +119,9 @@ jbyteArray rpccgoSuccessUnit(JNIEnv* env) {
     return rpccgoResult(env, true, {});
 }
 
-uintptr_t rpccgoVectorPtr(const std::vector<uint8_t>& data) {
-    if (data.empty()) { return 0; }
-    return reinterpret_cast<uintptr_t>(data.data());
+void* rpccgoVectorPtr(const std::vector<uint8_t>& data) {
+    if (data.empty()) { return nullptr; }
+    return const_cast<uint8_t*>(data.data());
 }
 
 std::string rpccgoErrorString(int32_t errID) {
@@ -203,3 +205,85 @@ int32_t rpccgoKotlinHandleResult(JNIEnv* env, jbyteArray result, int32_t* stream
     return 0;
 }
 
+struct rpccgoRegistrationListener {
+    jobject bridge;
+    jmethodID onEvent;
+};
+
+std::mutex rpccgoRegistrationMu;
+std::map<int32_t, rpccgoRegistrationListener> rpccgoRegistrationListeners;
+
+void rpccgoOnRegistrationEvent(int32_t watch, uintptr_t serviceIDPtr, int32_t serviceIDLen, int32_t oldKind, int32_t newKind, int32_t cleared, uint64_t seq) {
+    std::string serviceID;
+    if (serviceIDPtr != 0) {
+        serviceID.assign(reinterpret_cast<const char*>(serviceIDPtr), static_cast<size_t>(serviceIDLen));
+        rpccgoRelease(serviceIDPtr);
+    }
+    rpccgoJNIEnvScope envScope(nullptr);
+    JNIEnv* env = envScope.env;
+    if (env == nullptr) { return; }
+    jobject bridge = nullptr;
+    jmethodID onEvent = nullptr;
+    {
+        std::lock_guard<std::mutex> lock(rpccgoRegistrationMu);
+        auto it = rpccgoRegistrationListeners.find(watch);
+        if (it == rpccgoRegistrationListeners.end()) { return; }
+        bridge = env->NewLocalRef(it->second.bridge);
+        onEvent = it->second.onEvent;
+    }
+    if (bridge == nullptr) { return; }
+    jstring service = env->NewStringUTF(serviceID.c_str());
+    if (service != nullptr) {
+        env->CallVoidMethod(bridge, onEvent, service, static_cast<jint>(oldKind), static_cast<jint>(newKind), cleared != 0 ? JNI_TRUE : JNI_FALSE, static_cast<jlong>(seq));
+        env->DeleteLocalRef(service);
+    }
+    env->DeleteLocalRef(bridge);
+    if (env->ExceptionCheck()) { env->ExceptionClear(); }
+}
+
+void rpccgoOnRegistrationDone(int32_t watch) {
+    rpccgoJNIEnvScope envScope(nullptr);
+    JNIEnv* env = envScope.env;
+    std::lock_guard<std::mutex> lock(rpccgoRegistrationMu);
+    auto it = rpccgoRegistrationListeners.find(watch);
+    if (it == rpccgoRegistrationListeners.end()) { return; }
+    if (env != nullptr) { env->DeleteGlobalRef(it->second.bridge); }
+    rpccgoRegistrationListeners.erase(it);
+}
+
+// Java_com_ygrpc_examples_rpccgofluttersharedso_SharedSoDemoJni_rpccgoRegistrationWatch starts a registration watch that reports to a Kotlin bridge.
+extern "C" JNIEXPORT jbyteArray JNICALL Java_com_ygrpc_examples_rpccgofluttersharedso_SharedSoDemoJni_rpccgoRegistrationWatch(JNIEnv* env, jobject, jstring serviceID, jobject bridge) {
+    rpccgoJNIEnvScope envScope(env);
+    env = envScope.env;
+    if (env == nullptr) { return nullptr; }
+    if (serviceID == nullptr || bridge == nullptr) { return rpccgoErrorResult(env, "rpccgo: JNI registration watch arguments are null"); }
+    jclass bridgeClass = env->GetObjectClass(bridge);
+    jmethodID onEvent = env->GetMethodID(bridgeClass, "onEvent", "(Ljava/lang/String;IIZJ)V");
+    env->DeleteLocalRef(bridgeClass);
+    if (onEvent == nullptr) { return rpccgoErrorResult(env, "rpccgo: JNI registration bridge has no onEvent"); }
+    const char* chars = env->GetStringUTFChars(serviceID, nullptr);
+    if (chars == nullptr) { return rpccgoErrorResult(env, "rpccgo: JNI registration service id is unreadable"); }
+    std::string service(chars);
+    env->ReleaseStringUTFChars(serviceID, chars);
+    jobject globalBridge = env->NewGlobalRef(bridge);
+    if (globalBridge == nullptr) { return rpccgoErrorResult(env, "rpccgo: JNI registration bridge reference failed"); }
+    std::lock_guard<std::mutex> lock(rpccgoRegistrationMu);
+    int32_t watch = 0;
+    int32_t errID = rpccgoRegistrationWatch(const_cast<char*>(service.data()), static_cast<int32_t>(service.size()), &watch, rpccgoOnRegistrationEvent, rpccgoOnRegistrationDone);
+    if (errID != 0) {
+        env->DeleteGlobalRef(globalBridge);
+        return rpccgoErrorIDResult(env, errID);
+    }
+    rpccgoRegistrationListeners[watch] = rpccgoRegistrationListener{globalBridge, onEvent};
+    return rpccgoSuccessHandle(env, watch);
+}
+
+// Java_com_ygrpc_examples_rpccgofluttersharedso_SharedSoDemoJni_rpccgoRegistrationUnwatch cancels a registration watch; its bridge is released once the watch delivers onDone.
+extern "C" JNIEXPORT jbyteArray JNICALL Java_com_ygrpc_examples_rpccgofluttersharedso_SharedSoDemoJni_rpccgoRegistrationUnwatch(JNIEnv* env, jobject, jint watch) {
+    rpccgoJNIEnvScope envScope(env);
+    env = envScope.env;
+    if (env == nullptr) { return nullptr; }
+    int32_t errID = rpccgoRegistrationUnwatch(static_cast<int32_t>(watch));
+    if (errID != 0) { return rpccgoErrorIDResult(env, errID); }
+    return rpccgoSuccessUnit(env);
+}
@@ -32,7 +32,7 @@ jbyteArray rpccgoErrorIDResult(JNIEnv* env, int32_t errID);
 jbyteArray rpccgoSuccessBytes(JNIEnv* env, uintptr_t responsePtr, int32_t responseLen);
 jbyteArray rpccgoSuccessHandle(JNIEnv* env, int32_t handle);
 jbyteArray rpccgoSuccessUnit(JNIEnv* env);
-uintptr_t rpccgoVectorPtr(const std::vector<uint8_t>& data);
+void* rpccgoVectorPtr(const std::vector<uint8_t>& data);
 std::string rpccgoErrorString(int32_t errID);
 int32_t rpccgoStoreErrorString(const std::string& message);
 int32_t rpccgoExceptionError(JNIEnv* env, const std::string& message);
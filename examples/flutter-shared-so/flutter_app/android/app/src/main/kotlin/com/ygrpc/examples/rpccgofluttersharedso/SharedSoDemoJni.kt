@@ -64,6 +64,58 @@ object SharedSoDemoJni {
         return stream
     }
 
+    /** Kind of the Go server registered for a service, in rpcruntime.ServerKind order. */
+    enum class RpccgoServerKind {
+        INVALID,
+        GO_NATIVE,
+        CGO_NATIVE,
+        CGO_MESSAGE,
+        CONNECT,
+        GRPC,
+        CONNECT_REMOTE,
+        GRPC_REMOTE;
+
+        companion object {
+            fun fromValue(value: Int): RpccgoServerKind = values().getOrElse(value) { INVALID }
+        }
+    }
+
+    /** Replacement or removal of the server registered for a service; seq orders concurrent events. */
+    data class RpccgoRegistrationEvent(
+        val serviceId: String,
+        val oldKind: RpccgoServerKind,
+        val newKind: RpccgoServerKind,
+        val cleared: Boolean,
+        val seq: Long,
+    )
+
+    /** Receives registration events on a native thread; it must not block. */
+    fun interface RpccgoRegistrationListener {
+        fun onRegistrationEvent(event: RpccgoRegistrationEvent)
+    }
+
+    /** Handle for a registration watch. Closing it stops further events. */
+    class RpccgoRegistrationWatch internal constructor(private val watch: Int) : AutoCloseable {
+        private val active = AtomicBoolean(true)
+
+        override fun close() {
+            if (active.compareAndSet(true, false)) SharedSoDemoJni.rpccgoRegistrationUnwatch(watch)
+        }
+    }
+
+    @Keep
+    private class RpccgoRegistrationBridge(private val listener: RpccgoRegistrationListener) {
+        @Keep
+        fun onEvent(serviceId: String, oldKind: Int, newKind: Int, cleared: Boolean, seq: Long) {
+            listener.onRegistrationEvent(
+                RpccgoRegistrationEvent(serviceId, RpccgoServerKind.fromValue(oldKind), RpccgoServerKind.fromValue(newKind), cleared, seq),
+            )
+        }
+    }
+
+    private external fun rpccgoRegistrationWatch(serviceId: String, bridge: RpccgoRegistrationBridge): ByteArray?
+    private external fun rpccgoRegistrationUnwatch(watch: Int): ByteArray?
+
     private external fun sharedSoDemoComposeGreeting(request: ByteArray): ByteArray?
     private external fun sharedSoDemoComposeGreetingRegister(): ByteArray?
     private external fun sharedSoDemoIncrementRuntimeState(request: ByteArray): ByteArray?
@@ -734,6 +786,13 @@ object SharedSoDemoJni {
         encodeErrorResult("rpccgo: Kotlin server stream cancel failed: ${e.message ?: e::class.java.name}")
     }
 
+    /** Reports every replace or clear of the server registered for examples.flutter.sharedso.v1.SharedSoDemo. */
+    fun WatchSharedSoDemoRegistration(listener: RpccgoRegistrationListener): RpccgoResult<RpccgoRegistrationWatch> {
+        val watch = decodeHandleResult(rpccgoRegistrationWatch("examples.flutter.sharedso.v1.SharedSoDemo", RpccgoRegistrationBridge(listener)))
+        if (!watch.ok) return RpccgoResult.failure(watch.error ?: "rpccgo: registration watch failed")
+        return RpccgoResult.success(RpccgoRegistrationWatch(watch.value ?: 0))
+    }
+
     fun SetTorch(req: examples.flutter.sharedso.v1.SetTorchRequest): RpccgoResult<examples.flutter.sharedso.v1.SetTorchResponse> =
         decodeResult(androidDeviceSetTorch(req.toByteArray())) { examples.flutter.sharedso.v1.SetTorchResponse.parseFrom(it) }
 
@@ -1080,6 +1139,13 @@ object SharedSoDemoJni {
         encodeErrorResult("rpccgo: Kotlin server stream cancel failed: ${e.message ?: e::class.java.name}")
     }
 
+    /** Reports every replace or clear of the server registered for examples.flutter.sharedso.v1.AndroidDevice. */
+    fun WatchAndroidDeviceRegistration(listener: RpccgoRegistrationListener): RpccgoResult<RpccgoRegistrationWatch> {
+        val watch = decodeHandleResult(rpccgoRegistrationWatch("examples.flutter.sharedso.v1.AndroidDevice", RpccgoRegistrationBridge(listener)))
+        if (!watch.ok) return RpccgoResult.failure(watch.error ?: "rpccgo: registration watch failed")
+        return RpccgoResult.success(RpccgoRegistrationWatch(watch.value ?: 0))
+    }
+
     fun DescribeFlutter(req: examples.flutter.sharedso.v1.FlutterEchoRequest): RpccgoResult<examples.flutter.sharedso.v1.FlutterEchoResponse> =
         decodeResult(flutterDeviceDescribeFlutter(req.toByteArray())) { examples.flutter.sharedso.v1.FlutterEchoResponse.parseFrom(it) }
 
@@ -1221,6 +1287,13 @@ object SharedSoDemoJni {
         encodeErrorResult("rpccgo: Kotlin server stream cancel failed: ${e.message ?: e::class.java.name}")
     }
 
+    /** Reports every replace or clear of the server registered for examples.flutter.sharedso.v1.FlutterDevice. */
+    fun WatchFlutterDeviceRegistration(listener: RpccgoRegistrationListener): RpccgoResult<RpccgoRegistrationWatch> {
+        val watch = decodeHandleResult(rpccgoRegistrationWatch("examples.flutter.sharedso.v1.FlutterDevice", RpccgoRegistrationBridge(listener)))
+        if (!watch.ok) return RpccgoResult.failure(watch.error ?: "rpccgo: registration watch failed")
+        return RpccgoResult.success(RpccgoRegistrationWatch(watch.value ?: 0))
+    }
+
     private fun decodeResultPayload(bytes: ByteArray?): RpccgoResult<ByteArray> {
         if (bytes == null) return RpccgoResult.failure("rpccgo: JNI returned null")
         if (bytes.size < 5) return RpccgoResult.failure("rpccgo: JNI returned malformed result")
@@ -9,6 +9,36 @@ export 'shared_so.flutter_device.rpccgo.dart';
 export 'shared_so.pb.dart';
 export 'shared_so.shared_so_demo.rpccgo.dart';
 
+/// Kind of the Go server registered for a service, in rpcruntime.ServerKind order.
+enum RpccgoServerKind {
+  invalid,
+  goNative,
+  cgoNative,
+  cgoMessage,
+  connect,
+  grpc,
+  connectRemote,
+  grpcRemote;
+
+  /// Maps a RPCCGO_SERVER_KIND_* value, treating unknown values as [invalid].
+  static RpccgoServerKind fromValue(int value) =>
+      value > 0 && value < values.length ? values[value] : invalid;
+}
+
+/// Replacement or removal of the server registered for a service.
+///
+/// Kinds are those of the first server of the service route; [seq] orders
+/// events from concurrent registrations.
+final class RpccgoRegistrationEvent {
+  const RpccgoRegistrationEvent(this.serviceId, this.oldKind, this.newKind, this.cleared, this.seq);
+
+  final String serviceId;
+  final RpccgoServerKind oldKind;
+  final RpccgoServerKind newKind;
+  final bool cleared;
+  final int seq;
+}
+
 /// Stream type that can be cancelled by the shared rpccgo lifecycle scope.
 abstract interface class RpccgoDisposableStream {
   /// Cancels or otherwise releases the native stream owned by this Dart object.
@@ -22,13 +22,13 @@ import 'shared_so.pb.dart' as pb;
 typedef _RpccgoReleaseCAbi = ffi.Int32 Function(ffi.UintPtr ptr);
 typedef _RpccgoStoreErrorTextCAbi = ffi.Int32 Function(ffi.Pointer<ffi.Char> textPtr, ffi.Int32 textLen);
 typedef _RpccgoTakeErrorTextCAbi = ffi.Int32 Function(ffi.Int32 errID, ffi.Pointer<ffi.UintPtr> textPtr, ffi.Pointer<ffi.Int32> textLen);
-typedef _RpccgoMessageUnaryCAbi = ffi.Int32 Function(ffi.UintPtr requestPtr, ffi.Int32 requestLen, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
+typedef _RpccgoMessageUnaryCAbi = ffi.Int32 Function(ffi.Pointer<ffi.Uint8> requestPtr, ffi.Int32 requestLen, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
 typedef _RpccgoStreamStartCAbi = ffi.Int32 Function(ffi.Pointer<ffi.Int32> handle);
 typedef _RpccgoCallbackStreamStartCAbi = ffi.Int32 Function(ffi.Pointer<ffi.Int32> handle, ffi.Pointer<ffi.Void> onRecv, ffi.Pointer<ffi.Void> onDone);
-typedef _RpccgoServerStreamStartCAbi = ffi.Int32 Function(ffi.UintPtr requestPtr, ffi.Int32 requestLen, ffi.Pointer<ffi.Int32> handle, ffi.Pointer<ffi.Void> onRecv, ffi.Pointer<ffi.Void> onDone);
+typedef _RpccgoServerStreamStartCAbi = ffi.Int32 Function(ffi.Pointer<ffi.Uint8> requestPtr, ffi.Int32 requestLen, ffi.Pointer<ffi.Int32> handle, ffi.Pointer<ffi.Void> onRecv, ffi.Pointer<ffi.Void> onDone);
 typedef _RpccgoMessageOnRecvCAbi = ffi.Void Function(ffi.Int32 stream, ffi.UintPtr responsePtr, ffi.Int32 responseLen);
 typedef _RpccgoMessageOnDoneCAbi = ffi.Void Function(ffi.Int32 stream, ffi.Int32 errID);
-typedef _RpccgoStreamSendCAbi = ffi.Int32 Function(ffi.Int32 handle, ffi.UintPtr requestPtr, ffi.Int32 requestLen);
+typedef _RpccgoStreamSendCAbi = ffi.Int32 Function(ffi.Int32 handle, ffi.Pointer<ffi.Uint8> requestPtr, ffi.Int32 requestLen);
 typedef _RpccgoStreamRecvCAbi = ffi.Int32 Function(ffi.Int32 handle, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
 typedef _RpccgoStreamFinishCAbi = ffi.Int32 Function(ffi.Int32 handle, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
 typedef _RpccgoStreamFinishVoidCAbi = ffi.Int32 Function(ffi.Int32 handle);
@@ -40,6 +40,10 @@ typedef _RpccgoMessageServerSendCAbi = ffi.Int32 Function(ffi.Int32 stream, ffi.
 typedef _RpccgoMessageServerRecvCAbi = ffi.Int32 Function(ffi.Int32 stream, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
 typedef _RpccgoMessageServerFinishCAbi = ffi.Int32 Function(ffi.Int32 stream, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
 typedef _RpccgoMessageServerControlCAbi = ffi.Int32 Function(ffi.Int32 stream);
+typedef _RpccgoRegistrationEventCAbi = ffi.Void Function(ffi.Int32 watch, ffi.UintPtr serviceIDPtr, ffi.Int32 serviceIDLen, ffi.Int32 oldKind, ffi.Int32 newKind, ffi.Int32 cleared, ffi.Uint64 seq);
+typedef _RpccgoRegistrationDoneCAbi = ffi.Void Function(ffi.Int32 watch);
+typedef _RpccgoRegistrationWatchCAbi = ffi.Int32 Function(ffi.Pointer<ffi.Char> serviceID, ffi.Int32 serviceIDLen, ffi.Pointer<ffi.Int32> watch, ffi.Pointer<ffi.NativeFunction<_RpccgoRegistrationEventCAbi>> onEvent, ffi.Pointer<ffi.NativeFunction<_RpccgoRegistrationDoneCAbi>> onDone);
+typedef _RpccgoRegistrationUnwatchCAbi = ffi.Int32 Function(ffi.Int32 watch);
 typedef _RpccgoRegisterUnaryServerCAbi = ffi.Int32 Function(ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerUnaryCAbi>> callback);
 typedef _RpccgoRegisterClientStreamingServerCAbi = ffi.Int32 Function(ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerStartCAbi>> start, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerSendCAbi>> send, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerFinishCAbi>> finish, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerControlCAbi>> cancel);
 typedef _RpccgoRegisterServerStreamingServerCAbi = ffi.Int32 Function(ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerStartWithRequestCAbi>> start, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerRecvCAbi>> recv, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerControlCAbi>> finish, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerControlCAbi>> cancel);
@@ -54,14 +58,20 @@ external int _rpccgoStoreErrorTextRaw(ffi.Pointer<ffi.Char> textPtr, int textLen
 @ffi.Native<_RpccgoTakeErrorTextCAbi>(symbol: 'rpccgoTakeErrorText')
 external int _rpccgoTakeErrorTextRaw(int errID, ffi.Pointer<ffi.UintPtr> textPtr, ffi.Pointer<ffi.Int32> textLen);
 
+@ffi.Native<_RpccgoRegistrationWatchCAbi>(symbol: 'rpccgoRegistrationWatch')
+external int _rpccgoRegistrationWatchRaw(ffi.Pointer<ffi.Char> serviceID, int serviceIDLen, ffi.Pointer<ffi.Int32> watch, ffi.Pointer<ffi.NativeFunction<_RpccgoRegistrationEventCAbi>> onEvent, ffi.Pointer<ffi.NativeFunction<_RpccgoRegistrationDoneCAbi>> onDone);
+
+@ffi.Native<_RpccgoRegistrationUnwatchCAbi>(symbol: 'rpccgoRegistrationUnwatch')
+external int _rpccgoRegistrationUnwatchRaw(int watch);
+
 @ffi.Native<_RpccgoMessageUnaryCAbi>(symbol: 'rpccgoMsgFluttersharedv1AndroidDeviceSetTorch')
-external int _setTorchRaw(int requestPtr, int requestLen, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
+external int _setTorchRaw(ffi.Pointer<ffi.Uint8> requestPtr, int requestLen, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
 
 @ffi.Native<_RpccgoRegisterUnaryServerCAbi>(symbol: 'rpccgoMsgFluttersharedv1AndroidDeviceRegisterSetTorch')
 external int _setTorchRegisterServerRaw(ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerUnaryCAbi>> callback);
 
 @ffi.Native<_RpccgoServerStreamStartCAbi>(symbol: 'rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoStart')
-external int _watchAndroidEchoStartRaw(int requestPtr, int requestLen, ffi.Pointer<ffi.Int32> handle, ffi.Pointer<ffi.Void> onRecv, ffi.Pointer<ffi.Void> onDone);
+external int _watchAndroidEchoStartRaw(ffi.Pointer<ffi.Uint8> requestPtr, int requestLen, ffi.Pointer<ffi.Int32> handle, ffi.Pointer<ffi.Void> onRecv, ffi.Pointer<ffi.Void> onDone);
 
 @ffi.Native<_RpccgoStreamRecvCAbi>(symbol: 'rpccgoMsgFluttersharedv1AndroidDeviceWatchAndroidEchoRecv')
 external int _watchAndroidEchoRecvRaw(int handle, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
@@ -79,7 +89,7 @@ external int _watchAndroidEchoRegisterServerRaw(ffi.Pointer<ffi.NativeFunction<_
 external int _collectAndroidEchoStartRaw(ffi.Pointer<ffi.Int32> handle);
 
 @ffi.Native<_RpccgoStreamSendCAbi>(symbol: 'rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoSend')
-external int _collectAndroidEchoSendRaw(int handle, int requestPtr, int requestLen);
+external int _collectAndroidEchoSendRaw(int handle, ffi.Pointer<ffi.Uint8> requestPtr, int requestLen);
 
 @ffi.Native<_RpccgoStreamFinishCAbi>(symbol: 'rpccgoMsgFluttersharedv1AndroidDeviceCollectAndroidEchoFinish')
 external int _collectAndroidEchoFinishRaw(int handle, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
@@ -94,7 +104,7 @@ external int _collectAndroidEchoRegisterServerRaw(ffi.Pointer<ffi.NativeFunction
 external int _chatAndroidEchoStartRaw(ffi.Pointer<ffi.Int32> handle, ffi.Pointer<ffi.Void> onRecv, ffi.Pointer<ffi.Void> onDone);
 
 @ffi.Native<_RpccgoStreamSendCAbi>(symbol: 'rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoSend')
-external int _chatAndroidEchoSendRaw(int handle, int requestPtr, int requestLen);
+external int _chatAndroidEchoSendRaw(int handle, ffi.Pointer<ffi.Uint8> requestPtr, int requestLen);
 
 @ffi.Native<_RpccgoStreamRecvCAbi>(symbol: 'rpccgoMsgFluttersharedv1AndroidDeviceChatAndroidEchoRecv')
 external int _chatAndroidEchoRecvRaw(int handle, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
@@ -122,7 +132,7 @@ class AndroidDeviceRpccgoClient {
     final requestBytes = request.writeToBuffer();
     final requestPtr = _allocateBytes(requestBytes);
     try {
-      final errID = _setTorchRaw(requestPtr.address, requestBytes.length, responsePtr, responseLen);
+      final errID = _setTorchRaw(requestPtr, requestBytes.length, responsePtr, responseLen);
       final error = _takeErrorResult(errID);
       if (error != null) {
         return (value: null, error: error);
@@ -144,7 +154,7 @@ class AndroidDeviceRpccgoClient {
     final requestBytes = request.writeToBuffer();
     final requestPtr = _allocateBytes(requestBytes);
     try {
-      final errID = _watchAndroidEchoStartRaw(requestPtr.address, requestBytes.length, handlePtr, ffi.nullptr, ffi.nullptr);
+      final errID = _watchAndroidEchoStartRaw(requestPtr, requestBytes.length, handlePtr, ffi.nullptr, ffi.nullptr);
       final error = _takeErrorResult(errID);
       if (error != null) {
         return (value: null, error: error);
@@ -199,7 +209,7 @@ class AndroidDeviceRpccgoClient {
       });
     });
     try {
-      final errID = _watchAndroidEchoStartRaw(requestPtr.address, requestBytes.length, handlePtr, onRecvNative.nativeFunction.cast<ffi.Void>(), onDoneNative.nativeFunction.cast<ffi.Void>());
+      final errID = _watchAndroidEchoStartRaw(requestPtr, requestBytes.length, handlePtr, onRecvNative.nativeFunction.cast<ffi.Void>(), onDoneNative.nativeFunction.cast<ffi.Void>());
       final error = _takeErrorResult(errID);
       if (error != null) {
         closeCallbacks();
@@ -299,6 +309,64 @@ class AndroidDeviceRpccgoClient {
     }
   }
 
+  /// Emits an event whenever another component replaces or clears the server
+  /// registered for examples.flutter.sharedso.v1.AndroidDevice.
+  /// Cancelling the subscription stops the native watch.
+  async.Stream<rpccgo.RpccgoRegistrationEvent> RegistrationEvents() {
+    late final async.StreamController<rpccgo.RpccgoRegistrationEvent> controller;
+    ffi.NativeCallable<_RpccgoRegistrationEventCAbi>? onEventNative;
+    ffi.NativeCallable<_RpccgoRegistrationDoneCAbi>? onDoneNative;
+    var watch = 0;
+    void closeCallbacks() {
+      onEventNative?.close();
+      onDoneNative?.close();
+      onEventNative = null;
+      onDoneNative = null;
+    }
+    controller = async.StreamController<rpccgo.RpccgoRegistrationEvent>(
+      onListen: () {
+        final events = ffi.NativeCallable<_RpccgoRegistrationEventCAbi>.listener((int watchHandle, int serviceIDPtr, int serviceIDLen, int oldKind, int newKind, int cleared, int seq) {
+          final serviceID = _takeBytes(serviceIDPtr, serviceIDLen);
+          if (serviceID.error != null) {
+            controller.addError(serviceID.error!);
+            return;
+          }
+          controller.add(rpccgo.RpccgoRegistrationEvent(convert.utf8.decode(serviceID.value!), rpccgo.RpccgoServerKind.fromValue(oldKind), rpccgo.RpccgoServerKind.fromValue(newKind), cleared != 0, seq));
+        });
+        final done = ffi.NativeCallable<_RpccgoRegistrationDoneCAbi>.listener((int watchHandle) {
+          async.scheduleMicrotask(closeCallbacks);
+          controller.close();
+        });
+        onEventNative = events;
+        onDoneNative = done;
+        final serviceIDBytes = convert.utf8.encode('examples.flutter.sharedso.v1.AndroidDevice');
+        final serviceIDPtr = _allocateBytes(serviceIDBytes);
+        final watchPtr = pkg_ffi.calloc<ffi.Int32>();
+        try {
+          final errID = _rpccgoRegistrationWatchRaw(serviceIDPtr.cast<ffi.Char>(), serviceIDBytes.length, watchPtr, events.nativeFunction, done.nativeFunction);
+          final error = _takeErrorResult(errID);
+          if (error != null) {
+            closeCallbacks();
+            controller.addError(error);
+            controller.close();
+            return;
+          }
+          watch = watchPtr.value;
+        } finally {
+          pkg_ffi.calloc.free(serviceIDPtr);
+          pkg_ffi.calloc.free(watchPtr);
+        }
+      },
+      onCancel: () {
+        if (watch != 0) {
+          _takeErrorResult(_rpccgoRegistrationUnwatchRaw(watch));
+          watch = 0;
+        }
+      },
+    );
+    return controller.stream;
+  }
+
   ffi.Pointer<ffi.Uint8> _allocateBytes(List<int> data) {
     final ptr = pkg_ffi.calloc<ffi.Uint8>(data.length);
     ptr.asTypedList(data.length).setAll(0, data);
@@ -438,7 +506,7 @@ class AndroidDeviceCollectAndroidEchoStream {
     final requestBytes = request.writeToBuffer();
     final requestPtr = _client._allocateBytes(requestBytes);
     try {
-      final errID = _collectAndroidEchoSendRaw(_handle, requestPtr.address, requestBytes.length);
+      final errID = _collectAndroidEchoSendRaw(_handle, requestPtr, requestBytes.length);
       return _client._takeErrorResult(errID);
     } finally {
       pkg_ffi.calloc.free(requestPtr);
@@ -482,7 +550,7 @@ class AndroidDeviceChatAndroidEchoStream implements rpccgo.RpccgoDisposableStrea
     final requestBytes = request.writeToBuffer();
     final requestPtr = _client._allocateBytes(requestBytes);
     try {
-      final errID = _chatAndroidEchoSendRaw(_handle, requestPtr.address, requestBytes.length);
+      final errID = _chatAndroidEchoSendRaw(_handle, requestPtr, requestBytes.length);
       return _client._takeErrorResult(errID);
     } finally {
       pkg_ffi.calloc.free(requestPtr);
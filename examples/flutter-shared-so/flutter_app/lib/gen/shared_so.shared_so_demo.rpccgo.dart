@@ -22,13 +22,13 @@ import 'shared_so.pb.dart' as pb;
 typedef _RpccgoReleaseCAbi = ffi.Int32 Function(ffi.UintPtr ptr);
 typedef _RpccgoStoreErrorTextCAbi = ffi.Int32 Function(ffi.Pointer<ffi.Char> textPtr, ffi.Int32 textLen);
 typedef _RpccgoTakeErrorTextCAbi = ffi.Int32 Function(ffi.Int32 errID, ffi.Pointer<ffi.UintPtr> textPtr, ffi.Pointer<ffi.Int32> textLen);
-typedef _RpccgoMessageUnaryCAbi = ffi.Int32 Function(ffi.UintPtr requestPtr, ffi.Int32 requestLen, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
+typedef _RpccgoMessageUnaryCAbi = ffi.Int32 Function(ffi.Pointer<ffi.Uint8> requestPtr, ffi.Int32 requestLen, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
 typedef _RpccgoStreamStartCAbi = ffi.Int32 Function(ffi.Pointer<ffi.Int32> handle);
 typedef _RpccgoCallbackStreamStartCAbi = ffi.Int32 Function(ffi.Pointer<ffi.Int32> handle, ffi.Pointer<ffi.Void> onRecv, ffi.Pointer<ffi.Void> onDone);
-typedef _RpccgoServerStreamStartCAbi = ffi.Int32 Function(ffi.UintPtr requestPtr, ffi.Int32 requestLen, ffi.Pointer<ffi.Int32> handle, ffi.Pointer<ffi.Void> onRecv, ffi.Pointer<ffi.Void> onDone);
+typedef _RpccgoServerStreamStartCAbi = ffi.Int32 Function(ffi.Pointer<ffi.Uint8> requestPtr, ffi.Int32 requestLen, ffi.Pointer<ffi.Int32> handle, ffi.Pointer<ffi.Void> onRecv, ffi.Pointer<ffi.Void> onDone);
 typedef _RpccgoMessageOnRecvCAbi = ffi.Void Function(ffi.Int32 stream, ffi.UintPtr responsePtr, ffi.Int32 responseLen);
 typedef _RpccgoMessageOnDoneCAbi = ffi.Void Function(ffi.Int32 stream, ffi.Int32 errID);
-typedef _RpccgoStreamSendCAbi = ffi.Int32 Function(ffi.Int32 handle, ffi.UintPtr requestPtr, ffi.Int32 requestLen);
+typedef _RpccgoStreamSendCAbi = ffi.Int32 Function(ffi.Int32 handle, ffi.Pointer<ffi.Uint8> requestPtr, ffi.Int32 requestLen);
 typedef _RpccgoStreamRecvCAbi = ffi.Int32 Function(ffi.Int32 handle, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
 typedef _RpccgoStreamFinishCAbi = ffi.Int32 Function(ffi.Int32 handle, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
 typedef _RpccgoStreamFinishVoidCAbi = ffi.Int32 Function(ffi.Int32 handle);
@@ -40,6 +40,10 @@ typedef _RpccgoMessageServerSendCAbi = ffi.Int32 Function(ffi.Int32 stream, ffi.
 typedef _RpccgoMessageServerRecvCAbi = ffi.Int32 Function(ffi.Int32 stream, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
 typedef _RpccgoMessageServerFinishCAbi = ffi.Int32 Function(ffi.Int32 stream, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
 typedef _RpccgoMessageServerControlCAbi = ffi.Int32 Function(ffi.Int32 stream);
+typedef _RpccgoRegistrationEventCAbi = ffi.Void Function(ffi.Int32 watch, ffi.UintPtr serviceIDPtr, ffi.Int32 serviceIDLen, ffi.Int32 oldKind, ffi.Int32 newKind, ffi.Int32 cleared, ffi.Uint64 seq);
+typedef _RpccgoRegistrationDoneCAbi = ffi.Void Function(ffi.Int32 watch);
+typedef _RpccgoRegistrationWatchCAbi = ffi.Int32 Function(ffi.Pointer<ffi.Char> serviceID, ffi.Int32 serviceIDLen, ffi.Pointer<ffi.Int32> watch, ffi.Pointer<ffi.NativeFunction<_RpccgoRegistrationEventCAbi>> onEvent, ffi.Pointer<ffi.NativeFunction<_RpccgoRegistrationDoneCAbi>> onDone);
+typedef _RpccgoRegistrationUnwatchCAbi = ffi.Int32 Function(ffi.Int32 watch);
 typedef _RpccgoRegisterUnaryServerCAbi = ffi.Int32 Function(ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerUnaryCAbi>> callback);
 typedef _RpccgoRegisterClientStreamingServerCAbi = ffi.Int32 Function(ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerStartCAbi>> start, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerSendCAbi>> send, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerFinishCAbi>> finish, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerControlCAbi>> cancel);
 typedef _RpccgoRegisterServerStreamingServerCAbi = ffi.Int32 Function(ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerStartWithRequestCAbi>> start, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerRecvCAbi>> recv, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerControlCAbi>> finish, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerControlCAbi>> cancel);
@@ -54,26 +58,32 @@ external int _rpccgoStoreErrorTextRaw(ffi.Pointer<ffi.Char> textPtr, int textLen
 @ffi.Native<_RpccgoTakeErrorTextCAbi>(symbol: 'rpccgoTakeErrorText')
 external int _rpccgoTakeErrorTextRaw(int errID, ffi.Pointer<ffi.UintPtr> textPtr, ffi.Pointer<ffi.Int32> textLen);
 
+@ffi.Native<_RpccgoRegistrationWatchCAbi>(symbol: 'rpccgoRegistrationWatch')
+external int _rpccgoRegistrationWatchRaw(ffi.Pointer<ffi.Char> serviceID, int serviceIDLen, ffi.Pointer<ffi.Int32> watch, ffi.Pointer<ffi.NativeFunction<_RpccgoRegistrationEventCAbi>> onEvent, ffi.Pointer<ffi.NativeFunction<_RpccgoRegistrationDoneCAbi>> onDone);
+
+@ffi.Native<_RpccgoRegistrationUnwatchCAbi>(symbol: 'rpccgoRegistrationUnwatch')
+external int _rpccgoRegistrationUnwatchRaw(int watch);
+
 @ffi.Native<_RpccgoMessageUnaryCAbi>(symbol: 'rpccgoMsgFluttersharedv1SharedSoDemoComposeGreeting')
-external int _composeGreetingRaw(int requestPtr, int requestLen, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
+external int _composeGreetingRaw(ffi.Pointer<ffi.Uint8> requestPtr, int requestLen, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
 
 @ffi.Native<_RpccgoRegisterUnaryServerCAbi>(symbol: 'rpccgoMsgFluttersharedv1SharedSoDemoRegisterComposeGreeting')
 external int _composeGreetingRegisterServerRaw(ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerUnaryCAbi>> callback);
 
 @ffi.Native<_RpccgoMessageUnaryCAbi>(symbol: 'rpccgoMsgFluttersharedv1SharedSoDemoIncrementRuntimeState')
-external int _incrementRuntimeStateRaw(int requestPtr, int requestLen, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
+external int _incrementRuntimeStateRaw(ffi.Pointer<ffi.Uint8> requestPtr, int requestLen, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
 
 @ffi.Native<_RpccgoRegisterUnaryServerCAbi>(symbol: 'rpccgoMsgFluttersharedv1SharedSoDemoRegisterIncrementRuntimeState')
 external int _incrementRuntimeStateRegisterServerRaw(ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerUnaryCAbi>> callback);
 
 @ffi.Native<_RpccgoMessageUnaryCAbi>(symbol: 'rpccgoMsgFluttersharedv1SharedSoDemoReadRuntimeState')
-external int _readRuntimeStateRaw(int requestPtr, int requestLen, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
+external int _readRuntimeStateRaw(ffi.Pointer<ffi.Uint8> requestPtr, int requestLen, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
 
 @ffi.Native<_RpccgoRegisterUnaryServerCAbi>(symbol: 'rpccgoMsgFluttersharedv1SharedSoDemoRegisterReadRuntimeState')
 external int _readRuntimeStateRegisterServerRaw(ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerUnaryCAbi>> callback);
 
 @ffi.Native<_RpccgoServerStreamStartCAbi>(symbol: 'rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateStart')
-external int _watchRuntimeStateStartRaw(int requestPtr, int requestLen, ffi.Pointer<ffi.Int32> handle, ffi.Pointer<ffi.Void> onRecv, ffi.Pointer<ffi.Void> onDone);
+external int _watchRuntimeStateStartRaw(ffi.Pointer<ffi.Uint8> requestPtr, int requestLen, ffi.Pointer<ffi.Int32> handle, ffi.Pointer<ffi.Void> onRecv, ffi.Pointer<ffi.Void> onDone);
 
 @ffi.Native<_RpccgoStreamRecvCAbi>(symbol: 'rpccgoMsgFluttersharedv1SharedSoDemoWatchRuntimeStateRecv')
 external int _watchRuntimeStateRecvRaw(int handle, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
@@ -91,7 +101,7 @@ external int _watchRuntimeStateRegisterServerRaw(ffi.Pointer<ffi.NativeFunction<
 external int _collectRuntimeStateStartRaw(ffi.Pointer<ffi.Int32> handle);
 
 @ffi.Native<_RpccgoStreamSendCAbi>(symbol: 'rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateSend')
-external int _collectRuntimeStateSendRaw(int handle, int requestPtr, int requestLen);
+external int _collectRuntimeStateSendRaw(int handle, ffi.Pointer<ffi.Uint8> requestPtr, int requestLen);
 
 @ffi.Native<_RpccgoStreamFinishCAbi>(symbol: 'rpccgoMsgFluttersharedv1SharedSoDemoCollectRuntimeStateFinish')
 external int _collectRuntimeStateFinishRaw(int handle, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
@@ -103,7 +113,7 @@ external int _collectRuntimeStateCancelRaw(int handle);
 external int _collectRuntimeStateRegisterServerRaw(ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerStartCAbi>> start, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerSendCAbi>> send, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerFinishCAbi>> finish, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerControlCAbi>> cancel);
 
 @ffi.Native<_RpccgoServerStreamStartCAbi>(symbol: 'rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateStart')
-external int _streamRuntimeStateStartRaw(int requestPtr, int requestLen, ffi.Pointer<ffi.Int32> handle, ffi.Pointer<ffi.Void> onRecv, ffi.Pointer<ffi.Void> onDone);
+external int _streamRuntimeStateStartRaw(ffi.Pointer<ffi.Uint8> requestPtr, int requestLen, ffi.Pointer<ffi.Int32> handle, ffi.Pointer<ffi.Void> onRecv, ffi.Pointer<ffi.Void> onDone);
 
 @ffi.Native<_RpccgoStreamRecvCAbi>(symbol: 'rpccgoMsgFluttersharedv1SharedSoDemoStreamRuntimeStateRecv')
 external int _streamRuntimeStateRecvRaw(int handle, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
@@ -121,7 +131,7 @@ external int _streamRuntimeStateRegisterServerRaw(ffi.Pointer<ffi.NativeFunction
 external int _chatRuntimeStateStartRaw(ffi.Pointer<ffi.Int32> handle, ffi.Pointer<ffi.Void> onRecv, ffi.Pointer<ffi.Void> onDone);
 
 @ffi.Native<_RpccgoStreamSendCAbi>(symbol: 'rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateSend')
-external int _chatRuntimeStateSendRaw(int handle, int requestPtr, int requestLen);
+external int _chatRuntimeStateSendRaw(int handle, ffi.Pointer<ffi.Uint8> requestPtr, int requestLen);
 
 @ffi.Native<_RpccgoStreamRecvCAbi>(symbol: 'rpccgoMsgFluttersharedv1SharedSoDemoChatRuntimeStateRecv')
 external int _chatRuntimeStateRecvRaw(int handle, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);
@@ -149,7 +159,7 @@ class SharedSoDemoRpccgoClient {
     final requestBytes = request.writeToBuffer();
     final requestPtr = _allocateBytes(requestBytes);
     try {
-      final errID = _composeGreetingRaw(requestPtr.address, requestBytes.length, responsePtr, responseLen);
+      final errID = _composeGreetingRaw(requestPtr, requestBytes.length, responsePtr, responseLen);
       final error = _takeErrorResult(errID);
       if (error != null) {
         return (value: null, error: error);
@@ -172,7 +182,7 @@ class SharedSoDemoRpccgoClient {
     final requestBytes = request.writeToBuffer();
     final requestPtr = _allocateBytes(requestBytes);
     try {
-      final errID = _incrementRuntimeStateRaw(requestPtr.address, requestBytes.length, responsePtr, responseLen);
+      final errID = _incrementRuntimeStateRaw(requestPtr, requestBytes.length, responsePtr, responseLen);
       final error = _takeErrorResult(errID);
       if (error != null) {
         return (value: null, error: error);
@@ -195,7 +205,7 @@ class SharedSoDemoRpccgoClient {
     final requestBytes = request.writeToBuffer();
     final requestPtr = _allocateBytes(requestBytes);
     try {
-      final errID = _readRuntimeStateRaw(requestPtr.address, requestBytes.length, responsePtr, responseLen);
+      final errID = _readRuntimeStateRaw(requestPtr, requestBytes.length, responsePtr, responseLen);
       final error = _takeErrorResult(errID);
       if (error != null) {
         return (value: null, error: error);
@@ -217,7 +227,7 @@ class SharedSoDemoRpccgoClient {
     final requestBytes = request.writeToBuffer();
     final requestPtr = _allocateBytes(requestBytes);
     try {
-      final errID = _watchRuntimeStateStartRaw(requestPtr.address, requestBytes.length, handlePtr, ffi.nullptr, ffi.nullptr);
+      final errID = _watchRuntimeStateStartRaw(requestPtr, requestBytes.length, handlePtr, ffi.nullptr, ffi.nullptr);
       final error = _takeErrorResult(errID);
       if (error != null) {
         return (value: null, error: error);
@@ -272,7 +282,7 @@ class SharedSoDemoRpccgoClient {
       });
     });
     try {
-      final errID = _watchRuntimeStateStartRaw(requestPtr.address, requestBytes.length, handlePtr, onRecvNative.nativeFunction.cast<ffi.Void>(), onDoneNative.nativeFunction.cast<ffi.Void>());
+      final errID = _watchRuntimeStateStartRaw(requestPtr, requestBytes.length, handlePtr, onRecvNative.nativeFunction.cast<ffi.Void>(), onDoneNative.nativeFunction.cast<ffi.Void>());
       final error = _takeErrorResult(errID);
       if (error != null) {
         closeCallbacks();
@@ -307,7 +317,7 @@ class SharedSoDemoRpccgoClient {
     final requestBytes = request.writeToBuffer();
     final requestPtr = _allocateBytes(requestBytes);
     try {
-      final errID = _streamRuntimeStateStartRaw(requestPtr.address, requestBytes.length, handlePtr, ffi.nullptr, ffi.nullptr);
+      final errID = _streamRuntimeStateStartRaw(requestPtr, requestBytes.length, handlePtr, ffi.nullptr, ffi.nullptr);
       final error = _takeErrorResult(errID);
       if (error != null) {
         return (value: null, error: error);
@@ -362,7 +372,7 @@ class SharedSoDemoRpccgoClient {
       });
     });
     try {
-      final errID = _streamRuntimeStateStartRaw(requestPtr.address, requestBytes.length, handlePtr, onRecvNative.nativeFunction.cast<ffi.Void>(), onDoneNative.nativeFunction.cast<ffi.Void>());
+      final errID = _streamRuntimeStateStartRaw(requestPtr, requestBytes.length, handlePtr, onRecvNative.nativeFunction.cast<ffi.Void>(), onDoneNative.nativeFunction.cast<ffi.Void>());
       final error = _takeErrorResult(errID);
       if (error != null) {
         closeCallbacks();
@@ -448,6 +458,64 @@ class SharedSoDemoRpccgoClient {
     }
   }
 
+  /// Emits an event whenever another component replaces or clears the server
+  /// registered for examples.flutter.sharedso.v1.SharedSoDemo.
+  /// Cancelling the subscription stops the native watch.
+  async.Stream<rpccgo.RpccgoRegistrationEvent> RegistrationEvents() {
+    late final async.StreamController<rpccgo.RpccgoRegistrationEvent> controller;
+    ffi.NativeCallable<_RpccgoRegistrationEventCAbi>? onEventNative;
+    ffi.NativeCallable<_RpccgoRegistrationDoneCAbi>? onDoneNative;
+    var watch = 0;
+    void closeCallbacks() {
+      onEventNative?.close();
+      onDoneNative?.close();
+      onEventNative = null;
+      onDoneNative = null;
+    }
+    controller = async.StreamController<rpccgo.RpccgoRegistrationEvent>(
+      onListen: () {
+        final events = ffi.NativeCallable<_RpccgoRegistrationEventCAbi>.listener((int watchHandle, int serviceIDPtr, int serviceIDLen, int oldKind, int newKind, int cleared, int seq) {
+          final serviceID = _takeBytes(serviceIDPtr, serviceIDLen);
+          if (serviceID.error != null) {
+            controller.addError(serviceID.error!);
+            return;
+          }
+          controller.add(rpccgo.RpccgoRegistrationEvent(convert.utf8.decode(serviceID.value!), rpccgo.RpccgoServerKind.fromValue(oldKind), rpccgo.RpccgoServerKind.fromValue(newKind), cleared != 0, seq));
+        });
+        final done = ffi.NativeCallable<_RpccgoRegistrationDoneCAbi>.listener((int watchHandle) {
+          async.scheduleMicrotask(closeCallbacks);
+          controller.close();
+        });
+        onEventNative = events;
+        onDoneNative = done;
+        final serviceIDBytes = convert.utf8.encode('examples.flutter.sharedso.v1.SharedSoDemo');
+        final serviceIDPtr = _allocateBytes(serviceIDBytes);
+        final watchPtr = pkg_ffi.calloc<ffi.Int32>();
+        try {
+          final errID = _rpccgoRegistrationWatchRaw(serviceIDPtr.cast<ffi.Char>(), serviceIDBytes.length, watchPtr, events.nativeFunction, done.nativeFunction);
+          final error = _takeErrorResult(errID);
+          if (error != null) {
+            closeCallbacks();
+            controller.addError(error);
+            controller.close();
+            return;
+          }
+          watch = watchPtr.value;
+        } finally {
+          pkg_ffi.calloc.free(serviceIDPtr);
+          pkg_ffi.calloc.free(watchPtr);
+        }
+      },
+      onCancel: () {
+        if (watch != 0) {
+          _takeErrorResult(_rpccgoRegistrationUnwatchRaw(watch));
+          watch = 0;
+        }
+      },
+    );
+    return controller.stream;
+  }
+
   ffi.Pointer<ffi.Uint8> _allocateBytes(List<int> data) {
     final ptr = pkg_ffi.calloc<ffi.Uint8>(data.length);
     ptr.asTypedList(data.length).setAll(0, data);
@@ -587,7 +655,7 @@ class SharedSoDemoCollectRuntimeStateStream {
     final requestBytes = request.writeToBuffer();
     final requestPtr = _client._allocateBytes(requestBytes);
     try {
-      final errID = _collectRuntimeStateSendRaw(_handle, requestPtr.address, requestBytes.length);
+      final errID = _collectRuntimeStateSendRaw(_handle, requestPtr, requestBytes.length);
       return _client._takeErrorResult(errID);
     } finally {
       pkg_ffi.calloc.free(requestPtr);
@@ -711,7 +779,7 @@ class SharedSoDemoChatRuntimeStateStream implements rpccgo.RpccgoDisposableStrea
     final requestBytes = request.writeToBuffer();
     final requestPtr = _client._allocateBytes(requestBytes);
     try {
-      final errID = _chatRuntimeStateSendRaw(_handle, requestPtr.address, requestBytes.length);
+      final errID = _chatRuntimeStateSendRaw(_handle, requestPtr, requestBytes.length);
       return _client._takeErrorResult(errID);
     } finally {
       pkg_ffi.calloc.free(requestPtr);
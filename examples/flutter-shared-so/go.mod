@@ -10,7 +10,10 @@ require (
 
 require (
 	github.com/magefile/mage v1.17.2 // indirect
+	golang.org/x/net v0.48.0 // indirect
 	golang.org/x/sys v0.41.0 // indirect
+	golang.org/x/text v0.33.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260120221211-b8f7ae30c516 // indirect
 	google.golang.org/grpc v1.79.3 // indirect
 )
 
@@ -21,28 +21,82 @@ const androidDeviceServiceID rpcruntime.ServiceID = "examples.flutter.sharedso.v
 // AndroidDeviceMessageServerUnavailableErr is returned when a message server registration is missing or invalid.
 var AndroidDeviceMessageServerUnavailableErr = errors.New("rpccgo: message server is unavailable")
 
+func init() {
+	rpcruntime.RegisterServiceManifest(rpcruntime.ServiceManifest{
+		ServiceID:        androidDeviceServiceID,
+		ProtoFile:        "shared_so.proto",
+		Native:           false,
+		MessageTransport: rpcruntime.ServerKindConnect,
+		Methods: []rpcruntime.MethodManifest{
+			{Name: "SetTorch", FullName: "examples.flutter.sharedso.v1.AndroidDevice.SetTorch", Streaming: rpcruntime.MethodStreamingUnary, RequestType: "examples.flutter.sharedso.v1.SetTorchRequest", ResponseType: "examples.flutter.sharedso.v1.SetTorchResponse"},
+			{Name: "WatchAndroidEcho", FullName: "examples.flutter.sharedso.v1.AndroidDevice.WatchAndroidEcho", Streaming: rpcruntime.MethodStreamingServer, RequestType: "examples.flutter.sharedso.v1.AndroidEchoRequest", ResponseType: "examples.flutter.sharedso.v1.AndroidEchoResponse"},
+			{Name: "CollectAndroidEcho", FullName: "examples.flutter.sharedso.v1.AndroidDevice.CollectAndroidEcho", Streaming: rpcruntime.MethodStreamingClient, RequestType: "examples.flutter.sharedso.v1.AndroidEchoRequest", ResponseType: "examples.flutter.sharedso.v1.AndroidEchoResponse"},
+			{Name: "ChatAndroidEcho", FullName: "examples.flutter.sharedso.v1.AndroidDevice.ChatAndroidEcho", Streaming: rpcruntime.MethodStreamingBidi, RequestType: "examples.flutter.sharedso.v1.AndroidEchoRequest", ResponseType: "examples.flutter.sharedso.v1.AndroidEchoResponse"},
+		},
+	})
+	rpcruntime.RegisterDynamicMethod("examples.flutter.sharedso.v1.AndroidDevice.SetTorch", rpcruntime.DynamicUnary(InvokeAndroidDeviceMessageSetTorch))
+	rpcruntime.RegisterDynamicMethod("examples.flutter.sharedso.v1.AndroidDevice.WatchAndroidEcho", rpcruntime.DynamicServerStream(AndroidDeviceMessageWatchAndroidEchoStart, AndroidDeviceMessageWatchAndroidEchoRecv, AndroidDeviceMessageWatchAndroidEchoCancel))
+	rpcruntime.RegisterDynamicMethod("examples.flutter.sharedso.v1.AndroidDevice.CollectAndroidEcho", rpcruntime.DynamicClientStream(AndroidDeviceMessageCollectAndroidEchoStart, AndroidDeviceMessageCollectAndroidEchoSend, AndroidDeviceMessageCollectAndroidEchoFinish, AndroidDeviceMessageCollectAndroidEchoCancel))
+	rpcruntime.RegisterDynamicMethod("examples.flutter.sharedso.v1.AndroidDevice.ChatAndroidEcho", rpcruntime.DynamicBidiStream(AndroidDeviceMessageChatAndroidEchoStart, AndroidDeviceMessageChatAndroidEchoSend, AndroidDeviceMessageChatAndroidEchoRecv, AndroidDeviceMessageChatAndroidEchoCloseSend, AndroidDeviceMessageChatAndroidEchoFinish, AndroidDeviceMessageChatAndroidEchoCancel))
+}
+
 // ClearAndroidDeviceServer clears the current registered server for this service.
 func ClearAndroidDeviceServer() error {
-	return rpcruntime.ClearServer(androidDeviceServiceID)
+	return ClearAndroidDeviceServerIn(rpcruntime.DefaultServerRegistry())
 }
 
-// LoadAndroidDeviceRegisteredServer loads the current registered server record for this service.
+// ClearAndroidDeviceServerIn clears the current registered server for this service in registry.
+func ClearAndroidDeviceServerIn(registry *rpcruntime.ServerRegistry) error {
+	return registry.Clear(androidDeviceServiceID)
+}
+
+// LoadAndroidDeviceRegisteredServer loads the current registered server record for this service. With a multi-server route it returns the first target.
 func LoadAndroidDeviceRegisteredServer() (rpcruntime.RegisteredServer, error) {
-	return rpcruntime.LoadServer(androidDeviceServiceID)
+	return LoadAndroidDeviceRegisteredServerIn(rpcruntime.DefaultServerRegistry())
+}
+
+// LoadAndroidDeviceRegisteredServerIn loads the current registered server record for this service from registry.
+func LoadAndroidDeviceRegisteredServerIn(registry *rpcruntime.ServerRegistry) (rpcruntime.RegisteredServer, error) {
+	return registry.Load(androidDeviceServiceID)
+}
+
+// RegisterAndroidDeviceServerRoute replaces the registered servers for this service with an ordered or weighted route. Targets are records loaded after the generated Register helpers.
+func RegisterAndroidDeviceServerRoute(route rpcruntime.ServerRoute) error {
+	return RegisterAndroidDeviceServerRouteIn(rpcruntime.DefaultServerRegistry(), route)
+}
+
+// RegisterAndroidDeviceServerRouteIn replaces the registered servers for this service in registry with an ordered or weighted route.
+func RegisterAndroidDeviceServerRouteIn(registry *rpcruntime.ServerRegistry, route rpcruntime.ServerRoute) error {
+	return registry.RegisterRoute(androidDeviceServiceID, route)
+}
+
+// LoadAndroidDeviceServerRoute loads the registered server route for this service.
+func LoadAndroidDeviceServerRoute() (rpcruntime.ServerRoute, error) {
+	return LoadAndroidDeviceServerRouteIn(rpcruntime.DefaultServerRegistry())
+}
+
+// LoadAndroidDeviceServerRouteIn loads the registered server route for this service from registry.
+func LoadAndroidDeviceServerRouteIn(registry *rpcruntime.ServerRegistry) (rpcruntime.ServerRoute, error) {
+	return registry.LoadRoute(androidDeviceServiceID)
 }
 
 // RegisterAndroidDeviceConnectHandler registers the supplied connect handler server as the current server for this service.
 func RegisterAndroidDeviceConnectHandler(handler AndroidDeviceHandler) error {
+	return RegisterAndroidDeviceConnectHandlerIn(rpcruntime.DefaultServerRegistry(), handler)
+}
+
+// RegisterAndroidDeviceConnectHandlerIn registers the supplied connect handler server as the current server for this service in registry.
+func RegisterAndroidDeviceConnectHandlerIn(registry *rpcruntime.ServerRegistry, handler AndroidDeviceHandler) error {
 	if handler == nil {
-		_ = rpcruntime.ClearServer(androidDeviceServiceID)
+		_ = registry.Clear(androidDeviceServiceID)
 		return AndroidDeviceMessageServerUnavailableErr
 	}
-	err := rpcruntime.RegisterServer(androidDeviceServiceID, rpcruntime.RegisteredServer{
+	err := registry.Register(androidDeviceServiceID, rpcruntime.RegisteredServer{
 		Kind:   rpcruntime.ServerKindConnect,
 		Server: handler,
 	})
 	if err != nil {
-		_ = rpcruntime.ClearServer(androidDeviceServiceID)
+		_ = registry.Clear(androidDeviceServiceID)
 		return err
 	}
 	return nil
@@ -50,16 +104,21 @@ func RegisterAndroidDeviceConnectHandler(handler AndroidDeviceHandler) error {
 
 // RegisterAndroidDeviceConnectRemoteServer registers the supplied connect remote server as the current server for this service.
 func RegisterAndroidDeviceConnectRemoteServer(client AndroidDeviceClient) error {
+	return RegisterAndroidDeviceConnectRemoteServerIn(rpcruntime.DefaultServerRegistry(), client)
+}
+
+// RegisterAndroidDeviceConnectRemoteServerIn registers the supplied connect remote server as the current server for this service in registry.
+func RegisterAndroidDeviceConnectRemoteServerIn(registry *rpcruntime.ServerRegistry, client AndroidDeviceClient) error {
 	if client == nil {
-		_ = rpcruntime.ClearServer(androidDeviceServiceID)
+		_ = registry.Clear(androidDeviceServiceID)
 		return AndroidDeviceMessageServerUnavailableErr
 	}
-	err := rpcruntime.RegisterServer(androidDeviceServiceID, rpcruntime.RegisteredServer{
+	err := registry.Register(androidDeviceServiceID, rpcruntime.RegisteredServer{
 		Kind:   rpcruntime.ServerKindConnectRemote,
 		Server: client,
 	})
 	if err != nil {
-		_ = rpcruntime.ClearServer(androidDeviceServiceID)
+		_ = registry.Clear(androidDeviceServiceID)
 		return err
 	}
 	return nil
@@ -70,11 +129,13 @@ func newandroidDeviceWatchAndroidEchoConnectDirectMessageStreamSession(ctx conte
 		return nil, errors.New("rpccgo: message request is nil")
 	}
 	client, stream, streamCtx := rpcruntime.NewServerStreaming[*AndroidEchoResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:         "examples.flutter.sharedso.v1.AndroidDevice.WatchAndroidEcho",
 		ResponseBuffer: 1,
 		StreamClosed:   errors.New("rpccgo: message stream is closed"),
 		NilResponse:    errors.New("rpccgo: message response is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.AndroidDevice.WatchAndroidEcho handler", func(err error) { stream.Complete(err) })
 		conn := &rpcruntime.ConnectStreamingHandlerConn{SendFunc: func(message any) error {
 			resp, ok := message.(*AndroidEchoResponse)
 			if !ok || resp == nil {
@@ -82,7 +143,10 @@ func newandroidDeviceWatchAndroidEchoConnectDirectMessageStreamSession(ctx conte
 			}
 			return stream.Send(streamCtx, resp)
 		}}
-		stream.Complete(handler.WatchAndroidEcho(streamCtx, req, rpcruntime.NewConnectServerStream[AndroidEchoResponse](conn)))
+		handlerCtx, finishMetadata := rpcruntime.ConnectStreamingHandlerContext(streamCtx, conn)
+		err := handler.WatchAndroidEcho(handlerCtx, req, rpcruntime.NewConnectServerStream[AndroidEchoResponse](conn))
+		finishMetadata()
+		stream.Complete(err)
 	}()
 	return client, nil
 }
@@ -94,30 +158,36 @@ func newandroidDeviceWatchAndroidEchoConnectRemoteMessageStreamSession(ctx conte
 		return nil, errors.New("rpccgo: message request is nil")
 	}
 	streamCtx, cancel := context.WithCancel(ctx)
-	stream, err := client.WatchAndroidEcho(streamCtx, req)
+	callCtx, finishMetadata := rpcruntime.ConnectClientContext(streamCtx)
+	stream, err := client.WatchAndroidEcho(callCtx, req)
 	if err != nil {
 		cancel()
 		return nil, err
 	}
-	return &androidDeviceWatchAndroidEchoConnectRemoteMessageStreamSession{stream: stream, cancel: cancel}, nil
+	return &androidDeviceWatchAndroidEchoConnectRemoteMessageStreamSession{stream: stream, cancel: cancel, finishMetadata: finishMetadata}, nil
 }
 
 type androidDeviceWatchAndroidEchoConnectRemoteMessageStreamSession struct {
-	stream *connect.ServerStreamForClient[AndroidEchoResponse]
-	cancel context.CancelFunc
+	stream         *connect.ServerStreamForClient[AndroidEchoResponse]
+	cancel         context.CancelFunc
+	finishMetadata func()
 }
 
 func (s *androidDeviceWatchAndroidEchoConnectRemoteMessageStreamSession) Recv(ctx context.Context) (*AndroidEchoResponse, error) {
-	_ = ctx
 	if s == nil {
 		return nil, errors.New("rpccgo: connect remote server stream is nil")
 	}
 	if s.stream == nil {
 		return nil, errors.New("rpccgo: connect remote server stream is nil")
 	}
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
 	if !s.stream.Receive() {
+		s.finishMetadata()
 		if err := s.stream.Err(); err != nil {
-			return nil, err
+			return nil, rpcruntime.ContextError(ctx, err)
 		}
 		return nil, io.EOF
 	}
@@ -152,11 +222,13 @@ func (s *androidDeviceWatchAndroidEchoConnectRemoteMessageStreamSession) Cancel(
 
 func newandroidDeviceCollectAndroidEchoConnectDirectMessageStreamSession(ctx context.Context, handler AndroidDeviceHandler) rpcruntime.ClientStreamingClient[*AndroidEchoRequest, *AndroidEchoResponse] {
 	client, stream, streamCtx := rpcruntime.NewClientStreaming[*AndroidEchoRequest, *AndroidEchoResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:        "examples.flutter.sharedso.v1.AndroidDevice.CollectAndroidEcho",
 		RequestBuffer: 16,
 		StreamClosed:  errors.New("rpccgo: message stream is closed"),
 		NilRequest:    errors.New("rpccgo: message request is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.AndroidDevice.CollectAndroidEcho handler", func(err error) { stream.Complete(nil, err) })
 		conn := &rpcruntime.ConnectStreamingHandlerConn{ReceiveFunc: func(message any) error {
 			target, ok := message.(*AndroidEchoRequest)
 			if !ok || target == nil {
@@ -169,7 +241,9 @@ func newandroidDeviceCollectAndroidEchoConnectDirectMessageStreamSession(ctx con
 			*target = *req
 			return nil
 		}}
-		resp, err := handler.CollectAndroidEcho(streamCtx, rpcruntime.NewConnectClientStream[AndroidEchoRequest](conn))
+		handlerCtx, finishMetadata := rpcruntime.ConnectStreamingHandlerContext(streamCtx, conn)
+		resp, err := handler.CollectAndroidEcho(handlerCtx, rpcruntime.NewConnectClientStream[AndroidEchoRequest](conn))
+		finishMetadata()
 		stream.Complete(resp, err)
 	}()
 	return client
@@ -179,46 +253,55 @@ func newandroidDeviceCollectAndroidEchoConnectRemoteMessageStreamSession(ctx con
 	CollectAndroidEcho(context.Context) (*connect.ClientStreamForClientSimple[AndroidEchoRequest, AndroidEchoResponse], error)
 }) (*androidDeviceCollectAndroidEchoConnectRemoteMessageStreamSession, error) {
 	streamCtx, cancel := context.WithCancel(ctx)
-	stream, err := client.CollectAndroidEcho(streamCtx)
+	callCtx, finishMetadata := rpcruntime.ConnectClientContext(streamCtx)
+	stream, err := client.CollectAndroidEcho(callCtx)
 	if err != nil {
 		cancel()
 		return nil, err
 	}
-	return &androidDeviceCollectAndroidEchoConnectRemoteMessageStreamSession{stream: stream, cancel: cancel}, nil
+	return &androidDeviceCollectAndroidEchoConnectRemoteMessageStreamSession{stream: stream, cancel: cancel, finishMetadata: finishMetadata}, nil
 }
 
 type androidDeviceCollectAndroidEchoConnectRemoteMessageStreamSession struct {
-	stream *connect.ClientStreamForClientSimple[AndroidEchoRequest, AndroidEchoResponse]
-	cancel context.CancelFunc
+	stream         *connect.ClientStreamForClientSimple[AndroidEchoRequest, AndroidEchoResponse]
+	cancel         context.CancelFunc
+	finishMetadata func()
 }
 
 func (s *androidDeviceCollectAndroidEchoConnectRemoteMessageStreamSession) Send(ctx context.Context, req *AndroidEchoRequest) error {
-	_ = ctx
 	if req == nil {
 		return errors.New("rpccgo: message request is nil")
 	}
 	if s == nil || s.stream == nil {
 		return errors.New("rpccgo: connect remote client stream is nil")
 	}
-	return s.stream.Send(req)
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
+	return rpcruntime.ContextError(ctx, s.stream.Send(req))
 }
 
 func (s *androidDeviceCollectAndroidEchoConnectRemoteMessageStreamSession) Finish(ctx context.Context) (*AndroidEchoResponse, error) {
-	_ = ctx
 	if s == nil {
 		return nil, errors.New("rpccgo: connect remote client stream is nil")
 	}
 	if s.stream == nil {
 		return nil, errors.New("rpccgo: connect remote client stream is nil")
 	}
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
 	defer func() {
 		if s.cancel != nil {
 			s.cancel()
 		}
 	}()
 	resp, err := s.stream.CloseAndReceive()
+	s.finishMetadata()
 	if err != nil {
-		return nil, err
+		return nil, rpcruntime.ContextError(ctx, err)
 	}
 	if resp == nil {
 		return nil, errors.New("rpccgo: message response is nil")
@@ -258,6 +341,7 @@ func (s *androidDeviceCollectAndroidEchoConnectRemoteMessageStreamSession) Cance
 
 func newandroidDeviceChatAndroidEchoConnectDirectMessageStreamSession(ctx context.Context, handler AndroidDeviceHandler) rpcruntime.BidiStreamingClient[*AndroidEchoRequest, *AndroidEchoResponse] {
 	client, stream, streamCtx := rpcruntime.NewBidiStreaming[*AndroidEchoRequest, *AndroidEchoResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:         "examples.flutter.sharedso.v1.AndroidDevice.ChatAndroidEcho",
 		RequestBuffer:  16,
 		ResponseBuffer: 1,
 		StreamClosed:   errors.New("rpccgo: message stream is closed"),
@@ -265,6 +349,7 @@ func newandroidDeviceChatAndroidEchoConnectDirectMessageStreamSession(ctx contex
 		NilResponse:    errors.New("rpccgo: message response is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.AndroidDevice.ChatAndroidEcho handler", func(err error) { stream.Complete(err) })
 		conn := &rpcruntime.ConnectStreamingHandlerConn{
 			ReceiveFunc: func(message any) error {
 				target, ok := message.(*AndroidEchoRequest)
@@ -286,7 +371,10 @@ func newandroidDeviceChatAndroidEchoConnectDirectMessageStreamSession(ctx contex
 				return stream.Send(streamCtx, resp)
 			},
 		}
-		stream.Complete(handler.ChatAndroidEcho(streamCtx, rpcruntime.NewConnectBidiStream[AndroidEchoRequest, AndroidEchoResponse](conn)))
+		handlerCtx, finishMetadata := rpcruntime.ConnectStreamingHandlerContext(streamCtx, conn)
+		err := handler.ChatAndroidEcho(handlerCtx, rpcruntime.NewConnectBidiStream[AndroidEchoRequest, AndroidEchoResponse](conn))
+		finishMetadata()
+		stream.Complete(err)
 	}()
 	return client
 }
@@ -295,38 +383,47 @@ func newandroidDeviceChatAndroidEchoConnectRemoteMessageStreamSession(ctx contex
 	ChatAndroidEcho(context.Context) (*connect.BidiStreamForClientSimple[AndroidEchoRequest, AndroidEchoResponse], error)
 }) (*androidDeviceChatAndroidEchoConnectRemoteMessageStreamSession, error) {
 	streamCtx, cancel := context.WithCancel(ctx)
-	stream, err := client.ChatAndroidEcho(streamCtx)
+	callCtx, finishMetadata := rpcruntime.ConnectClientContext(streamCtx)
+	stream, err := client.ChatAndroidEcho(callCtx)
 	if err != nil {
 		cancel()
 		return nil, err
 	}
-	return &androidDeviceChatAndroidEchoConnectRemoteMessageStreamSession{stream: stream, cancel: cancel}, nil
+	return &androidDeviceChatAndroidEchoConnectRemoteMessageStreamSession{stream: stream, cancel: cancel, finishMetadata: finishMetadata}, nil
 }
 
 type androidDeviceChatAndroidEchoConnectRemoteMessageStreamSession struct {
-	stream *connect.BidiStreamForClientSimple[AndroidEchoRequest, AndroidEchoResponse]
-	cancel context.CancelFunc
+	stream         *connect.BidiStreamForClientSimple[AndroidEchoRequest, AndroidEchoResponse]
+	cancel         context.CancelFunc
+	finishMetadata func()
 }
 
 func (s *androidDeviceChatAndroidEchoConnectRemoteMessageStreamSession) Send(ctx context.Context, req *AndroidEchoRequest) error {
-	_ = ctx
 	if req == nil {
 		return errors.New("rpccgo: message request is nil")
 	}
 	if s == nil || s.stream == nil {
 		return errors.New("rpccgo: connect remote bidi stream is nil")
 	}
-	return s.stream.Send(req)
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
+	return rpcruntime.ContextError(ctx, s.stream.Send(req))
 }
 
 func (s *androidDeviceChatAndroidEchoConnectRemoteMessageStreamSession) Recv(ctx context.Context) (*AndroidEchoResponse, error) {
-	_ = ctx
 	if s == nil || s.stream == nil {
 		return nil, errors.New("rpccgo: connect remote bidi stream is nil")
 	}
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
 	resp, err := s.stream.Receive()
 	if err != nil {
-		return nil, err
+		s.finishMetadata()
+		return nil, rpcruntime.ContextError(ctx, err)
 	}
 	if resp == nil {
 		return nil, errors.New("rpccgo: message response is nil")
@@ -364,15 +461,29 @@ func (s *androidDeviceChatAndroidEchoConnectRemoteMessageStreamSession) CloseSen
 	return s.stream.CloseRequest()
 }
 
-// InvokeAndroidDeviceMessageSetTorch invokes the current registered server using the message contract for SetTorch.
+// InvokeAndroidDeviceMessageSetTorch invokes the server selected by the service route using the message contract for SetTorch.
 func InvokeAndroidDeviceMessageSetTorch(ctx context.Context, req *SetTorchRequest) (*SetTorchResponse, error) {
 	if req == nil {
 		return nil, errors.New("rpccgo: message request is nil")
 	}
-	registered, err := rpcruntime.LoadServer(androidDeviceServiceID)
+	var resp *SetTorchResponse
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteUnary(ctx, rpcruntime.CallInfo{
+		ServiceID: androidDeviceServiceID,
+		Method:    "examples.flutter.sharedso.v1.AndroidDevice.SetTorch",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationUnary,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		resp, callErr = invokeAndroidDeviceMessageSetTorch(ctx, registered, req)
+		return callErr
+	})
 	if err != nil {
 		return nil, err
 	}
+	return resp, nil
+}
+
+func invokeAndroidDeviceMessageSetTorch(ctx context.Context, registered rpcruntime.RegisteredServer, req *SetTorchRequest) (*SetTorchResponse, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(AndroidDeviceCGOMessageServer)
@@ -392,7 +503,9 @@ func InvokeAndroidDeviceMessageSetTorch(ctx context.Context, req *SetTorchReques
 		if !ok {
 			return nil, fmt.Errorf("rpccgo: AndroidDevice connect handler registered server has invalid type")
 		}
-		messageResp, err := server.SetTorch(ctx, req)
+		callCtx, finishMetadata := rpcruntime.ConnectHandlerContext(ctx, "/examples.flutter.sharedso.v1.AndroidDevice/SetTorch")
+		messageResp, err := server.SetTorch(callCtx, req)
+		finishMetadata()
 		if err != nil {
 			return nil, err
 		}
@@ -405,7 +518,9 @@ func InvokeAndroidDeviceMessageSetTorch(ctx context.Context, req *SetTorchReques
 		if !ok {
 			return nil, fmt.Errorf("rpccgo: AndroidDevice connect remote registered server has invalid type")
 		}
-		messageResp, err := server.SetTorch(ctx, req)
+		callCtx, finishMetadata := rpcruntime.ConnectClientContext(ctx)
+		messageResp, err := server.SetTorch(callCtx, req)
+		finishMetadata()
 		if err != nil {
 			return nil, err
 		}
@@ -418,15 +533,29 @@ func InvokeAndroidDeviceMessageSetTorch(ctx context.Context, req *SetTorchReques
 	}
 }
 
-// AndroidDeviceMessageWatchAndroidEchoStart starts a message contract stream for WatchAndroidEcho on the current registered server.
+// AndroidDeviceMessageWatchAndroidEchoStart starts a message contract stream for WatchAndroidEcho on the server selected by the service route.
 func AndroidDeviceMessageWatchAndroidEchoStart(ctx context.Context, req *AndroidEchoRequest) (rpcruntime.StreamHandle, error) {
 	if req == nil {
 		return 0, errors.New("rpccgo: message request is nil")
 	}
-	registered, err := rpcruntime.LoadServer(androidDeviceServiceID)
+	var streamHandle rpcruntime.StreamHandle
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{
+		ServiceID: androidDeviceServiceID,
+		Method:    "examples.flutter.sharedso.v1.AndroidDevice.WatchAndroidEcho",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationStart,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		streamHandle, callErr = androidDeviceMessageWatchAndroidEchoStart(ctx, registered, req)
+		return callErr
+	})
 	if err != nil {
 		return 0, err
 	}
+	return streamHandle, nil
+}
+
+func androidDeviceMessageWatchAndroidEchoStart(ctx context.Context, registered rpcruntime.RegisteredServer, req *AndroidEchoRequest) (rpcruntime.StreamHandle, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(AndroidDeviceCGOMessageServer)
@@ -437,7 +566,7 @@ func AndroidDeviceMessageWatchAndroidEchoStart(ctx context.Context, req *Android
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)
 	case rpcruntime.ServerKindConnect:
 		server, ok := registered.Server.(AndroidDeviceHandler)
 		if !ok {
@@ -447,7 +576,7 @@ func AndroidDeviceMessageWatchAndroidEchoStart(ctx context.Context, req *Android
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnect, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnect, source)
 	case rpcruntime.ServerKindConnectRemote:
 		server, ok := registered.Server.(AndroidDeviceClient)
 		if !ok {
@@ -457,18 +586,32 @@ func AndroidDeviceMessageWatchAndroidEchoStart(ctx context.Context, req *Android
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnectRemote, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnectRemote, source)
 	default:
 		return 0, fmt.Errorf("rpccgo: AndroidDevice registered server kind %d is unsupported for message stream starts", registered.Kind)
 	}
 }
 
-// AndroidDeviceMessageCollectAndroidEchoStart starts a message contract stream for CollectAndroidEcho on the current registered server.
+// AndroidDeviceMessageCollectAndroidEchoStart starts a message contract stream for CollectAndroidEcho on the server selected by the service route.
 func AndroidDeviceMessageCollectAndroidEchoStart(ctx context.Context) (rpcruntime.StreamHandle, error) {
-	registered, err := rpcruntime.LoadServer(androidDeviceServiceID)
+	var streamHandle rpcruntime.StreamHandle
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{
+		ServiceID: androidDeviceServiceID,
+		Method:    "examples.flutter.sharedso.v1.AndroidDevice.CollectAndroidEcho",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationStart,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		streamHandle, callErr = androidDeviceMessageCollectAndroidEchoStart(ctx, registered)
+		return callErr
+	})
 	if err != nil {
 		return 0, err
 	}
+	return streamHandle, nil
+}
+
+func androidDeviceMessageCollectAndroidEchoStart(ctx context.Context, registered rpcruntime.RegisteredServer) (rpcruntime.StreamHandle, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(AndroidDeviceCGOMessageServer)
@@ -479,14 +622,14 @@ func AndroidDeviceMessageCollectAndroidEchoStart(ctx context.Context) (rpcruntim
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)
 	case rpcruntime.ServerKindConnect:
 		server, ok := registered.Server.(AndroidDeviceHandler)
 		if !ok {
 			return 0, fmt.Errorf("rpccgo: AndroidDevice connect handler registered server has invalid type")
 		}
 		source := newandroidDeviceCollectAndroidEchoConnectDirectMessageStreamSession(ctx, server)
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnect, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnect, source)
 	case rpcruntime.ServerKindConnectRemote:
 		server, ok := registered.Server.(AndroidDeviceClient)
 		if !ok {
@@ -496,18 +639,32 @@ func AndroidDeviceMessageCollectAndroidEchoStart(ctx context.Context) (rpcruntim
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnectRemote, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnectRemote, source)
 	default:
 		return 0, fmt.Errorf("rpccgo: AndroidDevice registered server kind %d is unsupported for message stream starts", registered.Kind)
 	}
 }
 
-// AndroidDeviceMessageChatAndroidEchoStart starts a message contract stream for ChatAndroidEcho on the current registered server.
+// AndroidDeviceMessageChatAndroidEchoStart starts a message contract stream for ChatAndroidEcho on the server selected by the service route.
 func AndroidDeviceMessageChatAndroidEchoStart(ctx context.Context) (rpcruntime.StreamHandle, error) {
-	registered, err := rpcruntime.LoadServer(androidDeviceServiceID)
+	var streamHandle rpcruntime.StreamHandle
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{
+		ServiceID: androidDeviceServiceID,
+		Method:    "examples.flutter.sharedso.v1.AndroidDevice.ChatAndroidEcho",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationStart,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		streamHandle, callErr = androidDeviceMessageChatAndroidEchoStart(ctx, registered)
+		return callErr
+	})
 	if err != nil {
 		return 0, err
 	}
+	return streamHandle, nil
+}
+
+func androidDeviceMessageChatAndroidEchoStart(ctx context.Context, registered rpcruntime.RegisteredServer) (rpcruntime.StreamHandle, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(AndroidDeviceCGOMessageServer)
@@ -518,14 +675,14 @@ func AndroidDeviceMessageChatAndroidEchoStart(ctx context.Context) (rpcruntime.S
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)
 	case rpcruntime.ServerKindConnect:
 		server, ok := registered.Server.(AndroidDeviceHandler)
 		if !ok {
 			return 0, fmt.Errorf("rpccgo: AndroidDevice connect handler registered server has invalid type")
 		}
 		source := newandroidDeviceChatAndroidEchoConnectDirectMessageStreamSession(ctx, server)
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnect, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnect, source)
 	case rpcruntime.ServerKindConnectRemote:
 		server, ok := registered.Server.(AndroidDeviceClient)
 		if !ok {
@@ -535,7 +692,7 @@ func AndroidDeviceMessageChatAndroidEchoStart(ctx context.Context) (rpcruntime.S
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnectRemote, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnectRemote, source)
 	default:
 		return 0, fmt.Errorf("rpccgo: AndroidDevice registered server kind %d is unsupported for message stream starts", registered.Kind)
 	}
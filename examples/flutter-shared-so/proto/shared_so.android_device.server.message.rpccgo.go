@@ -28,10 +28,30 @@ type AndroidDeviceCGOMessageServer interface {
 
 // AndroidDeviceMessageWatchAndroidEchoRecv receives a message response from an active WatchAndroidEcho stream.
 func AndroidDeviceMessageWatchAndroidEchoRecv(ctx context.Context, handle rpcruntime.StreamHandle) (*AndroidEchoResponse, error) {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return nil, err
 	}
+	var resp *AndroidEchoResponse
+	err = rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: androidDeviceServiceID,
+		Method:    "examples.flutter.sharedso.v1.AndroidDevice.WatchAndroidEcho",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationRecv,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		var callErr error
+		resp, callErr = androidDeviceMessageWatchAndroidEchoRecv(ctx, handle, entry)
+		return callErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func androidDeviceMessageWatchAndroidEchoRecv(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) (*AndroidEchoResponse, error) {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*AndroidEchoResponse])
@@ -106,10 +126,23 @@ func AndroidDeviceMessageWatchAndroidEchoRecv(ctx context.Context, handle rpcrun
 
 // AndroidDeviceMessageWatchAndroidEchoCancel cancels an active message WatchAndroidEcho stream and releases its handle.
 func AndroidDeviceMessageWatchAndroidEchoCancel(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: androidDeviceServiceID,
+		Method:    "examples.flutter.sharedso.v1.AndroidDevice.WatchAndroidEcho",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationCancel,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return androidDeviceMessageWatchAndroidEchoCancel(ctx, handle, entry)
+	})
+}
+
+func androidDeviceMessageWatchAndroidEchoCancel(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*AndroidEchoResponse])
@@ -119,7 +152,7 @@ func AndroidDeviceMessageWatchAndroidEchoCancel(ctx context.Context, handle rpcr
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnect:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*AndroidEchoResponse])
@@ -129,7 +162,7 @@ func AndroidDeviceMessageWatchAndroidEchoCancel(ctx context.Context, handle rpcr
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnectRemote:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*AndroidEchoResponse])
@@ -139,7 +172,7 @@ func AndroidDeviceMessageWatchAndroidEchoCancel(ctx context.Context, handle rpcr
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	default:
 		return fmt.Errorf("rpccgo: AndroidDevice message stream session kind %d is unsupported", entry.Kind)
@@ -151,10 +184,23 @@ func AndroidDeviceMessageCollectAndroidEchoSend(ctx context.Context, handle rpcr
 	if req == nil {
 		return errors.New("rpccgo: message request is nil")
 	}
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: androidDeviceServiceID,
+		Method:    "examples.flutter.sharedso.v1.AndroidDevice.CollectAndroidEcho",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationSend,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return androidDeviceMessageCollectAndroidEchoSend(ctx, handle, entry, req)
+	})
+}
+
+func androidDeviceMessageCollectAndroidEchoSend(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession, req *AndroidEchoRequest) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[*AndroidEchoRequest, *AndroidEchoResponse])
@@ -181,10 +227,30 @@ func AndroidDeviceMessageCollectAndroidEchoSend(ctx context.Context, handle rpcr
 
 // AndroidDeviceMessageCollectAndroidEchoFinish finishes an active message CollectAndroidEcho stream and releases its handle.
 func AndroidDeviceMessageCollectAndroidEchoFinish(ctx context.Context, handle rpcruntime.StreamHandle) (*AndroidEchoResponse, error) {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
+	if err != nil {
+		return nil, err
+	}
+	var resp *AndroidEchoResponse
+	err = rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: androidDeviceServiceID,
+		Method:    "examples.flutter.sharedso.v1.AndroidDevice.CollectAndroidEcho",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationFinish,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		var callErr error
+		resp, callErr = androidDeviceMessageCollectAndroidEchoFinish(ctx, handle, entry)
+		return callErr
+	})
 	if err != nil {
 		return nil, err
 	}
+	return resp, nil
+}
+
+func androidDeviceMessageCollectAndroidEchoFinish(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) (*AndroidEchoResponse, error) {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[*AndroidEchoRequest, *AndroidEchoResponse])
@@ -241,10 +307,23 @@ func AndroidDeviceMessageCollectAndroidEchoFinish(ctx context.Context, handle rp
 
 // AndroidDeviceMessageCollectAndroidEchoCancel cancels an active message CollectAndroidEcho stream and releases its handle.
 func AndroidDeviceMessageCollectAndroidEchoCancel(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: androidDeviceServiceID,
+		Method:    "examples.flutter.sharedso.v1.AndroidDevice.CollectAndroidEcho",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationCancel,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return androidDeviceMessageCollectAndroidEchoCancel(ctx, handle, entry)
+	})
+}
+
+func androidDeviceMessageCollectAndroidEchoCancel(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[*AndroidEchoRequest, *AndroidEchoResponse])
@@ -254,7 +333,7 @@ func AndroidDeviceMessageCollectAndroidEchoCancel(ctx context.Context, handle rp
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnect:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[*AndroidEchoRequest, *AndroidEchoResponse])
@@ -264,7 +343,7 @@ func AndroidDeviceMessageCollectAndroidEchoCancel(ctx context.Context, handle rp
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnectRemote:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[*AndroidEchoRequest, *AndroidEchoResponse])
@@ -274,7 +353,7 @@ func AndroidDeviceMessageCollectAndroidEchoCancel(ctx context.Context, handle rp
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	default:
 		return fmt.Errorf("rpccgo: AndroidDevice message stream session kind %d is unsupported", entry.Kind)
@@ -286,10 +365,23 @@ func AndroidDeviceMessageChatAndroidEchoSend(ctx context.Context, handle rpcrunt
 	if req == nil {
 		return errors.New("rpccgo: message request is nil")
 	}
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: androidDeviceServiceID,
+		Method:    "examples.flutter.sharedso.v1.AndroidDevice.ChatAndroidEcho",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationSend,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return androidDeviceMessageChatAndroidEchoSend(ctx, handle, entry, req)
+	})
+}
+
+func androidDeviceMessageChatAndroidEchoSend(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession, req *AndroidEchoRequest) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*AndroidEchoRequest, *AndroidEchoResponse])
@@ -316,10 +408,30 @@ func AndroidDeviceMessageChatAndroidEchoSend(ctx context.Context, handle rpcrunt
 
 // AndroidDeviceMessageChatAndroidEchoRecv receives a message response from an active ChatAndroidEcho stream.
 func AndroidDeviceMessageChatAndroidEchoRecv(ctx context.Context, handle rpcruntime.StreamHandle) (*AndroidEchoResponse, error) {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
+	if err != nil {
+		return nil, err
+	}
+	var resp *AndroidEchoResponse
+	err = rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: androidDeviceServiceID,
+		Method:    "examples.flutter.sharedso.v1.AndroidDevice.ChatAndroidEcho",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationRecv,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		var callErr error
+		resp, callErr = androidDeviceMessageChatAndroidEchoRecv(ctx, handle, entry)
+		return callErr
+	})
 	if err != nil {
 		return nil, err
 	}
+	return resp, nil
+}
+
+func androidDeviceMessageChatAndroidEchoRecv(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) (*AndroidEchoResponse, error) {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*AndroidEchoRequest, *AndroidEchoResponse])
@@ -367,10 +479,23 @@ func AndroidDeviceMessageChatAndroidEchoRecv(ctx context.Context, handle rpcrunt
 
 // AndroidDeviceMessageChatAndroidEchoCloseSend closes the message send side of an active ChatAndroidEcho stream.
 func AndroidDeviceMessageChatAndroidEchoCloseSend(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: androidDeviceServiceID,
+		Method:    "examples.flutter.sharedso.v1.AndroidDevice.ChatAndroidEcho",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationCloseSend,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return androidDeviceMessageChatAndroidEchoCloseSend(ctx, handle, entry)
+	})
+}
+
+func androidDeviceMessageChatAndroidEchoCloseSend(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*AndroidEchoRequest, *AndroidEchoResponse])
@@ -397,10 +522,23 @@ func AndroidDeviceMessageChatAndroidEchoCloseSend(ctx context.Context, handle rp
 
 // AndroidDeviceMessageChatAndroidEchoFinish finishes an active message ChatAndroidEcho stream and releases its handle.
 func AndroidDeviceMessageChatAndroidEchoFinish(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: androidDeviceServiceID,
+		Method:    "examples.flutter.sharedso.v1.AndroidDevice.ChatAndroidEcho",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationFinish,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return androidDeviceMessageChatAndroidEchoFinish(ctx, handle, entry)
+	})
+}
+
+func androidDeviceMessageChatAndroidEchoFinish(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*AndroidEchoRequest, *AndroidEchoResponse])
@@ -410,7 +548,7 @@ func AndroidDeviceMessageChatAndroidEchoFinish(ctx context.Context, handle rpcru
 		if err := source.Finish(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnect:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*AndroidEchoRequest, *AndroidEchoResponse])
@@ -420,7 +558,7 @@ func AndroidDeviceMessageChatAndroidEchoFinish(ctx context.Context, handle rpcru
 		if err := source.Finish(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnectRemote:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*AndroidEchoRequest, *AndroidEchoResponse])
@@ -430,7 +568,7 @@ func AndroidDeviceMessageChatAndroidEchoFinish(ctx context.Context, handle rpcru
 		if err := source.Finish(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	default:
 		return fmt.Errorf("rpccgo: AndroidDevice message stream session kind %d is unsupported", entry.Kind)
@@ -439,10 +577,23 @@ func AndroidDeviceMessageChatAndroidEchoFinish(ctx context.Context, handle rpcru
 
 // AndroidDeviceMessageChatAndroidEchoCancel cancels an active message ChatAndroidEcho stream and releases its handle.
 func AndroidDeviceMessageChatAndroidEchoCancel(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: androidDeviceServiceID,
+		Method:    "examples.flutter.sharedso.v1.AndroidDevice.ChatAndroidEcho",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationCancel,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return androidDeviceMessageChatAndroidEchoCancel(ctx, handle, entry)
+	})
+}
+
+func androidDeviceMessageChatAndroidEchoCancel(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*AndroidEchoRequest, *AndroidEchoResponse])
@@ -452,7 +603,7 @@ func AndroidDeviceMessageChatAndroidEchoCancel(ctx context.Context, handle rpcru
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnect:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*AndroidEchoRequest, *AndroidEchoResponse])
@@ -462,7 +613,7 @@ func AndroidDeviceMessageChatAndroidEchoCancel(ctx context.Context, handle rpcru
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnectRemote:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*AndroidEchoRequest, *AndroidEchoResponse])
@@ -472,7 +623,7 @@ func AndroidDeviceMessageChatAndroidEchoCancel(ctx context.Context, handle rpcru
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	default:
 		return fmt.Errorf("rpccgo: AndroidDevice message stream session kind %d is unsupported", entry.Kind)
@@ -504,25 +655,30 @@ func (UnimplementedAndroidDeviceCGOMessageServer) ChatAndroidEcho(ctx context.Co
 
 // RegisterAndroidDeviceCGOMessageServer registers a cgo message server as the current server for AndroidDevice.
 func RegisterAndroidDeviceCGOMessageServer(server AndroidDeviceCGOMessageServer) error {
+	return RegisterAndroidDeviceCGOMessageServerIn(rpcruntime.DefaultServerRegistry(), server)
+}
+
+// RegisterAndroidDeviceCGOMessageServerIn registers a cgo message server as the current server for AndroidDevice in registry.
+func RegisterAndroidDeviceCGOMessageServerIn(registry *rpcruntime.ServerRegistry, server AndroidDeviceCGOMessageServer) error {
 	if server == nil {
-		_ = registerAndroidDeviceCGOMessageServer(server)
+		_ = registerAndroidDeviceCGOMessageServerIn(registry, server)
 		return errors.New("rpccgo: AndroidDevice cgo message server is nil")
 	}
-	return registerAndroidDeviceCGOMessageServer(server)
+	return registerAndroidDeviceCGOMessageServerIn(registry, server)
 }
 
-// registerAndroidDeviceCGOMessageServer registers the supplied cgo message server as the current server for this service.
-func registerAndroidDeviceCGOMessageServer(server AndroidDeviceCGOMessageServer) error {
+// registerAndroidDeviceCGOMessageServerIn registers the supplied cgo message server as the current server for this service in registry.
+func registerAndroidDeviceCGOMessageServerIn(registry *rpcruntime.ServerRegistry, server AndroidDeviceCGOMessageServer) error {
 	if server == nil {
-		_ = rpcruntime.ClearServer(androidDeviceServiceID)
+		_ = registry.Clear(androidDeviceServiceID)
 		return AndroidDeviceMessageServerUnavailableErr
 	}
-	err := rpcruntime.RegisterServer(androidDeviceServiceID, rpcruntime.RegisteredServer{
+	err := registry.Register(androidDeviceServiceID, rpcruntime.RegisteredServer{
 		Kind:   rpcruntime.ServerKindCGOMessage,
 		Server: server,
 	})
 	if err != nil {
-		_ = rpcruntime.ClearServer(androidDeviceServiceID)
+		_ = registry.Clear(androidDeviceServiceID)
 		return err
 	}
 	return nil
@@ -538,11 +694,13 @@ func androidDeviceWatchAndroidEchoCGOMessageStart(ctx context.Context, server An
 		return direct.WatchAndroidEchoStart(ctx, req)
 	}
 	client, stream, streamCtx := rpcruntime.NewServerStreaming[*AndroidEchoResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:         "examples.flutter.sharedso.v1.AndroidDevice.WatchAndroidEcho",
 		ResponseBuffer: 1,
 		StreamClosed:   errors.New("rpccgo: message stream is closed"),
 		NilResponse:    errors.New("rpccgo: message response is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.AndroidDevice.WatchAndroidEcho handler", func(err error) { stream.Complete(err) })
 		err := server.WatchAndroidEcho(streamCtx, req, stream)
 		stream.Complete(err)
 	}()
@@ -551,11 +709,13 @@ func androidDeviceWatchAndroidEchoCGOMessageStart(ctx context.Context, server An
 
 func androidDeviceCollectAndroidEchoCGOMessageStart(ctx context.Context, server AndroidDeviceCGOMessageServer) (rpcruntime.ClientStreamingClient[*AndroidEchoRequest, *AndroidEchoResponse], error) {
 	client, stream, streamCtx := rpcruntime.NewClientStreaming[*AndroidEchoRequest, *AndroidEchoResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:        "examples.flutter.sharedso.v1.AndroidDevice.CollectAndroidEcho",
 		RequestBuffer: 16,
 		StreamClosed:  errors.New("rpccgo: message stream is closed"),
 		NilRequest:    errors.New("rpccgo: message request is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.AndroidDevice.CollectAndroidEcho handler", func(err error) { stream.Complete(nil, err) })
 		resp, err := server.CollectAndroidEcho(streamCtx, stream)
 		stream.Complete(resp, err)
 	}()
@@ -564,6 +724,7 @@ func androidDeviceCollectAndroidEchoCGOMessageStart(ctx context.Context, server
 
 func androidDeviceChatAndroidEchoCGOMessageStart(ctx context.Context, server AndroidDeviceCGOMessageServer) (rpcruntime.BidiStreamingClient[*AndroidEchoRequest, *AndroidEchoResponse], error) {
 	client, stream, streamCtx := rpcruntime.NewBidiStreaming[*AndroidEchoRequest, *AndroidEchoResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:         "examples.flutter.sharedso.v1.AndroidDevice.ChatAndroidEcho",
 		RequestBuffer:  16,
 		ResponseBuffer: 1,
 		StreamClosed:   errors.New("rpccgo: message stream is closed"),
@@ -571,6 +732,7 @@ func androidDeviceChatAndroidEchoCGOMessageStart(ctx context.Context, server And
 		NilResponse:    errors.New("rpccgo: message response is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.AndroidDevice.ChatAndroidEcho handler", func(err error) { stream.Complete(err) })
 		err := server.ChatAndroidEcho(streamCtx, stream)
 		stream.Complete(err)
 	}()
@@ -20,28 +20,78 @@ const flutterDeviceServiceID rpcruntime.ServiceID = "examples.flutter.sharedso.v
 // FlutterDeviceMessageServerUnavailableErr is returned when a message server registration is missing or invalid.
 var FlutterDeviceMessageServerUnavailableErr = errors.New("rpccgo: message server is unavailable")
 
+func init() {
+	rpcruntime.RegisterServiceManifest(rpcruntime.ServiceManifest{
+		ServiceID:        flutterDeviceServiceID,
+		ProtoFile:        "shared_so.proto",
+		Native:           false,
+		MessageTransport: rpcruntime.ServerKindConnect,
+		Methods: []rpcruntime.MethodManifest{
+			{Name: "DescribeFlutter", FullName: "examples.flutter.sharedso.v1.FlutterDevice.DescribeFlutter", Streaming: rpcruntime.MethodStreamingUnary, RequestType: "examples.flutter.sharedso.v1.FlutterEchoRequest", ResponseType: "examples.flutter.sharedso.v1.FlutterEchoResponse"},
+			{Name: "WatchFlutterEcho", FullName: "examples.flutter.sharedso.v1.FlutterDevice.WatchFlutterEcho", Streaming: rpcruntime.MethodStreamingServer, RequestType: "examples.flutter.sharedso.v1.FlutterEchoRequest", ResponseType: "examples.flutter.sharedso.v1.FlutterEchoResponse"},
+		},
+	})
+	rpcruntime.RegisterDynamicMethod("examples.flutter.sharedso.v1.FlutterDevice.DescribeFlutter", rpcruntime.DynamicUnary(InvokeFlutterDeviceMessageDescribeFlutter))
+	rpcruntime.RegisterDynamicMethod("examples.flutter.sharedso.v1.FlutterDevice.WatchFlutterEcho", rpcruntime.DynamicServerStream(FlutterDeviceMessageWatchFlutterEchoStart, FlutterDeviceMessageWatchFlutterEchoRecv, FlutterDeviceMessageWatchFlutterEchoCancel))
+}
+
 // ClearFlutterDeviceServer clears the current registered server for this service.
 func ClearFlutterDeviceServer() error {
-	return rpcruntime.ClearServer(flutterDeviceServiceID)
+	return ClearFlutterDeviceServerIn(rpcruntime.DefaultServerRegistry())
+}
+
+// ClearFlutterDeviceServerIn clears the current registered server for this service in registry.
+func ClearFlutterDeviceServerIn(registry *rpcruntime.ServerRegistry) error {
+	return registry.Clear(flutterDeviceServiceID)
 }
 
-// LoadFlutterDeviceRegisteredServer loads the current registered server record for this service.
+// LoadFlutterDeviceRegisteredServer loads the current registered server record for this service. With a multi-server route it returns the first target.
 func LoadFlutterDeviceRegisteredServer() (rpcruntime.RegisteredServer, error) {
-	return rpcruntime.LoadServer(flutterDeviceServiceID)
+	return LoadFlutterDeviceRegisteredServerIn(rpcruntime.DefaultServerRegistry())
+}
+
+// LoadFlutterDeviceRegisteredServerIn loads the current registered server record for this service from registry.
+func LoadFlutterDeviceRegisteredServerIn(registry *rpcruntime.ServerRegistry) (rpcruntime.RegisteredServer, error) {
+	return registry.Load(flutterDeviceServiceID)
+}
+
+// RegisterFlutterDeviceServerRoute replaces the registered servers for this service with an ordered or weighted route. Targets are records loaded after the generated Register helpers.
+func RegisterFlutterDeviceServerRoute(route rpcruntime.ServerRoute) error {
+	return RegisterFlutterDeviceServerRouteIn(rpcruntime.DefaultServerRegistry(), route)
+}
+
+// RegisterFlutterDeviceServerRouteIn replaces the registered servers for this service in registry with an ordered or weighted route.
+func RegisterFlutterDeviceServerRouteIn(registry *rpcruntime.ServerRegistry, route rpcruntime.ServerRoute) error {
+	return registry.RegisterRoute(flutterDeviceServiceID, route)
+}
+
+// LoadFlutterDeviceServerRoute loads the registered server route for this service.
+func LoadFlutterDeviceServerRoute() (rpcruntime.ServerRoute, error) {
+	return LoadFlutterDeviceServerRouteIn(rpcruntime.DefaultServerRegistry())
+}
+
+// LoadFlutterDeviceServerRouteIn loads the registered server route for this service from registry.
+func LoadFlutterDeviceServerRouteIn(registry *rpcruntime.ServerRegistry) (rpcruntime.ServerRoute, error) {
+	return registry.LoadRoute(flutterDeviceServiceID)
 }
 
 // RegisterFlutterDeviceConnectHandler registers the supplied connect handler server as the current server for this service.
 func RegisterFlutterDeviceConnectHandler(handler FlutterDeviceHandler) error {
+	return RegisterFlutterDeviceConnectHandlerIn(rpcruntime.DefaultServerRegistry(), handler)
+}
+
+// RegisterFlutterDeviceConnectHandlerIn registers the supplied connect handler server as the current server for this service in registry.
+func RegisterFlutterDeviceConnectHandlerIn(registry *rpcruntime.ServerRegistry, handler FlutterDeviceHandler) error {
 	if handler == nil {
-		_ = rpcruntime.ClearServer(flutterDeviceServiceID)
+		_ = registry.Clear(flutterDeviceServiceID)
 		return FlutterDeviceMessageServerUnavailableErr
 	}
-	err := rpcruntime.RegisterServer(flutterDeviceServiceID, rpcruntime.RegisteredServer{
+	err := registry.Register(flutterDeviceServiceID, rpcruntime.RegisteredServer{
 		Kind:   rpcruntime.ServerKindConnect,
 		Server: handler,
 	})
 	if err != nil {
-		_ = rpcruntime.ClearServer(flutterDeviceServiceID)
+		_ = registry.Clear(flutterDeviceServiceID)
 		return err
 	}
 	return nil
@@ -49,16 +99,21 @@ func RegisterFlutterDeviceConnectHandler(handler FlutterDeviceHandler) error {
 
 // RegisterFlutterDeviceConnectRemoteServer registers the supplied connect remote server as the current server for this service.
 func RegisterFlutterDeviceConnectRemoteServer(client FlutterDeviceClient) error {
+	return RegisterFlutterDeviceConnectRemoteServerIn(rpcruntime.DefaultServerRegistry(), client)
+}
+
+// RegisterFlutterDeviceConnectRemoteServerIn registers the supplied connect remote server as the current server for this service in registry.
+func RegisterFlutterDeviceConnectRemoteServerIn(registry *rpcruntime.ServerRegistry, client FlutterDeviceClient) error {
 	if client == nil {
-		_ = rpcruntime.ClearServer(flutterDeviceServiceID)
+		_ = registry.Clear(flutterDeviceServiceID)
 		return FlutterDeviceMessageServerUnavailableErr
 	}
-	err := rpcruntime.RegisterServer(flutterDeviceServiceID, rpcruntime.RegisteredServer{
+	err := registry.Register(flutterDeviceServiceID, rpcruntime.RegisteredServer{
 		Kind:   rpcruntime.ServerKindConnectRemote,
 		Server: client,
 	})
 	if err != nil {
-		_ = rpcruntime.ClearServer(flutterDeviceServiceID)
+		_ = registry.Clear(flutterDeviceServiceID)
 		return err
 	}
 	return nil
@@ -69,11 +124,13 @@ func newflutterDeviceWatchFlutterEchoConnectDirectMessageStreamSession(ctx conte
 		return nil, errors.New("rpccgo: message request is nil")
 	}
 	client, stream, streamCtx := rpcruntime.NewServerStreaming[*FlutterEchoResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:         "examples.flutter.sharedso.v1.FlutterDevice.WatchFlutterEcho",
 		ResponseBuffer: 1,
 		StreamClosed:   errors.New("rpccgo: message stream is closed"),
 		NilResponse:    errors.New("rpccgo: message response is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.FlutterDevice.WatchFlutterEcho handler", func(err error) { stream.Complete(err) })
 		conn := &rpcruntime.ConnectStreamingHandlerConn{SendFunc: func(message any) error {
 			resp, ok := message.(*FlutterEchoResponse)
 			if !ok || resp == nil {
@@ -81,7 +138,10 @@ func newflutterDeviceWatchFlutterEchoConnectDirectMessageStreamSession(ctx conte
 			}
 			return stream.Send(streamCtx, resp)
 		}}
-		stream.Complete(handler.WatchFlutterEcho(streamCtx, req, rpcruntime.NewConnectServerStream[FlutterEchoResponse](conn)))
+		handlerCtx, finishMetadata := rpcruntime.ConnectStreamingHandlerContext(streamCtx, conn)
+		err := handler.WatchFlutterEcho(handlerCtx, req, rpcruntime.NewConnectServerStream[FlutterEchoResponse](conn))
+		finishMetadata()
+		stream.Complete(err)
 	}()
 	return client, nil
 }
@@ -93,30 +153,36 @@ func newflutterDeviceWatchFlutterEchoConnectRemoteMessageStreamSession(ctx conte
 		return nil, errors.New("rpccgo: message request is nil")
 	}
 	streamCtx, cancel := context.WithCancel(ctx)
-	stream, err := client.WatchFlutterEcho(streamCtx, req)
+	callCtx, finishMetadata := rpcruntime.ConnectClientContext(streamCtx)
+	stream, err := client.WatchFlutterEcho(callCtx, req)
 	if err != nil {
 		cancel()
 		return nil, err
 	}
-	return &flutterDeviceWatchFlutterEchoConnectRemoteMessageStreamSession{stream: stream, cancel: cancel}, nil
+	return &flutterDeviceWatchFlutterEchoConnectRemoteMessageStreamSession{stream: stream, cancel: cancel, finishMetadata: finishMetadata}, nil
 }
 
 type flutterDeviceWatchFlutterEchoConnectRemoteMessageStreamSession struct {
-	stream *connect.ServerStreamForClient[FlutterEchoResponse]
-	cancel context.CancelFunc
+	stream         *connect.ServerStreamForClient[FlutterEchoResponse]
+	cancel         context.CancelFunc
+	finishMetadata func()
 }
 
 func (s *flutterDeviceWatchFlutterEchoConnectRemoteMessageStreamSession) Recv(ctx context.Context) (*FlutterEchoResponse, error) {
-	_ = ctx
 	if s == nil {
 		return nil, errors.New("rpccgo: connect remote server stream is nil")
 	}
 	if s.stream == nil {
 		return nil, errors.New("rpccgo: connect remote server stream is nil")
 	}
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
 	if !s.stream.Receive() {
+		s.finishMetadata()
 		if err := s.stream.Err(); err != nil {
-			return nil, err
+			return nil, rpcruntime.ContextError(ctx, err)
 		}
 		return nil, io.EOF
 	}
@@ -149,15 +215,29 @@ func (s *flutterDeviceWatchFlutterEchoConnectRemoteMessageStreamSession) Cancel(
 	return nil
 }
 
-// InvokeFlutterDeviceMessageDescribeFlutter invokes the current registered server using the message contract for DescribeFlutter.
+// InvokeFlutterDeviceMessageDescribeFlutter invokes the server selected by the service route using the message contract for DescribeFlutter.
 func InvokeFlutterDeviceMessageDescribeFlutter(ctx context.Context, req *FlutterEchoRequest) (*FlutterEchoResponse, error) {
 	if req == nil {
 		return nil, errors.New("rpccgo: message request is nil")
 	}
-	registered, err := rpcruntime.LoadServer(flutterDeviceServiceID)
+	var resp *FlutterEchoResponse
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteUnary(ctx, rpcruntime.CallInfo{
+		ServiceID: flutterDeviceServiceID,
+		Method:    "examples.flutter.sharedso.v1.FlutterDevice.DescribeFlutter",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationUnary,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		resp, callErr = invokeFlutterDeviceMessageDescribeFlutter(ctx, registered, req)
+		return callErr
+	})
 	if err != nil {
 		return nil, err
 	}
+	return resp, nil
+}
+
+func invokeFlutterDeviceMessageDescribeFlutter(ctx context.Context, registered rpcruntime.RegisteredServer, req *FlutterEchoRequest) (*FlutterEchoResponse, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(FlutterDeviceCGOMessageServer)
@@ -177,7 +257,9 @@ func InvokeFlutterDeviceMessageDescribeFlutter(ctx context.Context, req *Flutter
 		if !ok {
 			return nil, fmt.Errorf("rpccgo: FlutterDevice connect handler registered server has invalid type")
 		}
-		messageResp, err := server.DescribeFlutter(ctx, req)
+		callCtx, finishMetadata := rpcruntime.ConnectHandlerContext(ctx, "/examples.flutter.sharedso.v1.FlutterDevice/DescribeFlutter")
+		messageResp, err := server.DescribeFlutter(callCtx, req)
+		finishMetadata()
 		if err != nil {
 			return nil, err
 		}
@@ -190,7 +272,9 @@ func InvokeFlutterDeviceMessageDescribeFlutter(ctx context.Context, req *Flutter
 		if !ok {
 			return nil, fmt.Errorf("rpccgo: FlutterDevice connect remote registered server has invalid type")
 		}
-		messageResp, err := server.DescribeFlutter(ctx, req)
+		callCtx, finishMetadata := rpcruntime.ConnectClientContext(ctx)
+		messageResp, err := server.DescribeFlutter(callCtx, req)
+		finishMetadata()
 		if err != nil {
 			return nil, err
 		}
@@ -203,15 +287,29 @@ func InvokeFlutterDeviceMessageDescribeFlutter(ctx context.Context, req *Flutter
 	}
 }
 
-// FlutterDeviceMessageWatchFlutterEchoStart starts a message contract stream for WatchFlutterEcho on the current registered server.
+// FlutterDeviceMessageWatchFlutterEchoStart starts a message contract stream for WatchFlutterEcho on the server selected by the service route.
 func FlutterDeviceMessageWatchFlutterEchoStart(ctx context.Context, req *FlutterEchoRequest) (rpcruntime.StreamHandle, error) {
 	if req == nil {
 		return 0, errors.New("rpccgo: message request is nil")
 	}
-	registered, err := rpcruntime.LoadServer(flutterDeviceServiceID)
+	var streamHandle rpcruntime.StreamHandle
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{
+		ServiceID: flutterDeviceServiceID,
+		Method:    "examples.flutter.sharedso.v1.FlutterDevice.WatchFlutterEcho",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationStart,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		streamHandle, callErr = flutterDeviceMessageWatchFlutterEchoStart(ctx, registered, req)
+		return callErr
+	})
 	if err != nil {
 		return 0, err
 	}
+	return streamHandle, nil
+}
+
+func flutterDeviceMessageWatchFlutterEchoStart(ctx context.Context, registered rpcruntime.RegisteredServer, req *FlutterEchoRequest) (rpcruntime.StreamHandle, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(FlutterDeviceCGOMessageServer)
@@ -222,7 +320,7 @@ func FlutterDeviceMessageWatchFlutterEchoStart(ctx context.Context, req *Flutter
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)
 	case rpcruntime.ServerKindConnect:
 		server, ok := registered.Server.(FlutterDeviceHandler)
 		if !ok {
@@ -232,7 +330,7 @@ func FlutterDeviceMessageWatchFlutterEchoStart(ctx context.Context, req *Flutter
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnect, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnect, source)
 	case rpcruntime.ServerKindConnectRemote:
 		server, ok := registered.Server.(FlutterDeviceClient)
 		if !ok {
@@ -242,7 +340,7 @@ func FlutterDeviceMessageWatchFlutterEchoStart(ctx context.Context, req *Flutter
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnectRemote, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnectRemote, source)
 	default:
 		return 0, fmt.Errorf("rpccgo: FlutterDevice registered server kind %d is unsupported for message stream starts", registered.Kind)
 	}
@@ -24,10 +24,30 @@ type FlutterDeviceCGOMessageServer interface {
 
 // FlutterDeviceMessageWatchFlutterEchoRecv receives a message response from an active WatchFlutterEcho stream.
 func FlutterDeviceMessageWatchFlutterEchoRecv(ctx context.Context, handle rpcruntime.StreamHandle) (*FlutterEchoResponse, error) {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return nil, err
 	}
+	var resp *FlutterEchoResponse
+	err = rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: flutterDeviceServiceID,
+		Method:    "examples.flutter.sharedso.v1.FlutterDevice.WatchFlutterEcho",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationRecv,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		var callErr error
+		resp, callErr = flutterDeviceMessageWatchFlutterEchoRecv(ctx, handle, entry)
+		return callErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func flutterDeviceMessageWatchFlutterEchoRecv(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) (*FlutterEchoResponse, error) {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*FlutterEchoResponse])
@@ -102,10 +122,23 @@ func FlutterDeviceMessageWatchFlutterEchoRecv(ctx context.Context, handle rpcrun
 
 // FlutterDeviceMessageWatchFlutterEchoCancel cancels an active message WatchFlutterEcho stream and releases its handle.
 func FlutterDeviceMessageWatchFlutterEchoCancel(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: flutterDeviceServiceID,
+		Method:    "examples.flutter.sharedso.v1.FlutterDevice.WatchFlutterEcho",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationCancel,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return flutterDeviceMessageWatchFlutterEchoCancel(ctx, handle, entry)
+	})
+}
+
+func flutterDeviceMessageWatchFlutterEchoCancel(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*FlutterEchoResponse])
@@ -115,7 +148,7 @@ func FlutterDeviceMessageWatchFlutterEchoCancel(ctx context.Context, handle rpcr
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnect:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*FlutterEchoResponse])
@@ -125,7 +158,7 @@ func FlutterDeviceMessageWatchFlutterEchoCancel(ctx context.Context, handle rpcr
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnectRemote:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*FlutterEchoResponse])
@@ -135,7 +168,7 @@ func FlutterDeviceMessageWatchFlutterEchoCancel(ctx context.Context, handle rpcr
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	default:
 		return fmt.Errorf("rpccgo: FlutterDevice message stream session kind %d is unsupported", entry.Kind)
@@ -157,25 +190,30 @@ func (UnimplementedFlutterDeviceCGOMessageServer) WatchFlutterEcho(ctx context.C
 
 // RegisterFlutterDeviceCGOMessageServer registers a cgo message server as the current server for FlutterDevice.
 func RegisterFlutterDeviceCGOMessageServer(server FlutterDeviceCGOMessageServer) error {
+	return RegisterFlutterDeviceCGOMessageServerIn(rpcruntime.DefaultServerRegistry(), server)
+}
+
+// RegisterFlutterDeviceCGOMessageServerIn registers a cgo message server as the current server for FlutterDevice in registry.
+func RegisterFlutterDeviceCGOMessageServerIn(registry *rpcruntime.ServerRegistry, server FlutterDeviceCGOMessageServer) error {
 	if server == nil {
-		_ = registerFlutterDeviceCGOMessageServer(server)
+		_ = registerFlutterDeviceCGOMessageServerIn(registry, server)
 		return errors.New("rpccgo: FlutterDevice cgo message server is nil")
 	}
-	return registerFlutterDeviceCGOMessageServer(server)
+	return registerFlutterDeviceCGOMessageServerIn(registry, server)
 }
 
-// registerFlutterDeviceCGOMessageServer registers the supplied cgo message server as the current server for this service.
-func registerFlutterDeviceCGOMessageServer(server FlutterDeviceCGOMessageServer) error {
+// registerFlutterDeviceCGOMessageServerIn registers the supplied cgo message server as the current server for this service in registry.
+func registerFlutterDeviceCGOMessageServerIn(registry *rpcruntime.ServerRegistry, server FlutterDeviceCGOMessageServer) error {
 	if server == nil {
-		_ = rpcruntime.ClearServer(flutterDeviceServiceID)
+		_ = registry.Clear(flutterDeviceServiceID)
 		return FlutterDeviceMessageServerUnavailableErr
 	}
-	err := rpcruntime.RegisterServer(flutterDeviceServiceID, rpcruntime.RegisteredServer{
+	err := registry.Register(flutterDeviceServiceID, rpcruntime.RegisteredServer{
 		Kind:   rpcruntime.ServerKindCGOMessage,
 		Server: server,
 	})
 	if err != nil {
-		_ = rpcruntime.ClearServer(flutterDeviceServiceID)
+		_ = registry.Clear(flutterDeviceServiceID)
 		return err
 	}
 	return nil
@@ -191,11 +229,13 @@ func flutterDeviceWatchFlutterEchoCGOMessageStart(ctx context.Context, server Fl
 		return direct.WatchFlutterEchoStart(ctx, req)
 	}
 	client, stream, streamCtx := rpcruntime.NewServerStreaming[*FlutterEchoResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:         "examples.flutter.sharedso.v1.FlutterDevice.WatchFlutterEcho",
 		ResponseBuffer: 1,
 		StreamClosed:   errors.New("rpccgo: message stream is closed"),
 		NilResponse:    errors.New("rpccgo: message response is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.FlutterDevice.WatchFlutterEcho handler", func(err error) { stream.Complete(err) })
 		err := server.WatchFlutterEcho(streamCtx, req, stream)
 		stream.Complete(err)
 	}()
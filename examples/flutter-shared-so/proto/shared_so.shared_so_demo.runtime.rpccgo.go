@@ -21,28 +21,88 @@ const sharedSoDemoServiceID rpcruntime.ServiceID = "examples.flutter.sharedso.v1
 // SharedSoDemoMessageServerUnavailableErr is returned when a message server registration is missing or invalid.
 var SharedSoDemoMessageServerUnavailableErr = errors.New("rpccgo: message server is unavailable")
 
+func init() {
+	rpcruntime.RegisterServiceManifest(rpcruntime.ServiceManifest{
+		ServiceID:        sharedSoDemoServiceID,
+		ProtoFile:        "shared_so.proto",
+		Native:           false,
+		MessageTransport: rpcruntime.ServerKindConnect,
+		Methods: []rpcruntime.MethodManifest{
+			{Name: "ComposeGreeting", FullName: "examples.flutter.sharedso.v1.SharedSoDemo.ComposeGreeting", Streaming: rpcruntime.MethodStreamingUnary, RequestType: "examples.flutter.sharedso.v1.ComposeGreetingRequest", ResponseType: "examples.flutter.sharedso.v1.ComposeGreetingResponse"},
+			{Name: "IncrementRuntimeState", FullName: "examples.flutter.sharedso.v1.SharedSoDemo.IncrementRuntimeState", Streaming: rpcruntime.MethodStreamingUnary, RequestType: "examples.flutter.sharedso.v1.IncrementRuntimeStateRequest", ResponseType: "examples.flutter.sharedso.v1.RuntimeStateResponse"},
+			{Name: "ReadRuntimeState", FullName: "examples.flutter.sharedso.v1.SharedSoDemo.ReadRuntimeState", Streaming: rpcruntime.MethodStreamingUnary, RequestType: "examples.flutter.sharedso.v1.ReadRuntimeStateRequest", ResponseType: "examples.flutter.sharedso.v1.RuntimeStateResponse"},
+			{Name: "WatchRuntimeState", FullName: "examples.flutter.sharedso.v1.SharedSoDemo.WatchRuntimeState", Streaming: rpcruntime.MethodStreamingServer, RequestType: "examples.flutter.sharedso.v1.ReadRuntimeStateRequest", ResponseType: "examples.flutter.sharedso.v1.RuntimeStateResponse"},
+			{Name: "CollectRuntimeState", FullName: "examples.flutter.sharedso.v1.SharedSoDemo.CollectRuntimeState", Streaming: rpcruntime.MethodStreamingClient, RequestType: "examples.flutter.sharedso.v1.IncrementRuntimeStateRequest", ResponseType: "examples.flutter.sharedso.v1.RuntimeStateResponse"},
+			{Name: "StreamRuntimeState", FullName: "examples.flutter.sharedso.v1.SharedSoDemo.StreamRuntimeState", Streaming: rpcruntime.MethodStreamingServer, RequestType: "examples.flutter.sharedso.v1.ReadRuntimeStateRequest", ResponseType: "examples.flutter.sharedso.v1.RuntimeStateResponse"},
+			{Name: "ChatRuntimeState", FullName: "examples.flutter.sharedso.v1.SharedSoDemo.ChatRuntimeState", Streaming: rpcruntime.MethodStreamingBidi, RequestType: "examples.flutter.sharedso.v1.IncrementRuntimeStateRequest", ResponseType: "examples.flutter.sharedso.v1.RuntimeStateResponse"},
+		},
+	})
+	rpcruntime.RegisterDynamicMethod("examples.flutter.sharedso.v1.SharedSoDemo.ComposeGreeting", rpcruntime.DynamicUnary(InvokeSharedSoDemoMessageComposeGreeting))
+	rpcruntime.RegisterDynamicMethod("examples.flutter.sharedso.v1.SharedSoDemo.IncrementRuntimeState", rpcruntime.DynamicUnary(InvokeSharedSoDemoMessageIncrementRuntimeState))
+	rpcruntime.RegisterDynamicMethod("examples.flutter.sharedso.v1.SharedSoDemo.ReadRuntimeState", rpcruntime.DynamicUnary(InvokeSharedSoDemoMessageReadRuntimeState))
+	rpcruntime.RegisterDynamicMethod("examples.flutter.sharedso.v1.SharedSoDemo.WatchRuntimeState", rpcruntime.DynamicServerStream(SharedSoDemoMessageWatchRuntimeStateStart, SharedSoDemoMessageWatchRuntimeStateRecv, SharedSoDemoMessageWatchRuntimeStateCancel))
+	rpcruntime.RegisterDynamicMethod("examples.flutter.sharedso.v1.SharedSoDemo.CollectRuntimeState", rpcruntime.DynamicClientStream(SharedSoDemoMessageCollectRuntimeStateStart, SharedSoDemoMessageCollectRuntimeStateSend, SharedSoDemoMessageCollectRuntimeStateFinish, SharedSoDemoMessageCollectRuntimeStateCancel))
+	rpcruntime.RegisterDynamicMethod("examples.flutter.sharedso.v1.SharedSoDemo.StreamRuntimeState", rpcruntime.DynamicServerStream(SharedSoDemoMessageStreamRuntimeStateStart, SharedSoDemoMessageStreamRuntimeStateRecv, SharedSoDemoMessageStreamRuntimeStateCancel))
+	rpcruntime.RegisterDynamicMethod("examples.flutter.sharedso.v1.SharedSoDemo.ChatRuntimeState", rpcruntime.DynamicBidiStream(SharedSoDemoMessageChatRuntimeStateStart, SharedSoDemoMessageChatRuntimeStateSend, SharedSoDemoMessageChatRuntimeStateRecv, SharedSoDemoMessageChatRuntimeStateCloseSend, SharedSoDemoMessageChatRuntimeStateFinish, SharedSoDemoMessageChatRuntimeStateCancel))
+}
+
 // ClearSharedSoDemoServer clears the current registered server for this service.
 func ClearSharedSoDemoServer() error {
-	return rpcruntime.ClearServer(sharedSoDemoServiceID)
+	return ClearSharedSoDemoServerIn(rpcruntime.DefaultServerRegistry())
+}
+
+// ClearSharedSoDemoServerIn clears the current registered server for this service in registry.
+func ClearSharedSoDemoServerIn(registry *rpcruntime.ServerRegistry) error {
+	return registry.Clear(sharedSoDemoServiceID)
 }
 
-// LoadSharedSoDemoRegisteredServer loads the current registered server record for this service.
+// LoadSharedSoDemoRegisteredServer loads the current registered server record for this service. With a multi-server route it returns the first target.
 func LoadSharedSoDemoRegisteredServer() (rpcruntime.RegisteredServer, error) {
-	return rpcruntime.LoadServer(sharedSoDemoServiceID)
+	return LoadSharedSoDemoRegisteredServerIn(rpcruntime.DefaultServerRegistry())
+}
+
+// LoadSharedSoDemoRegisteredServerIn loads the current registered server record for this service from registry.
+func LoadSharedSoDemoRegisteredServerIn(registry *rpcruntime.ServerRegistry) (rpcruntime.RegisteredServer, error) {
+	return registry.Load(sharedSoDemoServiceID)
+}
+
+// RegisterSharedSoDemoServerRoute replaces the registered servers for this service with an ordered or weighted route. Targets are records loaded after the generated Register helpers.
+func RegisterSharedSoDemoServerRoute(route rpcruntime.ServerRoute) error {
+	return RegisterSharedSoDemoServerRouteIn(rpcruntime.DefaultServerRegistry(), route)
+}
+
+// RegisterSharedSoDemoServerRouteIn replaces the registered servers for this service in registry with an ordered or weighted route.
+func RegisterSharedSoDemoServerRouteIn(registry *rpcruntime.ServerRegistry, route rpcruntime.ServerRoute) error {
+	return registry.RegisterRoute(sharedSoDemoServiceID, route)
+}
+
+// LoadSharedSoDemoServerRoute loads the registered server route for this service.
+func LoadSharedSoDemoServerRoute() (rpcruntime.ServerRoute, error) {
+	return LoadSharedSoDemoServerRouteIn(rpcruntime.DefaultServerRegistry())
+}
+
+// LoadSharedSoDemoServerRouteIn loads the registered server route for this service from registry.
+func LoadSharedSoDemoServerRouteIn(registry *rpcruntime.ServerRegistry) (rpcruntime.ServerRoute, error) {
+	return registry.LoadRoute(sharedSoDemoServiceID)
 }
 
 // RegisterSharedSoDemoConnectHandler registers the supplied connect handler server as the current server for this service.
 func RegisterSharedSoDemoConnectHandler(handler SharedSoDemoHandler) error {
+	return RegisterSharedSoDemoConnectHandlerIn(rpcruntime.DefaultServerRegistry(), handler)
+}
+
+// RegisterSharedSoDemoConnectHandlerIn registers the supplied connect handler server as the current server for this service in registry.
+func RegisterSharedSoDemoConnectHandlerIn(registry *rpcruntime.ServerRegistry, handler SharedSoDemoHandler) error {
 	if handler == nil {
-		_ = rpcruntime.ClearServer(sharedSoDemoServiceID)
+		_ = registry.Clear(sharedSoDemoServiceID)
 		return SharedSoDemoMessageServerUnavailableErr
 	}
-	err := rpcruntime.RegisterServer(sharedSoDemoServiceID, rpcruntime.RegisteredServer{
+	err := registry.Register(sharedSoDemoServiceID, rpcruntime.RegisteredServer{
 		Kind:   rpcruntime.ServerKindConnect,
 		Server: handler,
 	})
 	if err != nil {
-		_ = rpcruntime.ClearServer(sharedSoDemoServiceID)
+		_ = registry.Clear(sharedSoDemoServiceID)
 		return err
 	}
 	return nil
@@ -50,16 +110,21 @@ func RegisterSharedSoDemoConnectHandler(handler SharedSoDemoHandler) error {
 
 // RegisterSharedSoDemoConnectRemoteServer registers the supplied connect remote server as the current server for this service.
 func RegisterSharedSoDemoConnectRemoteServer(client SharedSoDemoClient) error {
+	return RegisterSharedSoDemoConnectRemoteServerIn(rpcruntime.DefaultServerRegistry(), client)
+}
+
+// RegisterSharedSoDemoConnectRemoteServerIn registers the supplied connect remote server as the current server for this service in registry.
+func RegisterSharedSoDemoConnectRemoteServerIn(registry *rpcruntime.ServerRegistry, client SharedSoDemoClient) error {
 	if client == nil {
-		_ = rpcruntime.ClearServer(sharedSoDemoServiceID)
+		_ = registry.Clear(sharedSoDemoServiceID)
 		return SharedSoDemoMessageServerUnavailableErr
 	}
-	err := rpcruntime.RegisterServer(sharedSoDemoServiceID, rpcruntime.RegisteredServer{
+	err := registry.Register(sharedSoDemoServiceID, rpcruntime.RegisteredServer{
 		Kind:   rpcruntime.ServerKindConnectRemote,
 		Server: client,
 	})
 	if err != nil {
-		_ = rpcruntime.ClearServer(sharedSoDemoServiceID)
+		_ = registry.Clear(sharedSoDemoServiceID)
 		return err
 	}
 	return nil
@@ -70,11 +135,13 @@ func newsharedSoDemoWatchRuntimeStateConnectDirectMessageStreamSession(ctx conte
 		return nil, errors.New("rpccgo: message request is nil")
 	}
 	client, stream, streamCtx := rpcruntime.NewServerStreaming[*RuntimeStateResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:         "examples.flutter.sharedso.v1.SharedSoDemo.WatchRuntimeState",
 		ResponseBuffer: 1,
 		StreamClosed:   errors.New("rpccgo: message stream is closed"),
 		NilResponse:    errors.New("rpccgo: message response is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.SharedSoDemo.WatchRuntimeState handler", func(err error) { stream.Complete(err) })
 		conn := &rpcruntime.ConnectStreamingHandlerConn{SendFunc: func(message any) error {
 			resp, ok := message.(*RuntimeStateResponse)
 			if !ok || resp == nil {
@@ -82,7 +149,10 @@ func newsharedSoDemoWatchRuntimeStateConnectDirectMessageStreamSession(ctx conte
 			}
 			return stream.Send(streamCtx, resp)
 		}}
-		stream.Complete(handler.WatchRuntimeState(streamCtx, req, rpcruntime.NewConnectServerStream[RuntimeStateResponse](conn)))
+		handlerCtx, finishMetadata := rpcruntime.ConnectStreamingHandlerContext(streamCtx, conn)
+		err := handler.WatchRuntimeState(handlerCtx, req, rpcruntime.NewConnectServerStream[RuntimeStateResponse](conn))
+		finishMetadata()
+		stream.Complete(err)
 	}()
 	return client, nil
 }
@@ -94,30 +164,36 @@ func newsharedSoDemoWatchRuntimeStateConnectRemoteMessageStreamSession(ctx conte
 		return nil, errors.New("rpccgo: message request is nil")
 	}
 	streamCtx, cancel := context.WithCancel(ctx)
-	stream, err := client.WatchRuntimeState(streamCtx, req)
+	callCtx, finishMetadata := rpcruntime.ConnectClientContext(streamCtx)
+	stream, err := client.WatchRuntimeState(callCtx, req)
 	if err != nil {
 		cancel()
 		return nil, err
 	}
-	return &sharedSoDemoWatchRuntimeStateConnectRemoteMessageStreamSession{stream: stream, cancel: cancel}, nil
+	return &sharedSoDemoWatchRuntimeStateConnectRemoteMessageStreamSession{stream: stream, cancel: cancel, finishMetadata: finishMetadata}, nil
 }
 
 type sharedSoDemoWatchRuntimeStateConnectRemoteMessageStreamSession struct {
-	stream *connect.ServerStreamForClient[RuntimeStateResponse]
-	cancel context.CancelFunc
+	stream         *connect.ServerStreamForClient[RuntimeStateResponse]
+	cancel         context.CancelFunc
+	finishMetadata func()
 }
 
 func (s *sharedSoDemoWatchRuntimeStateConnectRemoteMessageStreamSession) Recv(ctx context.Context) (*RuntimeStateResponse, error) {
-	_ = ctx
 	if s == nil {
 		return nil, errors.New("rpccgo: connect remote server stream is nil")
 	}
 	if s.stream == nil {
 		return nil, errors.New("rpccgo: connect remote server stream is nil")
 	}
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
 	if !s.stream.Receive() {
+		s.finishMetadata()
 		if err := s.stream.Err(); err != nil {
-			return nil, err
+			return nil, rpcruntime.ContextError(ctx, err)
 		}
 		return nil, io.EOF
 	}
@@ -152,11 +228,13 @@ func (s *sharedSoDemoWatchRuntimeStateConnectRemoteMessageStreamSession) Cancel(
 
 func newsharedSoDemoCollectRuntimeStateConnectDirectMessageStreamSession(ctx context.Context, handler SharedSoDemoHandler) rpcruntime.ClientStreamingClient[*IncrementRuntimeStateRequest, *RuntimeStateResponse] {
 	client, stream, streamCtx := rpcruntime.NewClientStreaming[*IncrementRuntimeStateRequest, *RuntimeStateResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:        "examples.flutter.sharedso.v1.SharedSoDemo.CollectRuntimeState",
 		RequestBuffer: 16,
 		StreamClosed:  errors.New("rpccgo: message stream is closed"),
 		NilRequest:    errors.New("rpccgo: message request is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.SharedSoDemo.CollectRuntimeState handler", func(err error) { stream.Complete(nil, err) })
 		conn := &rpcruntime.ConnectStreamingHandlerConn{ReceiveFunc: func(message any) error {
 			target, ok := message.(*IncrementRuntimeStateRequest)
 			if !ok || target == nil {
@@ -169,7 +247,9 @@ func newsharedSoDemoCollectRuntimeStateConnectDirectMessageStreamSession(ctx con
 			*target = *req
 			return nil
 		}}
-		resp, err := handler.CollectRuntimeState(streamCtx, rpcruntime.NewConnectClientStream[IncrementRuntimeStateRequest](conn))
+		handlerCtx, finishMetadata := rpcruntime.ConnectStreamingHandlerContext(streamCtx, conn)
+		resp, err := handler.CollectRuntimeState(handlerCtx, rpcruntime.NewConnectClientStream[IncrementRuntimeStateRequest](conn))
+		finishMetadata()
 		stream.Complete(resp, err)
 	}()
 	return client
@@ -179,46 +259,55 @@ func newsharedSoDemoCollectRuntimeStateConnectRemoteMessageStreamSession(ctx con
 	CollectRuntimeState(context.Context) (*connect.ClientStreamForClientSimple[IncrementRuntimeStateRequest, RuntimeStateResponse], error)
 }) (*sharedSoDemoCollectRuntimeStateConnectRemoteMessageStreamSession, error) {
 	streamCtx, cancel := context.WithCancel(ctx)
-	stream, err := client.CollectRuntimeState(streamCtx)
+	callCtx, finishMetadata := rpcruntime.ConnectClientContext(streamCtx)
+	stream, err := client.CollectRuntimeState(callCtx)
 	if err != nil {
 		cancel()
 		return nil, err
 	}
-	return &sharedSoDemoCollectRuntimeStateConnectRemoteMessageStreamSession{stream: stream, cancel: cancel}, nil
+	return &sharedSoDemoCollectRuntimeStateConnectRemoteMessageStreamSession{stream: stream, cancel: cancel, finishMetadata: finishMetadata}, nil
 }
 
 type sharedSoDemoCollectRuntimeStateConnectRemoteMessageStreamSession struct {
-	stream *connect.ClientStreamForClientSimple[IncrementRuntimeStateRequest, RuntimeStateResponse]
-	cancel context.CancelFunc
+	stream         *connect.ClientStreamForClientSimple[IncrementRuntimeStateRequest, RuntimeStateResponse]
+	cancel         context.CancelFunc
+	finishMetadata func()
 }
 
 func (s *sharedSoDemoCollectRuntimeStateConnectRemoteMessageStreamSession) Send(ctx context.Context, req *IncrementRuntimeStateRequest) error {
-	_ = ctx
 	if req == nil {
 		return errors.New("rpccgo: message request is nil")
 	}
 	if s == nil || s.stream == nil {
 		return errors.New("rpccgo: connect remote client stream is nil")
 	}
-	return s.stream.Send(req)
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
+	return rpcruntime.ContextError(ctx, s.stream.Send(req))
 }
 
 func (s *sharedSoDemoCollectRuntimeStateConnectRemoteMessageStreamSession) Finish(ctx context.Context) (*RuntimeStateResponse, error) {
-	_ = ctx
 	if s == nil {
 		return nil, errors.New("rpccgo: connect remote client stream is nil")
 	}
 	if s.stream == nil {
 		return nil, errors.New("rpccgo: connect remote client stream is nil")
 	}
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
 	defer func() {
 		if s.cancel != nil {
 			s.cancel()
 		}
 	}()
 	resp, err := s.stream.CloseAndReceive()
+	s.finishMetadata()
 	if err != nil {
-		return nil, err
+		return nil, rpcruntime.ContextError(ctx, err)
 	}
 	if resp == nil {
 		return nil, errors.New("rpccgo: message response is nil")
@@ -261,11 +350,13 @@ func newsharedSoDemoStreamRuntimeStateConnectDirectMessageStreamSession(ctx cont
 		return nil, errors.New("rpccgo: message request is nil")
 	}
 	client, stream, streamCtx := rpcruntime.NewServerStreaming[*RuntimeStateResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:         "examples.flutter.sharedso.v1.SharedSoDemo.StreamRuntimeState",
 		ResponseBuffer: 1,
 		StreamClosed:   errors.New("rpccgo: message stream is closed"),
 		NilResponse:    errors.New("rpccgo: message response is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.SharedSoDemo.StreamRuntimeState handler", func(err error) { stream.Complete(err) })
 		conn := &rpcruntime.ConnectStreamingHandlerConn{SendFunc: func(message any) error {
 			resp, ok := message.(*RuntimeStateResponse)
 			if !ok || resp == nil {
@@ -273,7 +364,10 @@ func newsharedSoDemoStreamRuntimeStateConnectDirectMessageStreamSession(ctx cont
 			}
 			return stream.Send(streamCtx, resp)
 		}}
-		stream.Complete(handler.StreamRuntimeState(streamCtx, req, rpcruntime.NewConnectServerStream[RuntimeStateResponse](conn)))
+		handlerCtx, finishMetadata := rpcruntime.ConnectStreamingHandlerContext(streamCtx, conn)
+		err := handler.StreamRuntimeState(handlerCtx, req, rpcruntime.NewConnectServerStream[RuntimeStateResponse](conn))
+		finishMetadata()
+		stream.Complete(err)
 	}()
 	return client, nil
 }
@@ -285,30 +379,36 @@ func newsharedSoDemoStreamRuntimeStateConnectRemoteMessageStreamSession(ctx cont
 		return nil, errors.New("rpccgo: message request is nil")
 	}
 	streamCtx, cancel := context.WithCancel(ctx)
-	stream, err := client.StreamRuntimeState(streamCtx, req)
+	callCtx, finishMetadata := rpcruntime.ConnectClientContext(streamCtx)
+	stream, err := client.StreamRuntimeState(callCtx, req)
 	if err != nil {
 		cancel()
 		return nil, err
 	}
-	return &sharedSoDemoStreamRuntimeStateConnectRemoteMessageStreamSession{stream: stream, cancel: cancel}, nil
+	return &sharedSoDemoStreamRuntimeStateConnectRemoteMessageStreamSession{stream: stream, cancel: cancel, finishMetadata: finishMetadata}, nil
 }
 
 type sharedSoDemoStreamRuntimeStateConnectRemoteMessageStreamSession struct {
-	stream *connect.ServerStreamForClient[RuntimeStateResponse]
-	cancel context.CancelFunc
+	stream         *connect.ServerStreamForClient[RuntimeStateResponse]
+	cancel         context.CancelFunc
+	finishMetadata func()
 }
 
 func (s *sharedSoDemoStreamRuntimeStateConnectRemoteMessageStreamSession) Recv(ctx context.Context) (*RuntimeStateResponse, error) {
-	_ = ctx
 	if s == nil {
 		return nil, errors.New("rpccgo: connect remote server stream is nil")
 	}
 	if s.stream == nil {
 		return nil, errors.New("rpccgo: connect remote server stream is nil")
 	}
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
 	if !s.stream.Receive() {
+		s.finishMetadata()
 		if err := s.stream.Err(); err != nil {
-			return nil, err
+			return nil, rpcruntime.ContextError(ctx, err)
 		}
 		return nil, io.EOF
 	}
@@ -343,6 +443,7 @@ func (s *sharedSoDemoStreamRuntimeStateConnectRemoteMessageStreamSession) Cancel
 
 func newsharedSoDemoChatRuntimeStateConnectDirectMessageStreamSession(ctx context.Context, handler SharedSoDemoHandler) rpcruntime.BidiStreamingClient[*IncrementRuntimeStateRequest, *RuntimeStateResponse] {
 	client, stream, streamCtx := rpcruntime.NewBidiStreaming[*IncrementRuntimeStateRequest, *RuntimeStateResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:         "examples.flutter.sharedso.v1.SharedSoDemo.ChatRuntimeState",
 		RequestBuffer:  16,
 		ResponseBuffer: 1,
 		StreamClosed:   errors.New("rpccgo: message stream is closed"),
@@ -350,6 +451,7 @@ func newsharedSoDemoChatRuntimeStateConnectDirectMessageStreamSession(ctx contex
 		NilResponse:    errors.New("rpccgo: message response is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.SharedSoDemo.ChatRuntimeState handler", func(err error) { stream.Complete(err) })
 		conn := &rpcruntime.ConnectStreamingHandlerConn{
 			ReceiveFunc: func(message any) error {
 				target, ok := message.(*IncrementRuntimeStateRequest)
@@ -371,7 +473,10 @@ func newsharedSoDemoChatRuntimeStateConnectDirectMessageStreamSession(ctx contex
 				return stream.Send(streamCtx, resp)
 			},
 		}
-		stream.Complete(handler.ChatRuntimeState(streamCtx, rpcruntime.NewConnectBidiStream[IncrementRuntimeStateRequest, RuntimeStateResponse](conn)))
+		handlerCtx, finishMetadata := rpcruntime.ConnectStreamingHandlerContext(streamCtx, conn)
+		err := handler.ChatRuntimeState(handlerCtx, rpcruntime.NewConnectBidiStream[IncrementRuntimeStateRequest, RuntimeStateResponse](conn))
+		finishMetadata()
+		stream.Complete(err)
 	}()
 	return client
 }
@@ -380,38 +485,47 @@ func newsharedSoDemoChatRuntimeStateConnectRemoteMessageStreamSession(ctx contex
 	ChatRuntimeState(context.Context) (*connect.BidiStreamForClientSimple[IncrementRuntimeStateRequest, RuntimeStateResponse], error)
 }) (*sharedSoDemoChatRuntimeStateConnectRemoteMessageStreamSession, error) {
 	streamCtx, cancel := context.WithCancel(ctx)
-	stream, err := client.ChatRuntimeState(streamCtx)
+	callCtx, finishMetadata := rpcruntime.ConnectClientContext(streamCtx)
+	stream, err := client.ChatRuntimeState(callCtx)
 	if err != nil {
 		cancel()
 		return nil, err
 	}
-	return &sharedSoDemoChatRuntimeStateConnectRemoteMessageStreamSession{stream: stream, cancel: cancel}, nil
+	return &sharedSoDemoChatRuntimeStateConnectRemoteMessageStreamSession{stream: stream, cancel: cancel, finishMetadata: finishMetadata}, nil
 }
 
 type sharedSoDemoChatRuntimeStateConnectRemoteMessageStreamSession struct {
-	stream *connect.BidiStreamForClientSimple[IncrementRuntimeStateRequest, RuntimeStateResponse]
-	cancel context.CancelFunc
+	stream         *connect.BidiStreamForClientSimple[IncrementRuntimeStateRequest, RuntimeStateResponse]
+	cancel         context.CancelFunc
+	finishMetadata func()
 }
 
 func (s *sharedSoDemoChatRuntimeStateConnectRemoteMessageStreamSession) Send(ctx context.Context, req *IncrementRuntimeStateRequest) error {
-	_ = ctx
 	if req == nil {
 		return errors.New("rpccgo: message request is nil")
 	}
 	if s == nil || s.stream == nil {
 		return errors.New("rpccgo: connect remote bidi stream is nil")
 	}
-	return s.stream.Send(req)
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
+	return rpcruntime.ContextError(ctx, s.stream.Send(req))
 }
 
 func (s *sharedSoDemoChatRuntimeStateConnectRemoteMessageStreamSession) Recv(ctx context.Context) (*RuntimeStateResponse, error) {
-	_ = ctx
 	if s == nil || s.stream == nil {
 		return nil, errors.New("rpccgo: connect remote bidi stream is nil")
 	}
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
 	resp, err := s.stream.Receive()
 	if err != nil {
-		return nil, err
+		s.finishMetadata()
+		return nil, rpcruntime.ContextError(ctx, err)
 	}
 	if resp == nil {
 		return nil, errors.New("rpccgo: message response is nil")
@@ -449,15 +563,29 @@ func (s *sharedSoDemoChatRuntimeStateConnectRemoteMessageStreamSession) CloseSen
 	return s.stream.CloseRequest()
 }
 
-// InvokeSharedSoDemoMessageComposeGreeting invokes the current registered server using the message contract for ComposeGreeting.
+// InvokeSharedSoDemoMessageComposeGreeting invokes the server selected by the service route using the message contract for ComposeGreeting.
 func InvokeSharedSoDemoMessageComposeGreeting(ctx context.Context, req *ComposeGreetingRequest) (*ComposeGreetingResponse, error) {
 	if req == nil {
 		return nil, errors.New("rpccgo: message request is nil")
 	}
-	registered, err := rpcruntime.LoadServer(sharedSoDemoServiceID)
+	var resp *ComposeGreetingResponse
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteUnary(ctx, rpcruntime.CallInfo{
+		ServiceID: sharedSoDemoServiceID,
+		Method:    "examples.flutter.sharedso.v1.SharedSoDemo.ComposeGreeting",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationUnary,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		resp, callErr = invokeSharedSoDemoMessageComposeGreeting(ctx, registered, req)
+		return callErr
+	})
 	if err != nil {
 		return nil, err
 	}
+	return resp, nil
+}
+
+func invokeSharedSoDemoMessageComposeGreeting(ctx context.Context, registered rpcruntime.RegisteredServer, req *ComposeGreetingRequest) (*ComposeGreetingResponse, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(SharedSoDemoCGOMessageServer)
@@ -477,7 +605,9 @@ func InvokeSharedSoDemoMessageComposeGreeting(ctx context.Context, req *ComposeG
 		if !ok {
 			return nil, fmt.Errorf("rpccgo: SharedSoDemo connect handler registered server has invalid type")
 		}
-		messageResp, err := server.ComposeGreeting(ctx, req)
+		callCtx, finishMetadata := rpcruntime.ConnectHandlerContext(ctx, "/examples.flutter.sharedso.v1.SharedSoDemo/ComposeGreeting")
+		messageResp, err := server.ComposeGreeting(callCtx, req)
+		finishMetadata()
 		if err != nil {
 			return nil, err
 		}
@@ -490,7 +620,9 @@ func InvokeSharedSoDemoMessageComposeGreeting(ctx context.Context, req *ComposeG
 		if !ok {
 			return nil, fmt.Errorf("rpccgo: SharedSoDemo connect remote registered server has invalid type")
 		}
-		messageResp, err := server.ComposeGreeting(ctx, req)
+		callCtx, finishMetadata := rpcruntime.ConnectClientContext(ctx)
+		messageResp, err := server.ComposeGreeting(callCtx, req)
+		finishMetadata()
 		if err != nil {
 			return nil, err
 		}
@@ -503,15 +635,29 @@ func InvokeSharedSoDemoMessageComposeGreeting(ctx context.Context, req *ComposeG
 	}
 }
 
-// InvokeSharedSoDemoMessageIncrementRuntimeState invokes the current registered server using the message contract for IncrementRuntimeState.
+// InvokeSharedSoDemoMessageIncrementRuntimeState invokes the server selected by the service route using the message contract for IncrementRuntimeState.
 func InvokeSharedSoDemoMessageIncrementRuntimeState(ctx context.Context, req *IncrementRuntimeStateRequest) (*RuntimeStateResponse, error) {
 	if req == nil {
 		return nil, errors.New("rpccgo: message request is nil")
 	}
-	registered, err := rpcruntime.LoadServer(sharedSoDemoServiceID)
+	var resp *RuntimeStateResponse
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteUnary(ctx, rpcruntime.CallInfo{
+		ServiceID: sharedSoDemoServiceID,
+		Method:    "examples.flutter.sharedso.v1.SharedSoDemo.IncrementRuntimeState",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationUnary,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		resp, callErr = invokeSharedSoDemoMessageIncrementRuntimeState(ctx, registered, req)
+		return callErr
+	})
 	if err != nil {
 		return nil, err
 	}
+	return resp, nil
+}
+
+func invokeSharedSoDemoMessageIncrementRuntimeState(ctx context.Context, registered rpcruntime.RegisteredServer, req *IncrementRuntimeStateRequest) (*RuntimeStateResponse, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(SharedSoDemoCGOMessageServer)
@@ -531,7 +677,9 @@ func InvokeSharedSoDemoMessageIncrementRuntimeState(ctx context.Context, req *In
 		if !ok {
 			return nil, fmt.Errorf("rpccgo: SharedSoDemo connect handler registered server has invalid type")
 		}
-		messageResp, err := server.IncrementRuntimeState(ctx, req)
+		callCtx, finishMetadata := rpcruntime.ConnectHandlerContext(ctx, "/examples.flutter.sharedso.v1.SharedSoDemo/IncrementRuntimeState")
+		messageResp, err := server.IncrementRuntimeState(callCtx, req)
+		finishMetadata()
 		if err != nil {
 			return nil, err
 		}
@@ -544,7 +692,9 @@ func InvokeSharedSoDemoMessageIncrementRuntimeState(ctx context.Context, req *In
 		if !ok {
 			return nil, fmt.Errorf("rpccgo: SharedSoDemo connect remote registered server has invalid type")
 		}
-		messageResp, err := server.IncrementRuntimeState(ctx, req)
+		callCtx, finishMetadata := rpcruntime.ConnectClientContext(ctx)
+		messageResp, err := server.IncrementRuntimeState(callCtx, req)
+		finishMetadata()
 		if err != nil {
 			return nil, err
 		}
@@ -557,15 +707,29 @@ func InvokeSharedSoDemoMessageIncrementRuntimeState(ctx context.Context, req *In
 	}
 }
 
-// InvokeSharedSoDemoMessageReadRuntimeState invokes the current registered server using the message contract for ReadRuntimeState.
+// InvokeSharedSoDemoMessageReadRuntimeState invokes the server selected by the service route using the message contract for ReadRuntimeState.
 func InvokeSharedSoDemoMessageReadRuntimeState(ctx context.Context, req *ReadRuntimeStateRequest) (*RuntimeStateResponse, error) {
 	if req == nil {
 		return nil, errors.New("rpccgo: message request is nil")
 	}
-	registered, err := rpcruntime.LoadServer(sharedSoDemoServiceID)
+	var resp *RuntimeStateResponse
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteUnary(ctx, rpcruntime.CallInfo{
+		ServiceID: sharedSoDemoServiceID,
+		Method:    "examples.flutter.sharedso.v1.SharedSoDemo.ReadRuntimeState",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationUnary,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		resp, callErr = invokeSharedSoDemoMessageReadRuntimeState(ctx, registered, req)
+		return callErr
+	})
 	if err != nil {
 		return nil, err
 	}
+	return resp, nil
+}
+
+func invokeSharedSoDemoMessageReadRuntimeState(ctx context.Context, registered rpcruntime.RegisteredServer, req *ReadRuntimeStateRequest) (*RuntimeStateResponse, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(SharedSoDemoCGOMessageServer)
@@ -585,7 +749,9 @@ func InvokeSharedSoDemoMessageReadRuntimeState(ctx context.Context, req *ReadRun
 		if !ok {
 			return nil, fmt.Errorf("rpccgo: SharedSoDemo connect handler registered server has invalid type")
 		}
-		messageResp, err := server.ReadRuntimeState(ctx, req)
+		callCtx, finishMetadata := rpcruntime.ConnectHandlerContext(ctx, "/examples.flutter.sharedso.v1.SharedSoDemo/ReadRuntimeState")
+		messageResp, err := server.ReadRuntimeState(callCtx, req)
+		finishMetadata()
 		if err != nil {
 			return nil, err
 		}
@@ -598,7 +764,9 @@ func InvokeSharedSoDemoMessageReadRuntimeState(ctx context.Context, req *ReadRun
 		if !ok {
 			return nil, fmt.Errorf("rpccgo: SharedSoDemo connect remote registered server has invalid type")
 		}
-		messageResp, err := server.ReadRuntimeState(ctx, req)
+		callCtx, finishMetadata := rpcruntime.ConnectClientContext(ctx)
+		messageResp, err := server.ReadRuntimeState(callCtx, req)
+		finishMetadata()
 		if err != nil {
 			return nil, err
 		}
@@ -611,15 +779,29 @@ func InvokeSharedSoDemoMessageReadRuntimeState(ctx context.Context, req *ReadRun
 	}
 }
 
-// SharedSoDemoMessageWatchRuntimeStateStart starts a message contract stream for WatchRuntimeState on the current registered server.
+// SharedSoDemoMessageWatchRuntimeStateStart starts a message contract stream for WatchRuntimeState on the server selected by the service route.
 func SharedSoDemoMessageWatchRuntimeStateStart(ctx context.Context, req *ReadRuntimeStateRequest) (rpcruntime.StreamHandle, error) {
 	if req == nil {
 		return 0, errors.New("rpccgo: message request is nil")
 	}
-	registered, err := rpcruntime.LoadServer(sharedSoDemoServiceID)
+	var streamHandle rpcruntime.StreamHandle
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{
+		ServiceID: sharedSoDemoServiceID,
+		Method:    "examples.flutter.sharedso.v1.SharedSoDemo.WatchRuntimeState",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationStart,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		streamHandle, callErr = sharedSoDemoMessageWatchRuntimeStateStart(ctx, registered, req)
+		return callErr
+	})
 	if err != nil {
 		return 0, err
 	}
+	return streamHandle, nil
+}
+
+func sharedSoDemoMessageWatchRuntimeStateStart(ctx context.Context, registered rpcruntime.RegisteredServer, req *ReadRuntimeStateRequest) (rpcruntime.StreamHandle, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(SharedSoDemoCGOMessageServer)
@@ -630,7 +812,7 @@ func SharedSoDemoMessageWatchRuntimeStateStart(ctx context.Context, req *ReadRun
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)
 	case rpcruntime.ServerKindConnect:
 		server, ok := registered.Server.(SharedSoDemoHandler)
 		if !ok {
@@ -640,7 +822,7 @@ func SharedSoDemoMessageWatchRuntimeStateStart(ctx context.Context, req *ReadRun
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnect, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnect, source)
 	case rpcruntime.ServerKindConnectRemote:
 		server, ok := registered.Server.(SharedSoDemoClient)
 		if !ok {
@@ -650,18 +832,32 @@ func SharedSoDemoMessageWatchRuntimeStateStart(ctx context.Context, req *ReadRun
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnectRemote, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnectRemote, source)
 	default:
 		return 0, fmt.Errorf("rpccgo: SharedSoDemo registered server kind %d is unsupported for message stream starts", registered.Kind)
 	}
 }
 
-// SharedSoDemoMessageCollectRuntimeStateStart starts a message contract stream for CollectRuntimeState on the current registered server.
+// SharedSoDemoMessageCollectRuntimeStateStart starts a message contract stream for CollectRuntimeState on the server selected by the service route.
 func SharedSoDemoMessageCollectRuntimeStateStart(ctx context.Context) (rpcruntime.StreamHandle, error) {
-	registered, err := rpcruntime.LoadServer(sharedSoDemoServiceID)
+	var streamHandle rpcruntime.StreamHandle
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{
+		ServiceID: sharedSoDemoServiceID,
+		Method:    "examples.flutter.sharedso.v1.SharedSoDemo.CollectRuntimeState",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationStart,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		streamHandle, callErr = sharedSoDemoMessageCollectRuntimeStateStart(ctx, registered)
+		return callErr
+	})
 	if err != nil {
 		return 0, err
 	}
+	return streamHandle, nil
+}
+
+func sharedSoDemoMessageCollectRuntimeStateStart(ctx context.Context, registered rpcruntime.RegisteredServer) (rpcruntime.StreamHandle, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(SharedSoDemoCGOMessageServer)
@@ -672,14 +868,14 @@ func SharedSoDemoMessageCollectRuntimeStateStart(ctx context.Context) (rpcruntim
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)
 	case rpcruntime.ServerKindConnect:
 		server, ok := registered.Server.(SharedSoDemoHandler)
 		if !ok {
 			return 0, fmt.Errorf("rpccgo: SharedSoDemo connect handler registered server has invalid type")
 		}
 		source := newsharedSoDemoCollectRuntimeStateConnectDirectMessageStreamSession(ctx, server)
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnect, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnect, source)
 	case rpcruntime.ServerKindConnectRemote:
 		server, ok := registered.Server.(SharedSoDemoClient)
 		if !ok {
@@ -689,21 +885,35 @@ func SharedSoDemoMessageCollectRuntimeStateStart(ctx context.Context) (rpcruntim
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnectRemote, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnectRemote, source)
 	default:
 		return 0, fmt.Errorf("rpccgo: SharedSoDemo registered server kind %d is unsupported for message stream starts", registered.Kind)
 	}
 }
 
-// SharedSoDemoMessageStreamRuntimeStateStart starts a message contract stream for StreamRuntimeState on the current registered server.
+// SharedSoDemoMessageStreamRuntimeStateStart starts a message contract stream for StreamRuntimeState on the server selected by the service route.
 func SharedSoDemoMessageStreamRuntimeStateStart(ctx context.Context, req *ReadRuntimeStateRequest) (rpcruntime.StreamHandle, error) {
 	if req == nil {
 		return 0, errors.New("rpccgo: message request is nil")
 	}
-	registered, err := rpcruntime.LoadServer(sharedSoDemoServiceID)
+	var streamHandle rpcruntime.StreamHandle
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{
+		ServiceID: sharedSoDemoServiceID,
+		Method:    "examples.flutter.sharedso.v1.SharedSoDemo.StreamRuntimeState",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationStart,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		streamHandle, callErr = sharedSoDemoMessageStreamRuntimeStateStart(ctx, registered, req)
+		return callErr
+	})
 	if err != nil {
 		return 0, err
 	}
+	return streamHandle, nil
+}
+
+func sharedSoDemoMessageStreamRuntimeStateStart(ctx context.Context, registered rpcruntime.RegisteredServer, req *ReadRuntimeStateRequest) (rpcruntime.StreamHandle, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(SharedSoDemoCGOMessageServer)
@@ -714,7 +924,7 @@ func SharedSoDemoMessageStreamRuntimeStateStart(ctx context.Context, req *ReadRu
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)
 	case rpcruntime.ServerKindConnect:
 		server, ok := registered.Server.(SharedSoDemoHandler)
 		if !ok {
@@ -724,7 +934,7 @@ func SharedSoDemoMessageStreamRuntimeStateStart(ctx context.Context, req *ReadRu
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnect, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnect, source)
 	case rpcruntime.ServerKindConnectRemote:
 		server, ok := registered.Server.(SharedSoDemoClient)
 		if !ok {
@@ -734,18 +944,32 @@ func SharedSoDemoMessageStreamRuntimeStateStart(ctx context.Context, req *ReadRu
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnectRemote, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnectRemote, source)
 	default:
 		return 0, fmt.Errorf("rpccgo: SharedSoDemo registered server kind %d is unsupported for message stream starts", registered.Kind)
 	}
 }
 
-// SharedSoDemoMessageChatRuntimeStateStart starts a message contract stream for ChatRuntimeState on the current registered server.
+// SharedSoDemoMessageChatRuntimeStateStart starts a message contract stream for ChatRuntimeState on the server selected by the service route.
 func SharedSoDemoMessageChatRuntimeStateStart(ctx context.Context) (rpcruntime.StreamHandle, error) {
-	registered, err := rpcruntime.LoadServer(sharedSoDemoServiceID)
+	var streamHandle rpcruntime.StreamHandle
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{
+		ServiceID: sharedSoDemoServiceID,
+		Method:    "examples.flutter.sharedso.v1.SharedSoDemo.ChatRuntimeState",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationStart,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		streamHandle, callErr = sharedSoDemoMessageChatRuntimeStateStart(ctx, registered)
+		return callErr
+	})
 	if err != nil {
 		return 0, err
 	}
+	return streamHandle, nil
+}
+
+func sharedSoDemoMessageChatRuntimeStateStart(ctx context.Context, registered rpcruntime.RegisteredServer) (rpcruntime.StreamHandle, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(SharedSoDemoCGOMessageServer)
@@ -756,14 +980,14 @@ func SharedSoDemoMessageChatRuntimeStateStart(ctx context.Context) (rpcruntime.S
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)
 	case rpcruntime.ServerKindConnect:
 		server, ok := registered.Server.(SharedSoDemoHandler)
 		if !ok {
 			return 0, fmt.Errorf("rpccgo: SharedSoDemo connect handler registered server has invalid type")
 		}
 		source := newsharedSoDemoChatRuntimeStateConnectDirectMessageStreamSession(ctx, server)
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnect, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnect, source)
 	case rpcruntime.ServerKindConnectRemote:
 		server, ok := registered.Server.(SharedSoDemoClient)
 		if !ok {
@@ -773,7 +997,7 @@ func SharedSoDemoMessageChatRuntimeStateStart(ctx context.Context) (rpcruntime.S
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnectRemote, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnectRemote, source)
 	default:
 		return 0, fmt.Errorf("rpccgo: SharedSoDemo registered server kind %d is unsupported for message stream starts", registered.Kind)
 	}
@@ -34,10 +34,30 @@ type SharedSoDemoCGOMessageServer interface {
 
 // SharedSoDemoMessageWatchRuntimeStateRecv receives a message response from an active WatchRuntimeState stream.
 func SharedSoDemoMessageWatchRuntimeStateRecv(ctx context.Context, handle rpcruntime.StreamHandle) (*RuntimeStateResponse, error) {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return nil, err
 	}
+	var resp *RuntimeStateResponse
+	err = rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: sharedSoDemoServiceID,
+		Method:    "examples.flutter.sharedso.v1.SharedSoDemo.WatchRuntimeState",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationRecv,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		var callErr error
+		resp, callErr = sharedSoDemoMessageWatchRuntimeStateRecv(ctx, handle, entry)
+		return callErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func sharedSoDemoMessageWatchRuntimeStateRecv(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) (*RuntimeStateResponse, error) {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*RuntimeStateResponse])
@@ -112,10 +132,23 @@ func SharedSoDemoMessageWatchRuntimeStateRecv(ctx context.Context, handle rpcrun
 
 // SharedSoDemoMessageWatchRuntimeStateCancel cancels an active message WatchRuntimeState stream and releases its handle.
 func SharedSoDemoMessageWatchRuntimeStateCancel(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: sharedSoDemoServiceID,
+		Method:    "examples.flutter.sharedso.v1.SharedSoDemo.WatchRuntimeState",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationCancel,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return sharedSoDemoMessageWatchRuntimeStateCancel(ctx, handle, entry)
+	})
+}
+
+func sharedSoDemoMessageWatchRuntimeStateCancel(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*RuntimeStateResponse])
@@ -125,7 +158,7 @@ func SharedSoDemoMessageWatchRuntimeStateCancel(ctx context.Context, handle rpcr
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnect:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*RuntimeStateResponse])
@@ -135,7 +168,7 @@ func SharedSoDemoMessageWatchRuntimeStateCancel(ctx context.Context, handle rpcr
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnectRemote:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*RuntimeStateResponse])
@@ -145,7 +178,7 @@ func SharedSoDemoMessageWatchRuntimeStateCancel(ctx context.Context, handle rpcr
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	default:
 		return fmt.Errorf("rpccgo: SharedSoDemo message stream session kind %d is unsupported", entry.Kind)
@@ -157,10 +190,23 @@ func SharedSoDemoMessageCollectRuntimeStateSend(ctx context.Context, handle rpcr
 	if req == nil {
 		return errors.New("rpccgo: message request is nil")
 	}
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: sharedSoDemoServiceID,
+		Method:    "examples.flutter.sharedso.v1.SharedSoDemo.CollectRuntimeState",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationSend,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return sharedSoDemoMessageCollectRuntimeStateSend(ctx, handle, entry, req)
+	})
+}
+
+func sharedSoDemoMessageCollectRuntimeStateSend(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession, req *IncrementRuntimeStateRequest) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[*IncrementRuntimeStateRequest, *RuntimeStateResponse])
@@ -187,10 +233,30 @@ func SharedSoDemoMessageCollectRuntimeStateSend(ctx context.Context, handle rpcr
 
 // SharedSoDemoMessageCollectRuntimeStateFinish finishes an active message CollectRuntimeState stream and releases its handle.
 func SharedSoDemoMessageCollectRuntimeStateFinish(ctx context.Context, handle rpcruntime.StreamHandle) (*RuntimeStateResponse, error) {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return nil, err
 	}
+	var resp *RuntimeStateResponse
+	err = rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: sharedSoDemoServiceID,
+		Method:    "examples.flutter.sharedso.v1.SharedSoDemo.CollectRuntimeState",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationFinish,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		var callErr error
+		resp, callErr = sharedSoDemoMessageCollectRuntimeStateFinish(ctx, handle, entry)
+		return callErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func sharedSoDemoMessageCollectRuntimeStateFinish(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) (*RuntimeStateResponse, error) {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[*IncrementRuntimeStateRequest, *RuntimeStateResponse])
@@ -247,10 +313,23 @@ func SharedSoDemoMessageCollectRuntimeStateFinish(ctx context.Context, handle rp
 
 // SharedSoDemoMessageCollectRuntimeStateCancel cancels an active message CollectRuntimeState stream and releases its handle.
 func SharedSoDemoMessageCollectRuntimeStateCancel(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: sharedSoDemoServiceID,
+		Method:    "examples.flutter.sharedso.v1.SharedSoDemo.CollectRuntimeState",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationCancel,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return sharedSoDemoMessageCollectRuntimeStateCancel(ctx, handle, entry)
+	})
+}
+
+func sharedSoDemoMessageCollectRuntimeStateCancel(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[*IncrementRuntimeStateRequest, *RuntimeStateResponse])
@@ -260,7 +339,7 @@ func SharedSoDemoMessageCollectRuntimeStateCancel(ctx context.Context, handle rp
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnect:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[*IncrementRuntimeStateRequest, *RuntimeStateResponse])
@@ -270,7 +349,7 @@ func SharedSoDemoMessageCollectRuntimeStateCancel(ctx context.Context, handle rp
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnectRemote:
 		source, ok := entry.Session.(rpcruntime.ClientStreamingClient[*IncrementRuntimeStateRequest, *RuntimeStateResponse])
@@ -280,7 +359,7 @@ func SharedSoDemoMessageCollectRuntimeStateCancel(ctx context.Context, handle rp
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	default:
 		return fmt.Errorf("rpccgo: SharedSoDemo message stream session kind %d is unsupported", entry.Kind)
@@ -289,10 +368,30 @@ func SharedSoDemoMessageCollectRuntimeStateCancel(ctx context.Context, handle rp
 
 // SharedSoDemoMessageStreamRuntimeStateRecv receives a message response from an active StreamRuntimeState stream.
 func SharedSoDemoMessageStreamRuntimeStateRecv(ctx context.Context, handle rpcruntime.StreamHandle) (*RuntimeStateResponse, error) {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
+	if err != nil {
+		return nil, err
+	}
+	var resp *RuntimeStateResponse
+	err = rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: sharedSoDemoServiceID,
+		Method:    "examples.flutter.sharedso.v1.SharedSoDemo.StreamRuntimeState",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationRecv,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		var callErr error
+		resp, callErr = sharedSoDemoMessageStreamRuntimeStateRecv(ctx, handle, entry)
+		return callErr
+	})
 	if err != nil {
 		return nil, err
 	}
+	return resp, nil
+}
+
+func sharedSoDemoMessageStreamRuntimeStateRecv(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) (*RuntimeStateResponse, error) {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*RuntimeStateResponse])
@@ -367,10 +466,23 @@ func SharedSoDemoMessageStreamRuntimeStateRecv(ctx context.Context, handle rpcru
 
 // SharedSoDemoMessageStreamRuntimeStateCancel cancels an active message StreamRuntimeState stream and releases its handle.
 func SharedSoDemoMessageStreamRuntimeStateCancel(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: sharedSoDemoServiceID,
+		Method:    "examples.flutter.sharedso.v1.SharedSoDemo.StreamRuntimeState",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationCancel,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return sharedSoDemoMessageStreamRuntimeStateCancel(ctx, handle, entry)
+	})
+}
+
+func sharedSoDemoMessageStreamRuntimeStateCancel(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*RuntimeStateResponse])
@@ -380,7 +492,7 @@ func SharedSoDemoMessageStreamRuntimeStateCancel(ctx context.Context, handle rpc
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnect:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*RuntimeStateResponse])
@@ -390,7 +502,7 @@ func SharedSoDemoMessageStreamRuntimeStateCancel(ctx context.Context, handle rpc
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnectRemote:
 		source, ok := entry.Session.(rpcruntime.ServerStreamingClient[*RuntimeStateResponse])
@@ -400,7 +512,7 @@ func SharedSoDemoMessageStreamRuntimeStateCancel(ctx context.Context, handle rpc
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	default:
 		return fmt.Errorf("rpccgo: SharedSoDemo message stream session kind %d is unsupported", entry.Kind)
@@ -412,10 +524,23 @@ func SharedSoDemoMessageChatRuntimeStateSend(ctx context.Context, handle rpcrunt
 	if req == nil {
 		return errors.New("rpccgo: message request is nil")
 	}
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: sharedSoDemoServiceID,
+		Method:    "examples.flutter.sharedso.v1.SharedSoDemo.ChatRuntimeState",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationSend,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return sharedSoDemoMessageChatRuntimeStateSend(ctx, handle, entry, req)
+	})
+}
+
+func sharedSoDemoMessageChatRuntimeStateSend(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession, req *IncrementRuntimeStateRequest) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*IncrementRuntimeStateRequest, *RuntimeStateResponse])
@@ -442,10 +567,30 @@ func SharedSoDemoMessageChatRuntimeStateSend(ctx context.Context, handle rpcrunt
 
 // SharedSoDemoMessageChatRuntimeStateRecv receives a message response from an active ChatRuntimeState stream.
 func SharedSoDemoMessageChatRuntimeStateRecv(ctx context.Context, handle rpcruntime.StreamHandle) (*RuntimeStateResponse, error) {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
+	if err != nil {
+		return nil, err
+	}
+	var resp *RuntimeStateResponse
+	err = rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: sharedSoDemoServiceID,
+		Method:    "examples.flutter.sharedso.v1.SharedSoDemo.ChatRuntimeState",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationRecv,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		var callErr error
+		resp, callErr = sharedSoDemoMessageChatRuntimeStateRecv(ctx, handle, entry)
+		return callErr
+	})
 	if err != nil {
 		return nil, err
 	}
+	return resp, nil
+}
+
+func sharedSoDemoMessageChatRuntimeStateRecv(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) (*RuntimeStateResponse, error) {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*IncrementRuntimeStateRequest, *RuntimeStateResponse])
@@ -493,10 +638,23 @@ func SharedSoDemoMessageChatRuntimeStateRecv(ctx context.Context, handle rpcrunt
 
 // SharedSoDemoMessageChatRuntimeStateCloseSend closes the message send side of an active ChatRuntimeState stream.
 func SharedSoDemoMessageChatRuntimeStateCloseSend(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: sharedSoDemoServiceID,
+		Method:    "examples.flutter.sharedso.v1.SharedSoDemo.ChatRuntimeState",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationCloseSend,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return sharedSoDemoMessageChatRuntimeStateCloseSend(ctx, handle, entry)
+	})
+}
+
+func sharedSoDemoMessageChatRuntimeStateCloseSend(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*IncrementRuntimeStateRequest, *RuntimeStateResponse])
@@ -523,10 +681,23 @@ func SharedSoDemoMessageChatRuntimeStateCloseSend(ctx context.Context, handle rp
 
 // SharedSoDemoMessageChatRuntimeStateFinish finishes an active message ChatRuntimeState stream and releases its handle.
 func SharedSoDemoMessageChatRuntimeStateFinish(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: sharedSoDemoServiceID,
+		Method:    "examples.flutter.sharedso.v1.SharedSoDemo.ChatRuntimeState",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationFinish,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return sharedSoDemoMessageChatRuntimeStateFinish(ctx, handle, entry)
+	})
+}
+
+func sharedSoDemoMessageChatRuntimeStateFinish(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*IncrementRuntimeStateRequest, *RuntimeStateResponse])
@@ -536,7 +707,7 @@ func SharedSoDemoMessageChatRuntimeStateFinish(ctx context.Context, handle rpcru
 		if err := source.Finish(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnect:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*IncrementRuntimeStateRequest, *RuntimeStateResponse])
@@ -546,7 +717,7 @@ func SharedSoDemoMessageChatRuntimeStateFinish(ctx context.Context, handle rpcru
 		if err := source.Finish(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnectRemote:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*IncrementRuntimeStateRequest, *RuntimeStateResponse])
@@ -556,7 +727,7 @@ func SharedSoDemoMessageChatRuntimeStateFinish(ctx context.Context, handle rpcru
 		if err := source.Finish(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	default:
 		return fmt.Errorf("rpccgo: SharedSoDemo message stream session kind %d is unsupported", entry.Kind)
@@ -565,10 +736,23 @@ func SharedSoDemoMessageChatRuntimeStateFinish(ctx context.Context, handle rpcru
 
 // SharedSoDemoMessageChatRuntimeStateCancel cancels an active message ChatRuntimeState stream and releases its handle.
 func SharedSoDemoMessageChatRuntimeStateCancel(ctx context.Context, handle rpcruntime.StreamHandle) error {
-	entry, err := rpcruntime.LoadStreamSession(handle)
+	entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)
 	if err != nil {
 		return err
 	}
+	return rpcruntime.InterceptStream(ctx, rpcruntime.CallInfo{
+		ServiceID: sharedSoDemoServiceID,
+		Method:    "examples.flutter.sharedso.v1.SharedSoDemo.ChatRuntimeState",
+		Contract:  rpcruntime.CallContractMessage,
+		Kind:      entry.Kind,
+		Operation: rpcruntime.CallOperationCancel,
+		Stream:    handle,
+	}, func(ctx context.Context, _ rpcruntime.CallInfo) error {
+		return sharedSoDemoMessageChatRuntimeStateCancel(ctx, handle, entry)
+	})
+}
+
+func sharedSoDemoMessageChatRuntimeStateCancel(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) error {
 	switch entry.Kind {
 	case rpcruntime.ServerKindCGOMessage:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*IncrementRuntimeStateRequest, *RuntimeStateResponse])
@@ -578,7 +762,7 @@ func SharedSoDemoMessageChatRuntimeStateCancel(ctx context.Context, handle rpcru
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnect:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*IncrementRuntimeStateRequest, *RuntimeStateResponse])
@@ -588,7 +772,7 @@ func SharedSoDemoMessageChatRuntimeStateCancel(ctx context.Context, handle rpcru
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	case rpcruntime.ServerKindConnectRemote:
 		source, ok := entry.Session.(rpcruntime.BidiStreamingClient[*IncrementRuntimeStateRequest, *RuntimeStateResponse])
@@ -598,7 +782,7 @@ func SharedSoDemoMessageChatRuntimeStateCancel(ctx context.Context, handle rpcru
 		if err := source.Cancel(ctx); err != nil {
 			return err
 		}
-		_, err = rpcruntime.RemoveStreamSession(handle)
+		_, err := rpcruntime.RemoveStreamSession(handle)
 		return err
 	default:
 		return fmt.Errorf("rpccgo: SharedSoDemo message stream session kind %d is unsupported", entry.Kind)
@@ -645,25 +829,30 @@ func (UnimplementedSharedSoDemoCGOMessageServer) ChatRuntimeState(ctx context.Co
 
 // RegisterSharedSoDemoCGOMessageServer registers a cgo message server as the current server for SharedSoDemo.
 func RegisterSharedSoDemoCGOMessageServer(server SharedSoDemoCGOMessageServer) error {
+	return RegisterSharedSoDemoCGOMessageServerIn(rpcruntime.DefaultServerRegistry(), server)
+}
+
+// RegisterSharedSoDemoCGOMessageServerIn registers a cgo message server as the current server for SharedSoDemo in registry.
+func RegisterSharedSoDemoCGOMessageServerIn(registry *rpcruntime.ServerRegistry, server SharedSoDemoCGOMessageServer) error {
 	if server == nil {
-		_ = registerSharedSoDemoCGOMessageServer(server)
+		_ = registerSharedSoDemoCGOMessageServerIn(registry, server)
 		return errors.New("rpccgo: SharedSoDemo cgo message server is nil")
 	}
-	return registerSharedSoDemoCGOMessageServer(server)
+	return registerSharedSoDemoCGOMessageServerIn(registry, server)
 }
 
-// registerSharedSoDemoCGOMessageServer registers the supplied cgo message server as the current server for this service.
-func registerSharedSoDemoCGOMessageServer(server SharedSoDemoCGOMessageServer) error {
+// registerSharedSoDemoCGOMessageServerIn registers the supplied cgo message server as the current server for this service in registry.
+func registerSharedSoDemoCGOMessageServerIn(registry *rpcruntime.ServerRegistry, server SharedSoDemoCGOMessageServer) error {
 	if server == nil {
-		_ = rpcruntime.ClearServer(sharedSoDemoServiceID)
+		_ = registry.Clear(sharedSoDemoServiceID)
 		return SharedSoDemoMessageServerUnavailableErr
 	}
-	err := rpcruntime.RegisterServer(sharedSoDemoServiceID, rpcruntime.RegisteredServer{
+	err := registry.Register(sharedSoDemoServiceID, rpcruntime.RegisteredServer{
 		Kind:   rpcruntime.ServerKindCGOMessage,
 		Server: server,
 	})
 	if err != nil {
-		_ = rpcruntime.ClearServer(sharedSoDemoServiceID)
+		_ = registry.Clear(sharedSoDemoServiceID)
 		return err
 	}
 	return nil
@@ -679,11 +868,13 @@ func sharedSoDemoWatchRuntimeStateCGOMessageStart(ctx context.Context, server Sh
 		return direct.WatchRuntimeStateStart(ctx, req)
 	}
 	client, stream, streamCtx := rpcruntime.NewServerStreaming[*RuntimeStateResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:         "examples.flutter.sharedso.v1.SharedSoDemo.WatchRuntimeState",
 		ResponseBuffer: 1,
 		StreamClosed:   errors.New("rpccgo: message stream is closed"),
 		NilResponse:    errors.New("rpccgo: message response is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.SharedSoDemo.WatchRuntimeState handler", func(err error) { stream.Complete(err) })
 		err := server.WatchRuntimeState(streamCtx, req, stream)
 		stream.Complete(err)
 	}()
@@ -692,11 +883,13 @@ func sharedSoDemoWatchRuntimeStateCGOMessageStart(ctx context.Context, server Sh
 
 func sharedSoDemoCollectRuntimeStateCGOMessageStart(ctx context.Context, server SharedSoDemoCGOMessageServer) (rpcruntime.ClientStreamingClient[*IncrementRuntimeStateRequest, *RuntimeStateResponse], error) {
 	client, stream, streamCtx := rpcruntime.NewClientStreaming[*IncrementRuntimeStateRequest, *RuntimeStateResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:        "examples.flutter.sharedso.v1.SharedSoDemo.CollectRuntimeState",
 		RequestBuffer: 16,
 		StreamClosed:  errors.New("rpccgo: message stream is closed"),
 		NilRequest:    errors.New("rpccgo: message request is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.SharedSoDemo.CollectRuntimeState handler", func(err error) { stream.Complete(nil, err) })
 		resp, err := server.CollectRuntimeState(streamCtx, stream)
 		stream.Complete(resp, err)
 	}()
@@ -713,11 +906,13 @@ func sharedSoDemoStreamRuntimeStateCGOMessageStart(ctx context.Context, server S
 		return direct.StreamRuntimeStateStart(ctx, req)
 	}
 	client, stream, streamCtx := rpcruntime.NewServerStreaming[*RuntimeStateResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:         "examples.flutter.sharedso.v1.SharedSoDemo.StreamRuntimeState",
 		ResponseBuffer: 1,
 		StreamClosed:   errors.New("rpccgo: message stream is closed"),
 		NilResponse:    errors.New("rpccgo: message response is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.SharedSoDemo.StreamRuntimeState handler", func(err error) { stream.Complete(err) })
 		err := server.StreamRuntimeState(streamCtx, req, stream)
 		stream.Complete(err)
 	}()
@@ -726,6 +921,7 @@ func sharedSoDemoStreamRuntimeStateCGOMessageStart(ctx context.Context, server S
 
 func sharedSoDemoChatRuntimeStateCGOMessageStart(ctx context.Context, server SharedSoDemoCGOMessageServer) (rpcruntime.BidiStreamingClient[*IncrementRuntimeStateRequest, *RuntimeStateResponse], error) {
 	client, stream, streamCtx := rpcruntime.NewBidiStreaming[*IncrementRuntimeStateRequest, *RuntimeStateResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:         "examples.flutter.sharedso.v1.SharedSoDemo.ChatRuntimeState",
 		RequestBuffer:  16,
 		ResponseBuffer: 1,
 		StreamClosed:   errors.New("rpccgo: message stream is closed"),
@@ -733,6 +929,7 @@ func sharedSoDemoChatRuntimeStateCGOMessageStart(ctx context.Context, server Sha
 		NilResponse:    errors.New("rpccgo: message response is nil"),
 	})
 	go func() {
+		defer rpcruntime.RecoverPanic("examples.flutter.sharedso.v1.SharedSoDemo.ChatRuntimeState handler", func(err error) { stream.Complete(err) })
 		err := server.ChatRuntimeState(streamCtx, stream)
 		stream.Complete(err)
 	}()
@@ -19,6 +19,7 @@ import (
 	rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -63,6 +64,154 @@ func TestGRPCGreeterTransportAndStreamingMatrix(t *testing.T) {
 	})
 }
 
+func TestGRPCGreeterCallsReleasePinnedBuffers(t *testing.T) {
+	ctx := context.Background()
+	registerNativeServer(t)
+	rpcruntime.ConfigurePinTracking(rpcruntime.PinTrackingConfig{Enabled: true, Stacks: true})
+	t.Cleanup(func() { rpcruntime.ConfigurePinTracking(rpcruntime.PinTrackingConfig{}) })
+
+	assertNativeUnary(t, ctx, "native", "local", "hello native from local")
+	assertNativeBroadcast(t, ctx, "stream", []string{"broadcast[0]:stream", "broadcast[1]:stream"})
+	registerMessageServer(t)
+	assertMessageUnary(t, ctx, "message", "local", "hello message from local")
+	assertMessageChat(t, ctx, "bidi-message", "chat:bidi-message")
+
+	if err := rpcruntime.CheckPinLeaks(0); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGRPCGreeterUnaryIntoCallerBuffer(t *testing.T) {
+	registerNativeServer(t)
+	rpcruntime.ConfigurePinTracking(rpcruntime.PinTrackingConfig{Enabled: true})
+	t.Cleanup(func() { rpcruntime.ConfigurePinTracking(rpcruntime.PinTrackingConfig{}) })
+
+	const want = "hello native from local"
+	input := nativeInput("native", "local")
+	var messagePtr uintptr
+	var messageLen, bufferLen int32
+	small := make([]byte, 4)
+	if errID := callGreeterSayHelloNativeUnaryInto(input.namePtr(), input.nameLen(), input.cityPtr(), input.cityLen(), small, &messagePtr, &messageLen, &bufferLen); errID != int32(rpcruntime.ErrorIDBufferTooSmall) {
+		t.Fatalf("native Into with a small buffer error id = %d, want %d", errID, rpcruntime.ErrorIDBufferTooSmall)
+	}
+	if bufferLen != int32(len(want)) || messagePtr != 0 || messageLen != 0 {
+		t.Fatalf("native Into overflow = ptr %#x len %d need %d, want no output and need %d", messagePtr, messageLen, bufferLen, len(want))
+	}
+	buffer := make([]byte, bufferLen)
+	if errID := callGreeterSayHelloNativeUnaryInto(input.namePtr(), input.nameLen(), input.cityPtr(), input.cityLen(), buffer, &messagePtr, &messageLen, &bufferLen); errID != 0 {
+		t.Fatalf("native Into error id = %d", errID)
+	}
+	if messagePtr != uintptr(bytesPtr(buffer)) || string(buffer[:messageLen]) != want || bufferLen != int32(len(want)) {
+		t.Fatalf("native Into wrote %q at %#x, used %d; want %q in the caller buffer", buffer[:messageLen], messagePtr, bufferLen, want)
+	}
+
+	registerMessageServer(t)
+	request := messageRequestBytes(t, "message", "local")
+	var responseLen int32
+	if errID := callGreeterSayHelloMessageUnaryInto(bytesPtr(request), int32(len(request)), nil, &responseLen); errID != int32(rpcruntime.ErrorIDBufferTooSmall) {
+		t.Fatalf("message Into without a buffer error id = %d, want %d", errID, rpcruntime.ErrorIDBufferTooSmall)
+	}
+	response := make([]byte, responseLen)
+	if errID := callGreeterSayHelloMessageUnaryInto(bytesPtr(request), int32(len(request)), response, &responseLen); errID != 0 {
+		t.Fatalf("message Into error id = %d", errID)
+	}
+	var decoded greeterv1.SayHelloResponse
+	if err := proto.Unmarshal(response[:responseLen], &decoded); err != nil || decoded.GetMessage() != "hello message from local" {
+		t.Fatalf("message Into response = %q, %v", decoded.GetMessage(), err)
+	}
+
+	if err := rpcruntime.CheckPinLeaks(0); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGRPCGreeterClientStreamTrailersThroughCallOptions(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	server := grpc.NewServer()
+	greeterv1.RegisterGreeterServer(server, trailerGreeterServer{})
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	if err := greeterv1.RegisterGreeterGRPCRemoteServer(greeterv1.NewGreeterClient(conn)); err != nil {
+		t.Fatalf("RegisterGreeterGRPCRemoteServer() error = %v", err)
+	}
+
+	options, errID := newCallOptions()
+	if errID != 0 {
+		t.Fatalf("newCallOptions() error id = %d", errID)
+	}
+	t.Cleanup(func() { _ = releaseCallOptions(options) })
+
+	stream, errID := greeterMessageCollectStartWithOptions(options)
+	if errID != 0 {
+		t.Fatalf("greeterMessageCollectStartWithOptions() error = %s", cgoErrorText(errID))
+	}
+	names := []string{"ada", "grace"}
+	for _, name := range names {
+		request := messageRequestBytes(t, name, "remote")
+		if errID := greeterMessageCollectSendWithOptions(options, stream, bytesPtr(request), int32(len(request))); errID != 0 {
+			t.Fatalf("greeterMessageCollectSendWithOptions() error = %s", cgoErrorText(errID))
+		}
+	}
+	var messagePtr uintptr
+	var messageLen int32
+	if errID := greeterMessageCollectFinishWithOptions(options, stream, &messagePtr, &messageLen); errID != 0 {
+		t.Fatalf("greeterMessageCollectFinishWithOptions() error = %s", cgoErrorText(errID))
+	}
+	assertMessageOutput(t, messagePtr, messageLen, "collect:ada,grace")
+
+	header, trailer, errID := takeCallOptionsResponseMetadata(options)
+	if errID != 0 {
+		t.Fatalf("takeCallOptionsResponseMetadata() error = %s", cgoErrorText(errID))
+	}
+	headerMD, err := rpcruntime.DecodeMetadata(header)
+	if err != nil {
+		t.Fatalf("DecodeMetadata(header) error = %v", err)
+	}
+	trailerMD, err := rpcruntime.DecodeMetadata(trailer)
+	if err != nil {
+		t.Fatalf("DecodeMetadata(trailer) error = %v", err)
+	}
+	if got := headerMD.Get("x-collect-header"); len(got) != 1 || got[0] != "sent" {
+		t.Fatalf("stream header x-collect-header = %v, want [sent]", got)
+	}
+	if got := trailerMD.Get("x-collected"); len(got) != 1 || got[0] != strconv.Itoa(len(names)) {
+		t.Fatalf("stream trailer x-collected = %v, want [%d]", got, len(names))
+	}
+}
+
+// trailerGreeterServer answers Collect over real gRPC and reports a header and
+// the number of received requests as a trailer.
+type trailerGreeterServer struct {
+	greeterv1.UnimplementedGreeterServer
+}
+
+func (trailerGreeterServer) Collect(stream grpc.ClientStreamingServer[greeterv1.SayHelloRequest, greeterv1.SayHelloResponse]) error {
+	if err := stream.SendHeader(metadata.Pairs("x-collect-header", "sent")); err != nil {
+		return err
+	}
+	var names []string
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			stream.SetTrailer(metadata.Pairs("x-collected", strconv.Itoa(len(names))))
+			return stream.SendAndClose(&greeterv1.SayHelloResponse{Message: "collect:" + strings.Join(names, ",")})
+		}
+		if err != nil {
+			return err
+		}
+		names = append(names, req.GetName())
+	}
+}
+
 func registerNativeServer(t *testing.T) {
 	t.Helper()
 	if err := greeterv1.RegisterGreeterGoNativeServer(backend.Greeter{}); err != nil {
@@ -441,7 +590,7 @@ func nativeInput(name, city string) nativeInputArgs {
 	}
 }
 
-func (a nativeInputArgs) namePtr() uintptr {
+func (a nativeInputArgs) namePtr() unsafe.Pointer {
 	return bytesPtr(a.name)
 }
 
@@ -449,7 +598,7 @@ func (a nativeInputArgs) nameLen() int32 {
 	return int32(len(a.name))
 }
 
-func (a nativeInputArgs) cityPtr() uintptr {
+func (a nativeInputArgs) cityPtr() unsafe.Pointer {
 	return bytesPtr(a.city)
 }
 
@@ -466,8 +615,8 @@ func messageRequestBytes(t *testing.T, name, city string) []byte {
 	return data
 }
 
-func bytesPtr(data []byte) uintptr {
-	return uintptr(unsafe.Pointer(unsafe.SliceData(data)))
+func bytesPtr(data []byte) unsafe.Pointer {
+	return unsafe.Pointer(unsafe.SliceData(data))
 }
 
 func assertNativeOutput(t *testing.T, ptr uintptr, length int32, want string) {
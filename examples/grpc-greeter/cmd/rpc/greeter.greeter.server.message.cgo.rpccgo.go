@@ -153,7 +153,9 @@ func (a *greeterCGOMessageAdapter) SayHello(ctx context.Context, req *v1.SayHell
 	}
 	var responsePtr C.uintptr_t
 	var responseLen C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callGreeterSayHelloCGOMessageUnary(callback, C.uintptr_t(requestPtr), C.int32_t(requestLen), &responsePtr, &responseLen))
+	leaveTrace()
 	if errID != 0 {
 		return nil, greeterCGOMessageServerError(errID)
 	}
@@ -172,7 +174,9 @@ func (a *greeterCGOMessageAdapter) CollectStart(ctx context.Context) (rpcruntime
 		return nil, errors.New("rpccgo: Greeter.Collect cgo message server method is not implemented")
 	}
 	var stream C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callGreeterCollectCGOMessageClientStreamStart(a.collectStart, &stream))
+	leaveTrace()
 	if errID != 0 {
 		return nil, greeterCGOMessageServerError(errID)
 	}
@@ -279,7 +283,9 @@ func (a *greeterCGOMessageAdapter) BroadcastStart(ctx context.Context, req *v1.S
 		return nil, err
 	}
 	var stream C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callGreeterBroadcastCGOMessageServerStreamStart(a.broadcastStart, C.uintptr_t(requestPtr), C.int32_t(requestLen), &stream))
+	leaveTrace()
 	if errID != 0 {
 		return nil, greeterCGOMessageServerError(errID)
 	}
@@ -365,7 +371,9 @@ func (a *greeterCGOMessageAdapter) ChatStart(ctx context.Context) (rpcruntime.Bi
 		return nil, errors.New("rpccgo: Greeter.Chat cgo message server method is not implemented")
 	}
 	var stream C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callGreeterChatCGOMessageBidiStreamStart(a.chatStart, &stream))
+	leaveTrace()
 	if errID != 0 {
 		return nil, greeterCGOMessageServerError(errID)
 	}
@@ -724,15 +732,12 @@ func greeterCGOMessageServerError(errID int32) error {
 	if errID == 0 {
 		return nil
 	}
-	text, ptr, ok := rpcruntime.TakeErrorText(rpcruntime.ErrorID(errID))
+	statusErr, ok := rpcruntime.TakeError(rpcruntime.ErrorID(errID))
 	if ok {
-		if ptr != 0 {
-			defer rpcruntime.Release(ptr)
-		}
-		if string(text) == io.EOF.Error() {
+		if statusErr.Message == io.EOF.Error() {
 			return io.EOF
 		}
-		return errors.New(string(text))
+		return rpcruntime.GRPCError(statusErr)
 	}
 	return fmt.Errorf("rpccgo: cgo message server callback returned unknown error id %d", errID)
 }
@@ -181,7 +181,9 @@ func (a *greeterCGONativeAdapter) SayHello(ctx context.Context, name *rpcruntime
 	var outMessagePtr C.uintptr_t
 	var outMessageLen C.int32_t
 	var outMessageOwnership C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callGreeterSayHelloCGONativeUnaryCallback(callback, greeterSayHelloCGONativeUnaryRequest.namePtr, greeterSayHelloCGONativeUnaryRequest.nameLen, greeterSayHelloCGONativeUnaryRequest.nameOwnership, greeterSayHelloCGONativeUnaryRequest.cityPtr, greeterSayHelloCGONativeUnaryRequest.cityLen, greeterSayHelloCGONativeUnaryRequest.cityOwnership, &outMessagePtr, &outMessageLen, &outMessageOwnership))
+	leaveTrace()
 	if errID != 0 {
 		cleanupErr := cleanupGreeterSayHelloCGONativeUnaryResponse(outMessagePtr, outMessageLen, outMessageOwnership)
 		callbackErr := greeterCGONativeServerErrorFromID(errID)
@@ -212,7 +214,9 @@ func (a *greeterCGONativeAdapter) CollectStart(ctx context.Context) (rpcruntime.
 		return nil, errors.New("rpccgo: Greeter.Collect native server method is not implemented")
 	}
 	var stream C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callGreeterCollectCGONativeClientStreamStartCallback(a.collectStart, &stream))
+	leaveTrace()
 	if errID != 0 {
 		return nil, greeterCGONativeServerErrorFromID(errID)
 	}
@@ -287,7 +291,9 @@ func (a *greeterCGONativeAdapter) BroadcastStart(ctx context.Context, name *rpcr
 	}
 	defer greeterBroadcastCGONativeServerStreamRequest.Release()
 	var stream C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callGreeterBroadcastCGONativeServerStreamStartCallback(a.broadcastStart, greeterBroadcastCGONativeServerStreamRequest.namePtr, greeterBroadcastCGONativeServerStreamRequest.nameLen, greeterBroadcastCGONativeServerStreamRequest.nameOwnership, greeterBroadcastCGONativeServerStreamRequest.cityPtr, greeterBroadcastCGONativeServerStreamRequest.cityLen, greeterBroadcastCGONativeServerStreamRequest.cityOwnership, &stream))
+	leaveTrace()
 	if errID != 0 {
 		return nil, greeterCGONativeServerErrorFromID(errID)
 	}
@@ -352,7 +358,9 @@ func (a *greeterCGONativeAdapter) ChatStart(ctx context.Context) (rpcruntime.Bid
 		return nil, errors.New("rpccgo: Greeter.Chat native server method is not implemented")
 	}
 	var stream C.int32_t
+	leaveTrace := rpccgoEnterCallbackTrace(ctx)
 	errID := int32(C.callGreeterChatCGONativeBidiStreamStartCallback(a.chatStart, &stream))
+	leaveTrace()
 	if errID != 0 {
 		return nil, greeterCGONativeServerErrorFromID(errID)
 	}
@@ -912,15 +920,12 @@ func greeterCGONativeServerErrorFromID(errID int32) error {
 	if errID == 0 {
 		return nil
 	}
-	text, ptr, ok := rpcruntime.TakeErrorText(rpcruntime.ErrorID(errID))
+	statusErr, ok := rpcruntime.TakeError(rpcruntime.ErrorID(errID))
 	if ok {
-		if ptr != 0 {
-			defer rpcruntime.Release(ptr)
-		}
-		if string(text) == io.EOF.Error() {
+		if statusErr.Message == io.EOF.Error() {
 			return io.EOF
 		}
-		return errors.New(string(text))
+		return rpcruntime.GRPCError(statusErr)
 	}
 	return fmt.Errorf("rpccgo: cgo native server callback returned unknown error id %d", errID)
 }
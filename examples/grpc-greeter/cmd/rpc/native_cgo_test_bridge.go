@@ -2,28 +2,44 @@ package main
 
 /*
 #include <stdint.h>
+
+static inline void* bridgePointer(uintptr_t ptr) { return (void*)ptr; }
 */
 import "C"
 
+import "unsafe"
+
 // Go test files cannot import C, so these helpers keep C export coverage in Go tests.
-func callGreeterSayHelloNativeUnary(namePtr uintptr, nameLen int32, nameOwnership int32, cityPtr uintptr, cityLen int32, cityOwnership int32, outMessagePtr *uintptr, outMessageLen *int32) int32 {
+func callGreeterSayHelloNativeUnary(namePtr unsafe.Pointer, nameLen int32, nameOwnership int32, cityPtr unsafe.Pointer, cityLen int32, cityOwnership int32, outMessagePtr *uintptr, outMessageLen *int32) int32 {
 	var messagePtr C.uintptr_t
 	var messageLen C.int32_t
 	var messageOwnership C.int32_t
-	errID := rpccgoNativeGreeterv1GreeterSayHello(C.uintptr_t(namePtr), C.int32_t(nameLen), C.int32_t(nameOwnership), C.uintptr_t(cityPtr), C.int32_t(cityLen), C.int32_t(cityOwnership), &messagePtr, &messageLen, &messageOwnership)
+	errID := rpccgoNativeGreeterv1GreeterSayHello(namePtr, C.int32_t(nameLen), C.int32_t(nameOwnership), cityPtr, C.int32_t(cityLen), C.int32_t(cityOwnership), &messagePtr, &messageLen, &messageOwnership)
 	*outMessagePtr = uintptr(messagePtr)
 	*outMessageLen = int32(messageLen)
 	return int32(errID)
 }
 
+func callGreeterSayHelloNativeUnaryInto(namePtr unsafe.Pointer, nameLen int32, cityPtr unsafe.Pointer, cityLen int32, buffer []byte, outMessagePtr *uintptr, outMessageLen *int32, outBufferLen *int32) int32 {
+	var messagePtr C.uintptr_t
+	var messageLen C.int32_t
+	var messageOwnership C.int32_t
+	var bufferLen C.int32_t
+	errID := rpccgoNativeGreeterv1GreeterSayHelloInto(namePtr, C.int32_t(nameLen), 0, cityPtr, C.int32_t(cityLen), 0, &messagePtr, &messageLen, &messageOwnership, unsafe.Pointer(unsafe.SliceData(buffer)), C.int32_t(len(buffer)), &bufferLen)
+	*outMessagePtr = uintptr(messagePtr)
+	*outMessageLen = int32(messageLen)
+	*outBufferLen = int32(bufferLen)
+	return int32(errID)
+}
+
 func greeterNativeCollectStart() (int32, int32) {
 	var stream C.int32_t
 	errID := rpccgoNativeGreeterv1GreeterCollectStart(&stream)
 	return int32(stream), int32(errID)
 }
 
-func greeterNativeCollectSend(stream int32, namePtr uintptr, nameLen int32, nameOwnership int32, cityPtr uintptr, cityLen int32, cityOwnership int32) int32 {
-	return int32(rpccgoNativeGreeterv1GreeterCollectSend(C.int32_t(stream), C.uintptr_t(namePtr), C.int32_t(nameLen), C.int32_t(nameOwnership), C.uintptr_t(cityPtr), C.int32_t(cityLen), C.int32_t(cityOwnership)))
+func greeterNativeCollectSend(stream int32, namePtr unsafe.Pointer, nameLen int32, nameOwnership int32, cityPtr unsafe.Pointer, cityLen int32, cityOwnership int32) int32 {
+	return int32(rpccgoNativeGreeterv1GreeterCollectSend(C.int32_t(stream), namePtr, C.int32_t(nameLen), C.int32_t(nameOwnership), cityPtr, C.int32_t(cityLen), C.int32_t(cityOwnership)))
 }
 
 func greeterNativeCollectFinish(stream int32, outMessagePtr *uintptr, outMessageLen *int32) int32 {
@@ -36,9 +52,9 @@ func greeterNativeCollectFinish(stream int32, outMessagePtr *uintptr, outMessage
 	return int32(errID)
 }
 
-func greeterNativeBroadcastStart(namePtr uintptr, nameLen int32, nameOwnership int32, cityPtr uintptr, cityLen int32, cityOwnership int32) (int32, int32) {
+func greeterNativeBroadcastStart(namePtr unsafe.Pointer, nameLen int32, nameOwnership int32, cityPtr unsafe.Pointer, cityLen int32, cityOwnership int32) (int32, int32) {
 	var stream C.int32_t
-	errID := rpccgoNativeGreeterv1GreeterBroadcastStart(C.uintptr_t(namePtr), C.int32_t(nameLen), C.int32_t(nameOwnership), C.uintptr_t(cityPtr), C.int32_t(cityLen), C.int32_t(cityOwnership), &stream, nil, nil)
+	errID := rpccgoNativeGreeterv1GreeterBroadcastStart(namePtr, C.int32_t(nameLen), C.int32_t(nameOwnership), cityPtr, C.int32_t(cityLen), C.int32_t(cityOwnership), &stream, nil, nil)
 	return int32(stream), int32(errID)
 }
 
@@ -58,8 +74,8 @@ func greeterNativeChatStart() (int32, int32) {
 	return int32(stream), int32(errID)
 }
 
-func greeterNativeChatSend(stream int32, namePtr uintptr, nameLen int32, nameOwnership int32, cityPtr uintptr, cityLen int32, cityOwnership int32) int32 {
-	return int32(rpccgoNativeGreeterv1GreeterChatSend(C.int32_t(stream), C.uintptr_t(namePtr), C.int32_t(nameLen), C.int32_t(nameOwnership), C.uintptr_t(cityPtr), C.int32_t(cityLen), C.int32_t(cityOwnership)))
+func greeterNativeChatSend(stream int32, namePtr unsafe.Pointer, nameLen int32, nameOwnership int32, cityPtr unsafe.Pointer, cityLen int32, cityOwnership int32) int32 {
+	return int32(rpccgoNativeGreeterv1GreeterChatSend(C.int32_t(stream), namePtr, C.int32_t(nameLen), C.int32_t(nameOwnership), cityPtr, C.int32_t(cityLen), C.int32_t(cityOwnership)))
 }
 
 func greeterNativeChatRecv(stream int32, outMessagePtr *uintptr, outMessageLen *int32) int32 {
@@ -80,23 +96,30 @@ func greeterNativeChatFinish(stream int32) int32 {
 	return int32(rpccgoNativeGreeterv1GreeterChatFinish(C.int32_t(stream)))
 }
 
-func callGreeterSayHelloMessageUnary(requestPtr uintptr, requestLen int32, outMessagePtr *uintptr, outMessageLen *int32) int32 {
+func callGreeterSayHelloMessageUnary(requestPtr unsafe.Pointer, requestLen int32, outMessagePtr *uintptr, outMessageLen *int32) int32 {
 	var messagePtr C.uintptr_t
 	var messageLen C.int32_t
-	errID := rpccgoMsgGreeterv1GreeterSayHello(C.uintptr_t(requestPtr), C.int32_t(requestLen), &messagePtr, &messageLen)
+	errID := rpccgoMsgGreeterv1GreeterSayHello(requestPtr, C.int32_t(requestLen), &messagePtr, &messageLen)
 	*outMessagePtr = uintptr(messagePtr)
 	*outMessageLen = int32(messageLen)
 	return int32(errID)
 }
 
+func callGreeterSayHelloMessageUnaryInto(requestPtr unsafe.Pointer, requestLen int32, buffer []byte, outResponseLen *int32) int32 {
+	var responseLen C.int32_t
+	errID := rpccgoMsgGreeterv1GreeterSayHelloInto(requestPtr, C.int32_t(requestLen), unsafe.Pointer(unsafe.SliceData(buffer)), C.int32_t(len(buffer)), &responseLen)
+	*outResponseLen = int32(responseLen)
+	return int32(errID)
+}
+
 func greeterMessageCollectStart() (int32, int32) {
 	var stream C.int32_t
 	errID := rpccgoMsgGreeterv1GreeterCollectStart(&stream)
 	return int32(stream), int32(errID)
 }
 
-func greeterMessageCollectSend(stream int32, requestPtr uintptr, requestLen int32) int32 {
-	return int32(rpccgoMsgGreeterv1GreeterCollectSend(C.int32_t(stream), C.uintptr_t(requestPtr), C.int32_t(requestLen)))
+func greeterMessageCollectSend(stream int32, requestPtr unsafe.Pointer, requestLen int32) int32 {
+	return int32(rpccgoMsgGreeterv1GreeterCollectSend(C.int32_t(stream), requestPtr, C.int32_t(requestLen)))
 }
 
 func greeterMessageCollectFinish(stream int32, outMessagePtr *uintptr, outMessageLen *int32) int32 {
@@ -108,9 +131,9 @@ func greeterMessageCollectFinish(stream int32, outMessagePtr *uintptr, outMessag
 	return int32(errID)
 }
 
-func greeterMessageBroadcastStart(requestPtr uintptr, requestLen int32) (int32, int32) {
+func greeterMessageBroadcastStart(requestPtr unsafe.Pointer, requestLen int32) (int32, int32) {
 	var stream C.int32_t
-	errID := rpccgoMsgGreeterv1GreeterBroadcastStart(C.uintptr_t(requestPtr), C.int32_t(requestLen), &stream, nil, nil)
+	errID := rpccgoMsgGreeterv1GreeterBroadcastStart(requestPtr, C.int32_t(requestLen), &stream, nil, nil)
 	return int32(stream), int32(errID)
 }
 
@@ -129,8 +152,8 @@ func greeterMessageChatStart() (int32, int32) {
 	return int32(stream), int32(errID)
 }
 
-func greeterMessageChatSend(stream int32, requestPtr uintptr, requestLen int32) int32 {
-	return int32(rpccgoMsgGreeterv1GreeterChatSend(C.int32_t(stream), C.uintptr_t(requestPtr), C.int32_t(requestLen)))
+func greeterMessageChatSend(stream int32, requestPtr unsafe.Pointer, requestLen int32) int32 {
+	return int32(rpccgoMsgGreeterv1GreeterChatSend(C.int32_t(stream), requestPtr, C.int32_t(requestLen)))
 }
 
 func greeterMessageChatRecv(stream int32, outMessagePtr *uintptr, outMessageLen *int32) int32 {
@@ -149,3 +172,47 @@ func greeterMessageChatCloseSend(stream int32) int32 {
 func greeterMessageChatFinish(stream int32) int32 {
 	return int32(rpccgoMsgGreeterv1GreeterChatFinish(C.int32_t(stream)))
 }
+
+func newCallOptions() (int32, int32) {
+	var options C.int32_t
+	errID := rpccgoCallOptionsNew(0, &options)
+	return int32(options), int32(errID)
+}
+
+func releaseCallOptions(options int32) int32 {
+	return int32(rpccgoCallOptionsRelease(C.int32_t(options)))
+}
+
+func greeterMessageCollectStartWithOptions(options int32) (int32, int32) {
+	var stream C.int32_t
+	errID := rpccgoMsgGreeterv1GreeterCollectStartWithOptions(C.int32_t(options), &stream)
+	return int32(stream), int32(errID)
+}
+
+func greeterMessageCollectSendWithOptions(options int32, stream int32, requestPtr unsafe.Pointer, requestLen int32) int32 {
+	return int32(rpccgoMsgGreeterv1GreeterCollectSendWithOptions(C.int32_t(options), C.int32_t(stream), requestPtr, C.int32_t(requestLen)))
+}
+
+func greeterMessageCollectFinishWithOptions(options int32, stream int32, outMessagePtr *uintptr, outMessageLen *int32) int32 {
+	var messagePtr C.uintptr_t
+	var messageLen C.int32_t
+	errID := rpccgoMsgGreeterv1GreeterCollectFinishWithOptions(C.int32_t(options), C.int32_t(stream), &messagePtr, &messageLen)
+	*outMessagePtr = uintptr(messagePtr)
+	*outMessageLen = int32(messageLen)
+	return int32(errID)
+}
+
+func takeCallOptionsResponseMetadata(options int32) ([]byte, []byte, int32) {
+	var headerPtr, trailerPtr C.uintptr_t
+	var headerLen, trailerLen C.int32_t
+	errID := rpccgoCallOptionsTakeResponseMetadata(C.int32_t(options), &headerPtr, &headerLen, &trailerPtr, &trailerLen)
+	return takeReleasedBytes(headerPtr, headerLen), takeReleasedBytes(trailerPtr, trailerLen), int32(errID)
+}
+
+func takeReleasedBytes(ptr C.uintptr_t, length C.int32_t) []byte {
+	if ptr == 0 {
+		return nil
+	}
+	defer rpccgoRelease(ptr)
+	return C.GoBytes(C.bridgePointer(ptr), C.int(length))
+}
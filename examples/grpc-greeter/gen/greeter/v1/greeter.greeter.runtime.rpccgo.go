@@ -24,28 +24,82 @@ var GreeterNativeServerUnavailableErr = errors.New("rpccgo: native server is una
 // GreeterMessageServerUnavailableErr is returned when a message server registration is missing or invalid.
 var GreeterMessageServerUnavailableErr = errors.New("rpccgo: message server is unavailable")
 
+func init() {
+	rpcruntime.RegisterServiceManifest(rpcruntime.ServiceManifest{
+		ServiceID:        greeterServiceID,
+		ProtoFile:        "proto/greeter.proto",
+		Native:           true,
+		MessageTransport: rpcruntime.ServerKindGRPC,
+		Methods: []rpcruntime.MethodManifest{
+			{Name: "SayHello", FullName: "examples.grpc.greeter.v1.Greeter.SayHello", Streaming: rpcruntime.MethodStreamingUnary, RequestType: "examples.grpc.greeter.v1.SayHelloRequest", ResponseType: "examples.grpc.greeter.v1.SayHelloResponse"},
+			{Name: "Collect", FullName: "examples.grpc.greeter.v1.Greeter.Collect", Streaming: rpcruntime.MethodStreamingClient, RequestType: "examples.grpc.greeter.v1.SayHelloRequest", ResponseType: "examples.grpc.greeter.v1.SayHelloResponse"},
+			{Name: "Broadcast", FullName: "examples.grpc.greeter.v1.Greeter.Broadcast", Streaming: rpcruntime.MethodStreamingServer, RequestType: "examples.grpc.greeter.v1.SayHelloRequest", ResponseType: "examples.grpc.greeter.v1.SayHelloResponse"},
+			{Name: "Chat", FullName: "examples.grpc.greeter.v1.Greeter.Chat", Streaming: rpcruntime.MethodStreamingBidi, RequestType: "examples.grpc.greeter.v1.SayHelloRequest", ResponseType: "examples.grpc.greeter.v1.SayHelloResponse"},
+		},
+	})
+	rpcruntime.RegisterDynamicMethod("examples.grpc.greeter.v1.Greeter.SayHello", rpcruntime.DynamicUnary(InvokeGreeterMessageSayHello))
+	rpcruntime.RegisterDynamicMethod("examples.grpc.greeter.v1.Greeter.Collect", rpcruntime.DynamicClientStream(GreeterMessageCollectStart, GreeterMessageCollectSend, GreeterMessageCollectFinish, GreeterMessageCollectCancel))
+	rpcruntime.RegisterDynamicMethod("examples.grpc.greeter.v1.Greeter.Broadcast", rpcruntime.DynamicServerStream(GreeterMessageBroadcastStart, GreeterMessageBroadcastRecv, GreeterMessageBroadcastCancel))
+	rpcruntime.RegisterDynamicMethod("examples.grpc.greeter.v1.Greeter.Chat", rpcruntime.DynamicBidiStream(GreeterMessageChatStart, GreeterMessageChatSend, GreeterMessageChatRecv, GreeterMessageChatCloseSend, GreeterMessageChatFinish, GreeterMessageChatCancel))
+}
+
 // ClearGreeterServer clears the current registered server for this service.
 func ClearGreeterServer() error {
-	return rpcruntime.ClearServer(greeterServiceID)
+	return ClearGreeterServerIn(rpcruntime.DefaultServerRegistry())
+}
+
+// ClearGreeterServerIn clears the current registered server for this service in registry.
+func ClearGreeterServerIn(registry *rpcruntime.ServerRegistry) error {
+	return registry.Clear(greeterServiceID)
 }
 
-// LoadGreeterRegisteredServer loads the current registered server record for this service.
+// LoadGreeterRegisteredServer loads the current registered server record for this service. With a multi-server route it returns the first target.
 func LoadGreeterRegisteredServer() (rpcruntime.RegisteredServer, error) {
-	return rpcruntime.LoadServer(greeterServiceID)
+	return LoadGreeterRegisteredServerIn(rpcruntime.DefaultServerRegistry())
+}
+
+// LoadGreeterRegisteredServerIn loads the current registered server record for this service from registry.
+func LoadGreeterRegisteredServerIn(registry *rpcruntime.ServerRegistry) (rpcruntime.RegisteredServer, error) {
+	return registry.Load(greeterServiceID)
+}
+
+// RegisterGreeterServerRoute replaces the registered servers for this service with an ordered or weighted route. Targets are records loaded after the generated Register helpers.
+func RegisterGreeterServerRoute(route rpcruntime.ServerRoute) error {
+	return RegisterGreeterServerRouteIn(rpcruntime.DefaultServerRegistry(), route)
+}
+
+// RegisterGreeterServerRouteIn replaces the registered servers for this service in registry with an ordered or weighted route.
+func RegisterGreeterServerRouteIn(registry *rpcruntime.ServerRegistry, route rpcruntime.ServerRoute) error {
+	return registry.RegisterRoute(greeterServiceID, route)
+}
+
+// LoadGreeterServerRoute loads the registered server route for this service.
+func LoadGreeterServerRoute() (rpcruntime.ServerRoute, error) {
+	return LoadGreeterServerRouteIn(rpcruntime.DefaultServerRegistry())
+}
+
+// LoadGreeterServerRouteIn loads the registered server route for this service from registry.
+func LoadGreeterServerRouteIn(registry *rpcruntime.ServerRegistry) (rpcruntime.ServerRoute, error) {
+	return registry.LoadRoute(greeterServiceID)
 }
 
 // RegisterGreeterGRPCServer registers the supplied grpc server server as the current server for this service.
 func RegisterGreeterGRPCServer(server GreeterServer) error {
+	return RegisterGreeterGRPCServerIn(rpcruntime.DefaultServerRegistry(), server)
+}
+
+// RegisterGreeterGRPCServerIn registers the supplied grpc server server as the current server for this service in registry.
+func RegisterGreeterGRPCServerIn(registry *rpcruntime.ServerRegistry, server GreeterServer) error {
 	if server == nil {
-		_ = rpcruntime.ClearServer(greeterServiceID)
+		_ = registry.Clear(greeterServiceID)
 		return GreeterMessageServerUnavailableErr
 	}
-	err := rpcruntime.RegisterServer(greeterServiceID, rpcruntime.RegisteredServer{
+	err := registry.Register(greeterServiceID, rpcruntime.RegisteredServer{
 		Kind:   rpcruntime.ServerKindGRPC,
 		Server: server,
 	})
 	if err != nil {
-		_ = rpcruntime.ClearServer(greeterServiceID)
+		_ = registry.Clear(greeterServiceID)
 		return err
 	}
 	return nil
@@ -53,16 +107,21 @@ func RegisterGreeterGRPCServer(server GreeterServer) error {
 
 // RegisterGreeterGRPCRemoteServer registers the supplied grpc remote server as the current server for this service.
 func RegisterGreeterGRPCRemoteServer(client GreeterClient) error {
+	return RegisterGreeterGRPCRemoteServerIn(rpcruntime.DefaultServerRegistry(), client)
+}
+
+// RegisterGreeterGRPCRemoteServerIn registers the supplied grpc remote server as the current server for this service in registry.
+func RegisterGreeterGRPCRemoteServerIn(registry *rpcruntime.ServerRegistry, client GreeterClient) error {
 	if client == nil {
-		_ = rpcruntime.ClearServer(greeterServiceID)
+		_ = registry.Clear(greeterServiceID)
 		return GreeterMessageServerUnavailableErr
 	}
-	err := rpcruntime.RegisterServer(greeterServiceID, rpcruntime.RegisteredServer{
+	err := registry.Register(greeterServiceID, rpcruntime.RegisteredServer{
 		Kind:   rpcruntime.ServerKindGRPCRemote,
 		Server: client,
 	})
 	if err != nil {
-		_ = rpcruntime.ClearServer(greeterServiceID)
+		_ = registry.Clear(greeterServiceID)
 		return err
 	}
 	return nil
@@ -70,54 +129,67 @@ func RegisterGreeterGRPCRemoteServer(client GreeterClient) error {
 
 func newgreeterCollectGRPCDirectMessageStreamSession(ctx context.Context, server GreeterServer) rpcruntime.ClientStreamingClient[*SayHelloRequest, *SayHelloResponse] {
 	client, stream, streamCtx := rpcruntime.NewClientStreaming[*SayHelloRequest, *SayHelloResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:        "examples.grpc.greeter.v1.Greeter.Collect",
 		RequestBuffer: 16,
 		StreamClosed:  errors.New("rpccgo: message stream is closed"),
 		NilRequest:    errors.New("rpccgo: message request is nil"),
 	})
 	grpcStream := rpcruntime.NewGRPCClientStreamingServer[SayHelloRequest, SayHelloResponse](streamCtx, stream)
-	go func() { grpcStream.Complete(server.Collect(grpcStream)) }()
+	go func() {
+		defer rpcruntime.RecoverPanic("examples.grpc.greeter.v1.Greeter.Collect handler", func(err error) { grpcStream.Complete(err) })
+		grpcStream.Complete(server.Collect(grpcStream))
+	}()
 	return client
 }
 
 func newgreeterCollectGRPCRemoteMessageStreamSession(ctx context.Context, client GreeterClient) (*greeterCollectGRPCRemoteMessageStreamSession, error) {
 	streamCtx, cancel := context.WithCancel(ctx)
-	stream, err := client.Collect(streamCtx)
+	callCtx, callOpts, finishMetadata := rpcruntime.GRPCClientContext(streamCtx)
+	stream, err := client.Collect(callCtx, callOpts...)
 	if err != nil {
 		cancel()
 		return nil, err
 	}
-	return &greeterCollectGRPCRemoteMessageStreamSession{stream: stream, cancel: cancel}, nil
+	return &greeterCollectGRPCRemoteMessageStreamSession{stream: stream, cancel: cancel, finishMetadata: finishMetadata}, nil
 }
 
 type greeterCollectGRPCRemoteMessageStreamSession struct {
-	stream grpc.ClientStreamingClient[SayHelloRequest, SayHelloResponse]
-	cancel context.CancelFunc
+	stream         grpc.ClientStreamingClient[SayHelloRequest, SayHelloResponse]
+	cancel         context.CancelFunc
+	finishMetadata func()
 }
 
 func (s *greeterCollectGRPCRemoteMessageStreamSession) Send(ctx context.Context, req *SayHelloRequest) error {
-	_ = ctx
 	if req == nil {
 		return errors.New("rpccgo: message request is nil")
 	}
 	if s == nil || s.stream == nil {
 		return errors.New("rpccgo: grpc remote client stream is nil")
 	}
-	return s.stream.Send(req)
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
+	return rpcruntime.ContextError(ctx, s.stream.Send(req))
 }
 
 func (s *greeterCollectGRPCRemoteMessageStreamSession) Finish(ctx context.Context) (*SayHelloResponse, error) {
-	_ = ctx
 	if s == nil || s.stream == nil {
 		return nil, errors.New("rpccgo: grpc remote client stream is nil")
 	}
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
 	defer func() {
 		if s.cancel != nil {
 			s.cancel()
 		}
 	}()
 	response, err := s.stream.CloseAndRecv()
+	s.finishMetadata()
 	if err != nil {
-		return nil, err
+		return nil, rpcruntime.ContextError(ctx, err)
 	}
 	if response == nil {
 		return nil, errors.New("rpccgo: message response is nil")
@@ -141,12 +213,16 @@ func newgreeterBroadcastGRPCDirectMessageStreamSession(ctx context.Context, serv
 		return nil, errors.New("rpccgo: message request is nil")
 	}
 	client, stream, streamCtx := rpcruntime.NewServerStreaming[*SayHelloResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:         "examples.grpc.greeter.v1.Greeter.Broadcast",
 		ResponseBuffer: 1,
 		StreamClosed:   errors.New("rpccgo: message stream is closed"),
 		NilResponse:    errors.New("rpccgo: message response is nil"),
 	})
 	grpcStream := rpcruntime.NewGRPCServerStreamingServer[SayHelloResponse](streamCtx, stream)
-	go func() { stream.Complete(server.Broadcast(req, grpcStream)) }()
+	go func() {
+		defer rpcruntime.RecoverPanic("examples.grpc.greeter.v1.Greeter.Broadcast handler", func(err error) { stream.Complete(err) })
+		stream.Complete(server.Broadcast(req, grpcStream))
+	}()
 	return client, nil
 }
 
@@ -155,30 +231,36 @@ func newgreeterBroadcastGRPCRemoteMessageStreamSession(ctx context.Context, clie
 		return nil, errors.New("rpccgo: message request is nil")
 	}
 	streamCtx, cancel := context.WithCancel(ctx)
-	stream, err := client.Broadcast(streamCtx, req)
+	callCtx, callOpts, finishMetadata := rpcruntime.GRPCClientContext(streamCtx)
+	stream, err := client.Broadcast(callCtx, req, callOpts...)
 	if err != nil {
 		cancel()
 		return nil, err
 	}
-	return &greeterBroadcastGRPCRemoteMessageStreamSession{stream: stream, cancel: cancel}, nil
+	return &greeterBroadcastGRPCRemoteMessageStreamSession{stream: stream, cancel: cancel, finishMetadata: finishMetadata}, nil
 }
 
 type greeterBroadcastGRPCRemoteMessageStreamSession struct {
-	stream grpc.ServerStreamingClient[SayHelloResponse]
-	cancel context.CancelFunc
+	stream         grpc.ServerStreamingClient[SayHelloResponse]
+	cancel         context.CancelFunc
+	finishMetadata func()
 }
 
 func (s *greeterBroadcastGRPCRemoteMessageStreamSession) Recv(ctx context.Context) (*SayHelloResponse, error) {
-	_ = ctx
 	if s == nil || s.stream == nil {
 		return nil, errors.New("rpccgo: grpc remote server stream is nil")
 	}
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
 	response, err := s.stream.Recv()
 	if err != nil {
+		s.finishMetadata()
 		if errors.Is(err, io.EOF) {
 			return nil, io.EOF
 		}
-		return nil, err
+		return nil, rpcruntime.ContextError(ctx, err)
 	}
 	if response == nil {
 		return nil, errors.New("rpccgo: message response is nil")
@@ -207,6 +289,7 @@ func (s *greeterBroadcastGRPCRemoteMessageStreamSession) Cancel(ctx context.Cont
 
 func newgreeterChatGRPCDirectMessageStreamSession(ctx context.Context, server GreeterServer) rpcruntime.BidiStreamingClient[*SayHelloRequest, *SayHelloResponse] {
 	client, stream, streamCtx := rpcruntime.NewBidiStreaming[*SayHelloRequest, *SayHelloResponse](ctx, rpcruntime.LocalStreamOptions{
+		Method:         "examples.grpc.greeter.v1.Greeter.Chat",
 		RequestBuffer:  16,
 		ResponseBuffer: 1,
 		StreamClosed:   errors.New("rpccgo: message stream is closed"),
@@ -214,47 +297,59 @@ func newgreeterChatGRPCDirectMessageStreamSession(ctx context.Context, server Gr
 		NilResponse:    errors.New("rpccgo: message response is nil"),
 	})
 	grpcStream := rpcruntime.NewGRPCBidiStreamingServer[SayHelloRequest, SayHelloResponse](streamCtx, stream)
-	go func() { stream.Complete(server.Chat(grpcStream)) }()
+	go func() {
+		defer rpcruntime.RecoverPanic("examples.grpc.greeter.v1.Greeter.Chat handler", func(err error) { stream.Complete(err) })
+		stream.Complete(server.Chat(grpcStream))
+	}()
 	return client
 }
 
 func newgreeterChatGRPCRemoteMessageStreamSession(ctx context.Context, client GreeterClient) (*greeterChatGRPCRemoteMessageStreamSession, error) {
 	streamCtx, cancel := context.WithCancel(ctx)
-	stream, err := client.Chat(streamCtx)
+	callCtx, callOpts, finishMetadata := rpcruntime.GRPCClientContext(streamCtx)
+	stream, err := client.Chat(callCtx, callOpts...)
 	if err != nil {
 		cancel()
 		return nil, err
 	}
-	return &greeterChatGRPCRemoteMessageStreamSession{stream: stream, cancel: cancel}, nil
+	return &greeterChatGRPCRemoteMessageStreamSession{stream: stream, cancel: cancel, finishMetadata: finishMetadata}, nil
 }
 
 type greeterChatGRPCRemoteMessageStreamSession struct {
-	stream grpc.BidiStreamingClient[SayHelloRequest, SayHelloResponse]
-	cancel context.CancelFunc
+	stream         grpc.BidiStreamingClient[SayHelloRequest, SayHelloResponse]
+	cancel         context.CancelFunc
+	finishMetadata func()
 }
 
 func (s *greeterChatGRPCRemoteMessageStreamSession) Send(ctx context.Context, req *SayHelloRequest) error {
-	_ = ctx
 	if req == nil {
 		return errors.New("rpccgo: message request is nil")
 	}
 	if s == nil || s.stream == nil {
 		return errors.New("rpccgo: grpc remote bidi stream is nil")
 	}
-	return s.stream.Send(req)
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
+	return rpcruntime.ContextError(ctx, s.stream.Send(req))
 }
 
 func (s *greeterChatGRPCRemoteMessageStreamSession) Recv(ctx context.Context) (*SayHelloResponse, error) {
-	_ = ctx
 	if s == nil || s.stream == nil {
 		return nil, errors.New("rpccgo: grpc remote bidi stream is nil")
 	}
+	if s.cancel != nil {
+		stop := context.AfterFunc(ctx, s.cancel)
+		defer stop()
+	}
 	response, err := s.stream.Recv()
 	if err != nil {
+		s.finishMetadata()
 		if errors.Is(err, io.EOF) {
 			return nil, io.EOF
 		}
-		return nil, err
+		return nil, rpcruntime.ContextError(ctx, err)
 	}
 	if response == nil {
 		return nil, errors.New("rpccgo: message response is nil")
@@ -289,12 +384,26 @@ func (s *greeterChatGRPCRemoteMessageStreamSession) CloseSend(ctx context.Contex
 	return s.stream.CloseSend()
 }
 
-// InvokeGreeterNativeSayHello invokes the current registered server using the native contract for SayHello.
+// InvokeGreeterNativeSayHello invokes the server selected by the service route using the native contract for SayHello.
 func InvokeGreeterNativeSayHello(ctx context.Context, name *rpcruntime.RpcString, city *rpcruntime.RpcString) (string, error) {
-	registered, err := rpcruntime.LoadServer(greeterServiceID)
+	var messageResult string
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteUnary(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.grpc.greeter.v1.Greeter.SayHello",
+		Contract:  rpcruntime.CallContractNative,
+		Operation: rpcruntime.CallOperationUnary,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		messageResult, callErr = invokeGreeterNativeSayHello(ctx, registered, name, city)
+		return callErr
+	})
 	if err != nil {
 		return "", err
 	}
+	return messageResult, nil
+}
+
+func invokeGreeterNativeSayHello(ctx context.Context, registered rpcruntime.RegisteredServer, name *rpcruntime.RpcString, city *rpcruntime.RpcString) (string, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindGoNative:
 		server, ok := registered.Server.(GreeterNativeServer)
@@ -332,7 +441,8 @@ func InvokeGreeterNativeSayHello(ctx context.Context, name *rpcruntime.RpcString
 			return "", err
 		}
 		var messageResp *SayHelloResponse
-		messageResp, err = server.SayHello(ctx, messageReq)
+		callCtx := rpcruntime.GRPCHandlerContext(ctx, "/examples.grpc.greeter.v1.Greeter/SayHello")
+		messageResp, err = server.SayHello(callCtx, messageReq)
 		if err != nil {
 			return "", err
 		}
@@ -354,7 +464,9 @@ func InvokeGreeterNativeSayHello(ctx context.Context, name *rpcruntime.RpcString
 			return "", err
 		}
 		var messageResp *SayHelloResponse
-		messageResp, err = server.SayHello(ctx, messageReq)
+		callCtx, callOpts, finishMetadata := rpcruntime.GRPCClientContext(ctx)
+		messageResp, err = server.SayHello(callCtx, messageReq, callOpts...)
+		finishMetadata()
 		if err != nil {
 			return "", err
 		}
@@ -367,19 +479,33 @@ func InvokeGreeterNativeSayHello(ctx context.Context, name *rpcruntime.RpcString
 		}
 		return convertGreeterSayHelloMessageToNativeResponse(messageResp)
 	default:
-		return "", err
+		return "", fmt.Errorf("rpccgo: Greeter registered server kind %d is unsupported for native calls", registered.Kind)
 	}
 }
 
-// InvokeGreeterMessageSayHello invokes the current registered server using the message contract for SayHello.
+// InvokeGreeterMessageSayHello invokes the server selected by the service route using the message contract for SayHello.
 func InvokeGreeterMessageSayHello(ctx context.Context, req *SayHelloRequest) (*SayHelloResponse, error) {
 	if req == nil {
 		return nil, errors.New("rpccgo: message request is nil")
 	}
-	registered, err := rpcruntime.LoadServer(greeterServiceID)
+	var resp *SayHelloResponse
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteUnary(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.grpc.greeter.v1.Greeter.SayHello",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationUnary,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		resp, callErr = invokeGreeterMessageSayHello(ctx, registered, req)
+		return callErr
+	})
 	if err != nil {
 		return nil, err
 	}
+	return resp, nil
+}
+
+func invokeGreeterMessageSayHello(ctx context.Context, registered rpcruntime.RegisteredServer, req *SayHelloRequest) (*SayHelloResponse, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindGoNative:
 		server, ok := registered.Server.(GreeterNativeServer)
@@ -429,7 +555,8 @@ func InvokeGreeterMessageSayHello(ctx context.Context, req *SayHelloRequest) (*S
 		if !ok {
 			return nil, fmt.Errorf("rpccgo: Greeter grpc server registered server has invalid type")
 		}
-		messageResp, err := server.SayHello(ctx, req)
+		callCtx := rpcruntime.GRPCHandlerContext(ctx, "/examples.grpc.greeter.v1.Greeter/SayHello")
+		messageResp, err := server.SayHello(callCtx, req)
 		if err != nil {
 			return nil, err
 		}
@@ -442,7 +569,9 @@ func InvokeGreeterMessageSayHello(ctx context.Context, req *SayHelloRequest) (*S
 		if !ok {
 			return nil, fmt.Errorf("rpccgo: Greeter grpc remote registered server has invalid type")
 		}
-		messageResp, err := server.SayHello(ctx, req)
+		callCtx, callOpts, finishMetadata := rpcruntime.GRPCClientContext(ctx)
+		messageResp, err := server.SayHello(callCtx, req, callOpts...)
+		finishMetadata()
 		if err != nil {
 			return nil, err
 		}
@@ -455,12 +584,26 @@ func InvokeGreeterMessageSayHello(ctx context.Context, req *SayHelloRequest) (*S
 	}
 }
 
-// GreeterNativeCollectStart starts a native contract stream for Collect on the current registered server.
+// GreeterNativeCollectStart starts a native contract stream for Collect on the server selected by the service route.
 func GreeterNativeCollectStart(ctx context.Context) (rpcruntime.StreamHandle, error) {
-	registered, err := rpcruntime.LoadServer(greeterServiceID)
+	var streamHandle rpcruntime.StreamHandle
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.grpc.greeter.v1.Greeter.Collect",
+		Contract:  rpcruntime.CallContractNative,
+		Operation: rpcruntime.CallOperationStart,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		streamHandle, callErr = greeterNativeCollectStart(ctx, registered)
+		return callErr
+	})
 	if err != nil {
 		return 0, err
 	}
+	return streamHandle, nil
+}
+
+func greeterNativeCollectStart(ctx context.Context, registered rpcruntime.RegisteredServer) (rpcruntime.StreamHandle, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindGoNative:
 		server, ok := registered.Server.(GreeterNativeServer)
@@ -471,7 +614,7 @@ func GreeterNativeCollectStart(ctx context.Context) (rpcruntime.StreamHandle, er
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGoNative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGoNative, source)
 	case rpcruntime.ServerKindCGONative:
 		server, ok := registered.Server.(GreeterNativeServer)
 		if !ok {
@@ -481,7 +624,7 @@ func GreeterNativeCollectStart(ctx context.Context) (rpcruntime.StreamHandle, er
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGONative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGONative, source)
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(GreeterCGOMessageServer)
 		if !ok {
@@ -491,14 +634,14 @@ func GreeterNativeCollectStart(ctx context.Context) (rpcruntime.StreamHandle, er
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)
 	case rpcruntime.ServerKindGRPC:
 		server, ok := registered.Server.(GreeterServer)
 		if !ok {
 			return 0, fmt.Errorf("rpccgo: Greeter grpc server registered server has invalid type")
 		}
 		source := newgreeterCollectGRPCDirectMessageStreamSession(ctx, server)
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGRPC, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGRPC, source)
 	case rpcruntime.ServerKindGRPCRemote:
 		server, ok := registered.Server.(GreeterClient)
 		if !ok {
@@ -508,18 +651,32 @@ func GreeterNativeCollectStart(ctx context.Context) (rpcruntime.StreamHandle, er
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGRPCRemote, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGRPCRemote, source)
 	default:
 		return 0, fmt.Errorf("rpccgo: Greeter registered server kind %d is unsupported for native stream starts", registered.Kind)
 	}
 }
 
-// GreeterMessageCollectStart starts a message contract stream for Collect on the current registered server.
+// GreeterMessageCollectStart starts a message contract stream for Collect on the server selected by the service route.
 func GreeterMessageCollectStart(ctx context.Context) (rpcruntime.StreamHandle, error) {
-	registered, err := rpcruntime.LoadServer(greeterServiceID)
+	var streamHandle rpcruntime.StreamHandle
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.grpc.greeter.v1.Greeter.Collect",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationStart,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		streamHandle, callErr = greeterMessageCollectStart(ctx, registered)
+		return callErr
+	})
 	if err != nil {
 		return 0, err
 	}
+	return streamHandle, nil
+}
+
+func greeterMessageCollectStart(ctx context.Context, registered rpcruntime.RegisteredServer) (rpcruntime.StreamHandle, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindGoNative:
 		server, ok := registered.Server.(GreeterNativeServer)
@@ -530,7 +687,7 @@ func GreeterMessageCollectStart(ctx context.Context) (rpcruntime.StreamHandle, e
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGoNative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGoNative, source)
 	case rpcruntime.ServerKindCGONative:
 		server, ok := registered.Server.(GreeterNativeServer)
 		if !ok {
@@ -540,7 +697,7 @@ func GreeterMessageCollectStart(ctx context.Context) (rpcruntime.StreamHandle, e
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGONative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGONative, source)
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(GreeterCGOMessageServer)
 		if !ok {
@@ -550,14 +707,14 @@ func GreeterMessageCollectStart(ctx context.Context) (rpcruntime.StreamHandle, e
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)
 	case rpcruntime.ServerKindGRPC:
 		server, ok := registered.Server.(GreeterServer)
 		if !ok {
 			return 0, fmt.Errorf("rpccgo: Greeter grpc server registered server has invalid type")
 		}
 		source := newgreeterCollectGRPCDirectMessageStreamSession(ctx, server)
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGRPC, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGRPC, source)
 	case rpcruntime.ServerKindGRPCRemote:
 		server, ok := registered.Server.(GreeterClient)
 		if !ok {
@@ -567,18 +724,32 @@ func GreeterMessageCollectStart(ctx context.Context) (rpcruntime.StreamHandle, e
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGRPCRemote, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGRPCRemote, source)
 	default:
 		return 0, fmt.Errorf("rpccgo: Greeter registered server kind %d is unsupported for message stream starts", registered.Kind)
 	}
 }
 
-// GreeterNativeBroadcastStart starts a native contract stream for Broadcast on the current registered server.
+// GreeterNativeBroadcastStart starts a native contract stream for Broadcast on the server selected by the service route.
 func GreeterNativeBroadcastStart(ctx context.Context, name *rpcruntime.RpcString, city *rpcruntime.RpcString) (rpcruntime.StreamHandle, error) {
-	registered, err := rpcruntime.LoadServer(greeterServiceID)
+	var streamHandle rpcruntime.StreamHandle
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.grpc.greeter.v1.Greeter.Broadcast",
+		Contract:  rpcruntime.CallContractNative,
+		Operation: rpcruntime.CallOperationStart,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		streamHandle, callErr = greeterNativeBroadcastStart(ctx, registered, name, city)
+		return callErr
+	})
 	if err != nil {
 		return 0, err
 	}
+	return streamHandle, nil
+}
+
+func greeterNativeBroadcastStart(ctx context.Context, registered rpcruntime.RegisteredServer, name *rpcruntime.RpcString, city *rpcruntime.RpcString) (rpcruntime.StreamHandle, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindGoNative:
 		server, ok := registered.Server.(GreeterNativeServer)
@@ -589,7 +760,7 @@ func GreeterNativeBroadcastStart(ctx context.Context, name *rpcruntime.RpcString
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGoNative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGoNative, source)
 	case rpcruntime.ServerKindCGONative:
 		server, ok := registered.Server.(GreeterNativeServer)
 		if !ok {
@@ -599,7 +770,7 @@ func GreeterNativeBroadcastStart(ctx context.Context, name *rpcruntime.RpcString
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGONative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGONative, source)
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(GreeterCGOMessageServer)
 		if !ok {
@@ -613,7 +784,7 @@ func GreeterNativeBroadcastStart(ctx context.Context, name *rpcruntime.RpcString
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)
 	case rpcruntime.ServerKindGRPC:
 		server, ok := registered.Server.(GreeterServer)
 		if !ok {
@@ -627,7 +798,7 @@ func GreeterNativeBroadcastStart(ctx context.Context, name *rpcruntime.RpcString
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGRPC, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGRPC, source)
 	case rpcruntime.ServerKindGRPCRemote:
 		server, ok := registered.Server.(GreeterClient)
 		if !ok {
@@ -641,21 +812,35 @@ func GreeterNativeBroadcastStart(ctx context.Context, name *rpcruntime.RpcString
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGRPCRemote, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGRPCRemote, source)
 	default:
 		return 0, fmt.Errorf("rpccgo: Greeter registered server kind %d is unsupported for native stream starts", registered.Kind)
 	}
 }
 
-// GreeterMessageBroadcastStart starts a message contract stream for Broadcast on the current registered server.
+// GreeterMessageBroadcastStart starts a message contract stream for Broadcast on the server selected by the service route.
 func GreeterMessageBroadcastStart(ctx context.Context, req *SayHelloRequest) (rpcruntime.StreamHandle, error) {
 	if req == nil {
 		return 0, errors.New("rpccgo: message request is nil")
 	}
-	registered, err := rpcruntime.LoadServer(greeterServiceID)
+	var streamHandle rpcruntime.StreamHandle
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.grpc.greeter.v1.Greeter.Broadcast",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationStart,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		streamHandle, callErr = greeterMessageBroadcastStart(ctx, registered, req)
+		return callErr
+	})
 	if err != nil {
 		return 0, err
 	}
+	return streamHandle, nil
+}
+
+func greeterMessageBroadcastStart(ctx context.Context, registered rpcruntime.RegisteredServer, req *SayHelloRequest) (rpcruntime.StreamHandle, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindGoNative:
 		server, ok := registered.Server.(GreeterNativeServer)
@@ -671,7 +856,7 @@ func GreeterMessageBroadcastStart(ctx context.Context, req *SayHelloRequest) (rp
 			return 0, err
 		}
 		goruntime.KeepAlive(reqOwner)
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGoNative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGoNative, source)
 	case rpcruntime.ServerKindCGONative:
 		server, ok := registered.Server.(GreeterNativeServer)
 		if !ok {
@@ -686,7 +871,7 @@ func GreeterMessageBroadcastStart(ctx context.Context, req *SayHelloRequest) (rp
 			return 0, err
 		}
 		goruntime.KeepAlive(reqOwner)
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGONative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGONative, source)
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(GreeterCGOMessageServer)
 		if !ok {
@@ -696,7 +881,7 @@ func GreeterMessageBroadcastStart(ctx context.Context, req *SayHelloRequest) (rp
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)
 	case rpcruntime.ServerKindGRPC:
 		server, ok := registered.Server.(GreeterServer)
 		if !ok {
@@ -706,7 +891,7 @@ func GreeterMessageBroadcastStart(ctx context.Context, req *SayHelloRequest) (rp
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGRPC, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGRPC, source)
 	case rpcruntime.ServerKindGRPCRemote:
 		server, ok := registered.Server.(GreeterClient)
 		if !ok {
@@ -716,18 +901,32 @@ func GreeterMessageBroadcastStart(ctx context.Context, req *SayHelloRequest) (rp
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGRPCRemote, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGRPCRemote, source)
 	default:
 		return 0, fmt.Errorf("rpccgo: Greeter registered server kind %d is unsupported for message stream starts", registered.Kind)
 	}
 }
 
-// GreeterNativeChatStart starts a native contract stream for Chat on the current registered server.
+// GreeterNativeChatStart starts a native contract stream for Chat on the server selected by the service route.
 func GreeterNativeChatStart(ctx context.Context) (rpcruntime.StreamHandle, error) {
-	registered, err := rpcruntime.LoadServer(greeterServiceID)
+	var streamHandle rpcruntime.StreamHandle
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.grpc.greeter.v1.Greeter.Chat",
+		Contract:  rpcruntime.CallContractNative,
+		Operation: rpcruntime.CallOperationStart,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		streamHandle, callErr = greeterNativeChatStart(ctx, registered)
+		return callErr
+	})
 	if err != nil {
 		return 0, err
 	}
+	return streamHandle, nil
+}
+
+func greeterNativeChatStart(ctx context.Context, registered rpcruntime.RegisteredServer) (rpcruntime.StreamHandle, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindGoNative:
 		server, ok := registered.Server.(GreeterNativeServer)
@@ -738,7 +937,7 @@ func GreeterNativeChatStart(ctx context.Context) (rpcruntime.StreamHandle, error
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGoNative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGoNative, source)
 	case rpcruntime.ServerKindCGONative:
 		server, ok := registered.Server.(GreeterNativeServer)
 		if !ok {
@@ -748,7 +947,7 @@ func GreeterNativeChatStart(ctx context.Context) (rpcruntime.StreamHandle, error
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGONative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGONative, source)
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(GreeterCGOMessageServer)
 		if !ok {
@@ -758,14 +957,14 @@ func GreeterNativeChatStart(ctx context.Context) (rpcruntime.StreamHandle, error
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)
 	case rpcruntime.ServerKindGRPC:
 		server, ok := registered.Server.(GreeterServer)
 		if !ok {
 			return 0, fmt.Errorf("rpccgo: Greeter grpc server registered server has invalid type")
 		}
 		source := newgreeterChatGRPCDirectMessageStreamSession(ctx, server)
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGRPC, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGRPC, source)
 	case rpcruntime.ServerKindGRPCRemote:
 		server, ok := registered.Server.(GreeterClient)
 		if !ok {
@@ -775,18 +974,32 @@ func GreeterNativeChatStart(ctx context.Context) (rpcruntime.StreamHandle, error
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGRPCRemote, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGRPCRemote, source)
 	default:
 		return 0, fmt.Errorf("rpccgo: Greeter registered server kind %d is unsupported for native stream starts", registered.Kind)
 	}
 }
 
-// GreeterMessageChatStart starts a message contract stream for Chat on the current registered server.
+// GreeterMessageChatStart starts a message contract stream for Chat on the server selected by the service route.
 func GreeterMessageChatStart(ctx context.Context) (rpcruntime.StreamHandle, error) {
-	registered, err := rpcruntime.LoadServer(greeterServiceID)
+	var streamHandle rpcruntime.StreamHandle
+	err := rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{
+		ServiceID: greeterServiceID,
+		Method:    "examples.grpc.greeter.v1.Greeter.Chat",
+		Contract:  rpcruntime.CallContractMessage,
+		Operation: rpcruntime.CallOperationStart,
+	}, func(ctx context.Context, registered rpcruntime.RegisteredServer) error {
+		var callErr error
+		streamHandle, callErr = greeterMessageChatStart(ctx, registered)
+		return callErr
+	})
 	if err != nil {
 		return 0, err
 	}
+	return streamHandle, nil
+}
+
+func greeterMessageChatStart(ctx context.Context, registered rpcruntime.RegisteredServer) (rpcruntime.StreamHandle, error) {
 	switch registered.Kind {
 	case rpcruntime.ServerKindGoNative:
 		server, ok := registered.Server.(GreeterNativeServer)
@@ -797,7 +1010,7 @@ func GreeterMessageChatStart(ctx context.Context) (rpcruntime.StreamHandle, erro
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGoNative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGoNative, source)
 	case rpcruntime.ServerKindCGONative:
 		server, ok := registered.Server.(GreeterNativeServer)
 		if !ok {
@@ -807,7 +1020,7 @@ func GreeterMessageChatStart(ctx context.Context) (rpcruntime.StreamHandle, erro
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGONative, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGONative, source)
 	case rpcruntime.ServerKindCGOMessage:
 		server, ok := registered.Server.(GreeterCGOMessageServer)
 		if !ok {
@@ -817,14 +1030,14 @@ func GreeterMessageChatStart(ctx context.Context) (rpcruntime.StreamHandle, erro
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)
 	case rpcruntime.ServerKindGRPC:
 		server, ok := registered.Server.(GreeterServer)
 		if !ok {
 			return 0, fmt.Errorf("rpccgo: Greeter grpc server registered server has invalid type")
 		}
 		source := newgreeterChatGRPCDirectMessageStreamSession(ctx, server)
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGRPC, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGRPC, source)
 	case rpcruntime.ServerKindGRPCRemote:
 		server, ok := registered.Server.(GreeterClient)
 		if !ok {
@@ -834,7 +1047,7 @@ func GreeterMessageChatStart(ctx context.Context) (rpcruntime.StreamHandle, erro
 		if err != nil {
 			return 0, err
 		}
-		return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGRPCRemote, source)
+		return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGRPCRemote, source)
 	default:
 		return 0, fmt.Errorf("rpccgo: Greeter registered server kind %d is unsupported for message stream starts", registered.Kind)
 	}
@@ -2,13 +2,15 @@ package generator
 
 import (
 	"fmt"
+	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // BuildContractPlan derives native and message method contracts from protobuf descriptors.
-func BuildContractPlan(service *protogen.Service, method *protogen.Method, methodPlan MethodPlan) (MethodContractPlan, error) {
+// goImportPath is the service's Go package, which declares the oneof case types.
+func BuildContractPlan(goImportPath protogen.GoImportPath, service *protogen.Service, method *protogen.Method, methodPlan MethodPlan) (MethodContractPlan, error) {
 	if service == nil {
 		return MethodContractPlan{}, fmt.Errorf("protogen service is nil")
 	}
@@ -16,11 +18,12 @@ func BuildContractPlan(service *protogen.Service, method *protogen.Method, metho
 		return MethodContractPlan{}, fmt.Errorf("protogen method is nil")
 	}
 
-	requestFields, err := buildFieldPlans(method.Input)
+	casePrefix := service.GoName + method.GoName
+	requestFields, err := buildFieldPlans(method.Input, oneofCaseScope{prefix: casePrefix + "Request", goImportPath: goImportPath})
 	if err != nil {
 		return MethodContractPlan{}, fmt.Errorf("service %s method %s: %w", service.Desc.FullName(), method.Desc.FullName(), err)
 	}
-	responseFields, err := buildFieldPlans(method.Output)
+	responseFields, err := buildFieldPlans(method.Output, oneofCaseScope{prefix: casePrefix + "Response", goImportPath: goImportPath})
 	if err != nil {
 		return MethodContractPlan{}, fmt.Errorf("service %s method %s: %w", service.Desc.FullName(), method.Desc.FullName(), err)
 	}
@@ -37,19 +40,63 @@ func BuildContractPlan(service *protogen.Service, method *protogen.Method, metho
 	}, nil
 }
 
-func buildFieldPlans(message *protogen.Message) ([]FieldPlan, error) {
+// oneofCaseScope names the Go case types declared for the oneofs of one
+// request or response message.
+type oneofCaseScope struct {
+	prefix       string
+	goImportPath protogen.GoImportPath
+}
+
+func buildFieldPlans(message *protogen.Message, cases oneofCaseScope) ([]FieldPlan, error) {
 	if message == nil {
 		return nil, fmt.Errorf("protogen message is nil")
 	}
 
 	fields := make([]FieldPlan, 0, len(message.Fields))
 	for _, field := range message.Fields {
+		if field.Desc.IsMap() {
+			halves, err := buildMapFieldPlans(field)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, halves...)
+			continue
+		}
+		if oneof := field.Oneof; oneof != nil && !oneof.Desc.IsSynthetic() {
+			if field == oneof.Fields[0] {
+				casePlan, err := buildOneofCaseFieldPlan(oneof, cases)
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, casePlan)
+			}
+			member, err := buildOneofMemberFieldPlan(field)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, member)
+			continue
+		}
 		fieldPlan, err := buildFieldPlan(field)
 		if err != nil {
 			return nil, err
 		}
 		fields = append(fields, fieldPlan)
 	}
+
+	seen := make(map[string]string, len(fields))
+	for _, field := range fields {
+		names := []string{field.GoName}
+		if field.Presence {
+			names = append(names, nativePresenceName(field))
+		}
+		for _, name := range names {
+			if other, ok := seen[name]; ok {
+				return nil, fmt.Errorf("field %s: native name %s collides with field %s", field.FullName, name, other)
+			}
+			seen[name] = field.FullName
+		}
+	}
 	return fields, nil
 }
 
@@ -57,24 +104,127 @@ func buildFieldPlan(field *protogen.Field) (FieldPlan, error) {
 	if field == nil {
 		return FieldPlan{}, fmt.Errorf("protogen field is nil")
 	}
-	if field.Desc.IsMap() {
-		return FieldPlan{}, fmt.Errorf("field %s: map fields are not supported in native ABI", field.Desc.FullName())
+	return buildElementFieldPlan(field, FieldPlan{
+		Name:     string(field.Desc.Name()),
+		GoName:   field.GoName,
+		FullName: string(field.Desc.FullName()),
+		Repeated: field.Desc.IsList(),
+		Presence: fieldHasNativePresence(field),
+	})
+}
+
+// fieldHasNativePresence reports whether a field needs a presence flag in the
+// native ABI. Members of real oneofs are tracked by the case discriminant.
+// Singular message fields have presence too, so an empty submessage that is
+// set stays apart from an unset one.
+func fieldHasNativePresence(field *protogen.Field) bool {
+	if !field.Desc.HasPresence() || field.Desc.IsList() {
+		return false
+	}
+	if oneof := field.Oneof; oneof != nil && !oneof.Desc.IsSynthetic() {
+		return false
 	}
+	return true
+}
 
-	kind, err := fieldKind(field.Desc.Kind())
-	if err != nil {
-		return FieldPlan{}, fmt.Errorf("field %s: %w", field.Desc.FullName(), err)
+// buildMapFieldPlans lowers a map field to a key half and a value half. Each
+// half is a repeated native field; entry i of the keys pairs with entry i of
+// the values.
+func buildMapFieldPlans(field *protogen.Field) ([]FieldPlan, error) {
+	entry := field.Message
+	if entry == nil || len(entry.Fields) != 2 {
+		return nil, fmt.Errorf("field %s: map entry message is malformed", field.Desc.FullName())
 	}
 
+	halves := []struct {
+		field  *protogen.Field
+		role   MapEntryRole
+		suffix string
+	}{
+		{field: entry.Fields[0], role: MapEntryRoleKey, suffix: "Keys"},
+		{field: entry.Fields[1], role: MapEntryRoleValue, suffix: "Values"},
+	}
+	plans := make([]FieldPlan, 0, len(halves))
+	for _, half := range halves {
+		switch half.field.Desc.Kind() {
+		case protoreflect.MessageKind, protoreflect.GroupKind:
+			return nil, fmt.Errorf("field %s: map %ss of kind %s are not supported in native ABI", field.Desc.FullName(), half.role, half.field.Desc.Kind())
+		}
+		plan, err := buildElementFieldPlan(half.field, FieldPlan{
+			Name:     string(field.Desc.Name()) + "_" + strings.ToLower(half.suffix),
+			GoName:   field.GoName + half.suffix,
+			FullName: string(field.Desc.FullName()),
+			Repeated: true,
+			MapEntry: MapEntryPlan{GoName: field.GoName, Role: half.role},
+		})
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+// buildOneofCaseFieldPlan lowers a oneof to its case discriminant, an enum
+// whose values are the field numbers of the members and zero when no member
+// is set. The member slots follow the discriminant.
+func buildOneofCaseFieldPlan(oneof *protogen.Oneof, scope oneofCaseScope) (FieldPlan, error) {
+	cases := make([]OneofCasePlan, 0, len(oneof.Fields))
+	for _, member := range oneof.Fields {
+		cases = append(cases, OneofCasePlan{
+			Name:   string(member.Desc.Name()),
+			GoName: member.GoName,
+			Number: int32(member.Desc.Number()),
+			WrapperType: MethodIOPlan{
+				GoName:       member.GoIdent.GoName,
+				GoImportPath: string(member.GoIdent.GoImportPath),
+				FullName:     string(member.Desc.FullName()),
+			},
+		})
+	}
 	plan := FieldPlan{
-		Name:     string(field.Desc.Name()),
-		GoName:   field.GoName,
-		FullName: string(field.Desc.FullName()),
-		Kind:     kind,
-		Repeated: field.Desc.IsList(),
-		Enum:     field.Desc.Kind() == protoreflect.EnumKind,
-		Message:  field.Desc.Kind() == protoreflect.MessageKind || field.Desc.Kind() == protoreflect.GroupKind,
+		Name:     string(oneof.Desc.Name()) + "_case",
+		GoName:   oneof.GoName + "Case",
+		FullName: string(oneof.Desc.FullName()),
+		Kind:     FieldKindEnum,
+		Enum:     true,
+		EnumType: MethodIOPlan{
+			GoName:       scope.prefix + oneof.GoName + "Case",
+			GoImportPath: string(scope.goImportPath),
+			FullName:     string(oneof.Desc.FullName()),
+		},
+		Oneof: OneofPlan{GoName: oneof.GoName, Role: OneofRoleCase, Cases: cases},
+	}
+	native, err := nativeFieldPlan(plan)
+	if err != nil {
+		return FieldPlan{}, fmt.Errorf("oneof %s: %w", plan.FullName, err)
 	}
+	plan.Native = native
+	return plan, nil
+}
+
+// buildOneofMemberFieldPlan lowers one member of a oneof like a singular
+// field; message members keep their encoded-bytes shape.
+func buildOneofMemberFieldPlan(field *protogen.Field) (FieldPlan, error) {
+	plan, err := buildFieldPlan(field)
+	if err != nil {
+		return FieldPlan{}, err
+	}
+	plan.Oneof = OneofPlan{GoName: field.Oneof.GoName, Role: OneofRoleMember}
+	return plan, nil
+}
+
+// buildElementFieldPlan completes plan, which carries the field identity, from
+// the kind and enum of the protobuf field that holds its elements.
+func buildElementFieldPlan(field *protogen.Field, plan FieldPlan) (FieldPlan, error) {
+	kind, err := fieldKind(field.Desc.Kind())
+	if err != nil {
+		return FieldPlan{}, fmt.Errorf("field %s: %w", plan.FullName, err)
+	}
+
+	plan.Kind = kind
+	plan.Enum = field.Desc.Kind() == protoreflect.EnumKind
+	plan.Message = field.Desc.Kind() == protoreflect.MessageKind || field.Desc.Kind() == protoreflect.GroupKind
 	if field.Enum != nil {
 		plan.EnumType = MethodIOPlan{
 			GoName:       field.Enum.GoIdent.GoName,
@@ -82,10 +232,17 @@ func buildFieldPlan(field *protogen.Field) (FieldPlan, error) {
 			FullName:     string(field.Enum.Desc.FullName()),
 		}
 	}
+	if field.Message != nil {
+		plan.MessageType = MethodIOPlan{
+			GoName:       field.Message.GoIdent.GoName,
+			GoImportPath: string(field.Message.GoIdent.GoImportPath),
+			FullName:     string(field.Message.Desc.FullName()),
+		}
+	}
 
 	native, err := nativeFieldPlan(plan)
 	if err != nil {
-		return FieldPlan{}, fmt.Errorf("field %s: %w", field.Desc.FullName(), err)
+		return FieldPlan{}, fmt.Errorf("field %s: %w", plan.FullName, err)
 	}
 	plan.Native = native
 	return plan, nil
@@ -132,18 +289,12 @@ func nativeFieldPlan(field FieldPlan) (NativeFieldPlan, error) {
 		}
 		return NativeFieldPlan{Kind: NativeFieldKindBool, Shape: NativeABIShapeBoolByte}, nil
 	case FieldKindString:
-		if field.Repeated {
-			return NativeFieldPlan{}, fmt.Errorf("repeated string fields are not supported in native ABI")
-		}
-		return NativeFieldPlan{Kind: NativeFieldKindString, Shape: repeatedShape(field.Repeated)}, nil
+		return NativeFieldPlan{Kind: NativeFieldKindString, Shape: bufferShape(field.Repeated)}, nil
 	case FieldKindBytes:
-		if field.Repeated {
-			return NativeFieldPlan{}, fmt.Errorf("repeated bytes fields are not supported in native ABI")
-		}
-		return NativeFieldPlan{Kind: NativeFieldKindBytes, Shape: repeatedShape(field.Repeated)}, nil
+		return NativeFieldPlan{Kind: NativeFieldKindBytes, Shape: bufferShape(field.Repeated)}, nil
 	case FieldKindMessage:
 		if field.Repeated {
-			return NativeFieldPlan{}, fmt.Errorf("repeated message fields are not supported in native ABI")
+			return NativeFieldPlan{Kind: NativeFieldKindMessageBytes, Shape: NativeABIShapeBufferArray}, nil
 		}
 		return NativeFieldPlan{Kind: NativeFieldKindMessageBytes, Shape: NativeABIShapeMessageBytes}, nil
 	case FieldKindEnum:
@@ -159,3 +310,13 @@ func repeatedShape(repeated bool) NativeABIShape {
 	}
 	return NativeABIShapeScalar
 }
+
+// bufferShape lowers repeated string and bytes fields to an array of
+// rpccgo_buffer ptr/len pairs; singular ones stay ptr/len scalars. Repeated
+// message fields use the same array with one encoded message per element.
+func bufferShape(repeated bool) NativeABIShape {
+	if repeated {
+		return NativeABIShapeBufferArray
+	}
+	return NativeABIShapeScalar
+}
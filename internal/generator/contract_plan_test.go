@@ -1,6 +1,8 @@
 package generator
 
 import (
+	"maps"
+	"slices"
 	"strings"
 	"testing"
 
@@ -90,6 +92,11 @@ func TestBuildContractPlanBuildsNativeAndMessageFields(t *testing.T) {
 		FullName: "test.v1.ContractRequest.child",
 		Kind:     FieldKindMessage,
 		Message:  true,
+		MessageType: MethodIOPlan{
+			GoName:       "Child",
+			GoImportPath: "example.com/test/v1",
+			FullName:     "test.v1.Child",
+		},
 		Native: NativeFieldPlan{
 			Kind:  NativeFieldKindMessageBytes,
 			Shape: NativeABIShapeMessageBytes,
@@ -145,63 +152,276 @@ func TestNativeFieldPlanMarksRepeatedBoolAsByteBufferWrapper(t *testing.T) {
 	})
 }
 
-func TestBuildContractPlanRejectsRepeatedMessage(t *testing.T) {
+func TestBuildContractPlanLowersRepeatedMessageToBufferArray(t *testing.T) {
 	plugin := newTestPlugin(t, "paths=source_relative", repeatedMessageContractTestFile())
 
-	_, err := BuildDescriptorPlan(plugin.Files[0])
-	if err == nil {
-		t.Fatal("BuildDescriptorPlan() error = nil, want repeated message error")
+	plan, err := BuildDescriptorPlan(plugin.Files[0])
+	if err != nil {
+		t.Fatalf("BuildDescriptorPlan() error = %v", err)
 	}
-	got := err.Error()
-	forbidden := []string{"test.v1.Contracts.Check", "test.v1.BadRequest.children", "repeated message fields are not supported"}
-	for _, want := range forbidden {
-		if !strings.Contains(got, want) {
-			t.Fatalf("BuildDescriptorPlan() error = %q, want substring %q", got, want)
+
+	assertNativeField(t, plan.Services[0].Methods[0].Contract.Native.RequestFields[0], FieldPlan{
+		Name:     "children",
+		GoName:   "Children",
+		FullName: "test.v1.BadRequest.children",
+		Kind:     FieldKindMessage,
+		Repeated: true,
+		Message:  true,
+		MessageType: MethodIOPlan{
+			GoName:       "Child",
+			GoImportPath: "example.com/test/v1",
+			FullName:     "test.v1.Child",
+		},
+		Native: NativeFieldPlan{
+			Kind:  NativeFieldKindMessageBytes,
+			Shape: NativeABIShapeBufferArray,
+		},
+	})
+}
+
+func TestBuildContractPlanLowersRepeatedStringAndBytesToBufferArrays(t *testing.T) {
+	for _, tc := range []struct {
+		file *descriptorpb.FileDescriptorProto
+		want FieldPlan
+	}{
+		{
+			file: repeatedStringContractTestFile(),
+			want: FieldPlan{
+				Name:     "tags",
+				GoName:   "Tags",
+				FullName: "test.v1.BadRequest.tags",
+				Kind:     FieldKindString,
+				Repeated: true,
+				Native:   NativeFieldPlan{Kind: NativeFieldKindString, Shape: NativeABIShapeBufferArray},
+			},
+		},
+		{
+			file: repeatedBytesContractTestFile(),
+			want: FieldPlan{
+				Name:     "payloads",
+				GoName:   "Payloads",
+				FullName: "test.v1.BadRequest.payloads",
+				Kind:     FieldKindBytes,
+				Repeated: true,
+				Native:   NativeFieldPlan{Kind: NativeFieldKindBytes, Shape: NativeABIShapeBufferArray},
+			},
+		},
+	} {
+		plugin := newTestPlugin(t, "paths=source_relative", tc.file)
+
+		plan, err := BuildDescriptorPlan(plugin.Files[0])
+		if err != nil {
+			t.Fatalf("BuildDescriptorPlan() error = %v", err)
+		}
+		fields := plan.Services[0].Methods[0].Contract.Native.RequestFields
+		if len(fields) != 1 {
+			t.Fatalf("request native fields = %d, want 1", len(fields))
 		}
+		assertNativeField(t, fields[0], tc.want)
+	}
+}
+
+func TestBuildContractPlanLowersMapFieldToKeyValueHalves(t *testing.T) {
+	plugin := newTestPlugin(t, "paths=source_relative", mapContractTestFile(descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_TYPE_INT64))
+
+	plan, err := BuildDescriptorPlan(plugin.Files[0])
+	if err != nil {
+		t.Fatalf("BuildDescriptorPlan() error = %v", err)
+	}
+	fields := plan.Services[0].Methods[0].Contract.Native.RequestFields
+	if len(fields) != 2 {
+		t.Fatalf("request native fields = %d, want key and value halves", len(fields))
+	}
+	assertNativeField(t, fields[0], FieldPlan{
+		Name:     "labels_keys",
+		GoName:   "LabelsKeys",
+		FullName: "test.v1.BadRequest.labels",
+		Kind:     FieldKindSignedInt32,
+		Repeated: true,
+		Native: NativeFieldPlan{
+			Kind:  NativeFieldKindSignedNumeric,
+			Shape: NativeABIShapeRepeated,
+		},
+	})
+	assertNativeField(t, fields[1], FieldPlan{
+		Name:     "labels_values",
+		GoName:   "LabelsValues",
+		FullName: "test.v1.BadRequest.labels",
+		Kind:     FieldKindSignedInt64,
+		Repeated: true,
+		Native: NativeFieldPlan{
+			Kind:  NativeFieldKindSignedNumeric,
+			Shape: NativeABIShapeRepeated,
+		},
+	})
+	if fields[0].MapEntry != (MapEntryPlan{GoName: "Labels", Role: MapEntryRoleKey}) || fields[1].MapEntry != (MapEntryPlan{GoName: "Labels", Role: MapEntryRoleValue}) {
+		t.Fatalf("map entries = (%#v, %#v), want Labels key and value halves", fields[0].MapEntry, fields[1].MapEntry)
 	}
 }
 
-func TestBuildContractPlanRejectsRepeatedStringNativeABI(t *testing.T) {
-	plugin := newTestPlugin(t, "paths=source_relative", repeatedStringContractTestFile())
+func TestBuildContractPlanLowersMapStringKeysToBufferArray(t *testing.T) {
+	plugin := newTestPlugin(t, "paths=source_relative", mapContractTestFile(descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_TYPE_BYTES))
+
+	plan, err := BuildDescriptorPlan(plugin.Files[0])
+	if err != nil {
+		t.Fatalf("BuildDescriptorPlan() error = %v", err)
+	}
+	fields := plan.Services[0].Methods[0].Contract.Native.RequestFields
+	if len(fields) != 2 || fields[0].Native.Shape != NativeABIShapeBufferArray || fields[1].Native.Shape != NativeABIShapeBufferArray {
+		t.Fatalf("request native fields = %#v, want buffer array key and value halves", fields)
+	}
+	if fields[0].Kind != FieldKindString || fields[1].Kind != FieldKindBytes {
+		t.Fatalf("map half kinds = (%s, %s), want string keys and bytes values", fields[0].Kind, fields[1].Kind)
+	}
+}
+
+func TestBuildContractPlanRejectsMapMessageValues(t *testing.T) {
+	file := mapContractTestFile(descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE)
+	file.MessageType[0].NestedType[0].Field[1].TypeName = proto.String(".test.v1.BadRequest")
+	plugin := newTestPlugin(t, "paths=source_relative", file)
 
 	_, err := BuildDescriptorPlan(plugin.Files[0])
 	if err == nil {
-		t.Fatal("BuildDescriptorPlan() error = nil, want repeated string unsupported error")
+		t.Fatal("BuildDescriptorPlan() error = nil, want map value error")
 	}
 	got := err.Error()
-	for _, want := range []string{"repeated string", "not supported"} {
+	for _, want := range []string{"test.v1.Contracts.Check", "test.v1.BadRequest.labels", "map values of kind message are not supported"} {
 		if !strings.Contains(got, want) {
 			t.Fatalf("BuildDescriptorPlan() error = %q, want substring %q", got, want)
 		}
 	}
 }
 
-func TestBuildContractPlanRejectsRepeatedBytesNativeABI(t *testing.T) {
-	plugin := newTestPlugin(t, "paths=source_relative", repeatedBytesContractTestFile())
+func TestBuildContractPlanRejectsMapHalfNameCollision(t *testing.T) {
+	file := mapContractTestFile(descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_TYPE_INT64)
+	file.MessageType[0].Field = append(file.MessageType[0].Field, fieldDescriptor("labels_keys", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, ""))
+	plugin := newTestPlugin(t, "paths=source_relative", file)
 
 	_, err := BuildDescriptorPlan(plugin.Files[0])
-	if err == nil {
-		t.Fatal("BuildDescriptorPlan() error = nil, want repeated bytes unsupported error")
+	if err == nil || !strings.Contains(err.Error(), "native name LabelsKeys collides with field test.v1.BadRequest.labels") {
+		t.Fatalf("BuildDescriptorPlan() error = %v, want LabelsKeys collision", err)
 	}
-	got := err.Error()
-	for _, want := range []string{"repeated bytes", "not supported"} {
-		if !strings.Contains(got, want) {
-			t.Fatalf("BuildDescriptorPlan() error = %q, want substring %q", got, want)
+}
+
+func TestBuildContractPlanLowersOneofToCaseAndMemberSlots(t *testing.T) {
+	plugin := newTestPlugin(t, "paths=source_relative", oneofContractTestFile(descriptorpb.FieldDescriptorProto_TYPE_INT64, ""))
+
+	plan, err := BuildDescriptorPlan(plugin.Files[0])
+	if err != nil {
+		t.Fatalf("BuildDescriptorPlan() error = %v", err)
+	}
+	fields := plan.Services[0].Methods[0].Contract.Native.RequestFields
+	if len(fields) != 4 {
+		t.Fatalf("request native fields = %d, want note, case and two members", len(fields))
+	}
+	caseField := fields[1]
+	assertNativeField(t, caseField, FieldPlan{
+		Name:     "value_case",
+		GoName:   "ValueCase",
+		FullName: "test.v1.BadRequest.value",
+		Kind:     FieldKindEnum,
+		Enum:     true,
+		EnumType: MethodIOPlan{
+			GoName:       "ContractsCheckRequestValueCase",
+			GoImportPath: "example.com/test/v1",
+			FullName:     "test.v1.BadRequest.value",
+		},
+		Native: NativeFieldPlan{
+			Kind:  NativeFieldKindEnum,
+			Shape: NativeABIShapeScalar,
+		},
+	})
+	if caseField.Oneof.GoName != "Value" || caseField.Oneof.Role != OneofRoleCase {
+		t.Fatalf("case Oneof = %#v, want Value case slot", caseField.Oneof)
+	}
+	wantCases := []OneofCasePlan{
+		{Name: "label", GoName: "Label", Number: 1, WrapperType: MethodIOPlan{GoName: "BadRequest_Label", GoImportPath: "example.com/test/v1", FullName: "test.v1.BadRequest.label"}},
+		{Name: "count", GoName: "Count", Number: 2, WrapperType: MethodIOPlan{GoName: "BadRequest_Count", GoImportPath: "example.com/test/v1", FullName: "test.v1.BadRequest.count"}},
+	}
+	if !slices.Equal(caseField.Oneof.Cases, wantCases) {
+		t.Fatalf("Oneof.Cases = %#v, want %#v", caseField.Oneof.Cases, wantCases)
+	}
+	for _, member := range fields[2:] {
+		if member.Oneof.GoName != "Value" || member.Oneof.Role != OneofRoleMember || member.Oneof.Cases != nil {
+			t.Fatalf("%s Oneof = %#v, want Value member slot", member.Name, member.Oneof)
 		}
 	}
+	if fields[0].Oneof.Role != "" || fields[3].Kind != FieldKindSignedInt64 || fields[3].Native.Shape != NativeABIShapeScalar {
+		t.Fatalf("note and count slots = %#v, %#v", fields[0], fields[3])
+	}
 }
 
-func TestBuildContractPlanRejectsMapField(t *testing.T) {
-	plugin := newTestPlugin(t, "paths=source_relative", mapContractTestFile())
+func TestBuildContractPlanLowersOneofMessageMembersToMessageBytes(t *testing.T) {
+	plugin := newTestPlugin(t, "paths=source_relative", oneofContractTestFile(descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".test.v1.Child"))
 
-	_, err := BuildDescriptorPlan(plugin.Files[0])
-	if err == nil {
-		t.Fatal("BuildDescriptorPlan() error = nil, want map field error")
+	plan, err := BuildDescriptorPlan(plugin.Files[0])
+	if err != nil {
+		t.Fatalf("BuildDescriptorPlan() error = %v", err)
 	}
-	got := err.Error()
-	for _, want := range []string{"test.v1.Contracts.Check", "test.v1.BadRequest.labels", "map fields are not supported"} {
-		if !strings.Contains(got, want) {
-			t.Fatalf("BuildDescriptorPlan() error = %q, want substring %q", got, want)
+	fields := plan.Services[0].Methods[0].Contract.Native.RequestFields
+	if len(fields) != 4 || fields[1].Oneof.Role != OneofRoleCase {
+		t.Fatalf("request native fields = %#v, want note, case and two members", fields)
+	}
+	member := fields[3]
+	assertNativeField(t, member, FieldPlan{
+		Name:     "count",
+		GoName:   "Count",
+		FullName: "test.v1.BadRequest.count",
+		Kind:     FieldKindMessage,
+		Message:  true,
+		MessageType: MethodIOPlan{
+			GoName:       "Child",
+			GoImportPath: "example.com/test/v1",
+			FullName:     "test.v1.Child",
+		},
+		Native: NativeFieldPlan{
+			Kind:  NativeFieldKindMessageBytes,
+			Shape: NativeABIShapeMessageBytes,
+		},
+	})
+	if member.Oneof.GoName != "Value" || member.Oneof.Role != OneofRoleMember || member.Presence {
+		t.Fatalf("count Oneof = %#v, presence = %v; want a Value member tracked by the case", member.Oneof, member.Presence)
+	}
+}
+
+func TestBuildContractPlanTracksPresenceOfOptionalFields(t *testing.T) {
+	plugin := newTestPlugin(t, "paths=source_relative", nativeServerPresenceFile())
+
+	plan, err := BuildDescriptorPlan(plugin.Files[0])
+	if err != nil {
+		t.Fatalf("BuildDescriptorPlan() error = %v", err)
+	}
+	fields := plan.Services[0].Methods[0].Contract.Native.RequestFields
+	got := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		got[field.Name] = field.Presence
+	}
+	want := map[string]bool{"note": false, "limit": true, "label": true, "blob": true}
+	if !maps.Equal(got, want) {
+		t.Fatalf("request field presence = %v, want %v", got, want)
+	}
+}
+
+func TestBuildContractPlanTracksPresenceOfProto2Fields(t *testing.T) {
+	file := oneofContractTestFile(descriptorpb.FieldDescriptorProto_TYPE_INT64, "")
+	file.Syntax = proto.String("proto2")
+	plugin := newTestPlugin(t, "paths=source_relative", file)
+
+	plan, err := BuildDescriptorPlan(plugin.Files[0])
+	if err != nil {
+		t.Fatalf("BuildDescriptorPlan() error = %v", err)
+	}
+	fields := plan.Services[0].Methods[0].Contract.Native.RequestFields
+	if len(fields) != 4 {
+		t.Fatalf("request native fields = %d, want note, case and two members", len(fields))
+	}
+	if !fields[0].Presence {
+		t.Fatalf("note Presence = false, want proto2 optional field to track presence")
+	}
+	// The oneof case discriminant already tells which member is set.
+	for _, field := range fields[1:] {
+		if field.Presence {
+			t.Fatalf("%s Presence = true, want oneof slots without presence flags", field.Name)
 		}
 	}
 }
@@ -236,6 +456,9 @@ func assertNativeField(t *testing.T, got FieldPlan, want FieldPlan) {
 	if got.EnumType != want.EnumType {
 		t.Fatalf("%s EnumType = %#v, want %#v", got.Name, got.EnumType, want.EnumType)
 	}
+	if got.MessageType != want.MessageType {
+		t.Fatalf("%s MessageType = %#v, want %#v", got.Name, got.MessageType, want.MessageType)
+	}
 	if got.Native != want.Native {
 		t.Fatalf("%s Native = %#v, want %#v", got.Name, got.Native, want.Native)
 	}
@@ -298,14 +521,14 @@ func repeatedBytesContractTestFile() *descriptorpb.FileDescriptorProto {
 	return badFieldContractTestFile(fieldDescriptor("payloads", 1, descriptorpb.FieldDescriptorProto_TYPE_BYTES, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, ""))
 }
 
-func mapContractTestFile() *descriptorpb.FileDescriptorProto {
+func mapContractTestFile(keyType, valueType descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FileDescriptorProto {
 	file := badFieldContractTestFile(fieldDescriptor("labels", 1, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, ".test.v1.BadRequest.LabelsEntry"))
 	file.MessageType[0].NestedType = []*descriptorpb.DescriptorProto{
 		{
 			Name: proto.String("LabelsEntry"),
 			Field: []*descriptorpb.FieldDescriptorProto{
-				fieldDescriptor("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
-				fieldDescriptor("value", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+				fieldDescriptor("key", 1, keyType, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+				fieldDescriptor("value", 2, valueType, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
 			},
 			Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
 		},
@@ -313,6 +536,17 @@ func mapContractTestFile() *descriptorpb.FileDescriptorProto {
 	return file
 }
 
+func oneofContractTestFile(countType descriptorpb.FieldDescriptorProto_Type, countTypeName string) *descriptorpb.FileDescriptorProto {
+	file := badFieldContractTestFile(fieldDescriptor("note", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""))
+	label := fieldDescriptor("label", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, "")
+	label.OneofIndex = proto.Int32(0)
+	count := fieldDescriptor("count", 2, countType, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, countTypeName)
+	count.OneofIndex = proto.Int32(0)
+	file.MessageType[0].Field = append(file.MessageType[0].Field, label, count)
+	file.MessageType[0].OneofDecl = []*descriptorpb.OneofDescriptorProto{{Name: proto.String("value")}}
+	return file
+}
+
 func unsignedContractTestFile() *descriptorpb.FileDescriptorProto {
 	return &descriptorpb.FileDescriptorProto{
 		Name:    proto.String("test/v1/unsigned_contracts.proto"),
@@ -66,7 +66,7 @@ func AttachMethodContractPlans(plan *FilePlan, file *protogen.File) error {
 		}
 		for mi := range plan.Services[si].Methods {
 			method := plan.Services[si].Methods[mi]
-			contract, err := BuildContractPlan(service, service.Methods[mi], method)
+			contract, err := BuildContractPlan(file.GoImportPath, service, service.Methods[mi], method)
 			if err != nil {
 				return err
 			}
@@ -193,19 +193,28 @@ func buildServiceDescriptorPlan(service *protogen.Service) (ServicePlan, error)
 		Methods:    make([]MethodPlan, 0, len(service.Methods)),
 	}
 	for _, method := range service.Methods {
-		methodPlan := buildMethodDescriptorPlan(method)
+		methodPlan, err := buildMethodDescriptorPlan(method)
+		if err != nil {
+			return ServicePlan{}, fmt.Errorf("method %s: %w", method.Desc.FullName(), err)
+		}
 		plan.Methods = append(plan.Methods, methodPlan)
 	}
 	return plan, nil
 }
 
-func buildMethodDescriptorPlan(method *protogen.Method) MethodPlan {
+func buildMethodDescriptorPlan(method *protogen.Method) (MethodPlan, error) {
+	streaming := StreamingKindOf(method.Desc.IsStreamingClient(), method.Desc.IsStreamingServer())
+	buffering, err := ParseMethodRPCCGOOptions(string(method.Comments.Leading), streaming)
+	if err != nil {
+		return MethodPlan{}, err
+	}
 	return MethodPlan{
-		Name:       string(method.Desc.Name()),
-		GoName:     method.GoName,
-		FullName:   string(method.Desc.FullName()),
-		DocComment: protoDocComment(string(method.Comments.Leading)),
-		Streaming:  StreamingKindOf(method.Desc.IsStreamingClient(), method.Desc.IsStreamingServer()),
+		Name:            string(method.Desc.Name()),
+		GoName:          method.GoName,
+		FullName:        string(method.Desc.FullName()),
+		DocComment:      protoDocComment(string(method.Comments.Leading)),
+		Streaming:       streaming,
+		StreamBuffering: buffering,
 		Request: MethodIOPlan{
 			GoName:       method.Input.GoIdent.GoName,
 			GoImportPath: string(method.Input.GoIdent.GoImportPath),
@@ -216,5 +225,5 @@ func buildMethodDescriptorPlan(method *protogen.Method) MethodPlan {
 			GoImportPath: string(method.Output.GoIdent.GoImportPath),
 			FullName:     string(method.Output.Desc.FullName()),
 		},
-	}
+	}, nil
 }
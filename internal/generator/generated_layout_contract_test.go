@@ -94,7 +94,7 @@ func TestGeneratedLayoutPublicAPIContract(t *testing.T) {
 		`const greeterServiceID rpcruntime.ServiceID = "test.v1.Greeter"`,
 	)
 	assertGeneratedContentContains(t, plugin, "test/v1/greeter.greeter.runtime.rpccgo.go",
-		"rpcruntime.RegisterServer(greeterServiceID, rpcruntime.RegisteredServer{",
+		"registry.Register(greeterServiceID, rpcruntime.RegisteredServer{",
 	)
 	assertGeneratedContentContains(t, plugin, "test/v1/greeter.greeter.runtime.rpccgo.go",
 		"Kind:   rpcruntime.ServerKindConnectRemote",
@@ -126,6 +126,84 @@ func TestGeneratedLayoutPublicAPIContract(t *testing.T) {
 	assertGeneratedContentContains(t, plugin, "test/cmd/rpc/rpccgo.exports.cgo.rpccgo.go",
 		"//export rpccgoTakeErrorText",
 	)
+	for _, fragment := range []string{
+		"#define RPCCGO_ERR_DEADLINE_EXCEEDED (-2)",
+		"#define RPCCGO_ERR_BUFFER_TOO_SMALL (-3)",
+		"func rpccgoCallOptionsNew(timeoutMs C.int64_t, options *C.int32_t) C.int32_t {",
+		"func rpccgoCallOptionsSetDeadline(options C.int32_t, deadlineUnixMs C.int64_t) C.int32_t {",
+		"func rpccgoCallOptionsSetMetadata(options C.int32_t, metadata *C.char, metadataLen C.int32_t) C.int32_t {",
+		"func rpccgoCallOptionsSetRegistry(options C.int32_t, registry C.int32_t) C.int32_t {",
+		"md, err := rpcruntime.DecodeMetadata(data)",
+		"func rpccgoCallOptionsTakeResponseMetadata(options C.int32_t, headerPtr *C.uintptr_t, headerLen *C.int32_t, trailerPtr *C.uintptr_t, trailerLen *C.int32_t) C.int32_t {",
+		"goHeaderPtr, goHeaderLen, err := rpcruntime.EncodePinnedMetadata(header)",
+		"func rpccgoCallOptionsCancel(options C.int32_t) C.int32_t {",
+		"func rpccgoCallOptionsRelease(options C.int32_t) C.int32_t {",
+		"#define RPCCGO_CODE_OK 0",
+		"#define RPCCGO_CODE_DEADLINE_EXCEEDED 4",
+		"#define RPCCGO_CODE_UNAUTHENTICATED 16",
+		"func rpccgoStoreErrorCode(code C.int32_t, text *C.char, textLen C.int32_t) C.int32_t {",
+		"return C.int32_t(rpcruntime.StoreError(rpcruntime.NewStatusError(rpcruntime.ErrorCode(code), string(data))))",
+		"func rpccgoStoreErrorStatus(statusPtr unsafe.Pointer, statusLen C.int32_t) C.int32_t {",
+		"func rpccgoTakeErrorCode(errID C.int32_t, code *C.int32_t, textPtr *C.uintptr_t, textLen *C.int32_t) C.int32_t {",
+		"func rpccgoTakeErrorStatus(errID C.int32_t, statusPtr *C.uintptr_t, statusLen *C.int32_t) C.int32_t {",
+		"func rpccgoDiscardError(errID C.int32_t) C.int32_t {",
+		"func rpccgoErrorStoreConfigure(ttlMs C.int64_t, capacity C.int32_t, explicitRelease C.int32_t) C.int32_t {",
+		"#define RPCCGO_TRACEPARENT_LEN 55",
+		"func rpccgoMetricsSnapshot(snapshotPtr *C.uintptr_t, snapshotLen *C.int32_t) C.int32_t {",
+		"goPtr, goLen, err := rpcruntime.EncodePinnedMetricsSnapshot()",
+		"typedef void (*rpccgo_panic_callback)(uintptr_t text_ptr, int32_t text_len);",
+		"func rpccgoPanicReportsConfigure(stacks C.int32_t, onPanic C.rpccgo_panic_callback) C.int32_t {",
+		"rpcruntime.ConfigurePanicReports(config)",
+		"func rpccgoPinTrackingConfigure(enabled C.int32_t, stacks C.int32_t) C.int32_t {",
+		"func rpccgoPinnedReport(olderThanMs C.int64_t, reportPtr *C.uintptr_t, reportLen *C.int32_t) C.int32_t {",
+		"goPtr, goLen, err := rpcruntime.EncodePinnedOutstandingPins(time.Duration(olderThanMs) * time.Millisecond)",
+		"func rpccgoStreamSessionsList(listPtr *C.uintptr_t, listLen *C.int32_t) C.int32_t {",
+		"func rpccgoStreamSessionCancel(stream C.int32_t) C.int32_t {",
+		"if err := rpcruntime.CancelStreamSession(context.Background(), rpcruntime.StreamHandle(stream)); err != nil {",
+		"func rpccgoStreamSessionsCancelIdle(idleMs C.int64_t, canceled *C.int32_t) C.int32_t {",
+		"func rpccgoStreamSessionsSetIdleTimeout(timeoutMs C.int64_t) C.int32_t {",
+		"func rpccgoStreamBufferingSet(method *C.char, methodLen C.int32_t, requestBuffer C.int32_t, responseBuffer C.int32_t, pipelinedSend C.int32_t) C.int32_t {",
+		"err = rpcruntime.SetStreamBuffering(name, rpcruntime.StreamBuffering{",
+		"func rpccgoStreamBufferingClear(method *C.char, methodLen C.int32_t) C.int32_t {",
+		"func rpccgoConcurrencyLimitSet(serviceID *C.char, serviceIDLen C.int32_t, method *C.char, methodLen C.int32_t, maxConcurrentUnary C.int32_t, maxActiveStreams C.int32_t, queueTimeoutMs C.int64_t) C.int32_t {",
+		"err = rpcruntime.SetConcurrencyLimit(scope, rpcruntime.ConcurrencyLimit{",
+		"func rpccgoConcurrencyLimitClear(serviceID *C.char, serviceIDLen C.int32_t, method *C.char, methodLen C.int32_t) C.int32_t {",
+		"func rpccgoConcurrencySnapshot(snapshotPtr *C.uintptr_t, snapshotLen *C.int32_t) C.int32_t {",
+		"func rpccgoShutdown(timeoutMs C.int64_t) C.int32_t {",
+		"timeout := rpcruntime.DefaultShutdownTimeout",
+		"#define RPCCGO_SERVER_KIND_CGO_MESSAGE 3",
+		"func rpccgoRegistrationWatch(serviceID *C.char, serviceIDLen C.int32_t, watch *C.int32_t, onEvent C.rpccgo_registration_event_callback, onDone C.rpccgo_registration_done_callback) C.int32_t {",
+		`defer rpcruntime.RecoverPanic("rpccgoRegistrationWatch event", func(error) {})`,
+		`defer rpcruntime.RecoverPanic("rpccgoRegistrationWatch done", func(error) {})`,
+		"#define RPCCGO_HEALTH_NOT_SERVING 2",
+		"func rpccgoHealthCheck(serviceID *C.char, serviceIDLen C.int32_t, status *C.int32_t, kind *C.int32_t) C.int32_t {",
+		"func rpccgoHealthReport(serviceID *C.char, serviceIDLen C.int32_t, status C.int32_t) C.int32_t {",
+		"func rpccgoHealthWatch(serviceID *C.char, serviceIDLen C.int32_t, watch *C.int32_t, onHealth C.rpccgo_health_callback, onDone C.rpccgo_registration_done_callback) C.int32_t {",
+		`defer rpcruntime.RecoverPanic("rpccgoHealthWatch health", func(error) {})`,
+		"handle, err := rpcruntime.NewHealthWatch(rpcruntime.ServiceID(id), onWatchHealth, onWatchDone)",
+		"#define RPCCGO_METHOD_STREAMING_BIDI 3",
+		"func rpccgoReflectionServices(servicesPtr *C.uintptr_t, servicesLen *C.int32_t) C.int32_t {",
+		"func rpccgoReflectionMethods(serviceID *C.char, serviceIDLen C.int32_t, methodsPtr *C.uintptr_t, methodsLen *C.int32_t) C.int32_t {",
+		"goPtr, goLen, err := rpcruntime.EncodePinnedMethodList(rpcruntime.ServiceID(id))",
+		"func rpccgoReflectionFileDescriptorSet(serviceID *C.char, serviceIDLen C.int32_t, setPtr *C.uintptr_t, setLen *C.int32_t) C.int32_t {",
+		"func rpccgoInvoke(serviceID *C.char, serviceIDLen C.int32_t, method *C.char, methodLen C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {",
+		"resp, err := rpcruntime.InvokeDynamic(ctx, id, name, request)",
+		"func rpccgoStreamStartWithContext(ctx context.Context, serviceID *C.char, serviceIDLen C.int32_t, method *C.char, methodLen C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t) (errID C.int32_t) {",
+		"func rpccgoStreamSend(handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) C.int32_t {",
+		"request, err := rpcruntime.BorrowBytes(requestPtr, int32(requestLen))",
+		"func rpccgoStreamRecv(handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {",
+		"func rpccgoStreamCloseSend(handle C.int32_t) C.int32_t {",
+		"func rpccgoStreamFinish(handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {",
+		"func rpccgoStreamCancel(handle C.int32_t) C.int32_t {",
+		"func rpccgoRegistrationUnwatch(watch C.int32_t) C.int32_t {",
+		"func rpccgoServerRegistryNew(registry *C.int32_t) C.int32_t {",
+		"func rpccgoServerRegistryCopyRoute(registry C.int32_t, serviceID *C.char, serviceIDLen C.int32_t) C.int32_t {",
+		"func rpccgoServerRegistryRelease(registry C.int32_t) C.int32_t {",
+		"func rpccgoCallbackTraceParent(buf *C.char, bufLen C.int32_t, traceParentLen *C.int32_t) C.int32_t {",
+		"func rpccgoEnterCallbackTrace(ctx context.Context) func() {",
+	} {
+		assertGeneratedContentContains(t, plugin, "test/cmd/rpc/rpccgo.exports.cgo.rpccgo.go", fragment)
+	}
 	assertGeneratedContentContains(t, plugin, "test/cmd/rpc/main.go",
 		"func main() {}",
 	)
@@ -143,6 +221,12 @@ func TestGeneratedLayoutPublicAPIContract(t *testing.T) {
 	assertGeneratedFileContentDoesNotContain(t, plugin, "test/cmd/rpc/greeter.greeter.server.native.cgo.rpccgo.go",
 		"CGONativeServerErrorTextForExport",
 	)
+	assertGeneratedContentContains(t, plugin, "test/cmd/rpc/greeter.greeter.server.native.cgo.rpccgo.go",
+		"leaveTrace := rpccgoEnterCallbackTrace(ctx)",
+	)
+	assertGeneratedContentContains(t, plugin, "test/cmd/rpc/greeter.greeter.server.message.cgo.rpccgo.go",
+		"leaveTrace := rpccgoEnterCallbackTrace(ctx)",
+	)
 	assertGeneratedContentContains(t, plugin, "test/cmd/rpc/rpccgo.exports.cgo.rpccgo.go",
 		"//export rpccgoRelease",
 	)
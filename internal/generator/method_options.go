@@ -0,0 +1,113 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	methodRequestBufferOption  = "request-buffer"
+	methodResponseBufferOption = "response-buffer"
+	methodPipelinedSendOption  = "pipelined-send"
+
+	defaultMethodRequestBuffer  = 16
+	defaultMethodResponseBuffer = 1
+	// maxMethodStreamBuffer matches rpcruntime.MaxStreamBuffer.
+	maxMethodStreamBuffer = 1 << 16
+)
+
+// MethodStreamBuffering records the queue sizes and send mode generated for
+// the in-process streams of one streaming method.
+type MethodStreamBuffering struct {
+	RequestBuffer  int
+	ResponseBuffer int
+	PipelinedSend  bool
+}
+
+// DefaultMethodStreamBuffering returns the buffering used when a method has no
+// @rpccgo directive: a small request queue, a single response slot and a
+// strict handoff on every send.
+func DefaultMethodStreamBuffering() MethodStreamBuffering {
+	return MethodStreamBuffering{
+		RequestBuffer:  defaultMethodRequestBuffer,
+		ResponseBuffer: defaultMethodResponseBuffer,
+	}
+}
+
+// ParseMethodRPCCGOOptions parses the method leading comment text for
+// @rpccgo stream buffering directives such as
+// "@rpccgo:request-buffer=256|pipelined-send". Options that do not apply to
+// the method's streaming kind are rejected.
+func ParseMethodRPCCGOOptions(comments string, streaming StreamingKind) (MethodStreamBuffering, error) {
+	buffering := DefaultMethodStreamBuffering()
+	directives := serviceRPCCGODirectives(comments)
+	if len(directives) == 0 {
+		return buffering, nil
+	}
+	if streaming == StreamingKindUnary {
+		return MethodStreamBuffering{}, fmt.Errorf("@rpccgo stream buffering directive on a unary method")
+	}
+
+	seen := make(map[string]string)
+	for _, directive := range directives {
+		trimmed := strings.TrimSpace(directive)
+		if trimmed == "" {
+			return MethodStreamBuffering{}, fmt.Errorf("empty @rpccgo directive")
+		}
+		for _, rawToken := range strings.Split(trimmed, "|") {
+			token := strings.TrimSpace(rawToken)
+			if token == "" {
+				return MethodStreamBuffering{}, fmt.Errorf("empty @rpccgo token in directive %q", directive)
+			}
+			name, value, hasValue := strings.Cut(token, "=")
+			name = strings.TrimSpace(name)
+			value = strings.TrimSpace(value)
+			if previous, ok := seen[name]; ok && previous != value {
+				return MethodStreamBuffering{}, fmt.Errorf("conflicting @rpccgo %s values %q and %q", name, previous, value)
+			}
+			seen[name] = value
+
+			switch name {
+			case methodRequestBufferOption:
+				if streaming == StreamingKindServerStreaming {
+					return MethodStreamBuffering{}, fmt.Errorf("@rpccgo %s does not apply to a server-streaming method", name)
+				}
+				size, err := parseMethodStreamBuffer(name, value, hasValue)
+				if err != nil {
+					return MethodStreamBuffering{}, err
+				}
+				buffering.RequestBuffer = size
+			case methodResponseBufferOption:
+				if streaming == StreamingKindClientStreaming {
+					return MethodStreamBuffering{}, fmt.Errorf("@rpccgo %s does not apply to a client-streaming method", name)
+				}
+				size, err := parseMethodStreamBuffer(name, value, hasValue)
+				if err != nil {
+					return MethodStreamBuffering{}, err
+				}
+				buffering.ResponseBuffer = size
+			case methodPipelinedSendOption:
+				if hasValue {
+					return MethodStreamBuffering{}, fmt.Errorf("@rpccgo %s does not take a value", name)
+				}
+				buffering.PipelinedSend = true
+			default:
+				return MethodStreamBuffering{}, fmt.Errorf("unknown @rpccgo method token %q; valid tokens: %s=N, %s=N, %s",
+					token, methodRequestBufferOption, methodResponseBufferOption, methodPipelinedSendOption)
+			}
+		}
+	}
+	return buffering, nil
+}
+
+func parseMethodStreamBuffer(name, value string, hasValue bool) (int, error) {
+	if !hasValue || value == "" {
+		return 0, fmt.Errorf("@rpccgo %s requires a size", name)
+	}
+	size, err := strconv.Atoi(value)
+	if err != nil || size < 0 || size > maxMethodStreamBuffer {
+		return 0, fmt.Errorf("@rpccgo %s=%s must be an integer in [0, %d]", name, value, maxMethodStreamBuffer)
+	}
+	return size, nil
+}
@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestParseMethodRPCCGOOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		comments  string
+		streaming StreamingKind
+		want      MethodStreamBuffering
+	}{
+		{
+			name:      "defaults without directive",
+			comments:  "Chat streams greetings.",
+			streaming: StreamingKindBidiStreaming,
+			want:      MethodStreamBuffering{RequestBuffer: 16, ResponseBuffer: 1},
+		},
+		{
+			name:      "unary without directive",
+			comments:  "SayHello returns a greeting.",
+			streaming: StreamingKindUnary,
+			want:      MethodStreamBuffering{RequestBuffer: 16, ResponseBuffer: 1},
+		},
+		{
+			name:      "parses buffers and pipelined send",
+			comments:  "// Upload ingests telemetry.\n// @rpccgo: request-buffer=256 | pipelined-send",
+			streaming: StreamingKindClientStreaming,
+			want:      MethodStreamBuffering{RequestBuffer: 256, ResponseBuffer: 1, PipelinedSend: true},
+		},
+		{
+			name:      "merges repeated directives",
+			comments:  "@rpccgo:response-buffer=0\n@rpccgo:request-buffer=4|response-buffer=0",
+			streaming: StreamingKindBidiStreaming,
+			want:      MethodStreamBuffering{RequestBuffer: 4, ResponseBuffer: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMethodRPCCGOOptions(tt.comments, tt.streaming)
+			if err != nil {
+				t.Fatalf("ParseMethodRPCCGOOptions() error = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseMethodRPCCGOOptions() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMethodRPCCGOOptionsRejectsInvalidDirectives(t *testing.T) {
+	tests := []struct {
+		name      string
+		comments  string
+		streaming StreamingKind
+		wantError string
+	}{
+		{name: "unary method", comments: "@rpccgo:pipelined-send", streaming: StreamingKindUnary, wantError: "unary method"},
+		{name: "unknown token", comments: "@rpccgo:native", streaming: StreamingKindBidiStreaming, wantError: `unknown @rpccgo method token "native"`},
+		{name: "missing size", comments: "@rpccgo:request-buffer", streaming: StreamingKindBidiStreaming, wantError: "requires a size"},
+		{name: "negative size", comments: "@rpccgo:request-buffer=-1", streaming: StreamingKindBidiStreaming, wantError: "must be an integer in [0, 65536]"},
+		{name: "oversized", comments: "@rpccgo:response-buffer=65537", streaming: StreamingKindBidiStreaming, wantError: "must be an integer in [0, 65536]"},
+		{name: "conflicting values", comments: "@rpccgo:request-buffer=8|request-buffer=16", streaming: StreamingKindBidiStreaming, wantError: "conflicting @rpccgo request-buffer"},
+		{name: "request buffer on server stream", comments: "@rpccgo:request-buffer=8", streaming: StreamingKindServerStreaming, wantError: "does not apply to a server-streaming method"},
+		{name: "response buffer on client stream", comments: "@rpccgo:response-buffer=8", streaming: StreamingKindClientStreaming, wantError: "does not apply to a client-streaming method"},
+		{name: "valued pipelined send", comments: "@rpccgo:pipelined-send=true", streaming: StreamingKindBidiStreaming, wantError: "does not take a value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseMethodRPCCGOOptions(tt.comments, tt.streaming)
+			if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+				t.Fatalf("ParseMethodRPCCGOOptions() error = %v, want %q", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestRenderLocalStreamsUseMethodStreamBuffering(t *testing.T) {
+	file := messageCgoTestFile()
+	file.SourceCodeInfo = &descriptorpb.SourceCodeInfo{
+		Location: []*descriptorpb.SourceCodeInfo_Location{
+			{
+				Path:            []int32{6, 0, 2, 1},
+				Span:            []int32{1, 0, 1},
+				LeadingComments: proto.String("Upload ingests telemetry.\n@rpccgo: request-buffer=256|pipelined-send\n"),
+			},
+		},
+	}
+	plugin := newTestPlugin(t, "paths=source_relative", file)
+
+	if _, err := GenerateWithOptions(plugin); err != nil {
+		t.Fatalf("GenerateWithOptions() error = %v", err)
+	}
+
+	const messageServerFile = "test/v1/message_cgo.greeter.server.message.rpccgo.go"
+	for _, fragment := range []string{
+		"Method:        \"test.v1.Greeter.Upload\",\n\t\tRequestBuffer: 256,\n\t\tPipelinedSend: true,",
+		"Method:         \"test.v1.Greeter.List\",\n\t\tResponseBuffer: 1,",
+		"Method:         \"test.v1.Greeter.Chat\",\n\t\tRequestBuffer:  16,\n\t\tResponseBuffer: 1,",
+	} {
+		assertGeneratedContentContains(t, plugin, messageServerFile, fragment)
+	}
+	assertGeneratedFileContentDoesNotContain(t, plugin, messageServerFile, "@rpccgo", "BorrowedRequests")
+}
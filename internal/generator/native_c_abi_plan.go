@@ -48,17 +48,19 @@ type CABISlotRole string
 
 // C ABI slot roles used by native C lowering.
 const (
-	CABISlotRoleValue      CABISlotRole = "value"
-	CABISlotRolePointer    CABISlotRole = "pointer"
-	CABISlotRoleLength     CABISlotRole = "length"
-	CABISlotRoleCount      CABISlotRole = "count"
-	CABISlotRoleOutValue   CABISlotRole = "out_value"
-	CABISlotRoleOutPointer CABISlotRole = "out_pointer"
-	CABISlotRoleOutLength  CABISlotRole = "out_length"
-	CABISlotRoleOutCount   CABISlotRole = "out_count"
-	CABISlotRoleHandle     CABISlotRole = "handle"
-	CABISlotRoleErrorID    CABISlotRole = "error_id"
-	CABISlotRoleCallback   CABISlotRole = "callback"
+	CABISlotRoleValue       CABISlotRole = "value"
+	CABISlotRolePointer     CABISlotRole = "pointer"
+	CABISlotRoleLength      CABISlotRole = "length"
+	CABISlotRoleCount       CABISlotRole = "count"
+	CABISlotRolePresence    CABISlotRole = "presence"
+	CABISlotRoleOutValue    CABISlotRole = "out_value"
+	CABISlotRoleOutPointer  CABISlotRole = "out_pointer"
+	CABISlotRoleOutLength   CABISlotRole = "out_length"
+	CABISlotRoleOutCount    CABISlotRole = "out_count"
+	CABISlotRoleOutPresence CABISlotRole = "out_presence"
+	CABISlotRoleHandle      CABISlotRole = "handle"
+	CABISlotRoleErrorID     CABISlotRole = "error_id"
+	CABISlotRoleCallback    CABISlotRole = "callback"
 )
 
 // NativeCRegisterABI builds the service-level C callback registration ABI for a native server.
@@ -298,7 +300,27 @@ func (b nativeCABIBuilder) outputSlots(fields []FieldPlan) []CABISlot {
 	return slots
 }
 
+// nativeCABIFieldSlots lowers one field to its C ABI slots. A field with
+// presence is preceded by an int8_t Has<Field> flag; its value slots are
+// ignored when the flag is zero.
 func nativeCABIFieldSlots(field FieldPlan, output bool) []CABISlot {
+	slots := nativeCABIFieldValueSlots(field, output)
+	if !field.Presence {
+		return slots
+	}
+	presence := CABISlot{Name: nativePresenceName(field), CType: "int8_t", CGoType: "C.int8_t", Role: CABISlotRolePresence, FieldGoName: field.GoName}
+	if output {
+		presence = CABISlot{Name: "out" + presence.Name, CType: "int8_t*", CGoType: "*C.int8_t", Role: CABISlotRoleOutPresence, FieldGoName: field.GoName}
+	}
+	return append([]CABISlot{presence}, slots...)
+}
+
+// nativePresenceName names the presence flag of a field in the C ABI.
+func nativePresenceName(field FieldPlan) string {
+	return "Has" + field.GoName
+}
+
+func nativeCABIFieldValueSlots(field FieldPlan, output bool) []CABISlot {
 	slot := func(name, ctype string, role CABISlotRole) CABISlot {
 		return CABISlot{Name: name, CType: ctype, CGoType: nativeCGoType(ctype), Role: role, FieldGoName: field.GoName}
 	}
@@ -326,7 +348,7 @@ func nativeCABIFieldSlots(field FieldPlan, output bool) []CABISlot {
 	switch field.Native.Shape {
 	case NativeABIShapeBoolByte:
 		return []CABISlot{slot(name(""), "int8_t"+ptr, roleValue)}
-	case NativeABIShapeRepeated, NativeABIShapeBoolByteBufferWrapper:
+	case NativeABIShapeRepeated, NativeABIShapeBoolByteBufferWrapper, NativeABIShapeBufferArray:
 		return []CABISlot{slot(name("Ptr"), "uintptr_t"+ptr, rolePointer), slot(name("Len"), "int32_t"+ptr, roleCount), slot(name("Ownership"), "int32_t"+ptr, roleValue)}
 	case NativeABIShapeScalar, NativeABIShapeMessageBytes:
 		switch field.Kind {
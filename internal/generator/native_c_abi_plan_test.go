@@ -53,6 +53,7 @@ func TestNativeCOperationABIUnaryAllFields(t *testing.T) {
 		{Name: "PayloadPtr", CType: "uintptr_t", CGoType: "C.uintptr_t", Role: CABISlotRolePointer, FieldGoName: "Payload"},
 		{Name: "PayloadLen", CType: "int32_t", CGoType: "C.int32_t", Role: CABISlotRoleLength, FieldGoName: "Payload"},
 		{Name: "PayloadOwnership", CType: "int32_t", CGoType: "C.int32_t", Role: CABISlotRoleValue, FieldGoName: "Payload"},
+		{Name: "HasChild", CType: "int8_t", CGoType: "C.int8_t", Role: CABISlotRolePresence, FieldGoName: "Child"},
 		{Name: "ChildPtr", CType: "uintptr_t", CGoType: "C.uintptr_t", Role: CABISlotRolePointer, FieldGoName: "Child"},
 		{Name: "ChildLen", CType: "int32_t", CGoType: "C.int32_t", Role: CABISlotRoleLength, FieldGoName: "Child"},
 		{Name: "ChildOwnership", CType: "int32_t", CGoType: "C.int32_t", Role: CABISlotRoleValue, FieldGoName: "Child"},
@@ -73,6 +74,84 @@ func TestNativeCOperationABIUnaryAllFields(t *testing.T) {
 	assertCABISlots(t, unary.Params, want)
 }
 
+func TestNativeCOperationABILowersMapFieldsToKeyValueArrays(t *testing.T) {
+	plugin := newTestPlugin(t, "paths=source_relative", nativeServerMapFile())
+	plan, err := BuildDescriptorPlan(plugin.Files[0])
+	if err != nil {
+		t.Fatalf("BuildDescriptorPlan() error = %v", err)
+	}
+
+	service := plan.Services[0]
+	unary, err := NativeCOperationABI(plan, service, service.Methods[0], NativeCOperationUnary)
+	if err != nil {
+		t.Fatalf("NativeCOperationABI() error = %v", err)
+	}
+
+	var got []CABISlot
+	for _, slot := range unary.Params {
+		if slot.FieldGoName == "TotalsKeys" || slot.FieldGoName == "TotalsValues" {
+			got = append(got, slot)
+		}
+	}
+	want := []CABISlot{
+		{Name: "TotalsKeysPtr", CType: "uintptr_t", CGoType: "C.uintptr_t", Role: CABISlotRolePointer, FieldGoName: "TotalsKeys"},
+		{Name: "TotalsKeysLen", CType: "int32_t", CGoType: "C.int32_t", Role: CABISlotRoleCount, FieldGoName: "TotalsKeys"},
+		{Name: "TotalsKeysOwnership", CType: "int32_t", CGoType: "C.int32_t", Role: CABISlotRoleValue, FieldGoName: "TotalsKeys"},
+		{Name: "TotalsValuesPtr", CType: "uintptr_t", CGoType: "C.uintptr_t", Role: CABISlotRolePointer, FieldGoName: "TotalsValues"},
+		{Name: "TotalsValuesLen", CType: "int32_t", CGoType: "C.int32_t", Role: CABISlotRoleCount, FieldGoName: "TotalsValues"},
+		{Name: "TotalsValuesOwnership", CType: "int32_t", CGoType: "C.int32_t", Role: CABISlotRoleValue, FieldGoName: "TotalsValues"},
+		{Name: "outTotalsKeysPtr", CType: "uintptr_t*", CGoType: "*C.uintptr_t", Role: CABISlotRoleOutPointer, FieldGoName: "TotalsKeys"},
+		{Name: "outTotalsKeysLen", CType: "int32_t*", CGoType: "*C.int32_t", Role: CABISlotRoleOutCount, FieldGoName: "TotalsKeys"},
+		{Name: "outTotalsKeysOwnership", CType: "int32_t*", CGoType: "*C.int32_t", Role: CABISlotRoleOutValue, FieldGoName: "TotalsKeys"},
+		{Name: "outTotalsValuesPtr", CType: "uintptr_t*", CGoType: "*C.uintptr_t", Role: CABISlotRoleOutPointer, FieldGoName: "TotalsValues"},
+		{Name: "outTotalsValuesLen", CType: "int32_t*", CGoType: "*C.int32_t", Role: CABISlotRoleOutCount, FieldGoName: "TotalsValues"},
+		{Name: "outTotalsValuesOwnership", CType: "int32_t*", CGoType: "*C.int32_t", Role: CABISlotRoleOutValue, FieldGoName: "TotalsValues"},
+	}
+	assertCABISlots(t, got, want)
+}
+
+func TestNativeCOperationABIPrefixesPresenceFieldsWithHasFlag(t *testing.T) {
+	plugin := newTestPlugin(t, "paths=source_relative", nativeServerPresenceFile())
+	plan, err := BuildDescriptorPlan(plugin.Files[0])
+	if err != nil {
+		t.Fatalf("BuildDescriptorPlan() error = %v", err)
+	}
+
+	service := plan.Services[0]
+	unary, err := NativeCOperationABI(plan, service, service.Methods[0], NativeCOperationUnary)
+	if err != nil {
+		t.Fatalf("NativeCOperationABI() error = %v", err)
+	}
+
+	var got []CABISlot
+	for _, slot := range unary.Params {
+		if slot.FieldGoName == "Note" || slot.FieldGoName == "Limit" || slot.FieldGoName == "Label" {
+			got = append(got, slot)
+		}
+	}
+	want := []CABISlot{
+		{Name: "NotePtr", CType: "uintptr_t", CGoType: "C.uintptr_t", Role: CABISlotRolePointer, FieldGoName: "Note"},
+		{Name: "NoteLen", CType: "int32_t", CGoType: "C.int32_t", Role: CABISlotRoleLength, FieldGoName: "Note"},
+		{Name: "NoteOwnership", CType: "int32_t", CGoType: "C.int32_t", Role: CABISlotRoleValue, FieldGoName: "Note"},
+		{Name: "HasLimit", CType: "int8_t", CGoType: "C.int8_t", Role: CABISlotRolePresence, FieldGoName: "Limit"},
+		{Name: "Limit", CType: "int32_t", CGoType: "C.int32_t", Role: CABISlotRoleValue, FieldGoName: "Limit"},
+		{Name: "HasLabel", CType: "int8_t", CGoType: "C.int8_t", Role: CABISlotRolePresence, FieldGoName: "Label"},
+		{Name: "LabelPtr", CType: "uintptr_t", CGoType: "C.uintptr_t", Role: CABISlotRolePointer, FieldGoName: "Label"},
+		{Name: "LabelLen", CType: "int32_t", CGoType: "C.int32_t", Role: CABISlotRoleLength, FieldGoName: "Label"},
+		{Name: "LabelOwnership", CType: "int32_t", CGoType: "C.int32_t", Role: CABISlotRoleValue, FieldGoName: "Label"},
+		{Name: "outNotePtr", CType: "uintptr_t*", CGoType: "*C.uintptr_t", Role: CABISlotRoleOutPointer, FieldGoName: "Note"},
+		{Name: "outNoteLen", CType: "int32_t*", CGoType: "*C.int32_t", Role: CABISlotRoleOutLength, FieldGoName: "Note"},
+		{Name: "outNoteOwnership", CType: "int32_t*", CGoType: "*C.int32_t", Role: CABISlotRoleOutValue, FieldGoName: "Note"},
+		{Name: "outHasLimit", CType: "int8_t*", CGoType: "*C.int8_t", Role: CABISlotRoleOutPresence, FieldGoName: "Limit"},
+		{Name: "outLimit", CType: "int32_t*", CGoType: "*C.int32_t", Role: CABISlotRoleOutValue, FieldGoName: "Limit"},
+		{Name: "outHasLabel", CType: "int8_t*", CGoType: "*C.int8_t", Role: CABISlotRoleOutPresence, FieldGoName: "Label"},
+		{Name: "outLabelPtr", CType: "uintptr_t*", CGoType: "*C.uintptr_t", Role: CABISlotRoleOutPointer, FieldGoName: "Label"},
+		{Name: "outLabelLen", CType: "int32_t*", CGoType: "*C.int32_t", Role: CABISlotRoleOutLength, FieldGoName: "Label"},
+		{Name: "outLabelOwnership", CType: "int32_t*", CGoType: "*C.int32_t", Role: CABISlotRoleOutValue, FieldGoName: "Label"},
+	}
+	assertCABISlots(t, got, want)
+}
+
 func TestNativeCOperationsForMethodStreamingOperationSets(t *testing.T) {
 	file := nativeCABIStreamingFile()
 	plugin := newTestPlugin(t, "paths=source_relative", file)
@@ -135,10 +135,12 @@ type MethodPlan struct {
 	FullName   string
 	DocComment string
 	Streaming  StreamingKind
-	Request    MethodIOPlan
-	Response   MethodIOPlan
-	Contract   MethodContractPlan
-	RenderPlan MethodRenderPlan
+	// StreamBuffering is selected by the method's @rpccgo directive.
+	StreamBuffering MethodStreamBuffering
+	Request         MethodIOPlan
+	Response        MethodIOPlan
+	Contract        MethodContractPlan
+	RenderPlan      MethodRenderPlan
 }
 
 // HasIdentity reports whether the method plan has protobuf identity and request/response types.
@@ -156,7 +158,60 @@ type FieldPlan struct {
 	Enum     bool
 	Message  bool
 	EnumType MethodIOPlan
-	Native   NativeFieldPlan
+	// MessageType is the element message of a message field.
+	MessageType MethodIOPlan
+	Native      NativeFieldPlan
+	// MapEntry is set on the two repeated halves a map field lowers to.
+	MapEntry MapEntryPlan
+	// Oneof is set on the case discriminant and the member slots a oneof lowers to.
+	Oneof OneofPlan
+	// Presence is set on singular fields whose set/unset state crosses the
+	// native boundary next to their value, such as proto3 optional fields.
+	Presence bool
+}
+
+// MapEntryPlan links one half of a lowered map field to the protobuf map field.
+type MapEntryPlan struct {
+	// GoName is the Go name of the protobuf map field.
+	GoName string
+	Role   MapEntryRole
+}
+
+// MapEntryRole identifies the key or value half of a lowered map field.
+type MapEntryRole string
+
+// Map entry roles. The zero role marks a field that is not part of a map.
+const (
+	MapEntryRoleKey   MapEntryRole = "key"
+	MapEntryRoleValue MapEntryRole = "value"
+)
+
+// OneofPlan links one slot of a lowered oneof to the protobuf oneof.
+type OneofPlan struct {
+	// GoName is the Go name of the protobuf oneof.
+	GoName string
+	Role   OneofRole
+	// Cases lists the oneof members in declaration order; it is set on the case slot.
+	Cases []OneofCasePlan
+}
+
+// OneofRole identifies the case discriminant or a member slot of a lowered oneof.
+type OneofRole string
+
+// Oneof roles. The zero role marks a field that is not part of a oneof.
+const (
+	OneofRoleCase   OneofRole = "case"
+	OneofRoleMember OneofRole = "member"
+)
+
+// OneofCasePlan describes one member of a oneof as seen by its case discriminant.
+type OneofCasePlan struct {
+	Name string
+	// GoName is the Go name of the member field.
+	GoName string
+	Number int32
+	// WrapperType is the protoc-gen-go wrapper struct that holds the member.
+	WrapperType MethodIOPlan
 }
 
 // FieldKind classifies protobuf field kinds used by contract planning.
@@ -201,6 +256,7 @@ const (
 	NativeABIShapeBoolByte              NativeABIShape = "bool_byte"
 	NativeABIShapeBoolByteBufferWrapper NativeABIShape = "bool_byte_buffer_wrapper"
 	NativeABIShapeMessageBytes          NativeABIShape = "message_bytes"
+	NativeABIShapeBufferArray           NativeABIShape = "buffer_array"
 )
 
 // NativeFieldPlan records the native kind and ABI shape for one protobuf field.
@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// cgoClientExport describes one C client export whose body runs under a
+// caller-selected context.
+type cgoClientExport struct {
+	Name   string
+	Doc    string
+	Params string
+	Return string
+	// KeepsContext marks stream starts: the started stream owns the call
+	// context, so the options variant only cancels it when the start fails.
+	KeepsContext bool
+	// StreamOp marks operations on a started stream. They keep the response
+	// metadata collector of the stream start, which records the stream's
+	// headers and trailers.
+	StreamOp bool
+}
+
+// renderCGOClientExportOpen renders the plain export and its WithOptions
+// variant, then opens the shared body function. The caller renders the body,
+// which sees the call context as ctx, and closes the function. The body
+// recovers panics into a stored error so none unwinds into the C caller.
+func renderCGOClientExportOpen(g *protogen.GeneratedFile, export cgoClientExport) {
+	bodyName := cgoClientExportBodyName(export.Name)
+	optionsName := cgoClientExportOptionsName(export.Name)
+	args := cgoExportParamNames(export.Params)
+
+	renderCGOExportDoc(g, export.Name, export.Doc)
+	g.P("//export ", export.Name)
+	g.P("func ", export.Name, "(", export.Params, ") ", export.Return, " {")
+	g.P("return ", bodyName, "(", nativeCExportParamJoin("context.Background()", args), ")")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, optionsName, "is "+export.Name+" bounded by the deadline and cancel token of a call options handle.")
+	g.P("//export ", optionsName)
+	g.P("func ", optionsName, "(", nativeCExportParamJoin("options C.int32_t", export.Params), ") ", export.Return, " {")
+	contextFunc := "CallOptionsContext"
+	if export.StreamOp {
+		contextFunc = "CallOptionsStreamContext"
+	}
+	g.P("ctx, cancel, err := rpcruntime.", contextFunc, "(rpcruntime.CallOptionsHandle(options))")
+	g.P("if err != nil {")
+	g.P("return ", export.Return, "(rpcruntime.StoreError(err))")
+	g.P("}")
+	if export.KeepsContext {
+		g.P("status := ", bodyName, "(", nativeCExportParamJoin("ctx", args), ")")
+		g.P("if status != 0 {")
+		g.P("cancel()")
+		g.P("}")
+		g.P("return status")
+	} else {
+		g.P("defer cancel()")
+		g.P("return ", bodyName, "(", nativeCExportParamJoin("ctx", args), ")")
+	}
+	g.P("}")
+	g.P()
+	g.P("func ", bodyName, "(", nativeCExportParamJoin("ctx context.Context", export.Params), ") (errID ", export.Return, ") {")
+	g.P("defer rpcruntime.RecoverPanic(", strconv.Quote(export.Name), ", func(err error) { errID = ", export.Return, "(rpcruntime.StoreError(err)) })")
+}
+
+func cgoClientExportOptionsName(exportName string) string {
+	return exportName + "WithOptions"
+}
+
+func cgoClientExportBodyName(exportName string) string {
+	return exportName + "WithContext"
+}
+
+// cgoExportParamNames turns a rendered "name type, name type" parameter list
+// into the matching argument list.
+func cgoExportParamNames(params string) string {
+	if strings.TrimSpace(params) == "" {
+		return ""
+	}
+	parts := strings.Split(params, ",")
+	names := make([]string, 0, len(parts))
+	for _, part := range parts {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		names = append(names, fields[0])
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,175 @@
+package generator
+
+import "google.golang.org/protobuf/compiler/protogen"
+
+// renderCGODynamicInvokeExports renders the message contract exports that
+// name the method at run time. They dispatch through the methods each
+// generated runtime file registers with rpcruntime.RegisterDynamicMethod.
+func renderCGODynamicInvokeExports(g *protogen.GeneratedFile, releaseName string) {
+	invokeName := cgoSharedExportName("invoke")
+	streamStartName := cgoSharedExportName("stream_start")
+	streamSendName := cgoSharedExportName("stream_send")
+	streamRecvName := cgoSharedExportName("stream_recv")
+	streamCloseSendName := cgoSharedExportName("stream_close_send")
+	streamFinishName := cgoSharedExportName("stream_finish")
+	streamCancelName := cgoSharedExportName("stream_cancel")
+	const methodParams = "serviceID *C.char, serviceIDLen C.int32_t, method *C.char, methodLen C.int32_t"
+
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:   invokeName,
+		Doc:    "invokes a unary method named at run time with a protobuf encoded request. method is the method name within the service or its protobuf full name. A non-zero response pointer must be released with " + releaseName + ".",
+		Params: methodParams + ", requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t",
+		Return: "C.int32_t",
+	})
+	renderCGODynamicOutputReset(g, "response")
+	renderCGODynamicMethodName(g)
+	renderCGODynamicRequest(g)
+	g.P("resp, err := rpcruntime.InvokeDynamic(ctx, id, name, request)")
+	renderCGODynamicResponse(g, "response")
+
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:         streamStartName,
+		Doc:          "starts a streaming method named at run time and returns its stream handle. The request is the protobuf encoded request of a server-streaming method and is ignored otherwise. Continue the stream with the generic stream exports or the per-method exports.",
+		Params:       methodParams + ", requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t",
+		Return:       "C.int32_t",
+		KeepsContext: true,
+	})
+	g.P("if handle == nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: dynamic stream handle pointer is nil")))`)
+	g.P("}")
+	g.P("*handle = 0")
+	renderCGODynamicMethodName(g)
+	renderCGODynamicRequest(g)
+	g.P("started, err := rpcruntime.StartDynamicStream(ctx, id, name, request)")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("*handle = C.int32_t(started)")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     streamSendName,
+		Doc:      "sends a protobuf encoded request on a client or bidi message stream.",
+		Params:   "handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t",
+		Return:   "C.int32_t",
+		StreamOp: true,
+	})
+	renderCGODynamicRequest(g)
+	g.P("if err := rpcruntime.SendDynamicStream(ctx, rpcruntime.StreamHandle(handle), request); err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     streamRecvName,
+		Doc:      "receives the next protobuf encoded response of a server or bidi message stream. The end of the stream is an error with RPCCGO_CODE_OUT_OF_RANGE. A non-zero response pointer must be released with " + releaseName + ".",
+		Params:   "handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t",
+		Return:   "C.int32_t",
+		StreamOp: true,
+	})
+	renderCGODynamicOutputReset(g, "response")
+	g.P("resp, err := rpcruntime.RecvDynamicStream(ctx, rpcruntime.StreamHandle(handle))")
+	renderCGODynamicResponse(g, "response")
+
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     streamCloseSendName,
+		Doc:      "closes the send side of a bidi message stream.",
+		Params:   "handle C.int32_t",
+		Return:   "C.int32_t",
+		StreamOp: true,
+	})
+	g.P("if err := rpcruntime.CloseSendDynamicStream(ctx, rpcruntime.StreamHandle(handle)); err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     streamFinishName,
+		Doc:      "finishes a client or bidi message stream and releases its handle. A client stream writes its protobuf encoded response; a bidi stream writes an empty response. A non-zero response pointer must be released with " + releaseName + ".",
+		Params:   "handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t",
+		Return:   "C.int32_t",
+		StreamOp: true,
+	})
+	renderCGODynamicOutputReset(g, "response")
+	g.P("resp, err := rpcruntime.FinishDynamicStream(ctx, rpcruntime.StreamHandle(handle))")
+	renderCGODynamicResponse(g, "response")
+
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     streamCancelName,
+		Doc:      "cancels a message stream and releases its handle.",
+		Params:   "handle C.int32_t",
+		Return:   "C.int32_t",
+		StreamOp: true,
+	})
+	g.P("if err := rpcruntime.CancelDynamicStream(ctx, rpcruntime.StreamHandle(handle)); err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+}
+
+func renderCGODynamicOutputReset(g *protogen.GeneratedFile, name string) {
+	g.P("if ", name, "Ptr == nil || ", name, "Len == nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: dynamic `, name, ` output pointer is nil")))`)
+	g.P("}")
+	g.P("*", name, "Ptr = 0")
+	g.P("*", name, "Len = 0")
+}
+
+func renderCGODynamicMethodName(g *protogen.GeneratedFile) {
+	g.P("serviceIDLength, err := rpcruntime.LengthFromInt32(int32(serviceIDLen))")
+	g.P("if err != nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: dynamic service id: %w", err)))`)
+	g.P("}")
+	g.P("methodLength, err := rpcruntime.LengthFromInt32(int32(methodLen))")
+	g.P("if err != nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: dynamic method: %w", err)))`)
+	g.P("}")
+	g.P("if (serviceID == nil && serviceIDLength != 0) || (method == nil && methodLength != 0) {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: dynamic method name pointer is nil")))`)
+	g.P("}")
+	g.P("var id rpcruntime.ServiceID")
+	g.P("if serviceIDLength != 0 {")
+	g.P("id = rpcruntime.ServiceID(unsafe.Slice((*byte)(unsafe.Pointer(serviceID)), serviceIDLength))")
+	g.P("}")
+	g.P("var name string")
+	g.P("if methodLength != 0 {")
+	g.P("name = string(unsafe.Slice((*byte)(unsafe.Pointer(method)), methodLength))")
+	g.P("}")
+}
+
+// renderCGODynamicRequest borrows the request bytes for the duration of the
+// export; the runtime decodes them before it returns. requestPtr arrives as a
+// C pointer, so no uintptr conversion is needed.
+func renderCGODynamicRequest(g *protogen.GeneratedFile) {
+	g.P("request, err := rpcruntime.BorrowBytes(requestPtr, int32(requestLen))")
+	g.P("if err != nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: dynamic request: %w", err)))`)
+	g.P("}")
+}
+
+func renderCGODynamicResponse(g *protogen.GeneratedFile, name string) {
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("goLen, err := rpcruntime.LengthToInt32(len(resp))")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("goPtr, err := rpcruntime.PinBytes(resp)")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("*", name, "Ptr = C.uintptr_t(goPtr)")
+	g.P("*", name, "Len = C.int32_t(goLen)")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+}
@@ -1,30 +1,142 @@
 package generator
 
-import "google.golang.org/protobuf/compiler/protogen"
+import (
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// cgoEnterCallbackTraceName is the shared package main helper that cgo server
+// adapters call around Unary and stream Start callbacks so the callback can read
+// the trace parent of the Go call through the callback_trace_parent export.
+const cgoEnterCallbackTraceName = "rpccgoEnterCallbackTrace"
 
 func renderCGOExportSupportFile(plugin *protogen.Plugin, pkg PackagePlan, file GeneratedArtifactPlan) {
 	g := newGeneratedSharedFile(plugin, file, protogen.GoImportPath(packageCGOImportPath(pkg)), "rpccgo cgo export support")
 	registerFreeName := cgoSharedExportName("register_free")
 	storeErrorTextName := cgoSharedExportName("store_error_text")
 	takeErrorTextName := cgoSharedExportName("take_error_text")
+	metricsSnapshotName := cgoSharedExportName("metrics_snapshot")
+	storeErrorCodeName := cgoSharedExportName("store_error_code")
+	storeErrorStatusName := cgoSharedExportName("store_error_status")
+	takeErrorCodeName := cgoSharedExportName("take_error_code")
+	takeErrorStatusName := cgoSharedExportName("take_error_status")
+	discardErrorName := cgoSharedExportName("discard_error")
+	errorStoreConfigureName := cgoSharedExportName("error_store_configure")
 	releaseName := cgoSharedExportName("release")
+	pinTrackingConfigureName := cgoSharedExportName("pin_tracking_configure")
+	pinnedReportName := cgoSharedExportName("pinned_report")
+	panicReportsConfigureName := cgoSharedExportName("panic_reports_configure")
+	streamSessionsListName := cgoSharedExportName("stream_sessions_list")
+	streamSessionCancelName := cgoSharedExportName("stream_session_cancel")
+	streamSessionsCancelIdleName := cgoSharedExportName("stream_sessions_cancel_idle")
+	streamSessionsSetIdleTimeoutName := cgoSharedExportName("stream_sessions_set_idle_timeout")
+	streamBufferingSetName := cgoSharedExportName("stream_buffering_set")
+	streamBufferingClearName := cgoSharedExportName("stream_buffering_clear")
+	concurrencyLimitSetName := cgoSharedExportName("concurrency_limit_set")
+	concurrencyLimitClearName := cgoSharedExportName("concurrency_limit_clear")
+	concurrencySnapshotName := cgoSharedExportName("concurrency_snapshot")
+	shutdownName := cgoSharedExportName("shutdown")
+	registrationWatchName := cgoSharedExportName("registration_watch")
+	registrationUnwatchName := cgoSharedExportName("registration_unwatch")
+	healthCheckName := cgoSharedExportName("health_check")
+	healthReportName := cgoSharedExportName("health_report")
+	healthWatchName := cgoSharedExportName("health_watch")
+	reflectionServicesName := cgoSharedExportName("reflection_services")
+	reflectionMethodsName := cgoSharedExportName("reflection_methods")
+	reflectionFileDescriptorSetName := cgoSharedExportName("reflection_file_descriptor_set")
+	serverRegistryNewName := cgoSharedExportName("server_registry_new")
+	serverRegistryCopyRouteName := cgoSharedExportName("server_registry_copy_route")
+	serverRegistryReleaseName := cgoSharedExportName("server_registry_release")
+	callOptionsNewName := cgoSharedExportName("call_options_new")
+	callOptionsSetDeadlineName := cgoSharedExportName("call_options_set_deadline")
+	callOptionsSetMetadataName := cgoSharedExportName("call_options_set_metadata")
+	callOptionsSetRegistryName := cgoSharedExportName("call_options_set_registry")
+	callOptionsTakeResponseMetadataName := cgoSharedExportName("call_options_take_response_metadata")
+	callOptionsCancelName := cgoSharedExportName("call_options_cancel")
+	callOptionsReleaseName := cgoSharedExportName("call_options_release")
+	callbackTraceParentName := cgoSharedExportName("callback_trace_parent")
 	g.P("package main")
 	g.P()
 	g.P("/*")
 	g.P("#include <stdint.h>")
+	g.P("#include <string.h>")
+	g.P()
+	g.P("#define RPCCGO_ERR_DEADLINE_EXCEEDED (-2)")
+	g.P("#define RPCCGO_ERR_BUFFER_TOO_SMALL (-3)")
+	g.P("#define RPCCGO_TRACEPARENT_LEN 55")
+	g.P()
+	for code, name := range cgoErrorCodeNames {
+		g.P("#define RPCCGO_CODE_", name, " ", code)
+	}
+	g.P()
+	for kind, name := range cgoServerKindNames {
+		g.P("#define RPCCGO_SERVER_KIND_", name, " ", kind)
+	}
+	g.P()
+	g.P("#define RPCCGO_HEALTH_UNKNOWN 0")
+	g.P("#define RPCCGO_HEALTH_SERVING 1")
+	g.P("#define RPCCGO_HEALTH_NOT_SERVING 2")
+	g.P("#define RPCCGO_METHOD_STREAMING_UNARY 0")
+	g.P("#define RPCCGO_METHOD_STREAMING_CLIENT 1")
+	g.P("#define RPCCGO_METHOD_STREAMING_SERVER 2")
+	g.P("#define RPCCGO_METHOD_STREAMING_BIDI 3")
+	g.P()
+	g.P("// One element of a repeated string, bytes or message native field.")
+	g.P("typedef struct {")
+	g.P("uintptr_t ptr;")
+	g.P("int32_t len;")
+	g.P("int32_t ownership;")
+	g.P("} rpccgo_buffer;")
 	g.P()
 	g.P("typedef void (*rpccgo_free_callback)(void*);")
+	g.P("typedef void (*rpccgo_registration_event_callback)(int32_t watch, uintptr_t service_id_ptr, int32_t service_id_len, int32_t old_kind, int32_t new_kind, int32_t cleared, uint64_t seq);")
+	g.P("typedef void (*rpccgo_registration_done_callback)(int32_t watch);")
+	g.P("typedef void (*rpccgo_health_callback)(int32_t watch, uintptr_t service_id_ptr, int32_t service_id_len, int32_t status, int32_t kind);")
+	g.P("typedef void (*rpccgo_panic_callback)(uintptr_t text_ptr, int32_t text_len);")
 	g.P()
 	g.P("static inline void rpccgo_call_free_callback(rpccgo_free_callback callback, void* ptr) {")
 	g.P("callback(ptr);")
 	g.P("}")
+	g.P()
+	g.P("static inline void rpccgo_call_registration_event_callback(rpccgo_registration_event_callback callback, int32_t watch, uintptr_t service_id_ptr, int32_t service_id_len, int32_t old_kind, int32_t new_kind, int32_t cleared, uint64_t seq) {")
+	g.P("callback(watch, service_id_ptr, service_id_len, old_kind, new_kind, cleared, seq);")
+	g.P("}")
+	g.P()
+	g.P("static inline void rpccgo_call_registration_done_callback(rpccgo_registration_done_callback callback, int32_t watch) {")
+	g.P("callback(watch);")
+	g.P("}")
+	g.P()
+	g.P("static inline void rpccgo_call_health_callback(rpccgo_health_callback callback, int32_t watch, uintptr_t service_id_ptr, int32_t service_id_len, int32_t status, int32_t kind) {")
+	g.P("callback(watch, service_id_ptr, service_id_len, status, kind);")
+	g.P("}")
+	g.P()
+	g.P("static inline void rpccgo_call_panic_callback(rpccgo_panic_callback callback, uintptr_t text_ptr, int32_t text_len) {")
+	g.P("callback(text_ptr, text_len);")
+	g.P("}")
+	g.P()
+	g.P("static _Thread_local char rpccgo_callback_trace_parent[RPCCGO_TRACEPARENT_LEN];")
+	g.P("static _Thread_local int32_t rpccgo_callback_trace_parent_len;")
+	g.P()
+	g.P("static inline int32_t rpccgo_get_callback_trace_parent(char* out) {")
+	g.P("memcpy(out, rpccgo_callback_trace_parent, rpccgo_callback_trace_parent_len);")
+	g.P("return rpccgo_callback_trace_parent_len;")
+	g.P("}")
+	g.P()
+	g.P("static inline void rpccgo_set_callback_trace_parent(const char* value, int32_t len) {")
+	g.P("memcpy(rpccgo_callback_trace_parent, value, len);")
+	g.P("rpccgo_callback_trace_parent_len = len;")
+	g.P("}")
 	g.P("*/")
 	g.P(`import "C"`)
 	g.P()
 	g.P("import (")
+	g.P(`context "context"`)
 	g.P(`errors "errors"`)
 	g.P(`fmt "fmt"`)
 	g.P(`rpcruntime "`, rpcruntimeImportPath, `"`)
+	g.P(`goruntime "runtime"`)
+	g.P(`time "time"`)
 	g.P(`unsafe "unsafe"`)
 	g.P(")")
 	g.P()
@@ -76,6 +188,104 @@ func renderCGOExportSupportFile(plugin *protogen.Plugin, pkg PackagePlan, file G
 	g.P("return 0")
 	g.P("}")
 	g.P()
+	renderCGOExportDoc(g, metricsSnapshotName, "encodes the runtime metrics as a rpccgo.runtime.v1.MetricsSnapshot protobuf message. A non-zero pointer must be released with "+releaseName+".")
+	g.P("//export ", metricsSnapshotName)
+	g.P("func ", metricsSnapshotName, "(snapshotPtr *C.uintptr_t, snapshotLen *C.int32_t) C.int32_t {")
+	g.P("if snapshotPtr == nil || snapshotLen == nil {")
+	g.P("return -1")
+	g.P("}")
+	g.P("goPtr, goLen, err := rpcruntime.EncodePinnedMetricsSnapshot()")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("*snapshotPtr = C.uintptr_t(goPtr)")
+	g.P("*snapshotLen = C.int32_t(goLen)")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, storeErrorCodeName, "stores C error text with a canonical RPC status code and returns its error id.")
+	g.P("//export ", storeErrorCodeName)
+	g.P("func ", storeErrorCodeName, "(code C.int32_t, text *C.char, textLen C.int32_t) C.int32_t {")
+	g.P("length, err := rpcruntime.LengthFromInt32(int32(textLen))")
+	g.P("if err != nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: cgo error text: %w", err)))`)
+	g.P("}")
+	g.P("if text == nil && length != 0 {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: cgo error text pointer is nil")))`)
+	g.P("}")
+	g.P("var data []byte")
+	g.P("if length != 0 {")
+	g.P("data = unsafe.Slice((*byte)(unsafe.Pointer(text)), length)")
+	g.P("}")
+	g.P("return C.int32_t(rpcruntime.StoreError(rpcruntime.NewStatusError(rpcruntime.ErrorCode(code), string(data))))")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, storeErrorStatusName, "stores an encoded google.rpc.Status from C in the Go error registry and returns its error id.")
+	g.P("//export ", storeErrorStatusName)
+	g.P("func ", storeErrorStatusName, "(statusPtr unsafe.Pointer, statusLen C.int32_t) C.int32_t {")
+	g.P("statusErr, err := rpcruntime.DecodeStatusError(uintptr(statusPtr), int32(statusLen))")
+	g.P("if err != nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: cgo error status decode failed: %w", err)))`)
+	g.P("}")
+	g.P("return C.int32_t(rpcruntime.StoreError(statusErr))")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, takeErrorCodeName, "takes the canonical RPC status code and error text for a C caller and releases the error id.")
+	g.P("//export ", takeErrorCodeName)
+	g.P("func ", takeErrorCodeName, "(errID C.int32_t, code *C.int32_t, textPtr *C.uintptr_t, textLen *C.int32_t) C.int32_t {")
+	g.P("if code == nil || textPtr == nil || textLen == nil {")
+	g.P("return -1")
+	g.P("}")
+	g.P("var goCode int32")
+	g.P("var goPtr uintptr")
+	g.P("var goLen int32")
+	g.P("status := rpcruntime.TakeErrorCodeForExport(int32(errID), &goCode, &goPtr, &goLen)")
+	g.P("if status != 0 {")
+	g.P("return C.int32_t(status)")
+	g.P("}")
+	g.P("*code = C.int32_t(goCode)")
+	g.P("*textPtr = C.uintptr_t(goPtr)")
+	g.P("*textLen = C.int32_t(goLen)")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, takeErrorStatusName, "takes the stored error as encoded google.rpc.Status bytes for a C caller and releases the error id.")
+	g.P("//export ", takeErrorStatusName)
+	g.P("func ", takeErrorStatusName, "(errID C.int32_t, statusPtr *C.uintptr_t, statusLen *C.int32_t) C.int32_t {")
+	g.P("if statusPtr == nil || statusLen == nil {")
+	g.P("return -1")
+	g.P("}")
+	g.P("var goPtr uintptr")
+	g.P("var goLen int32")
+	g.P("status := rpcruntime.TakeErrorStatusForExport(int32(errID), &goPtr, &goLen)")
+	g.P("if status != 0 {")
+	g.P("return C.int32_t(status)")
+	g.P("}")
+	g.P("*statusPtr = C.uintptr_t(goPtr)")
+	g.P("*statusLen = C.int32_t(goLen)")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, discardErrorName, "releases an error id without reading it. It returns -1 when the id is unknown, already taken or expired.")
+	g.P("//export ", discardErrorName)
+	g.P("func ", discardErrorName, "(errID C.int32_t) C.int32_t {")
+	g.P("if !rpcruntime.DiscardError(rpcruntime.ErrorID(errID)) {")
+	g.P("return -1")
+	g.P("}")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, errorStoreConfigureName, "sets how long error ids stay readable and how many are kept at once; storing past capacity drops the oldest id. Non-positive values keep the defaults of 3000 ms and 4096 ids. A non-zero explicitRelease keeps every error id until it is taken or released with "+discardErrorName+", ignoring ttlMs.")
+	g.P("//export ", errorStoreConfigureName)
+	g.P("func ", errorStoreConfigureName, "(ttlMs C.int64_t, capacity C.int32_t, explicitRelease C.int32_t) C.int32_t {")
+	g.P("rpcruntime.ConfigureErrorStore(rpcruntime.ErrorStoreConfig{")
+	g.P("TTL: time.Duration(ttlMs) * time.Millisecond,")
+	g.P("Capacity: int(capacity),")
+	g.P("ExplicitRelease: explicitRelease != 0,")
+	g.P("})")
+	g.P("return 0")
+	g.P("}")
+	g.P()
 	renderCGOExportDoc(g, releaseName, "releases memory previously handed to C through rpccgo ABI helpers.")
 	g.P("//export ", releaseName)
 	g.P("func ", releaseName, "(ptr C.uintptr_t) C.int32_t {")
@@ -84,4 +294,601 @@ func renderCGOExportSupportFile(plugin *protogen.Plugin, pkg PackagePlan, file G
 	g.P("}")
 	g.P("return 0")
 	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, pinTrackingConfigureName, "turns the pinned memory leak detector on or off. While enabled, every buffer handed to C records the Go function that pinned it, its size and pin time; a non-zero stacks also records the Go stack. Tracking walks the stack on every pin and is meant for debugging and tests.")
+	g.P("//export ", pinTrackingConfigureName)
+	g.P("func ", pinTrackingConfigureName, "(enabled C.int32_t, stacks C.int32_t) C.int32_t {")
+	g.P("rpcruntime.ConfigurePinTracking(rpcruntime.PinTrackingConfig{")
+	g.P("Enabled: enabled != 0,")
+	g.P("Stacks: stacks != 0,")
+	g.P("})")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, pinnedReportName, "encodes the tracked buffers pinned at least olderThanMs milliseconds ago and not yet released with "+releaseName+" as a rpccgo.runtime.v1.PinnedBufferList protobuf message. A non-zero pointer must be released with "+releaseName+".")
+	g.P("//export ", pinnedReportName)
+	g.P("func ", pinnedReportName, "(olderThanMs C.int64_t, reportPtr *C.uintptr_t, reportLen *C.int32_t) C.int32_t {")
+	g.P("if reportPtr == nil || reportLen == nil {")
+	g.P("return -1")
+	g.P("}")
+	g.P("goPtr, goLen, err := rpcruntime.EncodePinnedOutstandingPins(time.Duration(olderThanMs) * time.Millisecond)")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("*reportPtr = C.uintptr_t(goPtr)")
+	g.P("*reportLen = C.int32_t(goLen)")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, panicReportsConfigureName, "configures how panics recovered at cgo exports, callback receive loops and stream handler goroutines are reported. A recovered panic becomes an error with RPCCGO_CODE_INTERNAL; a non-zero stacks appends the Go stack to its text. A non-nil onPanic receives the same text on the panicking thread before the error is returned; a non-zero text pointer must be released with "+releaseName+". A nil onPanic stops reporting.")
+	g.P("//export ", panicReportsConfigureName)
+	g.P("func ", panicReportsConfigureName, "(stacks C.int32_t, onPanic C.rpccgo_panic_callback) C.int32_t {")
+	g.P("config := rpcruntime.PanicReportConfig{Stacks: stacks != 0}")
+	g.P("if onPanic != nil {")
+	g.P("config.OnPanic = func(panicErr *rpcruntime.PanicError) {")
+	g.P("text := panicErr.Error()")
+	g.P("_, ptr, err := rpcruntime.PinString(text)")
+	g.P("if err != nil {")
+	g.P("return")
+	g.P("}")
+	g.P("C.rpccgo_call_panic_callback(onPanic, C.uintptr_t(ptr), C.int32_t(len(text)))")
+	g.P("}")
+	g.P("}")
+	g.P("rpcruntime.ConfigurePanicReports(config)")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, streamSessionsListName, "encodes the active stream sessions as a rpccgo.runtime.v1.StreamSessionList protobuf message. A non-zero pointer must be released with "+releaseName+".")
+	g.P("//export ", streamSessionsListName)
+	g.P("func ", streamSessionsListName, "(listPtr *C.uintptr_t, listLen *C.int32_t) C.int32_t {")
+	g.P("if listPtr == nil || listLen == nil {")
+	g.P("return -1")
+	g.P("}")
+	g.P("goPtr, goLen, err := rpcruntime.EncodePinnedStreamSessions()")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("*listPtr = C.uintptr_t(goPtr)")
+	g.P("*listLen = C.int32_t(goLen)")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, streamSessionCancelName, "cancels an active stream session of any method and releases its handle. A callback receive stream still delivers onDone.")
+	g.P("//export ", streamSessionCancelName)
+	g.P("func ", streamSessionCancelName, "(stream C.int32_t) C.int32_t {")
+	g.P("if err := rpcruntime.CancelStreamSession(context.Background(), rpcruntime.StreamHandle(stream)); err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, streamSessionsCancelIdleName, "cancels every stream session without activity for at least idleMs milliseconds. canceled receives the number of sessions canceled.")
+	g.P("//export ", streamSessionsCancelIdleName)
+	g.P("func ", streamSessionsCancelIdleName, "(idleMs C.int64_t, canceled *C.int32_t) C.int32_t {")
+	g.P("handles, err := rpcruntime.CancelIdleStreamSessions(context.Background(), time.Duration(idleMs)*time.Millisecond)")
+	g.P("if canceled != nil {")
+	g.P("*canceled = C.int32_t(len(handles))")
+	g.P("}")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, streamSessionsSetIdleTimeoutName, "starts a background reaper that cancels stream sessions idle for at least timeoutMs milliseconds. A non-positive timeoutMs stops it.")
+	g.P("//export ", streamSessionsSetIdleTimeoutName)
+	g.P("func ", streamSessionsSetIdleTimeoutName, "(timeoutMs C.int64_t) C.int32_t {")
+	g.P("rpcruntime.SetStreamIdleTimeout(time.Duration(timeoutMs) * time.Millisecond)")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, streamBufferingSetName, "overrides the generated queue sizes and send mode of the in-process streams of a method, named by its full protobuf name. A non-zero pipelinedSend lets Send return once the value is queued instead of waiting for the peer. Streams started afterwards use the override.")
+	g.P("//export ", streamBufferingSetName)
+	g.P("func ", streamBufferingSetName, "(method *C.char, methodLen C.int32_t, requestBuffer C.int32_t, responseBuffer C.int32_t, pipelinedSend C.int32_t) C.int32_t {")
+	renderCGOStreamBufferingMethod(g)
+	g.P("err = rpcruntime.SetStreamBuffering(name, rpcruntime.StreamBuffering{")
+	g.P("RequestBuffer: int(requestBuffer),")
+	g.P("ResponseBuffer: int(responseBuffer),")
+	g.P("PipelinedSend: pipelinedSend != 0,")
+	g.P("})")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, streamBufferingClearName, "removes the stream buffering override of a method, restoring the generated buffering for streams started afterwards.")
+	g.P("//export ", streamBufferingClearName)
+	g.P("func ", streamBufferingClearName, "(method *C.char, methodLen C.int32_t) C.int32_t {")
+	renderCGOStreamBufferingMethod(g)
+	g.P("rpcruntime.ClearStreamBuffering(name)")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, concurrencyLimitSetName, "bounds the unary invokes and active stream sessions of a method, named by its full protobuf name, or of every method of a service when method is empty. Zero leaves a kind of call unlimited. An excess call waits up to queueTimeoutMs milliseconds for a slot, then fails with RPCCGO_CODE_RESOURCE_EXHAUSTED; zero fails it fast.")
+	g.P("//export ", concurrencyLimitSetName)
+	g.P("func ", concurrencyLimitSetName, "(serviceID *C.char, serviceIDLen C.int32_t, method *C.char, methodLen C.int32_t, maxConcurrentUnary C.int32_t, maxActiveStreams C.int32_t, queueTimeoutMs C.int64_t) C.int32_t {")
+	renderCGOConcurrencyScope(g)
+	g.P("err = rpcruntime.SetConcurrencyLimit(scope, rpcruntime.ConcurrencyLimit{")
+	g.P("MaxConcurrentUnary: int(maxConcurrentUnary),")
+	g.P("MaxActiveStreams: int(maxActiveStreams),")
+	g.P("QueueTimeout: time.Duration(queueTimeoutMs) * time.Millisecond,")
+	g.P("})")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, concurrencyLimitClearName, "removes the concurrency limit of a method, or of a service when method is empty. Queued calls proceed.")
+	g.P("//export ", concurrencyLimitClearName)
+	g.P("func ", concurrencyLimitClearName, "(serviceID *C.char, serviceIDLen C.int32_t, method *C.char, methodLen C.int32_t) C.int32_t {")
+	renderCGOConcurrencyScope(g)
+	g.P("rpcruntime.ClearConcurrencyLimit(scope)")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, concurrencySnapshotName, "encodes the configured concurrency limits with their active and queued calls as a rpccgo.runtime.v1.ConcurrencySnapshot protobuf message. A non-zero pointer must be released with "+releaseName+".")
+	g.P("//export ", concurrencySnapshotName)
+	g.P("func ", concurrencySnapshotName, "(snapshotPtr *C.uintptr_t, snapshotLen *C.int32_t) C.int32_t {")
+	g.P("if snapshotPtr == nil || snapshotLen == nil {")
+	g.P("return -1")
+	g.P("}")
+	g.P("goPtr, goLen, err := rpcruntime.EncodePinnedConcurrencySnapshot()")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("*snapshotPtr = C.uintptr_t(goPtr)")
+	g.P("*snapshotLen = C.int32_t(goLen)")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, shutdownName, "rejects new unary invokes and stream Starts, cancels every stream session and waits up to timeoutMs milliseconds for in-flight calls and callback receive onDone deliveries. A non-positive timeoutMs waits rpcruntime.DefaultShutdownTimeout (5s). The error text lists what was still pending.")
+	g.P("//export ", shutdownName)
+	g.P("func ", shutdownName, "(timeoutMs C.int64_t) C.int32_t {")
+	g.P("timeout := rpcruntime.DefaultShutdownTimeout")
+	g.P("if timeoutMs > 0 {")
+	g.P("timeout = time.Duration(timeoutMs) * time.Millisecond")
+	g.P("}")
+	g.P("ctx, cancel := context.WithTimeout(context.Background(), timeout)")
+	g.P("defer cancel()")
+	g.P("if err := rpcruntime.Shutdown(ctx); err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, registrationWatchName, "delivers every replace or clear of the registered server of a service to onEvent, in order and on a Go-owned thread. An empty service id watches every service. Kinds are RPCCGO_SERVER_KIND_* values of the first route target; a non-zero service id pointer must be released with "+releaseName+". onDone runs once after "+registrationUnwatchName+" and no callback runs after it.")
+	g.P("//export ", registrationWatchName)
+	g.P("func ", registrationWatchName, "(serviceID *C.char, serviceIDLen C.int32_t, watch *C.int32_t, onEvent C.rpccgo_registration_event_callback, onDone C.rpccgo_registration_done_callback) C.int32_t {")
+	g.P("if watch == nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: registration watch handle pointer is nil")))`)
+	g.P("}")
+	g.P("*watch = 0")
+	g.P("if onEvent == nil || onDone == nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: registration watch callbacks are nil")))`)
+	g.P("}")
+	g.P("length, err := rpcruntime.LengthFromInt32(int32(serviceIDLen))")
+	g.P("if err != nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: registration watch service id: %w", err)))`)
+	g.P("}")
+	g.P("if serviceID == nil && length != 0 {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: registration watch service id pointer is nil")))`)
+	g.P("}")
+	g.P("var id string")
+	g.P("if length != 0 {")
+	g.P("id = string(unsafe.Slice((*byte)(unsafe.Pointer(serviceID)), length))")
+	g.P("}")
+	g.P("onWatchEvent := func(handle rpcruntime.RegistrationWatchHandle, event rpcruntime.RegistrationEvent) {")
+	renderCGOWatchCallbackRecover(g, registrationWatchName+" event")
+	g.P("_, ptr, err := rpcruntime.PinString(string(event.ServiceID))")
+	g.P("if err != nil {")
+	g.P("return")
+	g.P("}")
+	g.P("cleared := C.int32_t(0)")
+	g.P("if event.Cleared {")
+	g.P("cleared = 1")
+	g.P("}")
+	g.P("C.rpccgo_call_registration_event_callback(onEvent, C.int32_t(handle), C.uintptr_t(ptr), C.int32_t(len(event.ServiceID)), C.int32_t(event.OldKind), C.int32_t(event.NewKind), cleared, C.uint64_t(event.Seq))")
+	g.P("}")
+	g.P("onWatchDone := func(handle rpcruntime.RegistrationWatchHandle) {")
+	renderCGOWatchCallbackRecover(g, registrationWatchName+" done")
+	g.P("C.rpccgo_call_registration_done_callback(onDone, C.int32_t(handle))")
+	g.P("}")
+	g.P("handle, err := rpcruntime.NewRegistrationWatch(rpcruntime.ServiceID(id), onWatchEvent, onWatchDone)")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("*watch = C.int32_t(handle)")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, registrationUnwatchName, "cancels a registration watch. Events already queued are still delivered before onDone.")
+	g.P("//export ", registrationUnwatchName)
+	g.P("func ", registrationUnwatchName, "(watch C.int32_t) C.int32_t {")
+	g.P("if err := rpcruntime.CancelRegistrationWatch(rpcruntime.RegistrationWatchHandle(watch)); err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, healthCheckName, "reports whether a service of the default registry has a registered server. status receives RPCCGO_HEALTH_SERVING while a server is registered, unless it reported otherwise with "+healthReportName+", and RPCCGO_HEALTH_NOT_SERVING without one; kind receives the RPCCGO_SERVER_KIND_* value of the first route target. An empty service id reports the runtime as a whole.")
+	g.P("//export ", healthCheckName)
+	g.P("func ", healthCheckName, "(serviceID *C.char, serviceIDLen C.int32_t, status *C.int32_t, kind *C.int32_t) C.int32_t {")
+	g.P("if status == nil || kind == nil {")
+	g.P("return -1")
+	g.P("}")
+	renderCGOServiceIDArg(g, "health")
+	g.P("health := rpcruntime.CheckHealth(rpcruntime.ServiceID(id))")
+	g.P("*status = C.int32_t(health.Status)")
+	g.P("*kind = C.int32_t(health.Kind)")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, healthReportName, "lets the server registered for a service report its own health as RPCCGO_HEALTH_SERVING or RPCCGO_HEALTH_NOT_SERVING. RPCCGO_HEALTH_UNKNOWN drops the report. A report lasts until the service is registered again or cleared.")
+	g.P("//export ", healthReportName)
+	g.P("func ", healthReportName, "(serviceID *C.char, serviceIDLen C.int32_t, status C.int32_t) C.int32_t {")
+	renderCGOServiceIDArg(g, "health")
+	g.P("if err := rpcruntime.ReportHealth(rpcruntime.ServiceID(id), rpcruntime.HealthStatus(status)); err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, healthWatchName, "delivers the health of a service, then every change of it, to onHealth in order and on a Go-owned thread. An empty service id watches every service without an initial delivery. A non-zero service id pointer must be released with "+releaseName+". Cancel the watch with "+registrationUnwatchName+"; onDone runs once after it and no callback runs after onDone.")
+	g.P("//export ", healthWatchName)
+	g.P("func ", healthWatchName, "(serviceID *C.char, serviceIDLen C.int32_t, watch *C.int32_t, onHealth C.rpccgo_health_callback, onDone C.rpccgo_registration_done_callback) C.int32_t {")
+	g.P("if watch == nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: health watch handle pointer is nil")))`)
+	g.P("}")
+	g.P("*watch = 0")
+	g.P("if onHealth == nil || onDone == nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: health watch callbacks are nil")))`)
+	g.P("}")
+	renderCGOServiceIDArg(g, "health")
+	g.P("onWatchHealth := func(handle rpcruntime.RegistrationWatchHandle, health rpcruntime.ServiceHealth) {")
+	renderCGOWatchCallbackRecover(g, healthWatchName+" health")
+	g.P("_, ptr, err := rpcruntime.PinString(string(health.ServiceID))")
+	g.P("if err != nil {")
+	g.P("return")
+	g.P("}")
+	g.P("C.rpccgo_call_health_callback(onHealth, C.int32_t(handle), C.uintptr_t(ptr), C.int32_t(len(health.ServiceID)), C.int32_t(health.Status), C.int32_t(health.Kind))")
+	g.P("}")
+	g.P("onWatchDone := func(handle rpcruntime.RegistrationWatchHandle) {")
+	renderCGOWatchCallbackRecover(g, healthWatchName+" done")
+	g.P("C.rpccgo_call_registration_done_callback(onDone, C.int32_t(handle))")
+	g.P("}")
+	g.P("handle, err := rpcruntime.NewHealthWatch(rpcruntime.ServiceID(id), onWatchHealth, onWatchDone)")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("*watch = C.int32_t(handle)")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, reflectionServicesName, "encodes every service generated into the library, with its .proto file, generated contracts and method count, as a rpccgo.runtime.v1.ServiceList protobuf message. A non-zero pointer must be released with "+releaseName+".")
+	g.P("//export ", reflectionServicesName)
+	g.P("func ", reflectionServicesName, "(servicesPtr *C.uintptr_t, servicesLen *C.int32_t) C.int32_t {")
+	g.P("if servicesPtr == nil || servicesLen == nil {")
+	g.P("return -1")
+	g.P("}")
+	g.P("goPtr, goLen, err := rpcruntime.EncodePinnedServiceList()")
+	renderCGOReflectionPayload(g, "services")
+	renderCGOExportDoc(g, reflectionMethodsName, "encodes the methods of a generated service, with their RPCCGO_METHOD_STREAMING_* shape and request and response message names, as a rpccgo.runtime.v1.MethodList protobuf message. A non-zero pointer must be released with "+releaseName+".")
+	g.P("//export ", reflectionMethodsName)
+	g.P("func ", reflectionMethodsName, "(serviceID *C.char, serviceIDLen C.int32_t, methodsPtr *C.uintptr_t, methodsLen *C.int32_t) C.int32_t {")
+	g.P("if methodsPtr == nil || methodsLen == nil {")
+	g.P("return -1")
+	g.P("}")
+	renderCGOServiceIDArg(g, "reflection")
+	g.P("goPtr, goLen, err := rpcruntime.EncodePinnedMethodList(rpcruntime.ServiceID(id))")
+	renderCGOReflectionPayload(g, "methods")
+	renderCGOExportDoc(g, reflectionFileDescriptorSetName, "serializes a google.protobuf.FileDescriptorSet holding the .proto file of a generated service and every file it imports, dependencies first. An empty service id covers every generated service. A non-zero pointer must be released with "+releaseName+".")
+	g.P("//export ", reflectionFileDescriptorSetName)
+	g.P("func ", reflectionFileDescriptorSetName, "(serviceID *C.char, serviceIDLen C.int32_t, setPtr *C.uintptr_t, setLen *C.int32_t) C.int32_t {")
+	g.P("if setPtr == nil || setLen == nil {")
+	g.P("return -1")
+	g.P("}")
+	renderCGOServiceIDArg(g, "reflection")
+	g.P("goPtr, goLen, err := rpcruntime.EncodePinnedFileDescriptorSet(rpcruntime.ServiceID(id))")
+	renderCGOReflectionPayload(g, "set")
+	renderCGODynamicInvokeExports(g, releaseName)
+	renderCGOExportDoc(g, serverRegistryNewName, "creates an empty server registry isolated from the default one and returns its handle. Select it for calls with "+callOptionsSetRegistryName+".")
+	g.P("//export ", serverRegistryNewName)
+	g.P("func ", serverRegistryNewName, "(registry *C.int32_t) C.int32_t {")
+	g.P("if registry == nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: server registry handle pointer is nil")))`)
+	g.P("}")
+	g.P("*registry = 0")
+	g.P("handle, err := rpcruntime.NewServerRegistryHandle(rpcruntime.NewServerRegistry())")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("*registry = C.int32_t(handle)")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, serverRegistryCopyRouteName, "copies the servers registered for a service in the default registry into a server registry handle, replacing its route. C servers registered through the generated Register exports reach a scoped registry this way.")
+	g.P("//export ", serverRegistryCopyRouteName)
+	g.P("func ", serverRegistryCopyRouteName, "(registry C.int32_t, serviceID *C.char, serviceIDLen C.int32_t) C.int32_t {")
+	g.P("target, err := rpcruntime.LoadServerRegistryHandle(rpcruntime.ServerRegistryHandle(registry))")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("length, err := rpcruntime.LengthFromInt32(int32(serviceIDLen))")
+	g.P("if err != nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: server registry service id: %w", err)))`)
+	g.P("}")
+	g.P("if serviceID == nil && length != 0 {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: server registry service id pointer is nil")))`)
+	g.P("}")
+	g.P("var id string")
+	g.P("if length != 0 {")
+	g.P("id = string(unsafe.Slice((*byte)(unsafe.Pointer(serviceID)), length))")
+	g.P("}")
+	g.P("route, err := rpcruntime.LoadServerRoute(rpcruntime.ServiceID(id))")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("if err := target.RegisterRoute(rpcruntime.ServiceID(id), route); err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, serverRegistryReleaseName, "releases a server registry handle. Call options that selected the registry keep using it.")
+	g.P("//export ", serverRegistryReleaseName)
+	g.P("func ", serverRegistryReleaseName, "(registry C.int32_t) C.int32_t {")
+	g.P("if err := rpcruntime.ReleaseServerRegistryHandle(rpcruntime.ServerRegistryHandle(registry)); err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, callOptionsNewName, "creates a call options handle for the WithOptions client exports. A positive timeoutMs bounds each call made with the handle.")
+	g.P("//export ", callOptionsNewName)
+	g.P("func ", callOptionsNewName, "(timeoutMs C.int64_t, options *C.int32_t) C.int32_t {")
+	g.P("if options == nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: call options handle pointer is nil")))`)
+	g.P("}")
+	g.P("*options = 0")
+	g.P("handle, err := rpcruntime.NewCallOptions(time.Duration(timeoutMs) * time.Millisecond)")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("*options = C.int32_t(handle)")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, callOptionsSetDeadlineName, "sets an absolute Unix millisecond deadline shared by later calls made with a call options handle. Zero clears it.")
+	g.P("//export ", callOptionsSetDeadlineName)
+	g.P("func ", callOptionsSetDeadlineName, "(options C.int32_t, deadlineUnixMs C.int64_t) C.int32_t {")
+	g.P("var deadline time.Time")
+	g.P("if deadlineUnixMs != 0 {")
+	g.P("deadline = time.UnixMilli(int64(deadlineUnixMs))")
+	g.P("}")
+	g.P("if err := rpcruntime.SetCallOptionsDeadline(rpcruntime.CallOptionsHandle(options), deadline); err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, callOptionsSetMetadataName, "sets the encoded request metadata sent by later calls made with a call options handle. An empty payload clears it.")
+	g.P("//export ", callOptionsSetMetadataName)
+	g.P("func ", callOptionsSetMetadataName, "(options C.int32_t, metadata *C.char, metadataLen C.int32_t) C.int32_t {")
+	g.P("length, err := rpcruntime.LengthFromInt32(int32(metadataLen))")
+	g.P("if err != nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: cgo metadata: %w", err)))`)
+	g.P("}")
+	g.P("if metadata == nil && length != 0 {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: cgo metadata pointer is nil")))`)
+	g.P("}")
+	g.P("var data []byte")
+	g.P("if length != 0 {")
+	g.P("data = unsafe.Slice((*byte)(unsafe.Pointer(metadata)), length)")
+	g.P("}")
+	g.P("md, err := rpcruntime.DecodeMetadata(data)")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("if err := rpcruntime.SetCallOptionsMetadata(rpcruntime.CallOptionsHandle(options), md); err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, callOptionsSetRegistryName, "routes later calls made with a call options handle, and the stream operations on streams they start, through a server registry handle. Zero selects the default registry.")
+	g.P("//export ", callOptionsSetRegistryName)
+	g.P("func ", callOptionsSetRegistryName, "(options C.int32_t, registry C.int32_t) C.int32_t {")
+	g.P("var target *rpcruntime.ServerRegistry")
+	g.P("if registry != 0 {")
+	g.P("var err error")
+	g.P("target, err = rpcruntime.LoadServerRegistryHandle(rpcruntime.ServerRegistryHandle(registry))")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("}")
+	g.P("if err := rpcruntime.SetCallOptionsServerRegistry(rpcruntime.CallOptionsHandle(options), target); err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, callOptionsTakeResponseMetadataName, "takes the encoded response headers and trailers of the most recent call made with a call options handle. Non-zero pointers must be released with "+releaseName+".")
+	g.P("//export ", callOptionsTakeResponseMetadataName)
+	g.P("func ", callOptionsTakeResponseMetadataName, "(options C.int32_t, headerPtr *C.uintptr_t, headerLen *C.int32_t, trailerPtr *C.uintptr_t, trailerLen *C.int32_t) C.int32_t {")
+	g.P("if headerPtr == nil || headerLen == nil || trailerPtr == nil || trailerLen == nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: response metadata output pointer is nil")))`)
+	g.P("}")
+	g.P("*headerPtr, *headerLen, *trailerPtr, *trailerLen = 0, 0, 0, 0")
+	g.P("header, trailer, err := rpcruntime.TakeCallOptionsResponseMetadata(rpcruntime.CallOptionsHandle(options))")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("goHeaderPtr, goHeaderLen, err := rpcruntime.EncodePinnedMetadata(header)")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("goTrailerPtr, goTrailerLen, err := rpcruntime.EncodePinnedMetadata(trailer)")
+	g.P("if err != nil {")
+	g.P("rpcruntime.Release(goHeaderPtr)")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("*headerPtr = C.uintptr_t(goHeaderPtr)")
+	g.P("*headerLen = C.int32_t(goHeaderLen)")
+	g.P("*trailerPtr = C.uintptr_t(goTrailerPtr)")
+	g.P("*trailerLen = C.int32_t(goTrailerLen)")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, callOptionsCancelName, "cancels every in-flight call and stream started with a call options handle.")
+	g.P("//export ", callOptionsCancelName)
+	g.P("func ", callOptionsCancelName, "(options C.int32_t) C.int32_t {")
+	g.P("if err := rpcruntime.CancelCallOptions(rpcruntime.CallOptionsHandle(options)); err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, callbackTraceParentName, "copies the W3C traceparent of the Go call that invoked the current cgo server Unary or stream Start callback. traceParentLen receives 0 when the call has no trace context; the value is copied only when bufLen is at least RPCCGO_TRACEPARENT_LEN.")
+	g.P("//export ", callbackTraceParentName)
+	g.P("func ", callbackTraceParentName, "(buf *C.char, bufLen C.int32_t, traceParentLen *C.int32_t) C.int32_t {")
+	g.P("if traceParentLen == nil {")
+	g.P("return -1")
+	g.P("}")
+	g.P("var value [C.RPCCGO_TRACEPARENT_LEN]byte")
+	g.P("length := C.rpccgo_get_callback_trace_parent((*C.char)(unsafe.Pointer(&value[0])))")
+	g.P("*traceParentLen = length")
+	g.P("if length != 0 && buf != nil && bufLen >= length {")
+	g.P("copy(unsafe.Slice((*byte)(unsafe.Pointer(buf)), int(length)), value[:length])")
+	g.P("}")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+	g.P("// ", cgoEnterCallbackTraceName, " exposes the trace parent of ctx to ", callbackTraceParentName, " on the")
+	g.P("// calling thread until the returned leave func runs.")
+	g.P("func ", cgoEnterCallbackTraceName, "(ctx context.Context) func() {")
+	g.P("sc := rpcruntime.SpanContextFromContext(ctx)")
+	g.P("if !sc.IsValid() {")
+	g.P("return func() {}")
+	g.P("}")
+	g.P("value := []byte(sc.TraceParent())")
+	g.P("goruntime.LockOSThread()")
+	g.P("var previous [C.RPCCGO_TRACEPARENT_LEN]byte")
+	g.P("previousLen := C.rpccgo_get_callback_trace_parent((*C.char)(unsafe.Pointer(&previous[0])))")
+	g.P("C.rpccgo_set_callback_trace_parent((*C.char)(unsafe.Pointer(&value[0])), C.int32_t(len(value)))")
+	g.P("return func() {")
+	g.P("C.rpccgo_set_callback_trace_parent((*C.char)(unsafe.Pointer(&previous[0])), previousLen)")
+	g.P("goruntime.UnlockOSThread()")
+	g.P("}")
+	g.P("}")
+	g.P()
+	renderCGOExportDoc(g, callOptionsReleaseName, "cancels and releases a call options handle.")
+	g.P("//export ", callOptionsReleaseName)
+	g.P("func ", callOptionsReleaseName, "(options C.int32_t) C.int32_t {")
+	g.P("if err := rpcruntime.ReleaseCallOptions(rpcruntime.CallOptionsHandle(options)); err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("return 0")
+	g.P("}")
+}
+
+// cgoServerKindNames lists the RPCCGO_SERVER_KIND_* macro suffixes in
+// rpcruntime.ServerKind order.
+var cgoServerKindNames = []string{
+	"INVALID",
+	"GO_NATIVE",
+	"CGO_NATIVE",
+	"CGO_MESSAGE",
+	"CONNECT",
+	"GRPC",
+	"CONNECT_REMOTE",
+	"GRPC_REMOTE",
+}
+
+// cgoErrorCodeNames lists the RPCCGO_CODE_* macro suffixes in google.rpc.Code order.
+var cgoErrorCodeNames = []string{
+	"OK",
+	"CANCELED",
+	"UNKNOWN",
+	"INVALID_ARGUMENT",
+	"DEADLINE_EXCEEDED",
+	"NOT_FOUND",
+	"ALREADY_EXISTS",
+	"PERMISSION_DENIED",
+	"RESOURCE_EXHAUSTED",
+	"FAILED_PRECONDITION",
+	"ABORTED",
+	"OUT_OF_RANGE",
+	"UNIMPLEMENTED",
+	"INTERNAL",
+	"UNAVAILABLE",
+	"DATA_LOSS",
+	"UNAUTHENTICATED",
+}
+
+func renderCGOStreamBufferingMethod(g *protogen.GeneratedFile) {
+	g.P("length, err := rpcruntime.LengthFromInt32(int32(methodLen))")
+	g.P("if err != nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: stream buffering method: %w", err)))`)
+	g.P("}")
+	g.P("if method == nil || length == 0 {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: stream buffering method is empty")))`)
+	g.P("}")
+	g.P("name := string(unsafe.Slice((*byte)(unsafe.Pointer(method)), length))")
+}
+
+func renderCGOConcurrencyScope(g *protogen.GeneratedFile) {
+	g.P("serviceIDLength, err := rpcruntime.LengthFromInt32(int32(serviceIDLen))")
+	g.P("if err != nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: concurrency limit service id: %w", err)))`)
+	g.P("}")
+	g.P("methodLength, err := rpcruntime.LengthFromInt32(int32(methodLen))")
+	g.P("if err != nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: concurrency limit method: %w", err)))`)
+	g.P("}")
+	g.P("if (serviceID == nil && serviceIDLength != 0) || (method == nil && methodLength != 0) {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: concurrency limit name pointer is nil")))`)
+	g.P("}")
+	g.P("var scope rpcruntime.ConcurrencyScope")
+	g.P("if serviceIDLength != 0 {")
+	g.P("scope.ServiceID = rpcruntime.ServiceID(unsafe.Slice((*byte)(unsafe.Pointer(serviceID)), serviceIDLength))")
+	g.P("}")
+	g.P("if methodLength != 0 {")
+	g.P("scope.Method = string(unsafe.Slice((*byte)(unsafe.Pointer(method)), methodLength))")
+	g.P("}")
+}
+
+func renderCGOServiceIDArg(g *protogen.GeneratedFile, label string) {
+	g.P("length, err := rpcruntime.LengthFromInt32(int32(serviceIDLen))")
+	g.P("if err != nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: `, label, ` service id: %w", err)))`)
+	g.P("}")
+	g.P("if serviceID == nil && length != 0 {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: `, label, ` service id pointer is nil")))`)
+	g.P("}")
+	g.P("var id string")
+	g.P("if length != 0 {")
+	g.P("id = string(unsafe.Slice((*byte)(unsafe.Pointer(serviceID)), length))")
+	g.P("}")
+}
+
+// renderCGOReflectionPayload writes the pinned payload held in goPtr and goLen
+// to the <name>Ptr and <name>Len out parameters of a reflection export.
+func renderCGOReflectionPayload(g *protogen.GeneratedFile, name string) {
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("*", name, "Ptr = C.uintptr_t(goPtr)")
+	g.P("*", name, "Len = C.int32_t(goLen)")
+	g.P("return 0")
+	g.P("}")
+	g.P()
+}
+
+// renderCGOWatchCallbackRecover keeps a panic in a watch delivery from
+// unwinding through the C frames of the host; the panic is reported and the
+// delivery dropped, like the stream callback receive loops do.
+func renderCGOWatchCallbackRecover(g *protogen.GeneratedFile, where string) {
+	g.P("defer rpcruntime.RecoverPanic(", strconv.Quote(where), ", func(error) {})")
 }
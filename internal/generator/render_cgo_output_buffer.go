@@ -0,0 +1,32 @@
+package generator
+
+import "google.golang.org/protobuf/compiler/protogen"
+
+// outputBufferParams returns the trailing parameters of an Into client export:
+// the caller buffer, its capacity and the length output.
+func outputBufferParams(lenName string) string {
+	return "bufferPtr unsafe.Pointer, bufferCap C.int32_t, " + lenName + " *C.int32_t"
+}
+
+// renderOutputBufferOpen clears the length output of an Into export and wraps
+// the caller buffer as buffer.
+func renderOutputBufferOpen(g *protogen.GeneratedFile, lenName, nilMessage string) {
+	g.P("if ", lenName, " != nil {")
+	g.P("*", lenName, " = 0")
+	g.P("}")
+	g.P("if ", lenName, " == nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(errors.New("`, nilMessage, `")))`)
+	g.P("}")
+	g.P("buffer, bufferErr := rpcruntime.NewOutputBuffer(bufferPtr, int32(bufferCap))")
+	g.P("if bufferErr != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(bufferErr))")
+	g.P("}")
+}
+
+// renderOutputBufferLenCommit reports the bytes used, or needed after an
+// overflow, through the length output of an Into export.
+func renderOutputBufferLenCommit(g *protogen.GeneratedFile, lenName string) {
+	g.P("if length, err := buffer.Len(); err == nil {")
+	g.P("*", lenName, " = C.int32_t(length)")
+	g.P("}")
+}
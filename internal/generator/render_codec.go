@@ -28,6 +28,9 @@ func renderCodecFile(plugin *protogen.Plugin, plan FilePlan, service ServicePlan
 	g.P()
 	g.P("import (")
 	g.P(`errors "errors"`)
+	if codecNeedsFmt(service) {
+		g.P(`fmt "fmt"`)
+	}
 	if codecNeedsGoRuntime(service) {
 		g.P(`goruntime "runtime"`)
 	}
@@ -42,11 +45,58 @@ func renderCodecFile(plugin *protogen.Plugin, plan FilePlan, service ServicePlan
 	g.P("// rpccgo native message codec generated file for ", service.GoName)
 	g.P()
 
+	for _, method := range service.Methods {
+		renderCodecOneofCaseTypes(g, service, method)
+	}
 	for _, method := range service.Methods {
 		renderCodecMethodStubs(g, service, method)
 	}
 }
 
+// renderCodecOneofCaseTypes declares the typed case discriminant of every
+// oneof in the method's request and response. Case values are the field
+// numbers of the members, so they match the C ABI int32 slot.
+func renderCodecOneofCaseTypes(g *protogen.GeneratedFile, service ServicePlan, method MethodPlan) {
+	directions := []struct {
+		label  string
+		fields []FieldPlan
+	}{
+		{label: "requests", fields: method.Contract.Native.RequestFields},
+		{label: "responses", fields: method.Contract.Native.ResponseFields},
+	}
+	for _, direction := range directions {
+		for _, field := range direction.fields {
+			if field.Oneof.Role != OneofRoleCase {
+				continue
+			}
+			typeName := field.EnumType.GoName
+			renderDoc(g, typeName, "identifies the member set in oneof "+field.FullName+" of "+service.GoName+"."+method.GoName+" "+direction.label+".")
+			g.P("type ", typeName, " int32")
+			g.P()
+			g.P("const (")
+			g.P(typeName, "NotSet ", typeName, " = 0")
+			for _, member := range field.Oneof.Cases {
+				g.P(typeName, member.GoName, " ", typeName, " = ", member.Number)
+			}
+			g.P(")")
+			g.P()
+			renderDoc(g, "String", "returns the protobuf name of the member, or the case number when it is unknown.")
+			g.P("func (c ", typeName, ") String() string {")
+			g.P("switch c {")
+			g.P("case ", typeName, "NotSet:")
+			g.P(`return "not_set"`)
+			for _, member := range field.Oneof.Cases {
+				g.P("case ", typeName, member.GoName, ":")
+				g.P(`return "`, member.Name, `"`)
+			}
+			g.P("}")
+			g.P(`return fmt.Sprintf("`, typeName, `(%d)", int32(c))`)
+			g.P("}")
+			g.P()
+		}
+	}
+}
+
 func codecNeedsRuntime(service ServicePlan) bool {
 	for _, method := range service.Methods {
 		for _, field := range append(method.Contract.Native.RequestFields, method.Contract.Native.ResponseFields...) {
@@ -58,6 +108,22 @@ func codecNeedsRuntime(service ServicePlan) bool {
 	return false
 }
 
+// codecNeedsFmt reports whether a method converts message fields, whose
+// errors are wrapped with the field name, or declares oneof case types.
+func codecNeedsFmt(service ServicePlan) bool {
+	for _, method := range service.Methods {
+		for _, field := range append(method.Contract.Native.RequestFields, method.Contract.Native.ResponseFields...) {
+			if field.Kind == FieldKindMessage {
+				return true
+			}
+			if field.Oneof.Role == OneofRoleCase {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func codecNeedsGoRuntime(service ServicePlan) bool {
 	for _, method := range service.Methods {
 		if codecNativeRequestNeedsKeepAlive(method.Contract.Native.RequestFields) {
@@ -158,24 +224,52 @@ func renderCodecMessageToNativeRequestValues(g *protogen.GeneratedFile, fields [
 		msgField := "msg." + field.GoName
 		rawName := lowerInitial(field.GoName) + "Raw"
 		g.P("var ", name, " ", nativeGoRequestFieldType(g, field))
-		switch field.Kind {
-		case FieldKindString:
-			g.P("if ", msgField, " != \"\" {")
-			g.P(name, ", err = rpcruntime.NewRpcStringChecked(unsafe.StringData(", msgField, "), int32(len(", msgField, ")), false)")
-			g.P("if err != nil {")
-			g.P("return ", codecMessageToNativeRequestZeroReturns(fields, "reqOwner", "err"))
-			g.P("}")
-			g.P("} else {")
-			g.P(name, " = rpcruntime.EmptyRpcString()")
-			g.P("}")
-		case FieldKindBytes, FieldKindMessage:
-			g.P("if len(", msgField, ") > 0 {")
-			g.P(name, ", err = rpcruntime.NewRpcBytesChecked(unsafe.SliceData(", msgField, "), int32(len(", msgField, ")), false)")
-			g.P("if err != nil {")
-			g.P("return ", codecMessageToNativeRequestZeroReturns(fields, "reqOwner", "err"))
+		if field.MapEntry.Role != "" {
+			renderCodecMessageMapToNativeRequestValues(g, fields, field)
+			continue
+		}
+		switch field.Oneof.Role {
+		case OneofRoleCase:
+			renderCodecMessageOneofCase(g, field, "msg")
+			continue
+		case OneofRoleMember:
+			msgField = "msg.Get" + field.GoName + "()"
+			if field.Kind == FieldKindMessage {
+				msgField = renderCodecMarshalRequestMessage(g, fields, field, msgField)
+			}
+		}
+		if field.Native.Shape == NativeABIShapeBufferArray {
+			elems := msgField
+			if field.Kind == FieldKindMessage {
+				elems = name + "Encoded"
+				g.P(elems, ", err := rpcruntime.MarshalMessages(", msgField, ")")
+				g.P("if err != nil {")
+				g.P("return ", codecMessageToNativeRequestZeroReturns(fields, "reqOwner", "err"))
+				g.P("}")
+			}
+			g.P(rawName, " := make([]rpcruntime.RpcBuffer, len(", elems, "))")
+			g.P("reqOwner = append(reqOwner, ", rawName, ")")
+			g.P("for i := range ", elems, " {")
+			g.P(rawName, "[i] = ", codecRequestRawValue(field, elems+"[i]"))
 			g.P("}")
-			g.P("} else {")
-			g.P(name, " = rpcruntime.EmptyRpcBytes()")
+			renderCodecRequestRepeatWrap(g, fields, field)
+			continue
+		}
+		switch field.Kind {
+		case FieldKindString, FieldKindBytes, FieldKindMessage:
+			if !field.Presence {
+				renderCodecRequestTextWrap(g, fields, field, msgField)
+				continue
+			}
+			// An unset string, bytes or message field stays a nil wrapper.
+			g.P("if ", msgField, " != nil {")
+			switch field.Kind {
+			case FieldKindString:
+				msgField = "*" + msgField
+			case FieldKindMessage:
+				msgField = renderCodecMarshalRequestMessage(g, fields, field, msgField)
+			}
+			renderCodecRequestTextWrap(g, fields, field, msgField)
 			g.P("}")
 		case FieldKindBool:
 			if field.Repeated {
@@ -232,6 +326,131 @@ func renderCodecMessageToNativeRequestValues(g *protogen.GeneratedFile, fields [
 	}
 }
 
+// renderCodecMarshalRequestMessage encodes a singular request message, keeps
+// the encoding alive in reqOwner and returns the name of the encoded bytes.
+func renderCodecMarshalRequestMessage(g *protogen.GeneratedFile, fields []FieldPlan, field FieldPlan, value string) string {
+	encoded := lowerInitial(field.GoName) + "Encoded"
+	g.P(encoded, ", err := rpcruntime.MarshalMessage(", value, ")")
+	g.P("if err != nil {")
+	g.P("return ", codecMessageToNativeRequestZeroReturns(fields, "reqOwner", "err"))
+	g.P("}")
+	g.P("reqOwner = append(reqOwner, ", encoded, ")")
+	return encoded
+}
+
+// renderCodecRequestTextWrap wraps a singular string or bytes request value,
+// borrowed from msg, in its native wrapper.
+func renderCodecRequestTextWrap(g *protogen.GeneratedFile, fields []FieldPlan, field FieldPlan, value string) {
+	name := lowerInitial(field.GoName)
+	if field.Kind == FieldKindString {
+		g.P("if ", value, " != \"\" {")
+		g.P(name, ", err = rpcruntime.NewRpcStringChecked(unsafe.StringData(", value, "), int32(len(", value, ")), false)")
+		g.P("if err != nil {")
+		g.P("return ", codecMessageToNativeRequestZeroReturns(fields, "reqOwner", "err"))
+		g.P("}")
+		g.P("} else {")
+		g.P(name, " = rpcruntime.EmptyRpcString()")
+		g.P("}")
+		return
+	}
+	g.P("if len(", value, ") > 0 {")
+	g.P(name, ", err = rpcruntime.NewRpcBytesChecked(unsafe.SliceData(", value, "), int32(len(", value, ")), false)")
+	g.P("if err != nil {")
+	g.P("return ", codecMessageToNativeRequestZeroReturns(fields, "reqOwner", "err"))
+	g.P("}")
+	g.P("} else {")
+	g.P(name, " = rpcruntime.EmptyRpcBytes()")
+	g.P("}")
+}
+
+// renderCodecMessageMapToNativeRequestValues fills both halves of a map field
+// from one range over the map, so entry i of the keys pairs with entry i of the
+// values. The key half renders the shared loop.
+func renderCodecMessageMapToNativeRequestValues(g *protogen.GeneratedFile, fields []FieldPlan, field FieldPlan) {
+	name := lowerInitial(field.GoName)
+	rawName := name + "Raw"
+	if field.MapEntry.Role == MapEntryRoleKey {
+		value := codecMapValueField(fields, field)
+		valueRawName := lowerInitial(value.GoName) + "Raw"
+		msgField := "msg." + field.MapEntry.GoName
+		g.P(rawName, " := make([]", codecRequestRawType(g, field), ", 0, len(", msgField, "))")
+		g.P(valueRawName, " := make([]", codecRequestRawType(g, value), ", 0, len(", msgField, "))")
+		g.P("for key, value := range ", msgField, " {")
+		g.P(rawName, " = append(", rawName, ", ", codecRequestRawValue(field, "key"), ")")
+		g.P(valueRawName, " = append(", valueRawName, ", ", codecRequestRawValue(value, "value"), ")")
+		g.P("}")
+		g.P("reqOwner = append(reqOwner, ", rawName, ", ", valueRawName, ")")
+	}
+	renderCodecRequestRepeatWrap(g, fields, field)
+}
+
+// renderCodecRequestRepeatWrap wraps the raw element slice of a repeated
+// request field, borrowed from reqOwner, in its native repeat wrapper.
+func renderCodecRequestRepeatWrap(g *protogen.GeneratedFile, fields []FieldPlan, field FieldPlan) {
+	name := lowerInitial(field.GoName)
+	rawName := name + "Raw"
+	g.P("if len(", rawName, ") > 0 {")
+	switch {
+	case field.Native.Shape == NativeABIShapeBufferArray:
+		wrapper, typeArgs := nativeBufferArrayWrapper(g, field)
+		g.P(name, ", err = rpcruntime.New", wrapper, "Checked", typeArgs, "(unsafe.SliceData(", rawName, "), int32(len(", rawName, ")), false)")
+	case field.Kind == FieldKindBool:
+		g.P(name, ", err = rpcruntime.NewRpcBoolRepeatChecked(unsafe.SliceData(", rawName, "), int32(len(", rawName, ")), false)")
+	default:
+		g.P(name, ", err = rpcruntime.NewRpcRepeatChecked[", nativeGoRequestRepeatElemType(g, field), "](unsafe.SliceData(", rawName, "), int32(len(", rawName, ")), false)")
+	}
+	g.P("if err != nil {")
+	g.P("return ", codecMessageToNativeRequestZeroReturns(fields, "reqOwner", "err"))
+	g.P("}")
+	g.P("} else {")
+	switch {
+	case field.Native.Shape == NativeABIShapeBufferArray:
+		wrapper, typeArgs := nativeBufferArrayWrapper(g, field)
+		g.P(name, " = rpcruntime.Empty", wrapper, typeArgs, "()")
+	case field.Kind == FieldKindBool:
+		g.P(name, " = rpcruntime.EmptyRpcBoolRepeat()")
+	default:
+		g.P(name, " = rpcruntime.EmptyRpcRepeat[", nativeGoRequestRepeatElemType(g, field), "]()")
+	}
+	g.P("}")
+}
+
+func codecRequestRawType(g *protogen.GeneratedFile, field FieldPlan) string {
+	switch {
+	case field.Native.Shape == NativeABIShapeBufferArray:
+		return "rpcruntime.RpcBuffer"
+	case field.Kind == FieldKindBool:
+		return "byte"
+	}
+	return nativeGoRequestRepeatElemType(g, field)
+}
+
+func codecRequestRawValue(field FieldPlan, value string) string {
+	switch field.Kind {
+	case FieldKindString:
+		return "rpcruntime.StringBuffer(" + value + ")"
+	case FieldKindBytes, FieldKindMessage:
+		return "rpcruntime.BytesBuffer(" + value + ")"
+	case FieldKindBool:
+		return "rpcruntime.BoolByte(" + value + ")"
+	case FieldKindEnum:
+		return "int32(" + value + ")"
+	default:
+		return value
+	}
+}
+
+// codecMapValueField returns the value half that pairs with the key half of a
+// lowered map field.
+func codecMapValueField(fields []FieldPlan, key FieldPlan) FieldPlan {
+	for _, field := range fields {
+		if field.MapEntry.GoName == key.MapEntry.GoName && field.MapEntry.Role == MapEntryRoleValue {
+			return field
+		}
+	}
+	return FieldPlan{}
+}
+
 func codecNativeRequestNeedsKeepAlive(fields []FieldPlan) bool {
 	for _, field := range fields {
 		if field.Kind == FieldKindString || field.Kind == FieldKindBytes || field.Kind == FieldKindMessage || field.Repeated {
@@ -241,9 +460,57 @@ func codecNativeRequestNeedsKeepAlive(fields []FieldPlan) bool {
 	return false
 }
 
-func renderCodecMessageToNativeValues(g *protogen.GeneratedFile, fields []FieldPlan, msgName, returnNames, _ string) {
+func renderCodecMessageToNativeValues(g *protogen.GeneratedFile, fields []FieldPlan, msgName, returnNames, errZero string) {
 	for _, field := range fields {
 		name := lowerInitial(field.GoName)
+		switch field.Oneof.Role {
+		case OneofRoleCase:
+			g.P("var ", name, " ", nativeGoResponseFieldType(g, field))
+			renderCodecMessageOneofCase(g, field, "msg")
+			continue
+		case OneofRoleMember:
+			if field.Kind == FieldKindMessage {
+				renderCodecMarshalResponseMessage(g, field, name, "msg.Get"+field.GoName+"()", errZero)
+				continue
+			}
+			g.P(name, " := msg.Get", field.GoName, "()")
+			continue
+		}
+		if field.Native.Shape == NativeABIShapeBufferArray && field.Kind == FieldKindMessage {
+			g.P(name, ", err := rpcruntime.MarshalMessages(msg.", field.GoName, ")")
+			g.P("if err != nil {")
+			g.P(`err = fmt.Errorf("`, field.FullName, `: %w", err)`)
+			g.P("return ", errZero)
+			g.P("}")
+			continue
+		}
+		if field.MapEntry.Role == MapEntryRoleKey {
+			value := codecMapValueField(fields, field)
+			valueName := lowerInitial(value.GoName)
+			msgField := "msg." + field.MapEntry.GoName
+			g.P(name, " := make(", nativeGoResponseFieldType(g, field), ", 0, len(", msgField, "))")
+			g.P(valueName, " := make(", nativeGoResponseFieldType(g, value), ", 0, len(", msgField, "))")
+			g.P("for key, value := range ", msgField, " {")
+			g.P(name, " = append(", name, ", key)")
+			g.P(valueName, " = append(", valueName, ", value)")
+			g.P("}")
+			continue
+		}
+		if field.MapEntry.Role == MapEntryRoleValue {
+			continue
+		}
+		if field.Kind == FieldKindMessage {
+			// A set message stays non-nil even when it encodes to no bytes.
+			g.P("var ", name, " []byte")
+			g.P("if msg.", field.GoName, " != nil {")
+			renderCodecMarshalResponseMessage(g, field, name+"Encoded", "msg."+field.GoName, errZero)
+			g.P(name, " = ", name, "Encoded")
+			g.P("if ", name, " == nil {")
+			g.P(name, " = []byte{}")
+			g.P("}")
+			g.P("}")
+			continue
+		}
 		switch field.Kind {
 		case FieldKindString:
 			g.P(name, " := msg.", field.GoName)
@@ -270,9 +537,48 @@ func renderCodecMessageToNativeValues(g *protogen.GeneratedFile, fields []FieldP
 	}
 }
 
+// renderCodecMarshalResponseMessage declares target as the encoding of a
+// singular response message.
+func renderCodecMarshalResponseMessage(g *protogen.GeneratedFile, field FieldPlan, target, value, errZero string) {
+	g.P(target, ", err := rpcruntime.MarshalMessage(", value, ")")
+	g.P("if err != nil {")
+	g.P(`err = fmt.Errorf("`, field.FullName, `: %w", err)`)
+	g.P("return ", errZero)
+	g.P("}")
+}
+
 func renderCodecNativeValuesToMessage(g *protogen.GeneratedFile, fields []FieldPlan, msgName string) {
 	for _, field := range fields {
 		name := lowerInitial(field.GoName)
+		switch field.Oneof.Role {
+		case OneofRoleCase:
+			renderCodecNativeOneofToMessage(g, fields, field, msgName, false)
+			continue
+		case OneofRoleMember:
+			continue
+		}
+		if field.MapEntry.Role == MapEntryRoleKey {
+			value := codecMapValueField(fields, field)
+			renderCodecNativeMapToMessage(g, field, value, msgName, name, lowerInitial(value.GoName), false)
+			continue
+		}
+		if field.MapEntry.Role == MapEntryRoleValue {
+			continue
+		}
+		if field.Native.Shape == NativeABIShapeBufferArray && field.Kind == FieldKindMessage {
+			g.P(name, "Messages, err := rpcruntime.UnmarshalMessages[", nativeGoFieldMessageType(g, field), "](", name, ")")
+			g.P("if err != nil {")
+			g.P(`return nil, fmt.Errorf("`, field.FullName, `: %w", err)`)
+			g.P("}")
+			g.P(msgName, ".", field.GoName, " = ", name, "Messages")
+			continue
+		}
+		if field.Kind == FieldKindMessage {
+			g.P("if ", name, " != nil {")
+			g.P(msgName, ".", field.GoName, " = ", renderCodecUnmarshalMessage(g, field, name))
+			g.P("}")
+			continue
+		}
 		switch field.Kind {
 		case FieldKindString:
 			g.P(msgName, ".", field.GoName, " = ", name)
@@ -296,6 +602,40 @@ func renderCodecNativeValuesToMessage(g *protogen.GeneratedFile, fields []FieldP
 func renderCodecNativeRequestValuesToMessage(g *protogen.GeneratedFile, fields []FieldPlan, msgName string) {
 	for _, field := range fields {
 		name := lowerInitial(field.GoName)
+		switch field.Oneof.Role {
+		case OneofRoleCase:
+			renderCodecNativeOneofToMessage(g, fields, field, msgName, true)
+			continue
+		case OneofRoleMember:
+			continue
+		}
+		if field.MapEntry.Role == MapEntryRoleKey {
+			value := codecMapValueField(fields, field)
+			valueName := lowerInitial(value.GoName)
+			g.P(name, "Raw := ", name, codecRequestMapSliceMethod(field))
+			g.P(valueName, "Raw := ", valueName, codecRequestMapSliceMethod(value))
+			renderCodecNativeMapToMessage(g, field, value, msgName, name+"Raw", valueName+"Raw", true)
+			continue
+		}
+		if field.MapEntry.Role == MapEntryRoleValue {
+			continue
+		}
+		if field.Native.Shape == NativeABIShapeBufferArray && field.Kind == FieldKindMessage {
+			g.P(name, "Messages, err := ", name, ".SafeSlice()")
+			g.P("if err != nil {")
+			g.P(`return nil, fmt.Errorf("`, field.FullName, `: %w", err)`)
+			g.P("}")
+			g.P(msgName, ".", field.GoName, " = ", name, "Messages")
+			continue
+		}
+		if field.Native.Shape == NativeABIShapeBufferArray {
+			g.P(msgName, ".", field.GoName, " = ", name, ".UnsafeSlice()")
+			continue
+		}
+		if field.Presence && (field.Kind == FieldKindString || nativeFieldIsBytes(field)) {
+			renderCodecNativeRequestPresenceToMessage(g, field, msgName)
+			continue
+		}
 		switch field.Kind {
 		case FieldKindString:
 			g.P(msgName, ".", field.GoName, " = ", name, ".UnsafeString()")
@@ -327,6 +667,139 @@ func renderCodecNativeRequestValuesToMessage(g *protogen.GeneratedFile, fields [
 	}
 }
 
+// renderCodecNativeRequestPresenceToMessage sets a string, bytes or message
+// field with presence only when its native wrapper is non-nil. Set bytes stay
+// non-nil even when empty, since nil bytes read as unset, and a set message is
+// decoded into a new, possibly empty, message.
+func renderCodecNativeRequestPresenceToMessage(g *protogen.GeneratedFile, field FieldPlan, msgName string) {
+	name := lowerInitial(field.GoName)
+	g.P("if ", name, " != nil {")
+	switch field.Kind {
+	case FieldKindString:
+		g.P(name, "Value := ", name, ".UnsafeString()")
+		g.P(msgName, ".", field.GoName, " = &", name, "Value")
+	case FieldKindMessage:
+		g.P(msgName, ".", field.GoName, " = ", renderCodecUnmarshalMessage(g, field, name+".UnsafeBytes()"))
+	default:
+		g.P(msgName, ".", field.GoName, " = ", name, ".UnsafeBytes()")
+		g.P("if ", msgName, ".", field.GoName, " == nil {")
+		g.P(msgName, ".", field.GoName, " = []byte{}")
+		g.P("}")
+	}
+	g.P("}")
+}
+
+// renderCodecUnmarshalMessage decodes the encoded value of a singular message
+// field into a new message and returns the name that holds it.
+func renderCodecUnmarshalMessage(g *protogen.GeneratedFile, field FieldPlan, value string) string {
+	message := lowerInitial(field.GoName) + "Message"
+	g.P(message, ", err := rpcruntime.UnmarshalMessage[", nativeGoFieldMessageType(g, field), "](", value, ")")
+	g.P("if err != nil {")
+	g.P(`return nil, fmt.Errorf("`, field.FullName, `: %w", err)`)
+	g.P("}")
+	return message
+}
+
+// renderCodecMessageOneofCase sets the case discriminant of a oneof from the
+// wrapper type held by the message. A nil oneof leaves the case NotSet.
+func renderCodecMessageOneofCase(g *protogen.GeneratedFile, field FieldPlan, msgName string) {
+	name := lowerInitial(field.GoName)
+	g.P("switch ", msgName, ".", field.Oneof.GoName, ".(type) {")
+	for _, member := range field.Oneof.Cases {
+		g.P("case *", codecOneofWrapperType(g, member), ":")
+		g.P(name, " = ", nativeOneofCaseConst(g, field, member.GoName))
+	}
+	g.P("}")
+}
+
+// renderCodecNativeOneofToMessage sets the oneof named by the case
+// discriminant from its member slot, so a member holding its zero value is
+// still set. Message members are decoded into a new message, which keeps an
+// empty one set. Slots of inactive members are ignored.
+func renderCodecNativeOneofToMessage(g *protogen.GeneratedFile, fields []FieldPlan, field FieldPlan, msgName string, request bool) {
+	name := lowerInitial(field.GoName)
+	g.P("switch ", name, " {")
+	g.P("case ", nativeOneofCaseConst(g, field, "NotSet"), ":")
+	for _, member := range field.Oneof.Cases {
+		memberField := codecOneofMemberField(fields, field, member)
+		value := codecOneofMemberValue(memberField, request)
+		g.P("case ", nativeOneofCaseConst(g, field, member.GoName), ":")
+		if memberField.Kind == FieldKindMessage {
+			value = renderCodecUnmarshalMessage(g, memberField, value)
+		}
+		g.P(msgName, ".", field.Oneof.GoName, " = &", codecOneofWrapperType(g, member), "{", member.GoName, ": ", value, "}")
+	}
+	g.P("default:")
+	g.P(`return nil, fmt.Errorf("rpccgo: oneof `, field.FullName, ` has unknown case %d", int32(`, name, "))")
+	g.P("}")
+}
+
+// codecOneofMemberField returns the member slot that pairs with one case of a
+// oneof case discriminant.
+func codecOneofMemberField(fields []FieldPlan, caseField FieldPlan, member OneofCasePlan) FieldPlan {
+	for _, field := range fields {
+		if field.Oneof.Role == OneofRoleMember && field.Oneof.GoName == caseField.Oneof.GoName && field.GoName == member.GoName {
+			return field
+		}
+	}
+	return FieldPlan{}
+}
+
+// codecOneofMemberValue returns the message value of a native oneof member.
+// Request strings, bytes and encoded messages borrow from their native
+// wrappers.
+func codecOneofMemberValue(field FieldPlan, request bool) string {
+	name := lowerInitial(field.GoName)
+	if request {
+		switch field.Kind {
+		case FieldKindString:
+			return name + ".UnsafeString()"
+		case FieldKindBytes, FieldKindMessage:
+			return name + ".UnsafeBytes()"
+		}
+	}
+	return name
+}
+
+func codecOneofWrapperType(g *protogen.GeneratedFile, member OneofCasePlan) string {
+	return g.QualifiedGoIdent(protogen.GoIdent{
+		GoName:       member.WrapperType.GoName,
+		GoImportPath: protogen.GoImportPath(member.WrapperType.GoImportPath),
+	})
+}
+
+func codecRequestMapSliceMethod(field FieldPlan) string {
+	if field.Kind == FieldKindBool {
+		return ".SafeSlice()"
+	}
+	return ".UnsafeSlice()"
+}
+
+// renderCodecNativeMapToMessage rebuilds a map field from its parallel key and
+// value slices. Later duplicates of a key replace earlier ones. rawEnums marks
+// request slices, which carry enums as int32.
+func renderCodecNativeMapToMessage(g *protogen.GeneratedFile, key, value FieldPlan, msgName, keys, values string, rawEnums bool) {
+	msgField := msgName + "." + key.MapEntry.GoName
+	g.P("if len(", keys, ") != len(", values, ") {")
+	g.P(`return nil, errors.New("rpccgo: map field `, key.MapEntry.GoName, ` has mismatched key and value counts")`)
+	g.P("}")
+	g.P("if len(", keys, ") > 0 {")
+	g.P(msgField, " = make(map[", nativeGoScalarType(g, key), "]", nativeGoScalarType(g, value), ", len(", keys, "))")
+	g.P("for i := range ", keys, " {")
+	g.P(msgField, "[", codecMapMessageValue(g, key, keys+"[i]", rawEnums), "] = ", codecMapMessageValue(g, value, values+"[i]", rawEnums))
+	g.P("}")
+	g.P("}")
+}
+
+// codecMapMessageValue converts one native map element to the Go type of the
+// protobuf map.
+func codecMapMessageValue(g *protogen.GeneratedFile, field FieldPlan, value string, rawEnums bool) string {
+	if rawEnums && field.Kind == FieldKindEnum {
+		return nativeGoEnumType(g, field) + "(" + value + ")"
+	}
+	return value
+}
+
 func renderCodecNativeRequestKeepAlive(g *protogen.GeneratedFile, fields []FieldPlan) {
 	for _, field := range fields {
 		if field.Kind == FieldKindString || field.Kind == FieldKindBytes || field.Kind == FieldKindMessage || field.Repeated {
@@ -1,6 +1,11 @@
 package generator
 
-import "testing"
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
 
 func TestRenderCodecFilesEmitsServiceCodecFile(t *testing.T) {
 	file := simpleTestFile()
@@ -127,7 +132,8 @@ func TestCodecNativeToMessageRequestUsesUnsafeWrappersAndKeepAlive(t *testing.T)
 		`goruntime "runtime"`,
 		"func convertAllServiceUnaryNativeToMessageRequest(name *rpcruntime.RpcString, enabled bool, child *rpcruntime.RpcBytes) (*AllRequest, error) {",
 		"msg.Name = name.UnsafeString()",
-		"msg.Child = child.UnsafeBytes()",
+		"childMessage, err := rpcruntime.UnmarshalMessage[*Child](child.UnsafeBytes())",
+		"msg.Child = childMessage",
 		"goruntime.KeepAlive(name)",
 		"goruntime.KeepAlive(child)",
 		"return msg, nil",
@@ -160,7 +166,9 @@ func TestCodecMessageToNativeRequestUsesBorrowedWrappersAndReturnedOwner(t *test
 		"name = rpcruntime.EmptyRpcString()",
 		"child = rpcruntime.EmptyRpcBytes()",
 		"name, err = rpcruntime.NewRpcStringChecked(unsafe.StringData(msg.Name), int32(len(msg.Name)), false)",
-		"child, err = rpcruntime.NewRpcBytesChecked(unsafe.SliceData(msg.Child), int32(len(msg.Child)), false)",
+		"childEncoded, err := rpcruntime.MarshalMessage(msg.Child)",
+		"reqOwner = append(reqOwner, childEncoded)",
+		"child, err = rpcruntime.NewRpcBytesChecked(unsafe.SliceData(childEncoded), int32(len(childEncoded)), false)",
 		"return nil, false, nil, reqOwner, err",
 		"return name, enabled, child, reqOwner, nil",
 	} {
@@ -236,6 +244,232 @@ func TestCodecNativeToMessageRequestUsesUnsafeRepeatedWrappersAndKeepAlive(t *te
 	assertGeneratedFileContentDoesNotContain(t, plugin, codecFile, "msg.Scores = scores.SafeSlice()", "moodsRaw := moods.SafeSlice()", "proto.Marshal")
 }
 
+func TestCodecLowersMapFieldsToParallelKeyValueWrappers(t *testing.T) {
+	file := nativeServerMapFile()
+	plugin := newTestPlugin(t, "paths=source_relative", file)
+
+	plans, err := Generate(plugin)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if err := RenderCodecFiles(plugin, firstFilePlan(t, plans)); err != nil {
+		t.Fatalf("RenderCodecFiles() error = %v", err)
+	}
+
+	const codecFile = "test/v1/native_map.map_service.codec.rpccgo.go"
+	for _, fragment := range []string{
+		"func convertMapServiceCheckMessageToNativeRequest(msg *RepeatedRequest) (*rpcruntime.RpcRepeat[int32], *rpcruntime.RpcRepeat[int64], *rpcruntime.RpcRepeat[uint32], *rpcruntime.RpcRepeat[int32], *rpcruntime.RpcBoolRepeat, *rpcruntime.RpcBoolRepeat, any, error) {",
+		"for key, value := range msg.Totals {",
+		"moodsValuesRaw = append(moodsValuesRaw, int32(value))",
+		"flagsKeysRaw = append(flagsKeysRaw, rpcruntime.BoolByte(key))",
+		"reqOwner = append(reqOwner, totalsKeysRaw, totalsValuesRaw)",
+		"totalsValues, err = rpcruntime.NewRpcRepeatChecked[int64](unsafe.SliceData(totalsValuesRaw), int32(len(totalsValuesRaw)), false)",
+		"flagsValues = rpcruntime.EmptyRpcBoolRepeat()",
+		"flagsKeysRaw := flagsKeys.SafeSlice()",
+		`return nil, errors.New("rpccgo: map field Moods has mismatched key and value counts")`,
+		"msg.Moods[moodsKeysRaw[i]] = Mood(moodsValuesRaw[i])",
+		"func convertMapServiceCheckMessageToNativeResponse(msg *RepeatedReply) ([]int32, []int64, []uint32, []Mood, []bool, []bool, error) {",
+		"moodsValues := make([]Mood, 0, len(msg.Moods))",
+		"msg.Totals = make(map[int32]int64, len(totalsKeys))",
+		"msg.Moods[moodsKeys[i]] = moodsValues[i]",
+		"goruntime.KeepAlive(flagsValues)",
+	} {
+		assertGeneratedContentContains(t, plugin, codecFile, fragment)
+	}
+}
+
+func TestCodecLowersRepeatedStringAndBytesToBufferArrays(t *testing.T) {
+	file := nativeServerBufferRepeatFile()
+	plugin := newTestPlugin(t, "paths=source_relative", file)
+
+	plans, err := Generate(plugin)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if err := RenderCodecFiles(plugin, firstFilePlan(t, plans)); err != nil {
+		t.Fatalf("RenderCodecFiles() error = %v", err)
+	}
+
+	const codecFile = "test/v1/native_buffer.buffer_service.codec.rpccgo.go"
+	for _, fragment := range []string{
+		"func convertBufferServiceCheckMessageToNativeRequest(msg *RepeatedRequest) (*rpcruntime.RpcStringRepeat, *rpcruntime.RpcBytesRepeat, *rpcruntime.RpcStringRepeat, *rpcruntime.RpcBytesRepeat, any, error) {",
+		"tagsRaw[i] = rpcruntime.StringBuffer(msg.Tags[i])",
+		"tags, err = rpcruntime.NewRpcStringRepeatChecked(unsafe.SliceData(tagsRaw), int32(len(tagsRaw)), false)",
+		"labelsValuesRaw = append(labelsValuesRaw, rpcruntime.BytesBuffer(value))",
+		"msg.Tags = tags.UnsafeSlice()",
+		"msg.Labels = make(map[string][]byte, len(labelsKeysRaw))",
+	} {
+		assertGeneratedContentContains(t, plugin, codecFile, fragment)
+	}
+}
+
+func TestCodecConvertsRepeatedMessagesThroughEncodedBufferArrays(t *testing.T) {
+	file := nativeServerMessageRepeatFile()
+	plugin := newTestPlugin(t, "paths=source_relative", file)
+
+	plans, err := Generate(plugin)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if err := RenderCodecFiles(plugin, firstFilePlan(t, plans)); err != nil {
+		t.Fatalf("RenderCodecFiles() error = %v", err)
+	}
+
+	const codecFile = "test/v1/native_message.message_service.codec.rpccgo.go"
+	for _, fragment := range []string{
+		`fmt "fmt"`,
+		"func convertMessageServiceCheckMessageToNativeRequest(msg *RepeatedRequest) (*rpcruntime.RpcMessageRepeat[*Child], *rpcruntime.RpcStringRepeat, any, error) {",
+		"childrenEncoded, err := rpcruntime.MarshalMessages(msg.Children)",
+		"childrenRaw[i] = rpcruntime.BytesBuffer(childrenEncoded[i])",
+		"children, err = rpcruntime.NewRpcMessageRepeatChecked[*Child](unsafe.SliceData(childrenRaw), int32(len(childrenRaw)), false)",
+		"children = rpcruntime.EmptyRpcMessageRepeat[*Child]()",
+		"childrenMessages, err := children.SafeSlice()",
+		`return nil, fmt.Errorf("test.v1.RepeatedRequest.children: %w", err)`,
+		"func convertMessageServiceCheckMessageToNativeResponse(msg *RepeatedReply) ([][]byte, []string, error) {",
+		"children, err := rpcruntime.MarshalMessages(msg.Children)",
+		"childrenMessages, err := rpcruntime.UnmarshalMessages[*Child](children)",
+		"msg.Children = childrenMessages",
+	} {
+		assertGeneratedContentContains(t, plugin, codecFile, fragment)
+	}
+}
+
+func TestCodecRoundTripsOneofThroughCaseDiscriminant(t *testing.T) {
+	file := nativeServerOneofFile()
+	plugin := newTestPlugin(t, "paths=source_relative", file)
+
+	plans, err := Generate(plugin)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if err := RenderCodecFiles(plugin, firstFilePlan(t, plans)); err != nil {
+		t.Fatalf("RenderCodecFiles() error = %v", err)
+	}
+
+	const codecFile = "test/v1/native_oneof.oneof_service.codec.rpccgo.go"
+	for _, fragment := range []string{
+		"type OneofServiceCheckRequestValueCase int32",
+		"OneofServiceCheckRequestValueCaseNotSet OneofServiceCheckRequestValueCase = 0",
+		"OneofServiceCheckRequestValueCaseLabel  OneofServiceCheckRequestValueCase = 1",
+		"OneofServiceCheckRequestValueCaseCount  OneofServiceCheckRequestValueCase = 2",
+		"type OneofServiceCheckResponseValueCase int32",
+		`return fmt.Sprintf("OneofServiceCheckResponseValueCase(%d)", int32(c))`,
+		"func convertOneofServiceCheckMessageToNativeRequest(msg *RepeatedRequest) (*rpcruntime.RpcString, OneofServiceCheckRequestValueCase, *rpcruntime.RpcString, int64, any, error) {",
+		"switch msg.Value.(type) {",
+		"case *RepeatedRequest_Label:",
+		"valueCase = OneofServiceCheckRequestValueCaseLabel",
+		"label, err = rpcruntime.NewRpcStringChecked(unsafe.StringData(msg.GetLabel()), int32(len(msg.GetLabel())), false)",
+		"count = msg.GetCount()",
+		"msg.Value = &RepeatedRequest_Label{Label: label.UnsafeString()}",
+		"msg.Value = &RepeatedRequest_Count{Count: count}",
+		`return nil, fmt.Errorf("rpccgo: oneof test.v1.RepeatedRequest.value has unknown case %d", int32(valueCase))`,
+		"label := msg.GetLabel()",
+		"valueCase = OneofServiceCheckResponseValueCaseCount",
+		"msg.Value = &RepeatedReply_Label{Label: label}",
+	} {
+		assertGeneratedContentContains(t, plugin, codecFile, fragment)
+	}
+}
+
+func TestCodecRoundTripsOneofMessageMemberAsEncodedBytes(t *testing.T) {
+	file := nativeServerOneofMessageFile()
+	plugin := newTestPlugin(t, "paths=source_relative", file)
+
+	plans, err := Generate(plugin)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if err := RenderCodecFiles(plugin, firstFilePlan(t, plans)); err != nil {
+		t.Fatalf("RenderCodecFiles() error = %v", err)
+	}
+
+	const codecFile = "test/v1/native_oneof_message.oneof_message_service.codec.rpccgo.go"
+	for _, fragment := range []string{
+		"OneofMessageServiceCheckRequestValueCaseChild  OneofMessageServiceCheckRequestValueCase = 4",
+		"func convertOneofMessageServiceCheckMessageToNativeRequest(msg *RepeatedRequest) (*rpcruntime.RpcString, OneofMessageServiceCheckRequestValueCase, *rpcruntime.RpcString, int64, *rpcruntime.RpcBytes, any, error) {",
+		"case *RepeatedRequest_Child:",
+		"childEncoded, err := rpcruntime.MarshalMessage(msg.GetChild())",
+		"reqOwner = append(reqOwner, childEncoded)",
+		"child, err = rpcruntime.NewRpcBytesChecked(unsafe.SliceData(childEncoded), int32(len(childEncoded)), false)",
+		"case OneofMessageServiceCheckRequestValueCaseChild:",
+		"childMessage, err := rpcruntime.UnmarshalMessage[*Child](child.UnsafeBytes())",
+		`return nil, fmt.Errorf("test.v1.RepeatedRequest.child: %w", err)`,
+		"msg.Value = &RepeatedRequest_Child{Child: childMessage}",
+		"func convertOneofMessageServiceCheckMessageToNativeResponse(msg *RepeatedReply) (string, OneofMessageServiceCheckResponseValueCase, string, int64, []byte, error) {",
+		"child, err := rpcruntime.MarshalMessage(msg.GetChild())",
+		`err = fmt.Errorf("test.v1.RepeatedReply.child: %w", err)`,
+		"childMessage, err := rpcruntime.UnmarshalMessage[*Child](child)",
+		"msg.Value = &RepeatedReply_Child{Child: childMessage}",
+	} {
+		assertGeneratedContentContains(t, plugin, codecFile, fragment)
+	}
+}
+
+func TestCodecPreservesPresenceOfOptionalFields(t *testing.T) {
+	file := nativeServerPresenceFile()
+	plugin := newTestPlugin(t, "paths=source_relative", file)
+
+	plans, err := Generate(plugin)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if err := RenderCodecFiles(plugin, firstFilePlan(t, plans)); err != nil {
+		t.Fatalf("RenderCodecFiles() error = %v", err)
+	}
+
+	const codecFile = "test/v1/native_presence.presence_service.codec.rpccgo.go"
+	for _, fragment := range []string{
+		"func convertPresenceServiceCheckMessageToNativeRequest(msg *RepeatedRequest) (*rpcruntime.RpcString, *int32, *rpcruntime.RpcString, *rpcruntime.RpcBytes, any, error) {",
+		"limit = msg.Limit",
+		"if msg.Label != nil {",
+		"label, err = rpcruntime.NewRpcStringChecked(unsafe.StringData(*msg.Label), int32(len(*msg.Label)), false)",
+		"if msg.Blob != nil {",
+		"msg.Limit = limit",
+		"if label != nil {",
+		"msg.Label = &labelValue",
+		"msg.Blob = []byte{}",
+		"func convertPresenceServiceCheckMessageToNativeResponse(msg *RepeatedReply) (string, *int32, *string, []byte, error) {",
+		"label := msg.Label",
+		"func convertPresenceServiceCheckNativeToMessageResponse(note string, limit *int32, label *string, blob []byte) (*RepeatedReply, error) {",
+		"msg.Label = label",
+	} {
+		assertGeneratedContentContains(t, plugin, codecFile, fragment)
+	}
+}
+
+func TestCodecPreservesPresenceOfMessageFields(t *testing.T) {
+	file := nativeServerPresenceFile()
+	for _, message := range file.MessageType {
+		message.Field = append(message.Field, fieldDescriptor("child", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ".test.v1.Child"))
+	}
+	file.MessageType = append(file.MessageType, &descriptorpb.DescriptorProto{Name: proto.String("Child")})
+	plugin := newTestPlugin(t, "paths=source_relative", file)
+
+	plans, err := Generate(plugin)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if err := RenderCodecFiles(plugin, firstFilePlan(t, plans)); err != nil {
+		t.Fatalf("RenderCodecFiles() error = %v", err)
+	}
+
+	const codecFile = "test/v1/native_presence.presence_service.codec.rpccgo.go"
+	for _, fragment := range []string{
+		"if msg.Child != nil {",
+		"childEncoded, err := rpcruntime.MarshalMessage(msg.Child)",
+		"child = rpcruntime.EmptyRpcBytes()",
+		"if child != nil {",
+		"childMessage, err := rpcruntime.UnmarshalMessage[*Child](child.UnsafeBytes())",
+		"var child []byte",
+		"child = childEncoded",
+		"child = []byte{}",
+		"childMessage, err := rpcruntime.UnmarshalMessage[*Child](child)",
+		"msg.Child = childMessage",
+	} {
+		assertGeneratedContentContains(t, plugin, codecFile, fragment)
+	}
+}
+
 func TestGenerateWithOptionsEmitsCodecWithoutRemoteAdapterFiles(t *testing.T) {
 	file := simpleTestFile()
 	setSimpleServiceComment(t, file, "@rpccgo: native\n")
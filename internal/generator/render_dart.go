@@ -50,6 +50,8 @@ func renderDartEntryFile(plugin *protogen.Plugin, plan GenerationPlan) {
 	for _, export := range paths {
 		g.P("export '", export, "';")
 	}
+	g.P()
+	renderDartRegistrationTypes(g)
 	if planHasRecvStreamingMethod(plan) {
 		g.P()
 		renderDartLifecycleSupport(g)
@@ -89,9 +91,7 @@ func renderDartServiceClientFile(plugin *protogen.Plugin, file FilePlan, service
 	g.P("import 'package:ffi/ffi.dart' as pkg_ffi;")
 	g.P("import 'package:protobuf/protobuf.dart' as protobuf;")
 	g.P()
-	if serviceHasRecvStreamingMethod(service) {
-		g.P("import '", path.Base(defaultDartNativeAssetName), "' as rpccgo;")
-	}
+	g.P("import '", path.Base(defaultDartNativeAssetName), "' as rpccgo;")
 	g.P("import '", dartPBImport(file), "' as pb;")
 	g.P()
 	g.P("// rpccgo Dart FFI message client generated file for ", service.GoName)
@@ -107,6 +107,7 @@ func renderDartServiceClientFile(plugin *protogen.Plugin, file FilePlan, service
 	for _, method := range service.Methods {
 		renderDartClientMethod(g, file, service, method)
 	}
+	renderDartRegistrationEventsMethod(g, service)
 	renderDartErrorHelpers(g)
 	g.P("}")
 	g.P()
@@ -201,13 +202,13 @@ func renderDartSharedTypedefs(g *protogen.GeneratedFile) {
 	g.P("typedef _RpccgoReleaseCAbi = ffi.Int32 Function(ffi.UintPtr ptr);")
 	g.P("typedef _RpccgoStoreErrorTextCAbi = ffi.Int32 Function(ffi.Pointer<ffi.Char> textPtr, ffi.Int32 textLen);")
 	g.P("typedef _RpccgoTakeErrorTextCAbi = ffi.Int32 Function(ffi.Int32 errID, ffi.Pointer<ffi.UintPtr> textPtr, ffi.Pointer<ffi.Int32> textLen);")
-	g.P("typedef _RpccgoMessageUnaryCAbi = ffi.Int32 Function(ffi.UintPtr requestPtr, ffi.Int32 requestLen, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);")
+	g.P("typedef _RpccgoMessageUnaryCAbi = ffi.Int32 Function(ffi.Pointer<ffi.Uint8> requestPtr, ffi.Int32 requestLen, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);")
 	g.P("typedef _RpccgoStreamStartCAbi = ffi.Int32 Function(ffi.Pointer<ffi.Int32> handle);")
 	g.P("typedef _RpccgoCallbackStreamStartCAbi = ffi.Int32 Function(ffi.Pointer<ffi.Int32> handle, ffi.Pointer<ffi.Void> onRecv, ffi.Pointer<ffi.Void> onDone);")
-	g.P("typedef _RpccgoServerStreamStartCAbi = ffi.Int32 Function(ffi.UintPtr requestPtr, ffi.Int32 requestLen, ffi.Pointer<ffi.Int32> handle, ffi.Pointer<ffi.Void> onRecv, ffi.Pointer<ffi.Void> onDone);")
+	g.P("typedef _RpccgoServerStreamStartCAbi = ffi.Int32 Function(ffi.Pointer<ffi.Uint8> requestPtr, ffi.Int32 requestLen, ffi.Pointer<ffi.Int32> handle, ffi.Pointer<ffi.Void> onRecv, ffi.Pointer<ffi.Void> onDone);")
 	g.P("typedef _RpccgoMessageOnRecvCAbi = ffi.Void Function(ffi.Int32 stream, ffi.UintPtr responsePtr, ffi.Int32 responseLen);")
 	g.P("typedef _RpccgoMessageOnDoneCAbi = ffi.Void Function(ffi.Int32 stream, ffi.Int32 errID);")
-	g.P("typedef _RpccgoStreamSendCAbi = ffi.Int32 Function(ffi.Int32 handle, ffi.UintPtr requestPtr, ffi.Int32 requestLen);")
+	g.P("typedef _RpccgoStreamSendCAbi = ffi.Int32 Function(ffi.Int32 handle, ffi.Pointer<ffi.Uint8> requestPtr, ffi.Int32 requestLen);")
 	g.P("typedef _RpccgoStreamRecvCAbi = ffi.Int32 Function(ffi.Int32 handle, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);")
 	g.P("typedef _RpccgoStreamFinishCAbi = ffi.Int32 Function(ffi.Int32 handle, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);")
 	g.P("typedef _RpccgoStreamFinishVoidCAbi = ffi.Int32 Function(ffi.Int32 handle);")
@@ -219,6 +220,10 @@ func renderDartSharedTypedefs(g *protogen.GeneratedFile) {
 	g.P("typedef _RpccgoMessageServerRecvCAbi = ffi.Int32 Function(ffi.Int32 stream, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);")
 	g.P("typedef _RpccgoMessageServerFinishCAbi = ffi.Int32 Function(ffi.Int32 stream, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);")
 	g.P("typedef _RpccgoMessageServerControlCAbi = ffi.Int32 Function(ffi.Int32 stream);")
+	g.P("typedef _RpccgoRegistrationEventCAbi = ffi.Void Function(ffi.Int32 watch, ffi.UintPtr serviceIDPtr, ffi.Int32 serviceIDLen, ffi.Int32 oldKind, ffi.Int32 newKind, ffi.Int32 cleared, ffi.Uint64 seq);")
+	g.P("typedef _RpccgoRegistrationDoneCAbi = ffi.Void Function(ffi.Int32 watch);")
+	g.P("typedef _RpccgoRegistrationWatchCAbi = ffi.Int32 Function(ffi.Pointer<ffi.Char> serviceID, ffi.Int32 serviceIDLen, ffi.Pointer<ffi.Int32> watch, ffi.Pointer<ffi.NativeFunction<_RpccgoRegistrationEventCAbi>> onEvent, ffi.Pointer<ffi.NativeFunction<_RpccgoRegistrationDoneCAbi>> onDone);")
+	g.P("typedef _RpccgoRegistrationUnwatchCAbi = ffi.Int32 Function(ffi.Int32 watch);")
 	g.P("typedef _RpccgoRegisterUnaryServerCAbi = ffi.Int32 Function(ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerUnaryCAbi>> callback);")
 	g.P("typedef _RpccgoRegisterClientStreamingServerCAbi = ffi.Int32 Function(ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerStartCAbi>> start, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerSendCAbi>> send, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerFinishCAbi>> finish, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerControlCAbi>> cancel);")
 	g.P("typedef _RpccgoRegisterServerStreamingServerCAbi = ffi.Int32 Function(ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerStartWithRequestCAbi>> start, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerRecvCAbi>> recv, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerControlCAbi>> finish, ffi.Pointer<ffi.NativeFunction<_RpccgoMessageServerControlCAbi>> cancel);")
@@ -236,6 +241,107 @@ func renderDartSharedNativeBindings(g *protogen.GeneratedFile) {
 	g.P("@ffi.Native<_RpccgoTakeErrorTextCAbi>(symbol: '", cgoSharedExportName("take_error_text"), "')")
 	g.P("external int ", dartSharedNativeBindingName("take_error_text"), "(int errID, ffi.Pointer<ffi.UintPtr> textPtr, ffi.Pointer<ffi.Int32> textLen);")
 	g.P()
+	g.P("@ffi.Native<_RpccgoRegistrationWatchCAbi>(symbol: '", cgoSharedExportName("registration_watch"), "')")
+	g.P("external int ", dartSharedNativeBindingName("registration_watch"), "(ffi.Pointer<ffi.Char> serviceID, int serviceIDLen, ffi.Pointer<ffi.Int32> watch, ffi.Pointer<ffi.NativeFunction<_RpccgoRegistrationEventCAbi>> onEvent, ffi.Pointer<ffi.NativeFunction<_RpccgoRegistrationDoneCAbi>> onDone);")
+	g.P()
+	g.P("@ffi.Native<_RpccgoRegistrationUnwatchCAbi>(symbol: '", cgoSharedExportName("registration_unwatch"), "')")
+	g.P("external int ", dartSharedNativeBindingName("registration_unwatch"), "(int watch);")
+	g.P()
+}
+
+// renderDartRegistrationTypes emits the registration event types shared by
+// every generated client. They live in the entry file so that clients of
+// different services hand out the same Dart types.
+func renderDartRegistrationTypes(g *protogen.GeneratedFile) {
+	g.P("/// Kind of the Go server registered for a service, in rpcruntime.ServerKind order.")
+	g.P("enum RpccgoServerKind {")
+	for i, name := range cgoServerKindNames {
+		sep := ","
+		if i == len(cgoServerKindNames)-1 {
+			sep = ";"
+		}
+		dartP(g, 1, lowerInitial(upperCamelFromSnake(strings.ToLower(name))), sep)
+	}
+	g.P()
+	dartP(g, 1, "/// Maps a RPCCGO_SERVER_KIND_* value, treating unknown values as [invalid].")
+	dartP(g, 1, "static RpccgoServerKind fromValue(int value) =>")
+	dartP(g, 3, "value > 0 && value < values.length ? values[value] : invalid;")
+	g.P("}")
+	g.P()
+	g.P("/// Replacement or removal of the server registered for a service.")
+	g.P("///")
+	g.P("/// Kinds are those of the first server of the service route; [seq] orders")
+	g.P("/// events from concurrent registrations.")
+	g.P("final class RpccgoRegistrationEvent {")
+	dartP(g, 1, "const RpccgoRegistrationEvent(this.serviceId, this.oldKind, this.newKind, this.cleared, this.seq);")
+	g.P()
+	dartP(g, 1, "final String serviceId;")
+	dartP(g, 1, "final RpccgoServerKind oldKind;")
+	dartP(g, 1, "final RpccgoServerKind newKind;")
+	dartP(g, 1, "final bool cleared;")
+	dartP(g, 1, "final int seq;")
+	g.P("}")
+}
+
+func renderDartRegistrationEventsMethod(g *protogen.GeneratedFile, service ServicePlan) {
+	dartP(g, 1, "/// Emits an event whenever another component replaces or clears the server")
+	dartP(g, 1, "/// registered for ", service.FullName, ".")
+	dartP(g, 1, "/// Cancelling the subscription stops the native watch.")
+	dartP(g, 1, "async.Stream<rpccgo.RpccgoRegistrationEvent> RegistrationEvents() {")
+	dartP(g, 2, "late final async.StreamController<rpccgo.RpccgoRegistrationEvent> controller;")
+	dartP(g, 2, "ffi.NativeCallable<_RpccgoRegistrationEventCAbi>? onEventNative;")
+	dartP(g, 2, "ffi.NativeCallable<_RpccgoRegistrationDoneCAbi>? onDoneNative;")
+	dartP(g, 2, "var watch = 0;")
+	dartP(g, 2, "void closeCallbacks() {")
+	dartP(g, 3, "onEventNative?.close();")
+	dartP(g, 3, "onDoneNative?.close();")
+	dartP(g, 3, "onEventNative = null;")
+	dartP(g, 3, "onDoneNative = null;")
+	dartP(g, 2, "}")
+	dartP(g, 2, "controller = async.StreamController<rpccgo.RpccgoRegistrationEvent>(")
+	dartP(g, 3, "onListen: () {")
+	dartP(g, 4, "final events = ffi.NativeCallable<_RpccgoRegistrationEventCAbi>.listener((int watchHandle, int serviceIDPtr, int serviceIDLen, int oldKind, int newKind, int cleared, int seq) {")
+	dartP(g, 5, "final serviceID = _takeBytes(serviceIDPtr, serviceIDLen);")
+	dartP(g, 5, "if (serviceID.error != null) {")
+	dartP(g, 6, "controller.addError(serviceID.error!);")
+	dartP(g, 6, "return;")
+	dartP(g, 5, "}")
+	dartP(g, 5, "controller.add(rpccgo.RpccgoRegistrationEvent(convert.utf8.decode(serviceID.value!), rpccgo.RpccgoServerKind.fromValue(oldKind), rpccgo.RpccgoServerKind.fromValue(newKind), cleared != 0, seq));")
+	dartP(g, 4, "});")
+	dartP(g, 4, "final done = ffi.NativeCallable<_RpccgoRegistrationDoneCAbi>.listener((int watchHandle) {")
+	dartP(g, 5, "async.scheduleMicrotask(closeCallbacks);")
+	dartP(g, 5, "controller.close();")
+	dartP(g, 4, "});")
+	dartP(g, 4, "onEventNative = events;")
+	dartP(g, 4, "onDoneNative = done;")
+	dartP(g, 4, "final serviceIDBytes = convert.utf8.encode('", service.FullName, "');")
+	dartP(g, 4, "final serviceIDPtr = _allocateBytes(serviceIDBytes);")
+	dartP(g, 4, "final watchPtr = pkg_ffi.calloc<ffi.Int32>();")
+	dartP(g, 4, "try {")
+	dartP(g, 5, "final errID = ", dartSharedNativeBindingName("registration_watch"), "(serviceIDPtr.cast<ffi.Char>(), serviceIDBytes.length, watchPtr, events.nativeFunction, done.nativeFunction);")
+	dartP(g, 5, "final error = _takeErrorResult(errID);")
+	dartP(g, 5, "if (error != null) {")
+	dartP(g, 6, "closeCallbacks();")
+	dartP(g, 6, "controller.addError(error);")
+	dartP(g, 6, "controller.close();")
+	dartP(g, 6, "return;")
+	dartP(g, 5, "}")
+	dartP(g, 5, "watch = watchPtr.value;")
+	dartP(g, 4, "} finally {")
+	dartP(g, 5, "pkg_ffi.calloc.free(serviceIDPtr);")
+	dartP(g, 5, "pkg_ffi.calloc.free(watchPtr);")
+	dartP(g, 4, "}")
+	dartP(g, 3, "},")
+	dartP(g, 3, "onCancel: () {")
+	dartP(g, 4, "if (watch != 0) {")
+	dartP(g, 5, "_takeErrorResult(", dartSharedNativeBindingName("registration_unwatch"), "(watch));")
+	dartP(g, 5, "watch = 0;")
+	dartP(g, 4, "}")
+	dartP(g, 3, "},")
+	dartP(g, 2, ");")
+	dartP(g, 2, "return controller.stream;")
+	dartP(g, 1, "}")
+	g.P()
 }
 
 func renderDartNativeBinding(g *protogen.GeneratedFile, file FilePlan, service ServicePlan, method MethodPlan) {
@@ -267,13 +373,13 @@ func renderDartNativeBindingOperation(g *protogen.GeneratedFile, file FilePlan,
 	g.P("@ffi.Native<", nativeType, ">(symbol: '", messageCExportFuncName(file, service, method, operation), "')")
 	switch method.Streaming {
 	case StreamingKindUnary:
-		g.P("external int ", bindingName, "(int requestPtr, int requestLen, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);")
+		g.P("external int ", bindingName, "(ffi.Pointer<ffi.Uint8> requestPtr, int requestLen, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);")
 	case StreamingKindClientStreaming:
 		switch operation {
 		case "start":
 			g.P("external int ", bindingName, "(ffi.Pointer<ffi.Int32> handle);")
 		case "send":
-			g.P("external int ", bindingName, "(int handle, int requestPtr, int requestLen);")
+			g.P("external int ", bindingName, "(int handle, ffi.Pointer<ffi.Uint8> requestPtr, int requestLen);")
 		case "finish":
 			g.P("external int ", bindingName, "(int handle, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);")
 		case "cancel":
@@ -282,7 +388,7 @@ func renderDartNativeBindingOperation(g *protogen.GeneratedFile, file FilePlan,
 	case StreamingKindServerStreaming:
 		switch operation {
 		case "start":
-			g.P("external int ", bindingName, "(int requestPtr, int requestLen, ffi.Pointer<ffi.Int32> handle, ffi.Pointer<ffi.Void> onRecv, ffi.Pointer<ffi.Void> onDone);")
+			g.P("external int ", bindingName, "(ffi.Pointer<ffi.Uint8> requestPtr, int requestLen, ffi.Pointer<ffi.Int32> handle, ffi.Pointer<ffi.Void> onRecv, ffi.Pointer<ffi.Void> onDone);")
 		case "recv":
 			g.P("external int ", bindingName, "(int handle, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);")
 		case "finish", "cancel", "close":
@@ -293,7 +399,7 @@ func renderDartNativeBindingOperation(g *protogen.GeneratedFile, file FilePlan,
 		case "start":
 			g.P("external int ", bindingName, "(ffi.Pointer<ffi.Int32> handle, ffi.Pointer<ffi.Void> onRecv, ffi.Pointer<ffi.Void> onDone);")
 		case "send":
-			g.P("external int ", bindingName, "(int handle, int requestPtr, int requestLen);")
+			g.P("external int ", bindingName, "(int handle, ffi.Pointer<ffi.Uint8> requestPtr, int requestLen);")
 		case "recv":
 			g.P("external int ", bindingName, "(int handle, ffi.Pointer<ffi.UintPtr> responsePtr, ffi.Pointer<ffi.Int32> responseLen);")
 		case "close_send", "finish", "cancel", "close":
@@ -342,7 +448,7 @@ func renderDartUnaryMethod(g *protogen.GeneratedFile, method MethodPlan) {
 	dartP(g, 2, "final requestBytes = request.writeToBuffer();")
 	dartP(g, 2, "final requestPtr = _allocateBytes(requestBytes);")
 	dartP(g, 2, "try {")
-	dartP(g, 3, "final errID = ", dartNativeBindingName(method, ""), "(requestPtr.address, requestBytes.length, responsePtr, responseLen);")
+	dartP(g, 3, "final errID = ", dartNativeBindingName(method, ""), "(requestPtr, requestBytes.length, responsePtr, responseLen);")
 	dartP(g, 3, "final error = _takeErrorResult(errID);")
 	dartP(g, 3, "if (error != null) {")
 	dartP(g, 4, "return (value: null, error: error);")
@@ -386,7 +492,7 @@ func renderDartServerStreamingMethods(g *protogen.GeneratedFile, service Service
 	dartP(g, 2, "final requestBytes = request.writeToBuffer();")
 	dartP(g, 2, "final requestPtr = _allocateBytes(requestBytes);")
 	dartP(g, 2, "try {")
-	dartP(g, 3, "final errID = ", dartNativeBindingName(method, "start"), "(requestPtr.address, requestBytes.length, handlePtr, ffi.nullptr, ffi.nullptr);")
+	dartP(g, 3, "final errID = ", dartNativeBindingName(method, "start"), "(requestPtr, requestBytes.length, handlePtr, ffi.nullptr, ffi.nullptr);")
 	dartP(g, 3, "final error = _takeErrorResult(errID);")
 	dartP(g, 3, "if (error != null) {")
 	dartP(g, 4, "return (value: null, error: error);")
@@ -427,7 +533,7 @@ func renderDartServerStreamingCallbackMethod(g *protogen.GeneratedFile, classNam
 	dartP(g, 2, "final requestPtr = _allocateBytes(requestBytes);")
 	renderDartCallbackCallableSetup(g, method)
 	dartP(g, 2, "try {")
-	dartP(g, 3, "final errID = ", dartNativeBindingName(method, "start"), "(requestPtr.address, requestBytes.length, handlePtr, onRecvNative.nativeFunction.cast<ffi.Void>(), onDoneNative.nativeFunction.cast<ffi.Void>());")
+	dartP(g, 3, "final errID = ", dartNativeBindingName(method, "start"), "(requestPtr, requestBytes.length, handlePtr, onRecvNative.nativeFunction.cast<ffi.Void>(), onDoneNative.nativeFunction.cast<ffi.Void>());")
 	renderDartCallbackStartResult(g, className)
 	dartP(g, 2, "} finally {")
 	dartP(g, 3, "pkg_ffi.calloc.free(requestPtr);")
@@ -539,7 +645,7 @@ func renderDartStreamClass(g *protogen.GeneratedFile, clientClassName, className
 		dartP(g, 2, "final requestBytes = request.writeToBuffer();")
 		dartP(g, 2, "final requestPtr = _client._allocateBytes(requestBytes);")
 		dartP(g, 2, "try {")
-		dartP(g, 3, "final errID = ", dartNativeBindingName(method, "send"), "(_handle, requestPtr.address, requestBytes.length);")
+		dartP(g, 3, "final errID = ", dartNativeBindingName(method, "send"), "(_handle, requestPtr, requestBytes.length);")
 		dartP(g, 3, "return _client._takeErrorResult(errID);")
 		dartP(g, 2, "} finally {")
 		dartP(g, 3, "pkg_ffi.calloc.free(requestPtr);")
@@ -38,6 +38,19 @@ func TestGenerateDartEmitsMessageFFIClient(t *testing.T) {
 	assertGeneratedContentContains(t, plugin, "test/v1/greeter.greeter.rpccgo.dart", "({pb.HelloReply? value, String? error}) SayHello(pb.HelloRequest request) {")
 	assertGeneratedContentContains(t, plugin, "test/v1/greeter.greeter.rpccgo.dart", "@ffi.Native<_RpccgoMessageUnaryCAbi>(symbol: 'rpccgoMsgTestv1GreeterSayHello')")
 	assertGeneratedContentContains(t, plugin, "test/v1/greeter.greeter.rpccgo.dart", "request.writeToBuffer()")
+	assertGeneratedContentContains(t, plugin, "rpccgo.dart", "enum RpccgoServerKind {\n  invalid,\n  goNative,")
+	assertGeneratedContentContains(t, plugin, "rpccgo.dart", "final class RpccgoRegistrationEvent {")
+	for _, fragment := range []string{
+		"import 'rpccgo.dart' as rpccgo;",
+		"async.Stream<rpccgo.RpccgoRegistrationEvent> RegistrationEvents() {",
+		"@ffi.Native<_RpccgoRegistrationWatchCAbi>(symbol: 'rpccgoRegistrationWatch')",
+		"@ffi.Native<_RpccgoRegistrationUnwatchCAbi>(symbol: 'rpccgoRegistrationUnwatch')",
+		"final serviceIDBytes = convert.utf8.encode('test.v1.Greeter');",
+		"ffi.NativeCallable<_RpccgoRegistrationEventCAbi>.listener((int watchHandle, int serviceIDPtr, int serviceIDLen, int oldKind, int newKind, int cleared, int seq) {",
+		"_takeErrorResult(_rpccgoRegistrationUnwatchRaw(watch));",
+	} {
+		assertGeneratedContentContains(t, plugin, "test/v1/greeter.greeter.rpccgo.dart", fragment)
+	}
 	assertGeneratedContentContains(t, plugin, "test/v1/greeter.greeter.rpccgo.dart", "pb.HelloReply.fromBuffer(responseBytes.value!)")
 	assertGeneratedFileContentDoesNotContain(t, plugin, "test/v1/greeter.greeter.rpccgo.dart",
 		"RpccgoResult",
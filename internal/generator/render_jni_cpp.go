@@ -42,7 +42,7 @@ func renderJNICPPCommonHeaderFile(plugin *protogen.Plugin, config JNIGeneratorCo
 	g.P("jbyteArray rpccgoSuccessBytes(JNIEnv* env, uintptr_t responsePtr, int32_t responseLen);")
 	g.P("jbyteArray rpccgoSuccessHandle(JNIEnv* env, int32_t handle);")
 	g.P("jbyteArray rpccgoSuccessUnit(JNIEnv* env);")
-	g.P("uintptr_t rpccgoVectorPtr(const std::vector<uint8_t>& data);")
+	g.P("void* rpccgoVectorPtr(const std::vector<uint8_t>& data);")
 	g.P("std::string rpccgoErrorString(int32_t errID);")
 	g.P("int32_t rpccgoStoreErrorString(const std::string& message);")
 	g.P("int32_t rpccgoExceptionError(JNIEnv* env, const std::string& message);")
@@ -58,6 +58,8 @@ func renderJNICPPCommonFile(plugin *protogen.Plugin, config JNIGeneratorConfig)
 	g.P()
 	g.P(`#include "`, path.Base(jniCPPCommonHeaderFilename(config)), `"`)
 	g.P()
+	g.P("#include <map>")
+	g.P("#include <mutex>")
 	g.P("#include <utility>")
 	g.P()
 	g.P("JavaVM* javaVM = nullptr;")
@@ -73,6 +75,93 @@ func renderJNICPPCommonFile(plugin *protogen.Plugin, config JNIGeneratorConfig)
 	g.P("}")
 	g.P()
 	renderJNICPPHelpers(g)
+	renderJNICPPRegistrationWatch(g, config)
+}
+
+// renderJNICPPRegistrationWatch bridges rpccgoRegistrationWatch to Kotlin.
+// Listeners are keyed by watch handle; the lock is held across the watch call
+// so an early event waits until its listener is stored.
+func renderJNICPPRegistrationWatch(g *protogen.GeneratedFile, config JNIGeneratorConfig) {
+	watchName := jniExportName(config.JNIClass, "rpccgoRegistrationWatch")
+	unwatchName := jniExportName(config.JNIClass, "rpccgoRegistrationUnwatch")
+	g.P("struct rpccgoRegistrationListener {")
+	g.P("    jobject bridge;")
+	g.P("    jmethodID onEvent;")
+	g.P("};")
+	g.P()
+	g.P("std::mutex rpccgoRegistrationMu;")
+	g.P("std::map<int32_t, rpccgoRegistrationListener> rpccgoRegistrationListeners;")
+	g.P()
+	g.P("void rpccgoOnRegistrationEvent(int32_t watch, uintptr_t serviceIDPtr, int32_t serviceIDLen, int32_t oldKind, int32_t newKind, int32_t cleared, uint64_t seq) {")
+	g.P("    std::string serviceID;")
+	g.P("    if (serviceIDPtr != 0) {")
+	g.P("        serviceID.assign(reinterpret_cast<const char*>(serviceIDPtr), static_cast<size_t>(serviceIDLen));")
+	g.P("        rpccgoRelease(serviceIDPtr);")
+	g.P("    }")
+	g.P("    rpccgoJNIEnvScope envScope(nullptr);")
+	g.P("    JNIEnv* env = envScope.env;")
+	g.P("    if (env == nullptr) { return; }")
+	g.P("    jobject bridge = nullptr;")
+	g.P("    jmethodID onEvent = nullptr;")
+	g.P("    {")
+	g.P("        std::lock_guard<std::mutex> lock(rpccgoRegistrationMu);")
+	g.P("        auto it = rpccgoRegistrationListeners.find(watch);")
+	g.P("        if (it == rpccgoRegistrationListeners.end()) { return; }")
+	g.P("        bridge = env->NewLocalRef(it->second.bridge);")
+	g.P("        onEvent = it->second.onEvent;")
+	g.P("    }")
+	g.P("    if (bridge == nullptr) { return; }")
+	g.P("    jstring service = env->NewStringUTF(serviceID.c_str());")
+	g.P("    if (service != nullptr) {")
+	g.P("        env->CallVoidMethod(bridge, onEvent, service, static_cast<jint>(oldKind), static_cast<jint>(newKind), cleared != 0 ? JNI_TRUE : JNI_FALSE, static_cast<jlong>(seq));")
+	g.P("        env->DeleteLocalRef(service);")
+	g.P("    }")
+	g.P("    env->DeleteLocalRef(bridge);")
+	g.P("    if (env->ExceptionCheck()) { env->ExceptionClear(); }")
+	g.P("}")
+	g.P()
+	g.P("void rpccgoOnRegistrationDone(int32_t watch) {")
+	g.P("    rpccgoJNIEnvScope envScope(nullptr);")
+	g.P("    JNIEnv* env = envScope.env;")
+	g.P("    std::lock_guard<std::mutex> lock(rpccgoRegistrationMu);")
+	g.P("    auto it = rpccgoRegistrationListeners.find(watch);")
+	g.P("    if (it == rpccgoRegistrationListeners.end()) { return; }")
+	g.P("    if (env != nullptr) { env->DeleteGlobalRef(it->second.bridge); }")
+	g.P("    rpccgoRegistrationListeners.erase(it);")
+	g.P("}")
+	g.P()
+	g.P("// ", watchName, " starts a registration watch that reports to a Kotlin bridge.")
+	g.P("extern \"C\" JNIEXPORT jbyteArray JNICALL ", watchName, "(JNIEnv* env, jobject, jstring serviceID, jobject bridge) {")
+	renderJNICPPEnvScope(g, "nullptr")
+	g.P("    if (serviceID == nullptr || bridge == nullptr) { return rpccgoErrorResult(env, \"rpccgo: JNI registration watch arguments are null\"); }")
+	g.P("    jclass bridgeClass = env->GetObjectClass(bridge);")
+	g.P("    jmethodID onEvent = env->GetMethodID(bridgeClass, \"onEvent\", \"(Ljava/lang/String;IIZJ)V\");")
+	g.P("    env->DeleteLocalRef(bridgeClass);")
+	g.P("    if (onEvent == nullptr) { return rpccgoErrorResult(env, \"rpccgo: JNI registration bridge has no onEvent\"); }")
+	g.P("    const char* chars = env->GetStringUTFChars(serviceID, nullptr);")
+	g.P("    if (chars == nullptr) { return rpccgoErrorResult(env, \"rpccgo: JNI registration service id is unreadable\"); }")
+	g.P("    std::string service(chars);")
+	g.P("    env->ReleaseStringUTFChars(serviceID, chars);")
+	g.P("    jobject globalBridge = env->NewGlobalRef(bridge);")
+	g.P("    if (globalBridge == nullptr) { return rpccgoErrorResult(env, \"rpccgo: JNI registration bridge reference failed\"); }")
+	g.P("    std::lock_guard<std::mutex> lock(rpccgoRegistrationMu);")
+	g.P("    int32_t watch = 0;")
+	g.P("    int32_t errID = ", cgoSharedExportName("registration_watch"), "(const_cast<char*>(service.data()), static_cast<int32_t>(service.size()), &watch, rpccgoOnRegistrationEvent, rpccgoOnRegistrationDone);")
+	g.P("    if (errID != 0) {")
+	g.P("        env->DeleteGlobalRef(globalBridge);")
+	g.P("        return rpccgoErrorIDResult(env, errID);")
+	g.P("    }")
+	g.P("    rpccgoRegistrationListeners[watch] = rpccgoRegistrationListener{globalBridge, onEvent};")
+	g.P("    return rpccgoSuccessHandle(env, watch);")
+	g.P("}")
+	g.P()
+	g.P("// ", unwatchName, " cancels a registration watch; its bridge is released once the watch delivers onDone.")
+	g.P("extern \"C\" JNIEXPORT jbyteArray JNICALL ", unwatchName, "(JNIEnv* env, jobject, jint watch) {")
+	renderJNICPPEnvScope(g, "nullptr")
+	g.P("    int32_t errID = ", cgoSharedExportName("registration_unwatch"), "(static_cast<int32_t>(watch));")
+	g.P("    if (errID != 0) { return rpccgoErrorIDResult(env, errID); }")
+	g.P("    return rpccgoSuccessUnit(env);")
+	g.P("}")
 }
 
 func renderJNICPPFile(plugin *protogen.Plugin, file FilePlan, service ServicePlan, config JNIGeneratorConfig) {
@@ -205,9 +294,9 @@ func renderJNICPPHelpers(g *protogen.GeneratedFile) {
 	g.P("    return rpccgoResult(env, true, {});")
 	g.P("}")
 	g.P()
-	g.P("uintptr_t rpccgoVectorPtr(const std::vector<uint8_t>& data) {")
-	g.P("    if (data.empty()) { return 0; }")
-	g.P("    return reinterpret_cast<uintptr_t>(data.data());")
+	g.P("void* rpccgoVectorPtr(const std::vector<uint8_t>& data) {")
+	g.P("    if (data.empty()) { return nullptr; }")
+	g.P("    return const_cast<uint8_t*>(data.data());")
 	g.P("}")
 	g.P()
 	g.P("std::string rpccgoErrorString(int32_t errID) {")
@@ -10,9 +10,7 @@ func renderJNIKotlinFile(plugin *protogen.Plugin, plan FilePlan, services []Serv
 	pkg, className := jniClassPackageAndSimpleName(config.JNIClass)
 	g.P("package ", pkg)
 	g.P()
-	if jniServicesHaveRecvStreamingMethod(services) {
-		g.P("import androidx.annotation.Keep")
-	}
+	g.P("import androidx.annotation.Keep")
 	g.P("import com.google.protobuf.MessageLite")
 	g.P("import java.nio.ByteBuffer")
 	g.P("import java.nio.ByteOrder")
@@ -20,9 +18,7 @@ func renderJNIKotlinFile(plugin *protogen.Plugin, plan FilePlan, services []Serv
 		g.P("import java.util.concurrent.ConcurrentHashMap")
 		g.P("import java.util.concurrent.atomic.AtomicInteger")
 	}
-	if jniServicesHaveRecvStreamingMethod(services) {
-		g.P("import java.util.concurrent.atomic.AtomicBoolean")
-	}
+	g.P("import java.util.concurrent.atomic.AtomicBoolean")
 	g.P()
 	g.P("data class RpccgoResult<T>(val value: T?, val error: String?) {")
 	g.P("    val ok: Boolean get() = error == null")
@@ -40,6 +36,7 @@ func renderJNIKotlinFile(plugin *protogen.Plugin, plan FilePlan, services []Serv
 	if jniServicesHaveRecvStreamingMethod(services) {
 		renderKotlinCallbackStreamSupport(g)
 	}
+	renderKotlinRegistrationSupport(g, className)
 	for _, service := range services {
 		for _, method := range service.Methods {
 			renderKotlinCallbackListener(g, service, method)
@@ -63,6 +60,7 @@ func renderJNIKotlinFile(plugin *protogen.Plugin, plan FilePlan, services []Serv
 			}
 			renderKotlinServerMethod(g, service, method)
 		}
+		renderKotlinRegistrationWatchMethod(g, service)
 	}
 	g.P("    private fun decodeResultPayload(bytes: ByteArray?): RpccgoResult<ByteArray> {")
 	g.P(`        if (bytes == null) return RpccgoResult.failure("rpccgo: JNI returned null")`)
@@ -178,6 +176,72 @@ func renderKotlinCallbackStreamSupport(g *protogen.GeneratedFile) {
 	g.P()
 }
 
+// renderKotlinRegistrationSupport emits the registration event types and the
+// JNI bridge that receives rpccgoRegistrationWatch events.
+func renderKotlinRegistrationSupport(g *protogen.GeneratedFile, className string) {
+	g.P("    /** Kind of the Go server registered for a service, in rpcruntime.ServerKind order. */")
+	g.P("    enum class RpccgoServerKind {")
+	for i, name := range cgoServerKindNames {
+		sep := ","
+		if i == len(cgoServerKindNames)-1 {
+			sep = ";"
+		}
+		g.P("        ", name, sep)
+	}
+	g.P()
+	g.P("        companion object {")
+	g.P("            fun fromValue(value: Int): RpccgoServerKind = values().getOrElse(value) { INVALID }")
+	g.P("        }")
+	g.P("    }")
+	g.P()
+	g.P("    /** Replacement or removal of the server registered for a service; seq orders concurrent events. */")
+	g.P("    data class RpccgoRegistrationEvent(")
+	g.P("        val serviceId: String,")
+	g.P("        val oldKind: RpccgoServerKind,")
+	g.P("        val newKind: RpccgoServerKind,")
+	g.P("        val cleared: Boolean,")
+	g.P("        val seq: Long,")
+	g.P("    )")
+	g.P()
+	g.P("    /** Receives registration events on a native thread; it must not block. */")
+	g.P("    fun interface RpccgoRegistrationListener {")
+	g.P("        fun onRegistrationEvent(event: RpccgoRegistrationEvent)")
+	g.P("    }")
+	g.P()
+	g.P("    /** Handle for a registration watch. Closing it stops further events. */")
+	g.P("    class RpccgoRegistrationWatch internal constructor(private val watch: Int) : AutoCloseable {")
+	g.P("        private val active = AtomicBoolean(true)")
+	g.P()
+	g.P("        override fun close() {")
+	g.P("            if (active.compareAndSet(true, false)) ", className, ".rpccgoRegistrationUnwatch(watch)")
+	g.P("        }")
+	g.P("    }")
+	g.P()
+	g.P("    @Keep")
+	g.P("    private class RpccgoRegistrationBridge(private val listener: RpccgoRegistrationListener) {")
+	g.P("        @Keep")
+	g.P("        fun onEvent(serviceId: String, oldKind: Int, newKind: Int, cleared: Boolean, seq: Long) {")
+	g.P("            listener.onRegistrationEvent(")
+	g.P("                RpccgoRegistrationEvent(serviceId, RpccgoServerKind.fromValue(oldKind), RpccgoServerKind.fromValue(newKind), cleared, seq),")
+	g.P("            )")
+	g.P("        }")
+	g.P("    }")
+	g.P()
+	g.P("    private external fun rpccgoRegistrationWatch(serviceId: String, bridge: RpccgoRegistrationBridge): ByteArray?")
+	g.P("    private external fun rpccgoRegistrationUnwatch(watch: Int): ByteArray?")
+	g.P()
+}
+
+func renderKotlinRegistrationWatchMethod(g *protogen.GeneratedFile, service ServicePlan) {
+	g.P("    /** Reports every replace or clear of the server registered for ", service.FullName, ". */")
+	g.P("    fun Watch", service.GoName, "Registration(listener: RpccgoRegistrationListener): RpccgoResult<RpccgoRegistrationWatch> {")
+	g.P("        val watch = decodeHandleResult(rpccgoRegistrationWatch(\"", service.FullName, "\", RpccgoRegistrationBridge(listener)))")
+	g.P("        if (!watch.ok) return RpccgoResult.failure(watch.error ?: \"rpccgo: registration watch failed\")")
+	g.P("        return RpccgoResult.success(RpccgoRegistrationWatch(watch.value ?: 0))")
+	g.P("    }")
+	g.P()
+}
+
 func renderKotlinNativeDeclarations(g *protogen.GeneratedFile, service ServicePlan, method MethodPlan) {
 	prefix := lowerInitial(service.GoName) + method.GoName
 	switch method.Streaming {
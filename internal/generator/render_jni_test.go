@@ -46,6 +46,27 @@ func TestGenerateJNIEmitsKotlinAndCPPMessageClient(t *testing.T) {
 	assertGeneratedContentContains(t, plugin, "kotlin/com/example/GreeterJni.kt", "private external fun greeterSayHelloRegister(): ByteArray?")
 	assertGeneratedContentContains(t, plugin, "kotlin/com/example/GreeterJni.kt", "fun RegisterSayHello(handler: (test.v1.HelloRequest) -> RpccgoResult<test.v1.HelloReply>): RpccgoResult<Unit>")
 	assertGeneratedContentContains(t, plugin, "kotlin/com/example/GreeterJni.kt", "@Keep\n    private fun greeterSayHelloHandle(requestBytes: ByteArray): ByteArray = try {")
+	for _, fragment := range []string{
+		"std::map<int32_t, rpccgoRegistrationListener> rpccgoRegistrationListeners;",
+		"void rpccgoOnRegistrationEvent(int32_t watch, uintptr_t serviceIDPtr, int32_t serviceIDLen, int32_t oldKind, int32_t newKind, int32_t cleared, uint64_t seq) {",
+		`env->GetMethodID(bridgeClass, "onEvent", "(Ljava/lang/String;IIZJ)V");`,
+		"extern \"C\" JNIEXPORT jbyteArray JNICALL Java_com_example_GreeterJni_rpccgoRegistrationWatch(JNIEnv* env, jobject, jstring serviceID, jobject bridge) {",
+		"rpccgoRegistrationWatch(const_cast<char*>(service.data()), static_cast<int32_t>(service.size()), &watch, rpccgoOnRegistrationEvent, rpccgoOnRegistrationDone);",
+		"extern \"C\" JNIEXPORT jbyteArray JNICALL Java_com_example_GreeterJni_rpccgoRegistrationUnwatch(JNIEnv* env, jobject, jint watch) {",
+	} {
+		assertGeneratedContentContains(t, plugin, "cpp/rpccgo/rpccgo.jni.cpp", fragment)
+	}
+	for _, fragment := range []string{
+		"enum class RpccgoServerKind {\n        INVALID,",
+		"fun interface RpccgoRegistrationListener {",
+		"fun onEvent(serviceId: String, oldKind: Int, newKind: Int, cleared: Boolean, seq: Long) {",
+		"private external fun rpccgoRegistrationWatch(serviceId: String, bridge: RpccgoRegistrationBridge): ByteArray?",
+		"fun WatchGreeterRegistration(listener: RpccgoRegistrationListener): RpccgoResult<RpccgoRegistrationWatch> {",
+		`val watch = decodeHandleResult(rpccgoRegistrationWatch("test.v1.Greeter", RpccgoRegistrationBridge(listener)))`,
+		"if (active.compareAndSet(true, false)) GreeterJni.rpccgoRegistrationUnwatch(watch)",
+	} {
+		assertGeneratedContentContains(t, plugin, "kotlin/com/example/GreeterJni.kt", fragment)
+	}
 }
 
 func TestGenerateJNIEmitsStreamingOperations(t *testing.T) {
@@ -1,6 +1,10 @@
 package generator
 
-import "google.golang.org/protobuf/compiler/protogen"
+import (
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
 
 func renderMessageClientCGOFile(plugin *protogen.Plugin, plan FilePlan, service ServicePlan, file GeneratedArtifactPlan) error {
 	cgoImportPath := protogen.GoImportPath(cgoGoImportPath(plan))
@@ -28,6 +32,9 @@ func renderMessageClientCGOFile(plugin *protogen.Plugin, plan FilePlan, service
 		g.P(`io "io"`)
 	}
 	g.P(`rpcruntime "`, rpcruntimeImportPath, `"`)
+	if serviceHasUnaryMethod(service) {
+		g.P(`unsafe "unsafe"`)
+	}
 	g.P(")")
 	g.P()
 	g.P("// ", messageStageMarker(service, file))
@@ -83,10 +90,12 @@ func renderMessageCExportWrappers(g *protogen.GeneratedFile, plan FilePlan, serv
 
 func renderMessageUnaryCExportWrapper(g *protogen.GeneratedFile, plan FilePlan, service ServicePlan, method MethodPlan, servicePackage string) {
 	exportName := messageCExportFuncName(plan, service, method, "")
-	renderCGOExportDoc(g, exportName, "invokes the message unary client entrypoint for "+method.FullName+".")
-	g.P("//export ", exportName)
-	g.P("func ", exportName, "(requestPtr C.uintptr_t, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:   exportName,
+		Doc:    "invokes the message unary client entrypoint for " + method.FullName + ".",
+		Params: "requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t",
+		Return: "C.int32_t",
+	})
 	renderMessageCExportOutputValidation(g)
 	g.P("req := &", g.QualifiedGoIdent(protogen.GoIdent{GoName: method.Request.GoName, GoImportPath: protogen.GoImportPath(method.Request.GoImportPath)}), "{}")
 	g.P("if err := rpcruntime.DecodeMessage(uintptr(requestPtr), int32(requestLen), req); err != nil {")
@@ -105,14 +114,41 @@ func renderMessageUnaryCExportWrapper(g *protogen.GeneratedFile, plan FilePlan,
 	g.P("return 0")
 	g.P("}")
 	g.P()
+
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:   exportName + "Into",
+		Doc:    "is " + exportName + " marshaling the response into the caller buffer at bufferPtr instead of pinned memory, so there is nothing to release. responseLen receives the encoded length, or the length needed together with RPCCGO_ERR_BUFFER_TOO_SMALL; retrying repeats the call.",
+		Params: nativeCExportParamJoin("requestPtr unsafe.Pointer, requestLen C.int32_t", outputBufferParams("responseLen")),
+		Return: "C.int32_t",
+	})
+	renderOutputBufferOpen(g, "responseLen", "rpccgo: message client output pointer is nil")
+	g.P("req := &", g.QualifiedGoIdent(protogen.GoIdent{GoName: method.Request.GoName, GoImportPath: protogen.GoImportPath(method.Request.GoImportPath)}), "{}")
+	g.P("if err := rpcruntime.DecodeMessage(uintptr(requestPtr), int32(requestLen), req); err != nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: message request decode failed: %w", err)))`)
+	g.P("}")
+	g.P("resp, err := ", servicePackage, "Invoke", service.GoName, "Message", method.GoName, "(ctx, req)")
+	g.P("if err != nil {")
+	g.P("return C.int32_t(rpcruntime.StoreError(err))")
+	g.P("}")
+	g.P("_, encodeErr := buffer.PutMessage(resp)")
+	renderOutputBufferLenCommit(g, "responseLen")
+	g.P("if encodeErr != nil {")
+	g.P(`return C.int32_t(rpcruntime.StoreError(fmt.Errorf("rpccgo: message response encode failed: %w", encodeErr)))`)
+	g.P("}")
+	g.P("return 0")
+	g.P("}")
+	g.P()
 }
 
 func renderMessageClientStreamingCExportWrappers(g *protogen.GeneratedFile, plan FilePlan, service ServicePlan, method MethodPlan, servicePackage string) {
 	startName := messageCExportFuncName(plan, service, method, "start")
-	renderCGOExportDoc(g, startName, "starts the message client-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", startName)
-	g.P("func ", startName, "(handle *C.int32_t) C.int32_t {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:         startName,
+		Doc:          "starts the message client-streaming client entrypoint for " + method.FullName + ".",
+		Params:       "handle *C.int32_t",
+		Return:       "C.int32_t",
+		KeepsContext: true,
+	})
 	renderMessageCExportHandleValidation(g)
 	g.P("handleValue, err := ", servicePackage, runtimeMessageStreamOperationCallName(service, method, "Start"), "(ctx)")
 	g.P("if err != nil {")
@@ -124,10 +160,13 @@ func renderMessageClientStreamingCExportWrappers(g *protogen.GeneratedFile, plan
 	g.P()
 
 	sendName := messageCExportFuncName(plan, service, method, "send")
-	renderCGOExportDoc(g, sendName, "sends a message request to the client-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", sendName)
-	g.P("func ", sendName, "(handle C.int32_t, requestPtr C.uintptr_t, requestLen C.int32_t) C.int32_t {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     sendName,
+		Doc:      "sends a message request to the client-streaming client entrypoint for " + method.FullName + ".",
+		Params:   "handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t",
+		Return:   "C.int32_t",
+		StreamOp: true,
+	})
 	g.P("handleValue := int32(handle)")
 	g.P("req := &", g.QualifiedGoIdent(protogen.GoIdent{GoName: method.Request.GoName, GoImportPath: protogen.GoImportPath(method.Request.GoImportPath)}), "{}")
 	g.P("if err := rpcruntime.DecodeMessage(uintptr(requestPtr), int32(requestLen), req); err != nil {")
@@ -141,10 +180,13 @@ func renderMessageClientStreamingCExportWrappers(g *protogen.GeneratedFile, plan
 	g.P()
 
 	finishName := messageCExportFuncName(plan, service, method, "finish")
-	renderCGOExportDoc(g, finishName, "finishes the message client-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", finishName)
-	g.P("func ", finishName, "(handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     finishName,
+		Doc:      "finishes the message client-streaming client entrypoint for " + method.FullName + ".",
+		Params:   "handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t",
+		Return:   "C.int32_t",
+		StreamOp: true,
+	})
 	renderMessageCExportOutputValidation(g)
 	g.P("handleValue := int32(handle)")
 	g.P("resp, err := ", servicePackage, runtimeMessageStreamOperationCallName(service, method, "Finish"), "(ctx, rpcruntime.StreamHandle(handleValue))")
@@ -162,10 +204,13 @@ func renderMessageClientStreamingCExportWrappers(g *protogen.GeneratedFile, plan
 	g.P()
 
 	cancelName := messageCExportFuncName(plan, service, method, "cancel")
-	renderCGOExportDoc(g, cancelName, "cancels the message client-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", cancelName)
-	g.P("func ", cancelName, "(handle C.int32_t) C.int32_t {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     cancelName,
+		Doc:      "cancels the message client-streaming client entrypoint for " + method.FullName + ".",
+		Params:   "handle C.int32_t",
+		Return:   "C.int32_t",
+		StreamOp: true,
+	})
 	g.P("handleValue := int32(handle)")
 	g.P("err := ", servicePackage, runtimeMessageStreamOperationCallName(service, method, "Cancel"), "(ctx, rpcruntime.StreamHandle(handleValue))")
 	g.P("if err != nil {")
@@ -178,10 +223,13 @@ func renderMessageClientStreamingCExportWrappers(g *protogen.GeneratedFile, plan
 
 func renderMessageServerStreamingCExportWrappers(g *protogen.GeneratedFile, plan FilePlan, service ServicePlan, method MethodPlan, servicePackage string) {
 	startName := messageCExportFuncName(plan, service, method, "start")
-	renderCGOExportDoc(g, startName, "starts the message server-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", startName)
-	g.P("func ", startName, "(requestPtr C.uintptr_t, requestLen C.int32_t, handle *C.int32_t, onRecv C.", messageOnRecvCallbackName(service), ", onDone C.", messageOnDoneCallbackName(service), ") C.int32_t {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:         startName,
+		Doc:          "starts the message server-streaming client entrypoint for " + method.FullName + ".",
+		Params:       "requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t, onRecv C." + messageOnRecvCallbackName(service) + ", onDone C." + messageOnDoneCallbackName(service),
+		Return:       "C.int32_t",
+		KeepsContext: true,
+	})
 	renderMessageCExportHandleValidation(g)
 	g.P("req := &", g.QualifiedGoIdent(protogen.GoIdent{GoName: method.Request.GoName, GoImportPath: protogen.GoImportPath(method.Request.GoImportPath)}), "{}")
 	g.P("if err := rpcruntime.DecodeMessage(uintptr(requestPtr), int32(requestLen), req); err != nil {")
@@ -200,10 +248,13 @@ func renderMessageServerStreamingCExportWrappers(g *protogen.GeneratedFile, plan
 	g.P()
 
 	recvName := messageCExportFuncName(plan, service, method, "recv")
-	renderCGOExportDoc(g, recvName, "receives a message response from the server-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", recvName)
-	g.P("func ", recvName, "(handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     recvName,
+		Doc:      "receives a message response from the server-streaming client entrypoint for " + method.FullName + ".",
+		Params:   "handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t",
+		Return:   "C.int32_t",
+		StreamOp: true,
+	})
 	renderMessageCExportOutputValidation(g)
 	g.P("handleValue := int32(handle)")
 	g.P("if rpcruntime.StreamCallbackReceiveEnabled(rpcruntime.StreamHandle(handleValue)) {")
@@ -223,10 +274,13 @@ func renderMessageServerStreamingCExportWrappers(g *protogen.GeneratedFile, plan
 	g.P("}")
 	g.P()
 	cancelName := messageCExportFuncName(plan, service, method, "cancel")
-	renderCGOExportDoc(g, cancelName, "cancels the message server-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", cancelName)
-	g.P("func ", cancelName, "(handle C.int32_t) C.int32_t {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     cancelName,
+		Doc:      "cancels the message server-streaming client entrypoint for " + method.FullName + ".",
+		Params:   "handle C.int32_t",
+		Return:   "C.int32_t",
+		StreamOp: true,
+	})
 	g.P("handleValue := int32(handle)")
 	g.P("callbackState, _ := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))")
 	g.P("if callbackState != nil { callbackState.MarkCanceled() }")
@@ -239,10 +293,13 @@ func renderMessageServerStreamingCExportWrappers(g *protogen.GeneratedFile, plan
 	g.P("}")
 	g.P()
 	closeName := messageCExportFuncName(plan, service, method, "close")
-	renderCGOExportDoc(g, closeName, "closes callback receive ownership for the message server-streaming client entrypoint for "+method.FullName+" without delivering further callbacks.")
-	g.P("//export ", closeName)
-	g.P("func ", closeName, "(handle C.int32_t) C.int32_t {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     closeName,
+		Doc:      "closes callback receive ownership for the message server-streaming client entrypoint for " + method.FullName + " without delivering further callbacks.",
+		Params:   "handle C.int32_t",
+		Return:   "C.int32_t",
+		StreamOp: true,
+	})
 	renderMessageCallbackReceiveCloseBody(g, service, method, servicePackage)
 	g.P("}")
 	g.P()
@@ -250,10 +307,13 @@ func renderMessageServerStreamingCExportWrappers(g *protogen.GeneratedFile, plan
 
 func renderMessageBidiStreamingCExportWrappers(g *protogen.GeneratedFile, plan FilePlan, service ServicePlan, method MethodPlan, servicePackage string) {
 	startName := messageCExportFuncName(plan, service, method, "start")
-	renderCGOExportDoc(g, startName, "starts the message bidi-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", startName)
-	g.P("func ", startName, "(handle *C.int32_t, onRecv C.", messageOnRecvCallbackName(service), ", onDone C.", messageOnDoneCallbackName(service), ") C.int32_t {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:         startName,
+		Doc:          "starts the message bidi-streaming client entrypoint for " + method.FullName + ".",
+		Params:       "handle *C.int32_t, onRecv C." + messageOnRecvCallbackName(service) + ", onDone C." + messageOnDoneCallbackName(service),
+		Return:       "C.int32_t",
+		KeepsContext: true,
+	})
 	renderMessageCExportHandleValidation(g)
 	g.P("handleValue, err := ", servicePackage, runtimeMessageStreamOperationCallName(service, method, "Start"), "(ctx)")
 	g.P("if err != nil {")
@@ -268,10 +328,13 @@ func renderMessageBidiStreamingCExportWrappers(g *protogen.GeneratedFile, plan F
 	g.P()
 
 	sendName := messageCExportFuncName(plan, service, method, "send")
-	renderCGOExportDoc(g, sendName, "sends a message request to the bidi-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", sendName)
-	g.P("func ", sendName, "(handle C.int32_t, requestPtr C.uintptr_t, requestLen C.int32_t) C.int32_t {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     sendName,
+		Doc:      "sends a message request to the bidi-streaming client entrypoint for " + method.FullName + ".",
+		Params:   "handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t",
+		Return:   "C.int32_t",
+		StreamOp: true,
+	})
 	g.P("handleValue := int32(handle)")
 	g.P("req := &", g.QualifiedGoIdent(protogen.GoIdent{GoName: method.Request.GoName, GoImportPath: protogen.GoImportPath(method.Request.GoImportPath)}), "{}")
 	g.P("if err := rpcruntime.DecodeMessage(uintptr(requestPtr), int32(requestLen), req); err != nil {")
@@ -285,10 +348,13 @@ func renderMessageBidiStreamingCExportWrappers(g *protogen.GeneratedFile, plan F
 	g.P()
 
 	recvName := messageCExportFuncName(plan, service, method, "recv")
-	renderCGOExportDoc(g, recvName, "receives a message response from the bidi-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", recvName)
-	g.P("func ", recvName, "(handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     recvName,
+		Doc:      "receives a message response from the bidi-streaming client entrypoint for " + method.FullName + ".",
+		Params:   "handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t",
+		Return:   "C.int32_t",
+		StreamOp: true,
+	})
 	renderMessageCExportOutputValidation(g)
 	g.P("handleValue := int32(handle)")
 	g.P("if rpcruntime.StreamCallbackReceiveEnabled(rpcruntime.StreamHandle(handleValue)) {")
@@ -308,10 +374,13 @@ func renderMessageBidiStreamingCExportWrappers(g *protogen.GeneratedFile, plan F
 	g.P("}")
 	g.P()
 	closeSendName := messageCExportFuncName(plan, service, method, "close_send")
-	renderCGOExportDoc(g, closeSendName, "closes the message bidi-streaming client send side for "+method.FullName+".")
-	g.P("//export ", closeSendName)
-	g.P("func ", closeSendName, "(handle C.int32_t) C.int32_t {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     closeSendName,
+		Doc:      "closes the message bidi-streaming client send side for " + method.FullName + ".",
+		Params:   "handle C.int32_t",
+		Return:   "C.int32_t",
+		StreamOp: true,
+	})
 	g.P("handleValue := int32(handle)")
 	g.P("err := ", servicePackage, runtimeMessageStreamOperationCallName(service, method, "CloseSend"), "(ctx, rpcruntime.StreamHandle(handleValue))")
 	g.P("if err != nil {")
@@ -322,10 +391,13 @@ func renderMessageBidiStreamingCExportWrappers(g *protogen.GeneratedFile, plan F
 	g.P()
 
 	finishName := messageCExportFuncName(plan, service, method, "finish")
-	renderCGOExportDoc(g, finishName, "finishes the message bidi-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", finishName)
-	g.P("func ", finishName, "(handle C.int32_t) C.int32_t {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     finishName,
+		Doc:      "finishes the message bidi-streaming client entrypoint for " + method.FullName + ".",
+		Params:   "handle C.int32_t",
+		Return:   "C.int32_t",
+		StreamOp: true,
+	})
 	g.P("handleValue := int32(handle)")
 	g.P("callbackState, _ := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))")
 	g.P("if callbackState != nil { callbackState.MarkCanceled() }")
@@ -339,10 +411,13 @@ func renderMessageBidiStreamingCExportWrappers(g *protogen.GeneratedFile, plan F
 	g.P()
 
 	cancelName := messageCExportFuncName(plan, service, method, "cancel")
-	renderCGOExportDoc(g, cancelName, "cancels the message bidi-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", cancelName)
-	g.P("func ", cancelName, "(handle C.int32_t) C.int32_t {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     cancelName,
+		Doc:      "cancels the message bidi-streaming client entrypoint for " + method.FullName + ".",
+		Params:   "handle C.int32_t",
+		Return:   "C.int32_t",
+		StreamOp: true,
+	})
 	g.P("handleValue := int32(handle)")
 	g.P("callbackState, _ := rpcruntime.StreamCallbackReceiveState(rpcruntime.StreamHandle(handleValue))")
 	g.P("if callbackState != nil { callbackState.MarkCanceled() }")
@@ -355,10 +430,13 @@ func renderMessageBidiStreamingCExportWrappers(g *protogen.GeneratedFile, plan F
 	g.P("}")
 	g.P()
 	closeName := messageCExportFuncName(plan, service, method, "close")
-	renderCGOExportDoc(g, closeName, "closes callback receive ownership for the message bidi-streaming client entrypoint for "+method.FullName+" without delivering further callbacks.")
-	g.P("//export ", closeName)
-	g.P("func ", closeName, "(handle C.int32_t) C.int32_t {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     closeName,
+		Doc:      "closes callback receive ownership for the message bidi-streaming client entrypoint for " + method.FullName + " without delivering further callbacks.",
+		Params:   "handle C.int32_t",
+		Return:   "C.int32_t",
+		StreamOp: true,
+	})
 	renderMessageCallbackReceiveCloseBody(g, service, method, servicePackage)
 	g.P("}")
 	g.P()
@@ -416,6 +494,9 @@ func renderMessageCallbackReceiveStart(g *protogen.GeneratedFile, service Servic
 	g.P("return C.int32_t(rpcruntime.StoreError(err))")
 	g.P("}")
 	g.P("go func() {")
+	g.P("defer rpcruntime.RecoverPanic(", strconv.Quote(method.FullName+" callback receive"), ", func(err error) {")
+	renderMessageCallbackReceiveFinish(g, service, handleValue, onDone, "int32(rpcruntime.StoreError(err))")
+	g.P("})")
 	g.P("for {")
 	g.P("resp, err := source.Recv(context.Background())")
 	g.P("if err != nil {")
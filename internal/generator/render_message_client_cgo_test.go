@@ -18,11 +18,16 @@ func TestRenderMessageClientCGODefinesUnaryExportSurface(t *testing.T) {
 		`fmt "fmt"`,
 		`rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"`,
 		"//export rpccgoMsgTestv1GreeterUnary",
-		"func rpccgoMsgTestv1GreeterUnary(requestPtr C.uintptr_t, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {",
+		"func rpccgoMsgTestv1GreeterUnary(requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {",
+		"//export rpccgoMsgTestv1GreeterUnaryInto",
+		"func rpccgoMsgTestv1GreeterUnaryInto(requestPtr unsafe.Pointer, requestLen C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, responseLen *C.int32_t) C.int32_t {",
+		"buffer, bufferErr := rpcruntime.NewOutputBuffer(bufferPtr, int32(bufferCap))",
+		"_, encodeErr := buffer.PutMessage(resp)",
+		`unsafe "unsafe"`,
 		"//export rpccgoMsgTestv1GreeterUploadStart",
 		"func rpccgoMsgTestv1GreeterUploadStart(handle *C.int32_t) C.int32_t {",
 		"//export rpccgoMsgTestv1GreeterUploadSend",
-		"func rpccgoMsgTestv1GreeterUploadSend(handle C.int32_t, requestPtr C.uintptr_t, requestLen C.int32_t) C.int32_t {",
+		"func rpccgoMsgTestv1GreeterUploadSend(handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) C.int32_t {",
 		"//export rpccgoMsgTestv1GreeterUploadFinish",
 		"func rpccgoMsgTestv1GreeterUploadFinish(handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {",
 		"//export rpccgoMsgTestv1GreeterListStart",
@@ -30,7 +35,7 @@ func TestRenderMessageClientCGODefinesUnaryExportSurface(t *testing.T) {
 		"typedef void (*GreeterRpccgoMessageOnDoneCallback)(int32_t stream, int32_t err_id);",
 		"static inline void callGreeterRpccgoMessageOnRecvCallback",
 		"static inline void callGreeterRpccgoMessageOnDoneCallback",
-		"func rpccgoMsgTestv1GreeterListStart(requestPtr C.uintptr_t, requestLen C.int32_t, handle *C.int32_t, onRecv C.GreeterRpccgoMessageOnRecvCallback, onDone C.GreeterRpccgoMessageOnDoneCallback) C.int32_t {",
+		"func rpccgoMsgTestv1GreeterListStart(requestPtr unsafe.Pointer, requestLen C.int32_t, handle *C.int32_t, onRecv C.GreeterRpccgoMessageOnRecvCallback, onDone C.GreeterRpccgoMessageOnDoneCallback) C.int32_t {",
 		"//export rpccgoMsgTestv1GreeterListRecv",
 		"func rpccgoMsgTestv1GreeterListRecv(handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {",
 		"callbackState, err := rpcruntime.EnableStreamCallbackReceive(rpcruntime.StreamHandle(handleValue))",
@@ -52,7 +57,18 @@ func TestRenderMessageClientCGODefinesUnaryExportSurface(t *testing.T) {
 		`if err := v1.GreeterMessageUploadSend(ctx, rpcruntime.StreamHandle(handleValue), req); err != nil {`,
 		`resp, err := v1.GreeterMessageUploadFinish(ctx, rpcruntime.StreamHandle(handleValue))`,
 		`err := v1.GreeterMessageChatCloseSend(ctx, rpcruntime.StreamHandle(handleValue))`,
-		"ctx := context.Background()",
+		"return rpccgoMsgTestv1GreeterUnaryWithContext(context.Background(), requestPtr, requestLen, responsePtr, responseLen)",
+		"//export rpccgoMsgTestv1GreeterUnaryWithOptions",
+		"func rpccgoMsgTestv1GreeterUnaryWithOptions(options C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {",
+		"ctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))",
+		"defer cancel()",
+		"func rpccgoMsgTestv1GreeterUploadStartWithOptions(options C.int32_t, handle *C.int32_t) C.int32_t {\n\tctx, cancel, err := rpcruntime.CallOptionsContext(rpcruntime.CallOptionsHandle(options))",
+		"func rpccgoMsgTestv1GreeterUploadFinishWithOptions(options C.int32_t, handle C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) C.int32_t {\n\tctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))",
+		"func rpccgoMsgTestv1GreeterChatSendWithOptions(options C.int32_t, handle C.int32_t, requestPtr unsafe.Pointer, requestLen C.int32_t) C.int32_t {\n\tctx, cancel, err := rpcruntime.CallOptionsStreamContext(rpcruntime.CallOptionsHandle(options))",
+		"func rpccgoMsgTestv1GreeterUnaryWithContext(ctx context.Context, requestPtr unsafe.Pointer, requestLen C.int32_t, responsePtr *C.uintptr_t, responseLen *C.int32_t) (errID C.int32_t) {",
+		`defer rpcruntime.RecoverPanic("rpccgoMsgTestv1GreeterUnary", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })`,
+		`defer rpcruntime.RecoverPanic("test.v1.Greeter.List callback receive", func(err error) {`,
+		"status := rpccgoMsgTestv1GreeterListStartWithContext(ctx, requestPtr, requestLen, handle, onRecv, onDone)",
 		`return C.int32_t(rpcruntime.StoreError(errors.New("rpccgo: message client output pointer is nil")))`,
 		"req := &v1.HelloRequest{}",
 		`if err := rpcruntime.DecodeMessage(uintptr(requestPtr), int32(requestLen), req); err != nil {`,
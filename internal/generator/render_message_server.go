@@ -64,11 +64,16 @@ func renderMessageServerFile(plugin *protogen.Plugin, plan FilePlan, service Ser
 	renderUnimplementedCGOMessageServer(g, service, runtimeMethods)
 	renderDoc(g, "Register"+service.GoName+"CGOMessageServer", "registers a cgo message server as the current server for "+service.GoName+".")
 	g.P("func Register", service.GoName, "CGOMessageServer(server ", serverName, ") error {")
+	g.P("return Register", service.GoName, "CGOMessageServerIn(rpcruntime.DefaultServerRegistry(), server)")
+	g.P("}")
+	g.P()
+	renderDoc(g, "Register"+service.GoName+"CGOMessageServerIn", "registers a cgo message server as the current server for "+service.GoName+" in registry.")
+	g.P("func Register", service.GoName, "CGOMessageServerIn(registry *rpcruntime.ServerRegistry, server ", serverName, ") error {")
 	g.P("if server == nil {")
-	g.P("_ = register", service.GoName, "CGOMessageServer(server)")
+	g.P("_ = register", service.GoName, "CGOMessageServerIn(registry, server)")
 	g.P(`return errors.New("rpccgo: `, service.GoName, ` cgo message server is nil")`)
 	g.P("}")
-	g.P("return register", service.GoName, "CGOMessageServer(server)")
+	g.P("return register", service.GoName, "CGOMessageServerIn(registry, server)")
 	g.P("}")
 	g.P()
 	if err := renderCGOMessageServerRuntimeRegistration(g, service); err != nil {
@@ -164,5 +169,8 @@ func validateMessageServerSymbols(service ServicePlan) error {
 	if err := addGenerated("Register"+service.GoName+"CGOMessageServer", service.FullName+" cgo message server registration"); err != nil {
 		return err
 	}
+	if err := addGenerated("Register"+service.GoName+"CGOMessageServerIn", service.FullName+" registry-scoped cgo message server registration"); err != nil {
+		return err
+	}
 	return nil
 }
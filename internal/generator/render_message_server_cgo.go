@@ -176,7 +176,9 @@ func renderCGOMessageServerUnaryAdapter(g *protogen.GeneratedFile, service Servi
 	renderCGOMessageRequestPtrLen(g, "reqBytes", "return nil, err")
 	g.P("var responsePtr C.uintptr_t")
 	g.P("var responseLen C.int32_t")
+	g.P("leaveTrace := ", cgoEnterCallbackTraceName, "(ctx)")
 	g.P("errID := int32(C.", messageCGOServerUnaryTrampolineName(service, method), "(callback, C.uintptr_t(requestPtr), C.int32_t(requestLen), &responsePtr, &responseLen))")
+	g.P("leaveTrace()")
 	g.P("if errID != 0 {")
 	g.P("return nil, ", messageCGOServerErrorIDHelperName(service), "(errID)")
 	g.P("}")
@@ -375,21 +377,27 @@ func renderCGOMessageErrorIDHelper(g *protogen.GeneratedFile, service ServicePla
 	g.P("if errID == 0 {")
 	g.P("return nil")
 	g.P("}")
-	g.P("text, ptr, ok := rpcruntime.TakeErrorText(rpcruntime.ErrorID(errID))")
+	g.P("statusErr, ok := rpcruntime.TakeError(rpcruntime.ErrorID(errID))")
 	g.P("if ok {")
-	g.P("if ptr != 0 {")
-	g.P("defer rpcruntime.Release(ptr)")
-	g.P("}")
-	g.P("if string(text) == io.EOF.Error() {")
+	g.P("if statusErr.Message == io.EOF.Error() {")
 	g.P("return io.EOF")
 	g.P("}")
-	g.P("return errors.New(string(text))")
+	g.P("return ", cgoServerTransportErrorFunc(service), "(statusErr)")
 	g.P("}")
 	g.P(`return fmt.Errorf("rpccgo: cgo message server callback returned unknown error id %d", errID)`)
 	g.P("}")
 	g.P()
 }
 
+// cgoServerTransportErrorFunc names the rpcruntime conversion that turns a coded
+// C callback error into the error type the service's network transport reports.
+func cgoServerTransportErrorFunc(service ServicePlan) string {
+	if service.Generation.MessageTransport == MessageTransportGRPC {
+		return "rpcruntime.GRPCError"
+	}
+	return "rpcruntime.ConnectError"
+}
+
 func renderCGOMessageServerTrampolines(g *protogen.GeneratedFile, service ServicePlan, method MethodPlan) {
 	switch method.Streaming {
 	case StreamingKindUnary:
@@ -427,7 +435,9 @@ func renderCGOMessageServerClientStreamAdapter(g *protogen.GeneratedFile, servic
 	g.P("return nil, ", cgoMessageServerMethodUnimplementedError(service, method))
 	g.P("}")
 	g.P("var stream C.int32_t")
+	g.P("leaveTrace := ", cgoEnterCallbackTraceName, "(ctx)")
 	g.P("errID := int32(C.", messageCGOServerClientStreamStartTrampolineName(service, method), "(a.", cgoMessageServerCallbackFieldName(method, "Start"), ", &stream))")
+	g.P("leaveTrace()")
 	g.P("if errID != 0 {")
 	g.P("return nil, ", messageCGOServerErrorIDHelperName(service), "(errID)")
 	g.P("}")
@@ -491,7 +501,9 @@ func renderCGOMessageServerServerStreamAdapter(g *protogen.GeneratedFile, servic
 	renderCGOMessageMarshalRequest(g, "req", "reqBytes", "return nil, err")
 	renderCGOMessageRequestPtrLen(g, "reqBytes", "return nil, err")
 	g.P("var stream C.int32_t")
+	g.P("leaveTrace := ", cgoEnterCallbackTraceName, "(ctx)")
 	g.P("errID := int32(C.", messageCGOServerServerStreamStartTrampolineName(service, method), "(a.", cgoMessageServerCallbackFieldName(method, "Start"), ", C.uintptr_t(requestPtr), C.int32_t(requestLen), &stream))")
+	g.P("leaveTrace()")
 	g.P("if errID != 0 { return nil, ", messageCGOServerErrorIDHelperName(service), "(errID) }")
 	g.P("return &", clientName, "{recv: a.", cgoMessageServerCallbackFieldName(method, "Recv"), ", finish: a.", cgoMessageServerCallbackFieldName(method, "Finish"), ", cancel: a.", cgoMessageServerCallbackFieldName(method, "Cancel"), ", stream: int32(stream)}, nil")
 	g.P("}")
@@ -560,7 +572,9 @@ func renderCGOMessageServerBidiStreamAdapter(g *protogen.GeneratedFile, service
 	g.P("func (a *", adapterName, ") ", method.GoName, "Start(ctx context.Context) (", cgoMessageBidiStreamingClientType(g, method), ", error) {")
 	renderCGOMessageStartGuard(g, service, method)
 	g.P("var stream C.int32_t")
+	g.P("leaveTrace := ", cgoEnterCallbackTraceName, "(ctx)")
 	g.P("errID := int32(C.", messageCGOServerBidiStreamStartTrampolineName(service, method), "(a.", cgoMessageServerCallbackFieldName(method, "Start"), ", &stream))")
+	g.P("leaveTrace()")
 	g.P("if errID != 0 { return nil, ", messageCGOServerErrorIDHelperName(service), "(errID) }")
 	g.P("return &", clientName, "{send: a.", cgoMessageServerCallbackFieldName(method, "Send"), ", recv: a.", cgoMessageServerCallbackFieldName(method, "Recv"), ", closeSend: a.", cgoMessageServerCallbackFieldName(method, "CloseSend"), ", finish: a.", cgoMessageServerCallbackFieldName(method, "Finish"), ", cancel: a.", cgoMessageServerCallbackFieldName(method, "Cancel"), ", stream: int32(stream)}, nil")
 	g.P("}")
@@ -22,7 +22,8 @@ func TestRenderMessageServerCGODefinesFlatServiceRegistration(t *testing.T) {
 		`protobuf "google.golang.org/protobuf/proto"`,
 		`rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"`,
 		`rpccgo: message request protobuf marshal failed`,
-		"rpcruntime.TakeErrorText",
+		"statusErr, ok := rpcruntime.TakeError(rpcruntime.ErrorID(errID))",
+		"return rpcruntime.ConnectError(statusErr)",
 		"unknown error id",
 		"typedef int32_t (*GreeterUnaryCGOMessageUnaryCallback)(uintptr_t request_ptr, int32_t request_len, uintptr_t* response_ptr, int32_t* response_len);",
 		"static inline int32_t callGreeterUnaryCGOMessageUnary(GreeterUnaryCGOMessageUnaryCallback callback, uintptr_t request_ptr, int32_t request_len, uintptr_t* response_ptr, int32_t* response_len) {",
@@ -111,3 +112,22 @@ func TestRenderMessageServerCGODefinesFlatServiceRegistration(t *testing.T) {
 	}
 	t.Fatalf("generated file %q not found", cgoServerFile)
 }
+
+func TestRenderMessageServerCGOMapsCallbackErrorsToGRPCStatus(t *testing.T) {
+	file := grpcStreamingRuntimeTestFile()
+	plugin := newTestPluginGenerating(t, "paths=source_relative", "test/v1/grpc_streaming_runtime.proto", file)
+
+	if _, err := GenerateWithOptions(plugin); err != nil {
+		t.Fatalf("GenerateWithOptions() error = %v", err)
+	}
+
+	for _, generated := range plugin.Response().GetFile() {
+		if !strings.HasSuffix(generated.GetName(), ".server.message.cgo.rpccgo.go") {
+			continue
+		}
+		assertGeneratedContentContains(t, plugin, generated.GetName(), "return rpcruntime.GRPCError(statusErr)")
+		assertGeneratedFileContentDoesNotContain(t, plugin, generated.GetName(), "rpcruntime.ConnectError(")
+		return
+	}
+	t.Fatal("no cgo message server file generated for gRPC service")
+}
@@ -27,8 +27,10 @@ func TestRenderMessageServerDefinesUnimplementedHelper(t *testing.T) {
 		"func (UnimplementedAllServiceCGOMessageServer) ServerStream(ctx context.Context, req *AllRequest, stream rpcruntime.ServerStreamingServer[*AllReply]) error {",
 		"func (UnimplementedAllServiceCGOMessageServer) BidiStream(ctx context.Context, stream rpcruntime.BidiStreamingServer[*AllRequest, *AllReply]) error {",
 		"func RegisterAllServiceCGOMessageServer(server AllServiceCGOMessageServer) error {",
-		"return registerAllServiceCGOMessageServer(server)",
-		"func registerAllServiceCGOMessageServer(server AllServiceCGOMessageServer) error {",
+		"return RegisterAllServiceCGOMessageServerIn(rpcruntime.DefaultServerRegistry(), server)",
+		"func RegisterAllServiceCGOMessageServerIn(registry *rpcruntime.ServerRegistry, server AllServiceCGOMessageServer) error {",
+		"return registerAllServiceCGOMessageServerIn(registry, server)",
+		"func registerAllServiceCGOMessageServerIn(registry *rpcruntime.ServerRegistry, server AllServiceCGOMessageServer) error {",
 		"Kind:   rpcruntime.ServerKindCGOMessage,",
 		"Server: server,",
 		"func allServiceClientStreamCGOMessageStart(ctx context.Context, server AllServiceCGOMessageServer) (rpcruntime.ClientStreamingClient[*AllRequest, *AllReply], error)",
@@ -3,6 +3,7 @@ package generator
 import (
 	"fmt"
 	"path"
+	"strconv"
 	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
@@ -137,8 +138,11 @@ func nativeClientResponseOutputParams(fields []FieldPlan) string {
 }
 
 func nativeClientRequestParamType(field FieldPlan, param string) string {
+	if nativeClientPresenceSymbol(field, param) {
+		return "int8"
+	}
 	if strings.HasSuffix(param, "Ptr") {
-		return "uintptr"
+		return "unsafe.Pointer"
 	}
 	if strings.HasSuffix(param, "Len") || strings.HasSuffix(param, "Ownership") {
 		return "int32"
@@ -147,6 +151,9 @@ func nativeClientRequestParamType(field FieldPlan, param string) string {
 }
 
 func nativeClientOutputParamType(field FieldPlan, param string) string {
+	if nativeClientPresenceSymbol(field, param) {
+		return "int8"
+	}
 	if strings.HasSuffix(param, "Ptr") {
 		return "uintptr"
 	}
@@ -229,6 +236,16 @@ func nativeClientOutputLenSymbol(field FieldPlan) string {
 	return "out" + field.GoName + "Len"
 }
 
+func nativeClientOutputPresenceSymbol(field FieldPlan) string {
+	return "out" + nativePresenceName(field)
+}
+
+// nativeClientPresenceSymbol reports whether symbol is the presence flag of
+// field, which is an int8 whatever the field kind.
+func nativeClientPresenceSymbol(field FieldPlan, symbol string) bool {
+	return field.Presence && (symbol == nativePresenceName(field) || symbol == nativeClientOutputPresenceSymbol(field))
+}
+
 func renderNativeClientStreamFacadeCall(g *protogen.GeneratedFile, service ServicePlan, method MethodPlan, servicePackage, operation, args string) {
 	g.P("err = ", servicePackage, runtimeNativeStreamOperationCallName(service, method, operation), "(", nativeClientStreamOperationArgs(args), ")")
 }
@@ -275,6 +292,25 @@ func renderNativeClientRequestDecoder(g *protogen.GeneratedFile, name string, fi
 
 func renderNativeRequestFieldDecode(g *protogen.GeneratedFile, fields []FieldPlan, field FieldPlan, unsupportedError string) {
 	name := nativeClientValueName(field)
+	if !field.Presence {
+		renderNativeRequestFieldValueDecode(g, fields, field, name, unsupportedError)
+		return
+	}
+	// A field with presence decodes its value slots only when the flag is
+	// set, and is otherwise left nil.
+	g.P("var ", name, " ", nativeGoRequestFieldType(g, field))
+	g.P("if ", nativePresenceName(field), " != 0 {")
+	present := name + "Present"
+	renderNativeRequestFieldValueDecode(g, fields, field, present, unsupportedError)
+	if field.Kind == FieldKindString || nativeFieldIsBytes(field) {
+		g.P(name, " = ", present)
+	} else {
+		g.P(name, " = &", present)
+	}
+	g.P("}")
+}
+
+func renderNativeRequestFieldValueDecode(g *protogen.GeneratedFile, fields []FieldPlan, field FieldPlan, name, unsupportedError string) {
 	switch field.Native.Shape {
 	case NativeABIShapeBoolByte:
 		g.P(name, " := ", field.GoName, " != 0")
@@ -299,6 +335,9 @@ func renderNativeRequestFieldDecode(g *protogen.GeneratedFile, fields []FieldPla
 		default:
 			g.P("return ", nativeClientDecodeErrorReturn(fields, unsupportedError))
 		}
+	case NativeABIShapeBufferArray:
+		wrapper, typeArgs := nativeBufferArrayWrapper(g, field)
+		renderNativeClientRepeatedDecode(g, fields, field, name, "rpcruntime.RpcBuffer", "rpcruntime."+wrapper+typeArgs, "rpcruntime.Empty"+wrapper+typeArgs+"()", "rpcruntime.New"+wrapper+"Checked"+typeArgs)
 	case NativeABIShapeScalar, NativeABIShapeMessageBytes:
 		switch field.Kind {
 		case FieldKindSignedInt32, FieldKindSignedInt64, FieldKindUnsignedInt32, FieldKindUnsignedInt64, FieldKindFloat, FieldKindDouble:
@@ -380,7 +419,7 @@ func nativeClientRequestCleanupError(fields []FieldPlan) string {
 
 func nativeClientFieldNeedsRequestRelease(field FieldPlan) bool {
 	switch field.Native.Shape {
-	case NativeABIShapeBoolByteBufferWrapper, NativeABIShapeRepeated:
+	case NativeABIShapeBoolByteBufferWrapper, NativeABIShapeRepeated, NativeABIShapeBufferArray:
 		return true
 	case NativeABIShapeScalar, NativeABIShapeMessageBytes:
 		return field.Kind == FieldKindString || field.Kind == FieldKindBytes || field.Kind == FieldKindMessage
@@ -401,11 +440,11 @@ func renderNativeClientRepeatedDecode(g *protogen.GeneratedFile, fields []FieldP
 	g.P(`return `, nativeClientDecodeErrorReturn(fields, `fmt.Errorf("`+field.FullName+`: %w", err)`))
 	g.P("}")
 	g.P("var ", name, " *", wrapperType)
-	g.P("if ", field.GoName, "Ptr == 0 || ", field.GoName, "Len == 0 {")
+	g.P("if ", field.GoName, "Ptr == nil || ", field.GoName, "Len == 0 {")
 	g.P(name, " = ", emptyExpr)
 	g.P("} else {")
 	g.P("var decodeErr error")
-	g.P(name, ", decodeErr = ", ctor, "((*", elemType, ")(unsafe.Pointer(", field.GoName, "Ptr)), ", field.GoName, "Len, ", field.GoName, "Ownership > 0)")
+	g.P(name, ", decodeErr = ", ctor, "((*", elemType, ")(", field.GoName, "Ptr), ", field.GoName, "Len, ", field.GoName, "Ownership > 0)")
 	g.P("if decodeErr != nil {")
 	g.P(`return `, nativeClientDecodeErrorReturn(fields, `fmt.Errorf("`+field.FullName+`: %w", decodeErr)`))
 	g.P("}")
@@ -417,11 +456,11 @@ func renderNativeClientStringDecode(g *protogen.GeneratedFile, fields []FieldPla
 	g.P(`return `, nativeClientDecodeErrorReturn(fields, `fmt.Errorf("`+field.FullName+`: %w", err)`))
 	g.P("}")
 	g.P("var ", name, " *rpcruntime.RpcString")
-	g.P("if ", field.GoName, "Ptr == 0 || ", field.GoName, "Len == 0 {")
+	g.P("if ", field.GoName, "Ptr == nil || ", field.GoName, "Len == 0 {")
 	g.P(name, " = rpcruntime.EmptyRpcString()")
 	g.P("} else {")
 	g.P("var decodeErr error")
-	g.P(name, ", decodeErr = rpcruntime.NewRpcStringChecked((*byte)(unsafe.Pointer(", field.GoName, "Ptr)), ", field.GoName, "Len, ", field.GoName, "Ownership > 0)")
+	g.P(name, ", decodeErr = rpcruntime.NewRpcStringChecked((*byte)(", field.GoName, "Ptr), ", field.GoName, "Len, ", field.GoName, "Ownership > 0)")
 	g.P("if decodeErr != nil {")
 	g.P(`return `, nativeClientDecodeErrorReturn(fields, `fmt.Errorf("`+field.FullName+`: %w", decodeErr)`))
 	g.P("}")
@@ -433,11 +472,11 @@ func renderNativeClientBytesDecode(g *protogen.GeneratedFile, fields []FieldPlan
 	g.P(`return `, nativeClientDecodeErrorReturn(fields, `fmt.Errorf("`+field.FullName+`: %w", err)`))
 	g.P("}")
 	g.P("var ", name, " *rpcruntime.RpcBytes")
-	g.P("if ", field.GoName, "Ptr == 0 || ", field.GoName, "Len == 0 {")
+	g.P("if ", field.GoName, "Ptr == nil || ", field.GoName, "Len == 0 {")
 	g.P(name, " = rpcruntime.EmptyRpcBytes()")
 	g.P("} else {")
 	g.P("var decodeErr error")
-	g.P(name, ", decodeErr = rpcruntime.NewRpcBytesChecked((*byte)(unsafe.Pointer(", field.GoName, "Ptr)), ", field.GoName, "Len, ", field.GoName, "Ownership > 0)")
+	g.P(name, ", decodeErr = rpcruntime.NewRpcBytesChecked((*byte)(", field.GoName, "Ptr), ", field.GoName, "Len, ", field.GoName, "Ownership > 0)")
 	g.P("if decodeErr != nil {")
 	g.P(`return `, nativeClientDecodeErrorReturn(fields, `fmt.Errorf("`+field.FullName+`: %w", decodeErr)`))
 	g.P("}")
@@ -447,9 +486,13 @@ func renderNativeClientBytesDecode(g *protogen.GeneratedFile, fields []FieldPlan
 func renderNativeUnaryResponseEncoder(g *protogen.GeneratedFile, service ServicePlan, method MethodPlan, unsupportedError string) {
 	renderNativeClientOutputValidator(g, nativeUnaryClientOutputValidatorName(service, method), method.Contract.Native.ResponseFields)
 	renderNativeClientResponseEncoder(g, nativeUnaryClientEncoderName(service, method), method.Contract.Native.ResponseFields, unsupportedError)
+	renderNativeClientResponseIntoEncoder(g, nativeUnaryClientIntoEncoderName(service, method), nativeUnaryClientOutputValidatorName(service, method), method.Contract.Native.ResponseFields, unsupportedError)
 }
 
-func renderNativeUnaryClientCallBody(g *protogen.GeneratedFile, service ServicePlan, method MethodPlan, servicePackage, ctx, requestArgs, responseArgs string) {
+// renderNativeUnaryClientCallBody renders the decode, invoke and encode steps of
+// a unary export. With into set, variable-length outputs are written into the
+// caller's buffer and bufferLen reports the bytes used or needed.
+func renderNativeUnaryClientCallBody(g *protogen.GeneratedFile, service ServicePlan, method MethodPlan, servicePackage, ctx, requestArgs, responseArgs string, into bool) {
 	if responseArgs != "" {
 		g.P("if err := ", nativeUnaryClientOutputValidatorName(service, method), "(", responseArgs, "); err != nil {")
 		g.P("return C.int32_t(rpcruntime.StoreError(err))")
@@ -478,6 +521,15 @@ func renderNativeUnaryClientCallBody(g *protogen.GeneratedFile, service ServiceP
 	g.P("if err != nil {")
 	g.P("return C.int32_t(rpcruntime.StoreError(err))")
 	g.P("}")
+	if into {
+		g.P("encodeErr := ", nativeUnaryClientIntoEncoderName(service, method), "(", nativeCExportParamJoin(responseNames, "buffer", responseArgs), ")")
+		renderOutputBufferLenCommit(g, "bufferLen")
+		g.P("if encodeErr != nil {")
+		g.P("return C.int32_t(rpcruntime.StoreError(encodeErr))")
+		g.P("}")
+		g.P("return 0")
+		return
+	}
 	g.P("if err := ", nativeUnaryClientEncoderName(service, method), "(", nativeClientEncoderCallArgs(responseNames), responseArgs, "); err != nil {")
 	g.P("return C.int32_t(rpcruntime.StoreError(err))")
 	g.P("}")
@@ -707,51 +759,77 @@ func renderNativeCExportWrappers(g *protogen.GeneratedFile, plan FilePlan, servi
 
 func renderNativeUnaryCExportWrapper(g *protogen.GeneratedFile, service ServicePlan, method MethodPlan, servicePackage string, unaryABI COperationABI) {
 	exportName := unaryABI.Symbol
-	renderCGOExportDoc(g, exportName, "invokes the native unary client entrypoint for "+method.FullName+".")
-	g.P("//export ", exportName)
-	g.P("func ", exportName, "(", nativeCExportParams(unaryABI.Params), ") ", unaryABI.Return.CGoType, " {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:   exportName,
+		Doc:    "invokes the native unary client entrypoint for " + method.FullName + ".",
+		Params: nativeCExportParams(unaryABI.Params),
+		Return: unaryABI.Return.CGoType,
+	})
 	renderNativeCExportOutputValidation(g, method.Contract.Native.ResponseFields, unaryABI.Params)
-	renderNativeUnaryClientCallBody(g, service, method, servicePackage, "ctx", nativeCExportGoArgs(service, method), nativeCExportOutputGoArgs(service, method))
+	renderNativeUnaryClientCallBody(g, service, method, servicePackage, "ctx", nativeCExportGoArgs(service, method), nativeCExportOutputGoArgs(service, method), false)
+	g.P("}")
+	g.P()
+
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:   exportName + "Into",
+		Doc:    "is " + exportName + " writing variable-length outputs into the caller buffer at bufferPtr instead of pinned memory; output pointers point into that buffer and need no release. bufferLen receives the bytes used, or the bytes needed together with RPCCGO_ERR_BUFFER_TOO_SMALL; retrying repeats the call, and inputs passed with ownership 1 are released even then, so a retry must pass fresh ones.",
+		Params: nativeCExportParamJoin(nativeCExportParams(unaryABI.Params), outputBufferParams("bufferLen")),
+		Return: unaryABI.Return.CGoType,
+	})
+	renderNativeCExportOutputValidation(g, method.Contract.Native.ResponseFields, unaryABI.Params)
+	renderOutputBufferOpen(g, "bufferLen", "rpccgo: native client output pointer is nil")
+	renderNativeUnaryClientCallBody(g, service, method, servicePackage, "ctx", nativeCExportGoArgs(service, method), nativeCExportOutputGoArgs(service, method), true)
 	g.P("}")
 	g.P()
 }
 
 func renderNativeClientStreamingCExportWrappers(g *protogen.GeneratedFile, service ServicePlan, method MethodPlan, servicePackage string, methodABI map[NativeCOperation]COperationABI) {
 	startABI := methodABI[NativeCOperationStart]
-	renderCGOExportDoc(g, startABI.Symbol, "starts the native client-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", startABI.Symbol)
-	g.P("func ", startABI.Symbol, "(", nativeCExportParams(startABI.Params), ") ", startABI.Return.CGoType, " {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:         startABI.Symbol,
+		Doc:          "starts the native client-streaming client entrypoint for " + method.FullName + ".",
+		Params:       nativeCExportParams(startABI.Params),
+		Return:       startABI.Return.CGoType,
+		KeepsContext: true,
+	})
 	renderNativeCExportHandleValidation(g, "stream")
 	renderNativeClientStreamingStartBody(g, service, method, servicePackage, "ctx", "stream")
 	g.P("}")
 	g.P()
 
 	sendABI := methodABI[NativeCOperationSend]
-	renderCGOExportDoc(g, sendABI.Symbol, "sends native request values to the client-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", sendABI.Symbol)
-	g.P("func ", sendABI.Symbol, "(", nativeCExportParams(sendABI.Params), ") ", sendABI.Return.CGoType, " {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     sendABI.Symbol,
+		Doc:      "sends native request values to the client-streaming client entrypoint for " + method.FullName + ".",
+		Params:   nativeCExportParams(sendABI.Params),
+		Return:   sendABI.Return.CGoType,
+		StreamOp: true,
+	})
 	renderNativeClientStreamingSendBody(g, service, method, servicePackage, "ctx", "stream", nativeCExportGoArgs(service, method))
 	g.P("}")
 	g.P()
 
 	finishABI := methodABI[NativeCOperationFinish]
-	renderCGOExportDoc(g, finishABI.Symbol, "finishes the native client-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", finishABI.Symbol)
-	g.P("func ", finishABI.Symbol, "(", nativeCExportParams(finishABI.Params), ") ", finishABI.Return.CGoType, " {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     finishABI.Symbol,
+		Doc:      "finishes the native client-streaming client entrypoint for " + method.FullName + ".",
+		Params:   nativeCExportParams(finishABI.Params),
+		Return:   finishABI.Return.CGoType,
+		StreamOp: true,
+	})
 	renderNativeCExportOutputValidation(g, method.Contract.Native.ResponseFields, finishABI.Params)
 	renderNativeClientStreamingFinishBody(g, service, method, servicePackage, "ctx", "stream", nativeCExportOutputGoArgs(service, method))
 	g.P("}")
 	g.P()
 
 	cancelABI := methodABI[NativeCOperationCancel]
-	renderCGOExportDoc(g, cancelABI.Symbol, "cancels the native client-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", cancelABI.Symbol)
-	g.P("func ", cancelABI.Symbol, "(", nativeCExportParams(cancelABI.Params), ") ", cancelABI.Return.CGoType, " {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     cancelABI.Symbol,
+		Doc:      "cancels the native client-streaming client entrypoint for " + method.FullName + ".",
+		Params:   nativeCExportParams(cancelABI.Params),
+		Return:   cancelABI.Return.CGoType,
+		StreamOp: true,
+	})
 	renderNativeStreamNoResultBody(g, service, method, servicePackage, "ctx", "stream", "Cancel")
 	g.P("}")
 	g.P()
@@ -759,37 +837,49 @@ func renderNativeClientStreamingCExportWrappers(g *protogen.GeneratedFile, servi
 
 func renderNativeServerStreamingCExportWrappers(g *protogen.GeneratedFile, service ServicePlan, method MethodPlan, servicePackage string, methodABI map[NativeCOperation]COperationABI) {
 	startABI := methodABI[NativeCOperationStart]
-	renderCGOExportDoc(g, startABI.Symbol, "starts the native server-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", startABI.Symbol)
-	g.P("func ", startABI.Symbol, "(", nativeCExportParamJoin(nativeCExportParams(startABI.Params), "onRecv C."+nativeCallbackReceiveOnRecvTypeName(service, method), "onDone C.RpccgoNativeOnDoneCallback"), ") ", startABI.Return.CGoType, " {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:         startABI.Symbol,
+		Doc:          "starts the native server-streaming client entrypoint for " + method.FullName + ".",
+		Params:       nativeCExportParamJoin(nativeCExportParams(startABI.Params), "onRecv C."+nativeCallbackReceiveOnRecvTypeName(service, method), "onDone C.RpccgoNativeOnDoneCallback"),
+		Return:       startABI.Return.CGoType,
+		KeepsContext: true,
+	})
 	renderNativeCExportHandleValidation(g, "stream")
 	renderNativeServerStreamingStartBody(g, service, method, servicePackage, "ctx", "stream", nativeCExportGoArgs(service, method), true, methodABI[NativeCOperationRecv])
 	g.P("}")
 	g.P()
 
 	recvABI := methodABI[NativeCOperationRecv]
-	renderCGOExportDoc(g, recvABI.Symbol, "receives native response values from the server-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", recvABI.Symbol)
-	g.P("func ", recvABI.Symbol, "(", nativeCExportParams(recvABI.Params), ") ", recvABI.Return.CGoType, " {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     recvABI.Symbol,
+		Doc:      "receives native response values from the server-streaming client entrypoint for " + method.FullName + ".",
+		Params:   nativeCExportParams(recvABI.Params),
+		Return:   recvABI.Return.CGoType,
+		StreamOp: true,
+	})
 	renderNativeCExportOutputValidation(g, method.Contract.Native.ResponseFields, recvABI.Params)
 	renderNativeServerStreamingRecvBody(g, service, method, servicePackage, "ctx", "stream", nativeCExportOutputGoArgs(service, method))
 	g.P("}")
 	g.P()
 	cancelABI := methodABI[NativeCOperationCancel]
-	renderCGOExportDoc(g, cancelABI.Symbol, "cancels the native server-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", cancelABI.Symbol)
-	g.P("func ", cancelABI.Symbol, "(", nativeCExportParams(cancelABI.Params), ") ", cancelABI.Return.CGoType, " {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     cancelABI.Symbol,
+		Doc:      "cancels the native server-streaming client entrypoint for " + method.FullName + ".",
+		Params:   nativeCExportParams(cancelABI.Params),
+		Return:   cancelABI.Return.CGoType,
+		StreamOp: true,
+	})
 	renderNativeStreamNoResultBody(g, service, method, servicePackage, "ctx", "stream", "Cancel")
 	g.P("}")
 	g.P()
 	closeName := strings.TrimSuffix(cancelABI.Symbol, "Cancel") + "Close"
-	renderCGOExportDoc(g, closeName, "closes callback receive ownership for the native server-streaming client entrypoint for "+method.FullName+" without delivering further callbacks.")
-	g.P("//export ", closeName)
-	g.P("func ", closeName, "(stream C.int32_t) C.int32_t {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     closeName,
+		Doc:      "closes callback receive ownership for the native server-streaming client entrypoint for " + method.FullName + " without delivering further callbacks.",
+		Params:   "stream C.int32_t",
+		Return:   "C.int32_t",
+		StreamOp: true,
+	})
 	renderNativeCallbackReceiveCloseBody(g, service, method, servicePackage, "ctx", "stream")
 	g.P("}")
 	g.P()
@@ -797,64 +887,85 @@ func renderNativeServerStreamingCExportWrappers(g *protogen.GeneratedFile, servi
 
 func renderNativeBidiStreamingCExportWrappers(g *protogen.GeneratedFile, service ServicePlan, method MethodPlan, servicePackage string, methodABI map[NativeCOperation]COperationABI) {
 	startABI := methodABI[NativeCOperationStart]
-	renderCGOExportDoc(g, startABI.Symbol, "starts the native bidi-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", startABI.Symbol)
-	g.P("func ", startABI.Symbol, "(", nativeCExportParamJoin(nativeCExportParams(startABI.Params), "onRecv C."+nativeCallbackReceiveOnRecvTypeName(service, method), "onDone C.RpccgoNativeOnDoneCallback"), ") ", startABI.Return.CGoType, " {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:         startABI.Symbol,
+		Doc:          "starts the native bidi-streaming client entrypoint for " + method.FullName + ".",
+		Params:       nativeCExportParamJoin(nativeCExportParams(startABI.Params), "onRecv C."+nativeCallbackReceiveOnRecvTypeName(service, method), "onDone C.RpccgoNativeOnDoneCallback"),
+		Return:       startABI.Return.CGoType,
+		KeepsContext: true,
+	})
 	renderNativeCExportHandleValidation(g, "stream")
 	renderNativeBidiStreamingStartBody(g, service, method, servicePackage, "ctx", "stream", true, methodABI[NativeCOperationRecv])
 	g.P("}")
 	g.P()
 
 	sendABI := methodABI[NativeCOperationSend]
-	renderCGOExportDoc(g, sendABI.Symbol, "sends native request values to the bidi-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", sendABI.Symbol)
-	g.P("func ", sendABI.Symbol, "(", nativeCExportParams(sendABI.Params), ") ", sendABI.Return.CGoType, " {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     sendABI.Symbol,
+		Doc:      "sends native request values to the bidi-streaming client entrypoint for " + method.FullName + ".",
+		Params:   nativeCExportParams(sendABI.Params),
+		Return:   sendABI.Return.CGoType,
+		StreamOp: true,
+	})
 	renderNativeBidiStreamingSendBody(g, service, method, servicePackage, "ctx", "stream", nativeCExportGoArgs(service, method))
 	g.P("}")
 	g.P()
 
 	recvABI := methodABI[NativeCOperationRecv]
-	renderCGOExportDoc(g, recvABI.Symbol, "receives native response values from the bidi-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", recvABI.Symbol)
-	g.P("func ", recvABI.Symbol, "(", nativeCExportParams(recvABI.Params), ") ", recvABI.Return.CGoType, " {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     recvABI.Symbol,
+		Doc:      "receives native response values from the bidi-streaming client entrypoint for " + method.FullName + ".",
+		Params:   nativeCExportParams(recvABI.Params),
+		Return:   recvABI.Return.CGoType,
+		StreamOp: true,
+	})
 	renderNativeCExportOutputValidation(g, method.Contract.Native.ResponseFields, recvABI.Params)
 	renderNativeBidiStreamingRecvBody(g, service, method, servicePackage, "ctx", "stream", nativeCExportOutputGoArgs(service, method))
 	g.P("}")
 	g.P()
 	closeSendABI := methodABI[NativeCOperationCloseSend]
-	renderCGOExportDoc(g, closeSendABI.Symbol, "closes the native bidi-streaming client send side for "+method.FullName+".")
-	g.P("//export ", closeSendABI.Symbol)
-	g.P("func ", closeSendABI.Symbol, "(", nativeCExportParams(closeSendABI.Params), ") ", closeSendABI.Return.CGoType, " {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     closeSendABI.Symbol,
+		Doc:      "closes the native bidi-streaming client send side for " + method.FullName + ".",
+		Params:   nativeCExportParams(closeSendABI.Params),
+		Return:   closeSendABI.Return.CGoType,
+		StreamOp: true,
+	})
 	renderNativeStreamNoResultBody(g, service, method, servicePackage, "ctx", "stream", "CloseSend")
 	g.P("}")
 	g.P()
 
 	finishABI := methodABI[NativeCOperationFinish]
-	renderCGOExportDoc(g, finishABI.Symbol, "finishes the native bidi-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", finishABI.Symbol)
-	g.P("func ", finishABI.Symbol, "(", nativeCExportParams(finishABI.Params), ") ", finishABI.Return.CGoType, " {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     finishABI.Symbol,
+		Doc:      "finishes the native bidi-streaming client entrypoint for " + method.FullName + ".",
+		Params:   nativeCExportParams(finishABI.Params),
+		Return:   finishABI.Return.CGoType,
+		StreamOp: true,
+	})
 	renderNativeStreamNoResultBody(g, service, method, servicePackage, "ctx", "stream", "Finish")
 	g.P("}")
 	g.P()
 
 	cancelABI := methodABI[NativeCOperationCancel]
-	renderCGOExportDoc(g, cancelABI.Symbol, "cancels the native bidi-streaming client entrypoint for "+method.FullName+".")
-	g.P("//export ", cancelABI.Symbol)
-	g.P("func ", cancelABI.Symbol, "(", nativeCExportParams(cancelABI.Params), ") ", cancelABI.Return.CGoType, " {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     cancelABI.Symbol,
+		Doc:      "cancels the native bidi-streaming client entrypoint for " + method.FullName + ".",
+		Params:   nativeCExportParams(cancelABI.Params),
+		Return:   cancelABI.Return.CGoType,
+		StreamOp: true,
+	})
 	renderNativeStreamNoResultBody(g, service, method, servicePackage, "ctx", "stream", "Cancel")
 	g.P("}")
 	g.P()
 	closeName := strings.TrimSuffix(cancelABI.Symbol, "Cancel") + "Close"
-	renderCGOExportDoc(g, closeName, "closes callback receive ownership for the native bidi-streaming client entrypoint for "+method.FullName+" without delivering further callbacks.")
-	g.P("//export ", closeName)
-	g.P("func ", closeName, "(stream C.int32_t) C.int32_t {")
-	g.P("ctx := context.Background()")
+	renderCGOClientExportOpen(g, cgoClientExport{
+		Name:     closeName,
+		Doc:      "closes callback receive ownership for the native bidi-streaming client entrypoint for " + method.FullName + " without delivering further callbacks.",
+		Params:   "stream C.int32_t",
+		Return:   "C.int32_t",
+		StreamOp: true,
+	})
 	renderNativeCallbackReceiveCloseBody(g, service, method, servicePackage, "ctx", "stream")
 	g.P("}")
 	g.P()
@@ -877,6 +988,9 @@ func renderNativeCallbackReceiveStart(g *protogen.GeneratedFile, service Service
 	g.P("return C.int32_t(rpcruntime.StoreError(err))")
 	g.P("}")
 	g.P("go func() {")
+	g.P("defer rpcruntime.RecoverPanic(", strconv.Quote(method.FullName+" callback receive"), ", func(err error) {")
+	renderNativeCallbackReceiveFinish(g, handleValue, onDone, "int32(rpcruntime.StoreError(err))")
+	g.P("})")
 	g.P("releaseCallbackOutputs := func(", nativeCallbackReceiveReleaseParams(method.Contract.Native.ResponseFields), ") {")
 	for _, field := range method.Contract.Native.ResponseFields {
 		if nativeClientFieldPinsOutput(field) {
@@ -960,10 +1074,17 @@ func nativeCExportFuncName(plan FilePlan, service ServicePlan, method MethodPlan
 	return cgoServiceExportName("native", plan, service, method.GoName, operation)
 }
 
+// nativeCExportParams renders the parameters of a client export. Input
+// pointers name caller memory that Go reads, so they are void* rather than the
+// uintptr_t the shared ABI uses for pinned Go memory handed to server callbacks.
 func nativeCExportParams(slots []CABISlot) string {
 	parts := make([]string, 0, len(slots))
 	for _, slot := range slots {
-		parts = append(parts, slot.Name+" "+slot.CGoType)
+		cgoType := slot.CGoType
+		if slot.Role == CABISlotRolePointer {
+			cgoType = "unsafe.Pointer"
+		}
+		parts = append(parts, slot.Name+" "+cgoType)
 	}
 	return strings.Join(parts, ", ")
 }
@@ -1027,8 +1148,11 @@ func nativeCExportOutputArgs(fields []FieldPlan) string {
 }
 
 func nativeCExportGoArg(symbol string, field FieldPlan) string {
+	if nativeClientPresenceSymbol(field, symbol) {
+		return "int8(" + symbol + ")"
+	}
 	if strings.HasSuffix(symbol, "Ptr") {
-		return "uintptr(" + symbol + ")"
+		return symbol
 	}
 	if strings.HasSuffix(symbol, "Len") || strings.HasSuffix(symbol, "Ownership") {
 		return "int32(" + symbol + ")"
@@ -1080,7 +1204,7 @@ func renderNativeBidiStreamingResponseEncoder(g *protogen.GeneratedFile, service
 }
 
 func renderNativeResponseFieldValidate(g *protogen.GeneratedFile, field FieldPlan, unsupportedError string) {
-	name := nativeClientResponseValueName(field)
+	name := nativeClientResponsePlainName(field)
 	switch field.Native.Shape {
 	case NativeABIShapeBoolByte:
 		return
@@ -1099,6 +1223,12 @@ func renderNativeResponseFieldValidate(g *protogen.GeneratedFile, field FieldPla
 			g.P("}")
 			return
 		}
+	case NativeABIShapeBufferArray:
+		g.P(nativeClientOutputLenLocal(field), ", err := rpcruntime.LengthToInt32(len(", name, "))")
+		g.P("if err != nil {")
+		g.P("return err")
+		g.P("}")
+		return
 	case NativeABIShapeScalar, NativeABIShapeMessageBytes:
 		switch field.Kind {
 		case FieldKindSignedInt32, FieldKindSignedInt64, FieldKindFloat, FieldKindDouble, FieldKindEnum:
@@ -1114,8 +1244,40 @@ func renderNativeResponseFieldValidate(g *protogen.GeneratedFile, field FieldPla
 	g.P("return ", unsupportedError)
 }
 
-func renderNativeResponseFieldStage(g *protogen.GeneratedFile, field FieldPlan, pinned []FieldPlan) {
+// renderNativeResponseFieldUnwrap splits a response field with presence into
+// its Has flag and a plain value that the stage and commit steps encode. An
+// unset field commits a zero flag and zero value slots.
+func renderNativeResponseFieldUnwrap(g *protogen.GeneratedFile, field FieldPlan) {
+	if !field.Presence {
+		return
+	}
 	name := nativeClientResponseValueName(field)
+	g.P("var ", name, "Has int8")
+	if nativeFieldIsBytes(field) {
+		g.P("if ", name, " != nil {")
+		g.P(name, "Has = 1")
+		g.P("}")
+		return
+	}
+	plain := nativeClientResponsePlainName(field)
+	g.P("var ", plain, " ", nativeGoScalarType(g, field))
+	g.P("if ", name, " != nil {")
+	g.P(name, "Has = 1")
+	g.P(plain, " = *", name)
+	g.P("}")
+}
+
+// nativeClientResponsePlainName returns the local that holds the plain value
+// of a response field; fields with presence are unwrapped first.
+func nativeClientResponsePlainName(field FieldPlan) string {
+	if field.Presence && !nativeFieldIsBytes(field) {
+		return nativeClientResponseValueName(field) + "Present"
+	}
+	return nativeClientResponseValueName(field)
+}
+
+func renderNativeResponseFieldStage(g *protogen.GeneratedFile, field FieldPlan, pinned []FieldPlan) {
+	name := nativeClientResponsePlainName(field)
 	switch field.Native.Shape {
 	case NativeABIShapeBoolByte:
 		g.P("var ", name, "Value int8")
@@ -1156,6 +1318,13 @@ func renderNativeResponseFieldStage(g *protogen.GeneratedFile, field FieldPlan,
 			g.P("}")
 			g.P("_ = ", nativeClientOutputPtrLocal(field))
 		}
+	case NativeABIShapeBufferArray:
+		g.P(nativeClientOutputPtrLocal(field), ", err := rpcruntime.Pin", nativeBufferArrayKindName(field), "Array(", name, ")")
+		g.P("if err != nil {")
+		renderReleasePinnedOutputFields(g, pinned)
+		g.P("return err")
+		g.P("}")
+		g.P("_ = ", nativeClientOutputPtrLocal(field))
 	case NativeABIShapeScalar, NativeABIShapeMessageBytes:
 		switch field.Kind {
 		case FieldKindSignedInt32, FieldKindSignedInt64, FieldKindUnsignedInt32, FieldKindUnsignedInt64, FieldKindFloat, FieldKindDouble:
@@ -1181,6 +1350,51 @@ func renderNativeResponseFieldStage(g *protogen.GeneratedFile, field FieldPlan,
 	}
 }
 
+// renderNativeResponseFieldStageInto is renderNativeResponseFieldStage writing
+// into the caller's OutputBuffer instead of pinning.
+func renderNativeResponseFieldStageInto(g *protogen.GeneratedFile, field FieldPlan) {
+	name := nativeClientResponsePlainName(field)
+	switch field.Native.Shape {
+	case NativeABIShapeBoolByte:
+		g.P("var ", name, "Value int8")
+		g.P("if ", name, " {")
+		g.P(name, "Value = 1")
+		g.P("}")
+	case NativeABIShapeBoolByteBufferWrapper:
+		g.P(name, "Bytes := make([]byte, len(", name, "))")
+		g.P("for i := range ", name, " {")
+		g.P("if ", name, "[i] {")
+		g.P(name, "Bytes[i] = 1")
+		g.P("}")
+		g.P("}")
+		g.P(nativeClientOutputPtrLocal(field), " := buffer.PutBytes(", name, "Bytes)")
+	case NativeABIShapeRepeated:
+		switch field.Kind {
+		case FieldKindSignedInt32, FieldKindSignedInt64, FieldKindUnsignedInt32, FieldKindUnsignedInt64, FieldKindFloat, FieldKindDouble:
+			g.P(nativeClientOutputPtrLocal(field), " := rpcruntime.PutOutputSlice(buffer, ", name, ")")
+		case FieldKindEnum:
+			g.P(name, "Values := make([]int32, len(", name, "))")
+			g.P("for i := range ", name, " {")
+			g.P(name, "Values[i] = int32(", name, "[i])")
+			g.P("}")
+			g.P(nativeClientOutputPtrLocal(field), " := rpcruntime.PutOutputSlice(buffer, ", name, "Values)")
+		}
+	case NativeABIShapeBufferArray:
+		g.P(nativeClientOutputPtrLocal(field), " := buffer.Put", nativeBufferArrayKindName(field), "Array(", name, ")")
+	case NativeABIShapeScalar, NativeABIShapeMessageBytes:
+		switch field.Kind {
+		case FieldKindSignedInt32, FieldKindSignedInt64, FieldKindUnsignedInt32, FieldKindUnsignedInt64, FieldKindFloat, FieldKindDouble:
+			g.P(name, "Value := ", name)
+		case FieldKindEnum:
+			g.P(name, "Value := int32(", name, ")")
+		case FieldKindString:
+			g.P(nativeClientOutputPtrLocal(field), " := buffer.PutString(", name, ")")
+		case FieldKindBytes, FieldKindMessage:
+			g.P(nativeClientOutputPtrLocal(field), " := buffer.PutBytes(", name, ")")
+		}
+	}
+}
+
 func renderReleasePinnedOutputFields(g *protogen.GeneratedFile, fields []FieldPlan) {
 	for _, field := range fields {
 		g.P("rpcruntime.Release(", nativeClientOutputPtrLocal(field), ")")
@@ -1211,6 +1425,7 @@ func renderNativeClientResponseEncoder(g *protogen.GeneratedFile, name string, f
 		g.P("}")
 	}
 	for _, field := range fields {
+		renderNativeResponseFieldUnwrap(g, field)
 		renderNativeResponseFieldValidate(g, field, unsupportedError)
 	}
 	var pinned []FieldPlan
@@ -1228,6 +1443,32 @@ func renderNativeClientResponseEncoder(g *protogen.GeneratedFile, name string, f
 	g.P()
 }
 
+func renderNativeClientResponseIntoEncoder(g *protogen.GeneratedFile, name, validatorName string, fields []FieldPlan, unsupportedError string) {
+	params := nativeCExportParamJoin(strings.TrimSuffix(nativeGoRequestArgsForResponse(g, fields), ", "), "buffer *rpcruntime.OutputBuffer", nativeClientResponseOutputParams(fields))
+	g.P("func ", name, "(", params, ") error {")
+	if nativeClientResponseOutputParams(fields) != "" {
+		g.P("if err := ", validatorName, "(", nativeClientResponseOutputCallArgs(fields), "); err != nil {")
+		g.P("return err")
+		g.P("}")
+	}
+	for _, field := range fields {
+		renderNativeResponseFieldUnwrap(g, field)
+		renderNativeResponseFieldValidate(g, field, unsupportedError)
+	}
+	for _, field := range fields {
+		renderNativeResponseFieldStageInto(g, field)
+	}
+	g.P("if err := buffer.Err(); err != nil {")
+	g.P("return err")
+	g.P("}")
+	for _, field := range fields {
+		renderNativeResponseFieldCommit(g, field)
+	}
+	g.P("return nil")
+	g.P("}")
+	g.P()
+}
+
 func nativeGoRequestArgsForResponse(g *protogen.GeneratedFile, fields []FieldPlan) string {
 	if len(fields) == 0 {
 		return ""
@@ -1247,11 +1488,14 @@ func nativeClientEncoderCallArgs(args string) string {
 }
 
 func renderNativeResponseFieldCommit(g *protogen.GeneratedFile, field FieldPlan) {
-	name := nativeClientResponseValueName(field)
+	name := nativeClientResponsePlainName(field)
+	if field.Presence {
+		g.P("*", nativeClientOutputPresenceSymbol(field), " = ", nativeClientResponseValueName(field), "Has")
+	}
 	switch field.Native.Shape {
 	case NativeABIShapeBoolByte:
 		g.P("*", nativeClientOutputValueSymbol(field), " = ", name, "Value")
-	case NativeABIShapeBoolByteBufferWrapper, NativeABIShapeRepeated:
+	case NativeABIShapeBoolByteBufferWrapper, NativeABIShapeRepeated, NativeABIShapeBufferArray:
 		g.P("*", nativeClientOutputPtrSymbol(field), " = ", nativeClientOutputPtrLocal(field))
 		g.P("*", nativeClientOutputLenSymbol(field), " = ", nativeClientOutputLenLocal(field))
 	case NativeABIShapeScalar, NativeABIShapeMessageBytes:
@@ -1272,6 +1516,24 @@ func nativeGoEnumType(g *protogen.GeneratedFile, field FieldPlan) string {
 	})
 }
 
+// nativeOneofCaseConst returns the case type constant of a oneof case
+// discriminant with the given suffix, such as NotSet or a member Go name.
+func nativeOneofCaseConst(g *protogen.GeneratedFile, field FieldPlan, suffix string) string {
+	return g.QualifiedGoIdent(protogen.GoIdent{
+		GoName:       field.EnumType.GoName + suffix,
+		GoImportPath: protogen.GoImportPath(field.EnumType.GoImportPath),
+	})
+}
+
+// nativeGoFieldMessageType returns the Go pointer type of the element message
+// of a message field.
+func nativeGoFieldMessageType(g *protogen.GeneratedFile, field FieldPlan) string {
+	return "*" + g.QualifiedGoIdent(protogen.GoIdent{
+		GoName:       field.MessageType.GoName,
+		GoImportPath: protogen.GoImportPath(field.MessageType.GoImportPath),
+	})
+}
+
 func cgoGoImportPath(plan FilePlan) string {
 	return path.Join(string(plan.GoImportPath), cgoDirForFilePlan(plan))
 }
@@ -1360,6 +1622,10 @@ func nativeUnaryClientFuncName(service ServicePlan, method MethodPlan) string {
 	return "Call" + service.GoName + method.GoName + "NativeUnary"
 }
 
+func nativeUnaryClientIntoEncoderName(service ServicePlan, method MethodPlan) string {
+	return nativeUnaryClientEncoderName(service, method) + "Into"
+}
+
 func nativeUnaryClientDecoderName(service ServicePlan, method MethodPlan) string {
 	return "decode" + service.GoName + method.GoName + "NativeUnaryRequest"
 }
@@ -1381,7 +1647,7 @@ func nativeClientNeedsFmt(service ServicePlan) bool {
 			if (field.Native.Shape == NativeABIShapeScalar || field.Native.Shape == NativeABIShapeMessageBytes) && (field.Kind == FieldKindString || field.Kind == FieldKindBytes || field.Kind == FieldKindMessage) {
 				return true
 			}
-			if field.Native.Shape == NativeABIShapeRepeated || field.Native.Shape == NativeABIShapeBoolByteBufferWrapper {
+			if field.Native.Shape == NativeABIShapeRepeated || field.Native.Shape == NativeABIShapeBoolByteBufferWrapper || field.Native.Shape == NativeABIShapeBufferArray {
 				return true
 			}
 		}
@@ -1395,11 +1661,11 @@ func nativeClientNeedsUnsafe(service ServicePlan) bool {
 			if (field.Native.Shape == NativeABIShapeScalar || field.Native.Shape == NativeABIShapeMessageBytes) && (field.Kind == FieldKindString || field.Kind == FieldKindBytes || field.Kind == FieldKindMessage) {
 				return true
 			}
-			if field.Native.Shape == NativeABIShapeRepeated || field.Native.Shape == NativeABIShapeBoolByteBufferWrapper {
+			if field.Native.Shape == NativeABIShapeRepeated || field.Native.Shape == NativeABIShapeBoolByteBufferWrapper || field.Native.Shape == NativeABIShapeBufferArray {
 				return true
 			}
 		}
-		if len(method.Contract.Native.ResponseFields) > 0 {
+		if len(method.Contract.Native.ResponseFields) > 0 || method.Streaming == StreamingKindUnary {
 			return true
 		}
 	}
@@ -1407,7 +1673,7 @@ func nativeClientNeedsUnsafe(service ServicePlan) bool {
 }
 
 func nativeClientFieldPinsOutput(field FieldPlan) bool {
-	if field.Native.Shape == NativeABIShapeRepeated || field.Native.Shape == NativeABIShapeBoolByteBufferWrapper {
+	if field.Native.Shape == NativeABIShapeRepeated || field.Native.Shape == NativeABIShapeBoolByteBufferWrapper || field.Native.Shape == NativeABIShapeBufferArray {
 		return true
 	}
 	return (field.Native.Shape == NativeABIShapeScalar || field.Native.Shape == NativeABIShapeMessageBytes) && (field.Kind == FieldKindString || field.Kind == FieldKindBytes || field.Kind == FieldKindMessage)
@@ -1486,16 +1752,30 @@ func nativeCallbackReceiveOnRecvTrampolineName(service ServicePlan, method Metho
 }
 
 func nativeClientInputFieldSymbols(field FieldPlan) []string {
+	if field.Presence {
+		return append([]string{nativePresenceName(field)}, nativeClientInputValueSymbols(field)...)
+	}
+	return nativeClientInputValueSymbols(field)
+}
+
+func nativeClientInputValueSymbols(field FieldPlan) []string {
 	if (field.Native.Shape == NativeABIShapeScalar || field.Native.Shape == NativeABIShapeMessageBytes) && (field.Kind == FieldKindString || field.Kind == FieldKindBytes || field.Kind == FieldKindMessage) {
 		return []string{field.GoName + "Ptr", field.GoName + "Len", field.GoName + "Ownership"}
 	}
-	if field.Native.Shape == NativeABIShapeRepeated || field.Native.Shape == NativeABIShapeBoolByteBufferWrapper {
+	if field.Native.Shape == NativeABIShapeRepeated || field.Native.Shape == NativeABIShapeBoolByteBufferWrapper || field.Native.Shape == NativeABIShapeBufferArray {
 		return []string{field.GoName + "Ptr", field.GoName + "Len", field.GoName + "Ownership"}
 	}
 	return []string{field.GoName}
 }
 
 func nativeClientOutputFieldSymbols(field FieldPlan) []string {
+	if field.Presence {
+		return append([]string{nativeClientOutputPresenceSymbol(field)}, nativeClientOutputValueSymbols(field)...)
+	}
+	return nativeClientOutputValueSymbols(field)
+}
+
+func nativeClientOutputValueSymbols(field FieldPlan) []string {
 	if nativeClientFieldPinsOutput(field) {
 		return []string{nativeClientOutputPtrSymbol(field), nativeClientOutputLenSymbol(field)}
 	}
@@ -1565,6 +1845,9 @@ func validateNativeClientCGOSymbols(plan FilePlan, service ServicePlan) error {
 			if err := addGenerated(nativeUnaryClientEncoderName(service, method), method.FullName+" unary response encoder"); err != nil {
 				return err
 			}
+			if err := addGenerated(nativeUnaryClientIntoEncoderName(service, method), method.FullName+" unary response buffer encoder"); err != nil {
+				return err
+			}
 			if err := addGenerated(nativeUnaryClientOutputValidatorName(service, method), method.FullName+" unary output validator"); err != nil {
 				return err
 			}
@@ -1653,6 +1936,7 @@ func addNativeClientGeneratedSymbols(seen map[string]string, service ServicePlan
 		case StreamingKindUnary:
 			add(nativeUnaryClientDecoderName(service, method), method.FullName+" unary request decoder")
 			add(nativeUnaryClientEncoderName(service, method), method.FullName+" unary response encoder")
+			add(nativeUnaryClientIntoEncoderName(service, method), method.FullName+" unary response buffer encoder")
 			add(nativeUnaryClientOutputValidatorName(service, method), method.FullName+" unary output validator")
 		case StreamingKindClientStreaming:
 			add(nativeClientStreamingDecoderName(service, method), method.FullName+" client stream request decoder")
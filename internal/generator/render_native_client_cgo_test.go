@@ -28,18 +28,20 @@ func TestRenderNativeClientCGODefinesUnaryExportSurface(t *testing.T) {
 		`rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"`,
 		`unsafe "unsafe"`,
 		"//export rpccgoNativeTestv1AllServiceUnary",
-		"func rpccgoNativeTestv1AllServiceUnary(NamePtr C.uintptr_t, NameLen C.int32_t, NameOwnership C.int32_t, Enabled C.int8_t, ChildPtr C.uintptr_t, ChildLen C.int32_t, ChildOwnership C.int32_t, outAccepted *C.int8_t, outPayloadPtr *C.uintptr_t, outPayloadLen *C.int32_t, outPayloadOwnership *C.int32_t) C.int32_t {",
-		"ctx := context.Background()",
+		"func rpccgoNativeTestv1AllServiceUnary(NamePtr unsafe.Pointer, NameLen C.int32_t, NameOwnership C.int32_t, Enabled C.int8_t, HasChild C.int8_t, ChildPtr unsafe.Pointer, ChildLen C.int32_t, ChildOwnership C.int32_t, outAccepted *C.int8_t, outPayloadPtr *C.uintptr_t, outPayloadLen *C.int32_t, outPayloadOwnership *C.int32_t) C.int32_t {",
+		"return rpccgoNativeTestv1AllServiceUnaryWithContext(context.Background(), NamePtr, NameLen, NameOwnership, Enabled, HasChild, ChildPtr, ChildLen, ChildOwnership, outAccepted, outPayloadPtr, outPayloadLen, outPayloadOwnership)",
+		"func rpccgoNativeTestv1AllServiceUnaryWithOptions(options C.int32_t, NamePtr unsafe.Pointer,",
+		"func rpccgoNativeTestv1AllServiceUnaryWithContext(ctx context.Context, NamePtr unsafe.Pointer,",
 		"if err := validateAllServiceUnaryNativeUnaryResponse((*int8)(unsafe.Pointer(outAccepted)), (*uintptr)(unsafe.Pointer(outPayloadPtr)), (*int32)(unsafe.Pointer(outPayloadLen))); err != nil {",
-		"nameValue, enabledValue, childValue, err := decodeAllServiceUnaryNativeUnaryRequest(uintptr(NamePtr), int32(NameLen), int32(NameOwnership), int8(Enabled), uintptr(ChildPtr), int32(ChildLen), int32(ChildOwnership))",
+		"nameValue, enabledValue, childValue, err := decodeAllServiceUnaryNativeUnaryRequest(NamePtr, int32(NameLen), int32(NameOwnership), int8(Enabled), int8(HasChild), ChildPtr, int32(ChildLen), int32(ChildOwnership))",
 		"acceptedResult, payloadResult, err := v1.InvokeAllServiceNativeUnary(ctx, nameValue, enabledValue, childValue)",
 		"return C.int32_t(rpcruntime.StoreError(err))",
 		"var decoded rpcruntime.NativeReleaseStack",
 		"if _, err := rpcruntime.LengthFromInt32(NameLen); err != nil {",
-		"if NamePtr == 0 || NameLen == 0 {",
+		"if NamePtr == nil || NameLen == 0 {",
 		"nameValue = rpcruntime.EmptyRpcString()",
 		"var decodeErr error",
-		"nameValue, decodeErr = rpcruntime.NewRpcStringChecked((*byte)(unsafe.Pointer(NamePtr)), NameLen, NameOwnership > 0)",
+		"nameValue, decodeErr = rpcruntime.NewRpcStringChecked((*byte)(NamePtr), NameLen, NameOwnership > 0)",
 		"if decodeErr != nil {",
 		`fmt.Errorf("test.v1.AllRequest.name: %w", decodeErr)`,
 		"return nil, false, nil, errors.Join(fmt.Errorf(\"test.v1.AllRequest.name: %w\", decodeErr), decoded.Release())",
@@ -50,6 +52,11 @@ func TestRenderNativeClientCGODefinesUnaryExportSurface(t *testing.T) {
 		"payloadLenValue, err := rpcruntime.LengthToInt32(len(payloadResult))",
 		"payloadPtrValue, err := rpcruntime.PinBytes(payloadResult)",
 		"*outAccepted = acceptedResultValue",
+		"//export rpccgoNativeTestv1AllServiceUnaryInto",
+		"func rpccgoNativeTestv1AllServiceUnaryInto(NamePtr unsafe.Pointer, NameLen C.int32_t, NameOwnership C.int32_t, Enabled C.int8_t, HasChild C.int8_t, ChildPtr unsafe.Pointer, ChildLen C.int32_t, ChildOwnership C.int32_t, outAccepted *C.int8_t, outPayloadPtr *C.uintptr_t, outPayloadLen *C.int32_t, outPayloadOwnership *C.int32_t, bufferPtr unsafe.Pointer, bufferCap C.int32_t, bufferLen *C.int32_t) C.int32_t {",
+		"func encodeAllServiceUnaryNativeUnaryResponseInto(acceptedResult bool, payloadResult []byte, buffer *rpcruntime.OutputBuffer,",
+		"payloadPtrValue := buffer.PutBytes(payloadResult)",
+		"encodeErr := encodeAllServiceUnaryNativeUnaryResponseInto(acceptedResult, payloadResult, buffer,",
 		"*outPayloadPtr = payloadPtrValue",
 		"*outPayloadLen = payloadLenValue",
 	} {
@@ -137,10 +144,10 @@ func TestRenderNativeClientCGOHandlesBytesOwnershipAndPinnedOutputRelease(t *tes
 	const nativeClientFile = "test/v1/cgo/native_unary.greeter.client.native.cgo.rpccgo.go"
 	for _, fragment := range []string{
 		"PayloadOwnership int32",
-		"if PayloadPtr == 0 || PayloadLen == 0 {",
+		"if PayloadPtr == nil || PayloadLen == 0 {",
 		"payloadValue = rpcruntime.EmptyRpcBytes()",
 		"var decodeErr error",
-		"payloadValue, decodeErr = rpcruntime.NewRpcBytesChecked((*byte)(unsafe.Pointer(PayloadPtr)), PayloadLen, PayloadOwnership > 0)",
+		"payloadValue, decodeErr = rpcruntime.NewRpcBytesChecked((*byte)(PayloadPtr), PayloadLen, PayloadOwnership > 0)",
 		"if decodeErr != nil {",
 		`fmt.Errorf("test.v1.HelloRequest.payload: %w", decodeErr)`,
 		"PayloadOwnership > 0",
@@ -209,34 +216,35 @@ func TestRenderNativeClientCGOSupportsRepeatedNativeABI(t *testing.T) {
 		"UnsignedTotalsPtr",
 		"UnsignedTotalsLen",
 		"UnsignedTotalsOwnership",
-		"if ScoresPtr == 0 || ScoresLen == 0 {",
+		"if ScoresPtr == nil || ScoresLen == 0 {",
 		"scoresValue = rpcruntime.EmptyRpcRepeat[int32]()",
 		"ScoresOwnership > 0",
-		"scoresValue, decodeErr = rpcruntime.NewRpcRepeatChecked((*int32)(unsafe.Pointer(ScoresPtr)), ScoresLen, ScoresOwnership > 0)",
-		"if FlagsPtr == 0 || FlagsLen == 0 {",
+		"scoresValue, decodeErr = rpcruntime.NewRpcRepeatChecked((*int32)(ScoresPtr), ScoresLen, ScoresOwnership > 0)",
+		"if FlagsPtr == nil || FlagsLen == 0 {",
 		"flagsValue = rpcruntime.EmptyRpcBoolRepeat()",
 		"FlagsOwnership > 0",
-		"flagsValue, decodeErr = rpcruntime.NewRpcBoolRepeatChecked((*byte)(unsafe.Pointer(FlagsPtr)), FlagsLen, FlagsOwnership > 0)",
-		"if CountsPtr == 0 || CountsLen == 0 {",
+		"flagsValue, decodeErr = rpcruntime.NewRpcBoolRepeatChecked((*byte)(FlagsPtr), FlagsLen, FlagsOwnership > 0)",
+		"if CountsPtr == nil || CountsLen == 0 {",
 		"countsValue = rpcruntime.EmptyRpcRepeat[int64]()",
-		"countsValue, decodeErr = rpcruntime.NewRpcRepeatChecked((*int64)(unsafe.Pointer(CountsPtr)), CountsLen, CountsOwnership > 0)",
-		"if RatiosPtr == 0 || RatiosLen == 0 {",
+		"countsValue, decodeErr = rpcruntime.NewRpcRepeatChecked((*int64)(CountsPtr), CountsLen, CountsOwnership > 0)",
+		"if RatiosPtr == nil || RatiosLen == 0 {",
 		"ratiosValue = rpcruntime.EmptyRpcRepeat[float64]()",
-		"ratiosValue, decodeErr = rpcruntime.NewRpcRepeatChecked((*float64)(unsafe.Pointer(RatiosPtr)), RatiosLen, RatiosOwnership > 0)",
-		"if MoodsPtr == 0 || MoodsLen == 0 {",
+		"ratiosValue, decodeErr = rpcruntime.NewRpcRepeatChecked((*float64)(RatiosPtr), RatiosLen, RatiosOwnership > 0)",
+		"if MoodsPtr == nil || MoodsLen == 0 {",
 		"moodsValue = rpcruntime.EmptyRpcRepeat[int32]()",
-		"moodsValue, decodeErr = rpcruntime.NewRpcRepeatChecked((*int32)(unsafe.Pointer(MoodsPtr)), MoodsLen, MoodsOwnership > 0)",
-		"if UnsignedScoresPtr == 0 || UnsignedScoresLen == 0 {",
+		"moodsValue, decodeErr = rpcruntime.NewRpcRepeatChecked((*int32)(MoodsPtr), MoodsLen, MoodsOwnership > 0)",
+		"if UnsignedScoresPtr == nil || UnsignedScoresLen == 0 {",
 		"unsignedScoresValue = rpcruntime.EmptyRpcRepeat[uint32]()",
-		"unsignedScoresValue, decodeErr = rpcruntime.NewRpcRepeatChecked((*uint32)(unsafe.Pointer(UnsignedScoresPtr)), UnsignedScoresLen, UnsignedScoresOwnership > 0)",
-		"if UnsignedTotalsPtr == 0 || UnsignedTotalsLen == 0 {",
+		"unsignedScoresValue, decodeErr = rpcruntime.NewRpcRepeatChecked((*uint32)(UnsignedScoresPtr), UnsignedScoresLen, UnsignedScoresOwnership > 0)",
+		"if UnsignedTotalsPtr == nil || UnsignedTotalsLen == 0 {",
 		"unsignedTotalsValue = rpcruntime.EmptyRpcRepeat[uint64]()",
-		"unsignedTotalsValue, decodeErr = rpcruntime.NewRpcRepeatChecked((*uint64)(unsafe.Pointer(UnsignedTotalsPtr)), UnsignedTotalsLen, UnsignedTotalsOwnership > 0)",
+		"unsignedTotalsValue, decodeErr = rpcruntime.NewRpcRepeatChecked((*uint64)(UnsignedTotalsPtr), UnsignedTotalsLen, UnsignedTotalsOwnership > 0)",
 		"rpcruntime.LengthFromInt32(ScoresLen)",
 		"rpcruntime.LengthFromInt32(FlagsLen)",
 		"rpcruntime.LengthFromInt32(UnsignedScoresLen)",
 		"rpcruntime.LengthFromInt32(UnsignedTotalsLen)",
 		"rpcruntime.Release(scoresPtrValue)",
+		"scoresPtrValue := rpcruntime.PutOutputSlice(buffer, scoresResult)",
 	} {
 		assertGeneratedContentContains(t, plugin, nativeClientFile, fragment)
 	}
@@ -253,11 +253,12 @@ func renderGoNativeClientStreamStartHelper(g *protogen.GeneratedFile, service Se
 	receiver := lowerInitial(service.GoName) + method.GoName + "GoNativeClientStreamingServer"
 	g.P("func ", goNativeStartHelperName(service.GoName, method.GoName), "(ctx context.Context, server ", serverName, ") (", clientType, ", error) {")
 	g.P("client, stream, streamCtx := rpcruntime.NewClientStreaming[", method.RenderPlan.Symbols.NativeStreamRequestType, ", ", method.RenderPlan.Symbols.NativeStreamResponseType, "](ctx, rpcruntime.LocalStreamOptions{")
-	g.P("RequestBuffer: 16,")
+	renderLocalStreamBufferingOptions(g, method.FullName, method.StreamBuffering, true, false, true)
 	g.P("StreamClosed: ", errorNames.StreamClosed, ",")
 	g.P("})")
 	g.P("serverStream := &", receiver, "{stream: stream}")
 	g.P("go func() {")
+	renderStreamHandlerRecover(g, method.FullName, "stream.Complete("+method.RenderPlan.Symbols.NativeStreamResponseType+"{}, err)")
 	g.P(renderNativeClientStreamResultLocals(method), "server.", method.GoName, "(streamCtx, serverStream)")
 	g.P("stream.Complete(", nativeResponseEnvelopeLiteralFromLocals(method), ", err)")
 	g.P("}()")
@@ -275,11 +276,12 @@ func renderGoNativeServerStreamStartHelper(g *protogen.GeneratedFile, service Se
 	requestArgs := nativeGoRequestArgNames(method.Contract.Native.RequestFields)
 	g.P("func ", goNativeStartHelperName(service.GoName, method.GoName), "(ctx context.Context, server ", serverName, requestParams, ") (", clientType, ", error) {")
 	g.P("client, stream, streamCtx := rpcruntime.NewServerStreaming[", method.RenderPlan.Symbols.NativeStreamResponseType, "](ctx, rpcruntime.LocalStreamOptions{")
-	g.P("ResponseBuffer: 1,")
+	renderLocalStreamBufferingOptions(g, method.FullName, method.StreamBuffering, false, true, true)
 	g.P("StreamClosed: ", errorNames.StreamClosed, ",")
 	g.P("})")
 	g.P("serverStream := &", receiver, "{stream: stream}")
 	g.P("go func() {")
+	renderStreamHandlerRecover(g, method.FullName, "stream.Complete(err)")
 	if len(method.Contract.Native.RequestFields) == 0 {
 		g.P("err := server.", method.GoName, "(streamCtx, serverStream)")
 	} else {
@@ -299,12 +301,12 @@ func renderGoNativeBidiStreamStartHelper(g *protogen.GeneratedFile, service Serv
 	receiver := lowerInitial(service.GoName) + method.GoName + "GoNativeBidiStreamingServer"
 	g.P("func ", goNativeStartHelperName(service.GoName, method.GoName), "(ctx context.Context, server ", serverName, ") (", clientType, ", error) {")
 	g.P("client, stream, streamCtx := rpcruntime.NewBidiStreaming[", method.RenderPlan.Symbols.NativeStreamRequestType, ", ", method.RenderPlan.Symbols.NativeStreamResponseType, "](ctx, rpcruntime.LocalStreamOptions{")
-	g.P("RequestBuffer: 16,")
-	g.P("ResponseBuffer: 1,")
+	renderLocalStreamBufferingOptions(g, method.FullName, method.StreamBuffering, true, true, true)
 	g.P("StreamClosed: ", errorNames.StreamClosed, ",")
 	g.P("})")
 	g.P("serverStream := &", receiver, "{stream: stream}")
 	g.P("go func() {")
+	renderStreamHandlerRecover(g, method.FullName, "stream.Complete(err)")
 	g.P("err := server.", method.GoName, "(streamCtx, serverStream)")
 	g.P("stream.Complete(err)")
 	g.P("}()")
@@ -510,11 +512,16 @@ func nativeEnvelopeFieldNames(fields []FieldPlan) string {
 func renderGoNativeRegistration(g *protogen.GeneratedFile, service ServicePlan, serverName, adapterName string) {
 	renderDoc(g, "Register"+service.GoName+"GoNativeServer", "registers a Go native server as the current server for "+service.GoName+".")
 	g.P("func Register", service.GoName, "GoNativeServer(server ", serverName, ") error {")
+	g.P("return Register", service.GoName, "GoNativeServerIn(rpcruntime.DefaultServerRegistry(), server)")
+	g.P("}")
+	g.P()
+	renderDoc(g, "Register"+service.GoName+"GoNativeServerIn", "registers a Go native server as the current server for "+service.GoName+" in registry.")
+	g.P("func Register", service.GoName, "GoNativeServerIn(registry *rpcruntime.ServerRegistry, server ", serverName, ") error {")
 	g.P("if server == nil {")
-	g.P("_ = register", service.GoName, "GoNativeServer(server)")
+	g.P("_ = register", service.GoName, "GoNativeServerIn(registry, server)")
 	g.P(`return errors.New("rpccgo: `, service.GoName, ` go native server is nil")`)
 	g.P("}")
-	g.P("return register", service.GoName, "GoNativeServer(server)")
+	g.P("return register", service.GoName, "GoNativeServerIn(registry, server)")
 	g.P("}")
 	g.P()
 	_ = adapterName
@@ -637,8 +644,12 @@ func nativeGoCallSuffix(args string) string {
 
 func nativeGoRequestFieldType(g *protogen.GeneratedFile, field FieldPlan) string {
 	if field.Repeated {
-		if field.Kind == FieldKindBool {
+		switch field.Kind {
+		case FieldKindBool:
 			return "*rpcruntime.RpcBoolRepeat"
+		case FieldKindString, FieldKindBytes, FieldKindMessage:
+			wrapper, typeArgs := nativeBufferArrayWrapper(g, field)
+			return "*rpcruntime." + wrapper + typeArgs
 		}
 		return "*rpcruntime.RpcRepeat[" + nativeGoRequestRepeatElemType(g, field) + "]"
 	}
@@ -648,8 +659,25 @@ func nativeGoRequestFieldType(g *protogen.GeneratedFile, field FieldPlan) string
 	case FieldKindBytes, FieldKindMessage:
 		return "*rpcruntime.RpcBytes"
 	default:
-		return nativeGoScalarType(g, field)
+		return nativeGoPresenceType(field) + nativeGoScalarType(g, field)
+	}
+}
+
+// nativeGoPresenceType returns the pointer prefix that lets a scalar field
+// with presence tell unset (nil) apart from its zero value. String and bytes
+// request wrappers and response bytes, encoded messages included, are nil
+// when unset and need no prefix.
+func nativeGoPresenceType(field FieldPlan) string {
+	if field.Presence {
+		return "*"
 	}
+	return ""
+}
+
+// nativeFieldIsBytes reports whether the native value of a singular field is
+// a byte string: a bytes field or an encoded message.
+func nativeFieldIsBytes(field FieldPlan) bool {
+	return field.Kind == FieldKindBytes || field.Kind == FieldKindMessage
 }
 
 func nativeGoRequestRepeatElemType(g *protogen.GeneratedFile, field FieldPlan) string {
@@ -659,6 +687,34 @@ func nativeGoRequestRepeatElemType(g *protogen.GeneratedFile, field FieldPlan) s
 	return nativeGoScalarType(g, field)
 }
 
+// nativeBufferArrayKindName names the rpcruntime buffer array helpers, such
+// as PinStringArray and CopyBytesArray, for a repeated string or bytes field.
+func nativeBufferArrayKindName(field FieldPlan) string {
+	if field.Kind == FieldKindString {
+		return "String"
+	}
+	return "Bytes"
+}
+
+// nativeBufferArraySafeSliceMethod returns the wrapper method that copies the
+// elements of a buffer array request field; message elements stay encoded.
+func nativeBufferArraySafeSliceMethod(field FieldPlan) string {
+	if field.Kind == FieldKindMessage {
+		return ".SafeEncodedSlice()"
+	}
+	return ".SafeSlice()"
+}
+
+// nativeBufferArrayWrapper returns the rpcruntime wrapper name and its type
+// arguments for a buffer array request field. Repeated message fields get the
+// lazily decoding RpcMessageRepeat view over their encoded elements.
+func nativeBufferArrayWrapper(g *protogen.GeneratedFile, field FieldPlan) (string, string) {
+	if field.Kind == FieldKindMessage {
+		return "RpcMessageRepeat", "[" + nativeGoFieldMessageType(g, field) + "]"
+	}
+	return "Rpc" + nativeBufferArrayKindName(field) + "Repeat", ""
+}
+
 func nativeGoResponseFieldType(g *protogen.GeneratedFile, field FieldPlan) string {
 	if field.Repeated {
 		return "[]" + nativeGoScalarType(g, field)
@@ -667,7 +723,7 @@ func nativeGoResponseFieldType(g *protogen.GeneratedFile, field FieldPlan) strin
 	case FieldKindBytes, FieldKindMessage:
 		return "[]byte"
 	default:
-		return nativeGoScalarType(g, field)
+		return nativeGoPresenceType(field) + nativeGoScalarType(g, field)
 	}
 }
 
@@ -699,7 +755,7 @@ func nativeGoScalarType(g *protogen.GeneratedFile, field FieldPlan) string {
 }
 
 func nativeGoZeroValue(field FieldPlan) string {
-	if field.Repeated || field.Kind == FieldKindBytes || field.Kind == FieldKindMessage {
+	if field.Repeated || field.Presence || field.Kind == FieldKindBytes || field.Kind == FieldKindMessage {
 		return "nil"
 	}
 	switch field.Kind {
@@ -789,6 +845,9 @@ func validateNativeServerSymbols(service ServicePlan) error {
 	if err := addGenerated("Register"+service.GoName+"GoNativeServer", service.FullName+" go native registration"); err != nil {
 		return err
 	}
+	if err := addGenerated("Register"+service.GoName+"GoNativeServerIn", service.FullName+" registry-scoped go native registration"); err != nil {
+		return err
+	}
 
 	for _, method := range service.Methods {
 		switch method.Streaming {
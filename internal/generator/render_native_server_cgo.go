@@ -205,11 +205,18 @@ func nativeCGOServerGoOutputValueArgs(fields []FieldPlan) []string {
 }
 
 func nativeCGOServerGoOutputValueFieldArgs(field FieldPlan) []string {
+	if field.Presence {
+		return append([]string{"out" + nativePresenceName(field)}, nativeCGOServerGoOutputValueSlotArgs(field)...)
+	}
+	return nativeCGOServerGoOutputValueSlotArgs(field)
+}
+
+func nativeCGOServerGoOutputValueSlotArgs(field FieldPlan) []string {
 	fieldName := "out" + field.GoName
 	switch field.Native.Shape {
 	case NativeABIShapeBoolByte:
 		return []string{fieldName + "Value"}
-	case NativeABIShapeRepeated, NativeABIShapeBoolByteBufferWrapper:
+	case NativeABIShapeRepeated, NativeABIShapeBoolByteBufferWrapper, NativeABIShapeBufferArray:
 		return []string{fieldName + "Ptr", fieldName + "Len", fieldName + "Ownership"}
 	case NativeABIShapeScalar, NativeABIShapeMessageBytes:
 		switch field.Kind {
@@ -224,6 +231,18 @@ func nativeCGOServerGoOutputValueFieldArgs(field FieldPlan) []string {
 }
 
 func nativeCGOServerGoFieldArgs(field FieldPlan, output bool) []string {
+	args := nativeCGOServerGoFieldValueArgs(field, output)
+	if !field.Presence {
+		return args
+	}
+	presence := lowerInitial(nativePresenceName(field))
+	if output {
+		presence = "&" + presence
+	}
+	return append([]string{presence}, args...)
+}
+
+func nativeCGOServerGoFieldValueArgs(field FieldPlan, output bool) []string {
 	name := lowerInitial(field.GoName)
 	prefix := ""
 	if output {
@@ -232,7 +251,7 @@ func nativeCGOServerGoFieldArgs(field FieldPlan, output bool) []string {
 	switch field.Native.Shape {
 	case NativeABIShapeBoolByte:
 		return []string{prefix + name + "Value"}
-	case NativeABIShapeRepeated, NativeABIShapeBoolByteBufferWrapper:
+	case NativeABIShapeRepeated, NativeABIShapeBoolByteBufferWrapper, NativeABIShapeBufferArray:
 		return []string{prefix + name + "Ptr", prefix + name + "Len", prefix + name + "Ownership"}
 	case NativeABIShapeScalar, NativeABIShapeMessageBytes:
 		switch field.Kind {
@@ -286,6 +305,15 @@ func nativeCGOServerGoABIArgWithRequest(param CABISlot, handleArg, requestArg st
 	if param.FieldGoName == "" {
 		return param.Name
 	}
+	switch param.Role {
+	case CABISlotRoleOutPresence:
+		return "&" + param.Name
+	case CABISlotRolePresence:
+		if requestArg != "" {
+			return requestArg + "." + lowerInitial(param.Name)
+		}
+		return lowerInitial(param.Name)
+	}
 	fieldName := param.FieldGoName
 	output := strings.HasPrefix(param.Name, "out"+fieldName)
 	base := fieldName
@@ -337,10 +365,17 @@ func nativeCGOServerGoFieldTypes(fields []FieldPlan) []string {
 }
 
 func nativeCGOServerGoFieldType(field FieldPlan) []string {
+	if field.Presence {
+		return append([]string{"C.int8_t"}, nativeCGOServerGoFieldValueType(field)...)
+	}
+	return nativeCGOServerGoFieldValueType(field)
+}
+
+func nativeCGOServerGoFieldValueType(field FieldPlan) []string {
 	switch field.Native.Shape {
 	case NativeABIShapeBoolByte:
 		return []string{"C.int8_t"}
-	case NativeABIShapeRepeated, NativeABIShapeBoolByteBufferWrapper:
+	case NativeABIShapeRepeated, NativeABIShapeBoolByteBufferWrapper, NativeABIShapeBufferArray:
 		return []string{"C.uintptr_t", "C.int32_t", "C.int32_t"}
 	case NativeABIShapeScalar, NativeABIShapeMessageBytes:
 		switch field.Kind {
@@ -522,11 +557,18 @@ func nativeCGOServerCallbackArgNames(requestFields, responseFields []FieldPlan)
 }
 
 func nativeCGOServerCFieldArgNames(field FieldPlan, output bool) []string {
+	if field.Presence {
+		return append([]string{nativeCGOServerCArgName(nativePresenceName(field), output)}, nativeCGOServerCFieldValueArgNames(field, output)...)
+	}
+	return nativeCGOServerCFieldValueArgNames(field, output)
+}
+
+func nativeCGOServerCFieldValueArgNames(field FieldPlan, output bool) []string {
 	prefix := ""
 	switch field.Native.Shape {
 	case NativeABIShapeBoolByte:
 		return []string{prefix + nativeCGOServerCArgName(field.GoName, output)}
-	case NativeABIShapeRepeated, NativeABIShapeBoolByteBufferWrapper:
+	case NativeABIShapeRepeated, NativeABIShapeBoolByteBufferWrapper, NativeABIShapeBufferArray:
 		return []string{prefix + nativeCGOServerCArgName(field.GoName+"Ptr", output), prefix + nativeCGOServerCArgName(field.GoName+"Len", output), prefix + nativeCGOServerCArgName(field.GoName+"Ownership", output)}
 	case NativeABIShapeScalar, NativeABIShapeMessageBytes:
 		switch field.Kind {
@@ -695,7 +737,9 @@ func renderCGONativeServerUnaryAdapter(g *protogen.GeneratedFile, service Servic
 	g.P("defer ", nativeCGOServerRequestEncoderReleaseCall(encoderName))
 	renderCGONativeServerResponseLocals(g, method.Contract.Native.ResponseFields)
 	unaryABI := nativeCGOServerOperationABI(abi, method, NativeCOperationUnary)
+	g.P("leaveTrace := ", cgoEnterCallbackTraceName, "(ctx)")
 	g.P("errID := int32(C.", nativeCGOServerTrampolineName(service, method), "(callback, ", nativeCGOServerRequestEncoderArgList(unaryABI.Params, "", encoderName), "))")
+	g.P("leaveTrace()")
 	g.P("if errID != 0 {")
 	g.P("cleanupErr := ", nativeCGOServerResponseCleanupName(service, method), "(", nativeCGOServerFlatOutputValueArgs(method.Contract.Native.ResponseFields), ")")
 	g.P("callbackErr := ", nativeCGOServerErrorIDHelperName(service), "(errID)")
@@ -740,7 +784,9 @@ func renderCGONativeServerClientStreamAdapter(g *protogen.GeneratedFile, service
 	g.P("}")
 	g.P("var stream C.int32_t")
 	startABI := nativeCGOServerOperationABI(abi, method, NativeCOperationStart)
+	g.P("leaveTrace := ", cgoEnterCallbackTraceName, "(ctx)")
 	g.P("errID := int32(C.", nativeCGOServerClientStreamStartTrampolineName(service, method), "(a.", cgoNativeServerCallbackFieldName(method, NativeCOperationStart), nativeCGOServerGoABICallSuffix(startABI.Params, "&stream"), "))")
+	g.P("leaveTrace()")
 	g.P("if errID != 0 {")
 	g.P("return nil, ", nativeCGOServerErrorIDHelperName(service), "(errID)")
 	g.P("}")
@@ -889,7 +935,9 @@ func renderCGONativeServerServerStreamAdapter(g *protogen.GeneratedFile, service
 	g.P("defer ", nativeCGOServerRequestEncoderReleaseCall(encoderName))
 	g.P("var stream C.int32_t")
 	startABI := nativeCGOServerOperationABI(abi, method, NativeCOperationStart)
+	g.P("leaveTrace := ", cgoEnterCallbackTraceName, "(ctx)")
 	g.P("errID := int32(C.", nativeCGOServerServerStreamStartTrampolineName(service, method), "(a.", cgoNativeServerCallbackFieldName(method, NativeCOperationStart), nativeCGOServerRequestEncoderCallSuffix(startABI.Params, "&stream", encoderName), "))")
+	g.P("leaveTrace()")
 	g.P("if errID != 0 {")
 	g.P("return nil, ", nativeCGOServerErrorIDHelperName(service), "(errID)")
 	g.P("}")
@@ -1036,7 +1084,9 @@ func renderCGONativeServerBidiStreamAdapter(g *protogen.GeneratedFile, service S
 	g.P("}")
 	g.P("var stream C.int32_t")
 	startABI := nativeCGOServerOperationABI(abi, method, NativeCOperationStart)
+	g.P("leaveTrace := ", cgoEnterCallbackTraceName, "(ctx)")
 	g.P("errID := int32(C.", nativeCGOServerBidiStreamStartTrampolineName(service, method), "(a.", cgoNativeServerCallbackFieldName(method, NativeCOperationStart), nativeCGOServerGoABICallSuffix(startABI.Params, "&stream"), "))")
+	g.P("leaveTrace()")
 	g.P("if errID != 0 {")
 	g.P("return nil, ", nativeCGOServerErrorIDHelperName(service), "(errID)")
 	g.P("}")
@@ -1279,12 +1329,31 @@ func renderCGONativeServerFlatRequestEncoder(g *protogen.GeneratedFile, name, re
 }
 
 func renderCGONativeServerRequestFieldEncode(g *protogen.GeneratedFile, field FieldPlan, errorNames nativeServerCGOErrorNames) {
+	name := lowerInitial(field.GoName)
+	if !field.Presence {
+		renderCGONativeServerRequestFieldValueEncode(g, field, name, errorNames)
+		return
+	}
+	// An unset field leaves its flag and value slots zero.
+	g.P("if ", name, " != nil {")
+	g.P(lowerInitial(nativePresenceName(field)), " = 1")
+	value := name
+	if field.Kind != FieldKindString && !nativeFieldIsBytes(field) {
+		value = "*" + name
+	}
+	renderCGONativeServerRequestFieldValueEncode(g, field, value, errorNames)
+	g.P("}")
+}
+
+// renderCGONativeServerRequestFieldValueEncode encodes the Go value expression
+// of one request field into the C locals named after the field.
+func renderCGONativeServerRequestFieldValueEncode(g *protogen.GeneratedFile, field FieldPlan, value string, errorNames nativeServerCGOErrorNames) {
 	name := lowerInitial(field.GoName)
 	errorReturn := nativeCGOServerRequestEncoderErrorReturn("err")
 	unsupportedReturn := nativeCGOServerRequestEncoderErrorReturn(errorNames.UnsupportedField)
 	switch field.Native.Shape {
 	case NativeABIShapeBoolByte:
-		g.P("if ", name, " {")
+		g.P("if ", value, " {")
 		g.P(name, "Value = 1")
 		g.P("}")
 	case NativeABIShapeBoolByteBufferWrapper:
@@ -1339,29 +1408,46 @@ func renderCGONativeServerRequestFieldEncode(g *protogen.GeneratedFile, field Fi
 		g.P("}")
 		g.P(name, "Ptr = C.uintptr_t(", name, "PtrValue)")
 		g.P(name, "Len = C.int32_t(", name, "LenValue)")
+	case NativeABIShapeBufferArray:
+		g.P(name, "Values := ", name, nativeBufferArraySafeSliceMethod(field))
+		g.P(name, "LenValue, err := rpcruntime.LengthToInt32(len(", name, "Values))")
+		g.P("if err != nil {")
+		renderCGONativeServerRequestEncoderReleasePinned(g)
+		g.P("return ", errorReturn)
+		g.P("}")
+		g.P(name, "PtrValue, err := rpcruntime.Pin", nativeBufferArrayKindName(field), "Array(", name, "Values)")
+		g.P("if err != nil {")
+		renderCGONativeServerRequestEncoderReleasePinned(g)
+		g.P("return ", errorReturn)
+		g.P("}")
+		g.P("if ", name, "PtrValue != 0 {")
+		g.P("pinned = append(pinned, ", name, "PtrValue)")
+		g.P("}")
+		g.P(name, "Ptr = C.uintptr_t(", name, "PtrValue)")
+		g.P(name, "Len = C.int32_t(", name, "LenValue)")
 	case NativeABIShapeScalar, NativeABIShapeMessageBytes:
 		switch field.Kind {
 		case FieldKindSignedInt32:
-			g.P(name, "Value = C.int32_t(", name, ")")
+			g.P(name, "Value = C.int32_t(", value, ")")
 		case FieldKindSignedInt64:
-			g.P(name, "Value = C.int64_t(", name, ")")
+			g.P(name, "Value = C.int64_t(", value, ")")
 		case FieldKindUnsignedInt32:
-			g.P(name, "Value = C.uint32_t(", name, ")")
+			g.P(name, "Value = C.uint32_t(", value, ")")
 		case FieldKindUnsignedInt64:
-			g.P(name, "Value = C.uint64_t(", name, ")")
+			g.P(name, "Value = C.uint64_t(", value, ")")
 		case FieldKindFloat:
-			g.P(name, "Value = C.float(", name, ")")
+			g.P(name, "Value = C.float(", value, ")")
 		case FieldKindDouble:
-			g.P(name, "Value = C.double(", name, ")")
+			g.P(name, "Value = C.double(", value, ")")
 		case FieldKindEnum:
-			g.P(name, "Value = C.int32_t(", name, ")")
+			g.P(name, "Value = C.int32_t(", value, ")")
 		case FieldKindString:
-			g.P(name, "LenValue, err := rpcruntime.LengthToInt32(len(", name, ".SafeString()))")
+			g.P(name, "LenValue, err := rpcruntime.LengthToInt32(len(", value, ".SafeString()))")
 			g.P("if err != nil {")
 			renderCGONativeServerRequestEncoderReleasePinned(g)
 			g.P("return ", errorReturn)
 			g.P("}")
-			g.P("_, ", name, "PtrValue, err := rpcruntime.PinString(", name, ".SafeString())")
+			g.P("_, ", name, "PtrValue, err := rpcruntime.PinString(", value, ".SafeString())")
 			g.P("if err != nil {")
 			renderCGONativeServerRequestEncoderReleasePinned(g)
 			g.P("return ", errorReturn)
@@ -1372,7 +1458,7 @@ func renderCGONativeServerRequestFieldEncode(g *protogen.GeneratedFile, field Fi
 			g.P(name, "Ptr = C.uintptr_t(", name, "PtrValue)")
 			g.P(name, "Len = C.int32_t(", name, "LenValue)")
 		case FieldKindBytes, FieldKindMessage:
-			g.P(name, "Bytes := ", name, ".SafeBytes()")
+			g.P(name, "Bytes := ", value, ".SafeBytes()")
 			g.P(name, "LenValue, err := rpcruntime.LengthToInt32(len(", name, "Bytes))")
 			g.P("if err != nil {")
 			renderCGONativeServerRequestEncoderReleasePinned(g)
@@ -1414,8 +1500,30 @@ func renderCGONativeServerFlatResponseDecoder(g *protogen.GeneratedFile, name st
 }
 
 func renderCGONativeServerResponseFieldDecode(g *protogen.GeneratedFile, fields []FieldPlan, field FieldPlan, errorNames nativeServerCGOErrorNames) {
+	name := lowerInitial(field.GoName) + "Result"
+	if !field.Presence {
+		renderCGONativeServerResponseFieldValueDecode(g, fields, field, name, errorNames)
+		return
+	}
+	// A field with presence decodes its value slots only when the flag is
+	// set. Set bytes stay non-nil even when empty, since nil means unset.
+	g.P("var ", name, " ", nativeGoResponseFieldType(g, field))
+	g.P("if ", lowerInitial(nativePresenceName(field)), " != 0 {")
+	present := name + "Present"
+	renderCGONativeServerResponseFieldValueDecode(g, fields, field, present, errorNames)
+	if nativeFieldIsBytes(field) {
+		g.P("if ", present, " == nil {")
+		g.P(present, " = []byte{}")
+		g.P("}")
+		g.P(name, " = ", present)
+	} else {
+		g.P(name, " = &", present)
+	}
+	g.P("}")
+}
+
+func renderCGONativeServerResponseFieldValueDecode(g *protogen.GeneratedFile, fields []FieldPlan, field FieldPlan, name string, errorNames nativeServerCGOErrorNames) {
 	fieldName := lowerInitial(field.GoName)
-	name := fieldName + "Result"
 	switch field.Native.Shape {
 	case NativeABIShapeBoolByte:
 		g.P(name, " := ", fieldName, "Value != 0")
@@ -1452,6 +1560,14 @@ func renderCGONativeServerResponseFieldDecode(g *protogen.GeneratedFile, fields
 		default:
 			g.P("return ", nativeGoZeroReturns(fields, errorNames.UnsupportedField))
 		}
+	case NativeABIShapeBufferArray:
+		g.P("if _, err := rpcruntime.LengthFromInt32(int32(", fieldName, "Len)); err != nil {")
+		g.P(`return `, nativeGoZeroReturns(fields, `fmt.Errorf("`+field.FullName+`: %w", err)`))
+		g.P("}")
+		g.P(name, ", err := rpcruntime.Copy", nativeBufferArrayKindName(field), "Array((*rpcruntime.RpcBuffer)(unsafe.Pointer(uintptr(", fieldName, "Ptr))), int32(", fieldName, "Len))")
+		g.P("if err != nil {")
+		g.P(`return `, nativeGoZeroReturns(fields, `fmt.Errorf("`+field.FullName+`: %w", err)`))
+		g.P("}")
 	case NativeABIShapeScalar, NativeABIShapeMessageBytes:
 		switch field.Kind {
 		case FieldKindSignedInt32:
@@ -1745,6 +1861,11 @@ func renderCGONativeServerFlatResponseCleanup(g *protogen.GeneratedFile, name st
 			g.P("}")
 			g.P("}")
 		}
+		if field.Native.Shape == NativeABIShapeBufferArray {
+			g.P("if err := rpcruntime.ReleaseBufferArray((*rpcruntime.RpcBuffer)(unsafe.Pointer(uintptr(", fieldName, "Ptr))), int32(", fieldName, "Len), ", fieldName, "Ownership > 0, \"", field.FullName, "\"); err != nil {")
+			g.P("cleanupErr = errors.Join(cleanupErr, err)")
+			g.P("}")
+		}
 	}
 	g.P("return cleanupErr")
 	g.P("}")
@@ -1756,15 +1877,12 @@ func renderCGONativeErrorIDHelper(g *protogen.GeneratedFile, service ServicePlan
 	g.P("if errID == 0 {")
 	g.P("return nil")
 	g.P("}")
-	g.P("text, ptr, ok := rpcruntime.TakeErrorText(rpcruntime.ErrorID(errID))")
+	g.P("statusErr, ok := rpcruntime.TakeError(rpcruntime.ErrorID(errID))")
 	g.P("if ok {")
-	g.P("if ptr != 0 {")
-	g.P("defer rpcruntime.Release(ptr)")
-	g.P("}")
-	g.P("if string(text) == io.EOF.Error() {")
+	g.P("if statusErr.Message == io.EOF.Error() {")
 	g.P("return io.EOF")
 	g.P("}")
-	g.P("return errors.New(string(text))")
+	g.P("return ", cgoServerTransportErrorFunc(service), "(statusErr)")
 	g.P("}")
 	g.P(`return fmt.Errorf("rpccgo: cgo native server callback returned unknown error id %d", errID)`)
 	g.P("}")
@@ -2024,7 +2142,7 @@ func nativeServerCGONeedsUnsafe(service ServicePlan) bool {
 }
 
 func nativeServerCGOFieldUsesUnsafe(field FieldPlan) bool {
-	if field.Native.Shape == NativeABIShapeRepeated || field.Native.Shape == NativeABIShapeBoolByteBufferWrapper {
+	if field.Native.Shape == NativeABIShapeRepeated || field.Native.Shape == NativeABIShapeBoolByteBufferWrapper || field.Native.Shape == NativeABIShapeBufferArray {
 		return true
 	}
 	return (field.Native.Shape == NativeABIShapeScalar || field.Native.Shape == NativeABIShapeMessageBytes) &&
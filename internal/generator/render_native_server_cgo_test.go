@@ -25,9 +25,9 @@ func TestRenderNativeServerCGODefinesFlatServiceCallbackRegistration(t *testing.
 		"package main",
 		`import "C"`,
 		`v1 "example.com/test/v1"`,
-		"typedef int32_t (*AllServiceUnaryCGONativeUnaryCallback)(uintptr_t NamePtr, int32_t NameLen, int32_t NameOwnership, int8_t Enabled, uintptr_t ChildPtr, int32_t ChildLen, int32_t ChildOwnership, int8_t *outAccepted, uintptr_t *outPayloadPtr, int32_t *outPayloadLen, int32_t *outPayloadOwnership);",
+		"typedef int32_t (*AllServiceUnaryCGONativeUnaryCallback)(uintptr_t NamePtr, int32_t NameLen, int32_t NameOwnership, int8_t Enabled, int8_t HasChild, uintptr_t ChildPtr, int32_t ChildLen, int32_t ChildOwnership, int8_t *outAccepted, uintptr_t *outPayloadPtr, int32_t *outPayloadLen, int32_t *outPayloadOwnership);",
 		"static inline int32_t callAllServiceUnaryCGONativeUnaryCallback",
-		"return callback(NamePtr, NameLen, NameOwnership, Enabled, ChildPtr, ChildLen, ChildOwnership, outAccepted, outPayloadPtr, outPayloadLen, outPayloadOwnership);",
+		"return callback(NamePtr, NameLen, NameOwnership, Enabled, HasChild, ChildPtr, ChildLen, ChildOwnership, outAccepted, outPayloadPtr, outPayloadLen, outPayloadOwnership);",
 		`rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"`,
 		`sync "sync"`,
 		`unsafe "unsafe"`,
@@ -88,12 +88,12 @@ func TestRenderNativeServerCGODefinesFlatServiceCallbackRegistration(t *testing.
 		`errors.New("rpccgo: AllService cgo native server unary callback is missing")`,
 		`errors.New("rpccgo: cgo native server stream callbacks are partially registered")`,
 		"callback := a.UnaryCallback",
-		"errID := int32(C.callAllServiceUnaryCGONativeUnaryCallback(callback, allServiceUnaryCGONativeUnaryRequest.namePtr, allServiceUnaryCGONativeUnaryRequest.nameLen, allServiceUnaryCGONativeUnaryRequest.nameOwnership, allServiceUnaryCGONativeUnaryRequest.enabledValue, allServiceUnaryCGONativeUnaryRequest.childPtr, allServiceUnaryCGONativeUnaryRequest.childLen, allServiceUnaryCGONativeUnaryRequest.childOwnership, &outAcceptedValue, &outPayloadPtr, &outPayloadLen, &outPayloadOwnership))",
+		"errID := int32(C.callAllServiceUnaryCGONativeUnaryCallback(callback, allServiceUnaryCGONativeUnaryRequest.namePtr, allServiceUnaryCGONativeUnaryRequest.nameLen, allServiceUnaryCGONativeUnaryRequest.nameOwnership, allServiceUnaryCGONativeUnaryRequest.enabledValue, allServiceUnaryCGONativeUnaryRequest.hasChild, allServiceUnaryCGONativeUnaryRequest.childPtr, allServiceUnaryCGONativeUnaryRequest.childLen, allServiceUnaryCGONativeUnaryRequest.childOwnership, &outAcceptedValue, &outPayloadPtr, &outPayloadLen, &outPayloadOwnership))",
 		"callbackErr := allServiceCGONativeServerErrorFromID(errID)",
 		"return false, nil, errors.Join(callbackErr, cleanupErr)",
 		"type allServiceUnaryCGONativeUnaryRequest struct {",
 		"func (r *allServiceUnaryCGONativeUnaryRequest) Release() {",
-		"return &allServiceUnaryCGONativeUnaryRequest{namePtr: namePtr, nameLen: nameLen, nameOwnership: nameOwnership, enabledValue: enabledValue, childPtr: childPtr, childLen: childLen, childOwnership: childOwnership, pinned: pinned}, nil",
+		"return &allServiceUnaryCGONativeUnaryRequest{namePtr: namePtr, nameLen: nameLen, nameOwnership: nameOwnership, enabledValue: enabledValue, hasChild: hasChild, childPtr: childPtr, childLen: childLen, childOwnership: childOwnership, pinned: pinned}, nil",
 		"_, namePtrValue, err := rpcruntime.PinString(name.SafeString())",
 		"pinned = append(pinned, namePtrValue)",
 		"rpcruntime.Release(pinned[i])",
@@ -103,7 +103,8 @@ func TestRenderNativeServerCGODefinesFlatServiceCallbackRegistration(t *testing.
 		`if err := rpcruntime.ReleaseC(unsafe.Pointer(uintptr(payloadPtr)), true, "test.v1.AllReply.payload"); err != nil {`,
 		"cleanupErr = errors.Join(cleanupErr, err)",
 		"func allServiceCGONativeServerErrorFromID(errID int32) error {",
-		"rpcruntime.TakeErrorText(rpcruntime.ErrorID(errID))",
+		"statusErr, ok := rpcruntime.TakeError(rpcruntime.ErrorID(errID))",
+		"return rpcruntime.ConnectError(statusErr)",
 	} {
 		assertGeneratedContentContains(t, plugin, cgoServerFile, fragment)
 	}
@@ -169,7 +170,8 @@ func TestRenderNativeServerCGOScalarOnlyGeneratedSourceCompiles(t *testing.T) {
 			strings.Contains(name, ".server.message.rpccgo.go") ||
 			strings.Contains(name, ".server.native.rpccgo.go") ||
 			strings.Contains(name, ".server.native.cgo.rpccgo.go") ||
-			strings.Contains(name, ".client.native.cgo.rpccgo.go")
+			strings.Contains(name, ".client.native.cgo.rpccgo.go") ||
+			strings.Contains(name, ".exports.cgo.rpccgo.go")
 	})
 	writeNativeServerCGOTestFile(t, filepath.Join(tmp, "test/v1/native_scalar_stubs.go"), `package testv1
 
@@ -260,6 +262,70 @@ func TestRenderNativeServerCGOSupportsRepeatedNativeABI(t *testing.T) {
 	}
 }
 
+func TestRenderNativeServerCGOSupportsBufferArrayNativeABI(t *testing.T) {
+	file := nativeServerBufferRepeatFile()
+	plugin := newTestPlugin(t, "paths=source_relative", file)
+
+	_, err := GenerateWithOptions(plugin)
+	if err != nil {
+		t.Fatalf("GenerateWithOptions() error = %v", err)
+	}
+
+	const cgoServerFile = "test/v1/cgo/native_buffer.buffer_service.server.native.cgo.rpccgo.go"
+	for _, fragment := range []string{
+		"uintptr_t TagsPtr, int32_t TagsLen, int32_t TagsOwnership",
+		"uintptr_t *outPayloadsPtr, int32_t *outPayloadsLen, int32_t *outPayloadsOwnership",
+		"tagsPtrValue, err := rpcruntime.PinStringArray(tagsValues)",
+		"labelsValuesPtrValue, err := rpcruntime.PinBytesArray(labelsValuesValues)",
+		"payloadsResult, err := rpcruntime.CopyBytesArray((*rpcruntime.RpcBuffer)(unsafe.Pointer(uintptr(payloadsPtr))), int32(payloadsLen))",
+		`rpcruntime.ReleaseBufferArray((*rpcruntime.RpcBuffer)(unsafe.Pointer(uintptr(tagsPtr))), int32(tagsLen), tagsOwnership > 0, "test.v1.RepeatedReply.tags")`,
+	} {
+		assertGeneratedContentContains(t, plugin, cgoServerFile, fragment)
+	}
+
+	const cgoClientFile = "test/v1/cgo/native_buffer.buffer_service.client.native.cgo.rpccgo.go"
+	for _, fragment := range []string{
+		"rpcruntime.NewRpcStringRepeatChecked((*rpcruntime.RpcBuffer)(TagsPtr), TagsLen, TagsOwnership > 0)",
+		"tagsPtrValue := buffer.PutStringArray(tagsResult)",
+	} {
+		assertGeneratedContentContains(t, plugin, cgoClientFile, fragment)
+	}
+}
+
+func TestRenderNativeCGOLowersRepeatedMessagesToEncodedBufferArrays(t *testing.T) {
+	file := nativeServerMessageRepeatFile()
+	plugin := newTestPlugin(t, "paths=source_relative", file)
+
+	_, err := GenerateWithOptions(plugin)
+	if err != nil {
+		t.Fatalf("GenerateWithOptions() error = %v", err)
+	}
+
+	assertGeneratedContentContains(t, plugin, "test/v1/native_message.message_service.server.native.rpccgo.go",
+		"Check(ctx context.Context, children *rpcruntime.RpcMessageRepeat[*Child], tags *rpcruntime.RpcStringRepeat) ([][]byte, []string, error)")
+
+	const cgoServerFile = "test/v1/cgo/native_message.message_service.server.native.cgo.rpccgo.go"
+	for _, fragment := range []string{
+		"uintptr_t ChildrenPtr, int32_t ChildrenLen, int32_t ChildrenOwnership",
+		"childrenValues := children.SafeEncodedSlice()",
+		"childrenPtrValue, err := rpcruntime.PinBytesArray(childrenValues)",
+		"childrenResult, err := rpcruntime.CopyBytesArray((*rpcruntime.RpcBuffer)(unsafe.Pointer(uintptr(childrenPtr))), int32(childrenLen))",
+		`rpcruntime.ReleaseBufferArray((*rpcruntime.RpcBuffer)(unsafe.Pointer(uintptr(childrenPtr))), int32(childrenLen), childrenOwnership > 0, "test.v1.RepeatedReply.children")`,
+	} {
+		assertGeneratedContentContains(t, plugin, cgoServerFile, fragment)
+	}
+
+	const cgoClientFile = "test/v1/cgo/native_message.message_service.client.native.cgo.rpccgo.go"
+	for _, fragment := range []string{
+		"childrenValue = rpcruntime.EmptyRpcMessageRepeat[*v1.Child]()",
+		"childrenValue, decodeErr = rpcruntime.NewRpcMessageRepeatChecked[*v1.Child]((*rpcruntime.RpcBuffer)(ChildrenPtr), ChildrenLen, ChildrenOwnership > 0)",
+		"childrenPtrValue, err := rpcruntime.PinBytesArray(childrenResult)",
+		"childrenPtrValue := buffer.PutBytesArray(childrenResult)",
+	} {
+		assertGeneratedContentContains(t, plugin, cgoClientFile, fragment)
+	}
+}
+
 func TestRenderNativeCGORepeatedUnsignedGeneratedSourceCompiles(t *testing.T) {
 	file := nativeServerRepeatedFile()
 	plugin := newTestPlugin(t, "paths=source_relative", file)
@@ -276,7 +342,8 @@ func TestRenderNativeCGORepeatedUnsignedGeneratedSourceCompiles(t *testing.T) {
 			strings.Contains(name, ".server.message.rpccgo.go") ||
 			strings.Contains(name, ".server.native.rpccgo.go") ||
 			strings.Contains(name, ".server.native.cgo.rpccgo.go") ||
-			strings.Contains(name, ".client.native.cgo.rpccgo.go")
+			strings.Contains(name, ".client.native.cgo.rpccgo.go") ||
+			strings.Contains(name, ".exports.cgo.rpccgo.go")
 	})
 	writeNativeRepeatedCompileStubs(t, tmp)
 
@@ -392,7 +459,8 @@ func TestRenderNativeServerCGOGeneratedSourceCompiles(t *testing.T) {
 			strings.Contains(name, ".server.message.rpccgo.go") ||
 			strings.Contains(name, ".server.native.rpccgo.go") ||
 			strings.Contains(name, ".server.native.cgo.rpccgo.go") ||
-			strings.Contains(name, ".client.native.cgo.rpccgo.go")
+			strings.Contains(name, ".client.native.cgo.rpccgo.go") ||
+			strings.Contains(name, ".exports.cgo.rpccgo.go")
 	})
 	writeNativeServerCompileStubs(t, tmp)
 
@@ -589,3 +657,204 @@ func nativeServerCGOCollisionTestFilePlan(serviceName string, methods []MethodPl
 		}},
 	}
 }
+
+func nativeServerMapFile() *descriptorpb.FileDescriptorProto {
+	file := nativeServerRepeatedFile()
+	file.Name = proto.String("test/v1/native_map.proto")
+	file.Service[0].Name = proto.String("MapService")
+	mapEntry := func(name string, keyType, valueType descriptorpb.FieldDescriptorProto_Type, valueTypeName string) *descriptorpb.DescriptorProto {
+		return &descriptorpb.DescriptorProto{
+			Name: proto.String(name),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				fieldDescriptor("key", 1, keyType, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+				fieldDescriptor("value", 2, valueType, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, valueTypeName),
+			},
+			Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+		}
+	}
+	for _, message := range file.MessageType {
+		typeName := ".test.v1." + message.GetName() + "."
+		message.Field = []*descriptorpb.FieldDescriptorProto{
+			fieldDescriptor("totals", 1, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, typeName+"TotalsEntry"),
+			fieldDescriptor("moods", 2, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, typeName+"MoodsEntry"),
+			fieldDescriptor("flags", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, typeName+"FlagsEntry"),
+		}
+		message.NestedType = []*descriptorpb.DescriptorProto{
+			mapEntry("TotalsEntry", descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_TYPE_INT64, ""),
+			mapEntry("MoodsEntry", descriptorpb.FieldDescriptorProto_TYPE_UINT32, descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".test.v1.Mood"),
+			mapEntry("FlagsEntry", descriptorpb.FieldDescriptorProto_TYPE_BOOL, descriptorpb.FieldDescriptorProto_TYPE_BOOL, ""),
+		}
+	}
+	return file
+}
+
+func nativeServerBufferRepeatFile() *descriptorpb.FileDescriptorProto {
+	file := nativeServerRepeatedFile()
+	file.Name = proto.String("test/v1/native_buffer.proto")
+	file.Service[0].Name = proto.String("BufferService")
+	for _, message := range file.MessageType {
+		message.Field = []*descriptorpb.FieldDescriptorProto{
+			fieldDescriptor("tags", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, ""),
+			fieldDescriptor("payloads", 2, descriptorpb.FieldDescriptorProto_TYPE_BYTES, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, ""),
+			fieldDescriptor("labels", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, ".test.v1."+message.GetName()+".LabelsEntry"),
+		}
+		message.NestedType = []*descriptorpb.DescriptorProto{{
+			Name: proto.String("LabelsEntry"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				fieldDescriptor("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+				fieldDescriptor("value", 2, descriptorpb.FieldDescriptorProto_TYPE_BYTES, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+			},
+			Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+		}}
+	}
+	return file
+}
+
+func nativeServerMessageRepeatFile() *descriptorpb.FileDescriptorProto {
+	file := nativeServerRepeatedFile()
+	file.Name = proto.String("test/v1/native_message.proto")
+	file.Service[0].Name = proto.String("MessageService")
+	for _, message := range file.MessageType {
+		message.Field = []*descriptorpb.FieldDescriptorProto{
+			fieldDescriptor("children", 1, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, ".test.v1.Child"),
+			fieldDescriptor("tags", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, ""),
+		}
+	}
+	file.MessageType = append(file.MessageType, &descriptorpb.DescriptorProto{
+		Name: proto.String("Child"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			fieldDescriptor("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+		},
+	})
+	return file
+}
+
+func TestRenderNativeCGOPassesOneofCaseAsTypedDiscriminant(t *testing.T) {
+	file := nativeServerOneofFile()
+	plugin := newTestPlugin(t, "paths=source_relative", file)
+
+	_, err := GenerateWithOptions(plugin)
+	if err != nil {
+		t.Fatalf("GenerateWithOptions() error = %v", err)
+	}
+
+	assertGeneratedContentContains(t, plugin, "test/v1/native_oneof.oneof_service.server.native.rpccgo.go",
+		"Check(ctx context.Context, note *rpcruntime.RpcString, valueCase OneofServiceCheckRequestValueCase, label *rpcruntime.RpcString, count int64) (string, OneofServiceCheckResponseValueCase, string, int64, error)")
+
+	const cgoServerFile = "test/v1/cgo/native_oneof.oneof_service.server.native.cgo.rpccgo.go"
+	for _, fragment := range []string{
+		"int32_t NoteOwnership, int32_t ValueCase, uintptr_t LabelPtr",
+		"valueCaseValue = C.int32_t(valueCase)",
+		"valueCaseResult := v1.OneofServiceCheckResponseValueCase(int32(valueCaseValue))",
+	} {
+		assertGeneratedContentContains(t, plugin, cgoServerFile, fragment)
+	}
+
+	const cgoClientFile = "test/v1/cgo/native_oneof.oneof_service.client.native.cgo.rpccgo.go"
+	for _, fragment := range []string{
+		"valueCaseValue := v1.OneofServiceCheckRequestValueCase(ValueCase)",
+		"valueCaseResultValue := int32(valueCaseResult)",
+	} {
+		assertGeneratedContentContains(t, plugin, cgoClientFile, fragment)
+	}
+}
+
+func TestRenderNativeCGOPassesPresenceFlagsForOptionalFields(t *testing.T) {
+	file := nativeServerPresenceFile()
+	plugin := newTestPlugin(t, "paths=source_relative", file)
+
+	_, err := GenerateWithOptions(plugin)
+	if err != nil {
+		t.Fatalf("GenerateWithOptions() error = %v", err)
+	}
+
+	assertGeneratedContentContains(t, plugin, "test/v1/native_presence.presence_service.server.native.rpccgo.go",
+		"Check(ctx context.Context, note *rpcruntime.RpcString, limit *int32, label *rpcruntime.RpcString, blob *rpcruntime.RpcBytes) (string, *int32, *string, []byte, error)")
+
+	const cgoServerFile = "test/v1/cgo/native_presence.presence_service.server.native.cgo.rpccgo.go"
+	for _, fragment := range []string{
+		"int32_t NoteOwnership, int8_t HasLimit, int32_t Limit, int8_t HasLabel, uintptr_t LabelPtr",
+		"int8_t *outHasLimit, int32_t *outLimit",
+		"if limit != nil {\n\t\thasLimit = 1\n\t\tlimitValue = C.int32_t(*limit)\n\t}",
+		"if hasLimit != 0 {\n\t\tlimitResultPresent := int32(limitValue)\n\t\tlimitResult = &limitResultPresent\n\t}",
+		"blobResultPresent = []byte{}",
+	} {
+		assertGeneratedContentContains(t, plugin, cgoServerFile, fragment)
+	}
+
+	const cgoClientFile = "test/v1/cgo/native_presence.presence_service.client.native.cgo.rpccgo.go"
+	for _, fragment := range []string{
+		"HasLimit C.int8_t, Limit C.int32_t",
+		"if HasLimit != 0 {\n\t\tlimitValuePresent := Limit\n\t\tlimitValue = &limitValuePresent\n\t}",
+		"if HasLabel != 0 {",
+		"if labelResult != nil {\n\t\tlabelResultHas = 1\n\t\tlabelResultPresent = *labelResult\n\t}",
+		"*outHasLimit = limitResultHas",
+		"*outHasBlob = blobResultHas",
+	} {
+		assertGeneratedContentContains(t, plugin, cgoClientFile, fragment)
+	}
+}
+
+func nativeServerOneofFile() *descriptorpb.FileDescriptorProto {
+	file := nativeServerRepeatedFile()
+	file.Name = proto.String("test/v1/native_oneof.proto")
+	file.Service[0].Name = proto.String("OneofService")
+	for _, message := range file.MessageType {
+		label := fieldDescriptor("label", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, "")
+		label.OneofIndex = proto.Int32(0)
+		count := fieldDescriptor("count", 2, descriptorpb.FieldDescriptorProto_TYPE_INT64, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, "")
+		count.OneofIndex = proto.Int32(0)
+		message.Field = []*descriptorpb.FieldDescriptorProto{
+			fieldDescriptor("note", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+			label,
+			count,
+		}
+		message.OneofDecl = []*descriptorpb.OneofDescriptorProto{{Name: proto.String("value")}}
+	}
+	return file
+}
+
+// nativeServerOneofMessageFile adds a message member to the oneof of
+// nativeServerOneofFile.
+func nativeServerOneofMessageFile() *descriptorpb.FileDescriptorProto {
+	file := nativeServerOneofFile()
+	file.Name = proto.String("test/v1/native_oneof_message.proto")
+	file.Service[0].Name = proto.String("OneofMessageService")
+	for _, message := range file.MessageType {
+		child := fieldDescriptor("child", 4, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ".test.v1.Child")
+		child.OneofIndex = proto.Int32(0)
+		message.Field = append(message.Field, child)
+	}
+	file.MessageType = append(file.MessageType, &descriptorpb.DescriptorProto{
+		Name: proto.String("Child"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			fieldDescriptor("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+		},
+	})
+	return file
+}
+
+func nativeServerPresenceFile() *descriptorpb.FileDescriptorProto {
+	file := nativeServerRepeatedFile()
+	file.Name = proto.String("test/v1/native_presence.proto")
+	file.Service[0].Name = proto.String("PresenceService")
+	for _, message := range file.MessageType {
+		message.Field = []*descriptorpb.FieldDescriptorProto{
+			fieldDescriptor("note", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+		}
+		message.OneofDecl = nil
+		appendProto3OptionalField(message, fieldDescriptor("limit", 1, descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""))
+		appendProto3OptionalField(message, fieldDescriptor("label", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""))
+		appendProto3OptionalField(message, fieldDescriptor("blob", 3, descriptorpb.FieldDescriptorProto_TYPE_BYTES, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""))
+	}
+	return file
+}
+
+// appendProto3OptionalField adds field to message as protoc lowers a proto3
+// optional field: a member of its own synthetic oneof.
+func appendProto3OptionalField(message *descriptorpb.DescriptorProto, field *descriptorpb.FieldDescriptorProto) {
+	field.Proto3Optional = proto.Bool(true)
+	field.OneofIndex = proto.Int32(int32(len(message.OneofDecl)))
+	message.OneofDecl = append(message.OneofDecl, &descriptorpb.OneofDescriptorProto{Name: proto.String("_" + field.GetName())})
+	message.Field = append(message.Field, field)
+}
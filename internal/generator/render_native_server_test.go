@@ -27,8 +27,10 @@ func TestRenderNativeServerDefinesInterfaceAdapterAndRegistration(t *testing.T)
 		`errors.New("rpccgo: AllService.Unary native server method is not implemented")`,
 		"func RegisterAllServiceGoNativeServer(server AllServiceNativeServer) error {",
 		`errors.New("rpccgo: AllService go native server is nil")`,
-		"return registerAllServiceGoNativeServer(server)",
-		"func registerAllServiceGoNativeServer(server AllServiceNativeServer) error {",
+		"return RegisterAllServiceGoNativeServerIn(rpcruntime.DefaultServerRegistry(), server)",
+		"func RegisterAllServiceGoNativeServerIn(registry *rpcruntime.ServerRegistry, server AllServiceNativeServer) error {",
+		"return registerAllServiceGoNativeServerIn(registry, server)",
+		"func registerAllServiceGoNativeServerIn(registry *rpcruntime.ServerRegistry, server AllServiceNativeServer) error {",
 		"Kind:   rpcruntime.ServerKindGoNative,",
 		"func RegisterAllServiceCGONativeServer(server AllServiceNativeServer) error {",
 		"Kind:   rpcruntime.ServerKindCGONative,",
@@ -93,6 +95,8 @@ func TestRenderNativeServerDefinesStreamingMethodSignatures(t *testing.T) {
 		"func (s *allServiceBidiStreamGoNativeBidiStreamingServer) Recv(ctx context.Context) (*rpcruntime.RpcString, bool, *rpcruntime.RpcBytes, error)",
 		"return s.stream.Send(ctx, AllServiceBidiStreamNativeStreamResponse{Accepted: accepted, Payload: payload})",
 		"client, stream, streamCtx := rpcruntime.NewBidiStreaming[AllServiceBidiStreamNativeStreamRequest, AllServiceBidiStreamNativeStreamResponse]",
+		`Method:           "test.v1.AllService.BidiStream",`,
+		"BorrowedRequests: true,",
 	} {
 		assertGeneratedContentContains(t, plugin, nativeServerFile, fragment)
 	}
@@ -426,6 +430,30 @@ func TestRegisterAllServiceGoNativeServerNilClearsCurrentServer(t *testing.T) {
 		t.Fatalf("LoadServer(after nil register) error = %v, want ErrNoRegisteredServer", err)
 	}
 }
+
+func TestRegisterAllServiceGoNativeServerInIsolatesRegistry(t *testing.T) {
+	registry := rpcruntime.NewServerRegistry()
+	if err := RegisterAllServiceGoNativeServerIn(registry, registrationClearAllServiceNativeServer{}); err != nil {
+		t.Fatalf("RegisterAllServiceGoNativeServerIn(valid) error = %v", err)
+	}
+	if _, err := LoadAllServiceRegisteredServer(); !errors.Is(err, rpcruntime.ErrNoRegisteredServer) {
+		t.Fatalf("LoadAllServiceRegisteredServer() error = %v, want ErrNoRegisteredServer", err)
+	}
+	if _, _, err := InvokeAllServiceNativeUnary(context.Background(), nil, true, nil); !errors.Is(err, rpcruntime.ErrNoRegisteredServer) {
+		t.Fatalf("InvokeAllServiceNativeUnary(default registry) error = %v, want ErrNoRegisteredServer", err)
+	}
+	ctx := rpcruntime.WithServerRegistry(context.Background(), registry)
+	accepted, payload, err := InvokeAllServiceNativeUnary(ctx, nil, true, nil)
+	if err != nil || !accepted || string(payload) != "ok" {
+		t.Fatalf("InvokeAllServiceNativeUnary(scoped registry) = (%v, %q, %v), want (true, ok, nil)", accepted, payload, err)
+	}
+	if err := ClearAllServiceServerIn(registry); err != nil {
+		t.Fatalf("ClearAllServiceServerIn() error = %v", err)
+	}
+	if _, err := LoadAllServiceRegisteredServerIn(registry); !errors.Is(err, rpcruntime.ErrNoRegisteredServer) {
+		t.Fatalf("LoadAllServiceRegisteredServerIn(after clear) error = %v, want ErrNoRegisteredServer", err)
+	}
+}
 `
 	target := filepath.Join(root, "test/v1/native_registration_clear_test.go")
 	if err := os.WriteFile(target, []byte(content), 0o644); err != nil {
@@ -446,10 +474,11 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 )
 
+type Child struct{}
 type AllRequest struct {
 	Name string
 	Enabled bool
-	Child []byte
+	Child *Child
 }
 type AllReply struct {
 	Accepted bool
@@ -458,7 +487,7 @@ type AllReply struct {
 type DefaultRequest struct {
 	Name string
 	Enabled bool
-	Child []byte
+	Child *Child
 }
 type DefaultReply struct {
 	Accepted bool
@@ -467,7 +496,7 @@ type DefaultReply struct {
 type ConnectRequest struct {
 	Name string
 	Enabled bool
-	Child []byte
+	Child *Child
 }
 type ConnectReply struct {
 	Accepted bool
@@ -476,7 +505,7 @@ type ConnectReply struct {
 type GrpcRequest struct {
 	Name string
 	Enabled bool
-	Child []byte
+	Child *Child
 }
 type GrpcReply struct {
 	Accepted bool
@@ -485,7 +514,7 @@ type GrpcReply struct {
 type MessageRequest struct {
 	Name string
 	Enabled bool
-	Child []byte
+	Child *Child
 }
 type MessageReply struct {
 	Accepted bool
@@ -494,7 +523,7 @@ type MessageReply struct {
 type ConnectNativeRequest struct {
 	Name string
 	Enabled bool
-	Child []byte
+	Child *Child
 }
 type ConnectNativeReply struct {
 	Accepted bool
@@ -503,7 +532,7 @@ type ConnectNativeReply struct {
 type NativeOnlyRequest struct {
 	Name string
 	Enabled bool
-	Child []byte
+	Child *Child
 }
 type NativeOnlyReply struct {
 	Accepted bool
@@ -559,6 +588,7 @@ type GrpcServiceClient interface {
 	GrpcUnary(context.Context, *GrpcRequest, ...grpc.CallOption) (*GrpcReply, error)
 }
 
+func (*Child) ProtoReflect() protoreflect.Message { return nil }
 func (*AllRequest) ProtoReflect() protoreflect.Message { return nil }
 func (*AllReply) ProtoReflect() protoreflect.Message { return nil }
 func (*DefaultRequest) ProtoReflect() protoreflect.Message { return nil }
@@ -2,6 +2,7 @@ package generator
 
 import (
 	"strconv"
+	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
 )
@@ -61,15 +62,46 @@ func renderRuntimeFile(plugin *protogen.Plugin, plan FilePlan, service ServicePl
 	renderDoc(g, service.GoName+"MessageServerUnavailableErr", "is returned when a message server registration is missing or invalid.")
 	g.P("var ", service.GoName, `MessageServerUnavailableErr = errors.New("rpccgo: message server is unavailable")`)
 	g.P()
+	renderRuntimeServiceManifest(g, plan, service, serviceIDName)
 
 	renderDoc(g, "Clear"+service.GoName+"Server", "clears the current registered server for this service.")
 	g.P("func Clear", service.GoName, "Server() error {")
-	g.P("return rpcruntime.ClearServer(", serviceIDName, ")")
+	g.P("return Clear", service.GoName, "ServerIn(rpcruntime.DefaultServerRegistry())")
 	g.P("}")
 	g.P()
-	renderDoc(g, "Load"+service.GoName+"RegisteredServer", "loads the current registered server record for this service.")
+	renderDoc(g, "Clear"+service.GoName+"ServerIn", "clears the current registered server for this service in registry.")
+	g.P("func Clear", service.GoName, "ServerIn(registry *rpcruntime.ServerRegistry) error {")
+	g.P("return registry.Clear(", serviceIDName, ")")
+	g.P("}")
+	g.P()
+	renderDoc(g, "Load"+service.GoName+"RegisteredServer", "loads the current registered server record for this service. With a multi-server route it returns the first target.")
 	g.P("func Load", service.GoName, "RegisteredServer() (rpcruntime.RegisteredServer, error) {")
-	g.P("return rpcruntime.LoadServer(", serviceIDName, ")")
+	g.P("return Load", service.GoName, "RegisteredServerIn(rpcruntime.DefaultServerRegistry())")
+	g.P("}")
+	g.P()
+	renderDoc(g, "Load"+service.GoName+"RegisteredServerIn", "loads the current registered server record for this service from registry.")
+	g.P("func Load", service.GoName, "RegisteredServerIn(registry *rpcruntime.ServerRegistry) (rpcruntime.RegisteredServer, error) {")
+	g.P("return registry.Load(", serviceIDName, ")")
+	g.P("}")
+	g.P()
+	renderDoc(g, "Register"+service.GoName+"ServerRoute", "replaces the registered servers for this service with an ordered or weighted route. Targets are records loaded after the generated Register helpers.")
+	g.P("func Register", service.GoName, "ServerRoute(route rpcruntime.ServerRoute) error {")
+	g.P("return Register", service.GoName, "ServerRouteIn(rpcruntime.DefaultServerRegistry(), route)")
+	g.P("}")
+	g.P()
+	renderDoc(g, "Register"+service.GoName+"ServerRouteIn", "replaces the registered servers for this service in registry with an ordered or weighted route.")
+	g.P("func Register", service.GoName, "ServerRouteIn(registry *rpcruntime.ServerRegistry, route rpcruntime.ServerRoute) error {")
+	g.P("return registry.RegisterRoute(", serviceIDName, ", route)")
+	g.P("}")
+	g.P()
+	renderDoc(g, "Load"+service.GoName+"ServerRoute", "loads the registered server route for this service.")
+	g.P("func Load", service.GoName, "ServerRoute() (rpcruntime.ServerRoute, error) {")
+	g.P("return Load", service.GoName, "ServerRouteIn(rpcruntime.DefaultServerRegistry())")
+	g.P("}")
+	g.P()
+	renderDoc(g, "Load"+service.GoName+"ServerRouteIn", "loads the registered server route for this service from registry.")
+	g.P("func Load", service.GoName, "ServerRouteIn(registry *rpcruntime.ServerRegistry) (rpcruntime.ServerRoute, error) {")
+	g.P("return registry.LoadRoute(", serviceIDName, ")")
 	g.P("}")
 	g.P()
 
@@ -84,6 +116,62 @@ func renderRuntimeFile(plugin *protogen.Plugin, plan FilePlan, service ServicePl
 	return nil
 }
 
+// renderRuntimeServiceManifest registers the service with rpcruntime
+// reflection so C callers can discover it without the generated header, and
+// its message facades with dynamic invocation so they can call it by name.
+func renderRuntimeServiceManifest(g *protogen.GeneratedFile, plan FilePlan, service ServicePlan, serviceIDName string) {
+	transport := "rpcruntime.ServerKindConnect"
+	if service.Generation.MessageTransport == MessageTransportGRPC {
+		transport = "rpcruntime.ServerKindGRPC"
+	}
+	g.P("func init() {")
+	g.P("rpcruntime.RegisterServiceManifest(rpcruntime.ServiceManifest{")
+	g.P("ServiceID: ", serviceIDName, ",")
+	g.P("ProtoFile: ", strconv.Quote(plan.ProtoPath), ",")
+	g.P("Native: ", strconv.FormatBool(service.Generation.NativeEnabled), ",")
+	g.P("MessageTransport: ", transport, ",")
+	g.P("Methods: []rpcruntime.MethodManifest{")
+	for _, method := range service.Methods {
+		g.P("{Name: ", strconv.Quote(method.Name), ", FullName: ", strconv.Quote(method.FullName), ", Streaming: ", runtimeMethodStreamingName(method.Streaming), ", RequestType: ", strconv.Quote(method.Request.FullName), ", ResponseType: ", strconv.Quote(method.Response.FullName), "},")
+	}
+	g.P("},")
+	g.P("})")
+	for _, method := range service.Methods {
+		g.P("rpcruntime.RegisterDynamicMethod(", strconv.Quote(method.FullName), ", ", runtimeDynamicMethod(service, method), ")")
+	}
+	g.P("}")
+	g.P()
+}
+
+// runtimeDynamicMethod adapts the message facades of method for
+// rpcruntime.RegisterDynamicMethod.
+func runtimeDynamicMethod(service ServicePlan, method MethodPlan) string {
+	op := func(operation string) string { return runtimeMessageStreamOperationCallName(service, method, operation) }
+	switch method.Streaming {
+	case StreamingKindClientStreaming:
+		return "rpcruntime.DynamicClientStream(" + strings.Join([]string{op("Start"), op("Send"), op("Finish"), op("Cancel")}, ", ") + ")"
+	case StreamingKindServerStreaming:
+		return "rpcruntime.DynamicServerStream(" + strings.Join([]string{op("Start"), op("Recv"), op("Cancel")}, ", ") + ")"
+	case StreamingKindBidiStreaming:
+		return "rpcruntime.DynamicBidiStream(" + strings.Join([]string{op("Start"), op("Send"), op("Recv"), op("CloseSend"), op("Finish"), op("Cancel")}, ", ") + ")"
+	default:
+		return "rpcruntime.DynamicUnary(Invoke" + service.GoName + "Message" + method.GoName + ")"
+	}
+}
+
+func runtimeMethodStreamingName(kind StreamingKind) string {
+	switch kind {
+	case StreamingKindClientStreaming:
+		return "rpcruntime.MethodStreamingClient"
+	case StreamingKindServerStreaming:
+		return "rpcruntime.MethodStreamingServer"
+	case StreamingKindBidiStreaming:
+		return "rpcruntime.MethodStreamingBidi"
+	default:
+		return "rpcruntime.MethodStreamingUnary"
+	}
+}
+
 func runtimeNeedsGoRuntime(service ServicePlan) bool {
 	if !service.Generation.NativeEnabled {
 		return false
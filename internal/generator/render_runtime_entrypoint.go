@@ -33,10 +33,16 @@ func renderRuntimeEntrypoints(g *protogen.GeneratedFile, service ServicePlan, se
 
 func renderRuntimeUnaryNativeEntrypoint(g *protogen.GeneratedFile, service ServicePlan, serviceIDName string, method runtimeMethodProjection) {
 	name := "Invoke" + service.GoName + "Native" + method.Identity.GoName
-	renderDoc(g, name, "invokes the current registered server using the native contract for "+method.Identity.GoName+".")
-	g.P("func Invoke", service.GoName, "Native", method.Identity.GoName, "(ctx context.Context", method.Native.Args, ") (", method.Native.Returns, ") {")
-	g.P("registered, err := rpcruntime.LoadServer(", serviceIDName, ")")
-	g.P("if err != nil { return ", method.Native.ErrZero, " }")
+	privateName := runtimePrivateFacadeName(name)
+	renderDoc(g, name, "invokes the server selected by the service route using the native contract for "+method.Identity.GoName+".")
+	g.P("func ", name, "(ctx context.Context", method.Native.Args, ") (", method.Native.Returns, ") {")
+	callInfo := runtimeCallInfoLiteral(serviceIDName, method, "Native", "Unary", "", "")
+	privateCall := privateName + "(ctx, registered" + nativeGoCallSuffix(method.Native.ArgNames) + ")"
+	renderRuntimeRoutedCall(g, "RouteUnary", callInfo, privateCall, runtimeInterceptNativeResult(method))
+	g.P("}")
+	g.P()
+
+	g.P("func ", privateName, "(ctx context.Context, registered rpcruntime.RegisteredServer", method.Native.Args, ") (", method.Native.Returns, ") {")
 	g.P("switch registered.Kind {")
 	for _, route := range method.Routes.NativeServers {
 		renderRuntimeUnaryNativeToNativeCase(g, service, method, route)
@@ -46,7 +52,7 @@ func renderRuntimeUnaryNativeEntrypoint(g *protogen.GeneratedFile, service Servi
 		renderRuntimeUnaryNativeToTransportCase(g, service, method, route)
 	}
 	g.P("default:")
-	g.P("return ", method.Native.ErrZero)
+	g.P("return ", nativeGoZeroReturnsForError(method, "fmt.Errorf(\"rpccgo: "+service.GoName+" registered server kind %d is unsupported for native calls\", registered.Kind)"))
 	g.P("}")
 	g.P("}")
 	g.P()
@@ -77,20 +83,25 @@ func renderRuntimeUnaryNativeToTransportCase(g *protogen.GeneratedFile, service
 	g.P("messageReq, err := ", method.Codec.NativeRequestToMessage, "(", method.Native.ArgNames, ")")
 	g.P("if err != nil { return ", method.Native.ErrZero, " }")
 	g.P("var messageResp ", runtimeMessageResponseType(method))
-	renderRuntimeTransportUnaryNativeMessageCall(g, method, "server", "messageReq")
+	renderRuntimeTransportUnaryNativeMessageCall(g, method, route.Kind, "server", "messageReq")
 	g.P("if err != nil { return ", method.Native.ErrZero, " }")
 	g.P("return ", method.Codec.MessageToNativeResponse, "(messageResp)")
 }
 
 func renderRuntimeUnaryMessageEntrypoint(g *protogen.GeneratedFile, service ServicePlan, serviceIDName string, method runtimeMethodProjection) {
 	name := "Invoke" + service.GoName + "Message" + method.Identity.GoName
-	renderDoc(g, name, "invokes the current registered server using the message contract for "+method.Identity.GoName+".")
-	g.P("func Invoke", service.GoName, "Message", method.Identity.GoName, "(ctx context.Context, req ", runtimeMessageRequestType(method), ") (", runtimeMessageResponseType(method), ", error) {")
+	privateName := runtimePrivateFacadeName(name)
+	renderDoc(g, name, "invokes the server selected by the service route using the message contract for "+method.Identity.GoName+".")
+	g.P("func ", name, "(ctx context.Context, req ", runtimeMessageRequestType(method), ") (", runtimeMessageResponseType(method), ", error) {")
 	g.P("if req == nil {")
 	g.P(`return nil, errors.New("rpccgo: message request is nil")`)
 	g.P("}")
-	g.P("registered, err := rpcruntime.LoadServer(", serviceIDName, ")")
-	g.P("if err != nil { return nil, err }")
+	callInfo := runtimeCallInfoLiteral(serviceIDName, method, "Message", "Unary", "", "")
+	renderRuntimeRoutedCall(g, "RouteUnary", callInfo, privateName+"(ctx, registered, req)", runtimeInterceptValueResult("resp", runtimeMessageResponseType(method), "nil, err"))
+	g.P("}")
+	g.P()
+
+	g.P("func ", privateName, "(ctx context.Context, registered rpcruntime.RegisteredServer, req ", runtimeMessageRequestType(method), ") (", runtimeMessageResponseType(method), ", error) {")
 	g.P("switch registered.Kind {")
 	for _, route := range method.Routes.NativeServers {
 		renderRuntimeUnaryMessageToNativeCase(g, service, method, route)
@@ -141,19 +152,27 @@ func renderRuntimeUnaryMessageToTransportCase(g *protogen.GeneratedFile, service
 	g.P("case ", route.Kind, ":")
 	g.P("server, ok := registered.Server.(", route.ServerType, ")")
 	g.P(`if !ok { return nil, fmt.Errorf("rpccgo: `, service.GoName, " ", route.Label, ` registered server has invalid type") }`)
-	renderRuntimeTransportUnaryMessageCall(g, method, "server", "req")
+	renderRuntimeTransportUnaryMessageCall(g, method, route.Kind, "server", "req")
 }
 
 func renderRuntimeNativeStartEntrypoint(g *protogen.GeneratedFile, service ServicePlan, serviceIDName string, method runtimeMethodProjection) {
 	name := runtimeStreamOperationName(service.GoName, "Native", method, "Start")
-	renderDoc(g, name, "starts a native contract stream for "+method.Identity.GoName+" on the current registered server.")
+	privateName := runtimePrivateFacadeName(name)
+	renderDoc(g, name, "starts a native contract stream for "+method.Identity.GoName+" on the server selected by the service route.")
+	args := ""
+	argNames := ""
 	if method.Stream.StartAcceptsRequest {
-		g.P("func ", name, "(ctx context.Context", method.Native.Args, ") (rpcruntime.StreamHandle, error) {")
-	} else {
-		g.P("func ", name, "(ctx context.Context) (rpcruntime.StreamHandle, error) {")
+		args = method.Native.Args
+		argNames = method.Native.ArgNames
 	}
-	g.P("registered, err := rpcruntime.LoadServer(", serviceIDName, ")")
-	g.P("if err != nil { return 0, err }")
+	g.P("func ", name, "(ctx context.Context", args, ") (rpcruntime.StreamHandle, error) {")
+	callInfo := runtimeCallInfoLiteral(serviceIDName, method, "Native", "Start", "", "")
+	privateCall := privateName + "(ctx, registered" + nativeGoCallSuffix(argNames) + ")"
+	renderRuntimeRoutedCall(g, "RouteStream", callInfo, privateCall, runtimeInterceptValueResult("streamHandle", "rpcruntime.StreamHandle", "0, err"))
+	g.P("}")
+	g.P()
+
+	g.P("func ", privateName, "(ctx context.Context, registered rpcruntime.RegisteredServer", args, ") (rpcruntime.StreamHandle, error) {")
 	g.P("switch registered.Kind {")
 	for _, route := range method.Routes.NativeServers {
 		renderRuntimeNativeStartNativeCase(g, service, method, route)
@@ -217,17 +236,26 @@ func renderRuntimeNativeStartTransportCase(g *protogen.GeneratedFile, service Se
 
 func renderRuntimeMessageStartEntrypoint(g *protogen.GeneratedFile, service ServicePlan, serviceIDName string, method runtimeMethodProjection) error {
 	name := runtimeStreamOperationName(service.GoName, "Message", method, "Start")
-	renderDoc(g, name, "starts a message contract stream for "+method.Identity.GoName+" on the current registered server.")
+	privateName := runtimePrivateFacadeName(name)
+	renderDoc(g, name, "starts a message contract stream for "+method.Identity.GoName+" on the server selected by the service route.")
+	args := ""
+	argNames := ""
+	if method.Stream.StartAcceptsRequest {
+		args = ", req " + runtimeMessageRequestType(method)
+		argNames = ", req"
+	}
+	g.P("func ", name, "(ctx context.Context", args, ") (rpcruntime.StreamHandle, error) {")
 	if method.Stream.StartAcceptsRequest {
-		g.P("func ", name, "(ctx context.Context, req ", runtimeMessageRequestType(method), ") (rpcruntime.StreamHandle, error) {")
 		g.P("if req == nil {")
 		g.P(`return 0, errors.New("rpccgo: message request is nil")`)
 		g.P("}")
-	} else {
-		g.P("func ", name, "(ctx context.Context) (rpcruntime.StreamHandle, error) {")
 	}
-	g.P("registered, err := rpcruntime.LoadServer(", serviceIDName, ")")
-	g.P("if err != nil { return 0, err }")
+	callInfo := runtimeCallInfoLiteral(serviceIDName, method, "Message", "Start", "", "")
+	renderRuntimeRoutedCall(g, "RouteStream", callInfo, privateName+"(ctx, registered"+argNames+")", runtimeInterceptValueResult("streamHandle", "rpcruntime.StreamHandle", "0, err"))
+	g.P("}")
+	g.P()
+
+	g.P("func ", privateName, "(ctx context.Context, registered rpcruntime.RegisteredServer", args, ") (rpcruntime.StreamHandle, error) {")
 	g.P("switch registered.Kind {")
 	for _, route := range method.Routes.NativeServers {
 		renderRuntimeMessageStartNativeCase(g, service, method, route)
@@ -317,11 +345,11 @@ func renderRuntimeTransportStreamSource(g *protogen.GeneratedFile, service Servi
 }
 
 func renderRuntimeCreateNativeStreamHandle(g *protogen.GeneratedFile, kind runtimeServerKindExpr, sourceExpr string) {
-	g.P("return rpcruntime.CreateStreamSession(", kind, ", ", sourceExpr, ")")
+	g.P("return rpcruntime.CreateStreamSessionContext(ctx, ", kind, ", ", sourceExpr, ")")
 }
 
 func renderRuntimeCreateMessageStreamHandle(g *protogen.GeneratedFile, kind runtimeServerKindExpr, sourceExpr string) {
-	g.P("return rpcruntime.CreateStreamSession(", kind, ", ", sourceExpr, ")")
+	g.P("return rpcruntime.CreateStreamSessionContext(ctx, ", kind, ", ", sourceExpr, ")")
 }
 
 func nativeGoZeroReturnsForError(method runtimeMethodProjection, errExpr string) string {
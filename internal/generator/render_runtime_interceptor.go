@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// runtimeInterceptResult describes how a public facade captures the values
+// returned by its private dispatch function inside the interceptor closure.
+type runtimeInterceptResult struct {
+	Decls     []string
+	Names     string
+	ErrReturn string
+}
+
+func runtimeInterceptErrorResult() runtimeInterceptResult {
+	return runtimeInterceptResult{}
+}
+
+func runtimeInterceptValueResult(name, goType, errReturn string) runtimeInterceptResult {
+	return runtimeInterceptResult{
+		Decls:     []string{"var " + name + " " + goType},
+		Names:     name,
+		ErrReturn: errReturn,
+	}
+}
+
+func runtimeInterceptNativeResult(method runtimeMethodProjection) runtimeInterceptResult {
+	if method.Native.ResultNames == "" {
+		return runtimeInterceptErrorResult()
+	}
+	return runtimeInterceptResult{
+		Decls:     method.Native.ResultVarDecls,
+		Names:     method.Native.ResultNames,
+		ErrReturn: method.Native.ErrZero,
+	}
+}
+
+func runtimeServiceIDName(serviceName string) string {
+	return lowerInitial(serviceName) + "ServiceID"
+}
+
+func runtimePrivateFacadeName(name string) string {
+	return lowerInitial(name)
+}
+
+func runtimeCallInfoLiteral(serviceIDName string, method runtimeMethodProjection, contract, operation, kindExpr, handleExpr string) string {
+	literal := "rpcruntime.CallInfo{\n" +
+		"ServiceID: " + serviceIDName + ",\n" +
+		"Method: " + strconv.Quote(method.Identity.SourceFullName) + ",\n" +
+		"Contract: rpcruntime.CallContract" + contract + ",\n"
+	if kindExpr != "" {
+		literal += "Kind: " + kindExpr + ",\n"
+	}
+	literal += "Operation: rpcruntime.CallOperation" + operation + ",\n"
+	if handleExpr != "" {
+		literal += "Stream: " + handleExpr + ",\n"
+	}
+	return literal + "}"
+}
+
+// renderRuntimeInterceptedCall routes a public facade through the registered
+// rpcruntime interceptor chain before calling its private dispatch function.
+func renderRuntimeInterceptedCall(g *protogen.GeneratedFile, intercept, callInfo, privateCall string, result runtimeInterceptResult) {
+	if result.Names == "" {
+		g.P("return rpcruntime.", intercept, "(ctx, ", callInfo, ", func(ctx context.Context, _ rpcruntime.CallInfo) error {")
+		g.P("return ", privateCall)
+		g.P("})")
+		return
+	}
+	for _, decl := range result.Decls {
+		g.P(decl)
+	}
+	g.P("err = rpcruntime.", intercept, "(ctx, ", callInfo, ", func(ctx context.Context, _ rpcruntime.CallInfo) error {")
+	g.P("var callErr error")
+	g.P(result.Names, ", callErr = ", privateCall)
+	g.P("return callErr")
+	g.P("})")
+	g.P("if err != nil { return ", result.ErrReturn, " }")
+	g.P("return ", result.Names, ", nil")
+}
+
+// renderRuntimeRoutedCall renders an Invoke or Start body that runs the
+// interceptors once and lets the route of the registry selected by ctx pick
+// the registered server inside them.
+func renderRuntimeRoutedCall(g *protogen.GeneratedFile, route, callInfo, privateCall string, result runtimeInterceptResult) {
+	if result.Names == "" {
+		g.P("return rpcruntime.ServerRegistryFromContext(ctx).", route, "(ctx, ", callInfo, ", func(ctx context.Context, registered rpcruntime.RegisteredServer) error {")
+		g.P("return ", privateCall)
+		g.P("})")
+		return
+	}
+	for _, decl := range result.Decls {
+		g.P(decl)
+	}
+	g.P("err := rpcruntime.ServerRegistryFromContext(ctx).", route, "(ctx, ", callInfo, ", func(ctx context.Context, registered rpcruntime.RegisteredServer) error {")
+	g.P("var callErr error")
+	g.P(result.Names, ", callErr = ", privateCall)
+	g.P("return callErr")
+	g.P("})")
+	g.P("if err != nil { return ", result.ErrReturn, " }")
+	g.P("return ", result.Names, ", nil")
+}
@@ -1,6 +1,10 @@
 package generator
 
-import "google.golang.org/protobuf/compiler/protogen"
+import (
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
 
 func renderMessageStartHelpers(g *protogen.GeneratedFile, service ServicePlan, methods []runtimeMethodProjection, serverName string) {
 	for _, method := range methods {
@@ -28,11 +32,12 @@ func renderMessageServerClientStreamStartHelper(g *protogen.GeneratedFile, servi
 	respType := runtimeMessageResponseType(method)
 	g.P("func ", cgoMessageStartHelperName(serviceName, method.Identity.GoName), "(ctx context.Context, server ", serverName, ") (", clientType, ", error) {")
 	g.P("client, stream, streamCtx := rpcruntime.NewClientStreaming[", reqType, ", ", respType, "](ctx, rpcruntime.LocalStreamOptions{")
-	g.P("RequestBuffer: 16,")
+	renderLocalStreamBufferingOptions(g, method.Identity.SourceFullName, method.Stream.Buffering, true, false, false)
 	g.P(`StreamClosed: errors.New("rpccgo: message stream is closed"),`)
 	g.P(`NilRequest: errors.New("rpccgo: message request is nil"),`)
 	g.P("})")
 	g.P("go func() {")
+	renderStreamHandlerRecover(g, method.Identity.SourceFullName, "stream.Complete(nil, err)")
 	g.P("resp, err := server.", method.Identity.GoName, "(streamCtx, stream)")
 	g.P("stream.Complete(resp, err)")
 	g.P("}()")
@@ -55,11 +60,12 @@ func renderMessageServerServerStreamStartHelper(g *protogen.GeneratedFile, servi
 	g.P("return direct.", method.Identity.GoName, "Start(ctx, req)")
 	g.P("}")
 	g.P("client, stream, streamCtx := rpcruntime.NewServerStreaming[", respType, "](ctx, rpcruntime.LocalStreamOptions{")
-	g.P("ResponseBuffer: 1,")
+	renderLocalStreamBufferingOptions(g, method.Identity.SourceFullName, method.Stream.Buffering, false, true, false)
 	g.P(`StreamClosed: errors.New("rpccgo: message stream is closed"),`)
 	g.P(`NilResponse: errors.New("rpccgo: message response is nil"),`)
 	g.P("})")
 	g.P("go func() {")
+	renderStreamHandlerRecover(g, method.Identity.SourceFullName, "stream.Complete(err)")
 	g.P("err := server.", method.Identity.GoName, "(streamCtx, req, stream)")
 	g.P("stream.Complete(err)")
 	g.P("}()")
@@ -74,13 +80,13 @@ func renderMessageServerBidiStreamStartHelper(g *protogen.GeneratedFile, service
 	respType := runtimeMessageResponseType(method)
 	g.P("func ", cgoMessageStartHelperName(serviceName, method.Identity.GoName), "(ctx context.Context, server ", serverName, ") (", clientType, ", error) {")
 	g.P("client, stream, streamCtx := rpcruntime.NewBidiStreaming[", reqType, ", ", respType, "](ctx, rpcruntime.LocalStreamOptions{")
-	g.P("RequestBuffer: 16,")
-	g.P("ResponseBuffer: 1,")
+	renderLocalStreamBufferingOptions(g, method.Identity.SourceFullName, method.Stream.Buffering, true, true, false)
 	g.P(`StreamClosed: errors.New("rpccgo: message stream is closed"),`)
 	g.P(`NilRequest: errors.New("rpccgo: message request is nil"),`)
 	g.P(`NilResponse: errors.New("rpccgo: message response is nil"),`)
 	g.P("})")
 	g.P("go func() {")
+	renderStreamHandlerRecover(g, method.Identity.SourceFullName, "stream.Complete(err)")
 	g.P("err := server.", method.Identity.GoName, "(streamCtx, stream)")
 	g.P("stream.Complete(err)")
 	g.P("}()")
@@ -88,3 +94,9 @@ func renderMessageServerBidiStreamStartHelper(g *protogen.GeneratedFile, service
 	g.P("}")
 	g.P()
 }
+
+// renderStreamHandlerRecover completes a local stream with the panic of the
+// server handler running on its goroutine instead of crashing the process.
+func renderStreamHandlerRecover(g *protogen.GeneratedFile, fullMethod, complete string) {
+	g.P("defer rpcruntime.RecoverPanic(", strconv.Quote(fullMethod+" handler"), ", func(err error) { ", complete, " })")
+}
@@ -0,0 +1,44 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// renderRuntimeTransportMetadataContext declares callCtx, the context that
+// carries request metadata into a unary call on a transport server. It returns
+// the extra call arguments and the statement that reports the response
+// metadata once the call returns.
+func renderRuntimeTransportMetadataContext(g *protogen.GeneratedFile, method runtimeMethodProjection, kind runtimeServerKindExpr) (string, string) {
+	procedure := strconv.Quote(runtimeMethodProcedure(method))
+	switch kind {
+	case runtimeServerKindConnect:
+		g.P("callCtx, finishMetadata := rpcruntime.ConnectHandlerContext(ctx, ", procedure, ")")
+		return "", "finishMetadata()"
+	case runtimeServerKindConnectRemote:
+		g.P("callCtx, finishMetadata := rpcruntime.ConnectClientContext(ctx)")
+		return "", "finishMetadata()"
+	case runtimeServerKindGRPC:
+		g.P("callCtx := rpcruntime.GRPCHandlerContext(ctx, ", procedure, ")")
+		return "", ""
+	case runtimeServerKindGRPCRemote:
+		g.P("callCtx, callOpts, finishMetadata := rpcruntime.GRPCClientContext(ctx)")
+		return ", callOpts...", "finishMetadata()"
+	default:
+		g.P("callCtx := ctx")
+		return "", ""
+	}
+}
+
+// runtimeMethodProcedure returns the "/package.Service/Method" procedure name
+// Connect and gRPC use for method.
+func runtimeMethodProcedure(method runtimeMethodProjection) string {
+	fullName := method.Identity.SourceFullName
+	dot := strings.LastIndex(fullName, ".")
+	if dot < 0 {
+		return "/" + fullName
+	}
+	return "/" + fullName[:dot] + "/" + fullName[dot+1:]
+}
@@ -53,6 +53,7 @@ type runtimeStreamProjection struct {
 	CanCloseSend          bool
 	FinishReturnsResponse bool
 	StartAcceptsRequest   bool
+	Buffering             MethodStreamBuffering
 }
 
 type runtimeMethodSymbolsProjection struct {
@@ -119,7 +120,8 @@ func runtimePlaceholderMethodProjection(serviceName, methodName string, shape ru
 			MessageMethodRef: methodName,
 		},
 		Stream: runtimeStreamProjection{
-			Shape: shape,
+			Shape:     shape,
+			Buffering: DefaultMethodStreamBuffering(),
 		},
 		Symbols: runtimeMethodSymbolsProjection{
 			NativeEntryMethod:        methodName,
@@ -282,6 +284,7 @@ func projectRuntimeStream(method MethodPlan) (runtimeStreamProjection, error) {
 		CanRecv:               capability.CanRecv,
 		CanCloseSend:          capability.CanCloseSend,
 		FinishReturnsResponse: capability.FinishReturnsResponse,
+		Buffering:             method.StreamBuffering,
 	}
 
 	switch {
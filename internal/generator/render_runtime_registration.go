@@ -44,18 +44,28 @@ func renderRuntimeRegistrationForSource(g *protogen.GeneratedFile, ctx runtimeRe
 }
 
 func renderRuntimeServerRegistration(g *protogen.GeneratedFile, serviceIDName string, projection registrationSourceProjection) {
-	renderDoc(g, projection.registerName, "registers the supplied "+projection.label+" server as the current server for this service.")
-	g.P("func ", projection.registerName, "(", projection.inputName, " ", projection.inputType, ") error {")
+	scopedName := projection.registerName + "In"
+	// Private register helpers back a public wrapper that owns the default
+	// registry variant, so only their registry-scoped form is rendered.
+	if projection.registerName != lowerInitial(projection.registerName) {
+		renderDoc(g, projection.registerName, "registers the supplied "+projection.label+" server as the current server for this service.")
+		g.P("func ", projection.registerName, "(", projection.inputName, " ", projection.inputType, ") error {")
+		g.P("return ", scopedName, "(rpcruntime.DefaultServerRegistry(), ", projection.inputName, ")")
+		g.P("}")
+		g.P()
+	}
+	renderDoc(g, scopedName, "registers the supplied "+projection.label+" server as the current server for this service in registry.")
+	g.P("func ", scopedName, "(registry *rpcruntime.ServerRegistry, ", projection.inputName, " ", projection.inputType, ") error {")
 	g.P("if ", projection.inputName, " == nil {")
-	g.P("_ = rpcruntime.ClearServer(", serviceIDName, ")")
+	g.P("_ = registry.Clear(", serviceIDName, ")")
 	g.P("return ", projection.nilErr)
 	g.P("}")
-	g.P("err := rpcruntime.RegisterServer(", serviceIDName, ", rpcruntime.RegisteredServer{")
+	g.P("err := registry.Register(", serviceIDName, ", rpcruntime.RegisteredServer{")
 	g.P("Kind: ", projection.serverKind, ",")
 	g.P("Server: ", projection.sourceExpr, ",")
 	g.P("})")
 	g.P("if err != nil {")
-	g.P("_ = rpcruntime.ClearServer(", serviceIDName, ")")
+	g.P("_ = registry.Clear(", serviceIDName, ")")
 	g.P("return err")
 	g.P("}")
 	g.P("return nil")
@@ -42,8 +42,7 @@ func renderRuntimeNativeStreamSend(g *protogen.GeneratedFile, serviceName string
 	name := runtimeStreamOperationName(serviceName, "Native", method, "Send")
 	renderDoc(g, name, "sends native request values on an active "+method.Identity.GoName+" stream.")
 	g.P("func ", name, "(ctx context.Context, handle rpcruntime.StreamHandle", method.Native.Args, ") error {")
-	g.P("entry, err := rpcruntime.LoadStreamSession(handle)")
-	g.P("if err != nil { return err }")
+	renderRuntimeStreamOperationDispatch(g, serviceName, method, "Native", "Send", method.Native.Args, nativeGoCallSuffix(method.Native.ArgNames), "error", runtimeInterceptErrorResult(), "err")
 	g.P("switch entry.Kind {")
 	for _, route := range method.Routes.NativeServers {
 		renderRuntimeNativeStreamNativeSessionCase(g, method, route, "source", "rpcruntime.ErrStreamInvalidHandle", func() {
@@ -66,8 +65,7 @@ func renderRuntimeNativeStreamRecv(g *protogen.GeneratedFile, serviceName string
 	name := runtimeStreamOperationName(serviceName, "Native", method, "Recv")
 	renderDoc(g, name, "receives native response values from an active "+method.Identity.GoName+" stream.")
 	g.P("func ", name, "(ctx context.Context, handle rpcruntime.StreamHandle) (", method.Native.Returns, ") {")
-	g.P("entry, err := rpcruntime.LoadStreamSession(handle)")
-	g.P("if err != nil { return ", method.Native.InvalidZero, " }")
+	renderRuntimeStreamOperationDispatch(g, serviceName, method, "Native", "Recv", "", "", "("+method.Native.Returns+")", runtimeInterceptNativeResult(method), method.Native.InvalidZero)
 	g.P("switch entry.Kind {")
 	for _, route := range method.Routes.NativeServers {
 		renderRuntimeNativeStreamNativeSessionCase(g, method, route, "source", method.Native.InvalidZero, func() {
@@ -102,7 +100,7 @@ func renderRuntimeNativeStreamRecv(g *protogen.GeneratedFile, serviceName string
 		g.P("return ", method.Codec.MessageToNativeResponse, "(messageResp)")
 	})
 	g.P("default:")
-	g.P("return ", method.Native.ErrZero)
+	g.P("return ", nativeGoZeroReturnsForError(method, runtimeUnsupportedStreamSessionError(serviceName, "native")))
 	g.P("}")
 	g.P("}")
 	g.P()
@@ -112,8 +110,7 @@ func renderRuntimeNativeStreamCloseSend(g *protogen.GeneratedFile, serviceName s
 	name := runtimeStreamOperationName(serviceName, "Native", method, "CloseSend")
 	renderDoc(g, name, "closes the native send side of an active "+method.Identity.GoName+" stream.")
 	g.P("func ", name, "(ctx context.Context, handle rpcruntime.StreamHandle) error {")
-	g.P("entry, err := rpcruntime.LoadStreamSession(handle)")
-	g.P("if err != nil { return err }")
+	renderRuntimeStreamOperationDispatch(g, serviceName, method, "Native", "CloseSend", "", "", "error", runtimeInterceptErrorResult(), "err")
 	g.P("switch entry.Kind {")
 	for _, route := range method.Routes.NativeServers {
 		renderRuntimeNativeStreamNativeSessionCase(g, method, route, "source", "rpcruntime.ErrStreamInvalidHandle", func() {
@@ -135,14 +132,10 @@ func renderRuntimeNativeStreamFinish(g *protogen.GeneratedFile, serviceName stri
 	renderDoc(g, name, "finishes an active native "+method.Identity.GoName+" stream and releases its handle.")
 	if method.Stream.FinishReturnsResponse {
 		g.P("func ", name, "(ctx context.Context, handle rpcruntime.StreamHandle) (", method.Native.Returns, ") {")
+		renderRuntimeStreamOperationDispatch(g, serviceName, method, "Native", "Finish", "", "", "("+method.Native.Returns+")", runtimeInterceptNativeResult(method), method.Native.InvalidZero)
 	} else {
 		g.P("func ", name, "(ctx context.Context, handle rpcruntime.StreamHandle) error {")
-	}
-	g.P("entry, err := rpcruntime.LoadStreamSession(handle)")
-	if method.Stream.FinishReturnsResponse {
-		g.P("if err != nil { return ", method.Native.InvalidZero, " }")
-	} else {
-		g.P("if err != nil { return err }")
+		renderRuntimeStreamOperationDispatch(g, serviceName, method, "Native", "Finish", "", "", "error", runtimeInterceptErrorResult(), "err")
 	}
 	g.P("switch entry.Kind {")
 	invalidReturn := "rpcruntime.ErrStreamInvalidHandle"
@@ -162,7 +155,7 @@ func renderRuntimeNativeStreamFinish(g *protogen.GeneratedFile, serviceName stri
 				g.P("return ", runtimeNativeResponseReturn("resp", method))
 			} else {
 				g.P("if err := source.Finish(ctx); err != nil { return err }")
-				g.P("_, err = rpcruntime.RemoveStreamSession(handle)")
+				g.P("_, err := rpcruntime.RemoveStreamSession(handle)")
 				g.P("return err")
 			}
 		})
@@ -175,13 +168,13 @@ func renderRuntimeNativeStreamFinish(g *protogen.GeneratedFile, serviceName stri
 			g.P("return ", method.Codec.MessageToNativeResponse, "(messageResp)")
 		} else {
 			g.P("if err := source.Finish(ctx); err != nil { return err }")
-			g.P("_, err = rpcruntime.RemoveStreamSession(handle)")
+			g.P("_, err := rpcruntime.RemoveStreamSession(handle)")
 			g.P("return err")
 		}
 	})
 	g.P("default:")
 	if method.Stream.FinishReturnsResponse {
-		g.P("return ", method.Native.ErrZero)
+		g.P("return ", nativeGoZeroReturnsForError(method, runtimeUnsupportedStreamSessionError(serviceName, "native")))
 	} else {
 		g.P(`return fmt.Errorf("rpccgo: `, serviceName, ` native stream session kind %d is unsupported", entry.Kind)`)
 	}
@@ -194,19 +187,18 @@ func renderRuntimeNativeStreamCancel(g *protogen.GeneratedFile, serviceName stri
 	name := runtimeStreamOperationName(serviceName, "Native", method, "Cancel")
 	renderDoc(g, name, "cancels an active native "+method.Identity.GoName+" stream and releases its handle.")
 	g.P("func ", name, "(ctx context.Context, handle rpcruntime.StreamHandle) error {")
-	g.P("entry, err := rpcruntime.LoadStreamSession(handle)")
-	g.P("if err != nil { return err }")
+	renderRuntimeStreamOperationDispatch(g, serviceName, method, "Native", "Cancel", "", "", "error", runtimeInterceptErrorResult(), "err")
 	g.P("switch entry.Kind {")
 	for _, route := range method.Routes.NativeServers {
 		renderRuntimeNativeStreamNativeSessionCase(g, method, route, "source", "rpcruntime.ErrStreamInvalidHandle", func() {
 			g.P("if err := source.Cancel(ctx); err != nil { return err }")
-			g.P("_, err = rpcruntime.RemoveStreamSession(handle)")
+			g.P("_, err := rpcruntime.RemoveStreamSession(handle)")
 			g.P("return err")
 		})
 	}
 	renderRuntimeNativeStreamMessageSessionCases(g, method, "source", "rpcruntime.ErrStreamInvalidHandle", func() {
 		g.P("if err := source.Cancel(ctx); err != nil { return err }")
-		g.P("_, err = rpcruntime.RemoveStreamSession(handle)")
+		g.P("_, err := rpcruntime.RemoveStreamSession(handle)")
 		g.P("return err")
 	})
 	g.P("default:")
@@ -223,8 +215,7 @@ func renderRuntimeMessageStreamSend(g *protogen.GeneratedFile, serviceName strin
 	g.P("if req == nil {")
 	g.P(`return errors.New("rpccgo: message request is nil")`)
 	g.P("}")
-	g.P("entry, err := rpcruntime.LoadStreamSession(handle)")
-	g.P("if err != nil { return err }")
+	renderRuntimeStreamOperationDispatch(g, serviceName, method, "Message", "Send", ", req "+runtimeMessageRequestType(method), ", req", "error", runtimeInterceptErrorResult(), "err")
 	g.P("switch entry.Kind {")
 	if nativeEnabled {
 		renderRuntimeMessageStreamNativeSessionCases(g, method, "source", "rpcruntime.ErrStreamInvalidHandle", func() {
@@ -249,8 +240,7 @@ func renderRuntimeMessageStreamRecv(g *protogen.GeneratedFile, serviceName strin
 	name := runtimeStreamOperationName(serviceName, "Message", method, "Recv")
 	renderDoc(g, name, "receives a message response from an active "+method.Identity.GoName+" stream.")
 	g.P("func ", name, "(ctx context.Context, handle rpcruntime.StreamHandle) (", runtimeMessageResponseType(method), ", error) {")
-	g.P("entry, err := rpcruntime.LoadStreamSession(handle)")
-	g.P("if err != nil { return nil, err }")
+	renderRuntimeStreamOperationDispatch(g, serviceName, method, "Message", "Recv", "", "", "("+runtimeMessageResponseType(method)+", error)", runtimeInterceptValueResult("resp", runtimeMessageResponseType(method), "nil, err"), "nil, err")
 	g.P("switch entry.Kind {")
 	if nativeEnabled {
 		renderRuntimeMessageStreamNativeSessionCases(g, method, "source", "nil, rpcruntime.ErrStreamInvalidHandle", func() {
@@ -298,8 +288,7 @@ func renderRuntimeMessageStreamCloseSend(g *protogen.GeneratedFile, serviceName
 	name := runtimeStreamOperationName(serviceName, "Message", method, "CloseSend")
 	renderDoc(g, name, "closes the message send side of an active "+method.Identity.GoName+" stream.")
 	g.P("func ", name, "(ctx context.Context, handle rpcruntime.StreamHandle) error {")
-	g.P("entry, err := rpcruntime.LoadStreamSession(handle)")
-	g.P("if err != nil { return err }")
+	renderRuntimeStreamOperationDispatch(g, serviceName, method, "Message", "CloseSend", "", "", "error", runtimeInterceptErrorResult(), "err")
 	g.P("switch entry.Kind {")
 	if nativeEnabled {
 		renderRuntimeMessageStreamNativeSessionCases(g, method, "source", "rpcruntime.ErrStreamInvalidHandle", func() {
@@ -321,14 +310,10 @@ func renderRuntimeMessageStreamFinish(g *protogen.GeneratedFile, serviceName str
 	renderDoc(g, name, "finishes an active message "+method.Identity.GoName+" stream and releases its handle.")
 	if method.Stream.FinishReturnsResponse {
 		g.P("func ", name, "(ctx context.Context, handle rpcruntime.StreamHandle) (", runtimeMessageResponseType(method), ", error) {")
+		renderRuntimeStreamOperationDispatch(g, serviceName, method, "Message", "Finish", "", "", "("+runtimeMessageResponseType(method)+", error)", runtimeInterceptValueResult("resp", runtimeMessageResponseType(method), "nil, err"), "nil, err")
 	} else {
 		g.P("func ", name, "(ctx context.Context, handle rpcruntime.StreamHandle) error {")
-	}
-	g.P("entry, err := rpcruntime.LoadStreamSession(handle)")
-	if method.Stream.FinishReturnsResponse {
-		g.P("if err != nil { return nil, err }")
-	} else {
-		g.P("if err != nil { return err }")
+		renderRuntimeStreamOperationDispatch(g, serviceName, method, "Message", "Finish", "", "", "error", runtimeInterceptErrorResult(), "err")
 	}
 	g.P("switch entry.Kind {")
 	invalidReturn := "rpcruntime.ErrStreamInvalidHandle"
@@ -348,7 +333,7 @@ func renderRuntimeMessageStreamFinish(g *protogen.GeneratedFile, serviceName str
 				g.P("return ", method.Codec.NativeResponseToMessage, "(", runtimeNativeResponseFieldArgs("resp", method), ")")
 			} else {
 				g.P("if err := source.Finish(ctx); err != nil { return err }")
-				g.P("_, err = rpcruntime.RemoveStreamSession(handle)")
+				g.P("_, err := rpcruntime.RemoveStreamSession(handle)")
 				g.P("return err")
 			}
 		})
@@ -364,7 +349,7 @@ func renderRuntimeMessageStreamFinish(g *protogen.GeneratedFile, serviceName str
 			g.P("return resp, nil")
 		} else {
 			g.P("if err := source.Finish(ctx); err != nil { return err }")
-			g.P("_, err = rpcruntime.RemoveStreamSession(handle)")
+			g.P("_, err := rpcruntime.RemoveStreamSession(handle)")
 			g.P("return err")
 		}
 	})
@@ -383,19 +368,18 @@ func renderRuntimeMessageStreamCancel(g *protogen.GeneratedFile, serviceName str
 	name := runtimeStreamOperationName(serviceName, "Message", method, "Cancel")
 	renderDoc(g, name, "cancels an active message "+method.Identity.GoName+" stream and releases its handle.")
 	g.P("func ", name, "(ctx context.Context, handle rpcruntime.StreamHandle) error {")
-	g.P("entry, err := rpcruntime.LoadStreamSession(handle)")
-	g.P("if err != nil { return err }")
+	renderRuntimeStreamOperationDispatch(g, serviceName, method, "Message", "Cancel", "", "", "error", runtimeInterceptErrorResult(), "err")
 	g.P("switch entry.Kind {")
 	if nativeEnabled {
 		renderRuntimeMessageStreamNativeSessionCases(g, method, "source", "rpcruntime.ErrStreamInvalidHandle", func() {
 			g.P("if err := source.Cancel(ctx); err != nil { return err }")
-			g.P("_, err = rpcruntime.RemoveStreamSession(handle)")
+			g.P("_, err := rpcruntime.RemoveStreamSession(handle)")
 			g.P("return err")
 		})
 	}
 	renderRuntimeMessageStreamMessageSessionCases(g, method, "source", "rpcruntime.ErrStreamInvalidHandle", func() {
 		g.P("if err := source.Cancel(ctx); err != nil { return err }")
-		g.P("_, err = rpcruntime.RemoveStreamSession(handle)")
+		g.P("_, err := rpcruntime.RemoveStreamSession(handle)")
 		g.P("return err")
 	})
 	g.P("default:")
@@ -405,6 +389,25 @@ func renderRuntimeMessageStreamCancel(g *protogen.GeneratedFile, serviceName str
 	g.P()
 }
 
+// renderRuntimeStreamOperationDispatch renders the stream session lookup and
+// interceptor call of a public stream operation, then opens the private
+// dispatch function that switches on the pinned session kind.
+func renderRuntimeStreamOperationDispatch(g *protogen.GeneratedFile, serviceName string, method runtimeMethodProjection, contract, operation, params, argNames, returns string, result runtimeInterceptResult, loadErrReturn string) {
+	name := runtimeStreamOperationName(serviceName, contract, method, operation)
+	privateName := runtimePrivateFacadeName(name)
+	g.P("entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)")
+	g.P("if err != nil { return ", loadErrReturn, " }")
+	callInfo := runtimeCallInfoLiteral(runtimeServiceIDName(serviceName), method, contract, operation, "entry.Kind", "handle")
+	renderRuntimeInterceptedCall(g, "InterceptStream", callInfo, privateName+"(ctx, handle, entry"+argNames+")", result)
+	g.P("}")
+	g.P()
+	g.P("func ", privateName, "(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession", params, ") ", returns, " {")
+}
+
+func runtimeUnsupportedStreamSessionError(serviceName, contract string) string {
+	return `fmt.Errorf("rpccgo: ` + serviceName + ` ` + contract + ` stream session kind %d is unsupported", entry.Kind)`
+}
+
 func renderRuntimeNativeStreamNativeSessionCase(g *protogen.GeneratedFile, method runtimeMethodProjection, route runtimeServerRouteProjection, sourceName, invalidReturn string, body func()) {
 	g.P("case ", route.Kind, ":")
 	g.P(sourceName, ", ok := entry.Session.(", runtimeNativeStreamingClientInterface(method), ")")
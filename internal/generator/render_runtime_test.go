@@ -51,12 +51,26 @@ func TestRenderRuntimeGlueDefinesServerRegistryAndTransportRegistration(t *testi
 	)
 	for _, fragment := range []string{
 		`const allServiceServiceID rpcruntime.ServiceID = "test.v1.AllService"`,
+		"rpcruntime.RegisterServiceManifest(rpcruntime.ServiceManifest{",
+		"ServiceID:        allServiceServiceID,",
+		`ProtoFile:        "test/v1/complete_service_plan.proto",`,
+		"MessageTransport: rpcruntime.ServerKindConnect,",
+		`{Name: "BidiStream", FullName: "test.v1.AllService.BidiStream", Streaming: rpcruntime.MethodStreamingBidi, RequestType: "test.v1.AllRequest", ResponseType: "test.v1.AllReply"},`,
+		`rpcruntime.RegisterDynamicMethod("test.v1.AllService.Unary", rpcruntime.DynamicUnary(InvokeAllServiceMessageUnary))`,
+		`defer rpcruntime.RecoverPanic("test.v1.AllService.ClientStream handler", func(err error) { stream.Complete(nil, err) })`,
+		`rpcruntime.RegisterDynamicMethod("test.v1.AllService.BidiStream", rpcruntime.DynamicBidiStream(AllServiceMessageBidiStreamStart, AllServiceMessageBidiStreamSend, AllServiceMessageBidiStreamRecv, AllServiceMessageBidiStreamCloseSend, AllServiceMessageBidiStreamFinish, AllServiceMessageBidiStreamCancel))`,
 		"func ClearAllServiceServer() error {",
-		"return rpcruntime.ClearServer(allServiceServiceID)",
+		"return ClearAllServiceServerIn(rpcruntime.DefaultServerRegistry())",
+		"func ClearAllServiceServerIn(registry *rpcruntime.ServerRegistry) error {",
+		"return registry.Clear(allServiceServiceID)",
 		"func LoadAllServiceRegisteredServer() (rpcruntime.RegisteredServer, error) {",
-		"return rpcruntime.LoadServer(allServiceServiceID)",
+		"return registry.Load(allServiceServiceID)",
+		"func RegisterAllServiceServerRoute(route rpcruntime.ServerRoute) error {",
+		"func RegisterAllServiceServerRouteIn(registry *rpcruntime.ServerRegistry, route rpcruntime.ServerRoute) error {",
+		"return registry.RegisterRoute(allServiceServiceID, route)",
+		"func LoadAllServiceServerRoute() (rpcruntime.ServerRoute, error) {",
 		"func InvokeAllServiceNativeUnary(ctx context.Context, name *rpcruntime.RpcString, enabled bool, child *rpcruntime.RpcBytes) (bool, []byte, error) {",
-		"registered, err := rpcruntime.LoadServer(allServiceServiceID)",
+		"err := rpcruntime.ServerRegistryFromContext(ctx).RouteUnary(ctx, rpcruntime.CallInfo{",
 		"case rpcruntime.ServerKindGoNative:",
 		"server, ok := registered.Server.(AllServiceNativeServer)",
 		"return server.Unary(ctx, name, enabled, child)",
@@ -67,9 +81,9 @@ func TestRenderRuntimeGlueDefinesServerRegistryAndTransportRegistration(t *testi
 		"type allServiceBinding struct {",
 		"invokeNativeUnary",
 		"invokeMessageUnary",
-		"func registerAllServiceGoNativeServer(server AllServiceNativeServer) error {",
+		"func registerAllServiceGoNativeServerIn(registry *rpcruntime.ServerRegistry, server AllServiceNativeServer) error {",
 		"func RegisterAllServiceCGONativeServer(server AllServiceNativeServer) error {",
-		"func registerAllServiceCGOMessageServer(server AllServiceCGOMessageServer) error {",
+		"func registerAllServiceCGOMessageServerIn(registry *rpcruntime.ServerRegistry, server AllServiceCGOMessageServer) error {",
 		"type AllServiceClientStreamNativeStreamSession interface {",
 		"type AllServiceClientStream"+"NativeStream struct {",
 		"var allServiceStream"+"Registry rpcruntime.StreamRegistry",
@@ -91,7 +105,7 @@ func TestRenderRuntimeGlueDefinesMessageContractRegistryRegistration(t *testing.
 	for _, fragment := range []string{
 		"func InvokeAllServiceMessageUnary(ctx context.Context, req *AllRequest) (*AllReply, error) {",
 		`return nil, errors.New("rpccgo: message request is nil")`,
-		"registered, err := rpcruntime.LoadServer(allServiceServiceID)",
+		"err := rpcruntime.ServerRegistryFromContext(ctx).RouteUnary(ctx, rpcruntime.CallInfo{",
 		"case rpcruntime.ServerKindCGOMessage:",
 		"resp, err := server.Unary(ctx, req)",
 		`return nil, errors.New("rpccgo: message response is nil")`,
@@ -124,10 +138,13 @@ func TestRenderRuntimeGlueDefinesConnectDirectRegistration(t *testing.T) {
 	const runtimeFile = "test/v1/complete_service_plan.default_service.runtime.rpccgo.go"
 	for _, fragment := range []string{
 		"func RegisterDefaultServiceConnectHandler(handler DefaultServiceHandler) error {",
-		"rpcruntime.RegisterServer(defaultServiceServiceID, rpcruntime.RegisteredServer{",
+		"func RegisterDefaultServiceConnectHandlerIn(registry *rpcruntime.ServerRegistry, handler DefaultServiceHandler) error {",
+		"registry.Register(defaultServiceServiceID, rpcruntime.RegisteredServer{",
 		"Kind:   rpcruntime.ServerKindConnect,",
 		"Server: handler,",
-		"messageResp, err := server.DefaultUnary(ctx, req)",
+		`callCtx, finishMetadata := rpcruntime.ConnectHandlerContext(ctx, "/test.v1.DefaultService/DefaultUnary")`,
+		"messageResp, err := server.DefaultUnary(callCtx, req)",
+		"finishMetadata()",
 		`return nil, errors.New("rpccgo: message response is nil")`,
 	} {
 		assertGeneratedContentContains(t, plugin, runtimeFile, fragment)
@@ -149,8 +166,8 @@ func TestRenderRuntimeGlueRoutesNativeUnaryToConnectHandler(t *testing.T) {
 		"Kind:   rpcruntime.ServerKindConnect,",
 		"Server: handler,",
 		"messageReq, err := convertConnectNativeServiceConnectNativeUnaryNativeToMessageRequest(name, enabled, child)",
-		"messageResp, err = server.ConnectNativeUnary(ctx, messageReq)",
-		"messageResp, err := server.ConnectNativeUnary(ctx, req)",
+		"messageResp, err = server.ConnectNativeUnary(callCtx, messageReq)",
+		"messageResp, err := server.ConnectNativeUnary(callCtx, req)",
 	} {
 		assertGeneratedContentContains(t, plugin, runtimeFile, fragment)
 	}
@@ -170,7 +187,9 @@ func TestRenderRuntimeGlueDefinesGRPCDirectRegistration(t *testing.T) {
 		"func RegisterGrpcServiceGRPCServer(server GrpcServiceServer) error {",
 		"Kind:   rpcruntime.ServerKindGRPC,",
 		"Server: server,",
-		"messageResp, err := server.GrpcUnary(ctx, req)",
+		`callCtx := rpcruntime.GRPCHandlerContext(ctx, "/test.v1.GrpcService/GrpcUnary")`,
+		"messageResp, err := server.GrpcUnary(callCtx, req)",
+		"callCtx, callOpts, finishMetadata := rpcruntime.GRPCClientContext(ctx)",
 		`return nil, errors.New("rpccgo: message response is nil")`,
 	} {
 		assertGeneratedContentContains(t, plugin, runtimeFile, fragment)
@@ -207,6 +226,43 @@ func TestRenderRuntimeGlueUsesGenericEndpointsForGRPCDirectStreaming(t *testing.
 	)
 }
 
+func TestRenderRuntimeGluePropagatesStreamMetadata(t *testing.T) {
+	file := completeServicePlanTestFile()
+	plugin := newTestPlugin(t, "paths=source_relative", file)
+
+	_, err := GenerateWithOptions(plugin)
+	if err != nil {
+		t.Fatalf("GenerateWithOptions() error = %v", err)
+	}
+
+	const runtimeFile = "test/v1/complete_service_plan.all_service.runtime.rpccgo.go"
+	for _, fragment := range []string{
+		"handlerCtx, finishMetadata := rpcruntime.ConnectStreamingHandlerContext(streamCtx, conn)",
+		"resp, err := handler.ClientStream(handlerCtx, rpcruntime.NewConnectClientStream[AllRequest](conn))",
+		"err := handler.BidiStream(handlerCtx, rpcruntime.NewConnectBidiStream[AllRequest, AllReply](conn))",
+		"callCtx, finishMetadata := rpcruntime.ConnectClientContext(streamCtx)",
+		"stream, err := client.ClientStream(callCtx)",
+		"finishMetadata func()",
+		"resp, err := s.stream.CloseAndReceive()\n\ts.finishMetadata()",
+	} {
+		assertGeneratedContentContains(t, plugin, runtimeFile, fragment)
+	}
+
+	grpcFile := grpcStreamingRuntimeTestFile()
+	grpcPlugin := newTestPluginGenerating(t, "paths=source_relative", "test/v1/grpc_streaming_runtime.proto", grpcFile)
+	if _, err := GenerateWithOptions(grpcPlugin); err != nil {
+		t.Fatalf("GenerateWithOptions() error = %v", err)
+	}
+	const grpcRuntimeFile = "test/v1/grpc_streaming_runtime.grpc_streaming_service.runtime.rpccgo.go"
+	for _, fragment := range []string{
+		"callCtx, callOpts, finishMetadata := rpcruntime.GRPCClientContext(streamCtx)",
+		"stream, err := client.ClientStream(callCtx, callOpts...)",
+		"response, err := s.stream.CloseAndRecv()\n\ts.finishMetadata()",
+	} {
+		assertGeneratedContentContains(t, grpcPlugin, grpcRuntimeFile, fragment)
+	}
+}
+
 func TestRenderRuntimeGlueDefinesPackageLevelStreamOperations(t *testing.T) {
 	file := completeServicePlanTestFile()
 	plugin := newTestPlugin(t, "paths=source_relative", file)
@@ -220,17 +276,17 @@ func TestRenderRuntimeGlueDefinesPackageLevelStreamOperations(t *testing.T) {
 	const messageServerFile = "test/v1/complete_service_plan.all_service.server.message.rpccgo.go"
 	for _, fragment := range []string{
 		"func AllServiceNativeClientStreamSend(ctx context.Context, handle rpcruntime.StreamHandle, name *rpcruntime.RpcString, enabled bool, child *rpcruntime.RpcBytes) error {",
-		"entry, err := rpcruntime.LoadStreamSession(handle)",
+		"entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)",
 		"source, ok := entry.Session.(rpcruntime.ClientStreamingClient[AllServiceClientStreamNativeStreamRequest, AllServiceClientStreamNativeStreamResponse])",
 		"return source.Send(ctx, AllServiceClientStreamNativeStreamRequest{Name: name, Enabled: enabled, Child: child})",
 		"func AllServiceNativeClientStreamFinish(ctx context.Context, handle rpcruntime.StreamHandle) (bool, []byte, error) {",
-		"entry, err := rpcruntime.LoadStreamSession(handle)",
+		"entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)",
 		"_, err = rpcruntime.RemoveStreamSession(handle)",
 		"return resp.Accepted, resp.Payload, nil",
 		"func AllServiceNativeServerStreamRecv(ctx context.Context, handle rpcruntime.StreamHandle) (bool, []byte, error) {",
-		"entry, err := rpcruntime.LoadStreamSession(handle)",
+		"entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)",
 		"func AllServiceNativeBidiStreamCloseSend(ctx context.Context, handle rpcruntime.StreamHandle) error {",
-		"entry, err := rpcruntime.LoadStreamSession(handle)",
+		"entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)",
 		"_, err = rpcruntime.RemoveStreamSession(handle)",
 		"return err",
 	} {
@@ -239,16 +295,16 @@ func TestRenderRuntimeGlueDefinesPackageLevelStreamOperations(t *testing.T) {
 	for _, fragment := range []string{
 		"func AllServiceMessageClientStreamSend(ctx context.Context, handle rpcruntime.StreamHandle, req *AllRequest) error {",
 		`return errors.New("rpccgo: message request is nil")`,
-		"entry, err := rpcruntime.LoadStreamSession(handle)",
+		"entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)",
 		"source, ok := entry.Session.(rpcruntime.ClientStreamingClient[*AllRequest, *AllReply])",
 		"func AllServiceMessageClientStreamFinish(ctx context.Context, handle rpcruntime.StreamHandle) (*AllReply, error) {",
-		"entry, err := rpcruntime.LoadStreamSession(handle)",
+		"entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)",
 		"_, err = rpcruntime.RemoveStreamSession(handle)",
 		"func AllServiceMessageServerStreamRecv(ctx context.Context, handle rpcruntime.StreamHandle) (*AllReply, error) {",
-		"entry, err := rpcruntime.LoadStreamSession(handle)",
+		"entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)",
 		`return nil, errors.New("rpccgo: message response is nil")`,
 		"func AllServiceMessageBidiStreamCloseSend(ctx context.Context, handle rpcruntime.StreamHandle) error {",
-		"entry, err := rpcruntime.LoadStreamSession(handle)",
+		"entry, err := rpcruntime.LoadStreamSessionContext(ctx, handle)",
 		"_, err = rpcruntime.RemoveStreamSession(handle)",
 	} {
 		assertGeneratedContentContains(t, plugin, messageServerFile, fragment)
@@ -284,6 +340,55 @@ func TestRenderRuntimeGlueDefinesPackageLevelStreamOperations(t *testing.T) {
 	)
 }
 
+func TestRenderRuntimeGlueRoutesFacadesThroughInterceptors(t *testing.T) {
+	file := completeServicePlanTestFile()
+	plugin := newTestPlugin(t, "paths=source_relative", file)
+
+	_, err := GenerateWithOptions(plugin)
+	if err != nil {
+		t.Fatalf("GenerateWithOptions() error = %v", err)
+	}
+
+	const runtimeFile = "test/v1/complete_service_plan.all_service.runtime.rpccgo.go"
+	const nativeServerFile = "test/v1/complete_service_plan.all_service.server.native.rpccgo.go"
+	const messageServerFile = "test/v1/complete_service_plan.all_service.server.message.rpccgo.go"
+	for _, fragment := range []string{
+		"rpcruntime.ServerRegistryFromContext(ctx).RouteUnary(ctx, rpcruntime.CallInfo{",
+		"rpcruntime.ServerRegistryFromContext(ctx).RouteStream(ctx, rpcruntime.CallInfo{",
+		"ServiceID: allServiceServiceID,",
+		"Contract:  rpcruntime.CallContractNative,",
+		"Contract:  rpcruntime.CallContractMessage,",
+		"Operation: rpcruntime.CallOperationUnary,",
+		"Operation: rpcruntime.CallOperationStart,",
+		"func invokeAllServiceNativeUnary(ctx context.Context, registered rpcruntime.RegisteredServer,",
+		"func invokeAllServiceMessageUnary(ctx context.Context, registered rpcruntime.RegisteredServer, req *AllRequest) (*AllReply, error) {",
+	} {
+		assertGeneratedContentContains(t, plugin, runtimeFile, fragment)
+	}
+	assertGeneratedFileContentDoesNotContain(t, plugin, runtimeFile, "RouteCall(", "Kind:      registered.Kind,")
+	for _, fragment := range []string{
+		"Operation: rpcruntime.CallOperationSend,",
+		"Operation: rpcruntime.CallOperationRecv,",
+		"Operation: rpcruntime.CallOperationCloseSend,",
+		"Operation: rpcruntime.CallOperationFinish,",
+		"Operation: rpcruntime.CallOperationCancel,",
+		"Kind:      entry.Kind,",
+		"Stream:    handle,",
+		"return allServiceNativeClientStreamSend(ctx, handle, entry, name, enabled, child)",
+		"func allServiceNativeClientStreamSend(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession, name *rpcruntime.RpcString, enabled bool, child *rpcruntime.RpcBytes) error {",
+		`fmt.Errorf("rpccgo: AllService native stream session kind %d is unsupported", entry.Kind)`,
+	} {
+		assertGeneratedContentContains(t, plugin, nativeServerFile, fragment)
+	}
+	for _, fragment := range []string{
+		"return allServiceMessageClientStreamSend(ctx, handle, entry, req)",
+		"resp, callErr = allServiceMessageClientStreamFinish(ctx, handle, entry)",
+		"func allServiceMessageServerStreamRecv(ctx context.Context, handle rpcruntime.StreamHandle, entry *rpcruntime.StreamSession) (*AllReply, error) {",
+	} {
+		assertGeneratedContentContains(t, plugin, messageServerFile, fragment)
+	}
+}
+
 func TestRenderRuntimeGlueUsesRPCRuntimeStreamHandleAndCoreStreamRegistry(t *testing.T) {
 	file := completeServicePlanTestFile()
 	plugin := newTestPlugin(t, "paths=source_relative", file)
@@ -296,8 +401,8 @@ func TestRenderRuntimeGlueUsesRPCRuntimeStreamHandleAndCoreStreamRegistry(t *tes
 	const runtimeFile = "test/v1/complete_service_plan.all_service.runtime.rpccgo.go"
 	for _, fragment := range []string{
 		"func AllServiceNativeClientStreamStart(ctx context.Context) (rpcruntime.StreamHandle, error) {",
-		"return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGoNative, source)",
-		"return rpcruntime.CreateStreamSession(rpcruntime.ServerKindConnect, source)",
+		"return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGoNative, source)",
+		"return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindConnect, source)",
 	} {
 		assertGeneratedContentContains(t, plugin, runtimeFile, fragment)
 	}
@@ -327,8 +432,8 @@ func TestRenderRuntimeGlueUsesRPCRuntimeStreamHandleForMessageHelpers(t *testing
 	const runtimeFile = "test/v1/complete_service_plan.all_service.runtime.rpccgo.go"
 	for _, fragment := range []string{
 		"func AllServiceMessageClientStreamStart(ctx context.Context) (rpcruntime.StreamHandle, error) {",
-		"return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGoNative, source)",
-		"return rpcruntime.CreateStreamSession(rpcruntime.ServerKindCGOMessage, source)",
+		"return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGoNative, source)",
+		"return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindCGOMessage, source)",
 	} {
 		assertGeneratedContentContains(t, plugin, runtimeFile, fragment)
 	}
@@ -356,7 +461,7 @@ func TestRenderRuntimeGlueRoutesMessageStreamsToNativeSessionsWithConverter(t *t
 	for _, fragment := range []string{
 		"func AllServiceMessageClientStreamStart(ctx context.Context) (rpcruntime.StreamHandle, error) {",
 		"case rpcruntime.ServerKindGoNative:",
-		"return rpcruntime.CreateStreamSession(rpcruntime.ServerKindGoNative, source)",
+		"return rpcruntime.CreateStreamSessionContext(ctx, rpcruntime.ServerKindGoNative, source)",
 	} {
 		assertGeneratedContentContains(t, plugin, runtimeFile, fragment)
 	}
@@ -686,10 +791,12 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 )
 
+type Child struct{}
+
 type GRPCStreamingRequest struct {
 	Name    string
 	Enabled bool
-	Child   []byte
+	Child   *Child
 }
 
 type GRPCStreamingReply struct {
@@ -708,6 +815,7 @@ type GrpcStreamingServiceClient interface {
 	BidiStream(context.Context, ...grpc.CallOption) (grpc.BidiStreamingClient[GRPCStreamingRequest, GRPCStreamingReply], error)
 }
 
+func (*Child) ProtoReflect() protoreflect.Message { return nil }
 func (*GRPCStreamingRequest) ProtoReflect() protoreflect.Message { return nil }
 func (*GRPCStreamingReply) ProtoReflect() protoreflect.Message { return nil }
 
@@ -799,3 +907,22 @@ func importedNativeServiceFile() *descriptorpb.FileDescriptorProto {
 		}},
 	}
 }
+
+func TestRenderRuntimeGlueRemoteStreamSessionsHonorOperationContext(t *testing.T) {
+	file := completeServicePlanTestFile()
+	plugin := newTestPlugin(t, "paths=source_relative", file)
+
+	_, err := GenerateWithOptions(plugin)
+	if err != nil {
+		t.Fatalf("GenerateWithOptions() error = %v", err)
+	}
+
+	const runtimeFile = "test/v1/complete_service_plan.all_service.runtime.rpccgo.go"
+	for _, fragment := range []string{
+		"stop := context.AfterFunc(ctx, s.cancel)",
+		"return rpcruntime.ContextError(ctx, s.stream.Send(req))",
+		"return nil, rpcruntime.ContextError(ctx, err)",
+	} {
+		assertGeneratedContentContains(t, plugin, runtimeFile, fragment)
+	}
+}
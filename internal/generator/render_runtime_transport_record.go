@@ -2,8 +2,12 @@ package generator
 
 import "google.golang.org/protobuf/compiler/protogen"
 
-func renderRuntimeTransportUnaryMessageCall(g *protogen.GeneratedFile, method runtimeMethodProjection, transportExpr, reqExpr string) {
-	g.P("messageResp, err := ", transportExpr, ".", method.Identity.MessageMethodRef, "(ctx, ", reqExpr, ")")
+func renderRuntimeTransportUnaryMessageCall(g *protogen.GeneratedFile, method runtimeMethodProjection, kind runtimeServerKindExpr, transportExpr, reqExpr string) {
+	callArgs, finish := renderRuntimeTransportMetadataContext(g, method, kind)
+	g.P("messageResp, err := ", transportExpr, ".", method.Identity.MessageMethodRef, "(callCtx, ", reqExpr, callArgs, ")")
+	if finish != "" {
+		g.P(finish)
+	}
 	g.P("if err != nil { return nil, err }")
 	g.P("if messageResp == nil {")
 	g.P(`return nil, errors.New("rpccgo: message response is nil")`)
@@ -11,8 +15,12 @@ func renderRuntimeTransportUnaryMessageCall(g *protogen.GeneratedFile, method ru
 	g.P("return messageResp, nil")
 }
 
-func renderRuntimeTransportUnaryNativeMessageCall(g *protogen.GeneratedFile, method runtimeMethodProjection, transportExpr, reqExpr string) {
-	g.P("messageResp, err = ", transportExpr, ".", method.Identity.MessageMethodRef, "(ctx, ", reqExpr, ")")
+func renderRuntimeTransportUnaryNativeMessageCall(g *protogen.GeneratedFile, method runtimeMethodProjection, kind runtimeServerKindExpr, transportExpr, reqExpr string) {
+	callArgs, finish := renderRuntimeTransportMetadataContext(g, method, kind)
+	g.P("messageResp, err = ", transportExpr, ".", method.Identity.MessageMethodRef, "(callCtx, ", reqExpr, callArgs, ")")
+	if finish != "" {
+		g.P(finish)
+	}
 	g.P("if err != nil { return ", method.Native.ErrZero, " }")
 	g.P("if messageResp == nil {")
 	g.P(`err = errors.New("rpccgo: message response is nil")`)
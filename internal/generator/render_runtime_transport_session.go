@@ -39,11 +39,12 @@ func renderConnectDirectGenericClientStream(g *protogen.GeneratedFile, method ru
 	respPtrType := runtimeMessageResponseType(method)
 	g.P("func new", wrapperName, "(ctx context.Context, handler ", handlerName, ") rpcruntime.ClientStreamingClient[", reqPtrType, ", ", respPtrType, "] {")
 	g.P("client, stream, streamCtx := rpcruntime.NewClientStreaming[", reqPtrType, ", ", respPtrType, "](ctx, rpcruntime.LocalStreamOptions{")
-	g.P("RequestBuffer: 16,")
+	renderLocalStreamBufferingOptions(g, method.Identity.SourceFullName, method.Stream.Buffering, true, false, false)
 	g.P(`StreamClosed: errors.New("rpccgo: message stream is closed"),`)
 	g.P(`NilRequest: errors.New("rpccgo: message request is nil"),`)
 	g.P("})")
 	g.P("go func() {")
+	renderStreamHandlerRecover(g, method.Identity.SourceFullName, "stream.Complete(nil, err)")
 	g.P("conn := &rpcruntime.ConnectStreamingHandlerConn{ReceiveFunc: func(message any) error {")
 	g.P("target, ok := message.(", reqPtrType, ")")
 	g.P(`if !ok || target == nil { return errors.New("rpccgo: connect handler stream request type mismatch") }`)
@@ -52,7 +53,9 @@ func renderConnectDirectGenericClientStream(g *protogen.GeneratedFile, method ru
 	g.P("*target = *req")
 	g.P("return nil")
 	g.P("}}")
-	g.P("resp, err := handler.", method.Identity.MessageMethodRef, "(streamCtx, rpcruntime.NewConnectClientStream[", reqType, "](conn))")
+	g.P("handlerCtx, finishMetadata := rpcruntime.ConnectStreamingHandlerContext(streamCtx, conn)")
+	g.P("resp, err := handler.", method.Identity.MessageMethodRef, "(handlerCtx, rpcruntime.NewConnectClientStream[", reqType, "](conn))")
+	g.P("finishMetadata()")
 	g.P("stream.Complete(resp, err)")
 	g.P("}()")
 	g.P("return client")
@@ -66,17 +69,21 @@ func renderConnectDirectGenericServerStream(g *protogen.GeneratedFile, method ru
 	g.P("func new", wrapperName, "(ctx context.Context, handler ", handlerName, ", req ", reqPtrType, ") (rpcruntime.ServerStreamingClient[", respPtrType, "], error) {")
 	g.P(`if req == nil { return nil, errors.New("rpccgo: message request is nil") }`)
 	g.P("client, stream, streamCtx := rpcruntime.NewServerStreaming[", respPtrType, "](ctx, rpcruntime.LocalStreamOptions{")
-	g.P("ResponseBuffer: 1,")
+	renderLocalStreamBufferingOptions(g, method.Identity.SourceFullName, method.Stream.Buffering, false, true, false)
 	g.P(`StreamClosed: errors.New("rpccgo: message stream is closed"),`)
 	g.P(`NilResponse: errors.New("rpccgo: message response is nil"),`)
 	g.P("})")
 	g.P("go func() {")
+	renderStreamHandlerRecover(g, method.Identity.SourceFullName, "stream.Complete(err)")
 	g.P("conn := &rpcruntime.ConnectStreamingHandlerConn{SendFunc: func(message any) error {")
 	g.P("resp, ok := message.(", respPtrType, ")")
 	g.P(`if !ok || resp == nil { return errors.New("rpccgo: connect handler stream response type mismatch") }`)
 	g.P("return stream.Send(streamCtx, resp)")
 	g.P("}}")
-	g.P("stream.Complete(handler.", method.Identity.MessageMethodRef, "(streamCtx, req, rpcruntime.NewConnectServerStream[", respType, "](conn)))")
+	g.P("handlerCtx, finishMetadata := rpcruntime.ConnectStreamingHandlerContext(streamCtx, conn)")
+	g.P("err := handler.", method.Identity.MessageMethodRef, "(handlerCtx, req, rpcruntime.NewConnectServerStream[", respType, "](conn))")
+	g.P("finishMetadata()")
+	g.P("stream.Complete(err)")
 	g.P("}()")
 	g.P("return client, nil")
 	g.P("}")
@@ -88,13 +95,13 @@ func renderConnectDirectGenericBidiStream(g *protogen.GeneratedFile, method runt
 	respPtrType := runtimeMessageResponseType(method)
 	g.P("func new", wrapperName, "(ctx context.Context, handler ", handlerName, ") rpcruntime.BidiStreamingClient[", reqPtrType, ", ", respPtrType, "] {")
 	g.P("client, stream, streamCtx := rpcruntime.NewBidiStreaming[", reqPtrType, ", ", respPtrType, "](ctx, rpcruntime.LocalStreamOptions{")
-	g.P("RequestBuffer: 16,")
-	g.P("ResponseBuffer: 1,")
+	renderLocalStreamBufferingOptions(g, method.Identity.SourceFullName, method.Stream.Buffering, true, true, false)
 	g.P(`StreamClosed: errors.New("rpccgo: message stream is closed"),`)
 	g.P(`NilRequest: errors.New("rpccgo: message request is nil"),`)
 	g.P(`NilResponse: errors.New("rpccgo: message response is nil"),`)
 	g.P("})")
 	g.P("go func() {")
+	renderStreamHandlerRecover(g, method.Identity.SourceFullName, "stream.Complete(err)")
 	g.P("conn := &rpcruntime.ConnectStreamingHandlerConn{")
 	g.P("ReceiveFunc: func(message any) error {")
 	g.P("target, ok := message.(", reqPtrType, ")")
@@ -110,7 +117,10 @@ func renderConnectDirectGenericBidiStream(g *protogen.GeneratedFile, method runt
 	g.P("return stream.Send(streamCtx, resp)")
 	g.P("},")
 	g.P("}")
-	g.P("stream.Complete(handler.", method.Identity.MessageMethodRef, "(streamCtx, rpcruntime.NewConnectBidiStream[", reqType, ", ", respType, "](conn)))")
+	g.P("handlerCtx, finishMetadata := rpcruntime.ConnectStreamingHandlerContext(streamCtx, conn)")
+	g.P("err := handler.", method.Identity.MessageMethodRef, "(handlerCtx, rpcruntime.NewConnectBidiStream[", reqType, ", ", respType, "](conn))")
+	g.P("finishMetadata()")
+	g.P("stream.Complete(err)")
 	g.P("}()")
 	g.P("return client")
 	g.P("}")
@@ -137,20 +147,21 @@ func renderConnectRemoteMessageSession(g *protogen.GeneratedFile, service Servic
 func renderConnectRemoteClientStreamSession(g *protogen.GeneratedFile, method runtimeMethodProjection, wrapperName, reqType, respType, clientType string) {
 	reqPtrType := runtimeMessageRequestType(method)
 	respPtrType := runtimeMessageResponseType(method)
-	renderRemoteStreamConstructor(g, method, wrapperName, clientType, "*connect.ClientStreamForClientSimple["+reqType+", "+respType+"]", "")
+	renderRemoteStreamConstructor(g, method, MessageTransportConnect, wrapperName, clientType, "*connect.ClientStreamForClientSimple["+reqType+", "+respType+"]", "")
 	renderRemoteSend(g, wrapperName, reqPtrType, "connect remote client stream")
 	g.P("func (s *", wrapperName, ") Finish(ctx context.Context) (", respPtrType, ", error) {")
-	g.P("_ = ctx")
 	g.P("if s == nil {")
 	g.P(`return nil, errors.New("rpccgo: connect remote client stream is nil")`)
 	g.P("}")
 	g.P("if s.stream == nil {")
 	g.P(`return nil, errors.New("rpccgo: connect remote client stream is nil")`)
 	g.P("}")
+	renderRemoteOperationContext(g)
 	g.P("defer func() { if s.cancel != nil { s.cancel() } }()")
 	g.P("resp, err := s.stream.CloseAndReceive()")
+	g.P("s.finishMetadata()")
 	g.P("if err != nil {")
-	g.P("return nil, err")
+	g.P("return nil, rpcruntime.ContextError(ctx, err)")
 	g.P("}")
 	g.P("if resp == nil {")
 	g.P(`return nil, errors.New("rpccgo: message response is nil")`)
@@ -193,14 +204,14 @@ func renderConnectRemoteClientStreamSession(g *protogen.GeneratedFile, method ru
 func renderConnectRemoteServerStreamSession(g *protogen.GeneratedFile, method runtimeMethodProjection, wrapperName, reqType, respType, clientType string) {
 	reqPtrType := runtimeMessageRequestType(method)
 	respPtrType := runtimeMessageResponseType(method)
-	renderRemoteStreamConstructor(g, method, wrapperName, clientType, "*connect.ServerStreamForClient["+respType+"]", reqPtrType)
+	renderRemoteStreamConstructor(g, method, MessageTransportConnect, wrapperName, clientType, "*connect.ServerStreamForClient["+respType+"]", reqPtrType)
 	renderConnectRemoteRecvFinishCancel(g, wrapperName, "server stream", respPtrType)
 }
 
 func renderConnectRemoteBidiStreamSession(g *protogen.GeneratedFile, method runtimeMethodProjection, wrapperName, reqType, respType, clientType string) {
 	reqPtrType := runtimeMessageRequestType(method)
 	respPtrType := runtimeMessageResponseType(method)
-	renderRemoteStreamConstructor(g, method, wrapperName, clientType, "*connect.BidiStreamForClientSimple["+reqType+", "+respType+"]", "")
+	renderRemoteStreamConstructor(g, method, MessageTransportConnect, wrapperName, clientType, "*connect.BidiStreamForClientSimple["+reqType+", "+respType+"]", "")
 	renderRemoteSend(g, wrapperName, reqPtrType, "connect remote bidi stream")
 	renderConnectRemoteBidiRecvFinishCancel(g, wrapperName, respPtrType)
 	g.P("func (s *", wrapperName, ") CloseSend(ctx context.Context) error {")
@@ -213,7 +224,7 @@ func renderConnectRemoteBidiStreamSession(g *protogen.GeneratedFile, method runt
 	g.P()
 }
 
-func renderRemoteStreamConstructor(g *protogen.GeneratedFile, method runtimeMethodProjection, wrapperName, clientType, streamType, reqType string) {
+func renderRemoteStreamConstructor(g *protogen.GeneratedFile, method runtimeMethodProjection, transport MessageTransport, wrapperName, clientType, streamType, reqType string) {
 	reqParam := ""
 	reqArg := ""
 	if reqType != "" {
@@ -227,47 +238,65 @@ func renderRemoteStreamConstructor(g *protogen.GeneratedFile, method runtimeMeth
 		g.P("}")
 	}
 	g.P("streamCtx, cancel := context.WithCancel(ctx)")
-	g.P("stream, err := client.", method.Identity.MessageMethodRef, "(streamCtx", reqArg, ")")
+	if transport == MessageTransportGRPC {
+		g.P("callCtx, callOpts, finishMetadata := rpcruntime.GRPCClientContext(streamCtx)")
+		g.P("stream, err := client.", method.Identity.MessageMethodRef, "(callCtx", reqArg, ", callOpts...)")
+	} else {
+		g.P("callCtx, finishMetadata := rpcruntime.ConnectClientContext(streamCtx)")
+		g.P("stream, err := client.", method.Identity.MessageMethodRef, "(callCtx", reqArg, ")")
+	}
 	g.P("if err != nil {")
 	g.P("cancel()")
 	g.P("return nil, err")
 	g.P("}")
-	g.P("return &", wrapperName, "{stream: stream, cancel: cancel}, nil")
+	g.P("return &", wrapperName, "{stream: stream, cancel: cancel, finishMetadata: finishMetadata}, nil")
 	g.P("}")
 	g.P()
 	g.P("type ", wrapperName, " struct {")
 	g.P("stream ", streamType)
 	g.P("cancel context.CancelFunc")
+	g.P("finishMetadata func()")
 	g.P("}")
 	g.P()
 }
 
+// renderRemoteOperationContext tears the remote stream down when the
+// operation context ends first, so a blocked Send or Recv honors the caller's
+// deadline. Remote transports only observe the context passed at Start.
+func renderRemoteOperationContext(g *protogen.GeneratedFile) {
+	g.P("if s.cancel != nil {")
+	g.P("stop := context.AfterFunc(ctx, s.cancel)")
+	g.P("defer stop()")
+	g.P("}")
+}
+
 func renderRemoteSend(g *protogen.GeneratedFile, wrapperName, reqType, label string) {
 	g.P("func (s *", wrapperName, ") Send(ctx context.Context, req ", reqType, ") error {")
-	g.P("_ = ctx")
 	g.P("if req == nil {")
 	g.P(`return errors.New("rpccgo: message request is nil")`)
 	g.P("}")
 	g.P("if s == nil || s.stream == nil {")
 	g.P(`return errors.New("rpccgo: `, label, ` is nil")`)
 	g.P("}")
-	g.P("return s.stream.Send(req)")
+	renderRemoteOperationContext(g)
+	g.P("return rpcruntime.ContextError(ctx, s.stream.Send(req))")
 	g.P("}")
 	g.P()
 }
 
 func renderConnectRemoteRecvFinishCancel(g *protogen.GeneratedFile, wrapperName, label, respType string) {
 	g.P("func (s *", wrapperName, ") Recv(ctx context.Context) (", respType, ", error) {")
-	g.P("_ = ctx")
 	g.P("if s == nil {")
 	g.P(`return nil, errors.New("rpccgo: connect remote `, label, ` is nil")`)
 	g.P("}")
 	g.P("if s.stream == nil {")
 	g.P(`return nil, errors.New("rpccgo: connect remote `, label, ` is nil")`)
 	g.P("}")
+	renderRemoteOperationContext(g)
 	g.P("if !s.stream.Receive() {")
+	g.P("s.finishMetadata()")
 	g.P("if err := s.stream.Err(); err != nil {")
-	g.P("return nil, err")
+	g.P("return nil, rpcruntime.ContextError(ctx, err)")
 	g.P("}")
 	g.P("return nil, io.EOF")
 	g.P("}")
@@ -304,13 +333,14 @@ func renderConnectRemoteRecvFinishCancel(g *protogen.GeneratedFile, wrapperName,
 
 func renderConnectRemoteBidiRecvFinishCancel(g *protogen.GeneratedFile, wrapperName, respType string) {
 	g.P("func (s *", wrapperName, ") Recv(ctx context.Context) (", respType, ", error) {")
-	g.P("_ = ctx")
 	g.P("if s == nil || s.stream == nil {")
 	g.P(`return nil, errors.New("rpccgo: connect remote bidi stream is nil")`)
 	g.P("}")
+	renderRemoteOperationContext(g)
 	g.P("resp, err := s.stream.Receive()")
 	g.P("if err != nil {")
-	g.P("return nil, err")
+	g.P("s.finishMetadata()")
+	g.P("return nil, rpcruntime.ContextError(ctx, err)")
 	g.P("}")
 	g.P("if resp == nil {")
 	g.P(`return nil, errors.New("rpccgo: message response is nil")`)
@@ -362,12 +392,15 @@ func renderGRPCDirectGenericClientStream(g *protogen.GeneratedFile, method runti
 	respPtrType := runtimeMessageResponseType(method)
 	g.P("func new", wrapperName, "(ctx context.Context, server ", serverName, ") rpcruntime.ClientStreamingClient[", reqPtrType, ", ", respPtrType, "] {")
 	g.P("client, stream, streamCtx := rpcruntime.NewClientStreaming[", reqPtrType, ", ", respPtrType, "](ctx, rpcruntime.LocalStreamOptions{")
-	g.P("RequestBuffer: 16,")
+	renderLocalStreamBufferingOptions(g, method.Identity.SourceFullName, method.Stream.Buffering, true, false, false)
 	g.P(`StreamClosed: errors.New("rpccgo: message stream is closed"),`)
 	g.P(`NilRequest: errors.New("rpccgo: message request is nil"),`)
 	g.P("})")
 	g.P("grpcStream := rpcruntime.NewGRPCClientStreamingServer[", reqType, ", ", respType, "](streamCtx, stream)")
-	g.P("go func() { grpcStream.Complete(server.", method.Identity.MessageMethodRef, "(grpcStream)) }()")
+	g.P("go func() {")
+	renderStreamHandlerRecover(g, method.Identity.SourceFullName, "grpcStream.Complete(err)")
+	g.P("grpcStream.Complete(server.", method.Identity.MessageMethodRef, "(grpcStream))")
+	g.P("}()")
 	g.P("return client")
 	g.P("}")
 	g.P()
@@ -379,12 +412,15 @@ func renderGRPCDirectGenericServerStream(g *protogen.GeneratedFile, method runti
 	g.P("func new", wrapperName, "(ctx context.Context, server ", serverName, ", req ", reqPtrType, ") (rpcruntime.ServerStreamingClient[", respPtrType, "], error) {")
 	g.P(`if req == nil { return nil, errors.New("rpccgo: message request is nil") }`)
 	g.P("client, stream, streamCtx := rpcruntime.NewServerStreaming[", respPtrType, "](ctx, rpcruntime.LocalStreamOptions{")
-	g.P("ResponseBuffer: 1,")
+	renderLocalStreamBufferingOptions(g, method.Identity.SourceFullName, method.Stream.Buffering, false, true, false)
 	g.P(`StreamClosed: errors.New("rpccgo: message stream is closed"),`)
 	g.P(`NilResponse: errors.New("rpccgo: message response is nil"),`)
 	g.P("})")
 	g.P("grpcStream := rpcruntime.NewGRPCServerStreamingServer[", respType, "](streamCtx, stream)")
-	g.P("go func() { stream.Complete(server.", method.Identity.MessageMethodRef, "(req, grpcStream)) }()")
+	g.P("go func() {")
+	renderStreamHandlerRecover(g, method.Identity.SourceFullName, "stream.Complete(err)")
+	g.P("stream.Complete(server.", method.Identity.MessageMethodRef, "(req, grpcStream))")
+	g.P("}()")
 	g.P("return client, nil")
 	g.P("}")
 	g.P()
@@ -395,14 +431,16 @@ func renderGRPCDirectGenericBidiStream(g *protogen.GeneratedFile, method runtime
 	respPtrType := runtimeMessageResponseType(method)
 	g.P("func new", wrapperName, "(ctx context.Context, server ", serverName, ") rpcruntime.BidiStreamingClient[", reqPtrType, ", ", respPtrType, "] {")
 	g.P("client, stream, streamCtx := rpcruntime.NewBidiStreaming[", reqPtrType, ", ", respPtrType, "](ctx, rpcruntime.LocalStreamOptions{")
-	g.P("RequestBuffer: 16,")
-	g.P("ResponseBuffer: 1,")
+	renderLocalStreamBufferingOptions(g, method.Identity.SourceFullName, method.Stream.Buffering, true, true, false)
 	g.P(`StreamClosed: errors.New("rpccgo: message stream is closed"),`)
 	g.P(`NilRequest: errors.New("rpccgo: message request is nil"),`)
 	g.P(`NilResponse: errors.New("rpccgo: message response is nil"),`)
 	g.P("})")
 	g.P("grpcStream := rpcruntime.NewGRPCBidiStreamingServer[", reqType, ", ", respType, "](streamCtx, stream)")
-	g.P("go func() { stream.Complete(server.", method.Identity.MessageMethodRef, "(grpcStream)) }()")
+	g.P("go func() {")
+	renderStreamHandlerRecover(g, method.Identity.SourceFullName, "stream.Complete(err)")
+	g.P("stream.Complete(server.", method.Identity.MessageMethodRef, "(grpcStream))")
+	g.P("}()")
 	g.P("return client")
 	g.P("}")
 	g.P()
@@ -426,17 +464,18 @@ func renderGRPCRemoteMessageSession(g *protogen.GeneratedFile, service ServicePl
 func renderGRPCRemoteClientStreamSession(g *protogen.GeneratedFile, method runtimeMethodProjection, wrapperName, reqType, respType, clientName string) {
 	reqPtrType := runtimeMessageRequestType(method)
 	respPtrType := runtimeMessageResponseType(method)
-	renderRemoteStreamConstructor(g, method, wrapperName, clientName, "grpc.ClientStreamingClient["+reqType+", "+respType+"]", "")
+	renderRemoteStreamConstructor(g, method, MessageTransportGRPC, wrapperName, clientName, "grpc.ClientStreamingClient["+reqType+", "+respType+"]", "")
 	renderRemoteSend(g, wrapperName, reqPtrType, "grpc remote client stream")
 	g.P("func (s *", wrapperName, ") Finish(ctx context.Context) (", respPtrType, ", error) {")
-	g.P("_ = ctx")
 	g.P("if s == nil || s.stream == nil {")
 	g.P(`return nil, errors.New("rpccgo: grpc remote client stream is nil")`)
 	g.P("}")
+	renderRemoteOperationContext(g)
 	g.P("defer func() { if s.cancel != nil { s.cancel() } }()")
 	g.P("response, err := s.stream.CloseAndRecv()")
+	g.P("s.finishMetadata()")
 	g.P("if err != nil {")
-	g.P("return nil, err")
+	g.P("return nil, rpcruntime.ContextError(ctx, err)")
 	g.P("}")
 	g.P("if response == nil {")
 	g.P(`return nil, errors.New("rpccgo: message response is nil")`)
@@ -460,14 +499,14 @@ func renderGRPCRemoteClientStreamSession(g *protogen.GeneratedFile, method runti
 func renderGRPCRemoteServerStreamSession(g *protogen.GeneratedFile, method runtimeMethodProjection, wrapperName, reqType, respType, clientName string) {
 	reqPtrType := runtimeMessageRequestType(method)
 	respPtrType := runtimeMessageResponseType(method)
-	renderRemoteStreamConstructor(g, method, wrapperName, clientName, "grpc.ServerStreamingClient["+respType+"]", reqPtrType)
+	renderRemoteStreamConstructor(g, method, MessageTransportGRPC, wrapperName, clientName, "grpc.ServerStreamingClient["+respType+"]", reqPtrType)
 	renderGRPCRemoteRecvFinishCancel(g, wrapperName, "server stream", respPtrType)
 }
 
 func renderGRPCRemoteBidiStreamSession(g *protogen.GeneratedFile, method runtimeMethodProjection, wrapperName, reqType, respType, clientName string) {
 	reqPtrType := runtimeMessageRequestType(method)
 	respPtrType := runtimeMessageResponseType(method)
-	renderRemoteStreamConstructor(g, method, wrapperName, clientName, "grpc.BidiStreamingClient["+reqType+", "+respType+"]", "")
+	renderRemoteStreamConstructor(g, method, MessageTransportGRPC, wrapperName, clientName, "grpc.BidiStreamingClient["+reqType+", "+respType+"]", "")
 	renderRemoteSend(g, wrapperName, reqPtrType, "grpc remote bidi stream")
 	renderGRPCRemoteRecvFinishCancel(g, wrapperName, "bidi stream", respPtrType)
 	g.P("func (s *", wrapperName, ") CloseSend(ctx context.Context) error {")
@@ -482,16 +521,17 @@ func renderGRPCRemoteBidiStreamSession(g *protogen.GeneratedFile, method runtime
 
 func renderGRPCRemoteRecvFinishCancel(g *protogen.GeneratedFile, wrapperName, label, respPtrType string) {
 	g.P("func (s *", wrapperName, ") Recv(ctx context.Context) (", respPtrType, ", error) {")
-	g.P("_ = ctx")
 	g.P("if s == nil || s.stream == nil {")
 	g.P(`return nil, errors.New("rpccgo: grpc remote `, label, ` is nil")`)
 	g.P("}")
+	renderRemoteOperationContext(g)
 	g.P("response, err := s.stream.Recv()")
 	g.P("if err != nil {")
+	g.P("s.finishMetadata()")
 	g.P("if errors.Is(err, io.EOF) {")
 	g.P("return nil, io.EOF")
 	g.P("}")
-	g.P("return nil, err")
+	g.P("return nil, rpcruntime.ContextError(ctx, err)")
 	g.P("}")
 	g.P("if response == nil {")
 	g.P(`return nil, errors.New("rpccgo: message response is nil")`)
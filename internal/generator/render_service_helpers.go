@@ -1,6 +1,10 @@
 package generator
 
-import "google.golang.org/protobuf/compiler/protogen"
+import (
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
 
 func serviceHasUnaryMethod(service ServicePlan) bool {
 	for _, method := range service.Methods {
@@ -53,3 +57,24 @@ func qualifiedMethodType(g *protogen.GeneratedFile, message MethodIOPlan) string
 		GoImportPath: protogen.GoImportPath(message.GoImportPath),
 	})
 }
+
+// renderLocalStreamBufferingOptions renders the rpcruntime.LocalStreamOptions
+// fields that size the queues of a generated in-process stream. Native streams
+// set borrowedRequests because their requests wrap C caller memory.
+func renderLocalStreamBufferingOptions(g *protogen.GeneratedFile, method string, buffering MethodStreamBuffering, requests, responses, borrowedRequests bool) {
+	if method != "" {
+		g.P("Method: ", strconv.Quote(method), ",")
+	}
+	if requests {
+		g.P("RequestBuffer: ", buffering.RequestBuffer, ",")
+	}
+	if responses {
+		g.P("ResponseBuffer: ", buffering.ResponseBuffer, ",")
+	}
+	if buffering.PipelinedSend {
+		g.P("PipelinedSend: true,")
+	}
+	if requests && borrowedRequests {
+		g.P("BorrowedRequests: true,")
+	}
+}
@@ -0,0 +1,591 @@
+package integration
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/ygrpc/rpccgo/internal/generator"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestBufferRepeatNativeABIAcceptance(t *testing.T) {
+	tmp := t.TempDir()
+	plugin := newBufferRepeatNativeABIPlugin(t, "example.com/buffernativeabi/buffer/v1;bufferv1")
+	if _, err := generator.GenerateWithOptions(plugin); err != nil {
+		t.Fatalf("GenerateWithOptions() error = %v", err)
+	}
+
+	writeMessageDirectPathGeneratedModule(t, tmp, plugin, "example.com/buffernativeabi")
+	writeFile(t, filepath.Join(tmp, "buffer/v1/buffer.pb.go"), bufferRepeatNativeABIPBGoSource)
+	writeFile(t, filepath.Join(tmp, "buffer/v1/buffer_connect_stubs.go"), bufferRepeatNativeABIConnectStubSource)
+	writeFile(t, filepath.Join(tmp, "buffer/v1/buffer_integration_reset.go"), bufferRepeatNativeABIResetSource)
+	writeFile(t, filepath.Join(tmp, "buffer/v1/cgo/buffer_native_cgo_client_bridge.go"), bufferRepeatNativeABICGOClientBridgeSource)
+	writeFile(t, filepath.Join(tmp, "buffer/v1/cgo/buffer_native_abi_test.go"), bufferRepeatNativeABIFixtureTestSource)
+
+	cmd := exec.Command("go", "test", "./buffer/v1/cgo", "-run", "^TestBufferRepeatNativeABI$", "-count=1")
+	cmd.Dir = tmp
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("buffer repeat native ABI fixture failed: %v\n%s", err, out)
+	}
+}
+
+func newBufferRepeatNativeABIPlugin(t *testing.T, goPackage string) *protogen.Plugin {
+	t.Helper()
+	bufferMessage := func(name string) *descriptorpb.DescriptorProto {
+		return &descriptorpb.DescriptorProto{
+			Name: proto.String(name),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				fieldDescriptor("tags", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, ""),
+				fieldDescriptor("payloads", 2, descriptorpb.FieldDescriptorProto_TYPE_BYTES, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, ""),
+				fieldDescriptor("labels", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, ".buffer.abi.v1."+name+".LabelsEntry"),
+			},
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("LabelsEntry"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					fieldDescriptor("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+					fieldDescriptor("value", 2, descriptorpb.FieldDescriptorProto_TYPE_BYTES, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+				},
+				Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+			}},
+		}
+	}
+	request := &pluginpb.CodeGeneratorRequest{
+		Parameter:      proto.String("paths=source_relative"),
+		FileToGenerate: []string{"buffer/v1/buffer.proto"},
+		ProtoFile: []*descriptorpb.FileDescriptorProto{{
+			Name:    proto.String("buffer/v1/buffer.proto"),
+			Package: proto.String("buffer.abi.v1"),
+			Syntax:  proto.String("proto3"),
+			Options: &descriptorpb.FileOptions{
+				GoPackage: proto.String(goPackage),
+			},
+			MessageType: []*descriptorpb.DescriptorProto{
+				bufferMessage("BufferRequest"),
+				bufferMessage("BufferReply"),
+			},
+			Service: []*descriptorpb.ServiceDescriptorProto{{
+				Name: proto.String("BufferGreeter"),
+				Method: []*descriptorpb.MethodDescriptorProto{{
+					Name:       proto.String("Echo"),
+					InputType:  proto.String(".buffer.abi.v1.BufferRequest"),
+					OutputType: proto.String(".buffer.abi.v1.BufferReply"),
+				}},
+			}},
+			SourceCodeInfo: &descriptorpb.SourceCodeInfo{Location: []*descriptorpb.SourceCodeInfo_Location{{
+				Path:            []int32{6, 0},
+				Span:            []int32{0, 0, 0},
+				LeadingComments: proto.String("@rpccgo: msg-connect|native\n"),
+			}}},
+		}},
+	}
+	plugin, err := generator.ProtogenOptions().New(request)
+	if err != nil {
+		t.Fatalf("protogen.Options.New() error = %v", err)
+	}
+	return plugin
+}
+
+const bufferRepeatNativeABIConnectStubSource = `package bufferv1
+
+import context "context"
+
+type BufferGreeterHandler interface {
+	Echo(context.Context, *BufferRequest) (*BufferReply, error)
+}
+
+type BufferGreeterClient interface {
+	Echo(context.Context, *BufferRequest) (*BufferReply, error)
+}
+
+type BufferGreeterServer interface {
+	Echo(context.Context, *BufferRequest) (*BufferReply, error)
+}
+`
+
+const bufferRepeatNativeABIResetSource = `package bufferv1
+
+import rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"
+
+func ResetBufferGreeterServerForIntegrationTest() {
+	_ = ClearBufferGreeterServer()
+	rpcruntime.ResetStreamSessionsForTesting()
+}
+`
+
+const bufferRepeatNativeABICGOClientBridgeSource = `package main
+
+/*
+#include <stdint.h>
+*/
+import "C"
+
+import context "context"
+import unsafe "unsafe"
+
+func CallBufferGreeterEchoNativeUnary(ctx context.Context, input *bufferInput, output *bufferOutput) int32 {
+	var out [4]struct {
+		ptr       C.uintptr_t
+		length    C.int32_t
+		ownership C.int32_t
+	}
+	errID := rpccgoNativeBufferv1BufferGreeterEcho(
+		unsafe.Pointer(input.Tags.Ptr), C.int32_t(input.Tags.Len), C.int32_t(input.Tags.Ownership),
+		unsafe.Pointer(input.Payloads.Ptr), C.int32_t(input.Payloads.Len), C.int32_t(input.Payloads.Ownership),
+		unsafe.Pointer(input.LabelsKeys.Ptr), C.int32_t(input.LabelsKeys.Len), C.int32_t(input.LabelsKeys.Ownership),
+		unsafe.Pointer(input.LabelsValues.Ptr), C.int32_t(input.LabelsValues.Len), C.int32_t(input.LabelsValues.Ownership),
+		&out[0].ptr, &out[0].length, &out[0].ownership,
+		&out[1].ptr, &out[1].length, &out[1].ownership,
+		&out[2].ptr, &out[2].length, &out[2].ownership,
+		&out[3].ptr, &out[3].length, &out[3].ownership,
+	)
+	fields := []*bufferArray{&output.Tags, &output.Payloads, &output.LabelsKeys, &output.LabelsValues}
+	for i, field := range fields {
+		*field = bufferArray{Ptr: uintptr(out[i].ptr), Len: int32(out[i].length), Ownership: int32(out[i].ownership)}
+	}
+	return int32(errID)
+}
+`
+
+const bufferRepeatNativeABIFixtureTestSource = `package main
+
+import (
+	context "context"
+	maps "maps"
+	slices "slices"
+	strings "strings"
+	sync "sync"
+	testing "testing"
+	unsafe "unsafe"
+
+	bufferv1 "example.com/buffernativeabi/buffer/v1"
+	rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"
+)
+
+type bufferGoNativeServer struct{}
+
+func (bufferGoNativeServer) Echo(ctx context.Context, tags *rpcruntime.RpcStringRepeat, payloads *rpcruntime.RpcBytesRepeat, labelsKeys *rpcruntime.RpcStringRepeat, labelsValues *rpcruntime.RpcBytesRepeat) ([]string, [][]byte, []string, [][]byte, error) {
+	outTags := make([]string, tags.Len())
+	for i := range outTags {
+		outTags[i] = strings.ToUpper(tags.At(int32(i)))
+	}
+	outPayloads := make([][]byte, payloads.Len())
+	for i := range outPayloads {
+		outPayloads[i] = append([]byte{byte(i)}, payloads.At(int32(i))...)
+	}
+	return outTags, outPayloads, labelsKeys.SafeSlice(), labelsValues.SafeSlice(), nil
+}
+
+type bufferConnectHandler struct{}
+
+func (bufferConnectHandler) Echo(ctx context.Context, req *bufferv1.BufferRequest) (*bufferv1.BufferReply, error) {
+	reply := &bufferv1.BufferReply{Labels: map[string][]byte{}}
+	for _, tag := range req.GetTags() {
+		reply.Tags = append(reply.Tags, strings.ToUpper(tag))
+	}
+	for i, payload := range req.GetPayloads() {
+		reply.Payloads = append(reply.Payloads, append([]byte{byte(i)}, payload...))
+	}
+	maps.Copy(reply.Labels, req.GetLabels())
+	return reply, nil
+}
+
+type bufferArray struct {
+	Ptr       uintptr
+	Len       int32
+	Ownership int32
+}
+
+type bufferInput struct {
+	Tags         bufferArray
+	Payloads     bufferArray
+	LabelsKeys   bufferArray
+	LabelsValues bufferArray
+	// elems keeps the Go arrays behind the borrowed pointers reachable.
+	elems [][]rpcruntime.RpcBuffer
+}
+
+func (in *bufferInput) array(values []string, ownership int32) bufferArray {
+	if len(values) == 0 {
+		return bufferArray{}
+	}
+	elems := make([]rpcruntime.RpcBuffer, len(values))
+	for i, value := range values {
+		data := []byte(value)
+		if len(data) > 0 {
+			elems[i] = rpcruntime.RpcBuffer{Ptr: unsafe.Pointer(&data[0]), Len: int32(len(data)), Ownership: ownership}
+		}
+	}
+	in.elems = append(in.elems, elems)
+	return bufferArray{Ptr: uintptr(unsafe.Pointer(&elems[0])), Len: int32(len(elems)), Ownership: ownership}
+}
+
+func newBufferInput(tags, payloads []string, labels map[string]string, ownership int32) *bufferInput {
+	in := &bufferInput{}
+	in.Tags = in.array(tags, ownership)
+	in.Payloads = in.array(payloads, ownership)
+	keys := slices.Sorted(maps.Keys(labels))
+	values := make([]string, len(keys))
+	for i, key := range keys {
+		values[i] = labels[key]
+	}
+	in.LabelsKeys = in.array(keys, ownership)
+	in.LabelsValues = in.array(values, ownership)
+	return in
+}
+
+type bufferOutput struct {
+	Tags         bufferArray
+	Payloads     bufferArray
+	LabelsKeys   bufferArray
+	LabelsValues bufferArray
+}
+
+func (o *bufferOutput) release() {
+	rpcruntime.Release(o.Tags.Ptr)
+	rpcruntime.Release(o.Payloads.Ptr)
+	rpcruntime.Release(o.LabelsKeys.Ptr)
+	rpcruntime.Release(o.LabelsValues.Ptr)
+}
+
+func copyStrings(t *testing.T, array bufferArray) []string {
+	t.Helper()
+	values, err := rpcruntime.CopyStringArray((*rpcruntime.RpcBuffer)(unsafe.Pointer(array.Ptr)), array.Len)
+	if err != nil {
+		t.Fatalf("CopyStringArray() error = %v", err)
+	}
+	return values
+}
+
+func copyBytes(t *testing.T, array bufferArray) []string {
+	t.Helper()
+	values, err := rpcruntime.CopyBytesArray((*rpcruntime.RpcBuffer)(unsafe.Pointer(array.Ptr)), array.Len)
+	if err != nil {
+		t.Fatalf("CopyBytesArray() error = %v", err)
+	}
+	out := make([]string, len(values))
+	for i, value := range values {
+		out[i] = string(value)
+	}
+	return out
+}
+
+func labelsFromOutput(t *testing.T, output *bufferOutput) map[string]string {
+	t.Helper()
+	keys := copyStrings(t, output.LabelsKeys)
+	values := copyBytes(t, output.LabelsValues)
+	if len(keys) != len(values) {
+		t.Fatalf("labels halves = (%d, %d), want paired entries", len(keys), len(values))
+	}
+	out := make(map[string]string, len(keys))
+	for i, key := range keys {
+		out[key] = values[i]
+	}
+	return out
+}
+
+func callBufferEcho(t *testing.T, input *bufferInput) *bufferOutput {
+	t.Helper()
+	output := &bufferOutput{}
+	if errID := CallBufferGreeterEchoNativeUnary(context.Background(), input, output); errID != 0 {
+		text, _, _ := rpcruntime.TakeErrorText(rpcruntime.ErrorID(errID))
+		t.Fatalf("CallBufferGreeterEchoNativeUnary() errID = %d: %s", errID, text)
+	}
+	t.Cleanup(output.release)
+	return output
+}
+
+func assertBufferEchoOutput(t *testing.T, output *bufferOutput, wantLabels map[string]string) {
+	t.Helper()
+	if got, want := copyStrings(t, output.Tags), []string{"ALPHA", "", "GAMMA"}; !slices.Equal(got, want) {
+		t.Fatalf("tags = %q, want %q", got, want)
+	}
+	if got, want := copyBytes(t, output.Payloads), []string{"\x00\x00\x01", "\x01"}; !slices.Equal(got, want) {
+		t.Fatalf("payloads = %q, want %q", got, want)
+	}
+	if got := labelsFromOutput(t, output); !maps.Equal(got, wantLabels) {
+		t.Fatalf("labels = %q, want %q", got, wantLabels)
+	}
+}
+
+func TestBufferRepeatNativeABI(t *testing.T) {
+	labels := map[string]string{"env": "prod", "empty": "", "bin": "\xff\x00"}
+	input := newBufferInput([]string{"alpha", "", "gamma"}, []string{"\x00\x01", ""}, labels, 0)
+
+	t.Run("native client routes buffer arrays to go native server", func(t *testing.T) {
+		bufferv1.ResetBufferGreeterServerForIntegrationTest()
+		if err := bufferv1.RegisterBufferGreeterGoNativeServer(bufferGoNativeServer{}); err != nil {
+			t.Fatalf("RegisterBufferGreeterGoNativeServer() error = %v", err)
+		}
+
+		assertBufferEchoOutput(t, callBufferEcho(t, input), labels)
+	})
+
+	t.Run("codec converts buffer arrays to and from message fields", func(t *testing.T) {
+		bufferv1.ResetBufferGreeterServerForIntegrationTest()
+		if err := bufferv1.RegisterBufferGreeterConnectHandler(bufferConnectHandler{}); err != nil {
+			t.Fatalf("RegisterBufferGreeterConnectHandler() error = %v", err)
+		}
+
+		assertBufferEchoOutput(t, callBufferEcho(t, input), labels)
+	})
+
+	t.Run("owned elements and arrays are freed once per call", func(t *testing.T) {
+		rpcruntime.ResetFreeCallbackForTesting()
+		t.Cleanup(rpcruntime.ResetFreeCallbackForTesting)
+		var mu sync.Mutex
+		freed := map[uintptr]int{}
+		rpcruntime.RegisterFreeCallback(func(ptr unsafe.Pointer) {
+			mu.Lock()
+			defer mu.Unlock()
+			freed[uintptr(ptr)]++
+		})
+		bufferv1.ResetBufferGreeterServerForIntegrationTest()
+		if err := bufferv1.RegisterBufferGreeterGoNativeServer(bufferGoNativeServer{}); err != nil {
+			t.Fatalf("RegisterBufferGreeterGoNativeServer() error = %v", err)
+		}
+
+		owned := newBufferInput([]string{"alpha", "", "gamma"}, []string{"\x00\x01", ""}, labels, 1)
+		assertBufferEchoOutput(t, callBufferEcho(t, owned), labels)
+
+		want := map[uintptr]int{}
+		for _, elems := range owned.elems {
+			want[uintptr(unsafe.Pointer(&elems[0]))] = 1
+			for _, elem := range elems {
+				if elem.Ptr != nil {
+					want[uintptr(elem.Ptr)] = 1
+				}
+			}
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if !maps.Equal(freed, want) {
+			t.Fatalf("freed = %v, want each owned element and array once: %v", freed, want)
+		}
+	})
+}
+`
+
+const bufferRepeatNativeABIPBGoSource = `// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v7.34.1
+// source: buffer/v1/buffer.proto
+
+package bufferv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BufferRequest struct {
+	state         protoimpl.MessageState ` + "`" + `protogen:"open.v1"` + "`" + `
+	Tags          []string               ` + "`" + `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"` + "`" + `
+	Payloads      [][]byte               ` + "`" + `protobuf:"bytes,2,rep,name=payloads,proto3" json:"payloads,omitempty"` + "`" + `
+	Labels        map[string][]byte      ` + "`" + `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` + "`" + `
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BufferRequest) Reset() {
+	*x = BufferRequest{}
+	mi := &file_buffer_v1_buffer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BufferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BufferRequest) ProtoMessage() {}
+
+func (x *BufferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_buffer_v1_buffer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BufferRequest.ProtoReflect.Descriptor instead.
+func (*BufferRequest) Descriptor() ([]byte, []int) {
+	return file_buffer_v1_buffer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BufferRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *BufferRequest) GetPayloads() [][]byte {
+	if x != nil {
+		return x.Payloads
+	}
+	return nil
+}
+
+func (x *BufferRequest) GetLabels() map[string][]byte {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type BufferReply struct {
+	state         protoimpl.MessageState ` + "`" + `protogen:"open.v1"` + "`" + `
+	Tags          []string               ` + "`" + `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"` + "`" + `
+	Payloads      [][]byte               ` + "`" + `protobuf:"bytes,2,rep,name=payloads,proto3" json:"payloads,omitempty"` + "`" + `
+	Labels        map[string][]byte      ` + "`" + `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` + "`" + `
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BufferReply) Reset() {
+	*x = BufferReply{}
+	mi := &file_buffer_v1_buffer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BufferReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BufferReply) ProtoMessage() {}
+
+func (x *BufferReply) ProtoReflect() protoreflect.Message {
+	mi := &file_buffer_v1_buffer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BufferReply.ProtoReflect.Descriptor instead.
+func (*BufferReply) Descriptor() ([]byte, []int) {
+	return file_buffer_v1_buffer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BufferReply) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *BufferReply) GetPayloads() [][]byte {
+	if x != nil {
+		return x.Payloads
+	}
+	return nil
+}
+
+func (x *BufferReply) GetLabels() map[string][]byte {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+var File_buffer_v1_buffer_proto protoreflect.FileDescriptor
+
+const file_buffer_v1_buffer_proto_rawDesc = "" +
+	"\n" +
+	"\x16buffer/v1/buffer.proto\x12\rbuffer.abi.v1\"\xbc\x01\n" +
+	"\rBufferRequest\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\x12\x1a\n" +
+	"\bpayloads\x18\x02 \x03(\fR\bpayloads\x12@\n" +
+	"\x06labels\x18\x03 \x03(\v2(.buffer.abi.v1.BufferRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"\xb8\x01\n" +
+	"\vBufferReply\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\x12\x1a\n" +
+	"\bpayloads\x18\x02 \x03(\fR\bpayloads\x12>\n" +
+	"\x06labels\x18\x03 \x03(\v2&.buffer.abi.v1.BufferReply.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01B0Z.example.com/buffernativeabi/buffer/v1;bufferv1b\x06proto3"
+
+var (
+	file_buffer_v1_buffer_proto_rawDescOnce sync.Once
+	file_buffer_v1_buffer_proto_rawDescData []byte
+)
+
+func file_buffer_v1_buffer_proto_rawDescGZIP() []byte {
+	file_buffer_v1_buffer_proto_rawDescOnce.Do(func() {
+		file_buffer_v1_buffer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_buffer_v1_buffer_proto_rawDesc), len(file_buffer_v1_buffer_proto_rawDesc)))
+	})
+	return file_buffer_v1_buffer_proto_rawDescData
+}
+
+var file_buffer_v1_buffer_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_buffer_v1_buffer_proto_goTypes = []any{
+	(*BufferRequest)(nil), // 0: buffer.abi.v1.BufferRequest
+	(*BufferReply)(nil),   // 1: buffer.abi.v1.BufferReply
+	nil,                   // 2: buffer.abi.v1.BufferRequest.LabelsEntry
+	nil,                   // 3: buffer.abi.v1.BufferReply.LabelsEntry
+}
+var file_buffer_v1_buffer_proto_depIdxs = []int32{
+	2, // 0: buffer.abi.v1.BufferRequest.labels:type_name -> buffer.abi.v1.BufferRequest.LabelsEntry
+	3, // 1: buffer.abi.v1.BufferReply.labels:type_name -> buffer.abi.v1.BufferReply.LabelsEntry
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_buffer_v1_buffer_proto_init() }
+func file_buffer_v1_buffer_proto_init() {
+	if File_buffer_v1_buffer_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_buffer_v1_buffer_proto_rawDesc), len(file_buffer_v1_buffer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_buffer_v1_buffer_proto_goTypes,
+		DependencyIndexes: file_buffer_v1_buffer_proto_depIdxs,
+		MessageInfos:      file_buffer_v1_buffer_proto_msgTypes,
+	}.Build()
+	File_buffer_v1_buffer_proto = out.File
+	file_buffer_v1_buffer_proto_goTypes = nil
+	file_buffer_v1_buffer_proto_depIdxs = nil
+}
+`
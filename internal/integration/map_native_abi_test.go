@@ -0,0 +1,556 @@
+package integration
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/ygrpc/rpccgo/internal/generator"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestMapNativeABIAcceptance(t *testing.T) {
+	tmp := t.TempDir()
+	plugin := newMapNativeABIPlugin(t, "example.com/mapnativeabi/map/v1;mapv1")
+	if _, err := generator.GenerateWithOptions(plugin); err != nil {
+		t.Fatalf("GenerateWithOptions() error = %v", err)
+	}
+
+	writeMessageDirectPathGeneratedModule(t, tmp, plugin, "example.com/mapnativeabi")
+	writeFile(t, filepath.Join(tmp, "map/v1/map.pb.go"), mapNativeABIPBGoSource)
+	writeFile(t, filepath.Join(tmp, "map/v1/map_connect_stubs.go"), mapNativeABIConnectStubSource)
+	writeFile(t, filepath.Join(tmp, "map/v1/map_integration_reset.go"), mapNativeABIResetSource)
+	writeFile(t, filepath.Join(tmp, "map/v1/cgo/map_native_cgo_client_bridge.go"), mapNativeABICGOClientBridgeSource)
+	writeFile(t, filepath.Join(tmp, "map/v1/cgo/map_native_abi_test.go"), mapNativeABIFixtureTestSource)
+
+	cmd := exec.Command("go", "test", "./map/v1/cgo", "-run", "^TestMapNativeABI$", "-count=1")
+	cmd.Dir = tmp
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("map native ABI fixture failed: %v\n%s", err, out)
+	}
+}
+
+func newMapNativeABIPlugin(t *testing.T, goPackage string) *protogen.Plugin {
+	t.Helper()
+	mapEntry := func(name string, keyType, valueType descriptorpb.FieldDescriptorProto_Type) *descriptorpb.DescriptorProto {
+		return &descriptorpb.DescriptorProto{
+			Name: proto.String(name),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				fieldDescriptor("key", 1, keyType, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+				fieldDescriptor("value", 2, valueType, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+			},
+			Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+		}
+	}
+	mapMessage := func(name string) *descriptorpb.DescriptorProto {
+		return &descriptorpb.DescriptorProto{
+			Name: proto.String(name),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				fieldDescriptor("totals", 1, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, ".map.abi.v1."+name+".TotalsEntry"),
+				fieldDescriptor("flags", 2, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, ".map.abi.v1."+name+".FlagsEntry"),
+			},
+			NestedType: []*descriptorpb.DescriptorProto{
+				mapEntry("TotalsEntry", descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+				mapEntry("FlagsEntry", descriptorpb.FieldDescriptorProto_TYPE_INT64, descriptorpb.FieldDescriptorProto_TYPE_BOOL),
+			},
+		}
+	}
+	request := &pluginpb.CodeGeneratorRequest{
+		Parameter:      proto.String("paths=source_relative"),
+		FileToGenerate: []string{"map/v1/map.proto"},
+		ProtoFile: []*descriptorpb.FileDescriptorProto{{
+			Name:    proto.String("map/v1/map.proto"),
+			Package: proto.String("map.abi.v1"),
+			Syntax:  proto.String("proto3"),
+			Options: &descriptorpb.FileOptions{
+				GoPackage: proto.String(goPackage),
+			},
+			MessageType: []*descriptorpb.DescriptorProto{
+				mapMessage("MapRequest"),
+				mapMessage("MapReply"),
+			},
+			Service: []*descriptorpb.ServiceDescriptorProto{{
+				Name: proto.String("MapGreeter"),
+				Method: []*descriptorpb.MethodDescriptorProto{{
+					Name:       proto.String("Echo"),
+					InputType:  proto.String(".map.abi.v1.MapRequest"),
+					OutputType: proto.String(".map.abi.v1.MapReply"),
+				}},
+			}},
+			SourceCodeInfo: &descriptorpb.SourceCodeInfo{Location: []*descriptorpb.SourceCodeInfo_Location{{
+				Path:            []int32{6, 0},
+				Span:            []int32{0, 0, 0},
+				LeadingComments: proto.String("@rpccgo: msg-connect|native\n"),
+			}}},
+		}},
+	}
+	plugin, err := generator.ProtogenOptions().New(request)
+	if err != nil {
+		t.Fatalf("protogen.Options.New() error = %v", err)
+	}
+	return plugin
+}
+
+const mapNativeABIConnectStubSource = `package mapv1
+
+import context "context"
+
+type MapGreeterHandler interface {
+	Echo(context.Context, *MapRequest) (*MapReply, error)
+}
+
+type MapGreeterClient interface {
+	Echo(context.Context, *MapRequest) (*MapReply, error)
+}
+
+type MapGreeterServer interface {
+	Echo(context.Context, *MapRequest) (*MapReply, error)
+}
+`
+
+const mapNativeABIResetSource = `package mapv1
+
+import rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"
+
+func ResetMapGreeterServerForIntegrationTest() {
+	_ = ClearMapGreeterServer()
+	rpcruntime.ResetStreamSessionsForTesting()
+}
+`
+
+const mapNativeABICGOClientBridgeSource = `package main
+
+/*
+#include <stdint.h>
+*/
+import "C"
+
+import context "context"
+import unsafe "unsafe"
+
+func CallMapGreeterEchoNativeUnary(ctx context.Context, input *mapInput, output *mapOutput) int32 {
+	var half [4]struct {
+		ptr       C.uintptr_t
+		length    C.int32_t
+		ownership C.int32_t
+	}
+	errID := rpccgoNativeMapv1MapGreeterEcho(
+		unsafe.Pointer(input.Totals.KeysPtr), C.int32_t(input.Totals.KeysLen), 0,
+		unsafe.Pointer(input.Totals.ValuesPtr), C.int32_t(input.Totals.ValuesLen), 0,
+		unsafe.Pointer(input.Flags.KeysPtr), C.int32_t(input.Flags.KeysLen), 0,
+		unsafe.Pointer(input.Flags.ValuesPtr), C.int32_t(input.Flags.ValuesLen), 0,
+		&half[0].ptr, &half[0].length, &half[0].ownership,
+		&half[1].ptr, &half[1].length, &half[1].ownership,
+		&half[2].ptr, &half[2].length, &half[2].ownership,
+		&half[3].ptr, &half[3].length, &half[3].ownership,
+	)
+	output.Totals = mapArrays{KeysPtr: uintptr(half[0].ptr), KeysLen: int32(half[0].length), ValuesPtr: uintptr(half[1].ptr), ValuesLen: int32(half[1].length)}
+	output.Flags = mapArrays{KeysPtr: uintptr(half[2].ptr), KeysLen: int32(half[2].length), ValuesPtr: uintptr(half[3].ptr), ValuesLen: int32(half[3].length)}
+	return int32(errID)
+}
+`
+
+const mapNativeABIFixtureTestSource = `package main
+
+import (
+	context "context"
+	errors "errors"
+	maps "maps"
+	strings "strings"
+	testing "testing"
+	unsafe "unsafe"
+
+	mapv1 "example.com/mapnativeabi/map/v1"
+	rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"
+)
+
+type mapGoNativeServer struct{}
+
+func (mapGoNativeServer) Echo(ctx context.Context, totalsKeys *rpcruntime.RpcRepeat[int32], totalsValues *rpcruntime.RpcRepeat[int64], flagsKeys *rpcruntime.RpcRepeat[int64], flagsValues *rpcruntime.RpcBoolRepeat) ([]int32, []int64, []int64, []bool, error) {
+	if totalsKeys.Len() != totalsValues.Len() || flagsKeys.Len() != flagsValues.Len() {
+		return nil, nil, nil, nil, errors.New("unpaired map halves")
+	}
+	outTotals := append([]int64(nil), totalsValues.SafeSlice()...)
+	for i := range outTotals {
+		outTotals[i] *= 10
+	}
+	outFlags := make([]bool, flagsValues.Len())
+	for i := range outFlags {
+		outFlags[i] = !flagsValues.At(int32(i))
+	}
+	return append([]int32(nil), totalsKeys.SafeSlice()...), outTotals, append([]int64(nil), flagsKeys.SafeSlice()...), outFlags, nil
+}
+
+type mapConnectHandler struct{}
+
+func (mapConnectHandler) Echo(ctx context.Context, req *mapv1.MapRequest) (*mapv1.MapReply, error) {
+	reply := &mapv1.MapReply{Totals: map[int32]int64{}, Flags: map[int64]bool{}}
+	for key, value := range req.GetTotals() {
+		reply.Totals[key] = value * 10
+	}
+	for key, value := range req.GetFlags() {
+		reply.Flags[key] = !value
+	}
+	return reply, nil
+}
+
+type mapArrays struct {
+	KeysPtr   uintptr
+	KeysLen   int32
+	ValuesPtr uintptr
+	ValuesLen int32
+}
+
+type mapInput struct {
+	Totals mapArrays
+	Flags  mapArrays
+	// arrays keeps the Go arrays behind the borrowed pointers reachable.
+	arrays []any
+}
+
+func newMapInput(totalsKeys []int32, totalsValues []int64, flagsKeys []int64, flagsValues []byte) *mapInput {
+	return &mapInput{
+		Totals: sliceArrays(totalsKeys, totalsValues),
+		Flags:  sliceArrays(flagsKeys, flagsValues),
+		arrays: []any{totalsKeys, totalsValues, flagsKeys, flagsValues},
+	}
+}
+
+type mapOutput struct {
+	Totals mapArrays
+	Flags  mapArrays
+}
+
+func (o *mapOutput) release() {
+	rpcruntime.Release(o.Totals.KeysPtr)
+	rpcruntime.Release(o.Totals.ValuesPtr)
+	rpcruntime.Release(o.Flags.KeysPtr)
+	rpcruntime.Release(o.Flags.ValuesPtr)
+}
+
+func sliceArrays[K, V any](keys []K, values []V) mapArrays {
+	var arrays mapArrays
+	if len(keys) > 0 {
+		arrays.KeysPtr = uintptr(unsafe.Pointer(&keys[0]))
+		arrays.KeysLen = int32(len(keys))
+	}
+	if len(values) > 0 {
+		arrays.ValuesPtr = uintptr(unsafe.Pointer(&values[0]))
+		arrays.ValuesLen = int32(len(values))
+	}
+	return arrays
+}
+
+func arraysToMap[K comparable, V any](arrays mapArrays, value func(V) V) map[K]V {
+	out := map[K]V{}
+	if arrays.KeysLen == 0 {
+		return out
+	}
+	keys := unsafe.Slice((*K)(unsafe.Pointer(arrays.KeysPtr)), int(arrays.KeysLen))
+	values := unsafe.Slice((*V)(unsafe.Pointer(arrays.ValuesPtr)), int(arrays.ValuesLen))
+	for i := range keys {
+		out[keys[i]] = value(values[i])
+	}
+	return out
+}
+
+func flagsFromOutput(arrays mapArrays) map[int64]bool {
+	raw := arraysToMap[int64, byte](arrays, func(v byte) byte { return v })
+	out := make(map[int64]bool, len(raw))
+	for key, value := range raw {
+		out[key] = value != 0
+	}
+	return out
+}
+
+func identity[V any](v V) V { return v }
+
+func callMapEcho(t *testing.T, input *mapInput) *mapOutput {
+	t.Helper()
+	output := &mapOutput{}
+	if errID := CallMapGreeterEchoNativeUnary(context.Background(), input, output); errID != 0 {
+		text, _, _ := rpcruntime.TakeErrorText(rpcruntime.ErrorID(errID))
+		t.Fatalf("CallMapGreeterEchoNativeUnary() errID = %d: %s", errID, text)
+	}
+	t.Cleanup(output.release)
+	return output
+}
+
+func TestMapNativeABI(t *testing.T) {
+	input := newMapInput([]int32{1, 2, 3}, []int64{10, 20, 30}, []int64{-7, 9}, []byte{1, 0})
+	wantTotals := map[int32]int64{1: 100, 2: 200, 3: 300}
+	wantFlags := map[int64]bool{-7: false, 9: true}
+
+	t.Run("native client routes map halves to go native server", func(t *testing.T) {
+		mapv1.ResetMapGreeterServerForIntegrationTest()
+		if err := mapv1.RegisterMapGreeterGoNativeServer(mapGoNativeServer{}); err != nil {
+			t.Fatalf("RegisterMapGreeterGoNativeServer() error = %v", err)
+		}
+
+		output := callMapEcho(t, input)
+		if got := arraysToMap[int32, int64](output.Totals, identity[int64]); !maps.Equal(got, wantTotals) {
+			t.Fatalf("totals = %v, want %v", got, wantTotals)
+		}
+		if got := flagsFromOutput(output.Flags); !maps.Equal(got, wantFlags) {
+			t.Fatalf("flags = %v, want %v", got, wantFlags)
+		}
+	})
+
+	t.Run("codec converts map halves to and from message maps", func(t *testing.T) {
+		mapv1.ResetMapGreeterServerForIntegrationTest()
+		if err := mapv1.RegisterMapGreeterConnectHandler(mapConnectHandler{}); err != nil {
+			t.Fatalf("RegisterMapGreeterConnectHandler() error = %v", err)
+		}
+
+		output := callMapEcho(t, input)
+		if output.Totals.KeysLen != 3 || output.Totals.ValuesLen != 3 {
+			t.Fatalf("totals halves = (%d, %d), want 3 paired entries", output.Totals.KeysLen, output.Totals.ValuesLen)
+		}
+		if got := arraysToMap[int32, int64](output.Totals, identity[int64]); !maps.Equal(got, wantTotals) {
+			t.Fatalf("totals = %v, want %v", got, wantTotals)
+		}
+		if got := flagsFromOutput(output.Flags); !maps.Equal(got, wantFlags) {
+			t.Fatalf("flags = %v, want %v", got, wantFlags)
+		}
+	})
+
+	t.Run("mismatched key and value counts return error id", func(t *testing.T) {
+		mapv1.ResetMapGreeterServerForIntegrationTest()
+		if err := mapv1.RegisterMapGreeterConnectHandler(mapConnectHandler{}); err != nil {
+			t.Fatalf("RegisterMapGreeterConnectHandler() error = %v", err)
+		}
+
+		mismatched := newMapInput([]int32{1, 2, 3}, []int64{10, 20}, nil, nil)
+		output := &mapOutput{}
+		errID := CallMapGreeterEchoNativeUnary(context.Background(), mismatched, output)
+		if errID == 0 {
+			output.release()
+			t.Fatal("mismatched map halves returned errID 0")
+		}
+		text, _, ok := rpcruntime.TakeErrorText(rpcruntime.ErrorID(errID))
+		if !ok || !strings.Contains(string(text), "map field Totals has mismatched key and value counts") {
+			t.Fatalf("mismatched map error text = %q, ok=%v", text, ok)
+		}
+	})
+}
+`
+
+const mapNativeABIPBGoSource = `// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v7.34.1
+// source: map/v1/map.proto
+
+package mapv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MapRequest struct {
+	state         protoimpl.MessageState ` + "`" + `protogen:"open.v1"` + "`" + `
+	Totals        map[int32]int64        ` + "`" + `protobuf:"bytes,1,rep,name=totals,proto3" json:"totals,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` + "`" + `
+	Flags         map[int64]bool         ` + "`" + `protobuf:"bytes,2,rep,name=flags,proto3" json:"flags,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` + "`" + `
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MapRequest) Reset() {
+	*x = MapRequest{}
+	mi := &file_map_v1_map_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapRequest) ProtoMessage() {}
+
+func (x *MapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_map_v1_map_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapRequest.ProtoReflect.Descriptor instead.
+func (*MapRequest) Descriptor() ([]byte, []int) {
+	return file_map_v1_map_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MapRequest) GetTotals() map[int32]int64 {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+func (x *MapRequest) GetFlags() map[int64]bool {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+type MapReply struct {
+	state         protoimpl.MessageState ` + "`" + `protogen:"open.v1"` + "`" + `
+	Totals        map[int32]int64        ` + "`" + `protobuf:"bytes,1,rep,name=totals,proto3" json:"totals,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` + "`" + `
+	Flags         map[int64]bool         ` + "`" + `protobuf:"bytes,2,rep,name=flags,proto3" json:"flags,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` + "`" + `
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MapReply) Reset() {
+	*x = MapReply{}
+	mi := &file_map_v1_map_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MapReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapReply) ProtoMessage() {}
+
+func (x *MapReply) ProtoReflect() protoreflect.Message {
+	mi := &file_map_v1_map_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapReply.ProtoReflect.Descriptor instead.
+func (*MapReply) Descriptor() ([]byte, []int) {
+	return file_map_v1_map_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MapReply) GetTotals() map[int32]int64 {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+func (x *MapReply) GetFlags() map[int64]bool {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+var File_map_v1_map_proto protoreflect.FileDescriptor
+
+const file_map_v1_map_proto_rawDesc = "" +
+	"\n" +
+	"\x10map/v1/map.proto\x12\n" +
+	"map.abi.v1\"\xf6\x01\n" +
+	"\n" +
+	"MapRequest\x12:\n" +
+	"\x06totals\x18\x01 \x03(\v2\".map.abi.v1.MapRequest.TotalsEntryR\x06totals\x127\n" +
+	"\x05flags\x18\x02 \x03(\v2!.map.abi.v1.MapRequest.FlagsEntryR\x05flags\x1a9\n" +
+	"\vTotalsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x05R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1a8\n" +
+	"\n" +
+	"FlagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\"\xf0\x01\n" +
+	"\bMapReply\x128\n" +
+	"\x06totals\x18\x01 \x03(\v2 .map.abi.v1.MapReply.TotalsEntryR\x06totals\x125\n" +
+	"\x05flags\x18\x02 \x03(\v2\x1f.map.abi.v1.MapReply.FlagsEntryR\x05flags\x1a9\n" +
+	"\vTotalsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x05R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1a8\n" +
+	"\n" +
+	"FlagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01B'Z%example.com/mapnativeabi/map/v1;mapv1b\x06proto3"
+
+var (
+	file_map_v1_map_proto_rawDescOnce sync.Once
+	file_map_v1_map_proto_rawDescData []byte
+)
+
+func file_map_v1_map_proto_rawDescGZIP() []byte {
+	file_map_v1_map_proto_rawDescOnce.Do(func() {
+		file_map_v1_map_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_map_v1_map_proto_rawDesc), len(file_map_v1_map_proto_rawDesc)))
+	})
+	return file_map_v1_map_proto_rawDescData
+}
+
+var file_map_v1_map_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_map_v1_map_proto_goTypes = []any{
+	(*MapRequest)(nil), // 0: map.abi.v1.MapRequest
+	(*MapReply)(nil),   // 1: map.abi.v1.MapReply
+	nil,                // 2: map.abi.v1.MapRequest.TotalsEntry
+	nil,                // 3: map.abi.v1.MapRequest.FlagsEntry
+	nil,                // 4: map.abi.v1.MapReply.TotalsEntry
+	nil,                // 5: map.abi.v1.MapReply.FlagsEntry
+}
+var file_map_v1_map_proto_depIdxs = []int32{
+	2, // 0: map.abi.v1.MapRequest.totals:type_name -> map.abi.v1.MapRequest.TotalsEntry
+	3, // 1: map.abi.v1.MapRequest.flags:type_name -> map.abi.v1.MapRequest.FlagsEntry
+	4, // 2: map.abi.v1.MapReply.totals:type_name -> map.abi.v1.MapReply.TotalsEntry
+	5, // 3: map.abi.v1.MapReply.flags:type_name -> map.abi.v1.MapReply.FlagsEntry
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_map_v1_map_proto_init() }
+func file_map_v1_map_proto_init() {
+	if File_map_v1_map_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_map_v1_map_proto_rawDesc), len(file_map_v1_map_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_map_v1_map_proto_goTypes,
+		DependencyIndexes: file_map_v1_map_proto_depIdxs,
+		MessageInfos:      file_map_v1_map_proto_msgTypes,
+	}.Build()
+	File_map_v1_map_proto = out.File
+	file_map_v1_map_proto_goTypes = nil
+	file_map_v1_map_proto_depIdxs = nil
+}
+`
@@ -109,6 +109,10 @@ func TestMessageDirectConnectHandlerRegistrationRoutesUnaryAndStreaming(t *testi
 	runMessageDirectRegistrationFixture(t, "@rpccgo: msg-connect\n", "TestDirectConnectHandlerRegistration")
 }
 
+func TestMessageDirectConnectHandlerRouteFailsOverAndPinsStreams(t *testing.T) {
+	runMessageDirectRegistrationFixture(t, "@rpccgo: msg-connect\n", "TestDirectConnectHandlerRoute")
+}
+
 func TestMessageDirectGRPCServerRegistrationRoutesUnaryAndStreaming(t *testing.T) {
 	runMessageDirectRegistrationFixture(t, "@rpccgo: msg-grpc\n", "TestDirectGRPCServerRegistration")
 }
@@ -517,18 +521,30 @@ const messageDirectConnectRegistrationTestSource = `package testv1
 
 import (
 	context "context"
+	errors "errors"
 	io "io"
+	strings "strings"
 	testing "testing"
 
 	connect "connectrpc.com/connect"
+	rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 type directConnectGreeter struct{ label string }
 
-var directConnectUploadLabel string
+var (
+	directConnectUploadLabel  string
+	directConnectUnaryLabels []string
+)
 
-func (directConnectGreeter) Unary(context.Context, *emptypb.Empty) (*emptypb.Empty, error) { return &emptypb.Empty{}, nil }
+func (g directConnectGreeter) Unary(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	directConnectUnaryLabels = append(directConnectUnaryLabels, g.label)
+	if g.label == "down" {
+		return nil, connect.NewError(connect.CodeUnavailable, errors.New("server is down"))
+	}
+	return &emptypb.Empty{}, nil
+}
 func (g directConnectGreeter) Upload(ctx context.Context, stream *connect.ClientStream[emptypb.Empty]) (*emptypb.Empty, error) {
 	directConnectUploadLabel = g.label
 	if g.label == "cancel" {
@@ -600,6 +616,54 @@ func TestDirectConnectHandlerRegistration(t *testing.T) {
 		t.Fatalf("chat Finish() error = %v", err)
 	}
 }
+
+func registerDirectConnectRoute(t *testing.T, policy rpcruntime.RoutingPolicy, labels ...string) {
+	t.Helper()
+	ResetGreeterServerForIntegrationTest()
+	var targets []rpcruntime.RouteTarget
+	for _, label := range labels {
+		if err := RegisterGreeterConnectHandler(directConnectGreeter{label: label}); err != nil {
+			t.Fatalf("RegisterGreeterConnectHandler(%s) error = %v", label, err)
+		}
+		registered, err := LoadGreeterRegisteredServer()
+		if err != nil {
+			t.Fatalf("LoadGreeterRegisteredServer(%s) error = %v", label, err)
+		}
+		targets = append(targets, rpcruntime.RouteTarget{Server: registered})
+	}
+	if err := RegisterGreeterServerRoute(rpcruntime.ServerRoute{Policy: policy, Targets: targets}); err != nil {
+		t.Fatalf("RegisterGreeterServerRoute() error = %v", err)
+	}
+	directConnectUnaryLabels = nil
+}
+
+func TestDirectConnectHandlerRoute(t *testing.T) {
+	registerDirectConnectRoute(t, rpcruntime.RoutingFailover, "down", "A")
+	if _, err := InvokeGreeterMessageUnary(context.Background(), &emptypb.Empty{}); err != nil {
+		t.Fatalf("InvokeGreeterMessageUnary() error = %v", err)
+	}
+	if got := strings.Join(directConnectUnaryLabels, ","); got != "down,A" {
+		t.Fatalf("failover unary calls = %q, want down,A", got)
+	}
+
+	registerDirectConnectRoute(t, rpcruntime.RoutingRoundRobin, "A", "B")
+	uploadHandle, err := GreeterMessageUploadStart(context.Background())
+	if err != nil {
+		t.Fatalf("GreeterMessageUploadStart() error = %v", err)
+	}
+	if _, err := InvokeGreeterMessageUnary(context.Background(), &emptypb.Empty{}); err != nil {
+		t.Fatalf("InvokeGreeterMessageUnary() error = %v", err)
+	}
+	if err := GreeterMessageUploadSend(context.Background(), uploadHandle, &emptypb.Empty{}); err != nil {
+		t.Fatalf("upload Send() error = %v", err)
+	}
+	if _, err := GreeterMessageUploadFinish(context.Background(), uploadHandle); err != nil {
+		t.Fatalf("upload Finish() error = %v", err)
+	}
+	if directConnectUploadLabel != "A" || strings.Join(directConnectUnaryLabels, ",") != "B" {
+		t.Fatalf("round robin upload = %q, unary = %v; want upload pinned to A and unary on B", directConnectUploadLabel, directConnectUnaryLabels)
+	}
+}
 `
 
 const messageDirectGRPCRegistrationTestSource = `package testv1
@@ -1370,6 +1434,7 @@ import "C"
 import (
 	context "context"
 	errors "errors"
+	unsafe "unsafe"
 
 	rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"
 )
@@ -1386,7 +1451,7 @@ func callGreeterUnaryMessageUnary(ctx context.Context, requestPtr uintptr, reque
 	}
 	var responsePtr C.uintptr_t
 	var responseLen C.int32_t
-	errID := rpccgoMsgTestv1GreeterUnary(C.uintptr_t(requestPtr), C.int32_t(requestLen), &responsePtr, &responseLen)
+	errID := rpccgoMsgTestv1GreeterUnary(unsafe.Pointer(requestPtr), C.int32_t(requestLen), &responsePtr, &responseLen)
 	output.DataPtr = uintptr(responsePtr)
 	output.DataLen = int32(responseLen)
 	return int32(errID)
@@ -1401,7 +1466,7 @@ func startGreeterUploadMessageClientStream(ctx context.Context) (int32, int32) {
 
 func sendGreeterUploadMessageClientStream(ctx context.Context, handle int32, requestPtr uintptr, requestLen int32) int32 {
 	_ = ctx
-	return int32(rpccgoMsgTestv1GreeterUploadSend(C.int32_t(handle), C.uintptr_t(requestPtr), C.int32_t(requestLen)))
+	return int32(rpccgoMsgTestv1GreeterUploadSend(C.int32_t(handle), unsafe.Pointer(requestPtr), C.int32_t(requestLen)))
 }
 
 func finishGreeterUploadMessageClientStream(ctx context.Context, handle int32, output *greeterMessageOutput) int32 {
@@ -1425,7 +1490,7 @@ func cancelGreeterUploadMessageClientStream(ctx context.Context, handle int32) i
 func startGreeterListMessageServerStream(ctx context.Context, requestPtr uintptr, requestLen int32) (int32, int32) {
 	_ = ctx
 	var handle C.int32_t
-	errID := rpccgoMsgTestv1GreeterListStart(C.uintptr_t(requestPtr), C.int32_t(requestLen), &handle, nil, nil)
+	errID := rpccgoMsgTestv1GreeterListStart(unsafe.Pointer(requestPtr), C.int32_t(requestLen), &handle, nil, nil)
 	return int32(handle), int32(errID)
 }
 
@@ -1461,7 +1526,7 @@ func startGreeterChatMessageBidiStream(ctx context.Context) (int32, int32) {
 
 func sendGreeterChatMessageBidiStream(ctx context.Context, handle int32, requestPtr uintptr, requestLen int32) int32 {
 	_ = ctx
-	return int32(rpccgoMsgTestv1GreeterChatSend(C.int32_t(handle), C.uintptr_t(requestPtr), C.int32_t(requestLen)))
+	return int32(rpccgoMsgTestv1GreeterChatSend(C.int32_t(handle), unsafe.Pointer(requestPtr), C.int32_t(requestLen)))
 }
 
 func readGreeterChatMessageBidiStream(ctx context.Context, handle int32, output *greeterMessageOutput) int32 {
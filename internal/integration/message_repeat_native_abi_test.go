@@ -0,0 +1,559 @@
+package integration
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/ygrpc/rpccgo/internal/generator"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestMessageRepeatNativeABIAcceptance(t *testing.T) {
+	tmp := t.TempDir()
+	plugin := newMessageRepeatNativeABIPlugin(t, "example.com/messagenativeabi/message/v1;messagev1")
+	if _, err := generator.GenerateWithOptions(plugin); err != nil {
+		t.Fatalf("GenerateWithOptions() error = %v", err)
+	}
+
+	writeMessageDirectPathGeneratedModule(t, tmp, plugin, "example.com/messagenativeabi")
+	writeFile(t, filepath.Join(tmp, "message/v1/message.pb.go"), messageRepeatNativeABIPBGoSource)
+	writeFile(t, filepath.Join(tmp, "message/v1/message_connect_stubs.go"), messageRepeatNativeABIConnectStubSource)
+	writeFile(t, filepath.Join(tmp, "message/v1/message_integration_reset.go"), messageRepeatNativeABIResetSource)
+	writeFile(t, filepath.Join(tmp, "message/v1/cgo/message_native_cgo_client_bridge.go"), messageRepeatNativeABICGOClientBridgeSource)
+	writeFile(t, filepath.Join(tmp, "message/v1/cgo/message_native_abi_test.go"), messageRepeatNativeABIFixtureTestSource)
+
+	cmd := exec.Command("go", "test", "./message/v1/cgo", "-run", "^TestMessageRepeatNativeABI$", "-count=1")
+	cmd.Dir = tmp
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("message repeat native ABI fixture failed: %v\n%s", err, out)
+	}
+}
+
+func newMessageRepeatNativeABIPlugin(t *testing.T, goPackage string) *protogen.Plugin {
+	t.Helper()
+	itemsMessage := func(name string) *descriptorpb.DescriptorProto {
+		return &descriptorpb.DescriptorProto{
+			Name: proto.String(name),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				fieldDescriptor("items", 1, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, ".message.abi.v1.Item"),
+			},
+		}
+	}
+	request := &pluginpb.CodeGeneratorRequest{
+		Parameter:      proto.String("paths=source_relative"),
+		FileToGenerate: []string{"message/v1/message.proto"},
+		ProtoFile: []*descriptorpb.FileDescriptorProto{{
+			Name:    proto.String("message/v1/message.proto"),
+			Package: proto.String("message.abi.v1"),
+			Syntax:  proto.String("proto3"),
+			Options: &descriptorpb.FileOptions{
+				GoPackage: proto.String(goPackage),
+			},
+			MessageType: []*descriptorpb.DescriptorProto{
+				{
+					Name: proto.String("Item"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						fieldDescriptor("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+						fieldDescriptor("weight", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+					},
+				},
+				itemsMessage("MessageRequest"),
+				itemsMessage("MessageReply"),
+			},
+			Service: []*descriptorpb.ServiceDescriptorProto{{
+				Name: proto.String("MessageGreeter"),
+				Method: []*descriptorpb.MethodDescriptorProto{{
+					Name:       proto.String("Echo"),
+					InputType:  proto.String(".message.abi.v1.MessageRequest"),
+					OutputType: proto.String(".message.abi.v1.MessageReply"),
+				}},
+			}},
+			SourceCodeInfo: &descriptorpb.SourceCodeInfo{Location: []*descriptorpb.SourceCodeInfo_Location{{
+				Path:            []int32{6, 0},
+				Span:            []int32{0, 0, 0},
+				LeadingComments: proto.String("@rpccgo: msg-connect|native\n"),
+			}}},
+		}},
+	}
+	plugin, err := generator.ProtogenOptions().New(request)
+	if err != nil {
+		t.Fatalf("protogen.Options.New() error = %v", err)
+	}
+	return plugin
+}
+
+const messageRepeatNativeABIConnectStubSource = `package messagev1
+
+import context "context"
+
+type MessageGreeterHandler interface {
+	Echo(context.Context, *MessageRequest) (*MessageReply, error)
+}
+
+type MessageGreeterClient interface {
+	Echo(context.Context, *MessageRequest) (*MessageReply, error)
+}
+
+type MessageGreeterServer interface {
+	Echo(context.Context, *MessageRequest) (*MessageReply, error)
+}
+`
+
+const messageRepeatNativeABIResetSource = `package messagev1
+
+import rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"
+
+func ResetMessageGreeterServerForIntegrationTest() {
+	_ = ClearMessageGreeterServer()
+	rpcruntime.ResetStreamSessionsForTesting()
+}
+`
+
+const messageRepeatNativeABICGOClientBridgeSource = `package main
+
+/*
+#include <stdint.h>
+*/
+import "C"
+
+import context "context"
+import unsafe "unsafe"
+
+func CallMessageGreeterEchoNativeUnary(ctx context.Context, input *itemsArray, output *itemsArray) int32 {
+	var outPtr C.uintptr_t
+	var outLen C.int32_t
+	var outOwnership C.int32_t
+	errID := rpccgoNativeMessagev1MessageGreeterEcho(
+		unsafe.Pointer(input.Ptr), C.int32_t(input.Len), C.int32_t(input.Ownership),
+		&outPtr, &outLen, &outOwnership,
+	)
+	*output = itemsArray{Ptr: uintptr(outPtr), Len: int32(outLen), Ownership: int32(outOwnership)}
+	return int32(errID)
+}
+`
+
+const messageRepeatNativeABIFixtureTestSource = `package main
+
+import (
+	context "context"
+	maps "maps"
+	strings "strings"
+	sync "sync"
+	testing "testing"
+	unsafe "unsafe"
+
+	messagev1 "example.com/messagenativeabi/message/v1"
+	rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"
+	proto "google.golang.org/protobuf/proto"
+)
+
+type messageGoNativeServer struct{}
+
+func (s *messageGoNativeServer) Echo(ctx context.Context, items *rpcruntime.RpcMessageRepeat[*messagev1.Item]) ([][]byte, error) {
+	last, err := items.At(items.Len() - 1)
+	if err != nil {
+		return nil, err
+	}
+	if last.GetName() != "gamma" {
+		return nil, rpcruntime.NewStatusError(rpcruntime.ErrorCodeInvalidArgument, "unexpected last item "+last.GetName())
+	}
+	decoded, err := items.SafeSlice()
+	if err != nil {
+		return nil, err
+	}
+	reply := make([]*messagev1.Item, len(decoded))
+	for i, item := range decoded {
+		reply[i] = doubledItem(item)
+	}
+	return rpcruntime.MarshalMessages(reply)
+}
+
+type messageConnectHandler struct{}
+
+func (messageConnectHandler) Echo(ctx context.Context, req *messagev1.MessageRequest) (*messagev1.MessageReply, error) {
+	reply := &messagev1.MessageReply{}
+	for _, item := range req.GetItems() {
+		reply.Items = append(reply.Items, doubledItem(item))
+	}
+	return reply, nil
+}
+
+func doubledItem(item *messagev1.Item) *messagev1.Item {
+	return &messagev1.Item{Name: strings.ToUpper(item.GetName()), Weight: item.GetWeight() * 2}
+}
+
+type itemsArray struct {
+	Ptr       uintptr
+	Len       int32
+	Ownership int32
+}
+
+type itemsInput struct {
+	itemsArray
+	// elems keeps the Go array behind the borrowed pointer reachable.
+	elems []rpcruntime.RpcBuffer
+}
+
+func newItemsInput(t *testing.T, encoded [][]byte, ownership int32) *itemsInput {
+	t.Helper()
+	in := &itemsInput{elems: make([]rpcruntime.RpcBuffer, len(encoded))}
+	for i, data := range encoded {
+		in.elems[i] = rpcruntime.BytesBuffer(data)
+		in.elems[i].Ownership = ownership
+	}
+	if len(in.elems) > 0 {
+		in.itemsArray = itemsArray{Ptr: uintptr(unsafe.Pointer(&in.elems[0])), Len: int32(len(in.elems)), Ownership: ownership}
+	}
+	return in
+}
+
+func encodeItems(t *testing.T, items ...*messagev1.Item) [][]byte {
+	t.Helper()
+	encoded, err := rpcruntime.MarshalMessages(items)
+	if err != nil {
+		t.Fatalf("MarshalMessages() error = %v", err)
+	}
+	return encoded
+}
+
+func callMessageEcho(t *testing.T, input *itemsInput) []*messagev1.Item {
+	t.Helper()
+	var output itemsArray
+	if errID := CallMessageGreeterEchoNativeUnary(context.Background(), &input.itemsArray, &output); errID != 0 {
+		text, _, _ := rpcruntime.TakeErrorText(rpcruntime.ErrorID(errID))
+		t.Fatalf("CallMessageGreeterEchoNativeUnary() errID = %d: %s", errID, text)
+	}
+	defer rpcruntime.Release(output.Ptr)
+	encoded, err := rpcruntime.CopyBytesArray((*rpcruntime.RpcBuffer)(unsafe.Pointer(output.Ptr)), output.Len)
+	if err != nil {
+		t.Fatalf("CopyBytesArray() error = %v", err)
+	}
+	items, err := rpcruntime.UnmarshalMessages[*messagev1.Item](encoded)
+	if err != nil {
+		t.Fatalf("UnmarshalMessages() error = %v", err)
+	}
+	return items
+}
+
+func assertDoubledItems(t *testing.T, got []*messagev1.Item) {
+	t.Helper()
+	want := []*messagev1.Item{{Name: "ALPHA", Weight: 2}, {}, {Name: "GAMMA", Weight: -6}}
+	if len(got) != len(want) {
+		t.Fatalf("items = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Fatalf("items[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMessageRepeatNativeABI(t *testing.T) {
+	encoded := encodeItems(t, &messagev1.Item{Name: "alpha", Weight: 1}, &messagev1.Item{}, &messagev1.Item{Name: "gamma", Weight: -3})
+
+	t.Run("native client routes encoded items to go native server", func(t *testing.T) {
+		messagev1.ResetMessageGreeterServerForIntegrationTest()
+		if err := messagev1.RegisterMessageGreeterGoNativeServer(&messageGoNativeServer{}); err != nil {
+			t.Fatalf("RegisterMessageGreeterGoNativeServer() error = %v", err)
+		}
+
+		assertDoubledItems(t, callMessageEcho(t, newItemsInput(t, encoded, 0)))
+	})
+
+	t.Run("codec converts encoded items to and from message fields", func(t *testing.T) {
+		messagev1.ResetMessageGreeterServerForIntegrationTest()
+		if err := messagev1.RegisterMessageGreeterConnectHandler(messageConnectHandler{}); err != nil {
+			t.Fatalf("RegisterMessageGreeterConnectHandler() error = %v", err)
+		}
+
+		assertDoubledItems(t, callMessageEcho(t, newItemsInput(t, encoded, 0)))
+	})
+
+	t.Run("invalid encoded item returns error id with element index", func(t *testing.T) {
+		messagev1.ResetMessageGreeterServerForIntegrationTest()
+		if err := messagev1.RegisterMessageGreeterConnectHandler(messageConnectHandler{}); err != nil {
+			t.Fatalf("RegisterMessageGreeterConnectHandler() error = %v", err)
+		}
+
+		invalid := newItemsInput(t, [][]byte{encoded[0], {0x0a, 0x05}}, 0)
+		var output itemsArray
+		errID := CallMessageGreeterEchoNativeUnary(context.Background(), &invalid.itemsArray, &output)
+		if errID == 0 {
+			rpcruntime.Release(output.Ptr)
+			t.Fatal("invalid encoded item returned errID 0")
+		}
+		text, _, ok := rpcruntime.TakeErrorText(rpcruntime.ErrorID(errID))
+		if !ok || !strings.Contains(string(text), "message.abi.v1.MessageRequest.items: element 1") {
+			t.Fatalf("invalid item error text = %q, ok=%v", text, ok)
+		}
+	})
+
+	t.Run("owned items and array are freed once per call", func(t *testing.T) {
+		rpcruntime.ResetFreeCallbackForTesting()
+		t.Cleanup(rpcruntime.ResetFreeCallbackForTesting)
+		var mu sync.Mutex
+		freed := map[uintptr]int{}
+		rpcruntime.RegisterFreeCallback(func(ptr unsafe.Pointer) {
+			mu.Lock()
+			defer mu.Unlock()
+			freed[uintptr(ptr)]++
+		})
+		messagev1.ResetMessageGreeterServerForIntegrationTest()
+		if err := messagev1.RegisterMessageGreeterGoNativeServer(&messageGoNativeServer{}); err != nil {
+			t.Fatalf("RegisterMessageGreeterGoNativeServer() error = %v", err)
+		}
+
+		owned := newItemsInput(t, encoded, 1)
+		assertDoubledItems(t, callMessageEcho(t, owned))
+
+		want := map[uintptr]int{uintptr(unsafe.Pointer(&owned.elems[0])): 1}
+		for _, elem := range owned.elems {
+			if elem.Ptr != nil {
+				want[uintptr(elem.Ptr)] = 1
+			}
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if !maps.Equal(freed, want) {
+			t.Fatalf("freed = %v, want each owned item and the array once: %v", freed, want)
+		}
+	})
+}
+`
+
+const messageRepeatNativeABIPBGoSource = `// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v7.34.1
+// source: message/v1/message.proto
+
+package messagev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Item struct {
+	state         protoimpl.MessageState ` + "`" + `protogen:"open.v1"` + "`" + `
+	Name          string                 ` + "`" + `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` + "`" + `
+	Weight        int32                  ` + "`" + `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"` + "`" + `
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Item) Reset() {
+	*x = Item{}
+	mi := &file_message_v1_message_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Item) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Item) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type MessageRequest struct {
+	state         protoimpl.MessageState ` + "`" + `protogen:"open.v1"` + "`" + `
+	Items         []*Item                ` + "`" + `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"` + "`" + `
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageRequest) Reset() {
+	*x = MessageRequest{}
+	mi := &file_message_v1_message_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageRequest) ProtoMessage() {}
+
+func (x *MessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageRequest.ProtoReflect.Descriptor instead.
+func (*MessageRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MessageRequest) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type MessageReply struct {
+	state         protoimpl.MessageState ` + "`" + `protogen:"open.v1"` + "`" + `
+	Items         []*Item                ` + "`" + `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"` + "`" + `
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageReply) Reset() {
+	*x = MessageReply{}
+	mi := &file_message_v1_message_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageReply) ProtoMessage() {}
+
+func (x *MessageReply) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageReply.ProtoReflect.Descriptor instead.
+func (*MessageReply) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MessageReply) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+var File_message_v1_message_proto protoreflect.FileDescriptor
+
+const file_message_v1_message_proto_rawDesc = "" +
+	"\n" +
+	"\x18message/v1/message.proto\x12\x0emessage.abi.v1\"2\n" +
+	"\x04Item\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\x05R\x06weight\"<\n" +
+	"\x0eMessageRequest\x12*\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.message.abi.v1.ItemR\x05items\":\n" +
+	"\fMessageReply\x12*\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.message.abi.v1.ItemR\x05itemsB3Z1example.com/messagenativeabi/message/v1;messagev1b\x06proto3"
+
+var (
+	file_message_v1_message_proto_rawDescOnce sync.Once
+	file_message_v1_message_proto_rawDescData []byte
+)
+
+func file_message_v1_message_proto_rawDescGZIP() []byte {
+	file_message_v1_message_proto_rawDescOnce.Do(func() {
+		file_message_v1_message_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_message_v1_message_proto_rawDesc), len(file_message_v1_message_proto_rawDesc)))
+	})
+	return file_message_v1_message_proto_rawDescData
+}
+
+var file_message_v1_message_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_message_v1_message_proto_goTypes = []any{
+	(*Item)(nil),           // 0: message.abi.v1.Item
+	(*MessageRequest)(nil), // 1: message.abi.v1.MessageRequest
+	(*MessageReply)(nil),   // 2: message.abi.v1.MessageReply
+}
+var file_message_v1_message_proto_depIdxs = []int32{
+	0, // 0: message.abi.v1.MessageRequest.items:type_name -> message.abi.v1.Item
+	0, // 1: message.abi.v1.MessageReply.items:type_name -> message.abi.v1.Item
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_message_v1_message_proto_init() }
+func file_message_v1_message_proto_init() {
+	if File_message_v1_message_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_v1_message_proto_rawDesc), len(file_message_v1_message_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_message_v1_message_proto_goTypes,
+		DependencyIndexes: file_message_v1_message_proto_depIdxs,
+		MessageInfos:      file_message_v1_message_proto_msgTypes,
+	}.Build()
+	File_message_v1_message_proto = out.File
+	file_message_v1_message_proto_goTypes = nil
+	file_message_v1_message_proto_depIdxs = nil
+}
+`
@@ -186,6 +186,7 @@ const nativeBidiStreamingCGOClientBridgeSource = `package main
 import "C"
 
 import context "context"
+import unsafe "unsafe"
 
 func GreeterChatNativeBidiStreamStart(ctx context.Context) (int32, int32) {
 	var stream C.int32_t
@@ -194,7 +195,7 @@ func GreeterChatNativeBidiStreamStart(ctx context.Context) (int32, int32) {
 }
 
 func GreeterChatNativeBidiStreamSend(ctx context.Context, stream int32, NamePtr uintptr, NameLen int32, NameOwnership int32, Seq int32) int32 {
-	return int32(rpccgoNativeTestv1GreeterChatSend(C.int32_t(stream), C.uintptr_t(NamePtr), C.int32_t(NameLen), C.int32_t(NameOwnership), C.int32_t(Seq)))
+	return int32(rpccgoNativeTestv1GreeterChatSend(C.int32_t(stream), unsafe.Pointer(NamePtr), C.int32_t(NameLen), C.int32_t(NameOwnership), C.int32_t(Seq)))
 }
 
 func GreeterChatNativeBidiStreamRecv(ctx context.Context, stream int32, outAck *int32, outMessagePtr *uintptr, outMessageLen *int32) int32 {
@@ -317,6 +317,34 @@ func TestNativeCGOServerUnaryRegistrationOverridesGoServer(t *testing.T) {
 	assertUnaryPayload(t, "go-server")
 }
 
+func TestNativeCGOServerUnaryCallbackSeesTraceParent(t *testing.T) {
+	v1.ResetGreeterServerForIntegrationTest()
+	rpcruntime.ResetFreeCallbackForTesting()
+	t.Cleanup(rpcruntime.ResetFreeCallbackForTesting)
+	registerCFreeCallback()
+	if err := registerGreeterCGONativeServerTraceParentCallback(); err != nil {
+		t.Fatalf("registerGreeterCGONativeServerTraceParentCallback() error = %v", err)
+	}
+	assertUnaryPayload(t, "")
+
+	recorder := rpcruntime.NewSpanRecorder()
+	rpcruntime.SetTracer(recorder)
+	t.Cleanup(func() { rpcruntime.SetTracer(nil) })
+	output := &sayHelloOutput{}
+	if errID := callSayHello(context.Background(), &sayHelloInput{}, output); errID != 0 {
+		t.Fatalf("CallGreeterSayHelloNativeUnary() errID = %d", errID)
+	}
+	got := string(unsafe.Slice((*byte)(unsafe.Pointer(output.PayloadPtr)), output.PayloadLen))
+	rpcruntime.Release(output.PayloadPtr)
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+	if want := spans[0].SpanContext.TraceParent(); got != want {
+		t.Fatalf("callback traceparent = %q, want %q", got, want)
+	}
+}
+
 func assertUnaryPayload(t *testing.T, want string) {
 	t.Helper()
 	name := []byte("native")
@@ -349,6 +377,7 @@ const nativeCGOServerUnaryFixtureCallbackSource = `package main
 #include <stdlib.h>
 
 extern int32_t rpccgoStoreErrorText(char* text, int32_t textLen);
+extern int32_t rpccgoCallbackTraceParent(char* buf, int32_t bufLen, int32_t* traceParentLen);
 
 typedef int32_t (*GreeterSayHelloCGONativeUnaryCallback)(uintptr_t NamePtr, int32_t NameLen, int32_t NameOwnership, uintptr_t PayloadPtr, int32_t PayloadLen, int32_t PayloadOwnership, int8_t Enabled, int8_t* outAccepted, uintptr_t* outPayloadPtr, int32_t* outPayloadLen, int32_t* outPayloadOwnership, uintptr_t* outNotePtr, int32_t* outNoteLen, int32_t* outNoteOwnership, uintptr_t* outExtraPayloadPtr, int32_t* outExtraPayloadLen, int32_t* outExtraPayloadOwnership);
 typedef int32_t (*GreeterSayUnsupportedCGONativeUnaryCallback)(uintptr_t NamePtr, int32_t NameLen, int32_t NameOwnership, uintptr_t PayloadPtr, int32_t PayloadLen, int32_t PayloadOwnership, int8_t Enabled, uintptr_t* outPayloadPtr, int32_t* outPayloadLen, int32_t* outPayloadOwnership, uintptr_t* outNotePtr, int32_t* outNoteLen, int32_t* outNoteOwnership, uintptr_t* outUnsupportedPtr, int32_t* outUnsupportedLen, int32_t* outUnsupportedOwnership);
@@ -418,6 +447,25 @@ static int32_t greeterPartialErrorCallback(uintptr_t NamePtr, int32_t NameLen, i
 	return rpccgoStoreErrorText(msg, sizeof(msg)-1);
 }
 
+static int32_t greeterTraceParentCallback(uintptr_t NamePtr, int32_t NameLen, int32_t NameOwnership, uintptr_t PayloadPtr, int32_t PayloadLen, int32_t PayloadOwnership, int8_t Enabled, int8_t* outAccepted, uintptr_t* outPayloadPtr, int32_t* outPayloadLen, int32_t* outPayloadOwnership, uintptr_t* outNotePtr, int32_t* outNoteLen, int32_t* outNoteOwnership, uintptr_t* outExtraPayloadPtr, int32_t* outExtraPayloadLen, int32_t* outExtraPayloadOwnership) {
+	char* resp = (char*)malloc(64);
+	if (resp == NULL) {
+		char msg[] = "callback malloc failed";
+		return rpccgoStoreErrorText(msg, sizeof(msg)-1);
+	}
+	int32_t traceParentLen = 0;
+	if (rpccgoCallbackTraceParent(resp, 64, &traceParentLen) != 0) {
+		free(resp);
+		char msg[] = "traceparent lookup failed";
+		return rpccgoStoreErrorText(msg, sizeof(msg)-1);
+	}
+	*outAccepted = 1;
+	*outPayloadPtr = (uintptr_t)resp;
+	*outPayloadLen = traceParentLen;
+	*outPayloadOwnership = 1;
+	return 0;
+}
+
 static GreeterCGONativeServerCallbacks greeterCallbacks(void) {
 	GreeterCGONativeServerCallbacks callbacks;
 	callbacks.SayHello = greeterSayHelloCallback;
@@ -447,6 +495,10 @@ static GreeterCGONativeServerCallbacks greeterNegativeLengthCallbacks(void) {
 static GreeterCGONativeServerCallbacks greeterPartialErrorCallbacks(void) {
 	return greeterCallbacksWithSayHello(greeterPartialErrorCallback);
 }
+
+static GreeterCGONativeServerCallbacks greeterTraceParentCallbacks(void) {
+	return greeterCallbacksWithSayHello(greeterTraceParentCallback);
+}
 */
 import "C"
 
@@ -499,6 +551,11 @@ func registerGreeterCGONativeServerPartialErrorCallback() error {
 	return registerGreeterCGONativeServerCallbacksTable(callbacks)
 }
 
+func registerGreeterCGONativeServerTraceParentCallback() error {
+	callbacks := C.greeterTraceParentCallbacks()
+	return registerGreeterCGONativeServerCallbacksTable(callbacks)
+}
+
 func registerGreeterCGONativeServerCallbacksTable(callbacks C.GreeterCGONativeServerCallbacks) error {
 	return nativeCGORegistrationError(rpccgoNativeTestv1GreeterRegister(callbacks.SayHello, callbacks.SayUnsupported))
 }
@@ -185,6 +185,7 @@ const nativeClientStreamingCGOClientBridgeSource = `package main
 import "C"
 
 import context "context"
+import unsafe "unsafe"
 
 func GreeterUploadNativeClientStreamStart(ctx context.Context) (int32, int32) {
 	var stream C.int32_t
@@ -193,7 +194,7 @@ func GreeterUploadNativeClientStreamStart(ctx context.Context) (int32, int32) {
 }
 
 func GreeterUploadNativeClientStreamSend(ctx context.Context, stream int32, NamePtr uintptr, NameLen int32, NameOwnership int32, PayloadPtr uintptr, PayloadLen int32, PayloadOwnership int32) int32 {
-	return int32(rpccgoNativeTestv1GreeterUploadSend(C.int32_t(stream), C.uintptr_t(NamePtr), C.int32_t(NameLen), C.int32_t(NameOwnership), C.uintptr_t(PayloadPtr), C.int32_t(PayloadLen), C.int32_t(PayloadOwnership)))
+	return int32(rpccgoNativeTestv1GreeterUploadSend(C.int32_t(stream), unsafe.Pointer(NamePtr), C.int32_t(NameLen), C.int32_t(NameOwnership), unsafe.Pointer(PayloadPtr), C.int32_t(PayloadLen), C.int32_t(PayloadOwnership)))
 }
 
 func GreeterUploadNativeClientStreamFinish(ctx context.Context, stream int32, outCount *int32, outSummaryPtr *uintptr, outSummaryLen *int32) int32 {
@@ -184,10 +184,11 @@ const nativeServerStreamingCGOClientBridgeSource = `package main
 import "C"
 
 import context "context"
+import unsafe "unsafe"
 
 func GreeterListNativeServerStreamStart(ctx context.Context, PrefixPtr uintptr, PrefixLen int32, PrefixOwnership int32, Limit int32) (int32, int32) {
 	var stream C.int32_t
-	errID := rpccgoNativeTestv1GreeterListStart(C.uintptr_t(PrefixPtr), C.int32_t(PrefixLen), C.int32_t(PrefixOwnership), C.int32_t(Limit), &stream, nil, nil)
+	errID := rpccgoNativeTestv1GreeterListStart(unsafe.Pointer(PrefixPtr), C.int32_t(PrefixLen), C.int32_t(PrefixOwnership), C.int32_t(Limit), &stream, nil, nil)
 	return int32(stream), int32(errID)
 }
 
@@ -216,6 +216,7 @@ const nativeUnaryCGOClientBridgeSource = `package main
 import "C"
 
 import context "context"
+import unsafe "unsafe"
 
 func CallGreeterSayHelloNativeUnary(ctx context.Context, NamePtr uintptr, NameLen int32, NameOwnership int32, PayloadPtr uintptr, PayloadLen int32, PayloadOwnership int32, Enabled int8, outAccepted *int8, outPayloadPtr *uintptr, outPayloadLen *int32, outNotePtr *uintptr, outNoteLen *int32, outExtraPayloadPtr *uintptr, outExtraPayloadLen *int32) int32 {
 	var accepted C.int8_t
@@ -228,7 +229,7 @@ func CallGreeterSayHelloNativeUnary(ctx context.Context, NamePtr uintptr, NameLe
 	var extraPayloadPtr C.uintptr_t
 	var extraPayloadLen C.int32_t
 	var extraPayloadOwnership C.int32_t
-	errID := rpccgoNativeTestv1GreeterSayHello(C.uintptr_t(NamePtr), C.int32_t(NameLen), C.int32_t(NameOwnership), C.uintptr_t(PayloadPtr), C.int32_t(PayloadLen), C.int32_t(PayloadOwnership), C.int8_t(Enabled), &accepted, &payloadPtr, &payloadLen, &payloadOwnership, &notePtr, &noteLen, &noteOwnership, &extraPayloadPtr, &extraPayloadLen, &extraPayloadOwnership)
+	errID := rpccgoNativeTestv1GreeterSayHello(unsafe.Pointer(NamePtr), C.int32_t(NameLen), C.int32_t(NameOwnership), unsafe.Pointer(PayloadPtr), C.int32_t(PayloadLen), C.int32_t(PayloadOwnership), C.int8_t(Enabled), &accepted, &payloadPtr, &payloadLen, &payloadOwnership, &notePtr, &noteLen, &noteOwnership, &extraPayloadPtr, &extraPayloadLen, &extraPayloadOwnership)
 	*outAccepted = int8(accepted)
 	*outPayloadPtr = uintptr(payloadPtr)
 	*outPayloadLen = int32(payloadLen)
@@ -249,7 +250,7 @@ func CallGreeterSayUnsupportedNativeUnary(ctx context.Context, NamePtr uintptr,
 	var unsupportedPtr C.uintptr_t
 	var unsupportedLen C.int32_t
 	var unsupportedOwnership C.int32_t
-	errID := rpccgoNativeTestv1GreeterSayUnsupported(C.uintptr_t(NamePtr), C.int32_t(NameLen), C.int32_t(NameOwnership), C.uintptr_t(PayloadPtr), C.int32_t(PayloadLen), C.int32_t(PayloadOwnership), C.int8_t(Enabled), &payloadPtr, &payloadLen, &payloadOwnership, &notePtr, &noteLen, &noteOwnership, &unsupportedPtr, &unsupportedLen, &unsupportedOwnership)
+	errID := rpccgoNativeTestv1GreeterSayUnsupported(unsafe.Pointer(NamePtr), C.int32_t(NameLen), C.int32_t(NameOwnership), unsafe.Pointer(PayloadPtr), C.int32_t(PayloadLen), C.int32_t(PayloadOwnership), C.int8_t(Enabled), &payloadPtr, &payloadLen, &payloadOwnership, &notePtr, &noteLen, &noteOwnership, &unsupportedPtr, &unsupportedLen, &unsupportedOwnership)
 	*outPayloadPtr = uintptr(payloadPtr)
 	*outPayloadLen = int32(payloadLen)
 	*outNotePtr = uintptr(notePtr)
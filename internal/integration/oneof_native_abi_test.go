@@ -0,0 +1,767 @@
+package integration
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/ygrpc/rpccgo/internal/generator"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestOneofNativeABIAcceptance(t *testing.T) {
+	tmp := t.TempDir()
+	plugin := newOneofNativeABIPlugin(t, "example.com/oneofnativeabi/oneof/v1;oneofv1")
+	if _, err := generator.GenerateWithOptions(plugin); err != nil {
+		t.Fatalf("GenerateWithOptions() error = %v", err)
+	}
+
+	writeMessageDirectPathGeneratedModule(t, tmp, plugin, "example.com/oneofnativeabi")
+	writeFile(t, filepath.Join(tmp, "oneof/v1/oneof.pb.go"), oneofNativeABIPBGoSource)
+	writeFile(t, filepath.Join(tmp, "oneof/v1/oneof_connect_stubs.go"), oneofNativeABIConnectStubSource)
+	writeFile(t, filepath.Join(tmp, "oneof/v1/oneof_integration_reset.go"), oneofNativeABIResetSource)
+	writeFile(t, filepath.Join(tmp, "oneof/v1/cgo/oneof_native_cgo_client_bridge.go"), oneofNativeABICGOClientBridgeSource)
+	writeFile(t, filepath.Join(tmp, "oneof/v1/cgo/oneof_native_abi_test.go"), oneofNativeABIFixtureTestSource)
+
+	cmd := exec.Command("go", "test", "./oneof/v1/cgo", "-run", "^TestOneofNativeABI$", "-count=1")
+	cmd.Dir = tmp
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("oneof native ABI fixture failed: %v\n%s", err, out)
+	}
+}
+
+func newOneofNativeABIPlugin(t *testing.T, goPackage string) *protogen.Plugin {
+	t.Helper()
+	choiceMessage := func(name string) *descriptorpb.DescriptorProto {
+		member := func(name string, number int32, fieldType descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+			field := fieldDescriptor(name, number, fieldType, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, typeName)
+			field.OneofIndex = proto.Int32(0)
+			return field
+		}
+		return &descriptorpb.DescriptorProto{
+			Name: proto.String(name),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				fieldDescriptor("note", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+				member("text", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+				member("number", 3, descriptorpb.FieldDescriptorProto_TYPE_INT64, ""),
+				member("flag", 4, descriptorpb.FieldDescriptorProto_TYPE_BOOL, ""),
+				member("blob", 5, descriptorpb.FieldDescriptorProto_TYPE_BYTES, ""),
+				member("child", 6, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".oneof.abi.v1.Child"),
+			},
+			OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("choice")}},
+		}
+	}
+	request := &pluginpb.CodeGeneratorRequest{
+		Parameter:      proto.String("paths=source_relative"),
+		FileToGenerate: []string{"oneof/v1/oneof.proto"},
+		ProtoFile: []*descriptorpb.FileDescriptorProto{{
+			Name:    proto.String("oneof/v1/oneof.proto"),
+			Package: proto.String("oneof.abi.v1"),
+			Syntax:  proto.String("proto3"),
+			Options: &descriptorpb.FileOptions{
+				GoPackage: proto.String(goPackage),
+			},
+			MessageType: []*descriptorpb.DescriptorProto{
+				choiceMessage("OneofRequest"),
+				choiceMessage("OneofReply"),
+				{
+					Name: proto.String("Child"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						fieldDescriptor("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+					},
+				},
+			},
+			Service: []*descriptorpb.ServiceDescriptorProto{{
+				Name: proto.String("OneofGreeter"),
+				Method: []*descriptorpb.MethodDescriptorProto{{
+					Name:       proto.String("Echo"),
+					InputType:  proto.String(".oneof.abi.v1.OneofRequest"),
+					OutputType: proto.String(".oneof.abi.v1.OneofReply"),
+				}},
+			}},
+			SourceCodeInfo: &descriptorpb.SourceCodeInfo{Location: []*descriptorpb.SourceCodeInfo_Location{{
+				Path:            []int32{6, 0},
+				Span:            []int32{0, 0, 0},
+				LeadingComments: proto.String("@rpccgo: msg-connect|native\n"),
+			}}},
+		}},
+	}
+	plugin, err := generator.ProtogenOptions().New(request)
+	if err != nil {
+		t.Fatalf("protogen.Options.New() error = %v", err)
+	}
+	return plugin
+}
+
+const oneofNativeABIConnectStubSource = `package oneofv1
+
+import context "context"
+
+type OneofGreeterHandler interface {
+	Echo(context.Context, *OneofRequest) (*OneofReply, error)
+}
+
+type OneofGreeterClient interface {
+	Echo(context.Context, *OneofRequest) (*OneofReply, error)
+}
+
+type OneofGreeterServer interface {
+	Echo(context.Context, *OneofRequest) (*OneofReply, error)
+}
+`
+
+const oneofNativeABIResetSource = `package oneofv1
+
+import rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"
+
+func ResetOneofGreeterServerForIntegrationTest() {
+	_ = ClearOneofGreeterServer()
+	rpcruntime.ResetStreamSessionsForTesting()
+}
+`
+
+const oneofNativeABICGOClientBridgeSource = `package main
+
+/*
+#include <stdint.h>
+*/
+import "C"
+
+import (
+	context "context"
+	goruntime "runtime"
+	unsafe "unsafe"
+
+	rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"
+)
+
+func CallOneofGreeterEchoNativeUnary(ctx context.Context, input choiceValues) (choiceValues, int32) {
+	var flag C.int8_t
+	if input.Flag {
+		flag = 1
+	}
+	var outNotePtr, outTextPtr, outBlobPtr, outChildPtr C.uintptr_t
+	var outNoteLen, outNoteOwnership, outTextLen, outTextOwnership, outBlobLen, outBlobOwnership, outChildLen, outChildOwnership C.int32_t
+	var outCase C.int32_t
+	var outNumber C.int64_t
+	var outFlag C.int8_t
+	errID := rpccgoNativeOneofv1OneofGreeterEcho(
+		unsafe.Pointer(unsafe.StringData(input.Note)), C.int32_t(len(input.Note)), 0,
+		C.int32_t(input.Case),
+		unsafe.Pointer(unsafe.StringData(input.Text)), C.int32_t(len(input.Text)), 0,
+		C.int64_t(input.Number),
+		flag,
+		unsafe.Pointer(unsafe.SliceData(input.Blob)), C.int32_t(len(input.Blob)), 0,
+		unsafe.Pointer(unsafe.SliceData(input.Child)), C.int32_t(len(input.Child)), 0,
+		&outNotePtr, &outNoteLen, &outNoteOwnership,
+		&outCase,
+		&outTextPtr, &outTextLen, &outTextOwnership,
+		&outNumber,
+		&outFlag,
+		&outBlobPtr, &outBlobLen, &outBlobOwnership,
+		&outChildPtr, &outChildLen, &outChildOwnership,
+	)
+	goruntime.KeepAlive(input)
+	if errID != 0 {
+		return choiceValues{}, int32(errID)
+	}
+	output := choiceValues{
+		Note:   takeOutputString(uintptr(outNotePtr), int32(outNoteLen)),
+		Case:   int32(outCase),
+		Text:   takeOutputString(uintptr(outTextPtr), int32(outTextLen)),
+		Number: int64(outNumber),
+		Flag:   outFlag != 0,
+		Blob:   []byte(takeOutputString(uintptr(outBlobPtr), int32(outBlobLen))),
+		Child:  []byte(takeOutputString(uintptr(outChildPtr), int32(outChildLen))),
+	}
+	return output, 0
+}
+
+func takeOutputString(ptr uintptr, length int32) string {
+	if ptr == 0 {
+		return ""
+	}
+	defer rpcruntime.Release(ptr)
+	return string(unsafe.Slice((*byte)(unsafe.Pointer(ptr)), length))
+}
+`
+
+const oneofNativeABIFixtureTestSource = `package main
+
+import (
+	bytes "bytes"
+	context "context"
+	strings "strings"
+	testing "testing"
+
+	oneofv1 "example.com/oneofnativeabi/oneof/v1"
+	rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"
+	proto "google.golang.org/protobuf/proto"
+)
+
+// choiceValues mirrors the native ABI slots of OneofRequest and OneofReply.
+type choiceValues struct {
+	Note   string
+	Case   int32
+	Text   string
+	Number int64
+	Flag   bool
+	Blob   []byte
+	Child  []byte
+}
+
+type oneofGoNativeServer struct{}
+
+func (oneofGoNativeServer) Echo(ctx context.Context, note *rpcruntime.RpcString, choiceCase oneofv1.OneofGreeterEchoRequestChoiceCase, text *rpcruntime.RpcString, number int64, flag bool, blob *rpcruntime.RpcBytes, child *rpcruntime.RpcBytes) (string, oneofv1.OneofGreeterEchoResponseChoiceCase, string, int64, bool, []byte, []byte, error) {
+	return note.SafeString() + ":" + choiceCase.String(), oneofv1.OneofGreeterEchoResponseChoiceCase(choiceCase), text.SafeString(), number, flag, blob.SafeBytes(), child.SafeBytes(), nil
+}
+
+type oneofConnectHandler struct{}
+
+func (oneofConnectHandler) Echo(ctx context.Context, req *oneofv1.OneofRequest) (*oneofv1.OneofReply, error) {
+	reply := &oneofv1.OneofReply{Note: req.GetNote()}
+	switch choice := req.GetChoice().(type) {
+	case nil:
+		reply.Note += ":not_set"
+	case *oneofv1.OneofRequest_Text:
+		reply.Note += ":text"
+		reply.Choice = &oneofv1.OneofReply_Text{Text: choice.Text}
+	case *oneofv1.OneofRequest_Number:
+		reply.Note += ":number"
+		reply.Choice = &oneofv1.OneofReply_Number{Number: choice.Number}
+	case *oneofv1.OneofRequest_Flag:
+		reply.Note += ":flag"
+		reply.Choice = &oneofv1.OneofReply_Flag{Flag: choice.Flag}
+	case *oneofv1.OneofRequest_Blob:
+		reply.Note += ":blob"
+		reply.Choice = &oneofv1.OneofReply_Blob{Blob: choice.Blob}
+	case *oneofv1.OneofRequest_Child:
+		reply.Note += ":child"
+		reply.Choice = &oneofv1.OneofReply_Child{Child: choice.Child}
+	}
+	return reply, nil
+}
+
+func assertChoiceEcho(t *testing.T, input choiceValues, want string) {
+	t.Helper()
+	output, errID := CallOneofGreeterEchoNativeUnary(context.Background(), input)
+	if errID != 0 {
+		text, _, _ := rpcruntime.TakeErrorText(rpcruntime.ErrorID(errID))
+		t.Fatalf("CallOneofGreeterEchoNativeUnary(%+v) errID = %d: %s", input, errID, text)
+	}
+	if output.Note != input.Note+":"+want || output.Case != input.Case {
+		t.Fatalf("echo of %+v = note %q case %d, want note %q case %d", input, output.Note, output.Case, input.Note+":"+want, input.Case)
+	}
+	if output.Text != input.Text || output.Number != input.Number || output.Flag != input.Flag || !bytes.Equal(output.Blob, input.Blob) || !bytes.Equal(output.Child, input.Child) {
+		t.Fatalf("echo of %+v = %+v, want the active member", input, output)
+	}
+}
+
+// oneofCases covers every member, each holding its zero value, so only the
+// case discriminant tells them apart from an unset oneof.
+var oneofCases = []struct {
+	name  string
+	input choiceValues
+}{
+	{name: "not_set", input: choiceValues{Note: "n", Case: int32(oneofv1.OneofGreeterEchoRequestChoiceCaseNotSet)}},
+	{name: "text", input: choiceValues{Note: "t", Case: int32(oneofv1.OneofGreeterEchoRequestChoiceCaseText)}},
+	{name: "number", input: choiceValues{Note: "i", Case: int32(oneofv1.OneofGreeterEchoRequestChoiceCaseNumber)}},
+	{name: "flag", input: choiceValues{Note: "f", Case: int32(oneofv1.OneofGreeterEchoRequestChoiceCaseFlag)}},
+	{name: "blob", input: choiceValues{Note: "b", Case: int32(oneofv1.OneofGreeterEchoRequestChoiceCaseBlob)}},
+	{name: "child", input: choiceValues{Note: "c", Case: int32(oneofv1.OneofGreeterEchoRequestChoiceCaseChild)}},
+}
+
+func TestOneofNativeABI(t *testing.T) {
+	t.Run("go native server receives the typed case", func(t *testing.T) {
+		oneofv1.ResetOneofGreeterServerForIntegrationTest()
+		if err := oneofv1.RegisterOneofGreeterGoNativeServer(oneofGoNativeServer{}); err != nil {
+			t.Fatalf("RegisterOneofGreeterGoNativeServer() error = %v", err)
+		}
+
+		for _, tc := range oneofCases {
+			assertChoiceEcho(t, tc.input, tc.name)
+		}
+		assertChoiceEcho(t, choiceValues{Note: "v", Case: 2, Text: "value"}, "text")
+	})
+
+	t.Run("codec round-trips the active case exactly", func(t *testing.T) {
+		oneofv1.ResetOneofGreeterServerForIntegrationTest()
+		if err := oneofv1.RegisterOneofGreeterConnectHandler(oneofConnectHandler{}); err != nil {
+			t.Fatalf("RegisterOneofGreeterConnectHandler() error = %v", err)
+		}
+
+		for _, tc := range oneofCases {
+			assertChoiceEcho(t, tc.input, tc.name)
+		}
+		assertChoiceEcho(t, choiceValues{Note: "v", Case: 3, Number: -7}, "number")
+		assertChoiceEcho(t, choiceValues{Note: "v", Case: 5, Blob: []byte{0, 1}}, "blob")
+		child, err := proto.Marshal(&oneofv1.Child{Name: "kid"})
+		if err != nil {
+			t.Fatalf("proto.Marshal() error = %v", err)
+		}
+		assertChoiceEcho(t, choiceValues{Note: "v", Case: 6, Child: child}, "child")
+	})
+
+	t.Run("unknown case returns error id", func(t *testing.T) {
+		oneofv1.ResetOneofGreeterServerForIntegrationTest()
+		if err := oneofv1.RegisterOneofGreeterConnectHandler(oneofConnectHandler{}); err != nil {
+			t.Fatalf("RegisterOneofGreeterConnectHandler() error = %v", err)
+		}
+
+		_, errID := CallOneofGreeterEchoNativeUnary(context.Background(), choiceValues{Case: 9})
+		if errID == 0 {
+			t.Fatal("unknown case returned errID 0")
+		}
+		text, _, ok := rpcruntime.TakeErrorText(rpcruntime.ErrorID(errID))
+		if !ok || !strings.Contains(string(text), "oneof oneof.abi.v1.OneofRequest.choice has unknown case 9") {
+			t.Fatalf("unknown case error text = %q, ok=%v", text, ok)
+		}
+	})
+}
+`
+
+const oneofNativeABIPBGoSource = `// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v7.34.1
+// source: oneof/v1/oneof.proto
+
+package oneofv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type OneofRequest struct {
+	state protoimpl.MessageState ` + "`" + `protogen:"open.v1"` + "`" + `
+	Note  string                 ` + "`" + `protobuf:"bytes,1,opt,name=note,proto3" json:"note,omitempty"` + "`" + `
+	// Types that are valid to be assigned to Choice:
+	//
+	//	*OneofRequest_Text
+	//	*OneofRequest_Number
+	//	*OneofRequest_Flag
+	//	*OneofRequest_Blob
+	//	*OneofRequest_Child
+	Choice        isOneofRequest_Choice ` + "`" + `protobuf_oneof:"choice"` + "`" + `
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OneofRequest) Reset() {
+	*x = OneofRequest{}
+	mi := &file_oneof_v1_oneof_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OneofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OneofRequest) ProtoMessage() {}
+
+func (x *OneofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oneof_v1_oneof_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OneofRequest.ProtoReflect.Descriptor instead.
+func (*OneofRequest) Descriptor() ([]byte, []int) {
+	return file_oneof_v1_oneof_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OneofRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *OneofRequest) GetChoice() isOneofRequest_Choice {
+	if x != nil {
+		return x.Choice
+	}
+	return nil
+}
+
+func (x *OneofRequest) GetText() string {
+	if x != nil {
+		if x, ok := x.Choice.(*OneofRequest_Text); ok {
+			return x.Text
+		}
+	}
+	return ""
+}
+
+func (x *OneofRequest) GetNumber() int64 {
+	if x != nil {
+		if x, ok := x.Choice.(*OneofRequest_Number); ok {
+			return x.Number
+		}
+	}
+	return 0
+}
+
+func (x *OneofRequest) GetFlag() bool {
+	if x != nil {
+		if x, ok := x.Choice.(*OneofRequest_Flag); ok {
+			return x.Flag
+		}
+	}
+	return false
+}
+
+func (x *OneofRequest) GetBlob() []byte {
+	if x != nil {
+		if x, ok := x.Choice.(*OneofRequest_Blob); ok {
+			return x.Blob
+		}
+	}
+	return nil
+}
+
+func (x *OneofRequest) GetChild() *Child {
+	if x != nil {
+		if x, ok := x.Choice.(*OneofRequest_Child); ok {
+			return x.Child
+		}
+	}
+	return nil
+}
+
+type isOneofRequest_Choice interface {
+	isOneofRequest_Choice()
+}
+
+type OneofRequest_Text struct {
+	Text string ` + "`" + `protobuf:"bytes,2,opt,name=text,proto3,oneof"` + "`" + `
+}
+
+type OneofRequest_Number struct {
+	Number int64 ` + "`" + `protobuf:"varint,3,opt,name=number,proto3,oneof"` + "`" + `
+}
+
+type OneofRequest_Flag struct {
+	Flag bool ` + "`" + `protobuf:"varint,4,opt,name=flag,proto3,oneof"` + "`" + `
+}
+
+type OneofRequest_Blob struct {
+	Blob []byte ` + "`" + `protobuf:"bytes,5,opt,name=blob,proto3,oneof"` + "`" + `
+}
+
+type OneofRequest_Child struct {
+	Child *Child ` + "`" + `protobuf:"bytes,6,opt,name=child,proto3,oneof"` + "`" + `
+}
+
+func (*OneofRequest_Text) isOneofRequest_Choice() {}
+
+func (*OneofRequest_Number) isOneofRequest_Choice() {}
+
+func (*OneofRequest_Flag) isOneofRequest_Choice() {}
+
+func (*OneofRequest_Blob) isOneofRequest_Choice() {}
+
+func (*OneofRequest_Child) isOneofRequest_Choice() {}
+
+type OneofReply struct {
+	state protoimpl.MessageState ` + "`" + `protogen:"open.v1"` + "`" + `
+	Note  string                 ` + "`" + `protobuf:"bytes,1,opt,name=note,proto3" json:"note,omitempty"` + "`" + `
+	// Types that are valid to be assigned to Choice:
+	//
+	//	*OneofReply_Text
+	//	*OneofReply_Number
+	//	*OneofReply_Flag
+	//	*OneofReply_Blob
+	//	*OneofReply_Child
+	Choice        isOneofReply_Choice ` + "`" + `protobuf_oneof:"choice"` + "`" + `
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OneofReply) Reset() {
+	*x = OneofReply{}
+	mi := &file_oneof_v1_oneof_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OneofReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OneofReply) ProtoMessage() {}
+
+func (x *OneofReply) ProtoReflect() protoreflect.Message {
+	mi := &file_oneof_v1_oneof_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OneofReply.ProtoReflect.Descriptor instead.
+func (*OneofReply) Descriptor() ([]byte, []int) {
+	return file_oneof_v1_oneof_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OneofReply) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *OneofReply) GetChoice() isOneofReply_Choice {
+	if x != nil {
+		return x.Choice
+	}
+	return nil
+}
+
+func (x *OneofReply) GetText() string {
+	if x != nil {
+		if x, ok := x.Choice.(*OneofReply_Text); ok {
+			return x.Text
+		}
+	}
+	return ""
+}
+
+func (x *OneofReply) GetNumber() int64 {
+	if x != nil {
+		if x, ok := x.Choice.(*OneofReply_Number); ok {
+			return x.Number
+		}
+	}
+	return 0
+}
+
+func (x *OneofReply) GetFlag() bool {
+	if x != nil {
+		if x, ok := x.Choice.(*OneofReply_Flag); ok {
+			return x.Flag
+		}
+	}
+	return false
+}
+
+func (x *OneofReply) GetBlob() []byte {
+	if x != nil {
+		if x, ok := x.Choice.(*OneofReply_Blob); ok {
+			return x.Blob
+		}
+	}
+	return nil
+}
+
+func (x *OneofReply) GetChild() *Child {
+	if x != nil {
+		if x, ok := x.Choice.(*OneofReply_Child); ok {
+			return x.Child
+		}
+	}
+	return nil
+}
+
+type isOneofReply_Choice interface {
+	isOneofReply_Choice()
+}
+
+type OneofReply_Text struct {
+	Text string ` + "`" + `protobuf:"bytes,2,opt,name=text,proto3,oneof"` + "`" + `
+}
+
+type OneofReply_Number struct {
+	Number int64 ` + "`" + `protobuf:"varint,3,opt,name=number,proto3,oneof"` + "`" + `
+}
+
+type OneofReply_Flag struct {
+	Flag bool ` + "`" + `protobuf:"varint,4,opt,name=flag,proto3,oneof"` + "`" + `
+}
+
+type OneofReply_Blob struct {
+	Blob []byte ` + "`" + `protobuf:"bytes,5,opt,name=blob,proto3,oneof"` + "`" + `
+}
+
+type OneofReply_Child struct {
+	Child *Child ` + "`" + `protobuf:"bytes,6,opt,name=child,proto3,oneof"` + "`" + `
+}
+
+func (*OneofReply_Text) isOneofReply_Choice() {}
+
+func (*OneofReply_Number) isOneofReply_Choice() {}
+
+func (*OneofReply_Flag) isOneofReply_Choice() {}
+
+func (*OneofReply_Blob) isOneofReply_Choice() {}
+
+func (*OneofReply_Child) isOneofReply_Choice() {}
+
+type Child struct {
+	state         protoimpl.MessageState ` + "`" + `protogen:"open.v1"` + "`" + `
+	Name          string                 ` + "`" + `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` + "`" + `
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Child) Reset() {
+	*x = Child{}
+	mi := &file_oneof_v1_oneof_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Child) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Child) ProtoMessage() {}
+
+func (x *Child) ProtoReflect() protoreflect.Message {
+	mi := &file_oneof_v1_oneof_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Child.ProtoReflect.Descriptor instead.
+func (*Child) Descriptor() ([]byte, []int) {
+	return file_oneof_v1_oneof_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Child) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_oneof_v1_oneof_proto protoreflect.FileDescriptor
+
+const file_oneof_v1_oneof_proto_rawDesc = "" +
+	"\n" +
+	"\x14oneof/v1/oneof.proto\x12\foneof.abi.v1\"\x8e\x01\n" +
+	"\fOneofRequest\x12\f\n" +
+	"\x04note\x18\x01 \x01(\t\x12\x0e\n" +
+	"\x04text\x18\x02 \x01(\tH\x00\x12\x10\n" +
+	"\x06number\x18\x03 \x01(\x03H\x00\x12\x0e\n" +
+	"\x04flag\x18\x04 \x01(\bH\x00\x12\x0e\n" +
+	"\x04blob\x18\x05 \x01(\fH\x00\x12$\n" +
+	"\x05child\x18\x06 \x01(\v2\x13.oneof.abi.v1.ChildH\x00B\b\n" +
+	"\x06choice\"\x8c\x01\n" +
+	"\n" +
+	"OneofReply\x12\f\n" +
+	"\x04note\x18\x01 \x01(\t\x12\x0e\n" +
+	"\x04text\x18\x02 \x01(\tH\x00\x12\x10\n" +
+	"\x06number\x18\x03 \x01(\x03H\x00\x12\x0e\n" +
+	"\x04flag\x18\x04 \x01(\bH\x00\x12\x0e\n" +
+	"\x04blob\x18\x05 \x01(\fH\x00\x12$\n" +
+	"\x05child\x18\x06 \x01(\v2\x13.oneof.abi.v1.ChildH\x00B\b\n" +
+	"\x06choice\"\x15\n" +
+	"\x05Child\x12\f\n" +
+	"\x04name\x18\x01 \x01(\t2L\n" +
+	"\fOneofGreeter\x12<\n" +
+	"\x04Echo\x12\x1a.oneof.abi.v1.OneofRequest\x1a\x18.oneof.abi.v1.OneofReplyB-Z+example.com/oneofnativeabi/oneof/v1;oneofv1b\x06proto3"
+
+var (
+	file_oneof_v1_oneof_proto_rawDescOnce sync.Once
+	file_oneof_v1_oneof_proto_rawDescData []byte
+)
+
+func file_oneof_v1_oneof_proto_rawDescGZIP() []byte {
+	file_oneof_v1_oneof_proto_rawDescOnce.Do(func() {
+		file_oneof_v1_oneof_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_oneof_v1_oneof_proto_rawDesc), len(file_oneof_v1_oneof_proto_rawDesc)))
+	})
+	return file_oneof_v1_oneof_proto_rawDescData
+}
+
+var file_oneof_v1_oneof_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_oneof_v1_oneof_proto_goTypes = []any{
+	(*OneofRequest)(nil), // 0: oneof.abi.v1.OneofRequest
+	(*OneofReply)(nil),   // 1: oneof.abi.v1.OneofReply
+	(*Child)(nil),        // 2: oneof.abi.v1.Child
+}
+var file_oneof_v1_oneof_proto_depIdxs = []int32{
+	2, // 0: oneof.abi.v1.OneofRequest.child:type_name -> oneof.abi.v1.Child
+	2, // 1: oneof.abi.v1.OneofReply.child:type_name -> oneof.abi.v1.Child
+	0, // 2: oneof.abi.v1.OneofGreeter.Echo:input_type -> oneof.abi.v1.OneofRequest
+	1, // 3: oneof.abi.v1.OneofGreeter.Echo:output_type -> oneof.abi.v1.OneofReply
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_oneof_v1_oneof_proto_init() }
+func file_oneof_v1_oneof_proto_init() {
+	if File_oneof_v1_oneof_proto != nil {
+		return
+	}
+	file_oneof_v1_oneof_proto_msgTypes[0].OneofWrappers = []any{
+		(*OneofRequest_Text)(nil),
+		(*OneofRequest_Number)(nil),
+		(*OneofRequest_Flag)(nil),
+		(*OneofRequest_Blob)(nil),
+		(*OneofRequest_Child)(nil),
+	}
+	file_oneof_v1_oneof_proto_msgTypes[1].OneofWrappers = []any{
+		(*OneofReply_Text)(nil),
+		(*OneofReply_Number)(nil),
+		(*OneofReply_Flag)(nil),
+		(*OneofReply_Blob)(nil),
+		(*OneofReply_Child)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_oneof_v1_oneof_proto_rawDesc), len(file_oneof_v1_oneof_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_oneof_v1_oneof_proto_goTypes,
+		DependencyIndexes: file_oneof_v1_oneof_proto_depIdxs,
+		MessageInfos:      file_oneof_v1_oneof_proto_msgTypes,
+	}.Build()
+	File_oneof_v1_oneof_proto = out.File
+	file_oneof_v1_oneof_proto_goTypes = nil
+	file_oneof_v1_oneof_proto_depIdxs = nil
+}
+`
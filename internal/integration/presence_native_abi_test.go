@@ -0,0 +1,666 @@
+package integration
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/ygrpc/rpccgo/internal/generator"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestPresenceNativeABIAcceptance(t *testing.T) {
+	tmp := t.TempDir()
+	plugin := newPresenceNativeABIPlugin(t, "example.com/presencenativeabi/presence/v1;presencev1")
+	if _, err := generator.GenerateWithOptions(plugin); err != nil {
+		t.Fatalf("GenerateWithOptions() error = %v", err)
+	}
+
+	writeMessageDirectPathGeneratedModule(t, tmp, plugin, "example.com/presencenativeabi")
+	writeFile(t, filepath.Join(tmp, "presence/v1/presence.pb.go"), presenceNativeABIPBGoSource)
+	writeFile(t, filepath.Join(tmp, "presence/v1/presence_connect_stubs.go"), presenceNativeABIConnectStubSource)
+	writeFile(t, filepath.Join(tmp, "presence/v1/presence_integration_reset.go"), presenceNativeABIResetSource)
+	writeFile(t, filepath.Join(tmp, "presence/v1/cgo/presence_native_cgo_client_bridge.go"), presenceNativeABICGOClientBridgeSource)
+	writeFile(t, filepath.Join(tmp, "presence/v1/cgo/presence_native_abi_test.go"), presenceNativeABIFixtureTestSource)
+
+	cmd := exec.Command("go", "test", "./presence/v1/cgo", "-run", "^TestPresenceNativeABI$", "-count=1")
+	cmd.Dir = tmp
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("presence native ABI fixture failed: %v\n%s", err, out)
+	}
+}
+
+func newPresenceNativeABIPlugin(t *testing.T, goPackage string) *protogen.Plugin {
+	t.Helper()
+	presenceMessage := func(name string) *descriptorpb.DescriptorProto {
+		message := &descriptorpb.DescriptorProto{
+			Name: proto.String(name),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				fieldDescriptor("note", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+			},
+		}
+		optional := func(name string, number int32, fieldType descriptorpb.FieldDescriptorProto_Type) {
+			field := fieldDescriptor(name, number, fieldType, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, "")
+			field.Proto3Optional = proto.Bool(true)
+			field.OneofIndex = proto.Int32(int32(len(message.OneofDecl)))
+			message.OneofDecl = append(message.OneofDecl, &descriptorpb.OneofDescriptorProto{Name: proto.String("_" + name)})
+			message.Field = append(message.Field, field)
+		}
+		optional("limit", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32)
+		optional("label", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+		optional("blob", 4, descriptorpb.FieldDescriptorProto_TYPE_BYTES)
+		optional("flag", 5, descriptorpb.FieldDescriptorProto_TYPE_BOOL)
+		message.Field = append(message.Field, fieldDescriptor("child", 6, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ".presence.abi.v1.Child"))
+		return message
+	}
+	request := &pluginpb.CodeGeneratorRequest{
+		Parameter:      proto.String("paths=source_relative"),
+		FileToGenerate: []string{"presence/v1/presence.proto"},
+		ProtoFile: []*descriptorpb.FileDescriptorProto{{
+			Name:    proto.String("presence/v1/presence.proto"),
+			Package: proto.String("presence.abi.v1"),
+			Syntax:  proto.String("proto3"),
+			Options: &descriptorpb.FileOptions{
+				GoPackage: proto.String(goPackage),
+			},
+			MessageType: []*descriptorpb.DescriptorProto{
+				presenceMessage("PresenceRequest"),
+				presenceMessage("PresenceReply"),
+				{
+					Name: proto.String("Child"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						fieldDescriptor("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, ""),
+					},
+				},
+			},
+			Service: []*descriptorpb.ServiceDescriptorProto{{
+				Name: proto.String("PresenceGreeter"),
+				Method: []*descriptorpb.MethodDescriptorProto{{
+					Name:       proto.String("Echo"),
+					InputType:  proto.String(".presence.abi.v1.PresenceRequest"),
+					OutputType: proto.String(".presence.abi.v1.PresenceReply"),
+				}},
+			}},
+			SourceCodeInfo: &descriptorpb.SourceCodeInfo{Location: []*descriptorpb.SourceCodeInfo_Location{{
+				Path:            []int32{6, 0},
+				Span:            []int32{0, 0, 0},
+				LeadingComments: proto.String("@rpccgo: msg-connect|native\n"),
+			}}},
+		}},
+	}
+	plugin, err := generator.ProtogenOptions().New(request)
+	if err != nil {
+		t.Fatalf("protogen.Options.New() error = %v", err)
+	}
+	return plugin
+}
+
+const presenceNativeABIConnectStubSource = `package presencev1
+
+import context "context"
+
+type PresenceGreeterHandler interface {
+	Echo(context.Context, *PresenceRequest) (*PresenceReply, error)
+}
+
+type PresenceGreeterClient interface {
+	Echo(context.Context, *PresenceRequest) (*PresenceReply, error)
+}
+
+type PresenceGreeterServer interface {
+	Echo(context.Context, *PresenceRequest) (*PresenceReply, error)
+}
+`
+
+const presenceNativeABIResetSource = `package presencev1
+
+import rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"
+
+func ResetPresenceGreeterServerForIntegrationTest() {
+	_ = ClearPresenceGreeterServer()
+	rpcruntime.ResetStreamSessionsForTesting()
+}
+`
+
+const presenceNativeABICGOClientBridgeSource = `package main
+
+/*
+#include <stdint.h>
+*/
+import "C"
+
+import (
+	context "context"
+	goruntime "runtime"
+	unsafe "unsafe"
+
+	rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"
+)
+
+func CallPresenceGreeterEchoNativeUnary(ctx context.Context, input presenceValues) (presenceValues, int32) {
+	var hasLimit, hasLabel, hasBlob, hasFlag, flag, hasChild C.int8_t
+	var limit C.int32_t
+	var label string
+	if input.Limit != nil {
+		hasLimit, limit = 1, C.int32_t(*input.Limit)
+	}
+	if input.Label != nil {
+		hasLabel, label = 1, *input.Label
+	}
+	if input.Blob != nil {
+		hasBlob = 1
+	}
+	if input.Flag != nil {
+		hasFlag = 1
+		if *input.Flag {
+			flag = 1
+		}
+	}
+	if input.Child != nil {
+		hasChild = 1
+	}
+	var outNotePtr, outLabelPtr, outBlobPtr, outChildPtr C.uintptr_t
+	var outNoteLen, outNoteOwnership, outLabelLen, outLabelOwnership, outBlobLen, outBlobOwnership, outChildLen, outChildOwnership C.int32_t
+	var outHasLimit, outHasLabel, outHasBlob, outHasFlag, outFlag, outHasChild C.int8_t
+	var outLimit C.int32_t
+	errID := rpccgoNativePresencev1PresenceGreeterEcho(
+		unsafe.Pointer(unsafe.StringData(input.Note)), C.int32_t(len(input.Note)), 0,
+		hasLimit, limit,
+		hasLabel, unsafe.Pointer(unsafe.StringData(label)), C.int32_t(len(label)), 0,
+		hasBlob, unsafe.Pointer(unsafe.SliceData(input.Blob)), C.int32_t(len(input.Blob)), 0,
+		hasFlag, flag,
+		hasChild, unsafe.Pointer(unsafe.SliceData(input.Child)), C.int32_t(len(input.Child)), 0,
+		&outNotePtr, &outNoteLen, &outNoteOwnership,
+		&outHasLimit, &outLimit,
+		&outHasLabel, &outLabelPtr, &outLabelLen, &outLabelOwnership,
+		&outHasBlob, &outBlobPtr, &outBlobLen, &outBlobOwnership,
+		&outHasFlag, &outFlag,
+		&outHasChild, &outChildPtr, &outChildLen, &outChildOwnership,
+	)
+	goruntime.KeepAlive(input)
+	goruntime.KeepAlive(label)
+	if errID != 0 {
+		return presenceValues{}, int32(errID)
+	}
+	output := presenceValues{Note: takeOutputString(uintptr(outNotePtr), int32(outNoteLen))}
+	if outHasLimit != 0 {
+		value := int32(outLimit)
+		output.Limit = &value
+	}
+	if outHasLabel != 0 {
+		value := takeOutputString(uintptr(outLabelPtr), int32(outLabelLen))
+		output.Label = &value
+	}
+	if outHasBlob != 0 {
+		output.Blob = []byte(takeOutputString(uintptr(outBlobPtr), int32(outBlobLen)))
+	}
+	if outHasFlag != 0 {
+		value := outFlag != 0
+		output.Flag = &value
+	}
+	if outHasChild != 0 {
+		output.Child = []byte(takeOutputString(uintptr(outChildPtr), int32(outChildLen)))
+	}
+	return output, 0
+}
+
+func takeOutputString(ptr uintptr, length int32) string {
+	if ptr == 0 {
+		return ""
+	}
+	defer rpcruntime.Release(ptr)
+	return string(unsafe.Slice((*byte)(unsafe.Pointer(ptr)), length))
+}
+`
+
+const presenceNativeABIFixtureTestSource = `package main
+
+import (
+	bytes "bytes"
+	context "context"
+	testing "testing"
+
+	presencev1 "example.com/presencenativeabi/presence/v1"
+	rpcruntime "github.com/ygrpc/rpccgo/rpcruntime"
+	proto "google.golang.org/protobuf/proto"
+)
+
+// presenceValues mirrors the native ABI slots of PresenceRequest and
+// PresenceReply; a nil pointer or slice stands for an unset field. Child holds
+// the encoded submessage.
+type presenceValues struct {
+	Note  string
+	Limit *int32
+	Label *string
+	Blob  []byte
+	Flag  *bool
+	Child []byte
+}
+
+type presenceGoNativeServer struct{}
+
+func (presenceGoNativeServer) Echo(ctx context.Context, note *rpcruntime.RpcString, limit *int32, label *rpcruntime.RpcString, blob *rpcruntime.RpcBytes, flag *bool, child *rpcruntime.RpcBytes) (string, *int32, *string, []byte, *bool, []byte, error) {
+	var labelValue *string
+	if label != nil {
+		value := label.SafeString()
+		labelValue = &value
+	}
+	var blobValue []byte
+	if blob != nil {
+		blobValue = append([]byte{}, blob.SafeBytes()...)
+	}
+	var childValue []byte
+	if child != nil {
+		childValue = append([]byte{}, child.SafeBytes()...)
+	}
+	return note.SafeString(), limit, labelValue, blobValue, flag, childValue, nil
+}
+
+type presenceConnectHandler struct{}
+
+func (presenceConnectHandler) Echo(ctx context.Context, req *presencev1.PresenceRequest) (*presencev1.PresenceReply, error) {
+	return &presencev1.PresenceReply{Note: req.GetNote(), Limit: req.Limit, Label: req.Label, Blob: req.Blob, Flag: req.Flag, Child: req.Child}, nil
+}
+
+func assertPresenceEcho(t *testing.T, input presenceValues) {
+	t.Helper()
+	output, errID := CallPresenceGreeterEchoNativeUnary(context.Background(), input)
+	if errID != 0 {
+		text, _, _ := rpcruntime.TakeErrorText(rpcruntime.ErrorID(errID))
+		t.Fatalf("CallPresenceGreeterEchoNativeUnary(%+v) errID = %d: %s", input, errID, text)
+	}
+	if output.Note != input.Note {
+		t.Fatalf("echo of %q note = %q", input.Note, output.Note)
+	}
+	if (output.Limit == nil) != (input.Limit == nil) || (input.Limit != nil && *output.Limit != *input.Limit) {
+		t.Fatalf("echo of %q limit = %v, want %v", input.Note, output.Limit, input.Limit)
+	}
+	if (output.Label == nil) != (input.Label == nil) || (input.Label != nil && *output.Label != *input.Label) {
+		t.Fatalf("echo of %q label = %v, want %v", input.Note, output.Label, input.Label)
+	}
+	if (output.Blob == nil) != (input.Blob == nil) || !bytes.Equal(output.Blob, input.Blob) {
+		t.Fatalf("echo of %q blob = %v, want %v", input.Note, output.Blob, input.Blob)
+	}
+	if (output.Flag == nil) != (input.Flag == nil) || (input.Flag != nil && *output.Flag != *input.Flag) {
+		t.Fatalf("echo of %q flag = %v, want %v", input.Note, output.Flag, input.Flag)
+	}
+	if (output.Child == nil) != (input.Child == nil) || !bytes.Equal(output.Child, input.Child) {
+		t.Fatalf("echo of %q child = %v, want %v", input.Note, output.Child, input.Child)
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+// presenceCases pairs every unset field with the same field set to its zero
+// value, which only the presence flag tells apart; the zero child is an empty
+// submessage that is set.
+func presenceCases(t *testing.T) []presenceValues {
+	t.Helper()
+	child, err := proto.Marshal(&presencev1.Child{Name: "kid"})
+	if err != nil {
+		t.Fatalf("proto.Marshal(Child) error = %v", err)
+	}
+	return []presenceValues{
+		{Note: "unset"},
+		{Note: "zero", Limit: ptr(int32(0)), Label: ptr(""), Blob: []byte{}, Flag: ptr(false), Child: []byte{}},
+		{Note: "value", Limit: ptr(int32(-7)), Label: ptr("label"), Blob: []byte{0, 1}, Flag: ptr(true), Child: child},
+	}
+}
+
+func TestPresenceNativeABI(t *testing.T) {
+	t.Run("go native server sees set and unset fields", func(t *testing.T) {
+		presencev1.ResetPresenceGreeterServerForIntegrationTest()
+		if err := presencev1.RegisterPresenceGreeterGoNativeServer(presenceGoNativeServer{}); err != nil {
+			t.Fatalf("RegisterPresenceGreeterGoNativeServer() error = %v", err)
+		}
+
+		for _, input := range presenceCases(t) {
+			assertPresenceEcho(t, input)
+		}
+	})
+
+	t.Run("codec round-trips presence through messages", func(t *testing.T) {
+		presencev1.ResetPresenceGreeterServerForIntegrationTest()
+		if err := presencev1.RegisterPresenceGreeterConnectHandler(presenceConnectHandler{}); err != nil {
+			t.Fatalf("RegisterPresenceGreeterConnectHandler() error = %v", err)
+		}
+
+		for _, input := range presenceCases(t) {
+			assertPresenceEcho(t, input)
+		}
+	})
+}
+`
+
+const presenceNativeABIPBGoSource = `// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v7.34.1
+// source: presence/v1/presence.proto
+
+package presencev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PresenceRequest struct {
+	state         protoimpl.MessageState ` + "`" + `protogen:"open.v1"` + "`" + `
+	Note          string                 ` + "`" + `protobuf:"bytes,1,opt,name=note,proto3" json:"note,omitempty"` + "`" + `
+	Limit         *int32                 ` + "`" + `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"` + "`" + `
+	Label         *string                ` + "`" + `protobuf:"bytes,3,opt,name=label,proto3,oneof" json:"label,omitempty"` + "`" + `
+	Blob          []byte                 ` + "`" + `protobuf:"bytes,4,opt,name=blob,proto3,oneof" json:"blob,omitempty"` + "`" + `
+	Flag          *bool                  ` + "`" + `protobuf:"varint,5,opt,name=flag,proto3,oneof" json:"flag,omitempty"` + "`" + `
+	Child         *Child                 ` + "`" + `protobuf:"bytes,6,opt,name=child,proto3" json:"child,omitempty"` + "`" + `
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PresenceRequest) Reset() {
+	*x = PresenceRequest{}
+	mi := &file_presence_v1_presence_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PresenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresenceRequest) ProtoMessage() {}
+
+func (x *PresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_presence_v1_presence_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresenceRequest.ProtoReflect.Descriptor instead.
+func (*PresenceRequest) Descriptor() ([]byte, []int) {
+	return file_presence_v1_presence_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PresenceRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *PresenceRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *PresenceRequest) GetLabel() string {
+	if x != nil && x.Label != nil {
+		return *x.Label
+	}
+	return ""
+}
+
+func (x *PresenceRequest) GetBlob() []byte {
+	if x != nil {
+		return x.Blob
+	}
+	return nil
+}
+
+func (x *PresenceRequest) GetFlag() bool {
+	if x != nil && x.Flag != nil {
+		return *x.Flag
+	}
+	return false
+}
+
+func (x *PresenceRequest) GetChild() *Child {
+	if x != nil {
+		return x.Child
+	}
+	return nil
+}
+
+type PresenceReply struct {
+	state         protoimpl.MessageState ` + "`" + `protogen:"open.v1"` + "`" + `
+	Note          string                 ` + "`" + `protobuf:"bytes,1,opt,name=note,proto3" json:"note,omitempty"` + "`" + `
+	Limit         *int32                 ` + "`" + `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"` + "`" + `
+	Label         *string                ` + "`" + `protobuf:"bytes,3,opt,name=label,proto3,oneof" json:"label,omitempty"` + "`" + `
+	Blob          []byte                 ` + "`" + `protobuf:"bytes,4,opt,name=blob,proto3,oneof" json:"blob,omitempty"` + "`" + `
+	Flag          *bool                  ` + "`" + `protobuf:"varint,5,opt,name=flag,proto3,oneof" json:"flag,omitempty"` + "`" + `
+	Child         *Child                 ` + "`" + `protobuf:"bytes,6,opt,name=child,proto3" json:"child,omitempty"` + "`" + `
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PresenceReply) Reset() {
+	*x = PresenceReply{}
+	mi := &file_presence_v1_presence_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PresenceReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresenceReply) ProtoMessage() {}
+
+func (x *PresenceReply) ProtoReflect() protoreflect.Message {
+	mi := &file_presence_v1_presence_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresenceReply.ProtoReflect.Descriptor instead.
+func (*PresenceReply) Descriptor() ([]byte, []int) {
+	return file_presence_v1_presence_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PresenceReply) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *PresenceReply) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *PresenceReply) GetLabel() string {
+	if x != nil && x.Label != nil {
+		return *x.Label
+	}
+	return ""
+}
+
+func (x *PresenceReply) GetBlob() []byte {
+	if x != nil {
+		return x.Blob
+	}
+	return nil
+}
+
+func (x *PresenceReply) GetFlag() bool {
+	if x != nil && x.Flag != nil {
+		return *x.Flag
+	}
+	return false
+}
+
+func (x *PresenceReply) GetChild() *Child {
+	if x != nil {
+		return x.Child
+	}
+	return nil
+}
+
+type Child struct {
+	state         protoimpl.MessageState ` + "`" + `protogen:"open.v1"` + "`" + `
+	Name          string                 ` + "`" + `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` + "`" + `
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Child) Reset() {
+	*x = Child{}
+	mi := &file_presence_v1_presence_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Child) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Child) ProtoMessage() {}
+
+func (x *Child) ProtoReflect() protoreflect.Message {
+	mi := &file_presence_v1_presence_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Child.ProtoReflect.Descriptor instead.
+func (*Child) Descriptor() ([]byte, []int) {
+	return file_presence_v1_presence_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Child) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_presence_v1_presence_proto protoreflect.FileDescriptor
+
+const file_presence_v1_presence_proto_rawDesc = "" +
+	"\n" +
+	"\x1apresence/v1/presence.proto\x12\x0fpresence.abi.v1\"\xba\x01\n" +
+	"\x0fPresenceRequest\x12\f\n" +
+	"\x04note\x18\x01 \x01(\t\x12\x12\n" +
+	"\x05limit\x18\x02 \x01(\x05H\x00\x88\x01\x01\x12\x12\n" +
+	"\x05label\x18\x03 \x01(\tH\x01\x88\x01\x01\x12\x11\n" +
+	"\x04blob\x18\x04 \x01(\fH\x02\x88\x01\x01\x12\x11\n" +
+	"\x04flag\x18\x05 \x01(\bH\x03\x88\x01\x01\x12%\n" +
+	"\x05child\x18\x06 \x01(\v2\x16.presence.abi.v1.ChildB\b\n" +
+	"\x06_limitB\b\n" +
+	"\x06_labelB\a\n" +
+	"\x05_blobB\a\n" +
+	"\x05_flag\"\xb8\x01\n" +
+	"\rPresenceReply\x12\f\n" +
+	"\x04note\x18\x01 \x01(\t\x12\x12\n" +
+	"\x05limit\x18\x02 \x01(\x05H\x00\x88\x01\x01\x12\x12\n" +
+	"\x05label\x18\x03 \x01(\tH\x01\x88\x01\x01\x12\x11\n" +
+	"\x04blob\x18\x04 \x01(\fH\x02\x88\x01\x01\x12\x11\n" +
+	"\x04flag\x18\x05 \x01(\bH\x03\x88\x01\x01\x12%\n" +
+	"\x05child\x18\x06 \x01(\v2\x16.presence.abi.v1.ChildB\b\n" +
+	"\x06_limitB\b\n" +
+	"\x06_labelB\a\n" +
+	"\x05_blobB\a\n" +
+	"\x05_flag\"\x15\n" +
+	"\x05Child\x12\f\n" +
+	"\x04name\x18\x01 \x01(\t2[\n" +
+	"\x0fPresenceGreeter\x12H\n" +
+	"\x04Echo\x12 .presence.abi.v1.PresenceRequest\x1a\x1e.presence.abi.v1.PresenceReplyB6Z4example.com/presencenativeabi/presence/v1;presencev1b\x06proto3"
+
+var (
+	file_presence_v1_presence_proto_rawDescOnce sync.Once
+	file_presence_v1_presence_proto_rawDescData []byte
+)
+
+func file_presence_v1_presence_proto_rawDescGZIP() []byte {
+	file_presence_v1_presence_proto_rawDescOnce.Do(func() {
+		file_presence_v1_presence_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_presence_v1_presence_proto_rawDesc), len(file_presence_v1_presence_proto_rawDesc)))
+	})
+	return file_presence_v1_presence_proto_rawDescData
+}
+
+var file_presence_v1_presence_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_presence_v1_presence_proto_goTypes = []any{
+	(*PresenceRequest)(nil), // 0: presence.abi.v1.PresenceRequest
+	(*PresenceReply)(nil),   // 1: presence.abi.v1.PresenceReply
+	(*Child)(nil),           // 2: presence.abi.v1.Child
+}
+var file_presence_v1_presence_proto_depIdxs = []int32{
+	2, // 0: presence.abi.v1.PresenceRequest.child:type_name -> presence.abi.v1.Child
+	2, // 1: presence.abi.v1.PresenceReply.child:type_name -> presence.abi.v1.Child
+	0, // 2: presence.abi.v1.PresenceGreeter.Echo:input_type -> presence.abi.v1.PresenceRequest
+	1, // 3: presence.abi.v1.PresenceGreeter.Echo:output_type -> presence.abi.v1.PresenceReply
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_presence_v1_presence_proto_init() }
+func file_presence_v1_presence_proto_init() {
+	if File_presence_v1_presence_proto != nil {
+		return
+	}
+	file_presence_v1_presence_proto_msgTypes[0].OneofWrappers = []any{}
+	file_presence_v1_presence_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_presence_v1_presence_proto_rawDesc), len(file_presence_v1_presence_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_presence_v1_presence_proto_goTypes,
+		DependencyIndexes: file_presence_v1_presence_proto_depIdxs,
+		MessageInfos:      file_presence_v1_presence_proto_msgTypes,
+	}.Build()
+	File_presence_v1_presence_proto = out.File
+	file_presence_v1_presence_proto_goTypes = nil
+	file_presence_v1_presence_proto_depIdxs = nil
+}
+`
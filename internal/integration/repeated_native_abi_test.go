@@ -183,6 +183,7 @@ const repeatedNativeABICGOClientBridgeSource = `package main
 import "C"
 
 import context "context"
+import unsafe "unsafe"
 
 func CallRepeatedGreeterEchoNativeUnary(ctx context.Context, ScoresPtr uintptr, ScoresLen int32, ScoresOwnership int32, FlagsPtr uintptr, FlagsLen int32, FlagsOwnership int32, outScoresPtr *uintptr, outScoresLen *int32, outFlagsPtr *uintptr, outFlagsLen *int32) int32 {
 	var scoresPtr C.uintptr_t
@@ -191,7 +192,7 @@ func CallRepeatedGreeterEchoNativeUnary(ctx context.Context, ScoresPtr uintptr,
 	var flagsPtr C.uintptr_t
 	var flagsLen C.int32_t
 	var flagsOwnership C.int32_t
-	errID := rpccgoNativeRepeatedv1RepeatedGreeterEcho(C.uintptr_t(ScoresPtr), C.int32_t(ScoresLen), C.int32_t(ScoresOwnership), C.uintptr_t(FlagsPtr), C.int32_t(FlagsLen), C.int32_t(FlagsOwnership), &scoresPtr, &scoresLen, &scoresOwnership, &flagsPtr, &flagsLen, &flagsOwnership)
+	errID := rpccgoNativeRepeatedv1RepeatedGreeterEcho(unsafe.Pointer(ScoresPtr), C.int32_t(ScoresLen), C.int32_t(ScoresOwnership), unsafe.Pointer(FlagsPtr), C.int32_t(FlagsLen), C.int32_t(FlagsOwnership), &scoresPtr, &scoresLen, &scoresOwnership, &flagsPtr, &flagsLen, &flagsOwnership)
 	*outScoresPtr = uintptr(scoresPtr)
 	*outScoresLen = int32(scoresLen)
 	*outFlagsPtr = uintptr(flagsPtr)
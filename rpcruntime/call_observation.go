@@ -0,0 +1,106 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// observeUnary records metrics for a unary call and, when tracer is set, runs
+// it inside a span that encloses every interceptor.
+func observeUnary(ctx context.Context, tracer Tracer, call CallInfo, next UnaryFunc) error {
+	metrics := methodMetricsFor(call)
+	metrics.calls.Add(1)
+	start := time.Now()
+	var span Span
+	if tracer != nil {
+		ctx, span = startCallSpan(ctx, tracer, call)
+	}
+	err := next(ctx, call)
+	metrics.observe(time.Since(start), err)
+	if span != nil {
+		span.End(err)
+	}
+	return err
+}
+
+// streamObservation follows one stream from Start until its session is
+// removed. It is handed to the session created by CreateStreamSessionContext.
+type streamObservation struct {
+	call    CallInfo
+	metrics *methodMetrics
+	span    Span
+	start   time.Time
+	permit  concurrencyPermit
+	claimed atomic.Bool
+	endOnce sync.Once
+}
+
+type streamObservationKey struct{}
+
+func (o *streamObservation) claim() bool {
+	return o != nil && o.claimed.CompareAndSwap(false, true)
+}
+
+// end records the outcome of the stream. session reports whether a session
+// had been created and is counted as active.
+func (o *streamObservation) end(err error, session bool) {
+	o.endOnce.Do(func() {
+		if session {
+			o.metrics.activeStreams.Add(-1)
+		}
+		o.permit.release()
+		o.metrics.observe(time.Since(o.start), err)
+		if o.span != nil {
+			o.span.End(err)
+		}
+	})
+}
+
+// observeStreamOperation runs a stream operation under metrics and the active
+// tracer. Start opens the session observation; any operation that removes the
+// session ends it.
+func observeStreamOperation(ctx context.Context, tracer Tracer, call CallInfo, next StreamFunc) error {
+	if call.Operation == CallOperationStart {
+		observation := &streamObservation{call: call, metrics: methodMetricsFor(call), start: time.Now()}
+		observation.metrics.calls.Add(1)
+		if tracer != nil {
+			ctx, observation.span = startCallSpan(ctx, tracer, call)
+		}
+		err := next(context.WithValue(ctx, streamObservationKey{}, observation), call)
+		if observation.claim() {
+			// No session took the observation, so the stream never started.
+			observation.end(err, false)
+		}
+		return err
+	}
+
+	entry, loadErr := LoadStreamSession(call.Stream)
+	if loadErr != nil {
+		return next(ctx, call)
+	}
+	entry.beginOperation()
+	defer entry.endOperation()
+	if entry.observation == nil {
+		return next(ctx, call)
+	}
+	if span := entry.observation.span; span != nil {
+		ctx = ContextWithSpanContext(ctx, span.SpanContext())
+	}
+	err := next(ctx, call)
+	if current, loadErr := LoadStreamSession(call.Stream); loadErr == nil && current == entry {
+		return err
+	}
+	endErr := err
+	switch {
+	case errors.Is(endErr, io.EOF):
+		endErr = nil
+	case endErr == nil && call.Operation == CallOperationCancel:
+		endErr = context.Canceled
+	}
+	entry.observation.end(endErr, true)
+	return err
+}
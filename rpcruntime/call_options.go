@@ -0,0 +1,249 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// CallOptionsHandle identifies call options created for a C caller.
+type CallOptionsHandle int32
+
+var ErrCallOptionsInvalidHandle = errors.New("call options handle is invalid")
+
+// callOptions carries the deadline settings, request metadata and cancel token
+// shared by every call made with one handle. ctx is canceled by
+// CancelCallOptions and ReleaseCallOptions, which cancels every call still
+// using the handle. response collects the response metadata of the most
+// recently started unary call or stream.
+type callOptions struct {
+	mu       sync.Mutex
+	timeout  time.Duration
+	deadline time.Time
+	metadata Metadata
+	response *ResponseMetadata
+	registry *ServerRegistry
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+type callOptionsRegistry struct {
+	mu      sync.Mutex
+	next    CallOptionsHandle
+	entries map[CallOptionsHandle]*callOptions
+}
+
+var callOptionsEntries callOptionsRegistry
+
+// NewCallOptions creates a call options handle.
+// A positive timeout bounds each call made with the handle, measured from the
+// start of that call; zero or a negative timeout leaves calls unbounded.
+func NewCallOptions(timeout time.Duration) (CallOptionsHandle, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	entry := &callOptions{ctx: ctx, cancel: cancel}
+	if timeout > 0 {
+		entry.timeout = timeout
+	}
+	handle, err := callOptionsEntries.create(entry)
+	if err != nil {
+		cancel()
+		return 0, err
+	}
+	return handle, nil
+}
+
+// SetCallOptionsDeadline sets an absolute deadline shared by every later call
+// made with handle. A zero deadline clears it.
+func SetCallOptionsDeadline(handle CallOptionsHandle, deadline time.Time) error {
+	entry, ok := callOptionsEntries.load(handle)
+	if !ok {
+		return ErrCallOptionsInvalidHandle
+	}
+	entry.mu.Lock()
+	entry.deadline = deadline
+	entry.mu.Unlock()
+	return nil
+}
+
+// SetCallOptionsMetadata sets the request metadata sent by every later call
+// made with handle. A nil md clears it.
+func SetCallOptionsMetadata(handle CallOptionsHandle, md Metadata) error {
+	entry, ok := callOptionsEntries.load(handle)
+	if !ok {
+		return ErrCallOptionsInvalidHandle
+	}
+	entry.mu.Lock()
+	entry.metadata = md.Copy()
+	entry.mu.Unlock()
+	return nil
+}
+
+// SetCallOptionsServerRegistry routes every later call made with handle
+// through registry, as WithServerRegistry does. A nil registry selects the
+// default registry.
+func SetCallOptionsServerRegistry(handle CallOptionsHandle, registry *ServerRegistry) error {
+	entry, ok := callOptionsEntries.load(handle)
+	if !ok {
+		return ErrCallOptionsInvalidHandle
+	}
+	entry.mu.Lock()
+	entry.registry = registry
+	entry.mu.Unlock()
+	return nil
+}
+
+// TakeCallOptionsResponseMetadata returns and clears the response headers and
+// trailers collected for the most recent call made with handle. Headers and
+// trailers of a stream are available once the stream has finished.
+func TakeCallOptionsResponseMetadata(handle CallOptionsHandle) (Metadata, Metadata, error) {
+	entry, ok := callOptionsEntries.load(handle)
+	if !ok {
+		return nil, nil, ErrCallOptionsInvalidHandle
+	}
+	entry.mu.Lock()
+	response := entry.response
+	entry.mu.Unlock()
+	if response == nil {
+		return nil, nil, nil
+	}
+	header, trailer := response.take()
+	return header, trailer, nil
+}
+
+// CancelCallOptions cancels every in-flight call and stream started with
+// handle. The handle stays valid, but later calls made with it fail with
+// context.Canceled until it is released.
+func CancelCallOptions(handle CallOptionsHandle) error {
+	entry, ok := callOptionsEntries.load(handle)
+	if !ok {
+		return ErrCallOptionsInvalidHandle
+	}
+	entry.cancel()
+	return nil
+}
+
+// ReleaseCallOptions cancels handle and forgets it.
+func ReleaseCallOptions(handle CallOptionsHandle) error {
+	entry, ok := callOptionsEntries.take(handle)
+	if !ok {
+		return ErrCallOptionsInvalidHandle
+	}
+	entry.cancel()
+	return nil
+}
+
+// CallOptionsContext derives the context for one call made with handle. The
+// context carries the handle's request metadata and collects the call's
+// response metadata for TakeCallOptionsResponseMetadata, and selects the
+// registry set with SetCallOptionsServerRegistry.
+//
+// The returned cancel must be called once the call returns. Streams started
+// with the context keep it for their whole lifetime, so a stream start only
+// cancels it when the start fails; the stream then ends with the options
+// deadline, CancelCallOptions or ReleaseCallOptions.
+func CallOptionsContext(handle CallOptionsHandle) (context.Context, context.CancelFunc, error) {
+	return callOptionsContext(handle, true)
+}
+
+// CallOptionsStreamContext derives the context for one operation on a stream
+// started with handle, such as a send, receive or finish. It applies the same
+// deadline, metadata and registry as CallOptionsContext but keeps the response
+// metadata collector installed by the stream start, which records the
+// stream's headers and trailers.
+func CallOptionsStreamContext(handle CallOptionsHandle) (context.Context, context.CancelFunc, error) {
+	return callOptionsContext(handle, false)
+}
+
+func callOptionsContext(handle CallOptionsHandle, collectResponse bool) (context.Context, context.CancelFunc, error) {
+	entry, ok := callOptionsEntries.load(handle)
+	if !ok {
+		return nil, nil, ErrCallOptionsInvalidHandle
+	}
+	ctx := entry.ctx
+	entry.mu.Lock()
+	deadline := entry.deadline
+	timeout := entry.timeout
+	if len(entry.metadata) > 0 {
+		ctx = WithRequestMetadata(ctx, entry.metadata)
+	}
+	if entry.registry != nil {
+		ctx = WithServerRegistry(ctx, entry.registry)
+	}
+	if collectResponse {
+		ctx, entry.response = WithResponseMetadata(ctx)
+	}
+	entry.mu.Unlock()
+
+	if timeout > 0 {
+		callDeadline := time.Now().Add(timeout)
+		if deadline.IsZero() || callDeadline.Before(deadline) {
+			deadline = callDeadline
+		}
+	}
+	if deadline.IsZero() {
+		return ctx, func() {}, nil
+	}
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	return ctx, cancel, nil
+}
+
+// ContextError prefers the context error once ctx is done, so callers see
+// context.DeadlineExceeded or context.Canceled instead of the transport error
+// produced by tearing the call down.
+func ContextError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+func (r *callOptionsRegistry) create(entry *callOptions) (CallOptionsHandle, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.entries == nil {
+		r.entries = make(map[CallOptionsHandle]*callOptions)
+	}
+	next := r.next
+	for scanned := 0; scanned < 1<<31-1; scanned++ {
+		next++
+		if next <= 0 {
+			next = 1
+		}
+		if _, exists := r.entries[next]; exists {
+			continue
+		}
+		r.next = next
+		r.entries[next] = entry
+		return next, nil
+	}
+	return 0, errors.New("call options handle space exhausted")
+}
+
+func (r *callOptionsRegistry) load(handle CallOptionsHandle) (*callOptions, bool) {
+	if handle == 0 {
+		return nil, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry, ok := r.entries[handle]
+	return entry, ok
+}
+
+func (r *callOptionsRegistry) take(handle CallOptionsHandle) (*callOptions, bool) {
+	if handle == 0 {
+		return nil, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry, ok := r.entries[handle]
+	if ok {
+		delete(r.entries, handle)
+	}
+	return entry, ok
+}
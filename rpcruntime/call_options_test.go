@@ -0,0 +1,182 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestCallOptionsContextAppliesPerCallTimeout(t *testing.T) {
+	handle, err := NewCallOptions(50 * time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewCallOptions returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = ReleaseCallOptions(handle) })
+
+	ctx, cancel, err := CallOptionsContext(handle)
+	if err != nil {
+		t.Fatalf("CallOptionsContext returned error: %v", err)
+	}
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("call context has no deadline")
+	}
+	if remaining := time.Until(deadline); remaining <= 0 || remaining > 50*time.Millisecond {
+		t.Fatalf("call deadline in %v, want within 50ms", remaining)
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("call context did not expire")
+	}
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Fatalf("ctx.Err() = %v, want context.DeadlineExceeded", ctx.Err())
+	}
+}
+
+func TestCallOptionsContextUsesEarlierOfTimeoutAndDeadline(t *testing.T) {
+	handle, err := NewCallOptions(time.Hour)
+	if err != nil {
+		t.Fatalf("NewCallOptions returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = ReleaseCallOptions(handle) })
+
+	deadline := time.Now().Add(time.Minute)
+	if err := SetCallOptionsDeadline(handle, deadline); err != nil {
+		t.Fatalf("SetCallOptionsDeadline returned error: %v", err)
+	}
+	ctx, cancel, err := CallOptionsContext(handle)
+	if err != nil {
+		t.Fatalf("CallOptionsContext returned error: %v", err)
+	}
+	defer cancel()
+	if got, _ := ctx.Deadline(); !got.Equal(deadline) {
+		t.Fatalf("call deadline = %v, want %v", got, deadline)
+	}
+}
+
+func TestCallOptionsWithoutDeadlineStayOpenUntilCanceled(t *testing.T) {
+	handle, err := NewCallOptions(0)
+	if err != nil {
+		t.Fatalf("NewCallOptions returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = ReleaseCallOptions(handle) })
+
+	ctx, cancel, err := CallOptionsContext(handle)
+	if err != nil {
+		t.Fatalf("CallOptionsContext returned error: %v", err)
+	}
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Fatal("call context unexpectedly has a deadline")
+	}
+	if err := CancelCallOptions(handle); err != nil {
+		t.Fatalf("CancelCallOptions returned error: %v", err)
+	}
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Fatalf("ctx.Err() = %v, want context.Canceled", ctx.Err())
+	}
+}
+
+func TestReleaseCallOptionsInvalidatesHandle(t *testing.T) {
+	handle, err := NewCallOptions(0)
+	if err != nil {
+		t.Fatalf("NewCallOptions returned error: %v", err)
+	}
+	ctx, cancel, err := CallOptionsContext(handle)
+	if err != nil {
+		t.Fatalf("CallOptionsContext returned error: %v", err)
+	}
+	defer cancel()
+
+	if err := ReleaseCallOptions(handle); err != nil {
+		t.Fatalf("ReleaseCallOptions returned error: %v", err)
+	}
+	if ctx.Err() == nil {
+		t.Fatal("released call options did not cancel in-flight context")
+	}
+	if _, _, err := CallOptionsContext(handle); !errors.Is(err, ErrCallOptionsInvalidHandle) {
+		t.Fatalf("CallOptionsContext after release error = %v, want %v", err, ErrCallOptionsInvalidHandle)
+	}
+	if err := ReleaseCallOptions(handle); !errors.Is(err, ErrCallOptionsInvalidHandle) {
+		t.Fatalf("second ReleaseCallOptions error = %v, want %v", err, ErrCallOptionsInvalidHandle)
+	}
+}
+
+func TestStoreErrorReturnsReservedIDForDeadlineExceeded(t *testing.T) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
+	<-ctx.Done()
+	if id := StoreError(ctx.Err()); id != ErrorIDDeadlineExceeded {
+		t.Fatalf("StoreError(ctx.Err()) = %d, want ErrorIDDeadlineExceeded", id)
+	}
+
+	for range 2 {
+		data, ptr, ok := TakeErrorText(ErrorIDDeadlineExceeded)
+		if !ok {
+			t.Fatal("TakeErrorText(ErrorIDDeadlineExceeded) failed")
+		}
+		if string(data) != context.DeadlineExceeded.Error() {
+			t.Fatalf("deadline text = %q, want %q", data, context.DeadlineExceeded.Error())
+		}
+		Release(ptr)
+	}
+}
+
+func TestStoreErrorKeepsCodedDeadlineExceeded(t *testing.T) {
+	detail, err := anypb.New(wrapperspb.String("retry later"))
+	if err != nil {
+		t.Fatalf("anypb.New() error = %v", err)
+	}
+	coded := NewStatusError(ErrorCodeDeadlineExceeded, "backend slow")
+	coded.Details = []*anypb.Any{detail}
+	for name, tc := range map[string]struct {
+		err     error
+		message string
+	}{
+		"wrapped": {fmt.Errorf("call failed: %w", context.DeadlineExceeded), "call failed: context deadline exceeded"},
+		"connect": {connect.NewError(connect.CodeDeadlineExceeded, errors.New("slow")), "slow"},
+		"grpc":    {status.Error(codes.DeadlineExceeded, "slow"), "slow"},
+		"status":  {coded, "backend slow"},
+	} {
+		id := StoreError(tc.err)
+		if id == ErrorIDDeadlineExceeded || id <= 0 {
+			t.Fatalf("%s: StoreError = %d, want a stored id", name, id)
+		}
+		got, ok := TakeError(id)
+		if !ok {
+			t.Fatalf("%s: TakeError(%d) failed", name, id)
+		}
+		if got.Code != ErrorCodeDeadlineExceeded || got.Message != tc.message {
+			t.Fatalf("%s: TakeError = code %d message %q, want DeadlineExceeded %q", name, got.Code, got.Message, tc.message)
+		}
+		if name == "status" && (len(got.Details) != 1 || !proto.Equal(got.Details[0], detail)) {
+			t.Fatalf("%s: details = %v, want %v", name, got.Details, detail)
+		}
+	}
+}
+
+func TestContextErrorPrefersDoneContext(t *testing.T) {
+	transportErr := errors.New("stream reset")
+	if err := ContextError(context.Background(), transportErr); !errors.Is(err, transportErr) {
+		t.Fatalf("ContextError with live context = %v, want %v", err, transportErr)
+	}
+	if err := ContextError(context.Background(), nil); err != nil {
+		t.Fatalf("ContextError(nil) = %v, want nil", err)
+	}
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	if err := ContextError(ctx, transportErr); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ContextError with expired context = %v, want context.DeadlineExceeded", err)
+	}
+}
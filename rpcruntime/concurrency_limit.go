@@ -0,0 +1,304 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrConcurrencyLimitExceeded is returned by unary invokes and stream Starts
+// that find every slot of a concurrency limit taken and no slot frees within
+// its QueueTimeout. ErrorCodeOf maps it to ErrorCodeResourceExhausted.
+var ErrConcurrencyLimitExceeded = errors.New("rpccgo: concurrency limit exceeded")
+
+// ConcurrencyScope names what a ConcurrencyLimit applies to: one method of a
+// service, or every method of it when Method is empty. Limits are process-wide:
+// calls routed through any ServerRegistry share the slots of their scope.
+type ConcurrencyScope struct {
+	ServiceID ServiceID
+	// Method is the protobuf full method name.
+	Method string
+}
+
+func (s ConcurrencyScope) String() string {
+	if s.Method != "" {
+		return s.Method
+	}
+	return string(s.ServiceID)
+}
+
+// ConcurrencyLimit bounds the calls a scope runs at once. A call must fit both
+// the limit of its method and the limit of its service.
+type ConcurrencyLimit struct {
+	// MaxConcurrentUnary bounds unary invokes running at once. Zero leaves
+	// them unlimited.
+	MaxConcurrentUnary int
+	// MaxActiveStreams bounds stream sessions from Start until the operation
+	// that removes the session. Zero leaves them unlimited.
+	MaxActiveStreams int
+	// QueueTimeout is how long an excess call waits for a slot before it fails
+	// with ErrConcurrencyLimitExceeded. Zero fails fast. The call context
+	// also ends the wait.
+	QueueTimeout time.Duration
+}
+
+// ConcurrencyOccupancy is a point-in-time view of one configured limit.
+// Calls admitted before the limit was set are not counted.
+type ConcurrencyOccupancy struct {
+	Scope         ConcurrencyScope
+	Limit         ConcurrencyLimit
+	ActiveUnary   int
+	ActiveStreams int
+	QueuedUnary   int
+	QueuedStreams int
+	// Rejected counts calls that failed with ErrConcurrencyLimitExceeded.
+	Rejected uint64
+}
+
+type concurrencySlot int
+
+const (
+	concurrencyUnarySlot concurrencySlot = iota
+	concurrencyStreamSlot
+)
+
+type concurrencyLimiter struct {
+	scope ConcurrencyScope
+
+	mu       sync.Mutex
+	limit    ConcurrencyLimit
+	active   [2]int
+	queued   [2]int
+	rejected uint64
+	// freed is closed and replaced whenever a queued call may now fit.
+	freed chan struct{}
+}
+
+// concurrencyLimiters maps ConcurrencyScope to *concurrencyLimiter.
+var concurrencyLimiters sync.Map
+
+// SetConcurrencyLimit sets the limit of scope. Changing a limit keeps the
+// calls it already admitted and lets queued calls retry against it.
+func SetConcurrencyLimit(scope ConcurrencyScope, limit ConcurrencyLimit) error {
+	if err := validateServiceID(scope.ServiceID); err != nil {
+		return err
+	}
+	if limit.MaxConcurrentUnary < 0 || limit.MaxActiveStreams < 0 {
+		return fmt.Errorf("rpccgo: concurrency limit of %s is negative", scope)
+	}
+	if limit.QueueTimeout < 0 {
+		return fmt.Errorf("rpccgo: concurrency queue timeout of %s is negative", scope)
+	}
+	value, _ := concurrencyLimiters.LoadOrStore(scope, &concurrencyLimiter{scope: scope, freed: make(chan struct{})})
+	limiter := value.(*concurrencyLimiter)
+	limiter.mu.Lock()
+	limiter.limit = limit
+	limiter.wakeLocked()
+	limiter.mu.Unlock()
+	return nil
+}
+
+// ClearConcurrencyLimit removes the limit of scope. Queued calls proceed.
+func ClearConcurrencyLimit(scope ConcurrencyScope) {
+	value, ok := concurrencyLimiters.LoadAndDelete(scope)
+	if !ok {
+		return
+	}
+	limiter := value.(*concurrencyLimiter)
+	limiter.mu.Lock()
+	limiter.limit = ConcurrencyLimit{}
+	limiter.wakeLocked()
+	limiter.mu.Unlock()
+}
+
+// LoadConcurrencyOccupancy returns the occupancy of the limit set for scope.
+func LoadConcurrencyOccupancy(scope ConcurrencyScope) (ConcurrencyOccupancy, bool) {
+	value, ok := concurrencyLimiters.Load(scope)
+	if !ok {
+		return ConcurrencyOccupancy{}, false
+	}
+	return value.(*concurrencyLimiter).occupancy(), true
+}
+
+// SnapshotConcurrency returns the occupancy of every configured limit,
+// ordered by service id and method.
+func SnapshotConcurrency() []ConcurrencyOccupancy {
+	var out []ConcurrencyOccupancy
+	concurrencyLimiters.Range(func(_, value any) bool {
+		out = append(out, value.(*concurrencyLimiter).occupancy())
+		return true
+	})
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i].Scope, out[j].Scope
+		if a.ServiceID != b.ServiceID {
+			return a.ServiceID < b.ServiceID
+		}
+		return a.Method < b.Method
+	})
+	return out
+}
+
+// concurrencyPermit holds the slots one call took; release returns them.
+type concurrencyPermit struct {
+	slot     concurrencySlot
+	limiters [2]*concurrencyLimiter
+}
+
+// acquireConcurrency takes a slot from the method limit and then the service
+// limit of call, waiting as their QueueTimeout allows.
+func acquireConcurrency(ctx context.Context, call CallInfo, slot concurrencySlot) (concurrencyPermit, error) {
+	permit := concurrencyPermit{slot: slot}
+	scopes := [2]ConcurrencyScope{{ServiceID: call.ServiceID, Method: call.Method}, {ServiceID: call.ServiceID}}
+	for i, scope := range scopes {
+		if i == 0 && scope.Method == "" {
+			continue
+		}
+		value, ok := concurrencyLimiters.Load(scope)
+		if !ok {
+			continue
+		}
+		limiter := value.(*concurrencyLimiter)
+		if err := limiter.acquire(ctx, slot); err != nil {
+			permit.release()
+			return concurrencyPermit{}, err
+		}
+		permit.limiters[i] = limiter
+	}
+	return permit, nil
+}
+
+func (p concurrencyPermit) release() {
+	for _, limiter := range p.limiters {
+		if limiter != nil {
+			limiter.release(p.slot)
+		}
+	}
+}
+
+func (l *concurrencyLimiter) acquire(ctx context.Context, slot concurrencySlot) error {
+	var timer *time.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for {
+		limit := l.limit.max(slot)
+		if limit == 0 || l.active[slot] < limit {
+			l.active[slot]++
+			return nil
+		}
+		if timer == nil {
+			if l.limit.QueueTimeout <= 0 {
+				return l.rejectLocked(slot, limit)
+			}
+			timer = time.NewTimer(l.limit.QueueTimeout)
+		}
+
+		freed := l.freed
+		l.queued[slot]++
+		l.mu.Unlock()
+		var timedOut bool
+		var ctxErr error
+		select {
+		case <-freed:
+		case <-timer.C:
+			timedOut = true
+		case <-ctx.Done():
+			ctxErr = ctx.Err()
+		}
+		l.mu.Lock()
+		l.queued[slot]--
+
+		switch {
+		case ctxErr != nil:
+			return ctxErr
+		case timedOut:
+			limit = l.limit.max(slot)
+			if limit == 0 || l.active[slot] < limit {
+				l.active[slot]++
+				return nil
+			}
+			return l.rejectLocked(slot, limit)
+		}
+	}
+}
+
+func (l *concurrencyLimiter) release(slot concurrencySlot) {
+	l.mu.Lock()
+	l.active[slot]--
+	l.wakeLocked()
+	l.mu.Unlock()
+}
+
+func (l *concurrencyLimiter) rejectLocked(slot concurrencySlot, limit int) error {
+	l.rejected++
+	if slot == concurrencyStreamSlot {
+		return fmt.Errorf("%w: %s allows %d active streams", ErrConcurrencyLimitExceeded, l.scope, limit)
+	}
+	return fmt.Errorf("%w: %s allows %d concurrent unary calls", ErrConcurrencyLimitExceeded, l.scope, limit)
+}
+
+func (l *concurrencyLimiter) wakeLocked() {
+	if l.queued[concurrencyUnarySlot] == 0 && l.queued[concurrencyStreamSlot] == 0 {
+		return
+	}
+	close(l.freed)
+	l.freed = make(chan struct{})
+}
+
+func (l *concurrencyLimiter) occupancy() ConcurrencyOccupancy {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return ConcurrencyOccupancy{
+		Scope:         l.scope,
+		Limit:         l.limit,
+		ActiveUnary:   l.active[concurrencyUnarySlot],
+		ActiveStreams: l.active[concurrencyStreamSlot],
+		QueuedUnary:   l.queued[concurrencyUnarySlot],
+		QueuedStreams: l.queued[concurrencyStreamSlot],
+		Rejected:      l.rejected,
+	}
+}
+
+func (l ConcurrencyLimit) max(slot concurrencySlot) int {
+	if slot == concurrencyStreamSlot {
+		return l.MaxActiveStreams
+	}
+	return l.MaxConcurrentUnary
+}
+
+// EncodeConcurrencySnapshot encodes limits as a
+// rpccgo.runtime.v1.ConcurrencySnapshot protobuf message; the schema ships as
+// concurrency_limits.proto next to this file.
+func EncodeConcurrencySnapshot(limits []ConcurrencyOccupancy) []byte {
+	var out []byte
+	for _, limit := range limits {
+		var entry []byte
+		entry = appendStringField(entry, 1, string(limit.Scope.ServiceID))
+		entry = appendStringField(entry, 2, limit.Scope.Method)
+		entry = appendVarintField(entry, 3, uint64(limit.Limit.MaxConcurrentUnary))
+		entry = appendVarintField(entry, 4, uint64(limit.Limit.MaxActiveStreams))
+		entry = appendVarintField(entry, 5, uint64(limit.Limit.QueueTimeout.Milliseconds()))
+		entry = appendVarintField(entry, 6, uint64(limit.ActiveUnary))
+		entry = appendVarintField(entry, 7, uint64(limit.ActiveStreams))
+		entry = appendVarintField(entry, 8, uint64(limit.QueuedUnary))
+		entry = appendVarintField(entry, 9, uint64(limit.QueuedStreams))
+		entry = appendVarintField(entry, 10, limit.Rejected)
+		out = appendMessageField(out, 1, entry)
+	}
+	return out
+}
+
+// EncodePinnedConcurrencySnapshot encodes the configured concurrency limits
+// into a pinned ptr/len payload for the C ABI. Callers must release a non-zero
+// pointer with Release after the ABI consumer is done with it.
+func EncodePinnedConcurrencySnapshot() (uintptr, int32, error) {
+	return pinPayload(EncodeConcurrencySnapshot(SnapshotConcurrency()))
+}
@@ -0,0 +1,261 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ygrpc/rpccgo/rpcruntime/internal/runtimev1"
+)
+
+var concurrencyTestCall = CallInfo{ServiceID: "rpccgo.test.v1.Greeter", Method: "rpccgo.test.v1.Greeter.SayHello"}
+
+// resetConcurrencyLimits removes every concurrency limit.
+func resetConcurrencyLimits() {
+	concurrencyLimiters.Range(func(key, _ any) bool {
+		ClearConcurrencyLimit(key.(ConcurrencyScope))
+		return true
+	})
+}
+
+// startBlockingUnary runs a unary call that holds its slot until release is
+// closed and returns its result channel once the call is running.
+func startBlockingUnary(t *testing.T, call CallInfo, release chan struct{}) chan error {
+	t.Helper()
+	running := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- InterceptUnary(context.Background(), call, func(context.Context, CallInfo) error {
+			close(running)
+			<-release
+			return nil
+		})
+	}()
+	select {
+	case <-running:
+	case err := <-done:
+		t.Fatalf("blocking unary call returned early: %v", err)
+	}
+	return done
+}
+
+func TestConcurrencyLimitRejectsExcessUnaryCallsFast(t *testing.T) {
+	t.Cleanup(resetConcurrencyLimits)
+	scope := ConcurrencyScope{ServiceID: concurrencyTestCall.ServiceID, Method: concurrencyTestCall.Method}
+	if err := SetConcurrencyLimit(scope, ConcurrencyLimit{MaxConcurrentUnary: 1}); err != nil {
+		t.Fatalf("SetConcurrencyLimit returned error: %v", err)
+	}
+
+	release := make(chan struct{})
+	done := startBlockingUnary(t, concurrencyTestCall, release)
+	ran := false
+	err := InterceptUnary(context.Background(), concurrencyTestCall, func(context.Context, CallInfo) error {
+		ran = true
+		return nil
+	})
+	if !errors.Is(err, ErrConcurrencyLimitExceeded) || ran {
+		t.Fatalf("InterceptUnary beyond the limit = %v (ran %v), want ErrConcurrencyLimitExceeded", err, ran)
+	}
+	if got := ErrorCodeOf(err); got != ErrorCodeResourceExhausted {
+		t.Fatalf("ErrorCodeOf(limit error) = %v, want ResourceExhausted", got)
+	}
+	other := CallInfo{ServiceID: concurrencyTestCall.ServiceID, Method: "rpccgo.test.v1.Greeter.SayBye"}
+	if err := InterceptUnary(context.Background(), other, func(context.Context, CallInfo) error { return nil }); err != nil {
+		t.Fatalf("InterceptUnary of another method returned error: %v", err)
+	}
+	if got, ok := LoadConcurrencyOccupancy(scope); !ok || got.ActiveUnary != 1 || got.Rejected != 1 {
+		t.Fatalf("LoadConcurrencyOccupancy = %+v, %v; want one active and one rejected call", got, ok)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("blocking unary call returned error: %v", err)
+	}
+	if got, _ := LoadConcurrencyOccupancy(scope); got.ActiveUnary != 0 {
+		t.Fatalf("ActiveUnary after the call = %d, want 0", got.ActiveUnary)
+	}
+}
+
+func TestConcurrencyLimitQueuesUnaryCallsUntilTimeout(t *testing.T) {
+	t.Cleanup(resetConcurrencyLimits)
+	scope := ConcurrencyScope{ServiceID: concurrencyTestCall.ServiceID}
+	if err := SetConcurrencyLimit(scope, ConcurrencyLimit{MaxConcurrentUnary: 1, QueueTimeout: 20 * time.Millisecond}); err != nil {
+		t.Fatalf("SetConcurrencyLimit returned error: %v", err)
+	}
+	release := make(chan struct{})
+	done := startBlockingUnary(t, concurrencyTestCall, release)
+
+	started := time.Now()
+	err := InterceptUnary(context.Background(), concurrencyTestCall, func(context.Context, CallInfo) error { return nil })
+	if !errors.Is(err, ErrConcurrencyLimitExceeded) || time.Since(started) < 20*time.Millisecond {
+		t.Fatalf("queued InterceptUnary = %v after %v, want ErrConcurrencyLimitExceeded after the queue timeout", err, time.Since(started))
+	}
+
+	if err := SetConcurrencyLimit(scope, ConcurrencyLimit{MaxConcurrentUnary: 1, QueueTimeout: time.Minute}); err != nil {
+		t.Fatalf("SetConcurrencyLimit returned error: %v", err)
+	}
+	queued := make(chan error, 1)
+	go func() {
+		queued <- InterceptUnary(context.Background(), concurrencyTestCall, func(context.Context, CallInfo) error { return nil })
+	}()
+	for {
+		if got, _ := LoadConcurrencyOccupancy(scope); got.QueuedUnary == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("blocking unary call returned error: %v", err)
+	}
+	if err := <-queued; err != nil {
+		t.Fatalf("queued InterceptUnary returned error: %v, want it to run once the slot freed", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	release = make(chan struct{})
+	defer close(release)
+	startBlockingUnary(t, concurrencyTestCall, release)
+	if err := InterceptUnary(ctx, concurrencyTestCall, func(context.Context, CallInfo) error { return nil }); !errors.Is(err, context.Canceled) {
+		t.Fatalf("InterceptUnary with a canceled context = %v, want context.Canceled", err)
+	}
+}
+
+func TestConcurrencyLimitHoldsStreamSlotUntilSessionRemoved(t *testing.T) {
+	ResetStreamSessionsForTesting()
+	t.Cleanup(ResetStreamSessionsForTesting)
+	t.Cleanup(resetConcurrencyLimits)
+	scope := ConcurrencyScope{ServiceID: concurrencyTestCall.ServiceID}
+	if err := SetConcurrencyLimit(scope, ConcurrencyLimit{MaxActiveStreams: 1}); err != nil {
+		t.Fatalf("SetConcurrencyLimit returned error: %v", err)
+	}
+	call := CallInfo{ServiceID: concurrencyTestCall.ServiceID, Method: "rpccgo.test.v1.Greeter.Chat"}
+
+	failed := call
+	failed.Operation = CallOperationStart
+	startErr := errors.New("start failed")
+	if err := InterceptStream(context.Background(), failed, func(context.Context, CallInfo) error { return startErr }); !errors.Is(err, startErr) {
+		t.Fatalf("failed Start returned %v", err)
+	}
+	handle := startStreamSession(t, call, ServerKindGoNative, &cancelableTestStream{})
+	if got, _ := LoadConcurrencyOccupancy(scope); got.ActiveStreams != 1 {
+		t.Fatalf("ActiveStreams = %d, want the session to hold the only slot", got.ActiveStreams)
+	}
+	ran := false
+	err := InterceptStream(context.Background(), failed, func(context.Context, CallInfo) error {
+		ran = true
+		return nil
+	})
+	if !errors.Is(err, ErrConcurrencyLimitExceeded) || ran {
+		t.Fatalf("Start beyond the limit = %v (ran %v), want ErrConcurrencyLimitExceeded", err, ran)
+	}
+
+	if err := CancelStreamSession(context.Background(), handle); err != nil {
+		t.Fatalf("CancelStreamSession returned error: %v", err)
+	}
+	if got, _ := LoadConcurrencyOccupancy(scope); got.ActiveStreams != 0 || got.Rejected != 1 {
+		t.Fatalf("occupancy after cancel = %+v, want a free slot and one rejection", got)
+	}
+	startStreamSession(t, call, ServerKindGoNative, &cancelableTestStream{})
+}
+
+func TestSetConcurrencyLimitValidatesAndClears(t *testing.T) {
+	t.Cleanup(resetConcurrencyLimits)
+	scope := ConcurrencyScope{ServiceID: concurrencyTestCall.ServiceID}
+	for name, tc := range map[string]struct {
+		scope ConcurrencyScope
+		limit ConcurrencyLimit
+	}{
+		"empty service id":       {scope: ConcurrencyScope{Method: concurrencyTestCall.Method}, limit: ConcurrencyLimit{MaxConcurrentUnary: 1}},
+		"negative unary limit":   {scope: scope, limit: ConcurrencyLimit{MaxConcurrentUnary: -1}},
+		"negative stream limit":  {scope: scope, limit: ConcurrencyLimit{MaxActiveStreams: -1}},
+		"negative queue timeout": {scope: scope, limit: ConcurrencyLimit{QueueTimeout: -time.Second}},
+	} {
+		if err := SetConcurrencyLimit(tc.scope, tc.limit); err == nil {
+			t.Fatalf("%s: SetConcurrencyLimit returned nil error", name)
+		}
+	}
+
+	method := ConcurrencyScope{ServiceID: concurrencyTestCall.ServiceID, Method: concurrencyTestCall.Method}
+	for _, s := range []ConcurrencyScope{method, scope} {
+		if err := SetConcurrencyLimit(s, ConcurrencyLimit{MaxConcurrentUnary: 2}); err != nil {
+			t.Fatalf("SetConcurrencyLimit(%v) returned error: %v", s, err)
+		}
+	}
+	if got := SnapshotConcurrency(); len(got) != 2 || got[0].Scope != scope || got[1].Scope != method {
+		t.Fatalf("SnapshotConcurrency = %+v, want the service limit before the method limit", got)
+	}
+	ClearConcurrencyLimit(method)
+	if _, ok := LoadConcurrencyOccupancy(method); ok {
+		t.Fatal("expected the method limit to be cleared")
+	}
+}
+
+func TestEncodeConcurrencySnapshotMatchesSchema(t *testing.T) {
+	data := EncodeConcurrencySnapshot([]ConcurrencyOccupancy{{
+		Scope:         ConcurrencyScope{ServiceID: "rpccgo.test.v1.Greeter", Method: "rpccgo.test.v1.Greeter.Chat"},
+		Limit:         ConcurrencyLimit{MaxConcurrentUnary: 4, MaxActiveStreams: 2, QueueTimeout: 1500 * time.Millisecond},
+		ActiveUnary:   3,
+		ActiveStreams: 2,
+		QueuedUnary:   1,
+		QueuedStreams: 5,
+		Rejected:      9,
+	}, {
+		Scope: ConcurrencyScope{ServiceID: "rpccgo.test.v1.Greeter"},
+		Limit: ConcurrencyLimit{MaxActiveStreams: 1},
+	}})
+
+	assertPayloadMatchesSchema(t, data, &runtimev1.ConcurrencySnapshot{
+		Limits: []*runtimev1.ConcurrencyOccupancy{{
+			ServiceId:          "rpccgo.test.v1.Greeter",
+			Method:             "rpccgo.test.v1.Greeter.Chat",
+			MaxConcurrentUnary: 4,
+			MaxActiveStreams:   2,
+			QueueTimeoutMs:     1500,
+			ActiveUnary:        3,
+			ActiveStreams:      2,
+			QueuedUnary:        1,
+			QueuedStreams:      5,
+			Rejected:           9,
+		}, {
+			ServiceId:        "rpccgo.test.v1.Greeter",
+			MaxActiveStreams: 1,
+		}},
+	})
+}
+
+func TestConcurrencyLimitIsSharedAcrossServerRegistries(t *testing.T) {
+	t.Cleanup(resetConcurrencyLimits)
+	scope := ConcurrencyScope{ServiceID: concurrencyTestCall.ServiceID}
+	if err := SetConcurrencyLimit(scope, ConcurrencyLimit{MaxConcurrentUnary: 1}); err != nil {
+		t.Fatalf("SetConcurrencyLimit returned error: %v", err)
+	}
+	first, second := NewServerRegistry(), NewServerRegistry()
+	for _, registry := range []*ServerRegistry{first, second} {
+		if err := registry.Register(concurrencyTestCall.ServiceID, RegisteredServer{Kind: ServerKindGoNative, Server: testRegisteredServer{name: "greeter"}}); err != nil {
+			t.Fatalf("Register returned error: %v", err)
+		}
+	}
+
+	running := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- first.RouteUnary(context.Background(), concurrencyTestCall, func(context.Context, RegisteredServer) error {
+			close(running)
+			<-release
+			return nil
+		})
+	}()
+	<-running
+	err := second.RouteUnary(context.Background(), concurrencyTestCall, func(context.Context, RegisteredServer) error { return nil })
+	if !errors.Is(err, ErrConcurrencyLimitExceeded) {
+		t.Fatalf("RouteUnary in another registry = %v, want ErrConcurrencyLimitExceeded from the shared limit", err)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("blocking unary call returned error: %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package rpcruntime
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	_ "unsafe" // for go:linkname
+
+	"connectrpc.com/connect"
+)
+
+// connectNewHandlerContext attaches a handler CallInfo the same way connect
+// handlers do, so handlers invoked directly through the registry can read
+// request headers and write response metadata with CallInfoForHandlerContext.
+//
+//go:linkname connectNewHandlerContext connectrpc.com/connect.newHandlerContext
+func connectNewHandlerContext(ctx context.Context, info connect.CallInfo) context.Context
+
+// connectHandlerCallInfo is the CallInfo seen by a Connect handler called
+// directly through the registry. The embedded interface is always nil; it only
+// supplies connect's unexported marker method.
+type connectHandlerCallInfo struct {
+	connect.CallInfo
+	spec            connect.Spec
+	requestHeader   http.Header
+	responseHeader  http.Header
+	responseTrailer http.Header
+}
+
+func (c *connectHandlerCallInfo) Spec() connect.Spec { return c.spec }
+
+func (c *connectHandlerCallInfo) Peer() connect.Peer { return connect.Peer{} }
+
+func (c *connectHandlerCallInfo) RequestHeader() http.Header { return c.requestHeader }
+
+func (c *connectHandlerCallInfo) ResponseHeader() http.Header { return c.responseHeader }
+
+func (c *connectHandlerCallInfo) ResponseTrailer() http.Header { return c.responseTrailer }
+
+func (c *connectHandlerCallInfo) HTTPMethod() string { return http.MethodPost }
+
+// connectStreamingCallInfo is the CallInfo seen by a streaming Connect handler
+// called directly through the registry; it reads through to conn.
+type connectStreamingCallInfo struct {
+	connect.CallInfo
+	conn *ConnectStreamingHandlerConn
+}
+
+func (c *connectStreamingCallInfo) Spec() connect.Spec { return c.conn.Spec() }
+
+func (c *connectStreamingCallInfo) Peer() connect.Peer { return c.conn.Peer() }
+
+func (c *connectStreamingCallInfo) RequestHeader() http.Header { return c.conn.RequestHeader() }
+
+func (c *connectStreamingCallInfo) ResponseHeader() http.Header { return c.conn.ResponseHeader() }
+
+func (c *connectStreamingCallInfo) ResponseTrailer() http.Header { return c.conn.ResponseTrailer() }
+
+func (c *connectStreamingCallInfo) HTTPMethod() string { return http.MethodPost }
+
+// ConnectHandlerContext prepares ctx for a unary Connect handler called
+// directly through the registry. The handler sees the request metadata of ctx
+// as request headers. The returned finish reports the headers and trailers the
+// handler set and must be called once the handler returns.
+func ConnectHandlerContext(ctx context.Context, procedure string) (context.Context, func()) {
+	info := &connectHandlerCallInfo{
+		spec:            connect.Spec{Procedure: procedure, StreamType: connect.StreamTypeUnary},
+		requestHeader:   httpHeaderFromMetadata(RequestMetadataFromContext(ctx)),
+		responseHeader:  http.Header{},
+		responseTrailer: http.Header{},
+	}
+	return connectNewHandlerContext(ctx, info), sync.OnceFunc(func() {
+		SetResponseHeader(ctx, info.responseHeader)
+		SetResponseTrailer(ctx, info.responseTrailer)
+	})
+}
+
+// ConnectStreamingHandlerContext prepares ctx and conn for a streaming Connect
+// handler called directly through the registry. The handler sees the request
+// metadata of ctx as request headers. Response headers are reported when the
+// handler sends its first message, and the returned finish reports the rest
+// once the handler returns.
+func ConnectStreamingHandlerContext(ctx context.Context, conn *ConnectStreamingHandlerConn) (context.Context, func()) {
+	conn.RequestHeaderValue = httpHeaderFromMetadata(RequestMetadataFromContext(ctx))
+	var headerOnce sync.Once
+	reportHeader := func() { SetResponseHeader(ctx, conn.ResponseHeader()) }
+	if send := conn.SendFunc; send != nil {
+		conn.SendFunc = func(message any) error {
+			headerOnce.Do(reportHeader)
+			return send(message)
+		}
+	}
+	return connectNewHandlerContext(ctx, &connectStreamingCallInfo{conn: conn}), sync.OnceFunc(func() {
+		headerOnce.Do(reportHeader)
+		SetResponseTrailer(ctx, conn.ResponseTrailer())
+	})
+}
+
+// ConnectClientContext prepares ctx for a call on a remote Connect client. The
+// request metadata of ctx is sent as request headers. The returned finish
+// reports the response headers and trailers once the response has been fully
+// received; later calls do nothing.
+func ConnectClientContext(ctx context.Context) (context.Context, func()) {
+	callCtx, info := connect.NewClientContext(ctx)
+	header := info.RequestHeader()
+	for key, values := range RequestMetadataFromContext(ctx) {
+		for _, value := range values {
+			header.Add(key, value)
+		}
+	}
+	return callCtx, sync.OnceFunc(func() {
+		SetResponseHeader(ctx, info.ResponseHeader())
+		SetResponseTrailer(ctx, info.ResponseTrailer())
+	})
+}
+
+func httpHeaderFromMetadata(md Metadata) http.Header {
+	header := make(http.Header, len(md))
+	for key, values := range md {
+		for _, value := range values {
+			header.Add(key, value)
+		}
+	}
+	return header
+}
@@ -0,0 +1,316 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// ErrUnknownMethod is returned by dynamic invocation when no generated method
+// matches the requested service and method. ErrorCodeOf maps it to
+// ErrorCodeUnimplemented.
+var ErrUnknownMethod = errors.New("rpccgo: unknown method")
+
+// DynamicMethod dispatches the message contract operations of one generated
+// method on encoded protobuf bytes. Generated runtime files build one per
+// method with DynamicUnary, DynamicClientStream, DynamicServerStream or
+// DynamicBidiStream around their message facades and register it with
+// RegisterDynamicMethod.
+type DynamicMethod struct {
+	streaming  MethodStreaming
+	newRequest func() protobuf.Message
+	invoke     func(context.Context, protobuf.Message) (protobuf.Message, error)
+	start      func(context.Context, protobuf.Message) (StreamHandle, error)
+	send       func(context.Context, StreamHandle, protobuf.Message) error
+	recv       func(context.Context, StreamHandle) (protobuf.Message, error)
+	closeSend  func(context.Context, StreamHandle) error
+	finish     func(context.Context, StreamHandle) (protobuf.Message, error)
+	cancel     func(context.Context, StreamHandle) error
+}
+
+// messagePointer is satisfied by *T for generated protobuf message types T.
+type messagePointer[T any] interface {
+	*T
+	protobuf.Message
+}
+
+// DynamicUnary adapts a generated Invoke<Service>Message<Method> facade.
+func DynamicUnary[Req any, ReqPtr messagePointer[Req], Resp protobuf.Message](invoke func(context.Context, ReqPtr) (Resp, error)) DynamicMethod {
+	return DynamicMethod{
+		streaming:  MethodStreamingUnary,
+		newRequest: func() protobuf.Message { return ReqPtr(new(Req)) },
+		invoke: func(ctx context.Context, req protobuf.Message) (protobuf.Message, error) {
+			return invoke(ctx, req.(ReqPtr))
+		},
+	}
+}
+
+// DynamicClientStream adapts the generated message facades of a
+// client-streaming method.
+func DynamicClientStream[Req any, ReqPtr messagePointer[Req], Resp protobuf.Message](
+	start func(context.Context) (StreamHandle, error),
+	send func(context.Context, StreamHandle, ReqPtr) error,
+	finish func(context.Context, StreamHandle) (Resp, error),
+	cancel func(context.Context, StreamHandle) error,
+) DynamicMethod {
+	return DynamicMethod{
+		streaming:  MethodStreamingClient,
+		newRequest: func() protobuf.Message { return ReqPtr(new(Req)) },
+		start:      func(ctx context.Context, _ protobuf.Message) (StreamHandle, error) { return start(ctx) },
+		send: func(ctx context.Context, handle StreamHandle, req protobuf.Message) error {
+			return send(ctx, handle, req.(ReqPtr))
+		},
+		finish: func(ctx context.Context, handle StreamHandle) (protobuf.Message, error) {
+			return finish(ctx, handle)
+		},
+		cancel: cancel,
+	}
+}
+
+// DynamicServerStream adapts the generated message facades of a
+// server-streaming method.
+func DynamicServerStream[Req any, ReqPtr messagePointer[Req], Resp protobuf.Message](
+	start func(context.Context, ReqPtr) (StreamHandle, error),
+	recv func(context.Context, StreamHandle) (Resp, error),
+	cancel func(context.Context, StreamHandle) error,
+) DynamicMethod {
+	return DynamicMethod{
+		streaming:  MethodStreamingServer,
+		newRequest: func() protobuf.Message { return ReqPtr(new(Req)) },
+		start: func(ctx context.Context, req protobuf.Message) (StreamHandle, error) {
+			return start(ctx, req.(ReqPtr))
+		},
+		recv: func(ctx context.Context, handle StreamHandle) (protobuf.Message, error) {
+			return recv(ctx, handle)
+		},
+		cancel: cancel,
+	}
+}
+
+// DynamicBidiStream adapts the generated message facades of a
+// bidi-streaming method.
+func DynamicBidiStream[Req any, ReqPtr messagePointer[Req], Resp protobuf.Message](
+	start func(context.Context) (StreamHandle, error),
+	send func(context.Context, StreamHandle, ReqPtr) error,
+	recv func(context.Context, StreamHandle) (Resp, error),
+	closeSend func(context.Context, StreamHandle) error,
+	finish func(context.Context, StreamHandle) error,
+	cancel func(context.Context, StreamHandle) error,
+) DynamicMethod {
+	return DynamicMethod{
+		streaming:  MethodStreamingBidi,
+		newRequest: func() protobuf.Message { return ReqPtr(new(Req)) },
+		start:      func(ctx context.Context, _ protobuf.Message) (StreamHandle, error) { return start(ctx) },
+		send: func(ctx context.Context, handle StreamHandle, req protobuf.Message) error {
+			return send(ctx, handle, req.(ReqPtr))
+		},
+		recv: func(ctx context.Context, handle StreamHandle) (protobuf.Message, error) {
+			return recv(ctx, handle)
+		},
+		closeSend: closeSend,
+		finish: func(ctx context.Context, handle StreamHandle) (protobuf.Message, error) {
+			return nil, finish(ctx, handle)
+		},
+		cancel: cancel,
+	}
+}
+
+// dynamicMethods maps a protobuf full method name to its DynamicMethod.
+var dynamicMethods sync.Map
+
+// RegisterDynamicMethod makes method callable by InvokeDynamic and the
+// dynamic stream operations under its protobuf full method name.
+func RegisterDynamicMethod(fullMethod string, method DynamicMethod) {
+	dynamicMethods.Store(fullMethod, method)
+}
+
+// lookupDynamicMethod accepts a method name relative to serviceID or a
+// protobuf full method name.
+func lookupDynamicMethod(serviceID ServiceID, method string) (DynamicMethod, error) {
+	fullMethod := method
+	if serviceID != "" && !strings.HasPrefix(method, string(serviceID)+".") {
+		fullMethod = string(serviceID) + "." + method
+	}
+	value, ok := dynamicMethods.Load(fullMethod)
+	if !ok {
+		return DynamicMethod{}, fmt.Errorf("%w %s", ErrUnknownMethod, fullMethod)
+	}
+	return value.(DynamicMethod), nil
+}
+
+// dynamicStreamMethod returns the method of the message stream session
+// behind handle.
+func dynamicStreamMethod(handle StreamHandle) (DynamicMethod, error) {
+	session, err := LoadStreamSession(handle)
+	if err != nil {
+		return DynamicMethod{}, err
+	}
+	info := session.info(handle)
+	if info.Contract != CallContractMessage {
+		return DynamicMethod{}, ErrStreamInvalidHandle
+	}
+	return lookupDynamicMethod("", info.Method)
+}
+
+func (m DynamicMethod) decodeRequest(request []byte) (protobuf.Message, error) {
+	req := m.newRequest()
+	if err := protobuf.Unmarshal(request, req); err != nil {
+		return nil, fmt.Errorf("rpccgo: message request decode failed: %w", err)
+	}
+	return req, nil
+}
+
+func encodeDynamicResponse(resp protobuf.Message) ([]byte, error) {
+	if resp == nil {
+		return nil, nil
+	}
+	data, err := protobuf.Marshal(resp)
+	if err != nil {
+		return nil, fmt.Errorf("rpccgo: message response encode failed: %w", err)
+	}
+	return data, nil
+}
+
+func unsupportedDynamicOperation(streaming MethodStreaming, operation string) error {
+	shapes := [...]string{"unary", "client-streaming", "server-streaming", "bidi-streaming"}
+	return NewStatusError(ErrorCodeFailedPrecondition, fmt.Sprintf("rpccgo: %s methods do not support %s", shapes[streaming], operation))
+}
+
+// InvokeDynamic calls a unary method by name with an encoded request and
+// returns the encoded response. method is relative to serviceID or a protobuf
+// full method name.
+func InvokeDynamic(ctx context.Context, serviceID ServiceID, method string, request []byte) ([]byte, error) {
+	m, err := lookupDynamicMethod(serviceID, method)
+	if err != nil {
+		return nil, err
+	}
+	if m.invoke == nil {
+		return nil, unsupportedDynamicOperation(m.streaming, "Invoke")
+	}
+	req, err := m.decodeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := m.invoke(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return encodeDynamicResponse(resp)
+}
+
+// StartDynamicStream starts a streaming method by name. request is the
+// encoded request of a server-streaming method and is ignored otherwise.
+func StartDynamicStream(ctx context.Context, serviceID ServiceID, method string, request []byte) (StreamHandle, error) {
+	m, err := lookupDynamicMethod(serviceID, method)
+	if err != nil {
+		return 0, err
+	}
+	if m.start == nil {
+		return 0, unsupportedDynamicOperation(m.streaming, "Start")
+	}
+	var req protobuf.Message
+	if m.streaming == MethodStreamingServer {
+		if req, err = m.decodeRequest(request); err != nil {
+			return 0, err
+		}
+	}
+	return m.start(ctx, req)
+}
+
+// SendDynamicStream sends an encoded request on a message stream.
+func SendDynamicStream(ctx context.Context, handle StreamHandle, request []byte) error {
+	m, err := dynamicStreamMethod(handle)
+	if err != nil {
+		return err
+	}
+	if m.send == nil {
+		return unsupportedDynamicOperation(m.streaming, "Send")
+	}
+	req, err := m.decodeRequest(request)
+	if err != nil {
+		return err
+	}
+	return m.send(ctx, handle, req)
+}
+
+// RecvDynamicStream receives the next encoded response of a message stream.
+// The end of the stream is io.EOF.
+func RecvDynamicStream(ctx context.Context, handle StreamHandle) ([]byte, error) {
+	m, err := dynamicStreamMethod(handle)
+	if err != nil {
+		return nil, err
+	}
+	if m.recv == nil {
+		return nil, unsupportedDynamicOperation(m.streaming, "Recv")
+	}
+	if StreamCallbackReceiveEnabled(handle) {
+		return nil, errors.New("rpccgo: stream receive is owned by callback receive mode")
+	}
+	resp, err := m.recv(ctx, handle)
+	if err != nil {
+		return nil, err
+	}
+	return encodeDynamicResponse(resp)
+}
+
+// CloseSendDynamicStream closes the send side of a bidi message stream.
+func CloseSendDynamicStream(ctx context.Context, handle StreamHandle) error {
+	m, err := dynamicStreamMethod(handle)
+	if err != nil {
+		return err
+	}
+	if m.closeSend == nil {
+		return unsupportedDynamicOperation(m.streaming, "CloseSend")
+	}
+	return m.closeSend(ctx, handle)
+}
+
+// FinishDynamicStream finishes a client or bidi message stream and releases
+// its handle. A client stream returns its encoded response; a bidi stream
+// returns nil.
+func FinishDynamicStream(ctx context.Context, handle StreamHandle) ([]byte, error) {
+	m, err := dynamicStreamMethod(handle)
+	if err != nil {
+		return nil, err
+	}
+	if m.finish == nil {
+		return nil, unsupportedDynamicOperation(m.streaming, "Finish")
+	}
+	var resp protobuf.Message
+	err = withCallbackReceiveStopped(handle, func() error {
+		resp, err = m.finish(ctx, handle)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return encodeDynamicResponse(resp)
+}
+
+// CancelDynamicStream cancels a message stream and releases its handle.
+func CancelDynamicStream(ctx context.Context, handle StreamHandle) error {
+	m, err := dynamicStreamMethod(handle)
+	if err != nil {
+		return err
+	}
+	return withCallbackReceiveStopped(handle, func() error { return m.cancel(ctx, handle) })
+}
+
+// withCallbackReceiveStopped runs op like the generated Finish and Cancel
+// exports: a callback receive loop is told to stop first and op returns after
+// its done callback.
+func withCallbackReceiveStopped(handle StreamHandle, op func() error) error {
+	session, _ := StreamCallbackReceiveState(handle)
+	if session != nil {
+		session.MarkCanceled()
+	}
+	err := op()
+	if session != nil {
+		session.WaitDone()
+	}
+	return err
+}
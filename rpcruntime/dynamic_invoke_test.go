@@ -0,0 +1,51 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/health/grpc_health_v1"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+func TestInvokeDynamicDispatchesByMethodName(t *testing.T) {
+	const fullMethod = "grpc.health.v1.Health.Check"
+	t.Cleanup(func() { dynamicMethods.Delete(fullMethod) })
+	RegisterDynamicMethod(fullMethod, DynamicUnary(func(_ context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+		if req.GetService() != "greeter" {
+			return nil, NewStatusError(ErrorCodeNotFound, req.GetService())
+		}
+		return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+	}))
+	request, err := protobuf.Marshal(&grpc_health_v1.HealthCheckRequest{Service: "greeter"})
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	for _, method := range []string{"Check", fullMethod} {
+		data, err := InvokeDynamic(context.Background(), "grpc.health.v1.Health", method, request)
+		if err != nil {
+			t.Fatalf("InvokeDynamic(%q) returned error: %v", method, err)
+		}
+		resp := &grpc_health_v1.HealthCheckResponse{}
+		if err := protobuf.Unmarshal(data, resp); err != nil || resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+			t.Fatalf("InvokeDynamic(%q) response = %v, %v; want SERVING", method, resp, err)
+		}
+	}
+	if _, err := InvokeDynamic(context.Background(), "", fullMethod, nil); ErrorCodeOf(err) != ErrorCodeNotFound {
+		t.Fatalf("InvokeDynamic with an empty request = %v, want the handler error", err)
+	}
+	if _, err := InvokeDynamic(context.Background(), "grpc.health.v1.Health", "Missing", request); !errors.Is(err, ErrUnknownMethod) || ErrorCodeOf(err) != ErrorCodeUnimplemented {
+		t.Fatalf("InvokeDynamic of an unknown method = %v, want ErrUnknownMethod", err)
+	}
+	if _, err := InvokeDynamic(context.Background(), "", fullMethod, []byte{0xff}); err == nil {
+		t.Fatal("InvokeDynamic with a malformed request returned nil error")
+	}
+	if _, err := StartDynamicStream(context.Background(), "", fullMethod, request); ErrorCodeOf(err) != ErrorCodeFailedPrecondition {
+		t.Fatalf("StartDynamicStream of a unary method = %v, want FailedPrecondition", err)
+	}
+	if err := SendDynamicStream(context.Background(), 0, request); !errors.Is(err, ErrStreamInvalidHandle) {
+		t.Fatalf("SendDynamicStream without a session = %v, want ErrStreamInvalidHandle", err)
+	}
+}
@@ -0,0 +1,204 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"connectrpc.com/connect"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ErrorCode is the canonical RPC status code stored with an error id.
+// Values match google.rpc.Code, connect.Code and gRPC codes.Code.
+type ErrorCode int32
+
+const (
+	ErrorCodeOK ErrorCode = iota
+	ErrorCodeCanceled
+	ErrorCodeUnknown
+	ErrorCodeInvalidArgument
+	ErrorCodeDeadlineExceeded
+	ErrorCodeNotFound
+	ErrorCodeAlreadyExists
+	ErrorCodePermissionDenied
+	ErrorCodeResourceExhausted
+	ErrorCodeFailedPrecondition
+	ErrorCodeAborted
+	ErrorCodeOutOfRange
+	ErrorCodeUnimplemented
+	ErrorCodeInternal
+	ErrorCodeUnavailable
+	ErrorCodeDataLoss
+	ErrorCodeUnauthenticated
+)
+
+const maxErrorCode = ErrorCodeUnauthenticated
+
+// StatusError is an error with a canonical code and optional google.rpc.Status
+// details. C callbacks produce it through the coded store exports.
+type StatusError struct {
+	Code    ErrorCode
+	Message string
+	Details []*anypb.Any
+}
+
+func (e *StatusError) Error() string {
+	return e.Message
+}
+
+// GRPCStatus lets gRPC servers report the stored code when a StatusError is
+// returned from a handler.
+func (e *StatusError) GRPCStatus() *status.Status {
+	return status.FromProto(e.Proto())
+}
+
+// Proto returns the error as a google.rpc.Status message.
+func (e *StatusError) Proto() *spb.Status {
+	return &spb.Status{
+		Code:    int32(e.Code),
+		Message: e.Message,
+		Details: e.Details,
+	}
+}
+
+// NewStatusError returns a StatusError with code and message.
+func NewStatusError(code ErrorCode, message string) *StatusError {
+	return &StatusError{Code: normalizeErrorCode(code), Message: message}
+}
+
+// DecodeStatusError decodes a borrowed google.rpc.Status ptr/len payload into a
+// StatusError.
+func DecodeStatusError(ptr uintptr, length int32) (*StatusError, error) {
+	var decoded spb.Status
+	if err := DecodeMessage(ptr, length, &decoded); err != nil {
+		return nil, err
+	}
+	return &StatusError{
+		Code:    normalizeErrorCode(ErrorCode(decoded.GetCode())),
+		Message: decoded.GetMessage(),
+		Details: decoded.GetDetails(),
+	}, nil
+}
+
+// ErrorCodeOf returns the canonical code carried by err.
+//
+// Connect errors, gRPC status errors and StatusError keep their codes;
+// context errors and rpccgo runtime errors map to their canonical codes; any
+// other error is ErrorCodeUnknown.
+func ErrorCodeOf(err error) ErrorCode {
+	if err == nil {
+		return ErrorCodeOK
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return normalizeErrorCode(statusErr.Code)
+	}
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		return ErrorCode(connectErr.Code())
+	}
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		return ErrorCodeInternal
+	}
+	if grpcStatus, ok := status.FromError(err); ok {
+		return normalizeErrorCode(ErrorCode(grpcStatus.Code()))
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorCodeDeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return ErrorCodeCanceled
+	case errors.Is(err, ErrBufferTooSmall), errors.Is(err, ErrConcurrencyLimitExceeded):
+		return ErrorCodeResourceExhausted
+	case errors.Is(err, io.EOF):
+		return ErrorCodeOutOfRange
+	case errors.Is(err, ErrStreamInvalidHandle), errors.Is(err, ErrCallOptionsInvalidHandle), errors.Is(err, ErrEmptyServiceID):
+		return ErrorCodeInvalidArgument
+	case errors.Is(err, ErrNoRegisteredServer), errors.Is(err, ErrShutdown):
+		return ErrorCodeUnavailable
+	case errors.Is(err, ErrNoServiceManifest):
+		return ErrorCodeNotFound
+	case errors.Is(err, ErrUnknownMethod):
+		return ErrorCodeUnimplemented
+	}
+	return ErrorCodeUnknown
+}
+
+// ConnectError converts a coded StatusError into a *connect.Error so Connect
+// handlers report its code and details. Other errors are returned unchanged.
+func ConnectError(err error) error {
+	statusErr, ok := codedStatusError(err)
+	if !ok {
+		return err
+	}
+	connectErr := connect.NewError(connect.Code(statusErr.Code), errors.New(statusErr.Message))
+	for _, detail := range statusErr.Details {
+		errDetail, detailErr := connect.NewErrorDetail(detail)
+		if detailErr != nil {
+			continue
+		}
+		connectErr.AddDetail(errDetail)
+	}
+	return connectErr
+}
+
+// GRPCError converts a coded StatusError into a gRPC status error. Other
+// errors are returned unchanged.
+func GRPCError(err error) error {
+	statusErr, ok := codedStatusError(err)
+	if !ok {
+		return err
+	}
+	return status.ErrorProto(statusErr.Proto())
+}
+
+// codedStatusError reports whether err is a StatusError that carries more than
+// plain text, so uncoded callback errors keep their original text.
+func codedStatusError(err error) (*StatusError, bool) {
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		return nil, false
+	}
+	if statusErr.Code == ErrorCodeUnknown && len(statusErr.Details) == 0 {
+		return nil, false
+	}
+	return statusErr, true
+}
+
+// errorStatusOf returns the status message and details that accompany the code
+// of err. Transport errors drop their code prefix from the message.
+func errorStatusOf(err error) (string, []*anypb.Any) {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Message, statusErr.Details
+	}
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		details := make([]*anypb.Any, 0, len(connectErr.Details()))
+		for _, detail := range connectErr.Details() {
+			details = append(details, &anypb.Any{
+				TypeUrl: "type.googleapis.com/" + strings.TrimPrefix(detail.Type(), "type.googleapis.com/"),
+				Value:   detail.Bytes(),
+			})
+		}
+		return connectErr.Message(), details
+	}
+	if grpcStatus, ok := status.FromError(err); ok {
+		return grpcStatus.Message(), grpcStatus.Proto().GetDetails()
+	}
+	return err.Error(), nil
+}
+
+// normalizeErrorCode maps codes outside google.rpc.Code, and OK, which never
+// describes an error, to ErrorCodeUnknown.
+func normalizeErrorCode(code ErrorCode) ErrorCode {
+	if code <= ErrorCodeOK || code > maxErrorCode {
+		return ErrorCodeUnknown
+	}
+	return code
+}
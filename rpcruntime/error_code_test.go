@@ -0,0 +1,189 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"unsafe"
+
+	"connectrpc.com/connect"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestErrorCodeOfMapsKnownErrors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err  error
+		want ErrorCode
+	}{
+		"nil":            {nil, ErrorCodeOK},
+		"plain":          {errors.New("boom"), ErrorCodeUnknown},
+		"status":         {NewStatusError(ErrorCodeNotFound, "missing"), ErrorCodeNotFound},
+		"wrapped status": {fmt.Errorf("call: %w", NewStatusError(ErrorCodeAborted, "retry")), ErrorCodeAborted},
+		"connect":        {connect.NewError(connect.CodePermissionDenied, errors.New("denied")), ErrorCodePermissionDenied},
+		"grpc":           {status.Error(codes.ResourceExhausted, "full"), ErrorCodeResourceExhausted},
+		"canceled":       {fmt.Errorf("call: %w", context.Canceled), ErrorCodeCanceled},
+		"deadline":       {context.DeadlineExceeded, ErrorCodeDeadlineExceeded},
+		"eof":            {io.EOF, ErrorCodeOutOfRange},
+		"invalid handle": {ErrStreamInvalidHandle, ErrorCodeInvalidArgument},
+		"no server":      {ErrNoRegisteredServer, ErrorCodeUnavailable},
+	} {
+		if got := ErrorCodeOf(tc.err); got != tc.want {
+			t.Fatalf("%s: ErrorCodeOf = %d, want %d", name, got, tc.want)
+		}
+	}
+}
+
+func TestNewStatusErrorRejectsNonErrorCodes(t *testing.T) {
+	for _, code := range []ErrorCode{ErrorCodeOK, -1, maxErrorCode + 1} {
+		if got := NewStatusError(code, "x").Code; got != ErrorCodeUnknown {
+			t.Fatalf("NewStatusError(%d).Code = %d, want ErrorCodeUnknown", code, got)
+		}
+	}
+}
+
+func TestTakeErrorKeepsStoredCodeAndDetails(t *testing.T) {
+	detail, err := anypb.New(wrapperspb.String("retry later"))
+	if err != nil {
+		t.Fatalf("anypb.New returned error: %v", err)
+	}
+	id := StoreError(&StatusError{Code: ErrorCodeUnavailable, Message: "busy", Details: []*anypb.Any{detail}})
+
+	got, ok := TakeError(id)
+	if !ok {
+		t.Fatal("TakeError failed")
+	}
+	if got.Code != ErrorCodeUnavailable || got.Message != "busy" || len(got.Details) != 1 {
+		t.Fatalf("TakeError = %+v, want Unavailable busy with one detail", got)
+	}
+	if _, ok := TakeError(id); ok {
+		t.Fatal("second TakeError succeeded, want id released")
+	}
+}
+
+func TestStoreErrorKeepsTransportStatus(t *testing.T) {
+	connectErr := connect.NewError(connect.CodeNotFound, errors.New("no user"))
+	got, ok := TakeError(StoreError(connectErr))
+	if !ok {
+		t.Fatal("TakeError failed for connect error")
+	}
+	if got.Code != ErrorCodeNotFound || got.Message != "no user" {
+		t.Fatalf("connect status = %+v, want NotFound \"no user\"", got)
+	}
+
+	got, ok = TakeError(StoreError(status.Error(codes.AlreadyExists, "dup")))
+	if !ok {
+		t.Fatal("TakeError failed for gRPC error")
+	}
+	if got.Code != ErrorCodeAlreadyExists || got.Message != "dup" {
+		t.Fatalf("gRPC status = %+v, want AlreadyExists \"dup\"", got)
+	}
+}
+
+func TestTakeErrorCodeForExportReturnsCodeAndText(t *testing.T) {
+	id := StoreError(NewStatusError(ErrorCodeFailedPrecondition, "not ready"))
+
+	var code int32
+	var ptr uintptr
+	var length int32
+	if status := TakeErrorCodeForExport(int32(id), &code, &ptr, &length); status != 0 {
+		t.Fatalf("TakeErrorCodeForExport status = %d, want 0", status)
+	}
+	defer Release(ptr)
+	if ErrorCode(code) != ErrorCodeFailedPrecondition {
+		t.Fatalf("code = %d, want FailedPrecondition", code)
+	}
+	entry, ok := pinnedMap.Load(ptr)
+	if !ok {
+		t.Fatal("error text is not pinned")
+	}
+	if text := string(entry.(*releaseEntry).value.([]byte)); text != "not ready" || int32(len(text)) != length {
+		t.Fatalf("text = %q (len %d), want %q", text, length, "not ready")
+	}
+	if status := TakeErrorCodeForExport(int32(id), &code, &ptr, &length); status != -1 {
+		t.Fatalf("second TakeErrorCodeForExport status = %d, want -1", status)
+	}
+}
+
+func TestErrorStatusRoundTripsThroughExports(t *testing.T) {
+	want := &spb.Status{Code: int32(codes.Unauthenticated), Message: "token expired"}
+	data, err := proto.Marshal(want)
+	if err != nil {
+		t.Fatalf("proto.Marshal returned error: %v", err)
+	}
+	decoded, err := DecodeStatusError(uintptr(unsafe.Pointer(&data[0])), int32(len(data)))
+	if err != nil {
+		t.Fatalf("DecodeStatusError returned error: %v", err)
+	}
+	id := StoreError(decoded)
+
+	var ptr uintptr
+	var length int32
+	if status := TakeErrorStatusForExport(int32(id), &ptr, &length); status != 0 {
+		t.Fatalf("TakeErrorStatusForExport status = %d, want 0", status)
+	}
+	defer Release(ptr)
+	var got spb.Status
+	if err := DecodeMessage(ptr, length, &got); err != nil {
+		t.Fatalf("DecodeMessage returned error: %v", err)
+	}
+	if !proto.Equal(&got, want) {
+		t.Fatalf("status = %v, want %v", &got, want)
+	}
+}
+
+func TestTakeErrorStatusForExportServesDeadlineExceeded(t *testing.T) {
+	for range 2 {
+		var ptr uintptr
+		var length int32
+		if status := TakeErrorStatusForExport(int32(ErrorIDDeadlineExceeded), &ptr, &length); status != 0 {
+			t.Fatalf("TakeErrorStatusForExport status = %d, want 0", status)
+		}
+		var got spb.Status
+		if err := DecodeMessage(ptr, length, &got); err != nil {
+			t.Fatalf("DecodeMessage returned error: %v", err)
+		}
+		Release(ptr)
+		if ErrorCode(got.GetCode()) != ErrorCodeDeadlineExceeded {
+			t.Fatalf("code = %d, want DeadlineExceeded", got.GetCode())
+		}
+	}
+}
+
+func TestTransportErrorsPreserveCallbackStatus(t *testing.T) {
+	detail, err := anypb.New(wrapperspb.String("quota"))
+	if err != nil {
+		t.Fatalf("anypb.New returned error: %v", err)
+	}
+	statusErr := &StatusError{Code: ErrorCodeResourceExhausted, Message: "slow down", Details: []*anypb.Any{detail}}
+
+	var connectErr *connect.Error
+	if !errors.As(ConnectError(statusErr), &connectErr) {
+		t.Fatal("ConnectError did not return *connect.Error")
+	}
+	if connectErr.Code() != connect.CodeResourceExhausted || connectErr.Message() != "slow down" || len(connectErr.Details()) != 1 {
+		t.Fatalf("connect error = %v with %d details, want resource_exhausted with one detail", connectErr, len(connectErr.Details()))
+	}
+
+	grpcStatus, ok := status.FromError(GRPCError(statusErr))
+	if !ok {
+		t.Fatal("GRPCError did not return a gRPC status error")
+	}
+	if grpcStatus.Code() != codes.ResourceExhausted || grpcStatus.Message() != "slow down" || len(grpcStatus.Proto().GetDetails()) != 1 {
+		t.Fatalf("gRPC status = %v, want ResourceExhausted with one detail", grpcStatus.Proto())
+	}
+
+	plain := NewStatusError(ErrorCodeUnknown, "callback failed")
+	if got := ConnectError(plain); got != error(plain) {
+		t.Fatalf("ConnectError(uncoded) = %v, want unchanged", got)
+	}
+	if got := GRPCError(plain); got != error(plain) {
+		t.Fatalf("GRPCError(uncoded) = %v, want unchanged", got)
+	}
+}
@@ -1,57 +1,187 @@
 package rpcruntime
 
 import (
+	"container/list"
+	"context"
+	"errors"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
 type ErrorID int32
 
+// ErrorIDDeadlineExceeded is the reserved error id returned for calls whose
+// own context deadline expired. It is never stored, so its text can be taken
+// any number of times. Coded DeadlineExceeded errors, such as a remote status
+// with a message or details, are stored like any other error.
+const ErrorIDDeadlineExceeded ErrorID = -2
+
+var deadlineExceededText = context.DeadlineExceeded.Error()
+
 type errorRecord struct {
 	text      string
+	code      ErrorCode
+	message   string
+	details   []*anypb.Any
 	expiresAt time.Time
 }
 
+var deadlineExceededRecord = errorRecord{
+	text:    deadlineExceededText,
+	code:    ErrorCodeDeadlineExceeded,
+	message: deadlineExceededText,
+}
+
+var bufferTooSmallRecord = errorRecord{
+	text:    bufferTooSmallText,
+	code:    ErrorCodeResourceExhausted,
+	message: bufferTooSmallText,
+}
+
+// reservedErrorRecord returns the fixed record of a reserved error id. Reserved
+// ids are never stored, so their text can be taken any number of times.
+func reservedErrorRecord(id ErrorID) (errorRecord, bool) {
+	switch id {
+	case ErrorIDDeadlineExceeded:
+		return deadlineExceededRecord, true
+	case ErrorIDBufferTooSmall:
+		return bufferTooSmallRecord, true
+	}
+	return errorRecord{}, false
+}
+
 type preparedErrorText struct {
 	data   []byte
 	ptr    uintptr
 	length int32
+	code   ErrorCode
+}
+
+const (
+	DefaultErrorTTL      = 3 * time.Second
+	DefaultErrorCapacity = 4096
+)
+
+// ErrorStoreConfig bounds the error ids handed to C callers.
+type ErrorStoreConfig struct {
+	// TTL is how long an error id stays readable. Zero or negative means
+	// DefaultErrorTTL. It is ignored in ExplicitRelease mode.
+	TTL time.Duration
+	// Capacity is the number of error ids kept at once. Storing past it drops
+	// the oldest id. Zero or negative means DefaultErrorCapacity.
+	Capacity int
+	// ExplicitRelease keeps every error id until it is taken or discarded
+	// with DiscardError; only Capacity evicts it.
+	ExplicitRelease bool
+}
+
+// ErrorStoreStats reports the error store counters since process start.
+type ErrorStoreStats struct {
+	// Records counts error ids that are stored and neither taken nor expired.
+	Records int64
+	// Dropped counts ids evicted to stay within Capacity before they were
+	// taken.
+	Dropped uint64
+	// Expired counts ids whose TTL elapsed before they were taken.
+	Expired uint64
+	// Discarded counts ids released through DiscardError.
+	Discarded uint64
 }
 
+// errorStore keeps records in insertion order so that capacity eviction and
+// expiry both work from the front of the list. A single timer, armed for the
+// oldest record, sweeps expired records that nobody reads.
 type errorStore struct {
 	mu      sync.RWMutex
-	records map[ErrorID]errorRecord
+	config  ErrorStoreConfig
+	records map[ErrorID]*list.Element
+	order   *list.List
+	timer   *time.Timer
+
+	droppedCount   atomic.Uint64
+	expiredCount   atomic.Uint64
+	discardedCount atomic.Uint64
+}
+
+type errorEntry struct {
+	id       ErrorID
+	record   errorRecord
+	storedAt time.Time
 }
 
 var (
 	errorSeq atomic.Int32
-	errorTTL = 3 * time.Second
 
 	errorRecords                    = newErrorStore()
 	pinErrorText                    = PinString
 	errorTextLengthToInt32ForExport = LengthToInt32
 )
 
+// ConfigureErrorStore replaces the error store limits. A new TTL or release
+// mode also applies to the ids already stored, counted from when each was
+// stored; a smaller Capacity drops the oldest ids at once.
+func ConfigureErrorStore(config ErrorStoreConfig) {
+	errorRecords.configure(config)
+}
+
+// ErrorStoreConfiguration returns the limits in effect, with defaults filled in.
+func ErrorStoreConfiguration() ErrorStoreConfig {
+	errorRecords.mu.RLock()
+	defer errorRecords.mu.RUnlock()
+	return errorRecords.config
+}
+
+// DiscardError releases id without reading it. It reports whether id was
+// still stored.
+func DiscardError(id ErrorID) bool {
+	if _, reserved := reservedErrorRecord(id); id == 0 || reserved {
+		return false
+	}
+	return errorRecords.discard(id)
+}
+
+// SnapshotErrorStore returns the error store counters.
+func SnapshotErrorStore() ErrorStoreStats {
+	return ErrorStoreStats{
+		Records:   errorRecords.live(),
+		Dropped:   errorRecords.droppedCount.Load(),
+		Expired:   errorRecords.expiredCount.Load(),
+		Discarded: errorRecords.discardedCount.Load(),
+	}
+}
+
 func StoreError(err error) ErrorID {
 	if err == nil {
 		return 0
 	}
-
-	next := nextErrorID()
-	id := ErrorID(next)
-	store := errorRecords
-	record := errorRecord{
-		text:      err.Error(),
-		expiresAt: time.Now().Add(errorTTL),
+	if isContextDeadline(err) {
+		return ErrorIDDeadlineExceeded
+	}
+	if errors.Is(err, ErrBufferTooSmall) {
+		return ErrorIDBufferTooSmall
 	}
-	store.store(id, record)
-	time.AfterFunc(errorTTL, func() {
-		store.delete(id)
+
+	code := ErrorCodeOf(err)
+	id := ErrorID(nextErrorID())
+	message, details := errorStatusOf(err)
+	errorRecords.store(id, errorRecord{
+		text:    err.Error(),
+		code:    code,
+		message: message,
+		details: details,
 	})
 	return id
 }
 
+// isContextDeadline reports whether err is the bare context deadline error,
+// which the reserved record reproduces exactly.
+func isContextDeadline(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) && err.Error() == deadlineExceededText
+}
+
 func nextErrorID() int32 {
 	for {
 		current := errorSeq.Load()
@@ -69,6 +199,13 @@ func TakeErrorText(id ErrorID) ([]byte, uintptr, bool) {
 	if id == 0 {
 		return nil, 0, false
 	}
+	if record, ok := reservedErrorRecord(id); ok {
+		data, ptr, err := pinErrorText(record.text)
+		if err != nil {
+			return nil, 0, false
+		}
+		return data, ptr, true
+	}
 
 	prepared, ok := errorRecords.takePrepared(id, func(record errorRecord) (preparedErrorText, error) {
 		data, ptr, err := pinErrorText(record.text)
@@ -88,7 +225,7 @@ func takeErrorTextForExport(id ErrorID) (preparedErrorText, bool) {
 		return preparedErrorText{}, false
 	}
 
-	return errorRecords.takePrepared(id, func(record errorRecord) (preparedErrorText, error) {
+	prepare := func(record errorRecord) (preparedErrorText, error) {
 		data, ptr, err := pinErrorText(record.text)
 		if err != nil {
 			return preparedErrorText{}, err
@@ -102,67 +239,244 @@ func takeErrorTextForExport(id ErrorID) (preparedErrorText, bool) {
 			data:   data,
 			ptr:    ptr,
 			length: length,
+			code:   record.code,
 		}, nil
-	})
+	}
+	if record, ok := reservedErrorRecord(id); ok {
+		prepared, err := prepare(record)
+		return prepared, err == nil
+	}
+	return errorRecords.takePrepared(id, prepare)
 }
 
 func newErrorStore() *errorStore {
-	return &errorStore{records: make(map[ErrorID]errorRecord)}
+	return &errorStore{
+		config:  normalizeErrorStoreConfig(ErrorStoreConfig{}),
+		records: make(map[ErrorID]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func normalizeErrorStoreConfig(config ErrorStoreConfig) ErrorStoreConfig {
+	if config.TTL <= 0 {
+		config.TTL = DefaultErrorTTL
+	}
+	if config.Capacity <= 0 {
+		config.Capacity = DefaultErrorCapacity
+	}
+	return config
+}
+
+func (s *errorStore) configure(config ErrorStoreConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous := s.config
+	s.config = normalizeErrorStoreConfig(config)
+	if s.config.TTL != previous.TTL || s.config.ExplicitRelease != previous.ExplicitRelease {
+		// Re-stamp every record so deadlines keep following insertion order
+		// and the sweep is re-armed for the new front.
+		for element := s.order.Front(); element != nil; element = element.Next() {
+			entry := element.Value.(*errorEntry)
+			entry.record.expiresAt = s.expiresAtLocked(entry.storedAt)
+		}
+		if s.timer != nil {
+			s.timer.Stop()
+			s.timer = nil
+		}
+	}
+	s.evictLocked(time.Now())
+	s.armLocked()
+}
+
+// expiresAtLocked returns the deadline of a record stored at storedAt, or the
+// zero time in ExplicitRelease mode.
+func (s *errorStore) expiresAtLocked(storedAt time.Time) time.Time {
+	if s.config.ExplicitRelease {
+		return time.Time{}
+	}
+	return storedAt.Add(s.config.TTL)
 }
 
 func (s *errorStore) store(id ErrorID, record errorRecord) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.records[id] = record
+
+	now := time.Now()
+	record.expiresAt = s.expiresAtLocked(now)
+	if element, ok := s.records[id]; ok {
+		// The id sequence wrapped onto a record nobody took.
+		s.order.Remove(element)
+		s.droppedCount.Add(1)
+	}
+	s.records[id] = s.order.PushBack(&errorEntry{id: id, record: record, storedAt: now})
+	s.evictLocked(now)
+	s.armLocked()
+}
+
+// evictLocked removes expired records from the front of the list, then drops
+// the oldest records until the store is within capacity.
+func (s *errorStore) evictLocked(now time.Time) {
+	for front := s.order.Front(); front != nil; front = s.order.Front() {
+		entry := front.Value.(*errorEntry)
+		if !s.expired(entry.record, now) {
+			break
+		}
+		s.removeLocked(front)
+		s.expiredCount.Add(1)
+	}
+	for s.order.Len() > s.config.Capacity {
+		s.removeLocked(s.order.Front())
+		s.droppedCount.Add(1)
+	}
+}
+
+// armLocked schedules the sweep for the oldest record that can expire.
+func (s *errorStore) armLocked() {
+	front := s.order.Front()
+	if front == nil || s.timer != nil {
+		return
+	}
+	expiresAt := front.Value.(*errorEntry).record.expiresAt
+	if expiresAt.IsZero() {
+		return
+	}
+	s.timer = time.AfterFunc(time.Until(expiresAt), s.sweep)
+}
+
+func (s *errorStore) sweep() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timer = nil
+	s.evictLocked(time.Now())
+	s.armLocked()
+}
+
+func (s *errorStore) removeLocked(element *list.Element) {
+	delete(s.records, element.Value.(*errorEntry).id)
+	s.order.Remove(element)
 }
 
 func (s *errorStore) takePrepared(id ErrorID, prepare func(errorRecord) (preparedErrorText, error)) (preparedErrorText, bool) {
 	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	record, ok := s.records[id]
+	element, ok := s.records[id]
 	if !ok {
-		s.mu.Unlock()
 		return preparedErrorText{}, false
 	}
+	record := element.Value.(*errorEntry).record
 	if s.expired(record, time.Now()) {
-		delete(s.records, id)
-		s.mu.Unlock()
+		s.removeLocked(element)
+		s.expiredCount.Add(1)
 		return preparedErrorText{}, false
 	}
 
 	prepared, err := prepare(record)
 	if err != nil {
-		s.mu.Unlock()
 		return preparedErrorText{}, false
 	}
-
-	delete(s.records, id)
-	s.mu.Unlock()
+	s.removeLocked(element)
 	return prepared, true
 }
 
-func (s *errorStore) has(id ErrorID) bool {
+func (s *errorStore) discard(id ErrorID) bool {
 	s.mu.Lock()
-	record, ok := s.records[id]
+	defer s.mu.Unlock()
+
+	element, ok := s.records[id]
 	if !ok {
-		s.mu.Unlock()
 		return false
 	}
-	if s.expired(record, time.Now()) {
-		delete(s.records, id)
-		s.mu.Unlock()
+	s.removeLocked(element)
+	if s.expired(element.Value.(*errorEntry).record, time.Now()) {
+		s.expiredCount.Add(1)
 		return false
 	}
-	s.mu.Unlock()
+	s.discardedCount.Add(1)
 	return true
 }
 
-func (s *errorStore) delete(id ErrorID) {
+func (s *errorStore) has(id ErrorID) bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	delete(s.records, id)
+
+	element, ok := s.records[id]
+	if !ok {
+		return false
+	}
+	if s.expired(element.Value.(*errorEntry).record, time.Now()) {
+		s.removeLocked(element)
+		s.expiredCount.Add(1)
+		return false
+	}
+	return true
+}
+
+// live counts the records that have not been taken or expired.
+func (s *errorStore) live() int64 {
+	now := time.Now()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var count int64
+	for element := s.order.Front(); element != nil; element = element.Next() {
+		if !s.expired(element.Value.(*errorEntry).record, now) {
+			count++
+		}
+	}
+	return count
 }
 
 func (s *errorStore) expired(record errorRecord, now time.Time) bool {
-	return !now.Before(record.expiresAt)
+	return !record.expiresAt.IsZero() && !now.Before(record.expiresAt)
+}
+
+// TakeError takes the record stored for id as a *StatusError and releases the id.
+func TakeError(id ErrorID) (*StatusError, bool) {
+	record, ok := takeErrorRecord(id)
+	if !ok {
+		return nil, false
+	}
+	return record.statusError(), true
+}
+
+func takeErrorStatusForExport(id ErrorID) (preparedErrorText, bool) {
+	if id == 0 {
+		return preparedErrorText{}, false
+	}
+
+	prepare := func(record errorRecord) (preparedErrorText, error) {
+		ptr, length, err := EncodeMessage(record.statusError().Proto())
+		if err != nil {
+			return preparedErrorText{}, err
+		}
+		return preparedErrorText{
+			ptr:    ptr,
+			length: length,
+			code:   record.code,
+		}, nil
+	}
+	if record, ok := reservedErrorRecord(id); ok {
+		prepared, err := prepare(record)
+		return prepared, err == nil
+	}
+	return errorRecords.takePrepared(id, prepare)
+}
+
+func takeErrorRecord(id ErrorID) (errorRecord, bool) {
+	if id == 0 {
+		return errorRecord{}, false
+	}
+	if record, ok := reservedErrorRecord(id); ok {
+		return record, true
+	}
+	var taken errorRecord
+	_, ok := errorRecords.takePrepared(id, func(record errorRecord) (preparedErrorText, error) {
+		taken = record
+		return preparedErrorText{}, nil
+	})
+	return taken, ok
+}
+
+func (r errorRecord) statusError() *StatusError {
+	return &StatusError{Code: r.code, Message: r.message, Details: r.details}
 }
@@ -74,18 +74,15 @@ func TestStoreErrorWrapsToOneAfterMaxInt32(t *testing.T) {
 }
 
 func TestStoredErrorExpiresAndGetsRemoved(t *testing.T) {
-	oldTTL := errorTTL
-	errorTTL = 20 * time.Millisecond
-	t.Cleanup(func() {
-		errorTTL = oldTTL
-	})
+	resetErrorRuntimeStateForTesting(t)
+	ConfigureErrorStore(ErrorStoreConfig{TTL: 20 * time.Millisecond})
 
 	id := StoreError(errors.New("stale"))
 	if id == 0 {
 		t.Fatal("expected non-zero error id")
 	}
 
-	time.Sleep(errorTTL + 40*time.Millisecond)
+	time.Sleep(60 * time.Millisecond)
 
 	if errorRecords.has(id) {
 		t.Fatal("expected expired error to be removed from map")
@@ -98,12 +95,7 @@ func TestStoredErrorExpiresAndGetsRemoved(t *testing.T) {
 
 func TestErrorStoreBackgroundCleanupRemovesExpiredRecordWithoutAccess(t *testing.T) {
 	resetErrorRuntimeStateForTesting(t)
-
-	oldTTL := errorTTL
-	errorTTL = 20 * time.Millisecond
-	t.Cleanup(func() {
-		errorTTL = oldTTL
-	})
+	ConfigureErrorStore(ErrorStoreConfig{TTL: 20 * time.Millisecond})
 
 	id := StoreError(errors.New("stale-without-access"))
 	if id == 0 {
@@ -116,6 +108,125 @@ func TestErrorStoreBackgroundCleanupRemovesExpiredRecordWithoutAccess(t *testing
 		_, ok := errorRecords.records[id]
 		return !ok
 	}, "expected background cleanup to remove expired error without any subsequent access")
+	if got := SnapshotErrorStore().Expired; got != 1 {
+		t.Fatalf("expected one expired error, got %d", got)
+	}
+}
+
+func TestErrorStoreDropsOldestPastCapacity(t *testing.T) {
+	resetErrorRuntimeStateForTesting(t)
+	ConfigureErrorStore(ErrorStoreConfig{Capacity: 2})
+
+	first := StoreError(errors.New("first"))
+	second := StoreError(errors.New("second"))
+	third := StoreError(errors.New("third"))
+
+	if errorRecords.has(first) {
+		t.Fatal("expected oldest error to be dropped past capacity")
+	}
+	if !errorRecords.has(second) || !errorRecords.has(third) {
+		t.Fatal("expected newest errors to stay within capacity")
+	}
+	stats := SnapshotErrorStore()
+	if stats.Records != 2 || stats.Dropped != 1 {
+		t.Fatalf("unexpected error store stats: %+v", stats)
+	}
+	if errorRecords.timer == nil {
+		t.Fatal("expected one store timer for the oldest record")
+	}
+}
+
+func TestErrorStoreShrinkingCapacityDropsOldest(t *testing.T) {
+	resetErrorRuntimeStateForTesting(t)
+
+	for i := 0; i < 3; i++ {
+		StoreError(fmt.Errorf("error %d", i))
+	}
+	ConfigureErrorStore(ErrorStoreConfig{Capacity: 1})
+
+	if stats := SnapshotErrorStore(); stats.Records != 1 || stats.Dropped != 2 {
+		t.Fatalf("unexpected error store stats after shrinking: %+v", stats)
+	}
+}
+
+func TestErrorStoreExplicitReleaseKeepsErrorsUntilTakenOrDiscarded(t *testing.T) {
+	resetErrorRuntimeStateForTesting(t)
+	ConfigureErrorStore(ErrorStoreConfig{TTL: time.Millisecond, ExplicitRelease: true})
+
+	kept := StoreError(errors.New("kept"))
+	discarded := StoreError(errors.New("discarded"))
+	time.Sleep(20 * time.Millisecond)
+
+	if errorRecords.timer != nil {
+		t.Fatal("expected explicit release mode to arm no timer")
+	}
+	if !DiscardError(discarded) {
+		t.Fatal("expected DiscardError to release a stored error")
+	}
+	if DiscardError(discarded) {
+		t.Fatal("expected second DiscardError to report a missing error")
+	}
+	data, ptr, ok := TakeErrorText(kept)
+	if !ok || string(data) != "kept" {
+		t.Fatalf("expected explicit release error to outlive its TTL, got %q %v", data, ok)
+	}
+	Release(ptr)
+
+	stats := SnapshotErrorStore()
+	if stats.Records != 0 || stats.Discarded != 1 || stats.Expired != 0 || stats.Dropped != 0 {
+		t.Fatalf("unexpected error store stats: %+v", stats)
+	}
+}
+
+func TestErrorStoreSwitchToTTLExpiresExplicitReleaseErrors(t *testing.T) {
+	resetErrorRuntimeStateForTesting(t)
+	ConfigureErrorStore(ErrorStoreConfig{ExplicitRelease: true})
+
+	kept := StoreError(errors.New("kept"))
+	ConfigureErrorStore(ErrorStoreConfig{TTL: 20 * time.Millisecond})
+	later := StoreError(errors.New("later"))
+
+	waitForCondition(t, 500*time.Millisecond, func() bool {
+		errorRecords.mu.RLock()
+		defer errorRecords.mu.RUnlock()
+		_, keptOK := errorRecords.records[kept]
+		_, laterOK := errorRecords.records[later]
+		return !keptOK && !laterOK
+	}, "expected the sweep to expire errors stored before and after the switch")
+	if stats := SnapshotErrorStore(); stats.Records != 0 || stats.Expired != 2 {
+		t.Fatalf("unexpected error store stats: %+v", stats)
+	}
+}
+
+func TestErrorStoreTTLChangeRearmsSweep(t *testing.T) {
+	resetErrorRuntimeStateForTesting(t)
+	ConfigureErrorStore(ErrorStoreConfig{TTL: time.Hour})
+
+	id := StoreError(errors.New("shortened"))
+	ConfigureErrorStore(ErrorStoreConfig{TTL: 20 * time.Millisecond})
+
+	waitForCondition(t, 500*time.Millisecond, func() bool {
+		errorRecords.mu.RLock()
+		defer errorRecords.mu.RUnlock()
+		_, ok := errorRecords.records[id]
+		return !ok
+	}, "expected the sweep to follow the shortened TTL without any access")
+}
+
+func TestDiscardErrorIgnoresReservedIDs(t *testing.T) {
+	if DiscardError(0) || DiscardError(ErrorIDDeadlineExceeded) {
+		t.Fatal("expected DiscardError to ignore reserved error ids")
+	}
+}
+
+func TestConfigureErrorStoreFillsDefaults(t *testing.T) {
+	resetErrorRuntimeStateForTesting(t)
+	ConfigureErrorStore(ErrorStoreConfig{TTL: -1, Capacity: -1})
+
+	config := ErrorStoreConfiguration()
+	if config.TTL != DefaultErrorTTL || config.Capacity != DefaultErrorCapacity || config.ExplicitRelease {
+		t.Fatalf("unexpected default error store config: %+v", config)
+	}
 }
 
 func TestTakeErrorTextKeepsRecordWhenPinFails(t *testing.T) {
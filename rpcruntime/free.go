@@ -169,3 +169,41 @@ func TakeErrorTextForExport(errID int32, textPtr *uintptr, textLen *int32) int32
 	_ = prepared.data
 	return 0
 }
+
+// TakeErrorCodeForExport is TakeErrorTextForExport that also reports the
+// canonical code stored with the error. Returns 0 on success, -1 on failure.
+func TakeErrorCodeForExport(errID int32, code *int32, textPtr *uintptr, textLen *int32) int32 {
+	prepared, ok := takeErrorTextForExport(ErrorID(errID))
+	if !ok {
+		return -1
+	}
+	if code != nil {
+		*code = int32(prepared.code)
+	}
+	if textPtr != nil {
+		*textPtr = prepared.ptr
+	}
+	if textLen != nil {
+		*textLen = prepared.length
+	}
+	_ = prepared.data
+	return 0
+}
+
+// TakeErrorStatusForExport takes the error stored for errID as encoded
+// google.rpc.Status bytes with output-pointer semantics suitable for the cgo
+// export ABI. Returns 0 on success, -1 on failure.
+func TakeErrorStatusForExport(errID int32, statusPtr *uintptr, statusLen *int32) int32 {
+	prepared, ok := takeErrorStatusForExport(ErrorID(errID))
+	if !ok {
+		return -1
+	}
+	if statusPtr != nil {
+		*statusPtr = prepared.ptr
+	}
+	if statusLen != nil {
+		*statusLen = prepared.length
+	}
+	_ = prepared.data
+	return 0
+}
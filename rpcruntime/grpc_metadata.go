@@ -0,0 +1,66 @@
+package rpcruntime
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// grpcTransportStream lets a unary gRPC server called directly through the
+// registry report response metadata with grpc.SetHeader and grpc.SetTrailer.
+type grpcTransportStream struct {
+	ctx    context.Context
+	method string
+}
+
+func (s *grpcTransportStream) Method() string { return s.method }
+
+func (s *grpcTransportStream) SetHeader(md metadata.MD) error {
+	SetResponseHeader(s.ctx, md)
+	return nil
+}
+
+func (s *grpcTransportStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *grpcTransportStream) SetTrailer(md metadata.MD) error {
+	SetResponseTrailer(s.ctx, md)
+	return nil
+}
+
+// GRPCHandlerContext prepares ctx for a unary gRPC server called directly
+// through the registry. The server sees the request metadata of ctx as
+// incoming metadata, and grpc.SetHeader and grpc.SetTrailer report response
+// metadata to the caller.
+func GRPCHandlerContext(ctx context.Context, method string) context.Context {
+	callCtx := grpcIncomingContext(ctx)
+	return grpc.NewContextWithServerTransportStream(callCtx, &grpcTransportStream{ctx: ctx, method: method})
+}
+
+// GRPCClientContext prepares ctx for a call on a remote gRPC client. The
+// request metadata of ctx is sent as outgoing metadata. The returned call
+// options must be passed to the call, and the returned finish reports the
+// response headers and trailers once the call has completed; later calls do
+// nothing.
+func GRPCClientContext(ctx context.Context) (context.Context, []grpc.CallOption, func()) {
+	callCtx := ctx
+	if md := RequestMetadataFromContext(ctx); len(md) > 0 {
+		callCtx = metadata.NewOutgoingContext(ctx, metadata.MD(md.Copy()))
+	}
+	var header, trailer metadata.MD
+	return callCtx, []grpc.CallOption{grpc.Header(&header), grpc.Trailer(&trailer)}, sync.OnceFunc(func() {
+		SetResponseHeader(ctx, header)
+		SetResponseTrailer(ctx, trailer)
+	})
+}
+
+func grpcIncomingContext(ctx context.Context) context.Context {
+	md := RequestMetadataFromContext(ctx)
+	if len(md) == 0 {
+		return ctx
+	}
+	return metadata.NewIncomingContext(ctx, metadata.MD(md.Copy()))
+}
@@ -15,6 +15,10 @@ var (
 	errGRPCStreamNoResponse  = errors.New("grpc client stream completed without SendAndClose")
 )
 
+// grpcServerStream carries the metadata side of a directly called gRPC
+// streaming server. Its context holds the caller's request metadata as
+// incoming metadata, and headers and trailers set by the server are reported
+// to the caller as they are set.
 type grpcServerStream struct {
 	ctx     context.Context
 	mu      sync.Mutex
@@ -26,6 +30,7 @@ func (s *grpcServerStream) SetHeader(md metadata.MD) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.header = joinMetadata(s.header, md)
+	SetResponseHeader(s.ctx, md)
 	return nil
 }
 
@@ -37,6 +42,7 @@ func (s *grpcServerStream) SetTrailer(md metadata.MD) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.trailer = joinMetadata(s.trailer, md)
+	SetResponseTrailer(s.ctx, md)
 }
 
 func (s *grpcServerStream) Context() context.Context { return s.ctx }
@@ -59,7 +65,7 @@ type GRPCClientStreamingServer[Req, Resp any] struct {
 
 // NewGRPCClientStreamingServer constructs a grpc-go client-streaming server adapter.
 func NewGRPCClientStreamingServer[Req, Resp any](ctx context.Context, stream *ClientStreamForServer[*Req, *Resp]) *GRPCClientStreamingServer[Req, Resp] {
-	return &GRPCClientStreamingServer[Req, Resp]{grpcServerStream: grpcServerStream{ctx: ctx}, stream: stream}
+	return &GRPCClientStreamingServer[Req, Resp]{grpcServerStream: grpcServerStream{ctx: grpcIncomingContext(ctx)}, stream: stream}
 }
 
 // Recv receives the next request from the local client endpoint.
@@ -115,7 +121,7 @@ type GRPCServerStreamingServer[Resp any] struct {
 
 // NewGRPCServerStreamingServer constructs a grpc-go server-streaming server adapter.
 func NewGRPCServerStreamingServer[Resp any](ctx context.Context, stream *ServerStreamForServer[*Resp]) *GRPCServerStreamingServer[Resp] {
-	return &GRPCServerStreamingServer[Resp]{grpcServerStream: grpcServerStream{ctx: ctx}, stream: stream}
+	return &GRPCServerStreamingServer[Resp]{grpcServerStream: grpcServerStream{ctx: grpcIncomingContext(ctx)}, stream: stream}
 }
 
 // Send sends a response to the local client endpoint.
@@ -146,7 +152,7 @@ type GRPCBidiStreamingServer[Req, Resp any] struct {
 
 // NewGRPCBidiStreamingServer constructs a grpc-go bidirectional-streaming server adapter.
 func NewGRPCBidiStreamingServer[Req, Resp any](ctx context.Context, stream *BidiStreamForServer[*Req, *Resp]) *GRPCBidiStreamingServer[Req, Resp] {
-	return &GRPCBidiStreamingServer[Req, Resp]{grpcServerStream: grpcServerStream{ctx: ctx}, stream: stream}
+	return &GRPCBidiStreamingServer[Req, Resp]{grpcServerStream: grpcServerStream{ctx: grpcIncomingContext(ctx)}, stream: stream}
 }
 
 // Recv receives the next request from the local client endpoint.
@@ -0,0 +1,215 @@
+package rpcruntime
+
+import (
+	"errors"
+	"sort"
+)
+
+// HealthStatus is the serving status of a service. Values match
+// grpc.health.v1.HealthCheckResponse.ServingStatus.
+type HealthStatus int32
+
+const (
+	HealthStatusUnknown HealthStatus = iota
+	HealthStatusServing
+	HealthStatusNotServing
+)
+
+// ErrInvalidHealthStatus is returned when a server reports a status other
+// than serving, not serving or unknown.
+var ErrInvalidHealthStatus = errors.New("rpccgo: health status must be serving, not serving or unknown")
+
+// ServiceHealth is the health of one service in a server registry.
+type ServiceHealth struct {
+	ServiceID ServiceID
+	Status    HealthStatus
+	// Kind is the kind of the first route target, or ServerKindInvalid when
+	// no server is registered.
+	Kind ServerKind
+}
+
+type healthWatcher struct {
+	serviceID ServiceID
+	fn        func(ServiceHealth)
+}
+
+// CheckHealth returns the health of serviceID in the default registry.
+func CheckHealth(serviceID ServiceID) ServiceHealth {
+	return defaultServerRegistry.CheckHealth(serviceID)
+}
+
+// ReportHealth records the status the server registered for serviceID in the
+// default registry reports for itself.
+func ReportHealth(serviceID ServiceID, status HealthStatus) error {
+	return defaultServerRegistry.ReportHealth(serviceID, status)
+}
+
+// ListHealth returns the health of every service of the default registry.
+func ListHealth() []ServiceHealth {
+	return defaultServerRegistry.ListHealth()
+}
+
+// WatchHealth subscribes fn to health changes of the default registry.
+func WatchHealth(serviceID ServiceID, fn func(ServiceHealth)) (cancel func()) {
+	return defaultServerRegistry.WatchHealth(serviceID, fn)
+}
+
+// CheckHealth returns the health of serviceID. A service is serving while a
+// server is registered for it, unless the server reported otherwise, and not
+// serving without one. An empty serviceID asks for the registry as a whole,
+// which is always serving.
+func (r *ServerRegistry) CheckHealth(serviceID ServiceID) ServiceHealth {
+	if serviceID == "" {
+		return ServiceHealth{Status: HealthStatusServing}
+	}
+	if r == nil {
+		return ServiceHealth{ServiceID: serviceID, Status: HealthStatusNotServing}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.healthLocked(serviceID)
+}
+
+// ReportHealth records the status the server registered for serviceID
+// reports for itself. HealthStatusUnknown drops the report so the status
+// follows the registry again. A report lasts until the service is registered
+// again or cleared.
+func (r *ServerRegistry) ReportHealth(serviceID ServiceID, status HealthStatus) error {
+	if r == nil {
+		return errNilServerRegistry
+	}
+	if err := validateServiceID(serviceID); err != nil {
+		return err
+	}
+	if status < HealthStatusUnknown || status > HealthStatusNotServing {
+		return ErrInvalidHealthStatus
+	}
+
+	r.mu.Lock()
+	if _, ok := r.routes[serviceID]; !ok {
+		r.mu.Unlock()
+		return ErrNoRegisteredServer
+	}
+	before := r.healthLocked(serviceID)
+	if status == HealthStatusUnknown {
+		delete(r.reports, serviceID)
+	} else {
+		if r.reports == nil {
+			r.reports = make(map[ServiceID]HealthStatus)
+		}
+		r.reports[serviceID] = status
+	}
+	after := r.healthLocked(serviceID)
+	r.mu.Unlock()
+
+	r.notifyHealth(before, after)
+	return nil
+}
+
+// ListHealth returns the health of every service with a registered server,
+// ordered by service id.
+func (r *ServerRegistry) ListHealth() []ServiceHealth {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	out := make([]ServiceHealth, 0, len(r.routes))
+	for serviceID := range r.routes {
+		out = append(out, r.healthLocked(serviceID))
+	}
+	r.mu.RUnlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].ServiceID < out[j].ServiceID })
+	return out
+}
+
+// WatchHealth subscribes fn to health changes of serviceID, or of every
+// service when serviceID is empty. Like Watch, fn runs on the goroutine that
+// changed the registry, after the registry lock is released, and must not
+// block. fn is not called with the current health; call CheckHealth after
+// subscribing.
+func (r *ServerRegistry) WatchHealth(serviceID ServiceID, fn func(ServiceHealth)) (cancel func()) {
+	if r == nil || fn == nil {
+		return func() {}
+	}
+
+	r.watchMu.Lock()
+	defer r.watchMu.Unlock()
+
+	if r.healthWatchers == nil {
+		r.healthWatchers = make(map[uint64]healthWatcher)
+	}
+	r.nextWatch++
+	id := r.nextWatch
+	r.healthWatchers[id] = healthWatcher{serviceID: serviceID, fn: fn}
+	return func() {
+		r.watchMu.Lock()
+		defer r.watchMu.Unlock()
+		delete(r.healthWatchers, id)
+	}
+}
+
+func (r *ServerRegistry) healthLocked(serviceID ServiceID) ServiceHealth {
+	health := ServiceHealth{ServiceID: serviceID, Status: HealthStatusNotServing}
+	route, ok := r.routes[serviceID]
+	if !ok {
+		return health
+	}
+	health.Kind = route.targets[0].Server.Kind
+	health.Status = HealthStatusServing
+	if reported, ok := r.reports[serviceID]; ok {
+		health.Status = reported
+	}
+	return health
+}
+
+func (r *ServerRegistry) notifyHealth(before, after ServiceHealth) {
+	if before == after {
+		return
+	}
+	r.watchMu.Lock()
+	var fns []func(ServiceHealth)
+	for _, watcher := range r.healthWatchers {
+		if watcher.serviceID == "" || watcher.serviceID == after.ServiceID {
+			fns = append(fns, watcher.fn)
+		}
+	}
+	r.watchMu.Unlock()
+
+	for _, fn := range fns {
+		fn(after)
+	}
+}
+
+// NewHealthWatch subscribes onHealth to health changes of the default
+// registry, like WatchHealth, for a C caller. Deliveries run in order on a
+// goroutine owned by the watch; a watch on one service starts with its
+// current health. Each delivery reads the health when it runs and skips a
+// service whose health did not change, so the last delivery always matches
+// the registry. Health watches share the registration watch handles and are
+// canceled with CancelRegistrationWatch.
+func NewHealthWatch(serviceID ServiceID, onHealth func(RegistrationWatchHandle, ServiceHealth), onDone func(RegistrationWatchHandle)) (RegistrationWatchHandle, error) {
+	if onHealth == nil || onDone == nil {
+		return 0, errors.New("health watch requires non-nil callbacks")
+	}
+	var handle RegistrationWatchHandle
+	watch := &registrationWatch{wake: make(chan struct{}, 1)}
+	// delivered is only touched by the delivery goroutine.
+	delivered := make(map[ServiceID]ServiceHealth)
+	deliver := func(serviceID ServiceID) func() {
+		return func() {
+			health := CheckHealth(serviceID)
+			if previous, ok := delivered[serviceID]; ok && previous == health {
+				return
+			}
+			delivered[serviceID] = health
+			onHealth(handle, health)
+		}
+	}
+	watch.stop = WatchHealth(serviceID, func(health ServiceHealth) {
+		watch.push(deliver(health.ServiceID))
+	})
+	if serviceID != "" {
+		watch.push(deliver(serviceID))
+	}
+	return watch.start(&handle, onDone)
+}
@@ -0,0 +1,118 @@
+package rpcruntime
+
+import (
+	"context"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthServer serves grpc.health.v1.Health from the health of a server
+// registry. Mount it on a gRPC server with grpc_health_v1.RegisterHealthServer
+// or on a Connect mux with ConnectHandler.
+//
+// Services without a registered server are NOT_SERVING rather than unknown,
+// so a peer can probe a service before the host registers it.
+type HealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	registry *ServerRegistry
+}
+
+// NewHealthServer returns a health server for registry, or for the default
+// registry when registry is nil.
+func NewHealthServer(registry *ServerRegistry) *HealthServer {
+	if registry == nil {
+		registry = DefaultServerRegistry()
+	}
+	return &HealthServer{registry: registry}
+}
+
+// Check returns the status of the requested service.
+func (s *HealthServer) Check(_ context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	return healthCheckResponse(s.registry.CheckHealth(ServiceID(req.GetService())).Status), nil
+}
+
+// List returns the status of every service with a registered server.
+func (s *HealthServer) List(context.Context, *grpc_health_v1.HealthListRequest) (*grpc_health_v1.HealthListResponse, error) {
+	res := &grpc_health_v1.HealthListResponse{Statuses: make(map[string]*grpc_health_v1.HealthCheckResponse)}
+	for _, health := range s.registry.ListHealth() {
+		res.Statuses[string(health.ServiceID)] = healthCheckResponse(health.Status)
+	}
+	return res, nil
+}
+
+// Watch sends the status of the requested service, then every change of it,
+// until the stream ends.
+func (s *HealthServer) Watch(req *grpc_health_v1.HealthCheckRequest, stream grpc.ServerStreamingServer[grpc_health_v1.HealthCheckResponse]) error {
+	return s.watch(stream.Context(), ServiceID(req.GetService()), stream.Send)
+}
+
+// ConnectHandler returns the path and handler that serve grpc.health.v1.Health
+// on a Connect mux over the Connect, gRPC and gRPC-Web protocols.
+func (s *HealthServer) ConnectHandler(options ...connect.HandlerOption) (string, http.Handler) {
+	mux := http.NewServeMux()
+	mux.Handle(grpc_health_v1.Health_Check_FullMethodName, connect.NewUnaryHandler(
+		grpc_health_v1.Health_Check_FullMethodName,
+		func(ctx context.Context, req *connect.Request[grpc_health_v1.HealthCheckRequest]) (*connect.Response[grpc_health_v1.HealthCheckResponse], error) {
+			res, err := s.Check(ctx, req.Msg)
+			if err != nil {
+				return nil, err
+			}
+			return connect.NewResponse(res), nil
+		},
+		options...,
+	))
+	mux.Handle(grpc_health_v1.Health_List_FullMethodName, connect.NewUnaryHandler(
+		grpc_health_v1.Health_List_FullMethodName,
+		func(ctx context.Context, req *connect.Request[grpc_health_v1.HealthListRequest]) (*connect.Response[grpc_health_v1.HealthListResponse], error) {
+			res, err := s.List(ctx, req.Msg)
+			if err != nil {
+				return nil, err
+			}
+			return connect.NewResponse(res), nil
+		},
+		options...,
+	))
+	mux.Handle(grpc_health_v1.Health_Watch_FullMethodName, connect.NewServerStreamHandler(
+		grpc_health_v1.Health_Watch_FullMethodName,
+		func(ctx context.Context, req *connect.Request[grpc_health_v1.HealthCheckRequest], stream *connect.ServerStream[grpc_health_v1.HealthCheckResponse]) error {
+			return s.watch(ctx, ServiceID(req.Msg.GetService()), stream.Send)
+		},
+		options...,
+	))
+	return "/" + grpc_health_v1.Health_ServiceDesc.ServiceName + "/", mux
+}
+
+// watch re-reads the health after every change notification, so bursts of
+// changes collapse into the latest status.
+func (s *HealthServer) watch(ctx context.Context, serviceID ServiceID, send func(*grpc_health_v1.HealthCheckResponse) error) error {
+	changed := make(chan struct{}, 1)
+	cancel := s.registry.WatchHealth(serviceID, func(ServiceHealth) {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	})
+	defer cancel()
+
+	last := HealthStatus(-1)
+	for {
+		if status := s.registry.CheckHealth(serviceID).Status; status != last {
+			if err := send(healthCheckResponse(status)); err != nil {
+				return err
+			}
+			last = status
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func healthCheckResponse(status HealthStatus) *grpc_health_v1.HealthCheckResponse {
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_ServingStatus(status)}
+}
@@ -0,0 +1,130 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestServerRegistryHealthFollowsRegistrationAndReports(t *testing.T) {
+	const serviceID ServiceID = "rpccgo.test.v1.Greeter"
+	registry := NewServerRegistry()
+	var changes []ServiceHealth
+	defer registry.WatchHealth(serviceID, func(health ServiceHealth) { changes = append(changes, health) })()
+
+	if got := registry.CheckHealth(serviceID); got.Status != HealthStatusNotServing || got.Kind != ServerKindInvalid {
+		t.Fatalf("CheckHealth before register = %+v, want not serving", got)
+	}
+	if err := registry.ReportHealth(serviceID, HealthStatusNotServing); !errors.Is(err, ErrNoRegisteredServer) {
+		t.Fatalf("ReportHealth without a server = %v, want ErrNoRegisteredServer", err)
+	}
+	server := RegisteredServer{Kind: ServerKindGoNative, Server: testRegisteredServer{name: "health"}}
+	if err := registry.Register(serviceID, server); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	if err := registry.Register(serviceID, server); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	if err := registry.ReportHealth(serviceID, HealthStatusNotServing); err != nil {
+		t.Fatalf("ReportHealth returned error: %v", err)
+	}
+	if err := registry.ReportHealth(serviceID, HealthStatus(7)); !errors.Is(err, ErrInvalidHealthStatus) {
+		t.Fatalf("ReportHealth with an invalid status = %v, want ErrInvalidHealthStatus", err)
+	}
+	if got := registry.ListHealth(); len(got) != 1 || got[0].Status != HealthStatusNotServing {
+		t.Fatalf("ListHealth = %+v, want the reported status", got)
+	}
+	if err := registry.Register(serviceID, server); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	if err := registry.Clear(serviceID); err != nil {
+		t.Fatalf("Clear returned error: %v", err)
+	}
+	if got := registry.CheckHealth(""); got.Status != HealthStatusServing {
+		t.Fatalf("CheckHealth of the registry = %+v, want serving", got)
+	}
+
+	want := []HealthStatus{HealthStatusServing, HealthStatusNotServing, HealthStatusServing, HealthStatusNotServing}
+	if len(changes) != len(want) {
+		t.Fatalf("health changes = %+v, want %v", changes, want)
+	}
+	for i, status := range want {
+		if changes[i].Status != status {
+			t.Fatalf("health change %d = %+v, want %v", i, changes[i], status)
+		}
+	}
+	if changes[0].Kind != ServerKindGoNative || changes[3].Kind != ServerKindInvalid {
+		t.Fatalf("health change kinds = %v/%v", changes[0].Kind, changes[3].Kind)
+	}
+}
+
+func TestHealthWatchStartsWithCurrentHealthThenDone(t *testing.T) {
+	const serviceID ServiceID = "rpccgo.test.v1.HealthWatchedGreeter"
+	t.Cleanup(func() { _ = ClearServer(serviceID) })
+
+	updates := make(chan ServiceHealth, 4)
+	done := make(chan struct{})
+	handle, err := NewHealthWatch(serviceID, func(_ RegistrationWatchHandle, health ServiceHealth) { updates <- health }, func(RegistrationWatchHandle) { close(done) })
+	if err != nil {
+		t.Fatalf("NewHealthWatch returned error: %v", err)
+	}
+	if got := <-updates; got.Status != HealthStatusNotServing {
+		t.Fatalf("first health = %+v, want not serving", got)
+	}
+	if err := RegisterServer(serviceID, RegisteredServer{Kind: ServerKindCGOMessage, Server: testRegisteredServer{name: "message"}}); err != nil {
+		t.Fatalf("RegisterServer returned error: %v", err)
+	}
+	if got := <-updates; got.Status != HealthStatusServing || got.Kind != ServerKindCGOMessage {
+		t.Fatalf("health after register = %+v, want serving cgo message", got)
+	}
+	if err := CancelRegistrationWatch(handle); err != nil {
+		t.Fatalf("CancelRegistrationWatch returned error: %v", err)
+	}
+	<-done
+}
+
+func TestHealthServerServesConnectCheckAndWatch(t *testing.T) {
+	const serviceID ServiceID = "rpccgo.test.v1.Greeter"
+	registry := NewServerRegistry()
+	if err := registry.Register(serviceID, RegisteredServer{Kind: ServerKindGoNative, Server: testRegisteredServer{name: "health"}}); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(NewHealthServer(registry).ConnectHandler())
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	check := connect.NewClient[grpc_health_v1.HealthCheckRequest, grpc_health_v1.HealthCheckResponse](server.Client(), server.URL+grpc_health_v1.Health_Check_FullMethodName)
+	res, err := check.CallUnary(context.Background(), connect.NewRequest(&grpc_health_v1.HealthCheckRequest{Service: string(serviceID)}))
+	if err != nil || res.Msg.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Fatalf("Check = %v, %v; want SERVING", res, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watch := connect.NewClient[grpc_health_v1.HealthCheckRequest, grpc_health_v1.HealthCheckResponse](server.Client(), server.URL+grpc_health_v1.Health_Watch_FullMethodName)
+	stream, err := watch.CallServerStream(ctx, connect.NewRequest(&grpc_health_v1.HealthCheckRequest{Service: string(serviceID)}))
+	if err != nil {
+		t.Fatalf("CallServerStream returned error: %v", err)
+	}
+	defer stream.Close()
+	if !stream.Receive() || stream.Msg().GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Fatalf("first Watch status = %v, %v; want SERVING", stream.Msg(), stream.Err())
+	}
+	if err := registry.ReportHealth(serviceID, HealthStatusNotServing); err != nil {
+		t.Fatalf("ReportHealth returned error: %v", err)
+	}
+	if !stream.Receive() || stream.Msg().GetStatus() != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("Watch status after report = %v, %v; want NOT_SERVING", stream.Msg(), stream.Err())
+	}
+
+	list, err := NewHealthServer(registry).List(context.Background(), &grpc_health_v1.HealthListRequest{})
+	if err != nil || list.GetStatuses()[string(serviceID)].GetStatus() != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("List = %v, %v", list, err)
+	}
+}
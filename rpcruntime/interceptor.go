@@ -0,0 +1,220 @@
+package rpcruntime
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// CallContract identifies the generated facade contract used by one call.
+type CallContract int
+
+const (
+	CallContractInvalid CallContract = iota
+	CallContractNative
+	CallContractMessage
+)
+
+// CallOperation identifies the facade operation wrapped by an interceptor.
+type CallOperation int
+
+const (
+	CallOperationInvalid CallOperation = iota
+	CallOperationUnary
+	CallOperationStart
+	CallOperationSend
+	CallOperationRecv
+	CallOperationCloseSend
+	CallOperationFinish
+	CallOperationCancel
+)
+
+// CallInfo describes one registry-dispatched facade call.
+//
+// Method is the protobuf full method name. Kind is the kind of the first
+// server the route selects for unary calls and stream starts, which a failover
+// may move past, or the kind pinned in the stream session for later stream
+// operations. Stream is zero for unary calls and
+// stream starts.
+type CallInfo struct {
+	ServiceID ServiceID
+	Method    string
+	Contract  CallContract
+	Kind      ServerKind
+	Operation CallOperation
+	Stream    StreamHandle
+}
+
+// UnaryFunc is the next step of a unary interceptor chain.
+type UnaryFunc func(ctx context.Context, call CallInfo) error
+
+// StreamFunc is the next step of a stream operation interceptor chain.
+type StreamFunc func(ctx context.Context, call CallInfo) error
+
+// Interceptor wraps registry-dispatched unary calls and stream operations.
+//
+// Stream Start, Send, Recv, CloseSend, Finish and Cancel each pass through
+// WrapStream once; CallInfo.Operation tells them apart.
+type Interceptor interface {
+	WrapUnary(UnaryFunc) UnaryFunc
+	WrapStream(StreamFunc) StreamFunc
+}
+
+// UnaryInterceptorFunc adapts a unary wrapper into an Interceptor that leaves stream operations untouched.
+type UnaryInterceptorFunc func(UnaryFunc) UnaryFunc
+
+// WrapUnary implements Interceptor.
+func (f UnaryInterceptorFunc) WrapUnary(next UnaryFunc) UnaryFunc { return f(next) }
+
+// WrapStream implements Interceptor.
+func (f UnaryInterceptorFunc) WrapStream(next StreamFunc) StreamFunc { return next }
+
+// StreamInterceptorFunc adapts a stream wrapper into an Interceptor that leaves unary calls untouched.
+type StreamInterceptorFunc func(StreamFunc) StreamFunc
+
+// WrapUnary implements Interceptor.
+func (f StreamInterceptorFunc) WrapUnary(next UnaryFunc) UnaryFunc { return next }
+
+// WrapStream implements Interceptor.
+func (f StreamInterceptorFunc) WrapStream(next StreamFunc) StreamFunc { return f(next) }
+
+type interceptorChain struct {
+	global   []Interceptor
+	services map[ServiceID][]Interceptor
+}
+
+var (
+	interceptorsMu sync.Mutex
+	// interceptors is read on every facade call; writers replace the whole
+	// snapshot so the hot path stays a single atomic load.
+	interceptors atomic.Pointer[interceptorChain]
+)
+
+// RegisterInterceptors appends process-wide interceptors.
+// The first registered interceptor is the outermost wrapper.
+func RegisterInterceptors(list ...Interceptor) {
+	updateInterceptorChain(func(chain *interceptorChain) {
+		chain.global = appendInterceptors(chain.global, list)
+	})
+}
+
+// RegisterServiceInterceptors appends interceptors that only wrap calls for serviceID.
+// Service interceptors run inside the process-wide interceptors.
+func RegisterServiceInterceptors(serviceID ServiceID, list ...Interceptor) error {
+	if err := validateServiceID(serviceID); err != nil {
+		return err
+	}
+	updateInterceptorChain(func(chain *interceptorChain) {
+		chain.services[serviceID] = appendInterceptors(chain.services[serviceID], list)
+	})
+	return nil
+}
+
+// ClearInterceptors removes every process-wide and service interceptor.
+func ClearInterceptors() {
+	interceptorsMu.Lock()
+	defer interceptorsMu.Unlock()
+	interceptors.Store(nil)
+}
+
+// InterceptUnary runs next through the registered unary interceptor chain for call.ServiceID.
+//
+// The call is counted in the runtime metrics and, when a Tracer is set, runs
+// inside a span that encloses every interceptor. After Shutdown it fails with
+// ErrShutdown without running next. A call beyond the ConcurrencyLimit of its
+// method or service waits for a slot or fails with ErrConcurrencyLimitExceeded.
+func InterceptUnary(ctx context.Context, call CallInfo, next UnaryFunc) error {
+	if err := beginCall(); err != nil {
+		return err
+	}
+	defer endCall()
+	return observeUnary(ctx, loadTracer(), call, func(ctx context.Context, call CallInfo) error {
+		permit, err := acquireConcurrency(ctx, call, concurrencyUnarySlot)
+		if err != nil {
+			return err
+		}
+		defer permit.release()
+		return interceptUnary(ctx, call, next)
+	})
+}
+
+func interceptUnary(ctx context.Context, call CallInfo, next UnaryFunc) error {
+	chain := interceptors.Load()
+	if chain == nil {
+		return next(ctx, call)
+	}
+	service := chain.services[call.ServiceID]
+	for i := len(service) - 1; i >= 0; i-- {
+		next = service[i].WrapUnary(next)
+	}
+	for i := len(chain.global) - 1; i >= 0; i-- {
+		next = chain.global[i].WrapUnary(next)
+	}
+	return next(ctx, call)
+}
+
+// InterceptStream runs next through the registered stream interceptor chain for call.ServiceID.
+//
+// Start opens the metrics and, when a Tracer is set, the span of the stream
+// session; the operation that removes the session ends them. After Shutdown a
+// Start fails with ErrShutdown while operations on existing sessions still run.
+// Start also takes a slot of the ConcurrencyLimit of its method and service,
+// which the session holds until it is removed.
+func InterceptStream(ctx context.Context, call CallInfo, next StreamFunc) error {
+	if call.Operation == CallOperationStart {
+		if err := beginCall(); err != nil {
+			return err
+		}
+		defer endCall()
+	}
+	return observeStreamOperation(ctx, loadTracer(), call, func(ctx context.Context, call CallInfo) error {
+		if call.Operation == CallOperationStart {
+			permit, err := acquireConcurrency(ctx, call, concurrencyStreamSlot)
+			if err != nil {
+				return err
+			}
+			// The slot is held until the stream observation ends.
+			ctx.Value(streamObservationKey{}).(*streamObservation).permit = permit
+		}
+		return interceptStream(ctx, call, next)
+	})
+}
+
+func interceptStream(ctx context.Context, call CallInfo, next StreamFunc) error {
+	chain := interceptors.Load()
+	if chain == nil {
+		return next(ctx, call)
+	}
+	service := chain.services[call.ServiceID]
+	for i := len(service) - 1; i >= 0; i-- {
+		next = service[i].WrapStream(next)
+	}
+	for i := len(chain.global) - 1; i >= 0; i-- {
+		next = chain.global[i].WrapStream(next)
+	}
+	return next(ctx, call)
+}
+
+func updateInterceptorChain(update func(*interceptorChain)) {
+	interceptorsMu.Lock()
+	defer interceptorsMu.Unlock()
+
+	next := &interceptorChain{services: make(map[ServiceID][]Interceptor)}
+	if current := interceptors.Load(); current != nil {
+		next.global = append(next.global, current.global...)
+		for serviceID, list := range current.services {
+			next.services[serviceID] = append([]Interceptor(nil), list...)
+		}
+	}
+	update(next)
+	interceptors.Store(next)
+}
+
+func appendInterceptors(current, list []Interceptor) []Interceptor {
+	for _, interceptor := range list {
+		if interceptor != nil {
+			current = append(current, interceptor)
+		}
+	}
+	return current
+}
@@ -0,0 +1,126 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type recordingInterceptor struct {
+	name  string
+	calls *[]string
+}
+
+func (r recordingInterceptor) WrapUnary(next UnaryFunc) UnaryFunc {
+	return func(ctx context.Context, call CallInfo) error {
+		*r.calls = append(*r.calls, r.name+":unary")
+		return next(ctx, call)
+	}
+}
+
+func (r recordingInterceptor) WrapStream(next StreamFunc) StreamFunc {
+	return func(ctx context.Context, call CallInfo) error {
+		*r.calls = append(*r.calls, r.name+":stream")
+		return next(ctx, call)
+	}
+}
+
+func TestInterceptUnaryWithoutInterceptorsCallsNext(t *testing.T) {
+	ClearInterceptors()
+	t.Cleanup(ClearInterceptors)
+
+	call := CallInfo{ServiceID: "rpccgo.test.v1.Greeter", Method: "rpccgo.test.v1.Greeter.SayHello", Operation: CallOperationUnary}
+	var got CallInfo
+	err := InterceptUnary(context.Background(), call, func(_ context.Context, call CallInfo) error {
+		got = call
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("InterceptUnary returned error: %v", err)
+	}
+	if got != call {
+		t.Fatalf("next saw %+v, want %+v", got, call)
+	}
+}
+
+func TestInterceptorsRunGlobalBeforeServiceInRegistrationOrder(t *testing.T) {
+	ClearInterceptors()
+	t.Cleanup(ClearInterceptors)
+
+	const serviceID ServiceID = "rpccgo.test.v1.Greeter"
+	var calls []string
+	RegisterInterceptors(recordingInterceptor{name: "global-1", calls: &calls}, nil)
+	if err := RegisterServiceInterceptors(serviceID, recordingInterceptor{name: "service", calls: &calls}); err != nil {
+		t.Fatalf("RegisterServiceInterceptors returned error: %v", err)
+	}
+	RegisterInterceptors(recordingInterceptor{name: "global-2", calls: &calls})
+
+	err := InterceptStream(context.Background(), CallInfo{ServiceID: serviceID, Operation: CallOperationSend}, func(context.Context, CallInfo) error {
+		calls = append(calls, "next")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("InterceptStream returned error: %v", err)
+	}
+	want := []string{"global-1:stream", "global-2:stream", "service:stream", "next"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+
+	calls = nil
+	if err := InterceptUnary(context.Background(), CallInfo{ServiceID: "rpccgo.test.v1.Other"}, func(context.Context, CallInfo) error {
+		calls = append(calls, "next")
+		return nil
+	}); err != nil {
+		t.Fatalf("InterceptUnary returned error: %v", err)
+	}
+	want = []string{"global-1:unary", "global-2:unary", "next"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Fatalf("calls for other service = %v, want %v", calls, want)
+	}
+}
+
+func TestInterceptorFuncAdaptersOnlyWrapTheirCallShape(t *testing.T) {
+	ClearInterceptors()
+	t.Cleanup(ClearInterceptors)
+
+	rejected := errors.New("rejected")
+	RegisterInterceptors(UnaryInterceptorFunc(func(UnaryFunc) UnaryFunc {
+		return func(context.Context, CallInfo) error { return rejected }
+	}))
+
+	if err := InterceptUnary(context.Background(), CallInfo{}, func(context.Context, CallInfo) error { return nil }); !errors.Is(err, rejected) {
+		t.Fatalf("InterceptUnary error = %v, want %v", err, rejected)
+	}
+	nextCalled := false
+	if err := InterceptStream(context.Background(), CallInfo{}, func(context.Context, CallInfo) error {
+		nextCalled = true
+		return nil
+	}); err != nil {
+		t.Fatalf("InterceptStream returned error: %v", err)
+	}
+	if !nextCalled {
+		t.Fatal("unary interceptor blocked a stream operation")
+	}
+
+	ClearInterceptors()
+	RegisterInterceptors(StreamInterceptorFunc(func(StreamFunc) StreamFunc {
+		return func(context.Context, CallInfo) error { return rejected }
+	}))
+	if err := InterceptStream(context.Background(), CallInfo{}, func(context.Context, CallInfo) error { return nil }); !errors.Is(err, rejected) {
+		t.Fatalf("InterceptStream error = %v, want %v", err, rejected)
+	}
+	if err := InterceptUnary(context.Background(), CallInfo{}, func(context.Context, CallInfo) error { return nil }); err != nil {
+		t.Fatalf("InterceptUnary returned error: %v", err)
+	}
+}
+
+func TestRegisterServiceInterceptorsRejectsEmptyServiceID(t *testing.T) {
+	ClearInterceptors()
+	t.Cleanup(ClearInterceptors)
+
+	if err := RegisterServiceInterceptors("", recordingInterceptor{}); !errors.Is(err, ErrEmptyServiceID) {
+		t.Fatalf("RegisterServiceInterceptors error = %v, want %v", err, ErrEmptyServiceID)
+	}
+}
@@ -0,0 +1,263 @@
+// Schema of the concurrency limit occupancy returned by rpccgoConcurrencySnapshot
+// and rpcruntime.EncodeConcurrencySnapshot. Hosts decode it with their own
+// protobuf runtime; rpcruntime writes the wire format directly.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: concurrency_limits.proto
+
+package runtimev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ConcurrencySnapshot struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Configured limits ordered by service id and method.
+	Limits        []*ConcurrencyOccupancy `protobuf:"bytes,1,rep,name=limits,proto3" json:"limits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConcurrencySnapshot) Reset() {
+	*x = ConcurrencySnapshot{}
+	mi := &file_concurrency_limits_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConcurrencySnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConcurrencySnapshot) ProtoMessage() {}
+
+func (x *ConcurrencySnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_concurrency_limits_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConcurrencySnapshot.ProtoReflect.Descriptor instead.
+func (*ConcurrencySnapshot) Descriptor() ([]byte, []int) {
+	return file_concurrency_limits_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConcurrencySnapshot) GetLimits() []*ConcurrencyOccupancy {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+type ConcurrencyOccupancy struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	// Protobuf full name of the method; empty for a limit on the whole service.
+	Method string `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	// Zero leaves the calls unlimited.
+	MaxConcurrentUnary int32 `protobuf:"varint,3,opt,name=max_concurrent_unary,json=maxConcurrentUnary,proto3" json:"max_concurrent_unary,omitempty"`
+	MaxActiveStreams   int32 `protobuf:"varint,4,opt,name=max_active_streams,json=maxActiveStreams,proto3" json:"max_active_streams,omitempty"`
+	// Zero fails excess calls fast.
+	QueueTimeoutMs int64 `protobuf:"varint,5,opt,name=queue_timeout_ms,json=queueTimeoutMs,proto3" json:"queue_timeout_ms,omitempty"`
+	ActiveUnary    int32 `protobuf:"varint,6,opt,name=active_unary,json=activeUnary,proto3" json:"active_unary,omitempty"`
+	ActiveStreams  int32 `protobuf:"varint,7,opt,name=active_streams,json=activeStreams,proto3" json:"active_streams,omitempty"`
+	// Calls waiting for a slot.
+	QueuedUnary   int32 `protobuf:"varint,8,opt,name=queued_unary,json=queuedUnary,proto3" json:"queued_unary,omitempty"`
+	QueuedStreams int32 `protobuf:"varint,9,opt,name=queued_streams,json=queuedStreams,proto3" json:"queued_streams,omitempty"`
+	// Calls that failed with RESOURCE_EXHAUSTED.
+	Rejected      uint64 `protobuf:"varint,10,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConcurrencyOccupancy) Reset() {
+	*x = ConcurrencyOccupancy{}
+	mi := &file_concurrency_limits_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConcurrencyOccupancy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConcurrencyOccupancy) ProtoMessage() {}
+
+func (x *ConcurrencyOccupancy) ProtoReflect() protoreflect.Message {
+	mi := &file_concurrency_limits_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConcurrencyOccupancy.ProtoReflect.Descriptor instead.
+func (*ConcurrencyOccupancy) Descriptor() ([]byte, []int) {
+	return file_concurrency_limits_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ConcurrencyOccupancy) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *ConcurrencyOccupancy) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *ConcurrencyOccupancy) GetMaxConcurrentUnary() int32 {
+	if x != nil {
+		return x.MaxConcurrentUnary
+	}
+	return 0
+}
+
+func (x *ConcurrencyOccupancy) GetMaxActiveStreams() int32 {
+	if x != nil {
+		return x.MaxActiveStreams
+	}
+	return 0
+}
+
+func (x *ConcurrencyOccupancy) GetQueueTimeoutMs() int64 {
+	if x != nil {
+		return x.QueueTimeoutMs
+	}
+	return 0
+}
+
+func (x *ConcurrencyOccupancy) GetActiveUnary() int32 {
+	if x != nil {
+		return x.ActiveUnary
+	}
+	return 0
+}
+
+func (x *ConcurrencyOccupancy) GetActiveStreams() int32 {
+	if x != nil {
+		return x.ActiveStreams
+	}
+	return 0
+}
+
+func (x *ConcurrencyOccupancy) GetQueuedUnary() int32 {
+	if x != nil {
+		return x.QueuedUnary
+	}
+	return 0
+}
+
+func (x *ConcurrencyOccupancy) GetQueuedStreams() int32 {
+	if x != nil {
+		return x.QueuedStreams
+	}
+	return 0
+}
+
+func (x *ConcurrencyOccupancy) GetRejected() uint64 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+var File_concurrency_limits_proto protoreflect.FileDescriptor
+
+const file_concurrency_limits_proto_rawDesc = "" +
+	"\n" +
+	"\x18concurrency_limits.proto\x12\x11rpccgo.runtime.v1\"V\n" +
+	"\x13ConcurrencySnapshot\x12?\n" +
+	"\x06limits\x18\x01 \x03(\v2'.rpccgo.runtime.v1.ConcurrencyOccupancyR\x06limits\"\x87\x03\n" +
+	"\x14ConcurrencyOccupancy\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x120\n" +
+	"\x14max_concurrent_unary\x18\x03 \x01(\x05R\x12maxConcurrentUnary\x12,\n" +
+	"\x12max_active_streams\x18\x04 \x01(\x05R\x10maxActiveStreams\x12(\n" +
+	"\x10queue_timeout_ms\x18\x05 \x01(\x03R\x0equeueTimeoutMs\x12!\n" +
+	"\factive_unary\x18\x06 \x01(\x05R\vactiveUnary\x12%\n" +
+	"\x0eactive_streams\x18\a \x01(\x05R\ractiveStreams\x12!\n" +
+	"\fqueued_unary\x18\b \x01(\x05R\vqueuedUnary\x12%\n" +
+	"\x0equeued_streams\x18\t \x01(\x05R\rqueuedStreams\x12\x1a\n" +
+	"\brejected\x18\n" +
+	" \x01(\x04R\brejectedb\x06proto3"
+
+var (
+	file_concurrency_limits_proto_rawDescOnce sync.Once
+	file_concurrency_limits_proto_rawDescData []byte
+)
+
+func file_concurrency_limits_proto_rawDescGZIP() []byte {
+	file_concurrency_limits_proto_rawDescOnce.Do(func() {
+		file_concurrency_limits_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_concurrency_limits_proto_rawDesc), len(file_concurrency_limits_proto_rawDesc)))
+	})
+	return file_concurrency_limits_proto_rawDescData
+}
+
+var file_concurrency_limits_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_concurrency_limits_proto_goTypes = []any{
+	(*ConcurrencySnapshot)(nil),  // 0: rpccgo.runtime.v1.ConcurrencySnapshot
+	(*ConcurrencyOccupancy)(nil), // 1: rpccgo.runtime.v1.ConcurrencyOccupancy
+}
+var file_concurrency_limits_proto_depIdxs = []int32{
+	1, // 0: rpccgo.runtime.v1.ConcurrencySnapshot.limits:type_name -> rpccgo.runtime.v1.ConcurrencyOccupancy
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_concurrency_limits_proto_init() }
+func file_concurrency_limits_proto_init() {
+	if File_concurrency_limits_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_concurrency_limits_proto_rawDesc), len(file_concurrency_limits_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_concurrency_limits_proto_goTypes,
+		DependencyIndexes: file_concurrency_limits_proto_depIdxs,
+		MessageInfos:      file_concurrency_limits_proto_msgTypes,
+	}.Build()
+	File_concurrency_limits_proto = out.File
+	file_concurrency_limits_proto_goTypes = nil
+	file_concurrency_limits_proto_depIdxs = nil
+}
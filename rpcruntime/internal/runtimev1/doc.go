@@ -0,0 +1,7 @@
+// Package runtimev1 holds the Go types generated from the runtime report
+// schemas that ship next to rpcruntime. rpcruntime writes the reports in the
+// wire format directly; its tests decode them with these types so that an
+// encoder cannot drift from its schema.
+package runtimev1
+
+//go:generate protoc -I ../.. --go_out=. --go_opt=paths=source_relative,Mconcurrency_limits.proto=github.com/ygrpc/rpccgo/rpcruntime/internal/runtimev1,Mmetrics.proto=github.com/ygrpc/rpccgo/rpcruntime/internal/runtimev1,Mpinned_buffers.proto=github.com/ygrpc/rpccgo/rpcruntime/internal/runtimev1,Mreflection.proto=github.com/ygrpc/rpccgo/rpcruntime/internal/runtimev1,Mstream_sessions.proto=github.com/ygrpc/rpccgo/rpcruntime/internal/runtimev1 concurrency_limits.proto metrics.proto pinned_buffers.proto reflection.proto stream_sessions.proto
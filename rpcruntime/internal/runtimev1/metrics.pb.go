@@ -0,0 +1,386 @@
+// Schema of the runtime metrics snapshot returned by rpccgoMetricsSnapshot and
+// rpcruntime.EncodeMetricsSnapshot. Hosts decode it with their own protobuf
+// runtime; rpcruntime writes the wire format directly.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: metrics.proto
+
+package runtimev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MetricsSnapshot struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One entry per service, method and server kind that has been called.
+	Methods []*MethodMetrics `protobuf:"bytes,1,rep,name=methods,proto3" json:"methods,omitempty"`
+	// Memory handed out through the ABI helpers that still waits for rpccgoRelease.
+	PinnedBytes   int64 `protobuf:"varint,2,opt,name=pinned_bytes,json=pinnedBytes,proto3" json:"pinned_bytes,omitempty"`
+	PinnedBuffers int64 `protobuf:"varint,3,opt,name=pinned_buffers,json=pinnedBuffers,proto3" json:"pinned_buffers,omitempty"`
+	// Error ids that have been stored but neither taken nor expired.
+	ErrorRecords int64 `protobuf:"varint,4,opt,name=error_records,json=errorRecords,proto3" json:"error_records,omitempty"`
+	// Error ids that left the store without being taken: evicted past the
+	// store capacity, expired, or released with rpccgoDiscardError.
+	ErrorsDropped   uint64 `protobuf:"varint,5,opt,name=errors_dropped,json=errorsDropped,proto3" json:"errors_dropped,omitempty"`
+	ErrorsExpired   uint64 `protobuf:"varint,6,opt,name=errors_expired,json=errorsExpired,proto3" json:"errors_expired,omitempty"`
+	ErrorsDiscarded uint64 `protobuf:"varint,7,opt,name=errors_discarded,json=errorsDiscarded,proto3" json:"errors_discarded,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MetricsSnapshot) Reset() {
+	*x = MetricsSnapshot{}
+	mi := &file_metrics_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetricsSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricsSnapshot) ProtoMessage() {}
+
+func (x *MetricsSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricsSnapshot.ProtoReflect.Descriptor instead.
+func (*MetricsSnapshot) Descriptor() ([]byte, []int) {
+	return file_metrics_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MetricsSnapshot) GetMethods() []*MethodMetrics {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *MetricsSnapshot) GetPinnedBytes() int64 {
+	if x != nil {
+		return x.PinnedBytes
+	}
+	return 0
+}
+
+func (x *MetricsSnapshot) GetPinnedBuffers() int64 {
+	if x != nil {
+		return x.PinnedBuffers
+	}
+	return 0
+}
+
+func (x *MetricsSnapshot) GetErrorRecords() int64 {
+	if x != nil {
+		return x.ErrorRecords
+	}
+	return 0
+}
+
+func (x *MetricsSnapshot) GetErrorsDropped() uint64 {
+	if x != nil {
+		return x.ErrorsDropped
+	}
+	return 0
+}
+
+func (x *MetricsSnapshot) GetErrorsExpired() uint64 {
+	if x != nil {
+		return x.ErrorsExpired
+	}
+	return 0
+}
+
+func (x *MetricsSnapshot) GetErrorsDiscarded() uint64 {
+	if x != nil {
+		return x.ErrorsDiscarded
+	}
+	return 0
+}
+
+type MethodMetrics struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	// Protobuf full name of the method.
+	Method string `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	// rpcruntime.ServerKind: 1 go native, 2 cgo native, 3 cgo message,
+	// 4 connect, 5 grpc, 6 connect remote, 7 grpc remote.
+	ServerKind int32 `protobuf:"varint,3,opt,name=server_kind,json=serverKind,proto3" json:"server_kind,omitempty"`
+	// Unary invokes, or stream Starts for streaming methods.
+	Calls uint64 `protobuf:"varint,4,opt,name=calls,proto3" json:"calls,omitempty"`
+	// Failed calls keyed by canonical status code (google.rpc.Code).
+	Errors        map[int32]uint64 `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	ActiveStreams int64            `protobuf:"varint,6,opt,name=active_streams,json=activeStreams,proto3" json:"active_streams,omitempty"`
+	// Messages delivered to onRecv by callback-receive streams.
+	CallbackDeliveries uint64            `protobuf:"varint,7,opt,name=callback_deliveries,json=callbackDeliveries,proto3" json:"callback_deliveries,omitempty"`
+	Latency            *LatencyHistogram `protobuf:"bytes,8,opt,name=latency,proto3" json:"latency,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *MethodMetrics) Reset() {
+	*x = MethodMetrics{}
+	mi := &file_metrics_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MethodMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MethodMetrics) ProtoMessage() {}
+
+func (x *MethodMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MethodMetrics.ProtoReflect.Descriptor instead.
+func (*MethodMetrics) Descriptor() ([]byte, []int) {
+	return file_metrics_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MethodMetrics) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *MethodMetrics) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *MethodMetrics) GetServerKind() int32 {
+	if x != nil {
+		return x.ServerKind
+	}
+	return 0
+}
+
+func (x *MethodMetrics) GetCalls() uint64 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *MethodMetrics) GetErrors() map[int32]uint64 {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *MethodMetrics) GetActiveStreams() int64 {
+	if x != nil {
+		return x.ActiveStreams
+	}
+	return 0
+}
+
+func (x *MethodMetrics) GetCallbackDeliveries() uint64 {
+	if x != nil {
+		return x.CallbackDeliveries
+	}
+	return 0
+}
+
+func (x *MethodMetrics) GetLatency() *LatencyHistogram {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+type LatencyHistogram struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Inclusive upper bounds of the buckets in bucket_counts; the last count
+	// has no upper bound.
+	BoundsNanos   []int64  `protobuf:"varint,1,rep,packed,name=bounds_nanos,json=boundsNanos,proto3" json:"bounds_nanos,omitempty"`
+	BucketCounts  []uint64 `protobuf:"varint,2,rep,packed,name=bucket_counts,json=bucketCounts,proto3" json:"bucket_counts,omitempty"`
+	Count         uint64   `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	SumNanos      int64    `protobuf:"varint,4,opt,name=sum_nanos,json=sumNanos,proto3" json:"sum_nanos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LatencyHistogram) Reset() {
+	*x = LatencyHistogram{}
+	mi := &file_metrics_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LatencyHistogram) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatencyHistogram) ProtoMessage() {}
+
+func (x *LatencyHistogram) ProtoReflect() protoreflect.Message {
+	mi := &file_metrics_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatencyHistogram.ProtoReflect.Descriptor instead.
+func (*LatencyHistogram) Descriptor() ([]byte, []int) {
+	return file_metrics_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LatencyHistogram) GetBoundsNanos() []int64 {
+	if x != nil {
+		return x.BoundsNanos
+	}
+	return nil
+}
+
+func (x *LatencyHistogram) GetBucketCounts() []uint64 {
+	if x != nil {
+		return x.BucketCounts
+	}
+	return nil
+}
+
+func (x *LatencyHistogram) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *LatencyHistogram) GetSumNanos() int64 {
+	if x != nil {
+		return x.SumNanos
+	}
+	return 0
+}
+
+var File_metrics_proto protoreflect.FileDescriptor
+
+const file_metrics_proto_rawDesc = "" +
+	"\n" +
+	"\rmetrics.proto\x12\x11rpccgo.runtime.v1\"\xb5\x02\n" +
+	"\x0fMetricsSnapshot\x12:\n" +
+	"\amethods\x18\x01 \x03(\v2 .rpccgo.runtime.v1.MethodMetricsR\amethods\x12!\n" +
+	"\fpinned_bytes\x18\x02 \x01(\x03R\vpinnedBytes\x12%\n" +
+	"\x0epinned_buffers\x18\x03 \x01(\x03R\rpinnedBuffers\x12#\n" +
+	"\rerror_records\x18\x04 \x01(\x03R\ferrorRecords\x12%\n" +
+	"\x0eerrors_dropped\x18\x05 \x01(\x04R\rerrorsDropped\x12%\n" +
+	"\x0eerrors_expired\x18\x06 \x01(\x04R\rerrorsExpired\x12)\n" +
+	"\x10errors_discarded\x18\a \x01(\x04R\x0ferrorsDiscarded\"\x95\x03\n" +
+	"\rMethodMetrics\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x12\x1f\n" +
+	"\vserver_kind\x18\x03 \x01(\x05R\n" +
+	"serverKind\x12\x14\n" +
+	"\x05calls\x18\x04 \x01(\x04R\x05calls\x12D\n" +
+	"\x06errors\x18\x05 \x03(\v2,.rpccgo.runtime.v1.MethodMetrics.ErrorsEntryR\x06errors\x12%\n" +
+	"\x0eactive_streams\x18\x06 \x01(\x03R\ractiveStreams\x12/\n" +
+	"\x13callback_deliveries\x18\a \x01(\x04R\x12callbackDeliveries\x12=\n" +
+	"\alatency\x18\b \x01(\v2#.rpccgo.runtime.v1.LatencyHistogramR\alatency\x1a9\n" +
+	"\vErrorsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x05R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x04R\x05value:\x028\x01\"\x8d\x01\n" +
+	"\x10LatencyHistogram\x12!\n" +
+	"\fbounds_nanos\x18\x01 \x03(\x03R\vboundsNanos\x12#\n" +
+	"\rbucket_counts\x18\x02 \x03(\x04R\fbucketCounts\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x04R\x05count\x12\x1b\n" +
+	"\tsum_nanos\x18\x04 \x01(\x03R\bsumNanosb\x06proto3"
+
+var (
+	file_metrics_proto_rawDescOnce sync.Once
+	file_metrics_proto_rawDescData []byte
+)
+
+func file_metrics_proto_rawDescGZIP() []byte {
+	file_metrics_proto_rawDescOnce.Do(func() {
+		file_metrics_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_metrics_proto_rawDesc), len(file_metrics_proto_rawDesc)))
+	})
+	return file_metrics_proto_rawDescData
+}
+
+var file_metrics_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_metrics_proto_goTypes = []any{
+	(*MetricsSnapshot)(nil),  // 0: rpccgo.runtime.v1.MetricsSnapshot
+	(*MethodMetrics)(nil),    // 1: rpccgo.runtime.v1.MethodMetrics
+	(*LatencyHistogram)(nil), // 2: rpccgo.runtime.v1.LatencyHistogram
+	nil,                      // 3: rpccgo.runtime.v1.MethodMetrics.ErrorsEntry
+}
+var file_metrics_proto_depIdxs = []int32{
+	1, // 0: rpccgo.runtime.v1.MetricsSnapshot.methods:type_name -> rpccgo.runtime.v1.MethodMetrics
+	3, // 1: rpccgo.runtime.v1.MethodMetrics.errors:type_name -> rpccgo.runtime.v1.MethodMetrics.ErrorsEntry
+	2, // 2: rpccgo.runtime.v1.MethodMetrics.latency:type_name -> rpccgo.runtime.v1.LatencyHistogram
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_metrics_proto_init() }
+func file_metrics_proto_init() {
+	if File_metrics_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_metrics_proto_rawDesc), len(file_metrics_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_metrics_proto_goTypes,
+		DependencyIndexes: file_metrics_proto_depIdxs,
+		MessageInfos:      file_metrics_proto_msgTypes,
+	}.Build()
+	File_metrics_proto = out.File
+	file_metrics_proto_goTypes = nil
+	file_metrics_proto_depIdxs = nil
+}
@@ -0,0 +1,215 @@
+// Schema of the outstanding pinned buffer report returned by
+// rpccgoPinnedReport and rpcruntime.EncodePinnedBuffers. Hosts decode it with
+// their own protobuf runtime; rpcruntime writes the wire format directly.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: pinned_buffers.proto
+
+package runtimev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PinnedBufferList struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Buffers pinned while tracking was enabled and not yet released, oldest
+	// first.
+	Buffers       []*PinnedBuffer `protobuf:"bytes,1,rep,name=buffers,proto3" json:"buffers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinnedBufferList) Reset() {
+	*x = PinnedBufferList{}
+	mi := &file_pinned_buffers_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinnedBufferList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinnedBufferList) ProtoMessage() {}
+
+func (x *PinnedBufferList) ProtoReflect() protoreflect.Message {
+	mi := &file_pinned_buffers_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinnedBufferList.ProtoReflect.Descriptor instead.
+func (*PinnedBufferList) Descriptor() ([]byte, []int) {
+	return file_pinned_buffers_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PinnedBufferList) GetBuffers() []*PinnedBuffer {
+	if x != nil {
+		return x.Buffers
+	}
+	return nil
+}
+
+type PinnedBuffer struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Pointer handed to C; release it with rpccgoRelease.
+	Ptr uint64 `protobuf:"varint,1,opt,name=ptr,proto3" json:"ptr,omitempty"`
+	// Go function that pinned the buffer, such as a generated export or encoder.
+	Label          string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Size           int64  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	PinnedAtUnixMs int64  `protobuf:"varint,4,opt,name=pinned_at_unix_ms,json=pinnedAtUnixMs,proto3" json:"pinned_at_unix_ms,omitempty"`
+	// Empty unless stacks were requested when tracking was enabled.
+	Stack         string `protobuf:"bytes,5,opt,name=stack,proto3" json:"stack,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinnedBuffer) Reset() {
+	*x = PinnedBuffer{}
+	mi := &file_pinned_buffers_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinnedBuffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinnedBuffer) ProtoMessage() {}
+
+func (x *PinnedBuffer) ProtoReflect() protoreflect.Message {
+	mi := &file_pinned_buffers_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinnedBuffer.ProtoReflect.Descriptor instead.
+func (*PinnedBuffer) Descriptor() ([]byte, []int) {
+	return file_pinned_buffers_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PinnedBuffer) GetPtr() uint64 {
+	if x != nil {
+		return x.Ptr
+	}
+	return 0
+}
+
+func (x *PinnedBuffer) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *PinnedBuffer) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *PinnedBuffer) GetPinnedAtUnixMs() int64 {
+	if x != nil {
+		return x.PinnedAtUnixMs
+	}
+	return 0
+}
+
+func (x *PinnedBuffer) GetStack() string {
+	if x != nil {
+		return x.Stack
+	}
+	return ""
+}
+
+var File_pinned_buffers_proto protoreflect.FileDescriptor
+
+const file_pinned_buffers_proto_rawDesc = "" +
+	"\n" +
+	"\x14pinned_buffers.proto\x12\x11rpccgo.runtime.v1\"M\n" +
+	"\x10PinnedBufferList\x129\n" +
+	"\abuffers\x18\x01 \x03(\v2\x1f.rpccgo.runtime.v1.PinnedBufferR\abuffers\"\x8b\x01\n" +
+	"\fPinnedBuffer\x12\x10\n" +
+	"\x03ptr\x18\x01 \x01(\x04R\x03ptr\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12)\n" +
+	"\x11pinned_at_unix_ms\x18\x04 \x01(\x03R\x0epinnedAtUnixMs\x12\x14\n" +
+	"\x05stack\x18\x05 \x01(\tR\x05stackb\x06proto3"
+
+var (
+	file_pinned_buffers_proto_rawDescOnce sync.Once
+	file_pinned_buffers_proto_rawDescData []byte
+)
+
+func file_pinned_buffers_proto_rawDescGZIP() []byte {
+	file_pinned_buffers_proto_rawDescOnce.Do(func() {
+		file_pinned_buffers_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pinned_buffers_proto_rawDesc), len(file_pinned_buffers_proto_rawDesc)))
+	})
+	return file_pinned_buffers_proto_rawDescData
+}
+
+var file_pinned_buffers_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pinned_buffers_proto_goTypes = []any{
+	(*PinnedBufferList)(nil), // 0: rpccgo.runtime.v1.PinnedBufferList
+	(*PinnedBuffer)(nil),     // 1: rpccgo.runtime.v1.PinnedBuffer
+}
+var file_pinned_buffers_proto_depIdxs = []int32{
+	1, // 0: rpccgo.runtime.v1.PinnedBufferList.buffers:type_name -> rpccgo.runtime.v1.PinnedBuffer
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_pinned_buffers_proto_init() }
+func file_pinned_buffers_proto_init() {
+	if File_pinned_buffers_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pinned_buffers_proto_rawDesc), len(file_pinned_buffers_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pinned_buffers_proto_goTypes,
+		DependencyIndexes: file_pinned_buffers_proto_depIdxs,
+		MessageInfos:      file_pinned_buffers_proto_msgTypes,
+	}.Build()
+	File_pinned_buffers_proto = out.File
+	file_pinned_buffers_proto_goTypes = nil
+	file_pinned_buffers_proto_depIdxs = nil
+}
@@ -0,0 +1,354 @@
+// Schema of the reflection payloads returned by rpccgoReflectionServices and
+// rpccgoReflectionMethods, and by rpcruntime.EncodeServiceList and
+// rpcruntime.EncodeMethodList. rpccgoReflectionFileDescriptorSet returns a
+// google.protobuf.FileDescriptorSet instead.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: reflection.proto
+
+package runtimev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ServiceList struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One entry per service generated into the binary, ordered by service id.
+	Services      []*ServiceInfo `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceList) Reset() {
+	*x = ServiceList{}
+	mi := &file_reflection_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceList) ProtoMessage() {}
+
+func (x *ServiceList) ProtoReflect() protoreflect.Message {
+	mi := &file_reflection_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceList.ProtoReflect.Descriptor instead.
+func (*ServiceList) Descriptor() ([]byte, []int) {
+	return file_reflection_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ServiceList) GetServices() []*ServiceInfo {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+type ServiceInfo struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	// Path of the .proto file that declares the service.
+	ProtoFile string `protobuf:"bytes,2,opt,name=proto_file,json=protoFile,proto3" json:"proto_file,omitempty"`
+	// Whether the native contract was generated.
+	Native bool `protobuf:"varint,3,opt,name=native,proto3" json:"native,omitempty"`
+	// rpcruntime.ServerKind of the message contract: 4 connect, 5 grpc.
+	MessageTransport int32 `protobuf:"varint,4,opt,name=message_transport,json=messageTransport,proto3" json:"message_transport,omitempty"`
+	MethodCount      int32 `protobuf:"varint,5,opt,name=method_count,json=methodCount,proto3" json:"method_count,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ServiceInfo) Reset() {
+	*x = ServiceInfo{}
+	mi := &file_reflection_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceInfo) ProtoMessage() {}
+
+func (x *ServiceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_reflection_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceInfo.ProtoReflect.Descriptor instead.
+func (*ServiceInfo) Descriptor() ([]byte, []int) {
+	return file_reflection_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ServiceInfo) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *ServiceInfo) GetProtoFile() string {
+	if x != nil {
+		return x.ProtoFile
+	}
+	return ""
+}
+
+func (x *ServiceInfo) GetNative() bool {
+	if x != nil {
+		return x.Native
+	}
+	return false
+}
+
+func (x *ServiceInfo) GetMessageTransport() int32 {
+	if x != nil {
+		return x.MessageTransport
+	}
+	return 0
+}
+
+func (x *ServiceInfo) GetMethodCount() int32 {
+	if x != nil {
+		return x.MethodCount
+	}
+	return 0
+}
+
+type MethodList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Methods       []*MethodInfo          `protobuf:"bytes,1,rep,name=methods,proto3" json:"methods,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MethodList) Reset() {
+	*x = MethodList{}
+	mi := &file_reflection_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MethodList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MethodList) ProtoMessage() {}
+
+func (x *MethodList) ProtoReflect() protoreflect.Message {
+	mi := &file_reflection_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MethodList.ProtoReflect.Descriptor instead.
+func (*MethodList) Descriptor() ([]byte, []int) {
+	return file_reflection_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MethodList) GetMethods() []*MethodInfo {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+type MethodInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Protobuf full name of the method.
+	FullName string `protobuf:"bytes,2,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	// rpcruntime.MethodStreaming: 0 unary, 1 client streaming,
+	// 2 server streaming, 3 bidi streaming.
+	Streaming int32 `protobuf:"varint,3,opt,name=streaming,proto3" json:"streaming,omitempty"`
+	// Protobuf full names of the request and response messages.
+	RequestType   string `protobuf:"bytes,4,opt,name=request_type,json=requestType,proto3" json:"request_type,omitempty"`
+	ResponseType  string `protobuf:"bytes,5,opt,name=response_type,json=responseType,proto3" json:"response_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MethodInfo) Reset() {
+	*x = MethodInfo{}
+	mi := &file_reflection_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MethodInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MethodInfo) ProtoMessage() {}
+
+func (x *MethodInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_reflection_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MethodInfo.ProtoReflect.Descriptor instead.
+func (*MethodInfo) Descriptor() ([]byte, []int) {
+	return file_reflection_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MethodInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MethodInfo) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *MethodInfo) GetStreaming() int32 {
+	if x != nil {
+		return x.Streaming
+	}
+	return 0
+}
+
+func (x *MethodInfo) GetRequestType() string {
+	if x != nil {
+		return x.RequestType
+	}
+	return ""
+}
+
+func (x *MethodInfo) GetResponseType() string {
+	if x != nil {
+		return x.ResponseType
+	}
+	return ""
+}
+
+var File_reflection_proto protoreflect.FileDescriptor
+
+const file_reflection_proto_rawDesc = "" +
+	"\n" +
+	"\x10reflection.proto\x12\x11rpccgo.runtime.v1\"I\n" +
+	"\vServiceList\x12:\n" +
+	"\bservices\x18\x01 \x03(\v2\x1e.rpccgo.runtime.v1.ServiceInfoR\bservices\"\xb3\x01\n" +
+	"\vServiceInfo\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\x12\x1d\n" +
+	"\n" +
+	"proto_file\x18\x02 \x01(\tR\tprotoFile\x12\x16\n" +
+	"\x06native\x18\x03 \x01(\bR\x06native\x12+\n" +
+	"\x11message_transport\x18\x04 \x01(\x05R\x10messageTransport\x12!\n" +
+	"\fmethod_count\x18\x05 \x01(\x05R\vmethodCount\"E\n" +
+	"\n" +
+	"MethodList\x127\n" +
+	"\amethods\x18\x01 \x03(\v2\x1d.rpccgo.runtime.v1.MethodInfoR\amethods\"\xa3\x01\n" +
+	"\n" +
+	"MethodInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tfull_name\x18\x02 \x01(\tR\bfullName\x12\x1c\n" +
+	"\tstreaming\x18\x03 \x01(\x05R\tstreaming\x12!\n" +
+	"\frequest_type\x18\x04 \x01(\tR\vrequestType\x12#\n" +
+	"\rresponse_type\x18\x05 \x01(\tR\fresponseTypeb\x06proto3"
+
+var (
+	file_reflection_proto_rawDescOnce sync.Once
+	file_reflection_proto_rawDescData []byte
+)
+
+func file_reflection_proto_rawDescGZIP() []byte {
+	file_reflection_proto_rawDescOnce.Do(func() {
+		file_reflection_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_reflection_proto_rawDesc), len(file_reflection_proto_rawDesc)))
+	})
+	return file_reflection_proto_rawDescData
+}
+
+var file_reflection_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_reflection_proto_goTypes = []any{
+	(*ServiceList)(nil), // 0: rpccgo.runtime.v1.ServiceList
+	(*ServiceInfo)(nil), // 1: rpccgo.runtime.v1.ServiceInfo
+	(*MethodList)(nil),  // 2: rpccgo.runtime.v1.MethodList
+	(*MethodInfo)(nil),  // 3: rpccgo.runtime.v1.MethodInfo
+}
+var file_reflection_proto_depIdxs = []int32{
+	1, // 0: rpccgo.runtime.v1.ServiceList.services:type_name -> rpccgo.runtime.v1.ServiceInfo
+	3, // 1: rpccgo.runtime.v1.MethodList.methods:type_name -> rpccgo.runtime.v1.MethodInfo
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_reflection_proto_init() }
+func file_reflection_proto_init() {
+	if File_reflection_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_reflection_proto_rawDesc), len(file_reflection_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_reflection_proto_goTypes,
+		DependencyIndexes: file_reflection_proto_depIdxs,
+		MessageInfos:      file_reflection_proto_msgTypes,
+	}.Build()
+	File_reflection_proto = out.File
+	file_reflection_proto_goTypes = nil
+	file_reflection_proto_depIdxs = nil
+}
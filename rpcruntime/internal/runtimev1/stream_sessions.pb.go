@@ -0,0 +1,246 @@
+// Schema of the active stream session list returned by rpccgoStreamSessionsList
+// and rpcruntime.EncodeStreamSessions. Hosts decode it with their own protobuf
+// runtime; rpcruntime writes the wire format directly.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: stream_sessions.proto
+
+package runtimev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type StreamSessionList struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Active sessions in handle order.
+	Sessions      []*StreamSessionInfo `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamSessionList) Reset() {
+	*x = StreamSessionList{}
+	mi := &file_stream_sessions_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamSessionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamSessionList) ProtoMessage() {}
+
+func (x *StreamSessionList) ProtoReflect() protoreflect.Message {
+	mi := &file_stream_sessions_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamSessionList.ProtoReflect.Descriptor instead.
+func (*StreamSessionList) Descriptor() ([]byte, []int) {
+	return file_stream_sessions_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StreamSessionList) GetSessions() []*StreamSessionInfo {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type StreamSessionInfo struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Handle int32                  `protobuf:"varint,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	// rpcruntime.ServerKind: 1 go native, 2 cgo native, 3 cgo message,
+	// 4 connect, 5 grpc, 6 connect remote, 7 grpc remote.
+	ServerKind int32 `protobuf:"varint,2,opt,name=server_kind,json=serverKind,proto3" json:"server_kind,omitempty"`
+	// Empty for sessions created outside an intercepted stream Start.
+	ServiceId string `protobuf:"bytes,3,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	// Protobuf full name of the method.
+	Method string `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
+	// rpcruntime.CallContract: 1 native, 2 message.
+	Contract        int32 `protobuf:"varint,5,opt,name=contract,proto3" json:"contract,omitempty"`
+	StartedAtUnixMs int64 `protobuf:"varint,6,opt,name=started_at_unix_ms,json=startedAtUnixMs,proto3" json:"started_at_unix_ms,omitempty"`
+	// Last intercepted stream operation or callback receive delivery.
+	LastActivityUnixMs int64 `protobuf:"varint,7,opt,name=last_activity_unix_ms,json=lastActivityUnixMs,proto3" json:"last_activity_unix_ms,omitempty"`
+	CallbackReceive    bool  `protobuf:"varint,8,opt,name=callback_receive,json=callbackReceive,proto3" json:"callback_receive,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *StreamSessionInfo) Reset() {
+	*x = StreamSessionInfo{}
+	mi := &file_stream_sessions_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamSessionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamSessionInfo) ProtoMessage() {}
+
+func (x *StreamSessionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_stream_sessions_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamSessionInfo.ProtoReflect.Descriptor instead.
+func (*StreamSessionInfo) Descriptor() ([]byte, []int) {
+	return file_stream_sessions_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StreamSessionInfo) GetHandle() int32 {
+	if x != nil {
+		return x.Handle
+	}
+	return 0
+}
+
+func (x *StreamSessionInfo) GetServerKind() int32 {
+	if x != nil {
+		return x.ServerKind
+	}
+	return 0
+}
+
+func (x *StreamSessionInfo) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *StreamSessionInfo) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *StreamSessionInfo) GetContract() int32 {
+	if x != nil {
+		return x.Contract
+	}
+	return 0
+}
+
+func (x *StreamSessionInfo) GetStartedAtUnixMs() int64 {
+	if x != nil {
+		return x.StartedAtUnixMs
+	}
+	return 0
+}
+
+func (x *StreamSessionInfo) GetLastActivityUnixMs() int64 {
+	if x != nil {
+		return x.LastActivityUnixMs
+	}
+	return 0
+}
+
+func (x *StreamSessionInfo) GetCallbackReceive() bool {
+	if x != nil {
+		return x.CallbackReceive
+	}
+	return false
+}
+
+var File_stream_sessions_proto protoreflect.FileDescriptor
+
+const file_stream_sessions_proto_rawDesc = "" +
+	"\n" +
+	"\x15stream_sessions.proto\x12\x11rpccgo.runtime.v1\"U\n" +
+	"\x11StreamSessionList\x12@\n" +
+	"\bsessions\x18\x01 \x03(\v2$.rpccgo.runtime.v1.StreamSessionInfoR\bsessions\"\xaa\x02\n" +
+	"\x11StreamSessionInfo\x12\x16\n" +
+	"\x06handle\x18\x01 \x01(\x05R\x06handle\x12\x1f\n" +
+	"\vserver_kind\x18\x02 \x01(\x05R\n" +
+	"serverKind\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x03 \x01(\tR\tserviceId\x12\x16\n" +
+	"\x06method\x18\x04 \x01(\tR\x06method\x12\x1a\n" +
+	"\bcontract\x18\x05 \x01(\x05R\bcontract\x12+\n" +
+	"\x12started_at_unix_ms\x18\x06 \x01(\x03R\x0fstartedAtUnixMs\x121\n" +
+	"\x15last_activity_unix_ms\x18\a \x01(\x03R\x12lastActivityUnixMs\x12)\n" +
+	"\x10callback_receive\x18\b \x01(\bR\x0fcallbackReceiveb\x06proto3"
+
+var (
+	file_stream_sessions_proto_rawDescOnce sync.Once
+	file_stream_sessions_proto_rawDescData []byte
+)
+
+func file_stream_sessions_proto_rawDescGZIP() []byte {
+	file_stream_sessions_proto_rawDescOnce.Do(func() {
+		file_stream_sessions_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_stream_sessions_proto_rawDesc), len(file_stream_sessions_proto_rawDesc)))
+	})
+	return file_stream_sessions_proto_rawDescData
+}
+
+var file_stream_sessions_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_stream_sessions_proto_goTypes = []any{
+	(*StreamSessionList)(nil), // 0: rpccgo.runtime.v1.StreamSessionList
+	(*StreamSessionInfo)(nil), // 1: rpccgo.runtime.v1.StreamSessionInfo
+}
+var file_stream_sessions_proto_depIdxs = []int32{
+	1, // 0: rpccgo.runtime.v1.StreamSessionList.sessions:type_name -> rpccgo.runtime.v1.StreamSessionInfo
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_stream_sessions_proto_init() }
+func file_stream_sessions_proto_init() {
+	if File_stream_sessions_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stream_sessions_proto_rawDesc), len(file_stream_sessions_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_stream_sessions_proto_goTypes,
+		DependencyIndexes: file_stream_sessions_proto_depIdxs,
+		MessageInfos:      file_stream_sessions_proto_msgTypes,
+	}.Build()
+	File_stream_sessions_proto = out.File
+	file_stream_sessions_proto_goTypes = nil
+	file_stream_sessions_proto_depIdxs = nil
+}
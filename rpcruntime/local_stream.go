@@ -9,11 +9,38 @@ import (
 
 // LocalStreamOptions configures an in-process streaming call.
 type LocalStreamOptions struct {
+	// Method is the full protobuf method name. When set, a StreamBuffering
+	// override registered for it replaces RequestBuffer, ResponseBuffer and
+	// PipelinedSend.
+	Method         string
 	RequestBuffer  int
 	ResponseBuffer int
-	StreamClosed   error
-	NilRequest     error
-	NilResponse    error
+	// PipelinedSend lets Send return once the value is queued instead of
+	// waiting for the peer to receive it.
+	PipelinedSend bool
+	// BorrowedRequests marks requests that reference caller memory only valid
+	// until Send returns; their sends always wait for the server to receive
+	// them, even with PipelinedSend.
+	BorrowedRequests bool
+	StreamClosed     error
+	NilRequest       error
+	NilResponse      error
+}
+
+func (o LocalStreamOptions) resolved() LocalStreamOptions {
+	if o.Method == "" {
+		return o
+	}
+	if buffering, ok := StreamBufferingFor(o.Method); ok {
+		o.RequestBuffer = buffering.RequestBuffer
+		o.ResponseBuffer = buffering.ResponseBuffer
+		o.PipelinedSend = buffering.PipelinedSend
+	}
+	return o
+}
+
+func (o LocalStreamOptions) pipelinedRequests() bool {
+	return o.PipelinedSend && !o.BorrowedRequests
 }
 
 type streamItem[T any] struct {
@@ -21,6 +48,35 @@ type streamItem[T any] struct {
 	received chan struct{}
 }
 
+// newStreamItem wraps value for a stream queue. Only strict handoffs, where
+// the sender waits for the peer, need a received signal.
+func newStreamItem[T any](value T, strict bool) streamItem[T] {
+	item := streamItem[T]{value: value}
+	if strict {
+		item.received = make(chan struct{})
+	}
+	return item
+}
+
+func (i streamItem[T]) markReceived() T {
+	if i.received != nil {
+		close(i.received)
+	}
+	return i.value
+}
+
+// tryReceive takes an already queued item without blocking, so a closed send
+// side never hides values sent before it closed.
+func tryReceive[T any](items <-chan streamItem[T]) (T, bool) {
+	select {
+	case item := <-items:
+		return item.markReceived(), true
+	default:
+		var zero T
+		return zero, false
+	}
+}
+
 func isNilStreamValue(value any) bool {
 	if value == nil {
 		return true
@@ -46,6 +102,7 @@ type clientStreamingState[Req, Resp any] struct {
 	err           error
 	streamClosed  error
 	nilRequest    error
+	pipelined     bool
 }
 
 // ClientStreamForClient is the client-side endpoint of an in-process client-streaming RPC.
@@ -60,6 +117,7 @@ type ClientStreamForServer[Req, Resp any] struct {
 
 // NewClientStreaming constructs the client and server endpoints of an in-process client-streaming RPC.
 func NewClientStreaming[Req, Resp any](ctx context.Context, options LocalStreamOptions) (*ClientStreamForClient[Req, Resp], *ClientStreamForServer[Req, Resp], context.Context) {
+	options = options.resolved()
 	streamCtx, cancel := context.WithCancel(ctx)
 	state := &clientStreamingState[Req, Resp]{
 		ctx:          streamCtx,
@@ -69,6 +127,7 @@ func NewClientStreaming[Req, Resp any](ctx context.Context, options LocalStreamO
 		done:         make(chan struct{}),
 		streamClosed: options.StreamClosed,
 		nilRequest:   options.NilRequest,
+		pipelined:    options.pipelinedRequests(),
 	}
 	return &ClientStreamForClient[Req, Resp]{state: state}, &ClientStreamForServer[Req, Resp]{state: state}, streamCtx
 }
@@ -84,7 +143,7 @@ func (c *ClientStreamForClient[Req, Resp]) Send(ctx context.Context, req Req) er
 		return s.streamClosed
 	default:
 	}
-	item := streamItem[Req]{value: req, received: make(chan struct{})}
+	item := newStreamItem(req, !s.pipelined)
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -98,6 +157,9 @@ func (c *ClientStreamForClient[Req, Resp]) Send(ctx context.Context, req Req) er
 	case <-s.sendDone:
 		return s.streamClosed
 	case s.requests <- item:
+		if s.pipelined {
+			return nil
+		}
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -153,11 +215,8 @@ func (c *ClientStreamForClient[Req, Resp]) Cancel(ctx context.Context) error {
 
 // Recv receives the next request from the client endpoint.
 func (s *ClientStreamForServer[Req, Resp]) Recv(ctx context.Context) (Req, error) {
-	select {
-	case req := <-s.state.requests:
-		close(req.received)
-		return req.value, nil
-	default:
+	if req, ok := tryReceive(s.state.requests); ok {
+		return req, nil
 	}
 	var zero Req
 	select {
@@ -176,9 +235,11 @@ func (s *ClientStreamForServer[Req, Resp]) Recv(ctx context.Context) (Req, error
 		}
 		return zero, s.state.ctx.Err()
 	case req := <-s.state.requests:
-		close(req.received)
-		return req.value, nil
+		return req.markReceived(), nil
 	case <-s.state.sendDone:
+		if req, ok := tryReceive(s.state.requests); ok {
+			return req, nil
+		}
 		return zero, io.EOF
 	}
 }
@@ -203,6 +264,7 @@ type serverStreamingState[Resp any] struct {
 	err          error
 	streamClosed error
 	nilResponse  error
+	pipelined    bool
 }
 
 // ServerStreamForClient is the client-side endpoint of an in-process server-streaming RPC.
@@ -217,6 +279,7 @@ type ServerStreamForServer[Resp any] struct {
 
 // NewServerStreaming constructs the client and server endpoints of an in-process server-streaming RPC.
 func NewServerStreaming[Resp any](ctx context.Context, options LocalStreamOptions) (*ServerStreamForClient[Resp], *ServerStreamForServer[Resp], context.Context) {
+	options = options.resolved()
 	streamCtx, cancel := context.WithCancel(ctx)
 	finishCtx, finishCancel := context.WithCancel(context.Background())
 	state := &serverStreamingState[Resp]{
@@ -228,6 +291,7 @@ func NewServerStreaming[Resp any](ctx context.Context, options LocalStreamOption
 		done:         make(chan struct{}),
 		streamClosed: options.StreamClosed,
 		nilResponse:  options.NilResponse,
+		pipelined:    options.PipelinedSend,
 	}
 	return &ServerStreamForClient[Resp]{state: state}, &ServerStreamForServer[Resp]{state: state}, streamCtx
 }
@@ -235,6 +299,9 @@ func NewServerStreaming[Resp any](ctx context.Context, options LocalStreamOption
 // Recv receives the next response from the server endpoint.
 func (c *ServerStreamForClient[Resp]) Recv(ctx context.Context) (Resp, error) {
 	s := c.state
+	if resp, ok := tryReceive(s.responses); ok {
+		return resp, nil
+	}
 	var zero Resp
 	select {
 	case <-ctx.Done():
@@ -242,9 +309,11 @@ func (c *ServerStreamForClient[Resp]) Recv(ctx context.Context) (Resp, error) {
 	case <-s.ctx.Done():
 		return zero, s.ctx.Err()
 	case resp := <-s.responses:
-		close(resp.received)
-		return resp.value, nil
+		return resp.markReceived(), nil
 	case <-s.done:
+		if resp, ok := tryReceive(s.responses); ok {
+			return resp, nil
+		}
 		if s.err != nil {
 			return zero, s.err
 		}
@@ -269,6 +338,9 @@ func (s *ServerStreamForServer[Resp]) Send(ctx context.Context, resp Resp) error
 	if s.state.nilResponse != nil && isNilStreamValue(resp) {
 		return s.state.nilResponse
 	}
+	if s.state.pipelined {
+		return queueStreamResponse(ctx, s.state.ctx, s.state.finishCtx, s.state.done, s.state.responses, s.state.streamClosed, func() error { return s.state.err }, resp)
+	}
 	return sendStreamResponse(ctx, s.state.ctx, s.state.finishCtx, s.state.done, s.state.responses, s.state.streamClosed, func() error { return s.state.err }, resp)
 }
 
@@ -300,6 +372,7 @@ type bidiStreamingState[Req, Resp any] struct {
 	streamClosed  error
 	nilRequest    error
 	nilResponse   error
+	pipelined     bool
 }
 
 // BidiStreamForClient is the client-side endpoint of an in-process bidirectional-streaming RPC.
@@ -314,6 +387,7 @@ type BidiStreamForServer[Req, Resp any] struct {
 
 // NewBidiStreaming constructs the client and server endpoints of an in-process bidirectional-streaming RPC.
 func NewBidiStreaming[Req, Resp any](ctx context.Context, options LocalStreamOptions) (*BidiStreamForClient[Req, Resp], *BidiStreamForServer[Req, Resp], context.Context) {
+	options = options.resolved()
 	streamCtx, cancel := context.WithCancel(ctx)
 	finishCtx, finishCancel := context.WithCancel(context.Background())
 	state := &bidiStreamingState[Req, Resp]{
@@ -328,6 +402,7 @@ func NewBidiStreaming[Req, Resp any](ctx context.Context, options LocalStreamOpt
 		streamClosed: options.StreamClosed,
 		nilRequest:   options.NilRequest,
 		nilResponse:  options.NilResponse,
+		pipelined:    options.pipelinedRequests(),
 	}
 	return &BidiStreamForClient[Req, Resp]{state: state}, &BidiStreamForServer[Req, Resp]{state: state}, streamCtx
 }
@@ -343,7 +418,7 @@ func (c *BidiStreamForClient[Req, Resp]) Send(ctx context.Context, req Req) erro
 		return s.streamClosed
 	default:
 	}
-	item := streamItem[Req]{value: req, received: make(chan struct{})}
+	item := newStreamItem(req, !s.pipelined)
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -357,6 +432,9 @@ func (c *BidiStreamForClient[Req, Resp]) Send(ctx context.Context, req Req) erro
 	case <-s.sendDone:
 		return s.streamClosed
 	case s.requests <- item:
+		if s.pipelined {
+			return nil
+		}
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -384,11 +462,8 @@ func (c *BidiStreamForClient[Req, Resp]) Send(ctx context.Context, req Req) erro
 func (c *BidiStreamForClient[Req, Resp]) Recv(ctx context.Context) (Resp, error) {
 	s := c.state
 	var zero Resp
-	select {
-	case resp := <-s.responses:
-		close(resp.received)
-		return resp.value, nil
-	default:
+	if resp, ok := tryReceive(s.responses); ok {
+		return resp, nil
 	}
 	select {
 	case <-ctx.Done():
@@ -396,14 +471,10 @@ func (c *BidiStreamForClient[Req, Resp]) Recv(ctx context.Context) (Resp, error)
 	case <-s.ctx.Done():
 		return zero, s.ctx.Err()
 	case resp := <-s.responses:
-		close(resp.received)
-		return resp.value, nil
+		return resp.markReceived(), nil
 	case <-s.done:
-		select {
-		case resp := <-s.responses:
-			close(resp.received)
-			return resp.value, nil
-		default:
+		if resp, ok := tryReceive(s.responses); ok {
+			return resp, nil
 		}
 		if s.err != nil {
 			return zero, s.err
@@ -459,15 +530,15 @@ func (c *BidiStreamForClient[Req, Resp]) Cancel(ctx context.Context) error {
 
 // Recv receives the next request from the client endpoint.
 func (s *BidiStreamForServer[Req, Resp]) Recv(ctx context.Context) (Req, error) {
-	select {
-	case req := <-s.state.requests:
-		close(req.received)
-		return req.value, nil
-	default:
+	if req, ok := tryReceive(s.state.requests); ok {
+		return req, nil
 	}
 	var zero Req
 	select {
 	case <-s.state.sendDone:
+		if req, ok := tryReceive(s.state.requests); ok {
+			return req, nil
+		}
 		return zero, io.EOF
 	default:
 	}
@@ -477,9 +548,11 @@ func (s *BidiStreamForServer[Req, Resp]) Recv(ctx context.Context) (Req, error)
 	case <-s.state.ctx.Done():
 		return zero, s.state.ctx.Err()
 	case req := <-s.state.requests:
-		close(req.received)
-		return req.value, nil
+		return req.markReceived(), nil
 	case <-s.state.sendDone:
+		if req, ok := tryReceive(s.state.requests); ok {
+			return req, nil
+		}
 		return zero, io.EOF
 	}
 }
@@ -489,7 +562,7 @@ func (s *BidiStreamForServer[Req, Resp]) Send(ctx context.Context, resp Resp) er
 	if s.state.nilResponse != nil && isNilStreamValue(resp) {
 		return s.state.nilResponse
 	}
-	return sendBidiStreamResponse(ctx, s.state.ctx, s.state.finishCtx, s.state.done, s.state.responses, s.state.streamClosed, func() error { return s.state.err }, resp)
+	return queueStreamResponse(ctx, s.state.ctx, s.state.finishCtx, s.state.done, s.state.responses, s.state.streamClosed, func() error { return s.state.err }, resp)
 }
 
 // FinishRequested returns a channel closed when the client asks the server to finish gracefully.
@@ -506,7 +579,7 @@ func (s *BidiStreamForServer[Req, Resp]) Complete(err error) {
 }
 
 func sendStreamResponse[T any](ctx, streamCtx, finishCtx context.Context, done <-chan struct{}, responses chan<- streamItem[T], streamClosed error, streamErr func() error, value T) error {
-	item := streamItem[T]{value: value, received: make(chan struct{})}
+	item := newStreamItem(value, true)
 	select {
 	case <-done:
 		if err := streamErr(); err != nil {
@@ -558,8 +631,10 @@ func sendStreamResponse[T any](ctx, streamCtx, finishCtx context.Context, done <
 	}
 }
 
-func sendBidiStreamResponse[T any](ctx, streamCtx, finishCtx context.Context, done <-chan struct{}, responses chan<- streamItem[T], streamClosed error, streamErr func() error, value T) error {
-	item := streamItem[T]{value: value, received: make(chan struct{})}
+// queueStreamResponse is sendStreamResponse without waiting for the client to
+// receive the response.
+func queueStreamResponse[T any](ctx, streamCtx, finishCtx context.Context, done <-chan struct{}, responses chan<- streamItem[T], streamClosed error, streamErr func() error, value T) error {
+	item := newStreamItem(value, false)
 	select {
 	case <-done:
 		if err := streamErr(); err != nil {
@@ -31,6 +31,22 @@ func DecodeMessage(ptr uintptr, length int32, message protobuf.Message) error {
 	return nil
 }
 
+// BorrowBytes returns a view of a borrowed ptr/len payload without copying it.
+// The view is only valid while the caller keeps the memory alive, normally
+// until the export that received it returns.
+func BorrowBytes(ptr unsafe.Pointer, length int32) ([]byte, error) {
+	if length < 0 {
+		return nil, errors.New("payload length is negative")
+	}
+	if length == 0 {
+		return nil, nil
+	}
+	if ptr == nil {
+		return nil, errors.New("payload pointer is nil")
+	}
+	return unsafe.Slice((*byte)(ptr), int(length)), nil
+}
+
 // EncodeMessage marshals message into a pinned protobuf ptr/len payload.
 // Callers must release the returned pointer with Release after the ABI consumer is done with it.
 func EncodeMessage(message protobuf.Message) (uintptr, int32, error) {
@@ -0,0 +1,201 @@
+package rpcruntime
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Metadata holds request or response metadata keyed by lower-case names, the
+// form shared by HTTP headers and gRPC metadata.
+type Metadata map[string][]string
+
+var errMetadataTruncated = errors.New("rpccgo: encoded metadata is truncated")
+
+// Append adds values under the lower-cased key.
+func (md Metadata) Append(key string, values ...string) {
+	key = strings.ToLower(key)
+	md[key] = append(md[key], values...)
+}
+
+// Get returns the values stored under key.
+func (md Metadata) Get(key string) []string {
+	return md[strings.ToLower(key)]
+}
+
+// Copy returns a deep copy of md.
+func (md Metadata) Copy() Metadata {
+	if md == nil {
+		return nil
+	}
+	out := make(Metadata, len(md))
+	for key, values := range md {
+		out[key] = append([]string(nil), values...)
+	}
+	return out
+}
+
+func joinMetadataInto(dst Metadata, src map[string][]string) Metadata {
+	for key, values := range src {
+		if len(values) == 0 {
+			continue
+		}
+		if dst == nil {
+			dst = make(Metadata, len(src))
+		}
+		dst.Append(key, values...)
+	}
+	return dst
+}
+
+// EncodeMetadata encodes md as the key/value pairs used by the C ABI. Each
+// value is written as a little-endian uint32 key length, the key bytes, a
+// little-endian uint32 value length and the value bytes; a key with several
+// values is written once per value. Keys are sorted so the encoding is stable.
+func EncodeMetadata(md Metadata) []byte {
+	keys := make([]string, 0, len(md))
+	size := 0
+	for key, values := range md {
+		keys = append(keys, key)
+		for _, value := range values {
+			size += 8 + len(key) + len(value)
+		}
+	}
+	sort.Strings(keys)
+	out := make([]byte, 0, size)
+	for _, key := range keys {
+		for _, value := range md[key] {
+			out = binary.LittleEndian.AppendUint32(out, uint32(len(key)))
+			out = append(out, key...)
+			out = binary.LittleEndian.AppendUint32(out, uint32(len(value)))
+			out = append(out, value...)
+		}
+	}
+	return out
+}
+
+// DecodeMetadata decodes key/value pairs written by EncodeMetadata.
+func DecodeMetadata(data []byte) (Metadata, error) {
+	var md Metadata
+	for len(data) > 0 {
+		key, rest, err := decodeMetadataField(data)
+		if err != nil {
+			return nil, err
+		}
+		value, rest, err := decodeMetadataField(rest)
+		if err != nil {
+			return nil, err
+		}
+		if key == "" {
+			return nil, errors.New("rpccgo: encoded metadata key is empty")
+		}
+		if md == nil {
+			md = make(Metadata)
+		}
+		md.Append(key, value)
+		data = rest
+	}
+	return md, nil
+}
+
+func decodeMetadataField(data []byte) (string, []byte, error) {
+	if len(data) < 4 {
+		return "", nil, errMetadataTruncated
+	}
+	length := binary.LittleEndian.Uint32(data)
+	data = data[4:]
+	if uint64(length) > uint64(len(data)) {
+		return "", nil, errMetadataTruncated
+	}
+	return string(data[:length]), data[length:], nil
+}
+
+// EncodePinnedMetadata encodes md into a pinned ptr/len payload. An empty md
+// yields a zero pointer and length. Callers must release a non-zero pointer
+// with Release after the ABI consumer is done with it.
+func EncodePinnedMetadata(md Metadata) (uintptr, int32, error) {
+	return pinPayload(EncodeMetadata(md))
+}
+
+// ResponseMetadata collects the response headers and trailers reported for a
+// call made with a context from WithResponseMetadata.
+type ResponseMetadata struct {
+	mu      sync.Mutex
+	header  Metadata
+	trailer Metadata
+}
+
+// Header returns a copy of the response headers collected so far.
+func (r *ResponseMetadata) Header() Metadata {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.header.Copy()
+}
+
+// Trailer returns a copy of the response trailers collected so far.
+func (r *ResponseMetadata) Trailer() Metadata {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.trailer.Copy()
+}
+
+func (r *ResponseMetadata) take() (Metadata, Metadata) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	header, trailer := r.header, r.trailer
+	r.header, r.trailer = nil, nil
+	return header, trailer
+}
+
+type requestMetadataKey struct{}
+
+type responseMetadataKey struct{}
+
+// WithRequestMetadata returns a context carrying md as the request metadata
+// for calls made with it. Transport servers receive it as Connect request
+// headers or incoming gRPC metadata, and remote transports forward it. md must
+// not be modified after the call.
+func WithRequestMetadata(ctx context.Context, md Metadata) context.Context {
+	return context.WithValue(ctx, requestMetadataKey{}, md)
+}
+
+// RequestMetadataFromContext returns the request metadata carried by ctx.
+// Go native servers use it to read the metadata sent by the caller.
+func RequestMetadataFromContext(ctx context.Context) Metadata {
+	md, _ := ctx.Value(requestMetadataKey{}).(Metadata)
+	return md
+}
+
+// WithResponseMetadata returns a context that collects the response headers
+// and trailers reported by the server handling calls made with it.
+func WithResponseMetadata(ctx context.Context) (context.Context, *ResponseMetadata) {
+	response := &ResponseMetadata{}
+	return context.WithValue(ctx, responseMetadataKey{}, response), response
+}
+
+// SetResponseHeader adds md to the response headers of the call carried by
+// ctx. It does nothing when the caller does not collect response metadata.
+func SetResponseHeader(ctx context.Context, md map[string][]string) {
+	response, ok := ctx.Value(responseMetadataKey{}).(*ResponseMetadata)
+	if !ok || len(md) == 0 {
+		return
+	}
+	response.mu.Lock()
+	response.header = joinMetadataInto(response.header, md)
+	response.mu.Unlock()
+}
+
+// SetResponseTrailer adds md to the response trailers of the call carried by
+// ctx. It does nothing when the caller does not collect response metadata.
+func SetResponseTrailer(ctx context.Context, md map[string][]string) {
+	response, ok := ctx.Value(responseMetadataKey{}).(*ResponseMetadata)
+	if !ok || len(md) == 0 {
+		return
+	}
+	response.mu.Lock()
+	response.trailer = joinMetadataInto(response.trailer, md)
+	response.mu.Unlock()
+}
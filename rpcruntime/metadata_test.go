@@ -0,0 +1,262 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"connectrpc.com/connect"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestMetadataEncodingRoundTrips(t *testing.T) {
+	md := Metadata{}
+	md.Append("Authorization", "Bearer token")
+	md.Append("x-trace", "a", "b")
+	md.Append("x-empty", "")
+
+	data := EncodeMetadata(md)
+	got, err := DecodeMetadata(data)
+	if err != nil {
+		t.Fatalf("DecodeMetadata returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, md) {
+		t.Fatalf("DecodeMetadata = %v, want %v", got, md)
+	}
+	if got.Get("AUTHORIZATION")[0] != "Bearer token" {
+		t.Fatalf("Get is not case-insensitive: %v", got)
+	}
+	if string(EncodeMetadata(got)) != string(data) {
+		t.Fatal("EncodeMetadata is not stable")
+	}
+}
+
+func TestDecodeMetadataRejectsMalformedPayloads(t *testing.T) {
+	data := EncodeMetadata(Metadata{"key": {"value"}})
+	for name, payload := range map[string][]byte{
+		"short length": data[:2],
+		"short key":    data[:6],
+		"short value":  data[:len(data)-1],
+		"empty key":    {0, 0, 0, 0, 0, 0, 0, 0},
+	} {
+		if _, err := DecodeMetadata(payload); err == nil {
+			t.Fatalf("%s: DecodeMetadata succeeded, want error", name)
+		}
+	}
+	if md, err := DecodeMetadata(nil); err != nil || md != nil {
+		t.Fatalf("DecodeMetadata(nil) = %v, %v; want nil, nil", md, err)
+	}
+}
+
+func TestConnectHandlerContextExchangesMetadata(t *testing.T) {
+	ctx, response := WithResponseMetadata(WithRequestMetadata(context.Background(), Metadata{"authorization": {"token"}}))
+	callCtx, finish := ConnectHandlerContext(ctx, "/test.v1.Service/Unary")
+
+	info, ok := connect.CallInfoForHandlerContext(callCtx)
+	if !ok {
+		t.Fatal("handler context has no CallInfo")
+	}
+	if got := info.RequestHeader().Get("Authorization"); got != "token" {
+		t.Fatalf("request header = %q, want token", got)
+	}
+	if got := info.Spec().Procedure; got != "/test.v1.Service/Unary" {
+		t.Fatalf("procedure = %q", got)
+	}
+	info.ResponseHeader().Set("X-Header", "h")
+	info.ResponseTrailer().Set("X-Trailer", "t")
+	finish()
+	finish()
+
+	if got := response.Header(); !reflect.DeepEqual(got, Metadata{"x-header": {"h"}}) {
+		t.Fatalf("response header = %v", got)
+	}
+	if got := response.Trailer(); !reflect.DeepEqual(got, Metadata{"x-trailer": {"t"}}) {
+		t.Fatalf("response trailer = %v", got)
+	}
+}
+
+func TestConnectStreamingHandlerContextReportsHeaderOnFirstSend(t *testing.T) {
+	ctx, response := WithResponseMetadata(WithRequestMetadata(context.Background(), Metadata{"x-trace": {"abc"}}))
+	conn := &ConnectStreamingHandlerConn{SendFunc: func(any) error { return nil }}
+	callCtx, finish := ConnectStreamingHandlerContext(ctx, conn)
+
+	info, ok := connect.CallInfoForHandlerContext(callCtx)
+	if !ok {
+		t.Fatal("handler context has no CallInfo")
+	}
+	if got := info.RequestHeader().Get("X-Trace"); got != "abc" {
+		t.Fatalf("request header = %q, want abc", got)
+	}
+	conn.ResponseHeader().Set("X-Header", "h")
+	if err := conn.Send(struct{}{}); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	if got := response.Header(); !reflect.DeepEqual(got, Metadata{"x-header": {"h"}}) {
+		t.Fatalf("response header after first send = %v", got)
+	}
+	conn.ResponseHeader().Set("X-Late", "ignored")
+	conn.ResponseTrailer().Set("X-Trailer", "t")
+	finish()
+
+	if got := response.Header(); !reflect.DeepEqual(got, Metadata{"x-header": {"h"}}) {
+		t.Fatalf("response header after finish = %v", got)
+	}
+	if got := response.Trailer(); !reflect.DeepEqual(got, Metadata{"x-trailer": {"t"}}) {
+		t.Fatalf("response trailer = %v", got)
+	}
+}
+
+func TestConnectClientContextForwardsMetadata(t *testing.T) {
+	const procedure = "/test.v1.Service/Echo"
+	mux := http.NewServeMux()
+	mux.Handle(procedure, connect.NewUnaryHandler(procedure, func(ctx context.Context, req *connect.Request[wrapperspb.StringValue]) (*connect.Response[wrapperspb.StringValue], error) {
+		resp := connect.NewResponse(wrapperspb.String(req.Header().Get("Authorization")))
+		resp.Header().Set("X-Header", "h")
+		resp.Trailer().Set("X-Trailer", "t")
+		return resp, nil
+	}))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := connect.NewClient[wrapperspb.StringValue, wrapperspb.StringValue](server.Client(), server.URL+procedure)
+
+	ctx, response := WithResponseMetadata(WithRequestMetadata(context.Background(), Metadata{"authorization": {"token"}}))
+	callCtx, finish := ConnectClientContext(ctx)
+	resp, err := client.CallUnary(callCtx, connect.NewRequest(wrapperspb.String("")))
+	if err != nil {
+		t.Fatalf("CallUnary returned error: %v", err)
+	}
+	finish()
+
+	if got := resp.Msg.GetValue(); got != "token" {
+		t.Fatalf("server saw authorization %q, want token", got)
+	}
+	if got := response.Header().Get("x-header"); !reflect.DeepEqual(got, []string{"h"}) {
+		t.Fatalf("response header = %v", got)
+	}
+	if got := response.Trailer().Get("x-trailer"); !reflect.DeepEqual(got, []string{"t"}) {
+		t.Fatalf("response trailer = %v", got)
+	}
+}
+
+func TestGRPCHandlerContextExchangesMetadata(t *testing.T) {
+	ctx, response := WithResponseMetadata(WithRequestMetadata(context.Background(), Metadata{"authorization": {"token"}}))
+	callCtx := GRPCHandlerContext(ctx, "/test.v1.Service/Unary")
+
+	incoming, ok := metadata.FromIncomingContext(callCtx)
+	if !ok || !reflect.DeepEqual(incoming.Get("authorization"), []string{"token"}) {
+		t.Fatalf("incoming metadata = %v", incoming)
+	}
+	if got, _ := grpc.Method(callCtx); got != "/test.v1.Service/Unary" {
+		t.Fatalf("grpc.Method = %q", got)
+	}
+	if err := grpc.SetHeader(callCtx, metadata.Pairs("x-header", "h")); err != nil {
+		t.Fatalf("grpc.SetHeader returned error: %v", err)
+	}
+	if err := grpc.SetTrailer(callCtx, metadata.Pairs("x-trailer", "t")); err != nil {
+		t.Fatalf("grpc.SetTrailer returned error: %v", err)
+	}
+
+	if got := response.Header(); !reflect.DeepEqual(got, Metadata{"x-header": {"h"}}) {
+		t.Fatalf("response header = %v", got)
+	}
+	if got := response.Trailer(); !reflect.DeepEqual(got, Metadata{"x-trailer": {"t"}}) {
+		t.Fatalf("response trailer = %v", got)
+	}
+}
+
+func TestGRPCStreamAdaptersReportMetadata(t *testing.T) {
+	ctx, response := WithResponseMetadata(WithRequestMetadata(context.Background(), Metadata{"x-trace": {"abc"}}))
+	_, server, streamCtx := NewServerStreaming[*grpcStreamTestResponse](ctx, LocalStreamOptions{
+		StreamClosed: errors.New("stream closed"),
+	})
+	adapter := NewGRPCServerStreamingServer[grpcStreamTestResponse](streamCtx, server)
+
+	incoming, _ := metadata.FromIncomingContext(adapter.Context())
+	if !reflect.DeepEqual(incoming.Get("x-trace"), []string{"abc"}) {
+		t.Fatalf("incoming metadata = %v", incoming)
+	}
+	if err := adapter.SetHeader(metadata.Pairs("x-header", "h")); err != nil {
+		t.Fatalf("SetHeader returned error: %v", err)
+	}
+	adapter.SetTrailer(metadata.Pairs("x-trailer", "t"))
+
+	if got := response.Header(); !reflect.DeepEqual(got, Metadata{"x-header": {"h"}}) {
+		t.Fatalf("response header = %v", got)
+	}
+	if got := response.Trailer(); !reflect.DeepEqual(got, Metadata{"x-trailer": {"t"}}) {
+		t.Fatalf("response trailer = %v", got)
+	}
+}
+
+func TestCallOptionsCarryMetadata(t *testing.T) {
+	handle, err := NewCallOptions(0)
+	if err != nil {
+		t.Fatalf("NewCallOptions returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = ReleaseCallOptions(handle) })
+
+	if err := SetCallOptionsMetadata(handle, Metadata{"authorization": {"token"}}); err != nil {
+		t.Fatalf("SetCallOptionsMetadata returned error: %v", err)
+	}
+	ctx, cancel, err := CallOptionsContext(handle)
+	if err != nil {
+		t.Fatalf("CallOptionsContext returned error: %v", err)
+	}
+	defer cancel()
+	if got := RequestMetadataFromContext(ctx).Get("authorization"); !reflect.DeepEqual(got, []string{"token"}) {
+		t.Fatalf("request metadata = %v", got)
+	}
+	SetResponseHeader(ctx, map[string][]string{"X-Header": {"h"}})
+	SetResponseTrailer(ctx, map[string][]string{"X-Trailer": {"t"}})
+
+	header, trailer, err := TakeCallOptionsResponseMetadata(handle)
+	if err != nil {
+		t.Fatalf("TakeCallOptionsResponseMetadata returned error: %v", err)
+	}
+	if !reflect.DeepEqual(header, Metadata{"x-header": {"h"}}) || !reflect.DeepEqual(trailer, Metadata{"x-trailer": {"t"}}) {
+		t.Fatalf("response metadata = %v, %v", header, trailer)
+	}
+	header, trailer, err = TakeCallOptionsResponseMetadata(handle)
+	if err != nil || header != nil || trailer != nil {
+		t.Fatalf("second take = %v, %v, %v; want empty", header, trailer, err)
+	}
+
+	if _, _, err := TakeCallOptionsResponseMetadata(0); !errors.Is(err, ErrCallOptionsInvalidHandle) {
+		t.Fatalf("TakeCallOptionsResponseMetadata(0) error = %v", err)
+	}
+}
+
+func TestCallOptionsStreamOperationsKeepTheStartCollector(t *testing.T) {
+	handle, err := NewCallOptions(0)
+	if err != nil {
+		t.Fatalf("NewCallOptions returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = ReleaseCallOptions(handle) })
+
+	startCtx, cancel, err := CallOptionsContext(handle)
+	if err != nil {
+		t.Fatalf("CallOptionsContext returned error: %v", err)
+	}
+	defer cancel()
+	for range 3 {
+		_, opCancel, err := CallOptionsStreamContext(handle)
+		if err != nil {
+			t.Fatalf("CallOptionsStreamContext returned error: %v", err)
+		}
+		opCancel()
+	}
+	SetResponseTrailer(startCtx, map[string][]string{"X-Trailer": {"t"}})
+
+	_, trailer, err := TakeCallOptionsResponseMetadata(handle)
+	if err != nil {
+		t.Fatalf("TakeCallOptionsResponseMetadata returned error: %v", err)
+	}
+	if !reflect.DeepEqual(trailer, Metadata{"x-trailer": {"t"}}) {
+		t.Fatalf("stream trailer = %v, want the trailer recorded on the start context", trailer)
+	}
+}
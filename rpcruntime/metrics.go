@@ -0,0 +1,228 @@
+package rpcruntime
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// latencyBucketBounds are the inclusive upper bounds of the latency histogram
+// buckets. A final bucket counts every slower call.
+var latencyBucketBounds = [...]time.Duration{
+	100 * time.Microsecond,
+	250 * time.Microsecond,
+	500 * time.Microsecond,
+	time.Millisecond,
+	2500 * time.Microsecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// MetricsSnapshot is a point-in-time copy of the runtime metrics.
+type MetricsSnapshot struct {
+	// Methods holds one entry per service, method and server kind that has
+	// been called, ordered by service id, method and kind.
+	Methods []MethodMetrics
+	// PinnedBytes and PinnedBuffers count memory handed out through the ABI
+	// helpers that is still waiting for Release.
+	PinnedBytes   int64
+	PinnedBuffers int64
+	// ErrorRecords counts error ids that have been stored but neither taken
+	// nor expired.
+	ErrorRecords int64
+	// ErrorsDropped, ErrorsExpired and ErrorsDiscarded count error ids that
+	// left the error store without being taken; see ErrorStoreStats.
+	ErrorsDropped   uint64
+	ErrorsExpired   uint64
+	ErrorsDiscarded uint64
+}
+
+// MethodMetrics holds the counters of one method served by one server kind.
+//
+// Unary methods count every invoke, and Latency records the invoke duration.
+// Streaming methods count every Start, and Latency records the session
+// lifetime from Start to the operation that removed the session, or the
+// duration of a Start that failed. Errors counts failed invokes, failed
+// Starts and sessions that ended with an error, including Cancel.
+type MethodMetrics struct {
+	ServiceID ServiceID
+	Method    string
+	Kind      ServerKind
+
+	Calls              uint64
+	Errors             map[ErrorCode]uint64
+	ActiveStreams      int64
+	CallbackDeliveries uint64
+	Latency            LatencyHistogram
+}
+
+// LatencyHistogram is a cumulative latency distribution.
+type LatencyHistogram struct {
+	// Bounds are the inclusive upper bounds of the buckets in Counts; the last
+	// count has no upper bound.
+	Bounds []time.Duration
+	Counts []uint64
+	Count  uint64
+	Sum    time.Duration
+}
+
+type methodMetricsKey struct {
+	serviceID ServiceID
+	method    string
+	kind      ServerKind
+}
+
+type methodMetrics struct {
+	calls              atomic.Uint64
+	errors             [maxErrorCode + 1]atomic.Uint64
+	activeStreams      atomic.Int64
+	callbackDeliveries atomic.Uint64
+	latencyCounts      [len(latencyBucketBounds) + 1]atomic.Uint64
+	latencySum         atomic.Int64
+}
+
+// methodMetricsRegistry maps methodMetricsKey to *methodMetrics. Entries are
+// created once per method and never removed, which matches sync.Map well.
+var methodMetricsRegistry sync.Map
+
+func methodMetricsFor(call CallInfo) *methodMetrics {
+	key := methodMetricsKey{serviceID: call.ServiceID, method: call.Method, kind: call.Kind}
+	if metrics, ok := methodMetricsRegistry.Load(key); ok {
+		return metrics.(*methodMetrics)
+	}
+	metrics, _ := methodMetricsRegistry.LoadOrStore(key, &methodMetrics{})
+	return metrics.(*methodMetrics)
+}
+
+func (m *methodMetrics) observe(elapsed time.Duration, err error) {
+	if err != nil {
+		m.errors[normalizeErrorCode(ErrorCodeOf(err))].Add(1)
+	}
+	bucket := sort.Search(len(latencyBucketBounds), func(i int) bool {
+		return elapsed <= latencyBucketBounds[i]
+	})
+	m.latencyCounts[bucket].Add(1)
+	m.latencySum.Add(int64(elapsed))
+}
+
+func (m *methodMetrics) snapshot(key methodMetricsKey) MethodMetrics {
+	out := MethodMetrics{
+		ServiceID:          key.serviceID,
+		Method:             key.method,
+		Kind:               key.kind,
+		Calls:              m.calls.Load(),
+		ActiveStreams:      m.activeStreams.Load(),
+		CallbackDeliveries: m.callbackDeliveries.Load(),
+		Latency: LatencyHistogram{
+			Bounds: append([]time.Duration(nil), latencyBucketBounds[:]...),
+			Counts: make([]uint64, len(m.latencyCounts)),
+			Sum:    time.Duration(m.latencySum.Load()),
+		},
+	}
+	for code := range m.errors {
+		if count := m.errors[code].Load(); count != 0 {
+			if out.Errors == nil {
+				out.Errors = make(map[ErrorCode]uint64)
+			}
+			out.Errors[ErrorCode(code)] = count
+		}
+	}
+	for i := range m.latencyCounts {
+		out.Latency.Counts[i] = m.latencyCounts[i].Load()
+		out.Latency.Count += out.Latency.Counts[i]
+	}
+	return out
+}
+
+// SnapshotMetrics returns the current runtime metrics.
+func SnapshotMetrics() MetricsSnapshot {
+	errorStats := SnapshotErrorStore()
+	snapshot := MetricsSnapshot{
+		PinnedBytes:     pinnedBytes.Load(),
+		PinnedBuffers:   pinnedBuffers.Load(),
+		ErrorRecords:    errorStats.Records,
+		ErrorsDropped:   errorStats.Dropped,
+		ErrorsExpired:   errorStats.Expired,
+		ErrorsDiscarded: errorStats.Discarded,
+	}
+	methodMetricsRegistry.Range(func(key, value any) bool {
+		snapshot.Methods = append(snapshot.Methods, value.(*methodMetrics).snapshot(key.(methodMetricsKey)))
+		return true
+	})
+	sort.Slice(snapshot.Methods, func(i, j int) bool {
+		a, b := snapshot.Methods[i], snapshot.Methods[j]
+		if a.ServiceID != b.ServiceID {
+			return a.ServiceID < b.ServiceID
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		return a.Kind < b.Kind
+	})
+	return snapshot
+}
+
+// EncodeMetricsSnapshot encodes snapshot as a rpccgo.runtime.v1.MetricsSnapshot
+// protobuf message; the schema ships as metrics.proto next to this file.
+func EncodeMetricsSnapshot(snapshot MetricsSnapshot) []byte {
+	var out []byte
+	for _, method := range snapshot.Methods {
+		out = appendMessageField(out, 1, encodeMethodMetrics(method))
+	}
+	out = appendVarintField(out, 2, uint64(snapshot.PinnedBytes))
+	out = appendVarintField(out, 3, uint64(snapshot.PinnedBuffers))
+	out = appendVarintField(out, 4, uint64(snapshot.ErrorRecords))
+	out = appendVarintField(out, 5, snapshot.ErrorsDropped)
+	out = appendVarintField(out, 6, snapshot.ErrorsExpired)
+	out = appendVarintField(out, 7, snapshot.ErrorsDiscarded)
+	return out
+}
+
+func encodeMethodMetrics(method MethodMetrics) []byte {
+	var out []byte
+	out = appendStringField(out, 1, string(method.ServiceID))
+	out = appendStringField(out, 2, method.Method)
+	out = appendVarintField(out, 3, uint64(method.Kind))
+	out = appendVarintField(out, 4, method.Calls)
+	codes := make([]ErrorCode, 0, len(method.Errors))
+	for code := range method.Errors {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+	for _, code := range codes {
+		var entry []byte
+		entry = appendVarintField(entry, 1, uint64(code))
+		entry = appendVarintField(entry, 2, method.Errors[code])
+		out = appendMessageField(out, 5, entry)
+	}
+	out = appendVarintField(out, 6, uint64(method.ActiveStreams))
+	out = appendVarintField(out, 7, method.CallbackDeliveries)
+
+	var latency []byte
+	latency = appendPackedVarintField(latency, 1, len(method.Latency.Bounds), func(i int) uint64 {
+		return uint64(method.Latency.Bounds[i])
+	})
+	latency = appendPackedVarintField(latency, 2, len(method.Latency.Counts), func(i int) uint64 {
+		return method.Latency.Counts[i]
+	})
+	latency = appendVarintField(latency, 3, method.Latency.Count)
+	latency = appendVarintField(latency, 4, uint64(method.Latency.Sum))
+	return appendMessageField(out, 8, latency)
+}
+
+// EncodePinnedMetricsSnapshot encodes the current metrics into a pinned
+// ptr/len payload for the C ABI. Callers must release a non-zero pointer with
+// Release after the ABI consumer is done with it.
+func EncodePinnedMetricsSnapshot() (uintptr, int32, error) {
+	return pinPayload(EncodeMetricsSnapshot(SnapshotMetrics()))
+}
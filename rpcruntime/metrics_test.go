@@ -0,0 +1,183 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ygrpc/rpccgo/rpcruntime/internal/runtimev1"
+)
+
+// resetMetrics forgets the per-method metrics. Pinned memory and
+// error record gauges follow live state and are not reset.
+func resetMetrics() {
+	methodMetricsRegistry.Range(func(key, _ any) bool {
+		methodMetricsRegistry.Delete(key)
+		return true
+	})
+}
+
+func methodMetricsSnapshot(t *testing.T, method string, kind ServerKind) MethodMetrics {
+	t.Helper()
+	for _, got := range SnapshotMetrics().Methods {
+		if got.Method == method && got.Kind == kind {
+			return got
+		}
+	}
+	t.Fatalf("no metrics recorded for %s kind %d", method, kind)
+	return MethodMetrics{}
+}
+
+func TestMetricsCountUnaryCallsAndErrors(t *testing.T) {
+	resetMetrics()
+	t.Cleanup(resetMetrics)
+
+	call := CallInfo{
+		ServiceID: "rpccgo.test.v1.Greeter",
+		Method:    "rpccgo.test.v1.Greeter.SayHello",
+		Kind:      ServerKindGoNative,
+		Operation: CallOperationUnary,
+	}
+	if err := InterceptUnary(context.Background(), call, func(context.Context, CallInfo) error { return nil }); err != nil {
+		t.Fatalf("InterceptUnary returned error: %v", err)
+	}
+	failure := NewStatusError(ErrorCodeNotFound, "missing")
+	if err := InterceptUnary(context.Background(), call, func(context.Context, CallInfo) error { return failure }); !errors.Is(err, failure) {
+		t.Fatalf("InterceptUnary error = %v, want %v", err, failure)
+	}
+
+	got := methodMetricsSnapshot(t, call.Method, ServerKindGoNative)
+	if got.ServiceID != call.ServiceID || got.Calls != 2 {
+		t.Fatalf("metrics = %+v, want 2 calls for %s", got, call.ServiceID)
+	}
+	if len(got.Errors) != 1 || got.Errors[ErrorCodeNotFound] != 1 {
+		t.Fatalf("errors = %v, want one NotFound", got.Errors)
+	}
+	if got.Latency.Count != 2 || len(got.Latency.Counts) != len(got.Latency.Bounds)+1 {
+		t.Fatalf("latency = %+v, want two observations", got.Latency)
+	}
+	var total uint64
+	for _, count := range got.Latency.Counts {
+		total += count
+	}
+	if total != 2 {
+		t.Fatalf("latency bucket total = %d, want 2", total)
+	}
+}
+
+func TestMetricsTrackStreamSessions(t *testing.T) {
+	resetMetrics()
+	t.Cleanup(resetMetrics)
+	ResetStreamSessionsForTesting()
+	t.Cleanup(ResetStreamSessionsForTesting)
+
+	call := CallInfo{
+		ServiceID: "rpccgo.test.v1.Greeter",
+		Method:    "rpccgo.test.v1.Greeter.Watch",
+		Kind:      ServerKindCGOMessage,
+	}
+	handle := startTracedStream(t, call)
+	if got := methodMetricsSnapshot(t, call.Method, ServerKindCGOMessage); got.Calls != 1 || got.ActiveStreams != 1 {
+		t.Fatalf("metrics after Start = %+v, want one active stream", got)
+	}
+
+	state, err := EnableStreamCallbackReceive(handle)
+	if err != nil {
+		t.Fatalf("EnableStreamCallbackReceive returned error: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if !state.BeginCallback() {
+			t.Fatal("BeginCallback returned false")
+		}
+		state.EndCallback()
+	}
+	if err := runTracedStreamOperation(call, CallOperationCancel, handle, true, nil); err != nil {
+		t.Fatalf("Cancel returned error: %v", err)
+	}
+
+	got := methodMetricsSnapshot(t, call.Method, ServerKindCGOMessage)
+	if got.ActiveStreams != 0 || got.CallbackDeliveries != 2 {
+		t.Fatalf("metrics after Cancel = %+v, want no active streams and two deliveries", got)
+	}
+	if got.Errors[ErrorCodeCanceled] != 1 || got.Latency.Count != 1 {
+		t.Fatalf("metrics after Cancel = %+v, want one canceled session", got)
+	}
+}
+
+func TestMetricsReportPinnedMemoryAndErrorRecords(t *testing.T) {
+	resetErrorRuntimeStateForTesting(t)
+
+	if got := SnapshotMetrics(); got.PinnedBytes != 0 || got.PinnedBuffers != 0 || got.ErrorRecords != 0 {
+		t.Fatalf("initial snapshot = %+v, want empty gauges", got)
+	}
+	bytesPtr, err := PinBytes(make([]byte, 10))
+	if err != nil {
+		t.Fatalf("PinBytes returned error: %v", err)
+	}
+	slicePtr, err := PinSlice(make([]int32, 3))
+	if err != nil {
+		t.Fatalf("PinSlice returned error: %v", err)
+	}
+	id := StoreError(errors.New("boom"))
+
+	got := SnapshotMetrics()
+	if got.PinnedBytes != 22 || got.PinnedBuffers != 2 || got.ErrorRecords != 1 {
+		t.Fatalf("snapshot = %+v, want 22 pinned bytes in 2 buffers and 1 error record", got)
+	}
+
+	Release(bytesPtr)
+	Release(slicePtr)
+	if _, ok := TakeError(id); !ok {
+		t.Fatal("TakeError returned false")
+	}
+	if got := SnapshotMetrics(); got.PinnedBytes != 0 || got.PinnedBuffers != 0 || got.ErrorRecords != 0 {
+		t.Fatalf("snapshot after release = %+v, want empty gauges", got)
+	}
+}
+
+func TestEncodeMetricsSnapshotMatchesSchema(t *testing.T) {
+	snapshot := MetricsSnapshot{
+		Methods: []MethodMetrics{{
+			ServiceID:          "rpccgo.test.v1.Greeter",
+			Method:             "rpccgo.test.v1.Greeter.SayHello",
+			Kind:               ServerKindConnect,
+			Calls:              3,
+			Errors:             map[ErrorCode]uint64{ErrorCodeInternal: 1, ErrorCodeUnavailable: 2},
+			ActiveStreams:      4,
+			CallbackDeliveries: 6,
+			Latency: LatencyHistogram{
+				Bounds: latencyBucketBounds[:2],
+				Counts: []uint64{2, 0, 1},
+				Count:  3,
+				Sum:    42,
+			},
+		}},
+		PinnedBytes:     7,
+		ErrorRecords:    1,
+		ErrorsDropped:   5,
+		ErrorsExpired:   8,
+		ErrorsDiscarded: 9,
+	}
+	assertPayloadMatchesSchema(t, EncodeMetricsSnapshot(snapshot), &runtimev1.MetricsSnapshot{
+		Methods: []*runtimev1.MethodMetrics{{
+			ServiceId:          "rpccgo.test.v1.Greeter",
+			Method:             "rpccgo.test.v1.Greeter.SayHello",
+			ServerKind:         int32(ServerKindConnect),
+			Calls:              3,
+			Errors:             map[int32]uint64{int32(ErrorCodeInternal): 1, int32(ErrorCodeUnavailable): 2},
+			ActiveStreams:      4,
+			CallbackDeliveries: 6,
+			Latency: &runtimev1.LatencyHistogram{
+				BoundsNanos:  []int64{int64(latencyBucketBounds[0]), int64(latencyBucketBounds[1])},
+				BucketCounts: []uint64{2, 0, 1},
+				Count:        3,
+				SumNanos:     42,
+			},
+		}},
+		PinnedBytes:     7,
+		ErrorRecords:    1,
+		ErrorsDropped:   5,
+		ErrorsExpired:   8,
+		ErrorsDiscarded: 9,
+	})
+}
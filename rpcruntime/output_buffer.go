@@ -0,0 +1,197 @@
+package rpcruntime
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// ErrorIDBufferTooSmall is the reserved error id returned by the Into client
+// exports when the response does not fit the caller's buffer. The export
+// reports the required size through its length output. Like
+// ErrorIDDeadlineExceeded it is never stored.
+const ErrorIDBufferTooSmall ErrorID = -3
+
+// ErrBufferTooSmall reports that an OutputBuffer could not hold everything
+// written to it.
+var ErrBufferTooSmall = errors.New("rpccgo: output buffer too small")
+
+var bufferTooSmallText = ErrBufferTooSmall.Error()
+
+// outputAlign keeps numeric arrays written to an OutputBuffer aligned for
+// their element type, whatever the alignment of the caller's buffer.
+const outputAlign = 8
+
+// OutputBuffer writes response data into memory owned by the C caller instead
+// of pinning Go memory, so the caller has nothing to release. Writes are laid
+// out back to back; once one does not fit, later writes only count the size
+// the caller must provide and Err reports ErrBufferTooSmall.
+type OutputBuffer struct {
+	data []byte
+	// used is the end of the last write. required counts every write with
+	// the worst-case padding of its alignment, so a buffer of that size fits
+	// the response at any address.
+	used     int
+	required int
+	overflow bool
+}
+
+// NewOutputBuffer wraps capacity bytes at buf. The memory must stay valid and
+// untouched by the caller until the export returns.
+func NewOutputBuffer(buf unsafe.Pointer, capacity int32) (*OutputBuffer, error) {
+	size, err := LengthFromInt32(capacity)
+	if err != nil {
+		return nil, fmt.Errorf("rpccgo: output buffer capacity: %w", err)
+	}
+	if buf == nil && size != 0 {
+		return nil, errors.New("rpccgo: output buffer pointer is nil")
+	}
+	out := &OutputBuffer{}
+	if size != 0 {
+		out.data = unsafe.Slice((*byte)(buf), size)
+	}
+	return out, nil
+}
+
+// PutBytes copies data into the buffer and returns its address there, or 0
+// when data is empty or does not fit.
+func (b *OutputBuffer) PutBytes(data []byte) uintptr {
+	offset, ok := b.reserve(len(data), 1)
+	if !ok {
+		return 0
+	}
+	copy(b.data[offset:], data)
+	return uintptr(unsafe.Pointer(&b.data[offset]))
+}
+
+// PutString copies s into the buffer like PutBytes.
+func (b *OutputBuffer) PutString(s string) uintptr {
+	offset, ok := b.reserve(len(s), 1)
+	if !ok {
+		return 0
+	}
+	copy(b.data[offset:], s)
+	return uintptr(unsafe.Pointer(&b.data[offset]))
+}
+
+// PutOutputSlice copies s into b, aligned for its element type, like
+// OutputBuffer.PutBytes.
+func PutOutputSlice[T NativeArrayElem](b *OutputBuffer, s []T) uintptr {
+	if len(s) == 0 {
+		return 0
+	}
+	raw := unsafe.Slice((*byte)(unsafe.Pointer(&s[0])), len(s)*int(unsafe.Sizeof(s[0])))
+	offset, ok := b.reserve(len(raw), outputAlign)
+	if !ok {
+		return 0
+	}
+	copy(b.data[offset:], raw)
+	return uintptr(unsafe.Pointer(&b.data[offset]))
+}
+
+// PutStringArray writes values as an RpcBuffer array, aligned like
+// PutOutputSlice, followed by the element data, and returns the array address
+// like OutputBuffer.PutBytes. Elements are borrowed from the caller's buffer.
+func (b *OutputBuffer) PutStringArray(values []string) uintptr {
+	return putOutputBufferArray(b, values)
+}
+
+// PutBytesArray writes values like PutStringArray.
+func (b *OutputBuffer) PutBytesArray(values [][]byte) uintptr {
+	return putOutputBufferArray(b, values)
+}
+
+func putOutputBufferArray[E string | []byte](b *OutputBuffer, values []E) uintptr {
+	if len(values) == 0 {
+		return 0
+	}
+	header, ok := b.reserve(rpcBufferArrayHeaderSize(len(values)), outputAlign)
+	var elems []RpcBuffer
+	if ok {
+		elems = unsafe.Slice((*RpcBuffer)(unsafe.Pointer(&b.data[header])), len(values))
+	}
+	for i, value := range values {
+		offset, fits := b.reserve(len(value), 1)
+		if !ok {
+			continue
+		}
+		elems[i] = RpcBuffer{}
+		if fits {
+			copy(b.data[offset:], value)
+			elems[i] = RpcBuffer{Ptr: unsafe.Pointer(&b.data[offset]), Len: int32(len(value))}
+		}
+	}
+	if !ok {
+		return 0
+	}
+	return uintptr(unsafe.Pointer(&b.data[header]))
+}
+
+// PutMessage marshals message directly into the buffer and returns its
+// encoded length. It returns ErrBufferTooSmall, with the length the message
+// needs, when the message does not fit.
+func (b *OutputBuffer) PutMessage(message protobuf.Message) (int32, error) {
+	if isNilMessage(message) {
+		return 0, errors.New("message is nil")
+	}
+	size := protobuf.Size(message)
+	length, err := LengthToInt32(size)
+	if err != nil {
+		return 0, err
+	}
+	offset, ok := b.reserve(size, 1)
+	if !ok {
+		return length, b.Err()
+	}
+	dst := b.data[offset : offset : offset+size]
+	data, err := protobuf.MarshalOptions{UseCachedSize: true}.MarshalAppend(dst, message)
+	if err != nil {
+		return 0, fmt.Errorf("protobuf marshal failed: %w", err)
+	}
+	if len(data) != size {
+		return 0, fmt.Errorf("protobuf marshal wrote %d bytes, want %d", len(data), size)
+	}
+	return length, nil
+}
+
+// Len returns the bytes written, or the bytes the caller must provide once the
+// buffer overflowed.
+func (b *OutputBuffer) Len() (int32, error) {
+	if b.overflow {
+		return LengthToInt32(b.required)
+	}
+	return LengthToInt32(b.used)
+}
+
+// Err returns ErrBufferTooSmall once a write did not fit.
+func (b *OutputBuffer) Err() error {
+	if b.overflow {
+		return fmt.Errorf("%w: need %d bytes, have %d", ErrBufferTooSmall, b.required, len(b.data))
+	}
+	return nil
+}
+
+// reserve returns the offset of size bytes whose address, not just offset, is
+// a multiple of align.
+func (b *OutputBuffer) reserve(size, align int) (int, bool) {
+	if size == 0 {
+		return 0, false
+	}
+	b.required += size + align - 1
+	if b.overflow {
+		return 0, false
+	}
+	offset := b.used
+	if len(b.data) != 0 {
+		base := uintptr(unsafe.Pointer(&b.data[0]))
+		offset = int((base+uintptr(b.used)+uintptr(align-1))&^uintptr(align-1) - base)
+	}
+	if offset+size > len(b.data) {
+		b.overflow = true
+		return 0, false
+	}
+	b.used = offset + size
+	return offset, true
+}
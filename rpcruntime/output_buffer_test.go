@@ -0,0 +1,158 @@
+package rpcruntime
+
+import (
+	"errors"
+	"testing"
+	"unsafe"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+func newTestOutputBuffer(t *testing.T, data []byte) *OutputBuffer {
+	t.Helper()
+	out, err := NewOutputBuffer(unsafe.Pointer(unsafe.SliceData(data)), int32(len(data)))
+	if err != nil {
+		t.Fatalf("NewOutputBuffer returned error: %v", err)
+	}
+	return out
+}
+
+func TestOutputBufferWritesIntoCallerMemory(t *testing.T) {
+	data := make([]byte, 64)
+	out := newTestOutputBuffer(t, data)
+
+	textPtr := out.PutString("abc")
+	valuesPtr := PutOutputSlice(out, []int64{7, 9})
+	if out.PutBytes(nil) != 0 {
+		t.Fatal("expected an empty write to return a zero pointer")
+	}
+	if err := out.Err(); err != nil {
+		t.Fatalf("Err returned %v", err)
+	}
+
+	if textPtr != uintptr(unsafe.Pointer(&data[0])) || string(data[:3]) != "abc" {
+		t.Fatalf("string written at %#x as %q, want the buffer start", textPtr, data[:3])
+	}
+	if valuesPtr != uintptr(unsafe.Pointer(&data[8])) {
+		t.Fatalf("slice written at offset %d, want 8", valuesPtr-uintptr(unsafe.Pointer(&data[0])))
+	}
+	if values := unsafe.Slice((*int64)(unsafe.Pointer(&data[8])), 2); values[0] != 7 || values[1] != 9 {
+		t.Fatalf("slice values = %v", values)
+	}
+	if length, err := out.Len(); err != nil || length != 24 {
+		t.Fatalf("Len = %d, %v; want 24", length, err)
+	}
+}
+
+func TestOutputBufferReportsRequiredSizeWhenTooSmall(t *testing.T) {
+	data := make([]byte, 4)
+	out := newTestOutputBuffer(t, data)
+
+	if out.PutString("abc") == 0 {
+		t.Fatal("expected the first write to fit")
+	}
+	if out.PutString("too long") != 0 {
+		t.Fatal("expected an overflowing write to return a zero pointer")
+	}
+	if out.PutBytes([]byte("x")) != 0 {
+		t.Fatal("expected writes after an overflow to return a zero pointer")
+	}
+	if !errors.Is(out.Err(), ErrBufferTooSmall) {
+		t.Fatalf("Err = %v, want ErrBufferTooSmall", out.Err())
+	}
+	if length, _ := out.Len(); length != 12 {
+		t.Fatalf("Len = %d, want the 12 bytes needed", length)
+	}
+}
+
+func TestOutputBufferAlignsArraysToTheirAddress(t *testing.T) {
+	backing := make([]byte, 64)
+	data := backing[1:]
+	out := newTestOutputBuffer(t, data)
+
+	out.PutString("abc")
+	valuesPtr := PutOutputSlice(out, []int64{7, 9})
+	if valuesPtr == 0 || valuesPtr%outputAlign != 0 {
+		t.Fatalf("slice written at %#x, want an %d-byte aligned address", valuesPtr, outputAlign)
+	}
+	offset := int(valuesPtr - uintptr(unsafe.Pointer(&data[0])))
+	if values := unsafe.Slice((*int64)(unsafe.Pointer(&data[offset])), 2); values[0] != 7 || values[1] != 9 {
+		t.Fatalf("slice values = %v", values)
+	}
+	if length, err := out.Len(); err != nil || int(length) != offset+16 {
+		t.Fatalf("Len = %d, %v; want the end of the slice", length, err)
+	}
+}
+
+func TestOutputBufferRequiredSizeFitsAnyAlignment(t *testing.T) {
+	write := func(out *OutputBuffer) {
+		out.PutString("abc")
+		PutOutputSlice(out, []int64{7, 9})
+		out.PutStringArray([]string{"x", "yz"})
+	}
+	probe, _ := NewOutputBuffer(nil, 0)
+	write(probe)
+	required, _ := probe.Len()
+
+	backing := make([]byte, int(required)+outputAlign)
+	for shift := range outputAlign {
+		out := newTestOutputBuffer(t, backing[shift:shift+int(required)])
+		write(out)
+		if err := out.Err(); err != nil {
+			t.Fatalf("shift %d: Err = %v with the reported %d bytes", shift, err, required)
+		}
+	}
+}
+
+func TestOutputBufferPutMessage(t *testing.T) {
+	message := &spb.Status{Code: 5, Message: "not found"}
+	want, err := protobuf.Marshal(message)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+
+	small, err := NewOutputBuffer(nil, 0)
+	if err != nil {
+		t.Fatalf("NewOutputBuffer returned error: %v", err)
+	}
+	length, err := small.PutMessage(message)
+	if !errors.Is(err, ErrBufferTooSmall) || length != int32(len(want)) {
+		t.Fatalf("PutMessage into an empty buffer = %d, %v; want %d and ErrBufferTooSmall", length, err, len(want))
+	}
+
+	data := make([]byte, len(want))
+	length, err = newTestOutputBuffer(t, data).PutMessage(message)
+	if err != nil || length != int32(len(want)) || string(data) != string(want) {
+		t.Fatalf("PutMessage = %d, %v, %x; want %x", length, err, data, want)
+	}
+}
+
+func TestNewOutputBufferRejectsInvalidBuffers(t *testing.T) {
+	if _, err := NewOutputBuffer(nil, 8); err == nil {
+		t.Fatal("expected a nil buffer with capacity to be rejected")
+	}
+	data := make([]byte, 1)
+	if _, err := NewOutputBuffer(unsafe.Pointer(&data[0]), -1); err == nil {
+		t.Fatal("expected a negative capacity to be rejected")
+	}
+}
+
+func TestBufferTooSmallUsesReservedErrorID(t *testing.T) {
+	out, _ := NewOutputBuffer(nil, 0)
+	out.PutString("data")
+
+	id := StoreError(out.Err())
+	if id != ErrorIDBufferTooSmall {
+		t.Fatalf("StoreError = %d, want ErrorIDBufferTooSmall", id)
+	}
+	for range 2 {
+		record, ok := takeErrorRecord(id)
+		if !ok || record.code != ErrorCodeResourceExhausted || record.text != ErrBufferTooSmall.Error() {
+			t.Fatalf("takeErrorRecord = %+v, %v", record, ok)
+		}
+	}
+	if DiscardError(id) {
+		t.Fatal("expected the reserved id to be discarded as not stored")
+	}
+}
@@ -0,0 +1,80 @@
+package rpcruntime
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// PanicError is a panic recovered by RecoverPanic. ErrorCodeOf maps it to
+// ErrorCodeInternal.
+type PanicError struct {
+	// Where names the cgo export or stream goroutine that recovered the panic.
+	Where string
+	Value any
+	// Stack is the Go stack of the panicking goroutine. It is empty unless
+	// PanicReportConfig.Stacks was set.
+	Stack string
+}
+
+func (e *PanicError) Error() string {
+	text := fmt.Sprintf("rpccgo: panic in %s: %v", e.Where, e.Value)
+	if e.Stack != "" {
+		text += "\n" + e.Stack
+	}
+	return text
+}
+
+// Unwrap returns the panic value when it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// PanicReportConfig controls how RecoverPanic reports recovered panics.
+type PanicReportConfig struct {
+	// Stacks records the Go stack of the panicking goroutine in the
+	// PanicError, so it also reaches the stored error text.
+	Stacks bool
+	// OnPanic, when set, receives every recovered panic before the error
+	// reaches the caller. It runs on the goroutine that panicked; a panic
+	// inside OnPanic is dropped.
+	OnPanic func(*PanicError)
+}
+
+var panicReports atomic.Pointer[PanicReportConfig]
+
+// ConfigurePanicReports replaces the panic report settings.
+func ConfigurePanicReports(config PanicReportConfig) {
+	panicReports.Store(&config)
+}
+
+// RecoverPanic recovers a panic of the calling goroutine, reports it and
+// hands it to fail as a *PanicError. Generated cgo client exports, callback
+// receive loops and the goroutines that run stream handlers defer it so a
+// panic never unwinds through C frames:
+//
+//	defer rpcruntime.RecoverPanic("rpccgoSayHello", func(err error) { errID = C.int32_t(rpcruntime.StoreError(err)) })
+//
+// It must be deferred directly and does nothing when the goroutine is not
+// panicking.
+func RecoverPanic(where string, fail func(error)) {
+	value := recover()
+	if value == nil {
+		return
+	}
+	panicErr := &PanicError{Where: where, Value: value}
+	config := panicReports.Load()
+	if config != nil && config.Stacks {
+		panicErr.Stack = string(debug.Stack())
+	}
+	if config != nil && config.OnPanic != nil {
+		reportPanic(config.OnPanic, panicErr)
+	}
+	fail(panicErr)
+}
+
+func reportPanic(onPanic func(*PanicError), panicErr *PanicError) {
+	defer func() { _ = recover() }()
+	onPanic(panicErr)
+}
@@ -0,0 +1,49 @@
+package rpcruntime
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRecoverPanicConvertsAndReportsPanics(t *testing.T) {
+	var reported *PanicError
+	ConfigurePanicReports(PanicReportConfig{
+		Stacks: true,
+		OnPanic: func(panicErr *PanicError) {
+			reported = panicErr
+			panic("report callbacks must not escape")
+		},
+	})
+	t.Cleanup(func() { ConfigurePanicReports(PanicReportConfig{}) })
+
+	cause := errors.New("boom")
+	var got error
+	func() {
+		defer RecoverPanic("rpccgoTestExport", func(err error) { got = err })
+		panic(cause)
+	}()
+
+	var panicErr *PanicError
+	if !errors.As(got, &panicErr) || panicErr.Where != "rpccgoTestExport" || panicErr.Value != cause {
+		t.Fatalf("recovered error = %#v, want PanicError for rpccgoTestExport", got)
+	}
+	if reported != panicErr {
+		t.Fatalf("reported panic = %p, want %p", reported, panicErr)
+	}
+	if !errors.Is(got, cause) || ErrorCodeOf(got) != ErrorCodeInternal {
+		t.Fatalf("recovered error code = %v, want Internal wrapping the panic value", ErrorCodeOf(got))
+	}
+	if !strings.Contains(got.Error(), "rpccgo: panic in rpccgoTestExport: boom") || !strings.Contains(panicErr.Stack, "TestRecoverPanicConvertsAndReportsPanics") {
+		t.Fatalf("recovered error text = %q", got.Error())
+	}
+
+	ConfigurePanicReports(PanicReportConfig{})
+	called := false
+	func() {
+		defer RecoverPanic("rpccgoTestExport", func(error) { called = true })
+	}()
+	if called {
+		t.Fatal("RecoverPanic called fail without a panic")
+	}
+}
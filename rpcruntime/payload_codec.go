@@ -0,0 +1,69 @@
+package rpcruntime
+
+import "google.golang.org/protobuf/encoding/protowire"
+
+// The runtime reports handed to C are written straight in the protobuf wire
+// format. Their schemas ship as .proto files next to this file, and the tests
+// decode every report with the types internal/runtimev1 generates from them.
+
+// appendVarintField appends a proto3 scalar varint field, omitting zero.
+func appendVarintField(out []byte, num protowire.Number, value uint64) []byte {
+	if value == 0 {
+		return out
+	}
+	out = protowire.AppendTag(out, num, protowire.VarintType)
+	return protowire.AppendVarint(out, value)
+}
+
+// appendBoolField appends a proto3 bool field, omitting false.
+func appendBoolField(out []byte, num protowire.Number, value bool) []byte {
+	if !value {
+		return out
+	}
+	return appendVarintField(out, num, 1)
+}
+
+// appendStringField appends a proto3 string field, omitting the empty string.
+func appendStringField(out []byte, num protowire.Number, value string) []byte {
+	if value == "" {
+		return out
+	}
+	out = protowire.AppendTag(out, num, protowire.BytesType)
+	return protowire.AppendString(out, value)
+}
+
+// appendMessageField appends an embedded message. It is written even when
+// message is empty so that every entry of a repeated field is kept.
+func appendMessageField(out []byte, num protowire.Number, message []byte) []byte {
+	out = protowire.AppendTag(out, num, protowire.BytesType)
+	return protowire.AppendBytes(out, message)
+}
+
+// appendPackedVarintField appends a packed repeated varint field of n values,
+// omitting an empty one.
+func appendPackedVarintField(out []byte, num protowire.Number, n int, value func(int) uint64) []byte {
+	if n == 0 {
+		return out
+	}
+	var packed []byte
+	for i := 0; i < n; i++ {
+		packed = protowire.AppendVarint(packed, value(i))
+	}
+	out = protowire.AppendTag(out, num, protowire.BytesType)
+	return protowire.AppendBytes(out, packed)
+}
+
+// pinPayload pins an encoded report into a ptr/len payload for the C ABI.
+// Callers must release a non-zero pointer with Release after the ABI consumer
+// is done with it.
+func pinPayload(data []byte) (uintptr, int32, error) {
+	length, err := LengthToInt32(len(data))
+	if err != nil {
+		return 0, 0, err
+	}
+	ptr, err := PinBytes(data)
+	if err != nil {
+		return 0, 0, err
+	}
+	return ptr, length, nil
+}
@@ -0,0 +1,21 @@
+package rpcruntime
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// assertPayloadMatchesSchema decodes data with the generated type of want and
+// compares the result with want. Unknown fields take part in the comparison,
+// so a field number or wire type that disagrees with the schema fails.
+func assertPayloadMatchesSchema(t *testing.T, data []byte, want proto.Message) {
+	t.Helper()
+	got := want.ProtoReflect().New().Interface()
+	if err := proto.Unmarshal(data, got); err != nil {
+		t.Fatalf("decode %s: %v", want.ProtoReflect().Descriptor().FullName(), err)
+	}
+	if !proto.Equal(got, want) {
+		t.Fatalf("decoded %s = %v, want %v", want.ProtoReflect().Descriptor().FullName(), got, want)
+	}
+}
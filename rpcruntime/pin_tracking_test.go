@@ -0,0 +1,126 @@
+package rpcruntime
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ygrpc/rpccgo/rpcruntime/internal/runtimev1"
+)
+
+func enablePinTrackingForTesting(t *testing.T, stacks bool) {
+	t.Helper()
+	releaseAllPinnedForTesting()
+	ConfigurePinTracking(PinTrackingConfig{Enabled: true, Stacks: stacks})
+	t.Cleanup(func() {
+		ConfigurePinTracking(PinTrackingConfig{})
+		releaseAllPinnedForTesting()
+	})
+}
+
+func pinForTrackingTest(t *testing.T, data []byte) uintptr {
+	t.Helper()
+	ptr, err := PinBytes(data)
+	if err != nil {
+		t.Fatalf("PinBytes returned error: %v", err)
+	}
+	return ptr
+}
+
+func TestCheckPinLeaksReportsUnreleasedBuffers(t *testing.T) {
+	enablePinTrackingForTesting(t, false)
+
+	leaked := pinForTrackingTest(t, []byte("leaked"))
+	released := pinForTrackingTest(t, []byte("released"))
+	if !Release(released) {
+		t.Fatal("expected release to succeed")
+	}
+
+	err := CheckPinLeaks(0)
+	var leak *PinLeakError
+	if !errors.As(err, &leak) {
+		t.Fatalf("CheckPinLeaks returned %v, want *PinLeakError", err)
+	}
+	if len(leak.Pins) != 1 || leak.Pins[0].Ptr != leaked || leak.Pins[0].Size != int64(len("leaked")) {
+		t.Fatalf("leaked pins = %+v, want only %#x", leak.Pins, leaked)
+	}
+	if !strings.HasSuffix(leak.Pins[0].Label, ".pinForTrackingTest") {
+		t.Fatalf("label = %q, want the pinning function outside PinBytes", leak.Pins[0].Label)
+	}
+	if leak.Pins[0].Stack != "" {
+		t.Fatalf("stack = %q, want none without Stacks", leak.Pins[0].Stack)
+	}
+	if !strings.Contains(err.Error(), "1 pinned buffer(s) not released") {
+		t.Fatalf("error text = %q", err)
+	}
+
+	Release(leaked)
+	if err := CheckPinLeaks(0); err != nil {
+		t.Fatalf("CheckPinLeaks after release returned %v", err)
+	}
+}
+
+func TestOutstandingPinsHonorsThresholdAndStacks(t *testing.T) {
+	enablePinTrackingForTesting(t, true)
+
+	ptr := pinForTrackingTest(t, []byte("young"))
+	if pins := OutstandingPins(time.Hour); len(pins) != 0 {
+		t.Fatalf("OutstandingPins(1h) = %+v, want none", pins)
+	}
+	pins := OutstandingPins(0)
+	if len(pins) != 1 || pins[0].Ptr != ptr {
+		t.Fatalf("OutstandingPins(0) = %+v, want %#x", pins, ptr)
+	}
+	if !strings.Contains(pins[0].Stack, "pinForTrackingTest") || !strings.Contains(pins[0].Stack, "rpcruntime.PinBytes") {
+		t.Fatalf("stack = %q, want the full pinning stack", pins[0].Stack)
+	}
+}
+
+func TestPinTrackingIgnoresBuffersPinnedWhileDisabled(t *testing.T) {
+	releaseAllPinnedForTesting()
+	t.Cleanup(releaseAllPinnedForTesting)
+
+	pinForTrackingTest(t, []byte("untracked"))
+	ConfigurePinTracking(PinTrackingConfig{Enabled: true})
+	t.Cleanup(func() { ConfigurePinTracking(PinTrackingConfig{}) })
+
+	if err := CheckPinLeaks(0); err != nil {
+		t.Fatalf("CheckPinLeaks reported an untracked buffer: %v", err)
+	}
+	if got := PinTrackingConfiguration(); !got.Enabled || got.Stacks {
+		t.Fatalf("PinTrackingConfiguration = %+v", got)
+	}
+	ConfigurePinTracking(PinTrackingConfig{Stacks: true})
+	if got := PinTrackingConfiguration(); got.Enabled || got.Stacks {
+		t.Fatalf("disabled PinTrackingConfiguration = %+v, want zero", got)
+	}
+}
+
+func TestEncodePinnedBuffersMatchesSchema(t *testing.T) {
+	data := EncodePinnedBuffers([]PinnedBuffer{{
+		Ptr:      0x1000,
+		Label:    "main.encodeGreeterSayHelloNativeUnaryResponse",
+		Size:     5,
+		PinnedAt: time.UnixMilli(1_700_000_000_000),
+		Stack:    "main.main\n",
+	}, {
+		Ptr:      0x2000,
+		Size:     8,
+		PinnedAt: time.UnixMilli(1_700_000_000_500),
+	}})
+
+	assertPayloadMatchesSchema(t, data, &runtimev1.PinnedBufferList{
+		Buffers: []*runtimev1.PinnedBuffer{{
+			Ptr:            0x1000,
+			Label:          "main.encodeGreeterSayHelloNativeUnaryResponse",
+			Size:           5,
+			PinnedAtUnixMs: 1_700_000_000_000,
+			Stack:          "main.main\n",
+		}, {
+			Ptr:            0x2000,
+			Size:           8,
+			PinnedAtUnixMs: 1_700_000_000_500,
+		}},
+	})
+}
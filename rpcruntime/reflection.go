@@ -0,0 +1,185 @@
+package rpcruntime
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// ErrNoServiceManifest is returned when reflection is asked about a service
+// that was not generated into the binary.
+var ErrNoServiceManifest = errors.New("rpccgo: no service manifest")
+
+// MethodStreaming is the streaming shape of a method.
+type MethodStreaming int32
+
+const (
+	MethodStreamingUnary MethodStreaming = iota
+	MethodStreamingClient
+	MethodStreamingServer
+	MethodStreamingBidi
+)
+
+// ServiceManifest describes one service the generator rendered into the
+// binary. Generated runtime files register one from init.
+type ServiceManifest struct {
+	ServiceID ServiceID
+	// ProtoFile is the path of the .proto file that declares the service, as
+	// registered in protoregistry.GlobalFiles by the protoc-gen-go output.
+	ProtoFile string
+	// Native reports whether native facades and exports were generated.
+	Native bool
+	// MessageTransport is ServerKindConnect or ServerKindGRPC, the transport
+	// the message facades were generated for.
+	MessageTransport ServerKind
+	Methods          []MethodManifest
+}
+
+// MethodManifest describes one method of a ServiceManifest.
+type MethodManifest struct {
+	Name string
+	// FullName is the protobuf full method name used as CallInfo.Method.
+	FullName  string
+	Streaming MethodStreaming
+	// RequestType and ResponseType are protobuf full message names.
+	RequestType  string
+	ResponseType string
+}
+
+var serviceManifests sync.Map // ServiceID -> ServiceManifest
+
+// RegisterServiceManifest records manifest, replacing an earlier manifest of
+// the same service.
+func RegisterServiceManifest(manifest ServiceManifest) {
+	serviceManifests.Store(manifest.ServiceID, manifest)
+}
+
+// LookupServiceManifest returns the manifest of serviceID.
+func LookupServiceManifest(serviceID ServiceID) (ServiceManifest, bool) {
+	value, ok := serviceManifests.Load(serviceID)
+	if !ok {
+		return ServiceManifest{}, false
+	}
+	return value.(ServiceManifest), true
+}
+
+// ListServiceManifests returns every registered manifest, ordered by service
+// id.
+func ListServiceManifests() []ServiceManifest {
+	var out []ServiceManifest
+	serviceManifests.Range(func(_, value any) bool {
+		out = append(out, value.(ServiceManifest))
+		return true
+	})
+	sort.Slice(out, func(i, j int) bool { return out[i].ServiceID < out[j].ServiceID })
+	return out
+}
+
+// ServiceFileDescriptorSet returns the files declaring serviceID and every
+// file they import, dependencies first. An empty serviceID covers every
+// registered service.
+func ServiceFileDescriptorSet(serviceID ServiceID) (*descriptorpb.FileDescriptorSet, error) {
+	manifests := ListServiceManifests()
+	if serviceID != "" {
+		manifest, ok := LookupServiceManifest(serviceID)
+		if !ok {
+			return nil, fmt.Errorf("%w for %s", ErrNoServiceManifest, serviceID)
+		}
+		manifests = []ServiceManifest{manifest}
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+	// add appends file after its imports so every file follows its dependencies.
+	var add func(protoreflect.FileDescriptor)
+	add = func(file protoreflect.FileDescriptor) {
+		if seen[file.Path()] {
+			return
+		}
+		seen[file.Path()] = true
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(file))
+	}
+	for _, manifest := range manifests {
+		file, err := protoregistry.GlobalFiles.FindFileByPath(manifest.ProtoFile)
+		if err != nil {
+			return nil, fmt.Errorf("rpccgo: descriptor of %s: %w", manifest.ServiceID, err)
+		}
+		add(file)
+	}
+	return set, nil
+}
+
+// EncodeServiceList encodes manifests without their methods as a
+// rpccgo.runtime.v1.ServiceList protobuf message; the schema ships as
+// reflection.proto next to this file.
+func EncodeServiceList(manifests []ServiceManifest) []byte {
+	var out []byte
+	for _, manifest := range manifests {
+		var entry []byte
+		entry = appendStringField(entry, 1, string(manifest.ServiceID))
+		entry = appendStringField(entry, 2, manifest.ProtoFile)
+		entry = appendBoolField(entry, 3, manifest.Native)
+		entry = appendVarintField(entry, 4, uint64(manifest.MessageTransport))
+		entry = appendVarintField(entry, 5, uint64(len(manifest.Methods)))
+		out = appendMessageField(out, 1, entry)
+	}
+	return out
+}
+
+// EncodeMethodList encodes the methods of manifest as a
+// rpccgo.runtime.v1.MethodList protobuf message.
+func EncodeMethodList(manifest ServiceManifest) []byte {
+	var out []byte
+	for _, method := range manifest.Methods {
+		var entry []byte
+		entry = appendStringField(entry, 1, method.Name)
+		entry = appendStringField(entry, 2, method.FullName)
+		entry = appendVarintField(entry, 3, uint64(method.Streaming))
+		entry = appendStringField(entry, 4, method.RequestType)
+		entry = appendStringField(entry, 5, method.ResponseType)
+		out = appendMessageField(out, 1, entry)
+	}
+	return out
+}
+
+// EncodePinnedServiceList encodes the registered services into a pinned
+// ptr/len payload for the C ABI. Callers must release a non-zero pointer with
+// Release after the ABI consumer is done with it.
+func EncodePinnedServiceList() (uintptr, int32, error) {
+	return pinPayload(EncodeServiceList(ListServiceManifests()))
+}
+
+// EncodePinnedMethodList encodes the methods of serviceID into a pinned
+// ptr/len payload for the C ABI.
+func EncodePinnedMethodList(serviceID ServiceID) (uintptr, int32, error) {
+	manifest, ok := LookupServiceManifest(serviceID)
+	if !ok {
+		return 0, 0, fmt.Errorf("%w for %s", ErrNoServiceManifest, serviceID)
+	}
+	return pinPayload(EncodeMethodList(manifest))
+}
+
+// EncodePinnedFileDescriptorSet serializes ServiceFileDescriptorSet into a
+// pinned ptr/len payload for the C ABI.
+func EncodePinnedFileDescriptorSet(serviceID ServiceID) (uintptr, int32, error) {
+	set, err := ServiceFileDescriptorSet(serviceID)
+	if err != nil {
+		return 0, 0, err
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(set)
+	if err != nil {
+		return 0, 0, err
+	}
+	return pinPayload(data)
+}
@@ -0,0 +1,64 @@
+package rpcruntime
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ygrpc/rpccgo/rpcruntime/internal/runtimev1"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestServiceManifestsListMethodsAndDescriptors(t *testing.T) {
+	const serviceID ServiceID = "grpc.health.v1.Health"
+	t.Cleanup(func() { serviceManifests.Delete(serviceID) })
+	RegisterServiceManifest(ServiceManifest{
+		ServiceID:        serviceID,
+		ProtoFile:        grpc_health_v1.File_grpc_health_v1_health_proto.Path(),
+		Native:           true,
+		MessageTransport: ServerKindGRPC,
+		Methods: []MethodManifest{
+			{Name: "Check", FullName: "grpc.health.v1.Health.Check", Streaming: MethodStreamingUnary, RequestType: "grpc.health.v1.HealthCheckRequest", ResponseType: "grpc.health.v1.HealthCheckResponse"},
+			{Name: "Watch", FullName: "grpc.health.v1.Health.Watch", Streaming: MethodStreamingServer, RequestType: "grpc.health.v1.HealthCheckRequest", ResponseType: "grpc.health.v1.HealthCheckResponse"},
+		},
+	})
+
+	if got, ok := LookupServiceManifest(serviceID); !ok || len(got.Methods) != 2 {
+		t.Fatalf("LookupServiceManifest = %+v, %v", got, ok)
+	}
+	set, err := ServiceFileDescriptorSet(serviceID)
+	if err != nil {
+		t.Fatalf("ServiceFileDescriptorSet returned error: %v", err)
+	}
+	if len(set.GetFile()) != 1 || set.GetFile()[0].GetName() != "grpc/health/v1/health.proto" || set.GetFile()[0].GetService()[0].GetName() != "Health" {
+		t.Fatalf("FileDescriptorSet files = %v", set.GetFile())
+	}
+	if _, err := ServiceFileDescriptorSet("rpccgo.test.v1.Missing"); !errors.Is(err, ErrNoServiceManifest) || ErrorCodeOf(err) != ErrorCodeNotFound {
+		t.Fatalf("ServiceFileDescriptorSet of a missing service = %v, want ErrNoServiceManifest", err)
+	}
+
+	manifest, _ := LookupServiceManifest(serviceID)
+	assertPayloadMatchesSchema(t, EncodeServiceList([]ServiceManifest{manifest}), &runtimev1.ServiceList{
+		Services: []*runtimev1.ServiceInfo{{
+			ServiceId:        string(serviceID),
+			ProtoFile:        "grpc/health/v1/health.proto",
+			Native:           true,
+			MessageTransport: int32(ServerKindGRPC),
+			MethodCount:      2,
+		}},
+	})
+	assertPayloadMatchesSchema(t, EncodeMethodList(manifest), &runtimev1.MethodList{
+		Methods: []*runtimev1.MethodInfo{{
+			Name:         "Check",
+			FullName:     "grpc.health.v1.Health.Check",
+			Streaming:    int32(MethodStreamingUnary),
+			RequestType:  "grpc.health.v1.HealthCheckRequest",
+			ResponseType: "grpc.health.v1.HealthCheckResponse",
+		}, {
+			Name:         "Watch",
+			FullName:     "grpc.health.v1.Health.Watch",
+			Streaming:    int32(MethodStreamingServer),
+			RequestType:  "grpc.health.v1.HealthCheckRequest",
+			ResponseType: "grpc.health.v1.HealthCheckResponse",
+		}},
+	})
+}
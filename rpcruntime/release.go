@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"unsafe"
 )
 
@@ -17,20 +18,29 @@ type NativeArrayElem interface {
 
 type releaseEntry struct {
 	value  any
+	size   int64
 	pinner runtime.Pinner
+	trace  *pinTrace
 }
 
 // pinnedMap tracks active pin/unpin lifetimes by exported pointer value.
 // Entries are short-lived and independently removed, which matches sync.Map well.
 var pinnedMap sync.Map
 
+// pinnedBytes and pinnedBuffers count the pinned memory still waiting for
+// Release; they feed the runtime metrics.
+var (
+	pinnedBytes   atomic.Int64
+	pinnedBuffers atomic.Int64
+)
+
 func PinBytes(b []byte) (uintptr, error) {
 	if len(b) == 0 {
 		return 0, nil
 	}
 
 	ptr := uintptr(unsafe.Pointer(&b[0]))
-	return registerPinned(ptr, b, unsafe.Pointer(&b[0]))
+	return registerPinned(ptr, b, unsafe.Pointer(&b[0]), len(b))
 }
 
 func PinString(s string) ([]byte, uintptr, error) {
@@ -54,7 +64,7 @@ func PinSlice[T NativeArrayElem](s []T) (uintptr, error) {
 	}
 
 	ptr := uintptr(unsafe.Pointer(&s[0]))
-	return registerPinned(ptr, s, unsafe.Pointer(&s[0]))
+	return registerPinned(ptr, s, unsafe.Pointer(&s[0]), len(s)*int(unsafe.Sizeof(s[0])))
 }
 
 func Release(ptr uintptr) bool {
@@ -69,18 +79,26 @@ func Release(ptr uintptr) bool {
 
 	entry := raw.(*releaseEntry)
 	entry.pinner.Unpin()
+	pinnedBytes.Add(-entry.size)
+	pinnedBuffers.Add(-1)
 	return true
 }
 
 // registerPinned treats the exported pointer value as a unique runtime handle.
 // Re-exporting the same backing store returns the existing pointer plus an error;
 // the runtime does not add reference counting or copy the backing store for you.
-func registerPinned(ptr uintptr, value any, target unsafe.Pointer) (uintptr, error) {
-	entry := &releaseEntry{value: value}
+func registerPinned(ptr uintptr, value any, target unsafe.Pointer, size int) (uintptr, error) {
+	entry := &releaseEntry{value: value, size: int64(size), trace: tracePin()}
 	entry.pinner.Pin(target)
 
+	// Count before publishing so a concurrent Release never drives the
+	// counters negative.
+	pinnedBytes.Add(entry.size)
+	pinnedBuffers.Add(1)
 	_, loaded := pinnedMap.LoadOrStore(ptr, entry)
 	if loaded {
+		pinnedBytes.Add(-entry.size)
+		pinnedBuffers.Add(-1)
 		entry.pinner.Unpin()
 		return ptr, fmt.Errorf("pointer %x already pinned", ptr)
 	}
@@ -0,0 +1,449 @@
+package rpcruntime
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+const (
+	rpcStringRepeatLabel = "RpcStringRepeat"
+	rpcBytesRepeatLabel  = "RpcBytesRepeat"
+)
+
+// RpcBuffer is one element of a repeated string or bytes field at the cgo
+// boundary. Its layout matches the C rpccgo_buffer struct. Ownership > 0 marks
+// element data the receiver frees through the registered free callback.
+type RpcBuffer struct {
+	Ptr       unsafe.Pointer
+	Len       int32
+	Ownership int32
+}
+
+// RpcStringRepeat wraps a borrowed or owned array of string buffers from the
+// cgo boundary. ownership covers the array itself; each element carries its
+// own ownership.
+type RpcStringRepeat struct {
+	ptr       *RpcBuffer
+	length    int32
+	ownership bool
+	owned     []unsafe.Pointer
+
+	safeOnce  sync.Once
+	safeCache []string
+
+	cleanup    runtime.Cleanup
+	hasCleanup bool
+}
+
+// RpcBytesRepeat wraps a borrowed or owned array of bytes buffers from the
+// cgo boundary. ownership covers the array itself; each element carries its
+// own ownership.
+type RpcBytesRepeat struct {
+	ptr       *RpcBuffer
+	length    int32
+	ownership bool
+	owned     []unsafe.Pointer
+
+	safeOnce  sync.Once
+	safeCache [][]byte
+
+	cleanup    runtime.Cleanup
+	hasCleanup bool
+}
+
+var (
+	emptyRpcStringRepeat = &RpcStringRepeat{}
+	emptyRpcBytesRepeat  = &RpcBytesRepeat{}
+)
+
+func NewRpcStringRepeat(ptr *RpcBuffer, length int32, ownership bool) *RpcStringRepeat {
+	rpc, err := NewRpcStringRepeatChecked(ptr, length, ownership)
+	if err != nil {
+		return nil
+	}
+	return rpc
+}
+
+// NewRpcStringRepeatChecked returns an RpcStringRepeat wrapper after
+// validating the array length and every element length.
+func NewRpcStringRepeatChecked(ptr *RpcBuffer, length int32, ownership bool) (*RpcStringRepeat, error) {
+	if err := checkRpcBufferArray(ptr, length); err != nil {
+		return nil, fmt.Errorf("NewRpcStringRepeat: %w", err)
+	}
+	rpc := &RpcStringRepeat{
+		ptr:       ptr,
+		length:    length,
+		ownership: ownership,
+		owned:     ownedRpcBufferPointers(ptr, length, ownership),
+	}
+	rpc.attachCleanup(rpcStringRepeatLabel)
+	return rpc, nil
+}
+
+func NewRpcBytesRepeat(ptr *RpcBuffer, length int32, ownership bool) *RpcBytesRepeat {
+	rpc, err := NewRpcBytesRepeatChecked(ptr, length, ownership)
+	if err != nil {
+		return nil
+	}
+	return rpc
+}
+
+// NewRpcBytesRepeatChecked returns an RpcBytesRepeat wrapper after validating
+// the array length and every element length.
+func NewRpcBytesRepeatChecked(ptr *RpcBuffer, length int32, ownership bool) (*RpcBytesRepeat, error) {
+	if err := checkRpcBufferArray(ptr, length); err != nil {
+		return nil, fmt.Errorf("NewRpcBytesRepeat: %w", err)
+	}
+	rpc := &RpcBytesRepeat{
+		ptr:       ptr,
+		length:    length,
+		ownership: ownership,
+		owned:     ownedRpcBufferPointers(ptr, length, ownership),
+	}
+	rpc.attachCleanup(rpcBytesRepeatLabel)
+	return rpc, nil
+}
+
+// EmptyRpcStringRepeat returns the canonical read-only empty string repeat wrapper.
+func EmptyRpcStringRepeat() *RpcStringRepeat {
+	return emptyRpcStringRepeat
+}
+
+// EmptyRpcBytesRepeat returns the canonical read-only empty bytes repeat wrapper.
+func EmptyRpcBytesRepeat() *RpcBytesRepeat {
+	return emptyRpcBytesRepeat
+}
+
+func (r *RpcStringRepeat) Len() int32 {
+	if r == nil {
+		return 0
+	}
+	return r.length
+}
+
+// At returns a zero-copy borrowed view of the string at i, or "" when i is out
+// of range. Callers must keep the wrapper reachable while using it.
+func (r *RpcStringRepeat) At(i int32) string {
+	if r == nil || r.ptr == nil || i < 0 || i >= r.length {
+		return ""
+	}
+	return rpcBufferString(rpcBufferSlice(r.ptr, r.length)[lengthFromInt32OrZero(i)])
+}
+
+// AtChecked returns the string at i or an explicit range error.
+func (r *RpcStringRepeat) AtChecked(i int32) (string, error) {
+	if r == nil || r.ptr == nil || i < 0 || i >= r.length {
+		return "", fmt.Errorf("RpcStringRepeat.AtChecked: index %d out of range [0, %d)", i, r.Len())
+	}
+	return r.At(i), nil
+}
+
+// UnsafeSlice returns zero-copy borrowed views over every element.
+// Callers must keep the wrapper reachable while using the returned strings.
+func (r *RpcStringRepeat) UnsafeSlice() []string {
+	if r == nil || r.ptr == nil || r.length == 0 {
+		return nil
+	}
+	elems := rpcBufferSlice(r.ptr, r.length)
+	values := make([]string, len(elems))
+	for i, elem := range elems {
+		values[i] = rpcBufferString(elem)
+	}
+	return values
+}
+
+// SafeSlice returns a cached copy that is safe to retain after the wrapper is released.
+func (r *RpcStringRepeat) SafeSlice() []string {
+	if r == nil || r.ptr == nil || r.length == 0 {
+		return nil
+	}
+
+	r.safeOnce.Do(func() {
+		r.safeCache, _ = CopyStringArray(r.ptr, r.length)
+	})
+	return r.safeCache
+}
+
+// Release deterministically releases owned elements and, when ownership is
+// true, the owned array.
+func (r *RpcStringRepeat) Release() error {
+	if r == nil {
+		return nil
+	}
+	if err := releaseRpcInputs(r.owned, rpcStringRepeatLabel); err != nil {
+		return err
+	}
+	if r.hasCleanup {
+		r.cleanup.Stop()
+	}
+	return nil
+}
+
+func (r *RpcBytesRepeat) Len() int32 {
+	if r == nil {
+		return 0
+	}
+	return r.length
+}
+
+// At returns a zero-copy borrowed view of the bytes at i, or nil when i is out
+// of range. Callers must keep the wrapper reachable while using it.
+func (r *RpcBytesRepeat) At(i int32) []byte {
+	if r == nil || r.ptr == nil || i < 0 || i >= r.length {
+		return nil
+	}
+	return rpcBufferBytes(rpcBufferSlice(r.ptr, r.length)[lengthFromInt32OrZero(i)])
+}
+
+// AtChecked returns the bytes at i or an explicit range error.
+func (r *RpcBytesRepeat) AtChecked(i int32) ([]byte, error) {
+	if r == nil || r.ptr == nil || i < 0 || i >= r.length {
+		return nil, fmt.Errorf("RpcBytesRepeat.AtChecked: index %d out of range [0, %d)", i, r.Len())
+	}
+	return r.At(i), nil
+}
+
+// UnsafeSlice returns zero-copy borrowed views over every element.
+// Callers must keep the wrapper reachable while using the returned slices.
+func (r *RpcBytesRepeat) UnsafeSlice() [][]byte {
+	if r == nil || r.ptr == nil || r.length == 0 {
+		return nil
+	}
+	elems := rpcBufferSlice(r.ptr, r.length)
+	values := make([][]byte, len(elems))
+	for i, elem := range elems {
+		values[i] = rpcBufferBytes(elem)
+	}
+	return values
+}
+
+// SafeSlice returns a cached copy that is safe to retain after the wrapper is released.
+func (r *RpcBytesRepeat) SafeSlice() [][]byte {
+	if r == nil || r.ptr == nil || r.length == 0 {
+		return nil
+	}
+
+	r.safeOnce.Do(func() {
+		r.safeCache, _ = CopyBytesArray(r.ptr, r.length)
+	})
+	return r.safeCache
+}
+
+// Release deterministically releases owned elements and, when ownership is
+// true, the owned array.
+func (r *RpcBytesRepeat) Release() error {
+	if r == nil {
+		return nil
+	}
+	if err := releaseRpcInputs(r.owned, rpcBytesRepeatLabel); err != nil {
+		return err
+	}
+	if r.hasCleanup {
+		r.cleanup.Stop()
+	}
+	return nil
+}
+
+func (r *RpcStringRepeat) attachCleanup(label string) {
+	if r == nil || len(r.owned) == 0 {
+		return
+	}
+	r.cleanup = runtime.AddCleanup(r, rpcInputsCleanup, registerRpcInputs(r.owned, label))
+	r.hasCleanup = true
+}
+
+func (r *RpcBytesRepeat) attachCleanup(label string) {
+	if r == nil || len(r.owned) == 0 {
+		return
+	}
+	r.cleanup = runtime.AddCleanup(r, rpcInputsCleanup, registerRpcInputs(r.owned, label))
+	r.hasCleanup = true
+}
+
+// StringBuffer returns a borrowed RpcBuffer over value. The caller must keep
+// value reachable while the buffer is in use.
+func StringBuffer(value string) RpcBuffer {
+	if len(value) == 0 {
+		return RpcBuffer{}
+	}
+	return RpcBuffer{Ptr: unsafe.Pointer(unsafe.StringData(value)), Len: int32(len(value))}
+}
+
+// BytesBuffer returns a borrowed RpcBuffer over value like StringBuffer.
+func BytesBuffer(value []byte) RpcBuffer {
+	if len(value) == 0 {
+		return RpcBuffer{}
+	}
+	return RpcBuffer{Ptr: unsafe.Pointer(unsafe.SliceData(value)), Len: int32(len(value))}
+}
+
+// CopyStringArray copies length string buffers at ptr into Go memory without
+// taking ownership of them.
+func CopyStringArray(ptr *RpcBuffer, length int32) ([]string, error) {
+	if err := checkRpcBufferArray(ptr, length); err != nil {
+		return nil, err
+	}
+	if ptr == nil || length == 0 {
+		return nil, nil
+	}
+	elems := rpcBufferSlice(ptr, length)
+	values := make([]string, len(elems))
+	for i, elem := range elems {
+		values[i] = string(rpcBufferBytes(elem))
+	}
+	return values, nil
+}
+
+// CopyBytesArray copies length bytes buffers at ptr into Go memory without
+// taking ownership of them.
+func CopyBytesArray(ptr *RpcBuffer, length int32) ([][]byte, error) {
+	if err := checkRpcBufferArray(ptr, length); err != nil {
+		return nil, err
+	}
+	if ptr == nil || length == 0 {
+		return nil, nil
+	}
+	elems := rpcBufferSlice(ptr, length)
+	values := make([][]byte, len(elems))
+	for i, elem := range elems {
+		if data := rpcBufferBytes(elem); data != nil {
+			values[i] = append([]byte{}, data...)
+		}
+	}
+	return values, nil
+}
+
+// ReleaseBufferArray frees the owned elements of a buffer array and, when owned
+// is true, the array itself through the registered free callback.
+func ReleaseBufferArray(ptr *RpcBuffer, length int32, owned bool, label string) error {
+	if _, err := LengthFromInt32(length); err != nil {
+		return fmt.Errorf("%s: %w", label, err)
+	}
+	var releaseErr error
+	for _, elemPtr := range ownedRpcBufferPointers(ptr, length, owned) {
+		releaseErr = errors.Join(releaseErr, ReleaseC(elemPtr, true, label))
+	}
+	return releaseErr
+}
+
+// PinStringArray pins values as one allocation holding the RpcBuffer array
+// followed by the element data, and returns the array address. Elements are
+// borrowed; releasing the returned pointer releases them all.
+func PinStringArray(values []string) (uintptr, error) {
+	return pinBufferArray(values)
+}
+
+// PinBytesArray pins values like PinStringArray.
+func PinBytesArray(values [][]byte) (uintptr, error) {
+	return pinBufferArray(values)
+}
+
+func pinBufferArray[E string | []byte](values []E) (uintptr, error) {
+	if len(values) == 0 {
+		return 0, nil
+	}
+	size := rpcBufferArrayHeaderSize(len(values))
+	for _, value := range values {
+		if _, err := LengthToInt32(len(value)); err != nil {
+			return 0, err
+		}
+		size += len(value)
+	}
+	words := make([]uint64, (size+7)/8)
+	data := unsafe.Slice((*byte)(unsafe.Pointer(&words[0])), size)
+	elems := unsafe.Slice((*RpcBuffer)(unsafe.Pointer(&words[0])), len(values))
+	offset := rpcBufferArrayHeaderSize(len(values))
+	for i, value := range values {
+		if len(value) == 0 {
+			continue
+		}
+		copy(data[offset:], value)
+		elems[i] = RpcBuffer{Ptr: unsafe.Pointer(&data[offset]), Len: int32(len(value))}
+		offset += len(value)
+	}
+	return registerPinned(uintptr(unsafe.Pointer(&words[0])), words, unsafe.Pointer(&words[0]), size)
+}
+
+func rpcBufferArrayHeaderSize(count int) int {
+	return count * int(unsafe.Sizeof(RpcBuffer{}))
+}
+
+func checkRpcBufferArray(ptr *RpcBuffer, length int32) error {
+	if _, err := LengthFromInt32(length); err != nil {
+		return err
+	}
+	if ptr == nil {
+		return nil
+	}
+	for i, elem := range rpcBufferSlice(ptr, length) {
+		if _, err := LengthFromInt32(elem.Len); err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ownedRpcBufferPointers lists the owned element data followed by the array,
+// the order in which they must be freed.
+func ownedRpcBufferPointers(ptr *RpcBuffer, length int32, ownership bool) []unsafe.Pointer {
+	if ptr == nil {
+		return nil
+	}
+	var owned []unsafe.Pointer
+	for _, elem := range rpcBufferSlice(ptr, length) {
+		if elem.Ownership > 0 && elem.Ptr != nil {
+			owned = append(owned, elem.Ptr)
+		}
+	}
+	if ownership {
+		owned = append(owned, unsafe.Pointer(ptr))
+	}
+	return owned
+}
+
+func rpcBufferSlice(ptr *RpcBuffer, length int32) []RpcBuffer {
+	if ptr == nil || length <= 0 {
+		return nil
+	}
+	return unsafe.Slice(ptr, lengthFromInt32OrZero(length))
+}
+
+func rpcBufferBytes(elem RpcBuffer) []byte {
+	if elem.Ptr == nil || elem.Len <= 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(elem.Ptr), lengthFromInt32OrZero(elem.Len))
+}
+
+func rpcBufferString(elem RpcBuffer) string {
+	if elem.Ptr == nil || elem.Len <= 0 {
+		return ""
+	}
+	return unsafe.String((*byte)(elem.Ptr), lengthFromInt32OrZero(elem.Len))
+}
+
+func registerRpcInputs(ptrs []unsafe.Pointer, label string) []rpcInputCleanupArg {
+	args := make([]rpcInputCleanupArg, 0, len(ptrs))
+	for _, ptr := range ptrs {
+		registerRpcInputRelease(ptr, true, label)
+		args = append(args, rpcInputCleanupArg{ptr: ptr, label: label})
+	}
+	return args
+}
+
+func rpcInputsCleanup(args []rpcInputCleanupArg) {
+	for _, arg := range args {
+		rpcInputCleanup(arg)
+	}
+}
+
+func releaseRpcInputs(ptrs []unsafe.Pointer, label string) error {
+	var err error
+	for _, ptr := range ptrs {
+		err = errors.Join(err, releaseRpcInput(ptr, true, label))
+	}
+	return err
+}
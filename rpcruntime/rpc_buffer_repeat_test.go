@@ -0,0 +1,216 @@
+package rpcruntime
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"unsafe"
+)
+
+func testRpcBuffers(values ...string) ([]RpcBuffer, [][]byte) {
+	elems := make([]RpcBuffer, len(values))
+	data := make([][]byte, len(values))
+	for i, value := range values {
+		data[i] = []byte(value)
+		if len(value) != 0 {
+			elems[i] = RpcBuffer{Ptr: unsafe.Pointer(&data[i][0]), Len: int32(len(value))}
+		}
+	}
+	return elems, data
+}
+
+func TestEmptyRpcStringAndBytesRepeatAreNilSafe(t *testing.T) {
+	if got := EmptyRpcStringRepeat(); got != EmptyRpcStringRepeat() || got.Len() != 0 || got.SafeSlice() != nil || got.At(0) != "" {
+		t.Fatalf("EmptyRpcStringRepeat() = %+v, want canonical empty wrapper", got)
+	}
+	if got := EmptyRpcBytesRepeat(); got != EmptyRpcBytesRepeat() || got.Len() != 0 || got.SafeSlice() != nil || got.At(0) != nil {
+		t.Fatalf("EmptyRpcBytesRepeat() = %+v, want canonical empty wrapper", got)
+	}
+	var nilRepeat *RpcStringRepeat
+	if nilRepeat.Len() != 0 || nilRepeat.UnsafeSlice() != nil || nilRepeat.Release() != nil {
+		t.Fatal("expected nil RpcStringRepeat to behave as empty")
+	}
+	if err := EmptyRpcBytesRepeat().Release(); err != nil {
+		t.Fatalf("expected EmptyRpcBytesRepeat().Release() to be a no-op, got %v", err)
+	}
+}
+
+func TestRpcStringRepeatLenAtAndSafeSlice(t *testing.T) {
+	elems, data := testRpcBuffers("alpha", "", "gamma")
+	rpc := NewRpcStringRepeat(&elems[0], int32(len(elems)), false)
+
+	if rpc.Len() != 3 {
+		t.Fatalf("Len() = %d, want 3", rpc.Len())
+	}
+	if got := rpc.At(0); got != "alpha" {
+		t.Fatalf("At(0) = %q, want alpha", got)
+	}
+	if got := rpc.At(1); got != "" {
+		t.Fatalf("At(1) = %q, want empty", got)
+	}
+	if _, err := rpc.AtChecked(3); err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Fatalf("AtChecked(3) error = %v, want out of range", err)
+	}
+
+	safe := rpc.SafeSlice()
+	if !slices.Equal(safe, []string{"alpha", "", "gamma"}) {
+		t.Fatalf("SafeSlice() = %q", safe)
+	}
+	data[0][0] = 'A'
+	if got := rpc.UnsafeSlice()[0]; got != "Alpha" {
+		t.Fatalf("UnsafeSlice()[0] = %q, want the borrowed view to see the update", got)
+	}
+	if got := rpc.SafeSlice()[0]; got != "alpha" {
+		t.Fatalf("SafeSlice()[0] = %q, want the cached copy", got)
+	}
+}
+
+func TestRpcBytesRepeatLenAtAndSafeSlice(t *testing.T) {
+	elems, data := testRpcBuffers("\x00\x01", "\xff")
+	rpc := NewRpcBytesRepeat(&elems[0], int32(len(elems)), false)
+
+	if got := rpc.At(0); string(got) != "\x00\x01" {
+		t.Fatalf("At(0) = %v", got)
+	}
+	safe := rpc.SafeSlice()
+	data[1][0] = 0
+	if got := safe[1]; string(got) != "\xff" {
+		t.Fatalf("SafeSlice()[1] = %v, want an independent copy", got)
+	}
+	if got := rpc.At(1); got[0] != 0 {
+		t.Fatalf("At(1) = %v, want the borrowed view to see the update", got)
+	}
+}
+
+func TestNewRpcStringRepeatCheckedRejectsNegativeElementLength(t *testing.T) {
+	elems := []RpcBuffer{{Len: 1}, {Len: -1}}
+	if _, err := NewRpcStringRepeatChecked(&elems[0], int32(len(elems)), false); err == nil || !strings.Contains(err.Error(), "element 1") {
+		t.Fatalf("NewRpcStringRepeatChecked error = %v, want element 1 length error", err)
+	}
+	if got := NewRpcBytesRepeat(&elems[0], -1, false); got != nil {
+		t.Fatalf("NewRpcBytesRepeat with negative length = %+v, want nil", got)
+	}
+}
+
+func TestRpcBytesRepeatReleaseFreesOwnedElementsThenArrayOnce(t *testing.T) {
+	ResetFreeCallbackForTesting()
+	t.Cleanup(ResetFreeCallbackForTesting)
+
+	var mu sync.Mutex
+	var freed []uintptr
+	RegisterFreeCallback(func(ptr unsafe.Pointer) {
+		mu.Lock()
+		defer mu.Unlock()
+		freed = append(freed, uintptr(ptr))
+	})
+	elems, _ := testRpcBuffers("owned", "borrowed")
+	elems[0].Ownership = 1
+	rpc := NewRpcBytesRepeat(&elems[0], int32(len(elems)), true)
+
+	if err := rpc.Release(); err != nil {
+		t.Fatalf("unexpected release error: %v", err)
+	}
+	if err := rpc.Release(); err != nil {
+		t.Fatalf("expected repeated release to stay a no-op, got %v", err)
+	}
+	want := []uintptr{uintptr(elems[0].Ptr), uintptr(unsafe.Pointer(&elems[0]))}
+	mu.Lock()
+	defer mu.Unlock()
+	if !slices.Equal(freed, want) {
+		t.Fatalf("freed = %#x, want owned element then array %#x", freed, want)
+	}
+}
+
+func TestRpcStringRepeatReleaseWithoutRegisteredCallbackCanRetry(t *testing.T) {
+	ResetFreeCallbackForTesting()
+	t.Cleanup(ResetFreeCallbackForTesting)
+
+	elems, _ := testRpcBuffers("owned")
+	elems[0].Ownership = 1
+	rpc := NewRpcStringRepeat(&elems[0], int32(len(elems)), false)
+	if err := rpc.Release(); err == nil {
+		t.Fatal("expected release without registered free callback to fail")
+	}
+
+	recorder := registerFreeCallbackRecorder()
+	if err := rpc.Release(); err != nil {
+		t.Fatalf("expected release to succeed after registering callback, got %v", err)
+	}
+	if got := recorder.calls.Load(); got != 1 {
+		t.Fatalf("expected retry release to free exactly once, got %d calls", got)
+	}
+	if got := recorder.ptr.Load(); got != uintptr(elems[0].Ptr) {
+		t.Fatalf("expected retry release to free the owned element %#x, got %#x", uintptr(elems[0].Ptr), got)
+	}
+}
+
+func TestRpcStringRepeatCleanupReleasesOwnedElementAfterGC(t *testing.T) {
+	ResetFreeCallbackForTesting()
+	t.Cleanup(ResetFreeCallbackForTesting)
+
+	recorder := registerFreeCallbackRecorder()
+	elems, _ := testRpcBuffers("owned")
+	elems[0].Ownership = 1
+	func() {
+		_ = NewRpcStringRepeat(&elems[0], int32(len(elems)), false)
+	}()
+
+	waitForRpcInputCleanupOnce(t, recorder, uintptr(elems[0].Ptr))
+}
+
+func TestPinStringArrayLaysOutBuffersInOnePinnedAllocation(t *testing.T) {
+	ptr, err := PinStringArray([]string{"one", "", "three"})
+	if err != nil {
+		t.Fatalf("PinStringArray returned error: %v", err)
+	}
+	defer Release(ptr)
+
+	raw, ok := pinnedMap.Load(ptr)
+	if !ok {
+		t.Fatalf("PinStringArray pointer %#x is not pinned", ptr)
+	}
+	words := raw.(*releaseEntry).value.([]uint64)
+	elems := unsafe.Slice((*RpcBuffer)(unsafe.Pointer(&words[0])), 3)
+	got, err := CopyStringArray(&elems[0], 3)
+	if err != nil {
+		t.Fatalf("CopyStringArray returned error: %v", err)
+	}
+	if !slices.Equal(got, []string{"one", "", "three"}) {
+		t.Fatalf("CopyStringArray = %q", got)
+	}
+	if elems[1].Ptr != nil || elems[0].Ownership != 0 {
+		t.Fatalf("elements = %+v, want a nil empty element and borrowed data", elems)
+	}
+	if ptr, err := PinBytesArray(nil); ptr != 0 || err != nil {
+		t.Fatalf("PinBytesArray(nil) = %#x, %v; want 0, nil", ptr, err)
+	}
+}
+
+func TestOutputBufferPutBytesArray(t *testing.T) {
+	storage := make([]uint64, 8)
+	buffer, err := NewOutputBuffer(unsafe.Pointer(&storage[0]), 64)
+	if err != nil {
+		t.Fatalf("NewOutputBuffer returned error: %v", err)
+	}
+	ptr := buffer.PutBytesArray([][]byte{[]byte("ab"), nil, []byte("c")})
+	if err := buffer.Err(); err != nil || ptr != uintptr(unsafe.Pointer(&storage[0])) {
+		t.Fatalf("PutBytesArray = %#x, %v; want the buffer start", ptr, err)
+	}
+	got, err := CopyBytesArray((*RpcBuffer)(unsafe.Pointer(&storage[0])), 3)
+	if err != nil || string(got[0]) != "ab" || got[1] != nil || string(got[2]) != "c" {
+		t.Fatalf("CopyBytesArray = %q, %v", got, err)
+	}
+	if n, _ := buffer.Len(); int(n) != 3*int(unsafe.Sizeof(RpcBuffer{}))+3 {
+		t.Fatalf("Len() = %d", n)
+	}
+
+	small, err := NewOutputBuffer(unsafe.Pointer(&storage[0]), 8)
+	if err != nil {
+		t.Fatalf("NewOutputBuffer returned error: %v", err)
+	}
+	if ptr := small.PutStringArray([]string{"abc"}); ptr != 0 || !errors.Is(small.Err(), ErrBufferTooSmall) {
+		t.Fatalf("PutStringArray into a small buffer = %#x, %v; want ErrBufferTooSmall", ptr, small.Err())
+	}
+}
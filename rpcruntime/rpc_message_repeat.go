@@ -0,0 +1,201 @@
+package rpcruntime
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+const rpcMessageRepeatLabel = "RpcMessageRepeat"
+
+// RpcMessageRepeat is a typed view over a repeated message input whose
+// elements cross the cgo boundary as encoded protobuf buffers. Elements are
+// decoded on first access and cached; the decoded messages do not borrow from
+// the input and stay valid after Release.
+type RpcMessageRepeat[T protobuf.Message] struct {
+	encoded *RpcBytesRepeat
+
+	mu      sync.Mutex
+	decoded []T
+}
+
+var emptyRpcMessageRepeatByType sync.Map
+
+func NewRpcMessageRepeat[T protobuf.Message](ptr *RpcBuffer, length int32, ownership bool) *RpcMessageRepeat[T] {
+	rpc, err := NewRpcMessageRepeatChecked[T](ptr, length, ownership)
+	if err != nil {
+		return nil
+	}
+	return rpc
+}
+
+// NewRpcMessageRepeatChecked returns an RpcMessageRepeat view after
+// validating the array length and every element length. Elements are not
+// decoded until they are read.
+func NewRpcMessageRepeatChecked[T protobuf.Message](ptr *RpcBuffer, length int32, ownership bool) (*RpcMessageRepeat[T], error) {
+	if err := checkRpcBufferArray(ptr, length); err != nil {
+		return nil, fmt.Errorf("NewRpcMessageRepeat: %w", err)
+	}
+	encoded := &RpcBytesRepeat{
+		ptr:       ptr,
+		length:    length,
+		ownership: ownership,
+		owned:     ownedRpcBufferPointers(ptr, length, ownership),
+	}
+	encoded.attachCleanup(rpcMessageRepeatLabel)
+	return &RpcMessageRepeat[T]{encoded: encoded}, nil
+}
+
+// EmptyRpcMessageRepeat returns the canonical read-only empty wrapper for T.
+func EmptyRpcMessageRepeat[T protobuf.Message]() *RpcMessageRepeat[T] {
+	typeKey := reflect.TypeFor[T]()
+	if cached, ok := emptyRpcMessageRepeatByType.Load(typeKey); ok {
+		return cached.(*RpcMessageRepeat[T])
+	}
+
+	empty := &RpcMessageRepeat[T]{encoded: EmptyRpcBytesRepeat()}
+	actual, _ := emptyRpcMessageRepeatByType.LoadOrStore(typeKey, empty)
+	return actual.(*RpcMessageRepeat[T])
+}
+
+func (r *RpcMessageRepeat[T]) Len() int32 {
+	if r == nil {
+		return 0
+	}
+	return r.encoded.Len()
+}
+
+// At decodes the message at i, or returns the cached message when it was
+// decoded before.
+func (r *RpcMessageRepeat[T]) At(i int32) (T, error) {
+	var zero T
+	if r == nil || i < 0 || i >= r.encoded.Len() {
+		return zero, fmt.Errorf("RpcMessageRepeat.At: index %d out of range [0, %d)", i, r.Len())
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.decodeLocked(lengthFromInt32OrZero(i))
+}
+
+// EncodedAt returns a zero-copy borrowed view of the encoded message at i, or
+// nil when i is out of range. Callers must keep the wrapper reachable while
+// using it.
+func (r *RpcMessageRepeat[T]) EncodedAt(i int32) []byte {
+	if r == nil {
+		return nil
+	}
+	return r.encoded.At(i)
+}
+
+// SafeSlice decodes every element and returns the cached messages.
+func (r *RpcMessageRepeat[T]) SafeSlice() ([]T, error) {
+	if r == nil || r.encoded.Len() == 0 {
+		return nil, nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.encoded.UnsafeSlice() {
+		if _, err := r.decodeLocked(i); err != nil {
+			return nil, err
+		}
+	}
+	return r.decoded, nil
+}
+
+// SafeEncodedSlice returns a cached copy of the encoded elements that is safe
+// to retain after the wrapper is released.
+func (r *RpcMessageRepeat[T]) SafeEncodedSlice() [][]byte {
+	if r == nil {
+		return nil
+	}
+	return r.encoded.SafeSlice()
+}
+
+// Release deterministically releases owned element buffers and the owned
+// array. Messages decoded before Release stay valid.
+func (r *RpcMessageRepeat[T]) Release() error {
+	if r == nil {
+		return nil
+	}
+	return r.encoded.Release()
+}
+
+func (r *RpcMessageRepeat[T]) decodeLocked(i int) (T, error) {
+	if r.decoded == nil {
+		r.decoded = make([]T, r.encoded.Len())
+	}
+	if message := r.decoded[i]; !isNilMessage(message) {
+		return message, nil
+	}
+	message, err := unmarshalMessage[T](r.encoded.UnsafeSlice()[i])
+	if err != nil {
+		var zero T
+		return zero, fmt.Errorf("element %d: %w", i, err)
+	}
+	r.decoded[i] = message
+	return message, nil
+}
+
+// MarshalMessage encodes the message of a singular message field. A nil
+// message encodes to nil.
+func MarshalMessage(message protobuf.Message) ([]byte, error) {
+	data, err := protobuf.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("protobuf marshal failed: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalMessage decodes the encoded value of a singular message field into
+// a new T, so an empty value still yields a set message. The message does not
+// borrow from data.
+func UnmarshalMessage[T protobuf.Message](data []byte) (T, error) {
+	return unmarshalMessage[T](data)
+}
+
+// MarshalMessages encodes each message of a repeated message field.
+func MarshalMessages[T protobuf.Message](messages []T) ([][]byte, error) {
+	if len(messages) == 0 {
+		return nil, nil
+	}
+	encoded := make([][]byte, len(messages))
+	for i, message := range messages {
+		data, err := protobuf.Marshal(message)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: protobuf marshal failed: %w", i, err)
+		}
+		encoded[i] = data
+	}
+	return encoded, nil
+}
+
+// UnmarshalMessages decodes each encoded element of a repeated message field
+// into a new T. The messages do not borrow from encoded.
+func UnmarshalMessages[T protobuf.Message](encoded [][]byte) ([]T, error) {
+	if len(encoded) == 0 {
+		return nil, nil
+	}
+	messages := make([]T, len(encoded))
+	for i, data := range encoded {
+		message, err := unmarshalMessage[T](data)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		messages[i] = message
+	}
+	return messages, nil
+}
+
+func unmarshalMessage[T protobuf.Message](data []byte) (T, error) {
+	var zero T
+	message, ok := zero.ProtoReflect().Type().New().Interface().(T)
+	if !ok {
+		return zero, fmt.Errorf("message type %T cannot be instantiated", zero)
+	}
+	if err := protobuf.Unmarshal(data, message); err != nil {
+		return zero, fmt.Errorf("protobuf unmarshal failed: %w", err)
+	}
+	return message, nil
+}
@@ -0,0 +1,167 @@
+package rpcruntime
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func testEncodedMessages(t *testing.T, values ...string) ([]RpcBuffer, [][]byte) {
+	t.Helper()
+	messages := make([]*wrapperspb.StringValue, len(values))
+	for i, value := range values {
+		messages[i] = wrapperspb.String(value)
+	}
+	encoded, err := MarshalMessages(messages)
+	if err != nil {
+		t.Fatalf("MarshalMessages returned error: %v", err)
+	}
+	elems := make([]RpcBuffer, len(encoded))
+	for i := range encoded {
+		elems[i] = BytesBuffer(encoded[i])
+	}
+	return elems, encoded
+}
+
+func TestEmptyRpcMessageRepeatIsCanonicalAndNilSafe(t *testing.T) {
+	empty := EmptyRpcMessageRepeat[*wrapperspb.StringValue]()
+	if empty != EmptyRpcMessageRepeat[*wrapperspb.StringValue]() || empty.Len() != 0 {
+		t.Fatalf("EmptyRpcMessageRepeat() = %+v, want canonical empty wrapper", empty)
+	}
+	if messages, err := empty.SafeSlice(); messages != nil || err != nil {
+		t.Fatalf("empty SafeSlice() = %v, %v; want nil, nil", messages, err)
+	}
+	var nilRepeat *RpcMessageRepeat[*wrapperspb.StringValue]
+	if nilRepeat.Len() != 0 || nilRepeat.EncodedAt(0) != nil || nilRepeat.Release() != nil {
+		t.Fatal("expected nil RpcMessageRepeat to behave as empty")
+	}
+	if _, err := nilRepeat.At(0); err == nil {
+		t.Fatal("expected At on a nil RpcMessageRepeat to fail")
+	}
+}
+
+func TestRpcMessageRepeatDecodesLazilyAndCaches(t *testing.T) {
+	elems, encoded := testEncodedMessages(t, "alpha", "", "gamma")
+	rpc, err := NewRpcMessageRepeatChecked[*wrapperspb.StringValue](&elems[0], int32(len(elems)), false)
+	if err != nil {
+		t.Fatalf("NewRpcMessageRepeatChecked returned error: %v", err)
+	}
+
+	first, err := rpc.At(2)
+	if err != nil || first.GetValue() != "gamma" {
+		t.Fatalf("At(2) = %v, %v; want gamma", first, err)
+	}
+	if rpc.decoded[0] != nil {
+		t.Fatal("At(2) decoded element 0, want lazy decoding")
+	}
+	again, _ := rpc.At(2)
+	if again != first {
+		t.Fatal("At(2) decoded the element twice, want the cached message")
+	}
+	if _, err := rpc.At(3); err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Fatalf("At(3) error = %v, want out of range", err)
+	}
+
+	messages, err := rpc.SafeSlice()
+	if err != nil {
+		t.Fatalf("SafeSlice returned error: %v", err)
+	}
+	if len(messages) != 3 || messages[0].GetValue() != "alpha" || messages[1].GetValue() != "" || messages[2] != first {
+		t.Fatalf("SafeSlice() = %v", messages)
+	}
+	if got := rpc.EncodedAt(0); string(got) != string(encoded[0]) {
+		t.Fatalf("EncodedAt(0) = %x, want %x", got, encoded[0])
+	}
+
+	// Decoded messages must not alias the input buffers.
+	clear(encoded[0])
+	if messages[0].GetValue() != "alpha" {
+		t.Fatalf("decoded message changed with its input buffer: %q", messages[0].GetValue())
+	}
+}
+
+func TestRpcMessageRepeatReportsDecodeErrorWithElementIndex(t *testing.T) {
+	elems, _ := testEncodedMessages(t, "ok")
+	invalid := []byte{0x0a, 0x05}
+	elems = append(elems, BytesBuffer(invalid))
+	rpc := NewRpcMessageRepeat[*wrapperspb.StringValue](&elems[0], int32(len(elems)), false)
+
+	if _, err := rpc.SafeSlice(); err == nil || !strings.Contains(err.Error(), "element 1") {
+		t.Fatalf("SafeSlice() error = %v, want element 1 decode error", err)
+	}
+	if message, err := rpc.At(0); err != nil || message.GetValue() != "ok" {
+		t.Fatalf("At(0) = %v, %v; want the valid element", message, err)
+	}
+}
+
+func TestRpcMessageRepeatReleaseFreesOwnedElementsAndArray(t *testing.T) {
+	ResetFreeCallbackForTesting()
+	t.Cleanup(ResetFreeCallbackForTesting)
+
+	recorder := registerFreeCallbackRecorder()
+	elems, _ := testEncodedMessages(t, "owned")
+	elems[0].Ownership = 1
+	rpc := NewRpcMessageRepeat[*wrapperspb.StringValue](&elems[0], int32(len(elems)), true)
+	message, err := rpc.At(0)
+	if err != nil {
+		t.Fatalf("At(0) returned error: %v", err)
+	}
+
+	if err := rpc.Release(); err != nil {
+		t.Fatalf("unexpected release error: %v", err)
+	}
+	if err := rpc.Release(); err != nil {
+		t.Fatalf("expected repeated release to stay a no-op, got %v", err)
+	}
+	if got := recorder.calls.Load(); got != 2 {
+		t.Fatalf("expected the owned element and array to be freed once each, got %d calls", got)
+	}
+	if got := recorder.ptr.Load(); got != uintptr(unsafe.Pointer(&elems[0])) {
+		t.Fatalf("expected the array to be freed last, got %#x", got)
+	}
+	if message.GetValue() != "owned" {
+		t.Fatalf("decoded message = %q after Release, want owned", message.GetValue())
+	}
+}
+
+func TestUnmarshalMessagesRoundTripsMarshalMessages(t *testing.T) {
+	encoded, err := MarshalMessages([]*wrapperspb.Int64Value{wrapperspb.Int64(-3), {}})
+	if err != nil {
+		t.Fatalf("MarshalMessages returned error: %v", err)
+	}
+	messages, err := UnmarshalMessages[*wrapperspb.Int64Value](encoded)
+	if err != nil {
+		t.Fatalf("UnmarshalMessages returned error: %v", err)
+	}
+	if len(messages) != 2 || !protobuf.Equal(messages[0], wrapperspb.Int64(-3)) || !protobuf.Equal(messages[1], &wrapperspb.Int64Value{}) {
+		t.Fatalf("UnmarshalMessages() = %v", messages)
+	}
+	if messages, err := UnmarshalMessages[*wrapperspb.Int64Value](nil); messages != nil || err != nil {
+		t.Fatalf("UnmarshalMessages(nil) = %v, %v; want nil, nil", messages, err)
+	}
+}
+
+func TestUnmarshalMessageKeepsAnEmptyMessageSet(t *testing.T) {
+	encoded, err := MarshalMessage(&wrapperspb.StringValue{})
+	if err != nil || len(encoded) != 0 {
+		t.Fatalf("MarshalMessage(empty) = %x, %v; want no bytes", encoded, err)
+	}
+	message, err := UnmarshalMessage[*wrapperspb.StringValue](encoded)
+	if err != nil || message == nil || !protobuf.Equal(message, &wrapperspb.StringValue{}) {
+		t.Fatalf("UnmarshalMessage(empty) = %v, %v; want a non-nil empty message", message, err)
+	}
+
+	encoded, err = MarshalMessage(wrapperspb.String("set"))
+	if err != nil {
+		t.Fatalf("MarshalMessage returned error: %v", err)
+	}
+	if message, err := UnmarshalMessage[*wrapperspb.StringValue](encoded); err != nil || message.GetValue() != "set" {
+		t.Fatalf("UnmarshalMessage() = %v, %v", message, err)
+	}
+	if _, err := UnmarshalMessage[*wrapperspb.StringValue]([]byte{0xff}); err == nil {
+		t.Fatal("expected a malformed message to fail")
+	}
+}
@@ -116,6 +116,14 @@ func EmptyRpcBoolRepeat() *RpcBoolRepeat {
 	return emptyRpcBoolRepeat
 }
 
+// BoolByte returns the byte encoding RpcBoolRepeat uses for value.
+func BoolByte(value bool) byte {
+	if value {
+		return 1
+	}
+	return 0
+}
+
 func (r *RpcRepeat[T]) Len() int32 {
 	if r == nil {
 		return 0
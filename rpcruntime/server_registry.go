@@ -35,8 +35,17 @@ type RegisteredServer struct {
 }
 
 type ServerRegistry struct {
-	mu      sync.RWMutex
-	servers map[ServiceID]RegisteredServer
+	mu     sync.RWMutex
+	routes map[ServiceID]*serverRoute
+	seq    uint64
+	// reports holds the health status registered servers reported for
+	// themselves; a new registration or clear drops it.
+	reports map[ServiceID]HealthStatus
+
+	watchMu        sync.Mutex
+	watchers       map[uint64]serverWatcher
+	healthWatchers map[uint64]healthWatcher
+	nextWatch      uint64
 }
 
 func RegisterServer(serviceID ServiceID, server RegisteredServer) error {
@@ -51,6 +60,7 @@ func ClearServer(serviceID ServiceID) error {
 	return defaultServerRegistry.Clear(serviceID)
 }
 
+// Register replaces the route of serviceID with server alone.
 func (r *ServerRegistry) Register(serviceID ServiceID, server RegisteredServer) error {
 	if r == nil {
 		return errNilServerRegistry
@@ -61,33 +71,52 @@ func (r *ServerRegistry) Register(serviceID ServiceID, server RegisteredServer)
 	if err := validateRegisteredServer(server); err != nil {
 		return err
 	}
+	r.store(serviceID, newServerRoute(ServerRoute{Targets: []RouteTarget{{Server: server}}}))
+	return nil
+}
 
-	r.mu.Lock()
-	defer r.mu.Unlock()
+// Load returns the first server of the route of serviceID.
+func (r *ServerRegistry) Load(serviceID ServiceID) (RegisteredServer, error) {
+	route, err := r.loadRoute(serviceID)
+	if err != nil {
+		return RegisteredServer{}, err
+	}
+	return route.targets[0].Server, nil
+}
 
-	if r.servers == nil {
-		r.servers = make(map[ServiceID]RegisteredServer)
+func (r *ServerRegistry) store(serviceID ServiceID, route *serverRoute) {
+	r.mu.Lock()
+	if r.routes == nil {
+		r.routes = make(map[ServiceID]*serverRoute)
 	}
-	r.servers[serviceID] = server
-	return nil
+	event := r.nextEventLocked(serviceID, r.routes[serviceID])
+	event.NewKind = route.targets[0].Server.Kind
+	before := r.healthLocked(serviceID)
+	delete(r.reports, serviceID)
+	r.routes[serviceID] = route
+	after := r.healthLocked(serviceID)
+	r.mu.Unlock()
+
+	r.notify(event)
+	r.notifyHealth(before, after)
 }
 
-func (r *ServerRegistry) Load(serviceID ServiceID) (RegisteredServer, error) {
+func (r *ServerRegistry) loadRoute(serviceID ServiceID) (*serverRoute, error) {
 	if r == nil {
-		return RegisteredServer{}, errNilServerRegistry
+		return nil, errNilServerRegistry
 	}
 	if err := validateServiceID(serviceID); err != nil {
-		return RegisteredServer{}, err
+		return nil, err
 	}
 
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	server, ok := r.servers[serviceID]
+	route, ok := r.routes[serviceID]
 	if !ok {
-		return RegisteredServer{}, ErrNoRegisteredServer
+		return nil, ErrNoRegisteredServer
 	}
-	return server, nil
+	return route, nil
 }
 
 func (r *ServerRegistry) Clear(serviceID ServiceID) error {
@@ -99,9 +128,21 @@ func (r *ServerRegistry) Clear(serviceID ServiceID) error {
 	}
 
 	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	delete(r.servers, serviceID)
+	old, ok := r.routes[serviceID]
+	if !ok {
+		r.mu.Unlock()
+		return nil
+	}
+	event := r.nextEventLocked(serviceID, old)
+	event.Cleared = true
+	before := r.healthLocked(serviceID)
+	delete(r.reports, serviceID)
+	delete(r.routes, serviceID)
+	after := r.healthLocked(serviceID)
+	r.mu.Unlock()
+
+	r.notify(event)
+	r.notifyHealth(before, after)
 	return nil
 }
 
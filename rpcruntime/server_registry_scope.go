@@ -0,0 +1,179 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ServerRegistryHandle identifies a server registry shared with a C caller.
+type ServerRegistryHandle int32
+
+var ErrServerRegistryInvalidHandle = errors.New("server registry handle is invalid")
+
+type serverRegistryContextKey struct{}
+
+type serverRegistryHandles struct {
+	mu      sync.Mutex
+	next    ServerRegistryHandle
+	entries map[ServerRegistryHandle]*ServerRegistry
+}
+
+var serverRegistryEntries serverRegistryHandles
+
+// NewServerRegistry returns an empty registry isolated from the default one.
+// The generated Register<Service>...In helpers register servers in it, and
+// calls made with a context from WithServerRegistry are routed through it.
+func NewServerRegistry() *ServerRegistry {
+	return &ServerRegistry{}
+}
+
+// DefaultServerRegistry returns the process-wide registry used by the
+// generated helpers without an In suffix and by calls whose context selects no
+// registry.
+func DefaultServerRegistry() *ServerRegistry {
+	return &defaultServerRegistry
+}
+
+// WithServerRegistry returns a context that routes generated Invoke facades,
+// stream Starts and stream operations through registry. A stream session
+// belongs to the registry that started it: later operations on its handle
+// must use a context selecting the same registry, or they fail with
+// ErrStreamInvalidHandle. A nil registry selects the default registry.
+func WithServerRegistry(ctx context.Context, registry *ServerRegistry) context.Context {
+	if registry == nil {
+		registry = &defaultServerRegistry
+	}
+	return context.WithValue(ctx, serverRegistryContextKey{}, registry)
+}
+
+// ServerRegistryFromContext returns the registry selected by
+// WithServerRegistry, or the default registry.
+func ServerRegistryFromContext(ctx context.Context) *ServerRegistry {
+	if ctx != nil {
+		if registry, ok := ctx.Value(serverRegistryContextKey{}).(*ServerRegistry); ok {
+			return registry
+		}
+	}
+	return &defaultServerRegistry
+}
+
+// LoadStreamSessionContext returns the active stream session like
+// LoadStreamSession, but only when it was started through the registry ctx
+// selects.
+func LoadStreamSessionContext(ctx context.Context, handle StreamHandle) (*StreamSession, error) {
+	session, err := LoadStreamSession(handle)
+	if err != nil {
+		return nil, err
+	}
+	if session.owner() != ServerRegistryFromContext(ctx) {
+		return nil, ErrStreamInvalidHandle
+	}
+	return session, nil
+}
+
+// ListStreamSessions returns the active stream sessions started through r in
+// handle order.
+func (r *ServerRegistry) ListStreamSessions() []StreamSessionInfo {
+	var sessions []StreamSessionInfo
+	streamSessions.Range(func(handle StreamHandle, value any) bool {
+		if session, ok := value.(*StreamSession); ok && session.owner() == r {
+			sessions = append(sessions, session.info(handle))
+		}
+		return true
+	})
+	return sessions
+}
+
+// CancelStreamSessions cancels every stream session started through r, as
+// CancelStreamSession does, and returns the errors of the sessions that failed
+// to cancel.
+func (r *ServerRegistry) CancelStreamSessions(ctx context.Context) error {
+	var errs []error
+	for _, session := range r.ListStreamSessions() {
+		if err := CancelStreamSession(ctx, session.Handle); err != nil && !errors.Is(err, ErrStreamInvalidHandle) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NewServerRegistryHandle shares registry with a C caller. The handle keeps
+// registry reachable until ReleaseServerRegistryHandle.
+func NewServerRegistryHandle(registry *ServerRegistry) (ServerRegistryHandle, error) {
+	if registry == nil {
+		return 0, errNilServerRegistry
+	}
+	return serverRegistryEntries.create(registry)
+}
+
+// LoadServerRegistryHandle returns the registry shared under handle.
+func LoadServerRegistryHandle(handle ServerRegistryHandle) (*ServerRegistry, error) {
+	registry, ok := serverRegistryEntries.load(handle)
+	if !ok {
+		return nil, ErrServerRegistryInvalidHandle
+	}
+	return registry, nil
+}
+
+// ReleaseServerRegistryHandle forgets handle. The registry keeps its servers
+// and stream sessions; call options that selected it keep using it.
+func ReleaseServerRegistryHandle(handle ServerRegistryHandle) error {
+	if _, ok := serverRegistryEntries.take(handle); !ok {
+		return ErrServerRegistryInvalidHandle
+	}
+	return nil
+}
+
+func (s *StreamSession) owner() *ServerRegistry {
+	if s.registry == nil {
+		return &defaultServerRegistry
+	}
+	return s.registry
+}
+
+func (r *serverRegistryHandles) create(registry *ServerRegistry) (ServerRegistryHandle, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.entries == nil {
+		r.entries = make(map[ServerRegistryHandle]*ServerRegistry)
+	}
+	next := r.next
+	for scanned := 0; scanned < 1<<31-1; scanned++ {
+		next++
+		if next <= 0 {
+			next = 1
+		}
+		if _, exists := r.entries[next]; exists {
+			continue
+		}
+		r.next = next
+		r.entries[next] = registry
+		return next, nil
+	}
+	return 0, errors.New("server registry handle space exhausted")
+}
+
+func (r *serverRegistryHandles) load(handle ServerRegistryHandle) (*ServerRegistry, bool) {
+	if handle == 0 {
+		return nil, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	registry, ok := r.entries[handle]
+	return registry, ok
+}
+
+func (r *serverRegistryHandles) take(handle ServerRegistryHandle) (*ServerRegistry, bool) {
+	if handle == 0 {
+		return nil, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	registry, ok := r.entries[handle]
+	if ok {
+		delete(r.entries, handle)
+	}
+	return registry, ok
+}
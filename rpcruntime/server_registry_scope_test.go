@@ -0,0 +1,139 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestServerRegistryFromContextSelectsScopedRegistry(t *testing.T) {
+	const serviceID ServiceID = "rpccgo.test.v1.ScopedGreeter"
+	scoped := NewServerRegistry()
+	server := RegisteredServer{Kind: ServerKindGoNative, Server: testRegisteredServer{name: "scoped"}}
+	if err := scoped.Register(serviceID, server); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+
+	if got := ServerRegistryFromContext(context.Background()); got != DefaultServerRegistry() {
+		t.Fatal("expected a bare context to select the default registry")
+	}
+	if got := ServerRegistryFromContext(WithServerRegistry(context.Background(), nil)); got != DefaultServerRegistry() {
+		t.Fatal("expected a nil registry to select the default registry")
+	}
+
+	ctx := WithServerRegistry(context.Background(), scoped)
+	var routed RegisteredServer
+	err := ServerRegistryFromContext(ctx).RouteCall(serviceID, func(registered RegisteredServer) error {
+		routed = registered
+		return nil
+	})
+	if err != nil || routed != server {
+		t.Fatalf("scoped RouteCall = %#v, %v; want %#v", routed, err, server)
+	}
+	if err := RouteCall(serviceID, func(RegisteredServer) error { return nil }); !errors.Is(err, ErrNoRegisteredServer) {
+		t.Fatalf("default RouteCall returned %v, want ErrNoRegisteredServer", err)
+	}
+}
+
+func TestStreamSessionsBelongToTheRegistryThatStartedThem(t *testing.T) {
+	ResetStreamSessionsForTesting()
+	t.Cleanup(ResetStreamSessionsForTesting)
+
+	scoped := NewServerRegistry()
+	scopedCtx := WithServerRegistry(context.Background(), scoped)
+	scopedStream := &cancelableTestStream{}
+	scopedHandle, err := CreateStreamSessionContext(scopedCtx, ServerKindConnect, scopedStream)
+	if err != nil {
+		t.Fatalf("CreateStreamSessionContext returned error: %v", err)
+	}
+	defaultHandle, err := CreateStreamSession(ServerKindConnect, &cancelableTestStream{})
+	if err != nil {
+		t.Fatalf("CreateStreamSession returned error: %v", err)
+	}
+
+	if _, err := LoadStreamSessionContext(context.Background(), scopedHandle); !errors.Is(err, ErrStreamInvalidHandle) {
+		t.Fatalf("default-context load of scoped handle returned %v, want ErrStreamInvalidHandle", err)
+	}
+	if _, err := LoadStreamSessionContext(scopedCtx, defaultHandle); !errors.Is(err, ErrStreamInvalidHandle) {
+		t.Fatalf("scoped load of default handle returned %v, want ErrStreamInvalidHandle", err)
+	}
+	if _, err := LoadStreamSessionContext(scopedCtx, scopedHandle); err != nil {
+		t.Fatalf("scoped load returned error: %v", err)
+	}
+
+	if sessions := scoped.ListStreamSessions(); len(sessions) != 1 || sessions[0].Handle != scopedHandle {
+		t.Fatalf("scoped ListStreamSessions = %+v, want handle %d", sessions, scopedHandle)
+	}
+	if sessions := DefaultServerRegistry().ListStreamSessions(); len(sessions) != 1 || sessions[0].Handle != defaultHandle {
+		t.Fatalf("default ListStreamSessions = %+v, want handle %d", sessions, defaultHandle)
+	}
+
+	if err := scoped.CancelStreamSessions(context.Background()); err != nil {
+		t.Fatalf("CancelStreamSessions returned error: %v", err)
+	}
+	if scopedStream.canceled.Load() != 1 {
+		t.Fatal("expected the scoped stream to be canceled")
+	}
+	if _, err := LoadStreamSession(defaultHandle); err != nil {
+		t.Fatalf("default stream was affected by scoped cancel: %v", err)
+	}
+}
+
+func TestServerRegistryHandleLifecycle(t *testing.T) {
+	scoped := NewServerRegistry()
+	handle, err := NewServerRegistryHandle(scoped)
+	if err != nil {
+		t.Fatalf("NewServerRegistryHandle returned error: %v", err)
+	}
+	if got, err := LoadServerRegistryHandle(handle); err != nil || got != scoped {
+		t.Fatalf("LoadServerRegistryHandle = %p, %v; want %p", got, err, scoped)
+	}
+	if err := ReleaseServerRegistryHandle(handle); err != nil {
+		t.Fatalf("ReleaseServerRegistryHandle returned error: %v", err)
+	}
+	if _, err := LoadServerRegistryHandle(handle); !errors.Is(err, ErrServerRegistryInvalidHandle) {
+		t.Fatalf("load after release returned %v, want ErrServerRegistryInvalidHandle", err)
+	}
+	if err := ReleaseServerRegistryHandle(handle); !errors.Is(err, ErrServerRegistryInvalidHandle) {
+		t.Fatalf("second release returned %v, want ErrServerRegistryInvalidHandle", err)
+	}
+	if _, err := NewServerRegistryHandle(nil); err == nil {
+		t.Fatal("NewServerRegistryHandle accepted a nil registry")
+	}
+}
+
+func TestCallOptionsContextSelectsServerRegistry(t *testing.T) {
+	handle, err := NewCallOptions(0)
+	if err != nil {
+		t.Fatalf("NewCallOptions returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = ReleaseCallOptions(handle) })
+
+	scoped := NewServerRegistry()
+	if err := SetCallOptionsServerRegistry(handle, scoped); err != nil {
+		t.Fatalf("SetCallOptionsServerRegistry returned error: %v", err)
+	}
+	ctx, cancel, err := CallOptionsContext(handle)
+	if err != nil {
+		t.Fatalf("CallOptionsContext returned error: %v", err)
+	}
+	defer cancel()
+	if ServerRegistryFromContext(ctx) != scoped {
+		t.Fatal("expected call options context to select the scoped registry")
+	}
+
+	if err := SetCallOptionsServerRegistry(handle, nil); err != nil {
+		t.Fatalf("SetCallOptionsServerRegistry returned error: %v", err)
+	}
+	ctx, cancel, err = CallOptionsContext(handle)
+	if err != nil {
+		t.Fatalf("CallOptionsContext returned error: %v", err)
+	}
+	defer cancel()
+	if ServerRegistryFromContext(ctx) != DefaultServerRegistry() {
+		t.Fatal("expected cleared call options registry to select the default registry")
+	}
+	if err := SetCallOptionsServerRegistry(0, scoped); !errors.Is(err, ErrCallOptionsInvalidHandle) {
+		t.Fatalf("SetCallOptionsServerRegistry on invalid handle returned %v", err)
+	}
+}
@@ -0,0 +1,235 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+)
+
+// RoutingPolicy selects which servers of a route receive a call.
+type RoutingPolicy int
+
+const (
+	// RoutingFailover sends every call to the first target and moves on to the
+	// next target when a call fails with one of the route's failover codes.
+	RoutingFailover RoutingPolicy = iota
+	// RoutingRoundRobin spreads calls across the targets in proportion to their
+	// weights. A call is tried on one target only.
+	RoutingRoundRobin
+)
+
+var (
+	ErrInvalidRoutingPolicy = errors.New("server registry requires valid routing policy")
+	ErrEmptyServerRoute     = errors.New("server registry requires at least one route target")
+)
+
+// ServerRoute is the ordered set of servers registered for one service and the
+// policy that picks among them for each unary invoke or stream Start. A stream
+// stays on the server chosen at Start.
+type ServerRoute struct {
+	Policy  RoutingPolicy
+	Targets []RouteTarget
+	// FailoverCodes lists the error codes that move a RoutingFailover call to
+	// the next target. Empty means ErrorCodeUnavailable.
+	FailoverCodes []ErrorCode
+}
+
+// RouteTarget is one server of a ServerRoute.
+type RouteTarget struct {
+	Server RegisteredServer
+	// Weight is the share of RoutingRoundRobin calls the target receives.
+	// Values below 1 count as 1; RoutingFailover ignores it.
+	Weight int
+}
+
+type serverRoute struct {
+	policy        RoutingPolicy
+	targets       []RouteTarget
+	failoverCodes []ErrorCode
+	totalWeight   uint64
+	next          atomic.Uint64
+}
+
+func newServerRoute(route ServerRoute) *serverRoute {
+	stored := &serverRoute{
+		policy:        route.Policy,
+		targets:       slices.Clone(route.Targets),
+		failoverCodes: slices.Clone(route.FailoverCodes),
+	}
+	if len(stored.failoverCodes) == 0 {
+		stored.failoverCodes = []ErrorCode{ErrorCodeUnavailable}
+	}
+	for i := range stored.targets {
+		stored.targets[i].Weight = max(stored.targets[i].Weight, 1)
+		stored.totalWeight += uint64(stored.targets[i].Weight)
+	}
+	return stored
+}
+
+func (r *serverRoute) pick() RegisteredServer {
+	slot := (r.next.Add(1) - 1) % r.totalWeight
+	for _, target := range r.targets {
+		if slot < uint64(target.Weight) {
+			return target.Server
+		}
+		slot -= uint64(target.Weight)
+	}
+	return r.targets[0].Server
+}
+
+// selected returns the servers one call may try, in order: a single weighted
+// pick for RoutingRoundRobin, every target for RoutingFailover.
+func (r *serverRoute) selected() []RegisteredServer {
+	if r.policy == RoutingRoundRobin {
+		return []RegisteredServer{r.pick()}
+	}
+	servers := make([]RegisteredServer, len(r.targets))
+	for i, target := range r.targets {
+		servers[i] = target.Server
+	}
+	return servers
+}
+
+// try runs call against servers until one succeeds or an error must not fail
+// over. keepsInputs reports whether a failed server left the call inputs
+// usable for the next one.
+func (r *serverRoute) try(servers []RegisteredServer, call func(RegisteredServer) error, keepsInputs func(RegisteredServer) bool) error {
+	var err error
+	for i, server := range servers {
+		err = call(server)
+		if err == nil || i == len(servers)-1 || !r.failsOver(err) || !keepsInputs(server) {
+			return err
+		}
+	}
+	return err
+}
+
+func (r *serverRoute) failsOver(err error) bool {
+	if errors.Is(err, ErrShutdown) {
+		return false
+	}
+	return slices.Contains(r.failoverCodes, ErrorCodeOf(err))
+}
+
+// keepsNativeInputs reports whether a failed attempt of call on server leaves
+// the native request inputs untouched. Native servers receive the caller's
+// RpcString and RpcBytes values themselves and may consume or release owned
+// input memory, so a native unary call never fails over past them. Other
+// servers only see a message converted from the inputs.
+func keepsNativeInputs(call CallInfo, server RegisteredServer) bool {
+	if call.Contract != CallContractNative || call.Operation != CallOperationUnary {
+		return true
+	}
+	return server.Kind != ServerKindGoNative && server.Kind != ServerKindCGONative
+}
+
+func RegisterServerRoute(serviceID ServiceID, route ServerRoute) error {
+	return defaultServerRegistry.RegisterRoute(serviceID, route)
+}
+
+func LoadServerRoute(serviceID ServiceID) (ServerRoute, error) {
+	return defaultServerRegistry.LoadRoute(serviceID)
+}
+
+// RouteCall runs call against the servers the route of serviceID selects,
+// without interceptors.
+func RouteCall(serviceID ServiceID, call func(RegisteredServer) error) error {
+	return defaultServerRegistry.RouteCall(serviceID, call)
+}
+
+// RegisterRoute replaces the route of serviceID.
+func (r *ServerRegistry) RegisterRoute(serviceID ServiceID, route ServerRoute) error {
+	if r == nil {
+		return errNilServerRegistry
+	}
+	if err := validateServiceID(serviceID); err != nil {
+		return err
+	}
+	if route.Policy != RoutingFailover && route.Policy != RoutingRoundRobin {
+		return ErrInvalidRoutingPolicy
+	}
+	if len(route.Targets) == 0 {
+		return ErrEmptyServerRoute
+	}
+	for _, target := range route.Targets {
+		if err := validateRegisteredServer(target.Server); err != nil {
+			return err
+		}
+	}
+	r.store(serviceID, newServerRoute(route))
+	return nil
+}
+
+// LoadRoute returns the route of serviceID. A server registered with Register
+// is a RoutingFailover route with a single target.
+func (r *ServerRegistry) LoadRoute(serviceID ServiceID) (ServerRoute, error) {
+	route, err := r.loadRoute(serviceID)
+	if err != nil {
+		return ServerRoute{}, err
+	}
+	return ServerRoute{
+		Policy:        route.policy,
+		Targets:       slices.Clone(route.targets),
+		FailoverCodes: slices.Clone(route.failoverCodes),
+	}, nil
+}
+
+// RouteCall runs call against the servers the route of serviceID selects and
+// returns the error of the last attempt. RoutingFailover stops at the first
+// error outside the failover codes; ErrShutdown never fails over.
+func (r *ServerRegistry) RouteCall(serviceID ServiceID, call func(RegisteredServer) error) error {
+	route, err := r.loadRoute(serviceID)
+	if err != nil {
+		return err
+	}
+	return route.try(route.selected(), call, func(RegisteredServer) bool { return true })
+}
+
+// RouteUnary runs one unary call through the interceptor chain and lets the
+// route of call.ServiceID pick the servers inside it, so failover attempts
+// count as a single intercepted call. call.Kind is set to the first server the
+// route selects. A missing route fails inside the chain like any other call
+// error, so interceptors, metrics and tracing observe it and a call after
+// Shutdown reports ErrShutdown. The generated Invoke facades use it.
+func (r *ServerRegistry) RouteUnary(ctx context.Context, call CallInfo, attempt func(context.Context, RegisteredServer) error) error {
+	route, servers, routeErr := r.selectRoute(&call)
+	return InterceptUnary(ctx, call, func(ctx context.Context, call CallInfo) error {
+		if routeErr != nil {
+			return routeErr
+		}
+		return route.try(servers, func(server RegisteredServer) error {
+			return attempt(ctx, server)
+		}, func(server RegisteredServer) bool {
+			return keepsNativeInputs(call, server)
+		})
+	})
+}
+
+// RouteStream is RouteUnary for stream Start: the Start interceptors, metrics
+// and concurrency slot cover every failover attempt once. The generated Start
+// facades use it.
+func (r *ServerRegistry) RouteStream(ctx context.Context, call CallInfo, attempt func(context.Context, RegisteredServer) error) error {
+	route, servers, routeErr := r.selectRoute(&call)
+	return InterceptStream(ctx, call, func(ctx context.Context, call CallInfo) error {
+		if routeErr != nil {
+			return routeErr
+		}
+		return route.try(servers, func(server RegisteredServer) error {
+			return attempt(ctx, server)
+		}, func(RegisteredServer) bool { return true })
+	})
+}
+
+// selectRoute picks the servers of the route of call.ServiceID and labels call
+// with the kind of the first one. The error is left for the intercepted
+// handler to return.
+func (r *ServerRegistry) selectRoute(call *CallInfo) (*serverRoute, []RegisteredServer, error) {
+	route, err := r.loadRoute(call.ServiceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	servers := route.selected()
+	call.Kind = servers[0].Kind
+	return route, servers, nil
+}
@@ -0,0 +1,267 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func testRouteTarget(name string, weight int) RouteTarget {
+	return RouteTarget{
+		Server: RegisteredServer{Kind: ServerKindGoNative, Server: testRegisteredServer{name: name}},
+		Weight: weight,
+	}
+}
+
+func routedServerNames(t *testing.T, registry *ServerRegistry, serviceID ServiceID, results map[string]error) ([]string, error) {
+	t.Helper()
+	var names []string
+	err := registry.RouteCall(serviceID, func(server RegisteredServer) error {
+		name := server.Server.(testRegisteredServer).name
+		names = append(names, name)
+		return results[name]
+	})
+	return names, err
+}
+
+func TestServerRegistryRegisterRouteValidatesTargets(t *testing.T) {
+	var registry ServerRegistry
+	const serviceID ServiceID = "rpccgo.test.v1.Greeter"
+
+	tests := map[string]struct {
+		route ServerRoute
+		want  error
+	}{
+		"no targets":     {ServerRoute{}, ErrEmptyServerRoute},
+		"unknown policy": {ServerRoute{Policy: RoutingRoundRobin + 1, Targets: []RouteTarget{testRouteTarget("a", 0)}}, ErrInvalidRoutingPolicy},
+		"invalid target": {ServerRoute{Targets: []RouteTarget{testRouteTarget("a", 0), {}}}, ErrInvalidServerKind},
+	}
+	for name, tt := range tests {
+		if err := registry.RegisterRoute(serviceID, tt.route); !errors.Is(err, tt.want) {
+			t.Fatalf("%s: RegisterRoute returned %v, want %v", name, err, tt.want)
+		}
+	}
+	if _, err := registry.LoadRoute(serviceID); !errors.Is(err, ErrNoRegisteredServer) {
+		t.Fatalf("LoadRoute after rejected routes returned %v, want ErrNoRegisteredServer", err)
+	}
+}
+
+func TestServerRegistryLoadReturnsFirstRouteTarget(t *testing.T) {
+	var registry ServerRegistry
+	const serviceID ServiceID = "rpccgo.test.v1.Greeter"
+
+	primary, fallback := testRouteTarget("primary", 0), testRouteTarget("fallback", 3)
+	if err := registry.RegisterRoute(serviceID, ServerRoute{Targets: []RouteTarget{primary, fallback}}); err != nil {
+		t.Fatalf("RegisterRoute returned error: %v", err)
+	}
+	loaded, err := registry.Load(serviceID)
+	if err != nil || loaded != primary.Server {
+		t.Fatalf("Load = %#v, %v; want %#v", loaded, err, primary.Server)
+	}
+	route, err := registry.LoadRoute(serviceID)
+	if err != nil {
+		t.Fatalf("LoadRoute returned error: %v", err)
+	}
+	primary.Weight = 1
+	want := ServerRoute{Targets: []RouteTarget{primary, fallback}, FailoverCodes: []ErrorCode{ErrorCodeUnavailable}}
+	if !reflect.DeepEqual(route, want) {
+		t.Fatalf("LoadRoute = %#v, want %#v", route, want)
+	}
+
+	if err := registry.Register(serviceID, fallback.Server); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	route, err = registry.LoadRoute(serviceID)
+	if err != nil || len(route.Targets) != 1 || route.Targets[0].Server != fallback.Server {
+		t.Fatalf("LoadRoute after Register = %#v, %v; want a single target", route, err)
+	}
+}
+
+func TestServerRegistryRouteCallFailsOverOnConfiguredCodes(t *testing.T) {
+	var registry ServerRegistry
+	const serviceID ServiceID = "rpccgo.test.v1.Greeter"
+	targets := []RouteTarget{testRouteTarget("remote", 0), testRouteTarget("local", 0)}
+	if err := registry.RegisterRoute(serviceID, ServerRoute{Targets: targets}); err != nil {
+		t.Fatalf("RegisterRoute returned error: %v", err)
+	}
+
+	names, err := routedServerNames(t, &registry, serviceID, map[string]error{
+		"remote": NewStatusError(ErrorCodeUnavailable, "unreachable"),
+	})
+	if err != nil || !reflect.DeepEqual(names, []string{"remote", "local"}) {
+		t.Fatalf("unavailable primary: calls %v, error %v; want remote then local", names, err)
+	}
+
+	notFound := NewStatusError(ErrorCodeNotFound, "missing")
+	names, err = routedServerNames(t, &registry, serviceID, map[string]error{"remote": notFound})
+	if !errors.Is(err, notFound) || !reflect.DeepEqual(names, []string{"remote"}) {
+		t.Fatalf("not found primary: calls %v, error %v; want remote only", names, err)
+	}
+
+	names, err = routedServerNames(t, &registry, serviceID, map[string]error{"remote": ErrShutdown})
+	if !errors.Is(err, ErrShutdown) || !reflect.DeepEqual(names, []string{"remote"}) {
+		t.Fatalf("shut down primary: calls %v, error %v; want remote only", names, err)
+	}
+
+	route := ServerRoute{Targets: targets, FailoverCodes: []ErrorCode{ErrorCodeNotFound}}
+	if err := registry.RegisterRoute(serviceID, route); err != nil {
+		t.Fatalf("RegisterRoute returned error: %v", err)
+	}
+	unavailable := NewStatusError(ErrorCodeUnavailable, "unreachable")
+	names, err = routedServerNames(t, &registry, serviceID, map[string]error{"remote": notFound, "local": unavailable})
+	if !errors.Is(err, unavailable) || !reflect.DeepEqual(names, []string{"remote", "local"}) {
+		t.Fatalf("custom codes: calls %v, error %v; want remote then local failing", names, err)
+	}
+}
+
+func TestServerRegistryRouteCallRoundRobinHonorsWeights(t *testing.T) {
+	var registry ServerRegistry
+	const serviceID ServiceID = "rpccgo.test.v1.Greeter"
+	route := ServerRoute{
+		Policy:  RoutingRoundRobin,
+		Targets: []RouteTarget{testRouteTarget("a", 2), testRouteTarget("b", 0)},
+	}
+	if err := registry.RegisterRoute(serviceID, route); err != nil {
+		t.Fatalf("RegisterRoute returned error: %v", err)
+	}
+
+	var got []string
+	for range 6 {
+		names, err := routedServerNames(t, &registry, serviceID, map[string]error{
+			"a": NewStatusError(ErrorCodeUnavailable, "unreachable"),
+		})
+		if len(names) != 1 || (names[0] == "a") != (err != nil) {
+			t.Fatalf("round robin calls %v, error %v; want one attempt and its error", names, err)
+		}
+		got = append(got, names...)
+	}
+	if want := []string{"a", "a", "b", "a", "a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("round robin calls = %v, want %v", got, want)
+	}
+}
+
+func TestRouteCallReportsMissingServer(t *testing.T) {
+	const serviceID ServiceID = "rpccgo.test.v1.Unrouted"
+	called := false
+	err := RouteCall(serviceID, func(RegisteredServer) error {
+		called = true
+		return nil
+	})
+	if !errors.Is(err, ErrNoRegisteredServer) || called {
+		t.Fatalf("RouteCall = %v (called %v), want ErrNoRegisteredServer", err, called)
+	}
+}
+
+func TestServerRegistryRouteUnaryInterceptsFailoverOnce(t *testing.T) {
+	ClearInterceptors()
+	t.Cleanup(ClearInterceptors)
+	var registry ServerRegistry
+	const serviceID ServiceID = "rpccgo.test.v1.Greeter"
+	remote := RouteTarget{Server: RegisteredServer{Kind: ServerKindConnectRemote, Server: testRegisteredServer{name: "remote"}}}
+	if err := registry.RegisterRoute(serviceID, ServerRoute{Targets: []RouteTarget{remote, testRouteTarget("local", 0)}}); err != nil {
+		t.Fatalf("RegisterRoute returned error: %v", err)
+	}
+	var intercepted []CallInfo
+	RegisterInterceptors(UnaryInterceptorFunc(func(next UnaryFunc) UnaryFunc {
+		return func(ctx context.Context, call CallInfo) error {
+			intercepted = append(intercepted, call)
+			return next(ctx, call)
+		}
+	}))
+
+	var names []string
+	call := CallInfo{ServiceID: serviceID, Contract: CallContractNative, Operation: CallOperationUnary}
+	err := registry.RouteUnary(context.Background(), call, func(ctx context.Context, server RegisteredServer) error {
+		name := server.Server.(testRegisteredServer).name
+		names = append(names, name)
+		if name == "remote" {
+			return NewStatusError(ErrorCodeUnavailable, "unreachable")
+		}
+		return nil
+	})
+	if err != nil || !reflect.DeepEqual(names, []string{"remote", "local"}) {
+		t.Fatalf("RouteUnary calls %v, error %v; want remote then local", names, err)
+	}
+	if len(intercepted) != 1 || intercepted[0].Kind != ServerKindConnectRemote {
+		t.Fatalf("intercepted calls = %+v, want one call with the first selected kind", intercepted)
+	}
+}
+
+func TestServerRegistryRouteReportsMissingRouteThroughInterceptors(t *testing.T) {
+	ClearInterceptors()
+	t.Cleanup(ClearInterceptors)
+	var observed []error
+	RegisterInterceptors(
+		UnaryInterceptorFunc(func(next UnaryFunc) UnaryFunc {
+			return func(ctx context.Context, call CallInfo) error {
+				err := next(ctx, call)
+				observed = append(observed, err)
+				return err
+			}
+		}),
+		StreamInterceptorFunc(func(next StreamFunc) StreamFunc {
+			return func(ctx context.Context, call CallInfo) error {
+				err := next(ctx, call)
+				observed = append(observed, err)
+				return err
+			}
+		}),
+	)
+	var registry ServerRegistry
+	const serviceID ServiceID = "rpccgo.test.v1.Unrouted"
+	attempt := func(context.Context, RegisteredServer) error {
+		t.Fatal("attempt ran without a route")
+		return nil
+	}
+
+	unaryErr := registry.RouteUnary(context.Background(), CallInfo{ServiceID: serviceID, Operation: CallOperationUnary}, attempt)
+	streamErr := registry.RouteStream(context.Background(), CallInfo{ServiceID: serviceID, Operation: CallOperationStart}, attempt)
+	if !errors.Is(unaryErr, ErrNoRegisteredServer) || !errors.Is(streamErr, ErrNoRegisteredServer) {
+		t.Fatalf("RouteUnary = %v, RouteStream = %v; want ErrNoRegisteredServer", unaryErr, streamErr)
+	}
+	if len(observed) != 2 || !errors.Is(observed[0], ErrNoRegisteredServer) || !errors.Is(observed[1], ErrNoRegisteredServer) {
+		t.Fatalf("interceptors observed %v, want both missing route failures", observed)
+	}
+
+	t.Cleanup(resetShutdown)
+	if err := Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown returned error: %v", err)
+	}
+	if err := registry.RouteUnary(context.Background(), CallInfo{ServiceID: serviceID, Operation: CallOperationUnary}, attempt); !errors.Is(err, ErrShutdown) {
+		t.Fatalf("RouteUnary after Shutdown = %v, want ErrShutdown", err)
+	}
+}
+
+func TestServerRegistryRouteUnaryKeepsNativeInputsOnOneNativeServer(t *testing.T) {
+	var registry ServerRegistry
+	const serviceID ServiceID = "rpccgo.test.v1.Greeter"
+	if err := registry.RegisterRoute(serviceID, ServerRoute{Targets: []RouteTarget{testRouteTarget("primary", 0), testRouteTarget("fallback", 0)}}); err != nil {
+		t.Fatalf("RegisterRoute returned error: %v", err)
+	}
+	unavailable := NewStatusError(ErrorCodeUnavailable, "unreachable")
+	route := func(contract CallContract) ([]string, error) {
+		var names []string
+		call := CallInfo{ServiceID: serviceID, Contract: contract, Operation: CallOperationUnary}
+		err := registry.RouteUnary(context.Background(), call, func(ctx context.Context, server RegisteredServer) error {
+			name := server.Server.(testRegisteredServer).name
+			names = append(names, name)
+			if name == "primary" {
+				return unavailable
+			}
+			return nil
+		})
+		return names, err
+	}
+
+	// The native server may already have released owned inputs.
+	names, err := route(CallContractNative)
+	if !errors.Is(err, unavailable) || !reflect.DeepEqual(names, []string{"primary"}) {
+		t.Fatalf("native contract: calls %v, error %v; want primary only", names, err)
+	}
+	// Message calls convert the request for each attempt.
+	names, err = route(CallContractMessage)
+	if err != nil || !reflect.DeepEqual(names, []string{"primary", "fallback"}) {
+		t.Fatalf("message contract: calls %v, error %v; want primary then fallback", names, err)
+	}
+}
@@ -0,0 +1,220 @@
+package rpcruntime
+
+import (
+	"errors"
+	"sync"
+)
+
+// RegistrationEvent reports that the route of a service was replaced or
+// cleared. Kinds are those of the first route target; ServerKindInvalid means
+// no server was registered. Seq increases with every change of one registry,
+// so a watcher can order events delivered from concurrent registrations.
+type RegistrationEvent struct {
+	ServiceID ServiceID
+	OldKind   ServerKind
+	NewKind   ServerKind
+	Cleared   bool
+	Seq       uint64
+}
+
+type serverWatcher struct {
+	serviceID ServiceID
+	fn        func(RegistrationEvent)
+}
+
+// WatchServers subscribes fn to registration changes of the default registry.
+func WatchServers(serviceID ServiceID, fn func(RegistrationEvent)) (cancel func()) {
+	return defaultServerRegistry.Watch(serviceID, fn)
+}
+
+// Watch subscribes fn to registration changes of serviceID, or of every
+// service when serviceID is empty. fn runs on the goroutine that registered or
+// cleared the server, after the registry lock is released, and must not block.
+// Clearing a service without a server emits nothing. The returned cancel stops
+// further deliveries and is safe to call more than once.
+func (r *ServerRegistry) Watch(serviceID ServiceID, fn func(RegistrationEvent)) (cancel func()) {
+	if r == nil || fn == nil {
+		return func() {}
+	}
+
+	r.watchMu.Lock()
+	defer r.watchMu.Unlock()
+
+	if r.watchers == nil {
+		r.watchers = make(map[uint64]serverWatcher)
+	}
+	r.nextWatch++
+	id := r.nextWatch
+	r.watchers[id] = serverWatcher{serviceID: serviceID, fn: fn}
+	return func() {
+		r.watchMu.Lock()
+		defer r.watchMu.Unlock()
+		delete(r.watchers, id)
+	}
+}
+
+func (r *ServerRegistry) nextEventLocked(serviceID ServiceID, old *serverRoute) RegistrationEvent {
+	r.seq++
+	event := RegistrationEvent{ServiceID: serviceID, Seq: r.seq}
+	if old != nil {
+		event.OldKind = old.targets[0].Server.Kind
+	}
+	return event
+}
+
+func (r *ServerRegistry) notify(event RegistrationEvent) {
+	r.watchMu.Lock()
+	var fns []func(RegistrationEvent)
+	for _, watcher := range r.watchers {
+		if watcher.serviceID == "" || watcher.serviceID == event.ServiceID {
+			fns = append(fns, watcher.fn)
+		}
+	}
+	r.watchMu.Unlock()
+
+	for _, fn := range fns {
+		fn(event)
+	}
+}
+
+// RegistrationWatchHandle identifies a registration watch created for a C
+// caller.
+type RegistrationWatchHandle int32
+
+var ErrRegistrationWatchInvalidHandle = errors.New("registration watch handle is invalid")
+
+// registrationWatch queues the deliveries of one C watch so that a single
+// goroutine runs them in order, off the registering goroutine, and delivers
+// onDone last once the watch is canceled.
+type registrationWatch struct {
+	mu     sync.Mutex
+	queue  []func()
+	closed bool
+	wake   chan struct{}
+	stop   func()
+}
+
+type registrationWatchRegistry struct {
+	mu      sync.Mutex
+	next    RegistrationWatchHandle
+	entries map[RegistrationWatchHandle]*registrationWatch
+}
+
+var registrationWatches registrationWatchRegistry
+
+// NewRegistrationWatch subscribes onEvent to registration changes of the
+// default registry, like WatchServers, for a C caller. Events are delivered
+// in order on a goroutine owned by the watch, and both callbacks receive the
+// watch handle. After
+// CancelRegistrationWatch, events already queued are delivered and onDone
+// runs once; neither callback runs after that.
+func NewRegistrationWatch(serviceID ServiceID, onEvent func(RegistrationWatchHandle, RegistrationEvent), onDone func(RegistrationWatchHandle)) (RegistrationWatchHandle, error) {
+	if onEvent == nil || onDone == nil {
+		return 0, errors.New("registration watch requires non-nil callbacks")
+	}
+	var handle RegistrationWatchHandle
+	watch := &registrationWatch{wake: make(chan struct{}, 1)}
+	watch.stop = WatchServers(serviceID, func(event RegistrationEvent) {
+		watch.push(func() { onEvent(handle, event) })
+	})
+	return watch.start(&handle, onDone)
+}
+
+// start registers watch under a new handle, stored in *handle before any
+// delivery runs, and starts its delivery goroutine.
+func (w *registrationWatch) start(handle *RegistrationWatchHandle, onDone func(RegistrationWatchHandle)) (RegistrationWatchHandle, error) {
+	created, err := registrationWatches.create(w)
+	if err != nil {
+		w.stop()
+		return 0, err
+	}
+	*handle = created
+	go w.deliver(created, onDone)
+	return created, nil
+}
+
+// CancelRegistrationWatch stops a watch created by NewRegistrationWatch and
+// releases its handle.
+func CancelRegistrationWatch(handle RegistrationWatchHandle) error {
+	watch, ok := registrationWatches.take(handle)
+	if !ok {
+		return ErrRegistrationWatchInvalidHandle
+	}
+	watch.stop()
+	watch.mu.Lock()
+	watch.closed = true
+	watch.mu.Unlock()
+	watch.signal()
+	return nil
+}
+
+func (w *registrationWatch) push(delivery func()) {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return
+	}
+	w.queue = append(w.queue, delivery)
+	w.mu.Unlock()
+	w.signal()
+}
+
+func (w *registrationWatch) signal() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (w *registrationWatch) deliver(handle RegistrationWatchHandle, onDone func(RegistrationWatchHandle)) {
+	for range w.wake {
+		w.mu.Lock()
+		deliveries, closed := w.queue, w.closed
+		w.queue = nil
+		w.mu.Unlock()
+
+		for _, delivery := range deliveries {
+			delivery()
+		}
+		if closed {
+			onDone(handle)
+			return
+		}
+	}
+}
+
+func (r *registrationWatchRegistry) create(watch *registrationWatch) (RegistrationWatchHandle, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.entries == nil {
+		r.entries = make(map[RegistrationWatchHandle]*registrationWatch)
+	}
+	next := r.next
+	for scanned := 0; scanned < 1<<31-1; scanned++ {
+		next++
+		if next <= 0 {
+			next = 1
+		}
+		if _, exists := r.entries[next]; exists {
+			continue
+		}
+		r.next = next
+		r.entries[next] = watch
+		return next, nil
+	}
+	return 0, errors.New("registration watch handle space exhausted")
+}
+
+func (r *registrationWatchRegistry) take(handle RegistrationWatchHandle) (*registrationWatch, bool) {
+	if handle == 0 {
+		return nil, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	watch, ok := r.entries[handle]
+	if ok {
+		delete(r.entries, handle)
+	}
+	return watch, ok
+}
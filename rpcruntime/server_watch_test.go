@@ -0,0 +1,126 @@
+package rpcruntime
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestServerRegistryWatchReportsReplaceAndClear(t *testing.T) {
+	var registry ServerRegistry
+	const serviceID ServiceID = "rpccgo.test.v1.Greeter"
+	const otherID ServiceID = "rpccgo.test.v1.Other"
+
+	var service, all []RegistrationEvent
+	cancelService := registry.Watch(serviceID, func(event RegistrationEvent) { service = append(service, event) })
+	cancelAll := registry.Watch("", func(event RegistrationEvent) { all = append(all, event) })
+	defer cancelAll()
+
+	native := RegisteredServer{Kind: ServerKindGoNative, Server: testRegisteredServer{name: "native"}}
+	remote := RegisteredServer{Kind: ServerKindConnectRemote, Server: testRegisteredServer{name: "remote"}}
+	if err := registry.Register(serviceID, native); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	if err := registry.RegisterRoute(serviceID, ServerRoute{Targets: []RouteTarget{{Server: remote}, {Server: native}}}); err != nil {
+		t.Fatalf("RegisterRoute returned error: %v", err)
+	}
+	if err := registry.Register(otherID, native); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	if err := registry.Clear(serviceID); err != nil {
+		t.Fatalf("Clear returned error: %v", err)
+	}
+	if err := registry.Clear(serviceID); err != nil {
+		t.Fatalf("second Clear returned error: %v", err)
+	}
+
+	want := []RegistrationEvent{
+		{ServiceID: serviceID, NewKind: ServerKindGoNative, Seq: 1},
+		{ServiceID: serviceID, OldKind: ServerKindGoNative, NewKind: ServerKindConnectRemote, Seq: 2},
+		{ServiceID: serviceID, OldKind: ServerKindConnectRemote, Cleared: true, Seq: 4},
+	}
+	if !reflect.DeepEqual(service, want) {
+		t.Fatalf("service events = %#v, want %#v", service, want)
+	}
+	if len(all) != 4 || all[2] != (RegistrationEvent{ServiceID: otherID, NewKind: ServerKindGoNative, Seq: 3}) {
+		t.Fatalf("all-service events = %#v, want the other service registration third", all)
+	}
+
+	cancelService()
+	cancelService()
+	if err := registry.Register(serviceID, native); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	if len(service) != len(want) || len(all) != 5 {
+		t.Fatalf("events after cancel: service %d, all %d; want %d and 5", len(service), len(all), len(want))
+	}
+}
+
+func TestServerRegistryWatchSkipsRejectedRegistrations(t *testing.T) {
+	var registry ServerRegistry
+	called := false
+	defer registry.Watch("", func(RegistrationEvent) { called = true })()
+
+	if err := registry.Register("rpccgo.test.v1.Greeter", RegisteredServer{}); err == nil {
+		t.Fatal("Register accepted an invalid server")
+	}
+	if err := registry.RegisterRoute("rpccgo.test.v1.Greeter", ServerRoute{}); err == nil {
+		t.Fatal("RegisterRoute accepted an empty route")
+	}
+	if called {
+		t.Fatal("watcher observed a rejected registration")
+	}
+}
+
+func TestServerRegistryWatchCanReenterRegistry(t *testing.T) {
+	var registry ServerRegistry
+	const serviceID ServiceID = "rpccgo.test.v1.Greeter"
+	var loaded RegisteredServer
+	defer registry.Watch(serviceID, func(RegistrationEvent) {
+		loaded, _ = registry.Load(serviceID)
+	})()
+
+	server := RegisteredServer{Kind: ServerKindGoNative, Server: testRegisteredServer{name: "native"}}
+	if err := registry.Register(serviceID, server); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	if loaded != server {
+		t.Fatalf("watcher loaded %#v, want %#v", loaded, server)
+	}
+}
+
+func TestRegistrationWatchDeliversInOrderThenDone(t *testing.T) {
+	const serviceID ServiceID = "rpccgo.test.v1.WatchedGreeter"
+	t.Cleanup(func() { _ = ClearServer(serviceID) })
+
+	events := make(chan RegistrationEvent, 4)
+	done := make(chan struct{})
+	handle, err := NewRegistrationWatch(serviceID, func(_ RegistrationWatchHandle, event RegistrationEvent) { events <- event }, func(RegistrationWatchHandle) { close(done) })
+	if err != nil {
+		t.Fatalf("NewRegistrationWatch returned error: %v", err)
+	}
+
+	server := RegisteredServer{Kind: ServerKindCGOMessage, Server: testRegisteredServer{name: "message"}}
+	if err := RegisterServer(serviceID, server); err != nil {
+		t.Fatalf("RegisterServer returned error: %v", err)
+	}
+	if err := ClearServer(serviceID); err != nil {
+		t.Fatalf("ClearServer returned error: %v", err)
+	}
+	if err := CancelRegistrationWatch(handle); err != nil {
+		t.Fatalf("CancelRegistrationWatch returned error: %v", err)
+	}
+	<-done
+	close(events)
+
+	var got []RegistrationEvent
+	for event := range events {
+		got = append(got, event)
+	}
+	if len(got) != 2 || got[0].NewKind != ServerKindCGOMessage || !got[1].Cleared || got[0].Seq >= got[1].Seq {
+		t.Fatalf("watch events = %#v, want register then clear", got)
+	}
+	if err := CancelRegistrationWatch(handle); !errors.Is(err, ErrRegistrationWatchInvalidHandle) {
+		t.Fatalf("second CancelRegistrationWatch returned %v, want ErrRegistrationWatchInvalidHandle", err)
+	}
+}
@@ -0,0 +1,162 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrShutdown is returned by unary invokes and stream Starts once Shutdown has
+// been called.
+var ErrShutdown = errors.New("rpccgo: runtime is shut down")
+
+// DefaultShutdownTimeout bounds the wait of the C shutdown export when the
+// caller passes no timeout, so a stuck stream cannot hang the host forever.
+const DefaultShutdownTimeout = 5 * time.Second
+
+// ShutdownError reports what was still pending when Shutdown gave up waiting.
+type ShutdownError struct {
+	// Err is the context error that ended the wait.
+	Err error
+	// PendingCalls counts unary invokes and stream Starts still running.
+	PendingCalls int64
+	// PendingStreams lists the stream sessions whose cancellation, including
+	// the onDone delivery of callback receive streams, had not completed.
+	PendingStreams []StreamSessionInfo
+}
+
+func (e *ShutdownError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "rpccgo: shutdown incomplete: %v: %d calls and %d stream sessions pending", e.Err, e.PendingCalls, len(e.PendingStreams))
+	for i, stream := range e.PendingStreams {
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "stream %d", stream.Handle)
+		if stream.Method != "" {
+			fmt.Fprintf(&b, " (%s)", stream.Method)
+		}
+	}
+	return b.String()
+}
+
+var runtimeShutdown struct {
+	closing  atomic.Bool
+	inflight atomic.Int64
+	drained  chan struct{}
+}
+
+func init() {
+	runtimeShutdown.drained = make(chan struct{}, 1)
+}
+
+// beginCall admits a unary invoke or stream Start unless the runtime is shut
+// down. Admitted calls must be paired with endCall.
+func beginCall() error {
+	runtimeShutdown.inflight.Add(1)
+	if runtimeShutdown.closing.Load() {
+		endCall()
+		return ErrShutdown
+	}
+	return nil
+}
+
+func endCall() {
+	if runtimeShutdown.inflight.Add(-1) == 0 && runtimeShutdown.closing.Load() {
+		select {
+		case runtimeShutdown.drained <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Shutdown stops the runtime for an embedded host that is going away. New
+// unary invokes and stream Starts fail with ErrShutdown from then on. Shutdown
+// stops the idle stream reaper, cancels every stream session as
+// CancelStreamSession does, including sessions started by calls already in
+// flight, and waits for in-flight calls and for callback receive streams to
+// deliver onDone.
+//
+// It returns nil once everything has drained, the joined cancellation errors
+// if some sessions failed to cancel, or a *ShutdownError describing what was
+// still pending when ctx ended. Calling Shutdown again retries the drain.
+func Shutdown(ctx context.Context) error {
+	runtimeShutdown.closing.Store(true)
+	SetStreamIdleTimeout(0)
+
+	var (
+		mu      sync.Mutex
+		pending = make(map[StreamHandle]StreamSessionInfo)
+		errs    []error
+		wg      sync.WaitGroup
+	)
+	cancelSessions := func() {
+		for _, info := range ListStreamSessions() {
+			mu.Lock()
+			_, started := pending[info.Handle]
+			pending[info.Handle] = info
+			mu.Unlock()
+			if started {
+				continue
+			}
+			wg.Add(1)
+			go func(info StreamSessionInfo) {
+				defer wg.Done()
+				err := CancelStreamSession(ctx, info.Handle)
+				mu.Lock()
+				delete(pending, info.Handle)
+				if err != nil && !errors.Is(err, ErrStreamInvalidHandle) {
+					errs = append(errs, fmt.Errorf("stream %d: %w", info.Handle, err))
+				}
+				mu.Unlock()
+			}(info)
+		}
+	}
+
+	cancelSessions()
+	for runtimeShutdown.inflight.Load() > 0 {
+		select {
+		case <-runtimeShutdown.drained:
+		case <-ctx.Done():
+			return shutdownIncomplete(ctx, &mu, pending)
+		}
+	}
+	// Starts that were in flight may have registered new sessions.
+	cancelSessions()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return shutdownIncomplete(ctx, &mu, pending)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	return errors.Join(errs...)
+}
+
+func shutdownIncomplete(ctx context.Context, mu *sync.Mutex, pending map[StreamHandle]StreamSessionInfo) error {
+	mu.Lock()
+	streams := make([]StreamSessionInfo, 0, len(pending))
+	for _, info := range pending {
+		streams = append(streams, info)
+	}
+	mu.Unlock()
+	sort.Slice(streams, func(i, j int) bool { return streams[i].Handle < streams[j].Handle })
+	return &ShutdownError{
+		Err:            ctx.Err(),
+		PendingCalls:   runtimeShutdown.inflight.Load(),
+		PendingStreams: streams,
+	}
+}
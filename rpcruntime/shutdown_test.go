@@ -0,0 +1,149 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// resetShutdown lets the runtime accept calls again after Shutdown.
+func resetShutdown() {
+	runtimeShutdown.closing.Store(false)
+}
+
+type blockingCancelStream struct {
+	release chan struct{}
+}
+
+func (s *blockingCancelStream) Cancel(context.Context) error {
+	<-s.release
+	return nil
+}
+
+func TestShutdownRejectsNewCalls(t *testing.T) {
+	ResetStreamSessionsForTesting()
+	t.Cleanup(ResetStreamSessionsForTesting)
+	t.Cleanup(resetShutdown)
+
+	if err := Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown returned error: %v", err)
+	}
+	ran := false
+	err := InterceptUnary(context.Background(), CallInfo{Method: "rpccgo.test.v1.Greeter.SayHello"}, func(context.Context, CallInfo) error {
+		ran = true
+		return nil
+	})
+	if !errors.Is(err, ErrShutdown) || ran {
+		t.Fatalf("InterceptUnary after Shutdown = %v (ran %v), want ErrShutdown", err, ran)
+	}
+	if got := ErrorCodeOf(err); got != ErrorCodeUnavailable {
+		t.Fatalf("ErrorCodeOf(ErrShutdown) = %v, want Unavailable", got)
+	}
+	call := CallInfo{Method: "rpccgo.test.v1.Greeter.Chat", Operation: CallOperationStart}
+	err = InterceptStream(context.Background(), call, func(context.Context, CallInfo) error {
+		ran = true
+		return nil
+	})
+	if !errors.Is(err, ErrShutdown) || ran {
+		t.Fatalf("InterceptStream Start after Shutdown = %v (ran %v), want ErrShutdown", err, ran)
+	}
+
+	resetShutdown()
+	if err := InterceptUnary(context.Background(), CallInfo{}, func(context.Context, CallInfo) error { return nil }); err != nil {
+		t.Fatalf("InterceptUnary after reset returned error: %v", err)
+	}
+}
+
+func TestShutdownCancelsStreamSessionsAndWaitsForDone(t *testing.T) {
+	ResetStreamSessionsForTesting()
+	t.Cleanup(ResetStreamSessionsForTesting)
+	t.Cleanup(resetShutdown)
+
+	plain := &cancelableTestStream{}
+	startStreamSession(t, CallInfo{}, ServerKindGoNative, plain)
+	callbackSession := &cancelableTestStream{}
+	handle := startStreamSession(t, CallInfo{}, ServerKindCGONative, callbackSession)
+	state, err := EnableStreamCallbackReceive(handle)
+	if err != nil {
+		t.Fatalf("EnableStreamCallbackReceive returned error: %v", err)
+	}
+	go func() {
+		for callbackSession.canceled.Load() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		time.Sleep(10 * time.Millisecond)
+		if state.BeginDoneCallback() {
+			state.EndDoneCallback()
+		}
+	}()
+
+	if err := Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown returned error: %v", err)
+	}
+	if plain.canceled.Load() != 1 || callbackSession.canceled.Load() != 1 {
+		t.Fatalf("Cancel calls = %d/%d, want 1/1", plain.canceled.Load(), callbackSession.canceled.Load())
+	}
+	if !state.done.Load() {
+		t.Fatal("Shutdown returned before onDone was delivered")
+	}
+	if sessions := ListStreamSessions(); len(sessions) != 0 {
+		t.Fatalf("sessions after Shutdown = %+v, want none", sessions)
+	}
+}
+
+func TestShutdownReportsPendingWorkWhenContextEnds(t *testing.T) {
+	ResetStreamSessionsForTesting()
+	t.Cleanup(ResetStreamSessionsForTesting)
+	t.Cleanup(resetShutdown)
+
+	stuck := &blockingCancelStream{release: make(chan struct{})}
+	call := CallInfo{Method: "rpccgo.test.v1.Greeter.Chat"}
+	handle := startStreamSession(t, call, ServerKindGoNative, stuck)
+	unaryRelease := make(chan struct{})
+	unaryDone := make(chan error, 1)
+	unaryStarted := make(chan struct{})
+	go func() {
+		unaryDone <- InterceptUnary(context.Background(), CallInfo{}, func(context.Context, CallInfo) error {
+			close(unaryStarted)
+			<-unaryRelease
+			return nil
+		})
+	}()
+	<-unaryStarted
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := Shutdown(ctx)
+	var shutdownErr *ShutdownError
+	if !errors.As(err, &shutdownErr) {
+		t.Fatalf("Shutdown error = %v, want *ShutdownError", err)
+	}
+	if !errors.Is(shutdownErr.Err, context.DeadlineExceeded) || shutdownErr.PendingCalls != 1 {
+		t.Fatalf("ShutdownError = %+v, want deadline with one pending call", shutdownErr)
+	}
+	var pending []StreamHandle
+	for _, stream := range shutdownErr.PendingStreams {
+		pending = append(pending, stream.Handle)
+	}
+	if !reflect.DeepEqual(pending, []StreamHandle{handle}) {
+		t.Fatalf("pending streams = %v, want [%d]", pending, handle)
+	}
+	if !strings.Contains(err.Error(), "rpccgo.test.v1.Greeter.Chat") {
+		t.Fatalf("error text %q does not name the pending method", err)
+	}
+
+	close(unaryRelease)
+	close(stuck.release)
+	if err := <-unaryDone; err != nil {
+		t.Fatalf("in-flight InterceptUnary returned error: %v", err)
+	}
+	if err := Shutdown(context.Background()); err != nil {
+		t.Fatalf("second Shutdown returned error: %v", err)
+	}
+	if sessions := ListStreamSessions(); len(sessions) != 0 {
+		t.Fatalf("sessions after Shutdown = %+v, want none", sessions)
+	}
+}
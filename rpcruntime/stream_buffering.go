@@ -0,0 +1,57 @@
+package rpcruntime
+
+import (
+	"fmt"
+	"sync"
+)
+
+// MaxStreamBuffer bounds the queue sizes accepted by SetStreamBuffering.
+const MaxStreamBuffer = 1 << 16
+
+// StreamBuffering sizes the queues of a method's in-process streams and
+// selects how Send hands values to the peer.
+type StreamBuffering struct {
+	// RequestBuffer is the number of requests queued ahead of the server.
+	RequestBuffer int
+	// ResponseBuffer is the number of responses queued ahead of the client.
+	ResponseBuffer int
+	// PipelinedSend lets Send return once the value is queued, so a sender
+	// only blocks when the buffer is full. Errors the peer reports afterwards
+	// surface on the next Send, Recv or Finish. Native requests, which borrow
+	// caller memory, are still handed off one at a time.
+	PipelinedSend bool
+}
+
+var streamBufferingOverrides sync.Map
+
+// SetStreamBuffering overrides the generated stream buffering of method, a
+// full protobuf method name such as "greeter.v1.Greeter.Chat". Streams started
+// afterwards use it; open streams keep their queues.
+func SetStreamBuffering(method string, buffering StreamBuffering) error {
+	if method == "" {
+		return fmt.Errorf("rpccgo: stream buffering method is empty")
+	}
+	if buffering.RequestBuffer < 0 || buffering.RequestBuffer > MaxStreamBuffer {
+		return fmt.Errorf("rpccgo: stream request buffer %d out of range [0, %d]", buffering.RequestBuffer, MaxStreamBuffer)
+	}
+	if buffering.ResponseBuffer < 0 || buffering.ResponseBuffer > MaxStreamBuffer {
+		return fmt.Errorf("rpccgo: stream response buffer %d out of range [0, %d]", buffering.ResponseBuffer, MaxStreamBuffer)
+	}
+	streamBufferingOverrides.Store(method, buffering)
+	return nil
+}
+
+// ClearStreamBuffering removes the override of method, restoring the
+// generated buffering for streams started afterwards.
+func ClearStreamBuffering(method string) {
+	streamBufferingOverrides.Delete(method)
+}
+
+// StreamBufferingFor returns the override registered for method, if any.
+func StreamBufferingFor(method string) (StreamBuffering, bool) {
+	value, ok := streamBufferingOverrides.Load(method)
+	if !ok {
+		return StreamBuffering{}, false
+	}
+	return value.(StreamBuffering), true
+}
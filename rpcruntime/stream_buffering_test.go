@@ -0,0 +1,124 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func sendWithTimeout(send func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	return send(ctx)
+}
+
+func TestPipelinedClientStreamQueuesSendsWithinBuffer(t *testing.T) {
+	client, server, streamCtx := NewClientStreaming[int, string](context.Background(), LocalStreamOptions{
+		RequestBuffer: 3,
+		PipelinedSend: true,
+	})
+	for i := range 3 {
+		if err := sendWithTimeout(func(ctx context.Context) error { return client.Send(ctx, i) }); err != nil {
+			t.Fatalf("Send(%d) error = %v, want queued without a receiver", i, err)
+		}
+	}
+	if err := sendWithTimeout(func(ctx context.Context) error { return client.Send(ctx, 3) }); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Send beyond the buffer error = %v, want deadline exceeded", err)
+	}
+
+	finished := make(chan error, 1)
+	go func() {
+		_, err := client.Finish(context.Background())
+		finished <- err
+	}()
+	for want := range 3 {
+		got, err := server.Recv(streamCtx)
+		if err != nil || got != want {
+			t.Fatalf("Recv() = %d, %v; want %d", got, err, want)
+		}
+	}
+	if _, err := server.Recv(streamCtx); !errors.Is(err, io.EOF) {
+		t.Fatalf("Recv() after queued requests error = %v, want EOF", err)
+	}
+	server.Complete("done", nil)
+	assertLocalStreamCompletes(t, finished)
+}
+
+func TestStrictSendWaitsForReceiverByDefault(t *testing.T) {
+	for name, options := range map[string]LocalStreamOptions{
+		"default":  {RequestBuffer: 3},
+		"borrowed": {RequestBuffer: 3, PipelinedSend: true, BorrowedRequests: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client, _, _ := NewBidiStreaming[int, int](context.Background(), options)
+			if err := sendWithTimeout(func(ctx context.Context) error { return client.Send(ctx, 1) }); !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("Send() error = %v, want a strict handoff", err)
+			}
+		})
+	}
+}
+
+func TestPipelinedServerStreamDeliversQueuedResponsesBeforeEOF(t *testing.T) {
+	client, server, streamCtx := NewServerStreaming[int](context.Background(), LocalStreamOptions{
+		ResponseBuffer: 2,
+		PipelinedSend:  true,
+	})
+	for i := range 2 {
+		if err := server.Send(streamCtx, i); err != nil {
+			t.Fatalf("Send(%d) error = %v", i, err)
+		}
+	}
+	server.Complete(nil)
+
+	for want := range 2 {
+		got, err := client.Recv(context.Background())
+		if err != nil || got != want {
+			t.Fatalf("Recv() = %d, %v; want %d", got, err, want)
+		}
+	}
+	if _, err := client.Recv(context.Background()); !errors.Is(err, io.EOF) {
+		t.Fatalf("Recv() after completion error = %v, want EOF", err)
+	}
+}
+
+func TestStreamBufferingOverrideAppliesToNewStreams(t *testing.T) {
+	const method = "test.v1.Greeter.Upload"
+	t.Cleanup(func() { ClearStreamBuffering(method) })
+
+	if err := SetStreamBuffering(method, StreamBuffering{RequestBuffer: -1}); err == nil {
+		t.Fatal("expected a negative buffer to be rejected")
+	}
+	if err := SetStreamBuffering(method, StreamBuffering{ResponseBuffer: MaxStreamBuffer + 1}); err == nil {
+		t.Fatal("expected an oversized buffer to be rejected")
+	}
+	if err := SetStreamBuffering("", StreamBuffering{}); err == nil {
+		t.Fatal("expected an empty method to be rejected")
+	}
+	if err := SetStreamBuffering(method, StreamBuffering{RequestBuffer: 2, PipelinedSend: true}); err != nil {
+		t.Fatalf("SetStreamBuffering() error = %v", err)
+	}
+	if got, ok := StreamBufferingFor(method); !ok || got.RequestBuffer != 2 || !got.PipelinedSend {
+		t.Fatalf("StreamBufferingFor() = %+v, %v", got, ok)
+	}
+
+	client, _, _ := NewClientStreaming[int, string](context.Background(), LocalStreamOptions{Method: method, RequestBuffer: 16})
+	for i := range 2 {
+		if err := sendWithTimeout(func(ctx context.Context) error { return client.Send(ctx, i) }); err != nil {
+			t.Fatalf("Send(%d) error = %v, want the override to pipeline", i, err)
+		}
+	}
+	if err := sendWithTimeout(func(ctx context.Context) error { return client.Send(ctx, 2) }); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Send beyond the overridden buffer error = %v, want deadline exceeded", err)
+	}
+
+	ClearStreamBuffering(method)
+	if _, ok := StreamBufferingFor(method); ok {
+		t.Fatal("expected the override to be cleared")
+	}
+	client, _, _ = NewClientStreaming[int, string](context.Background(), LocalStreamOptions{Method: method, RequestBuffer: 16})
+	if err := sendWithTimeout(func(ctx context.Context) error { return client.Send(ctx, 0) }); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Send() after clear error = %v, want the generated strict handoff", err)
+	}
+}
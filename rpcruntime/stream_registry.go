@@ -3,6 +3,7 @@ package rpcruntime
 import (
 	"errors"
 	"reflect"
+	"sort"
 	"sync"
 )
 
@@ -88,6 +89,27 @@ func (r *StreamRegistry) Take(handle StreamHandle) (any, bool) {
 	return session, true
 }
 
+// Range calls fn for each session registered when Range was called, in
+// handle order, until fn returns false. fn runs without the registry lock, so
+// it may create, load or remove sessions.
+func (r *StreamRegistry) Range(fn func(handle StreamHandle, session any) bool) {
+	r.mu.Lock()
+	handles := make([]StreamHandle, 0, len(r.entries))
+	sessions := make(map[StreamHandle]any, len(r.entries))
+	for handle, session := range r.entries {
+		handles = append(handles, handle)
+		sessions[handle] = session
+	}
+	r.mu.Unlock()
+
+	sort.Slice(handles, func(i, j int) bool { return handles[i] < handles[j] })
+	for _, handle := range handles {
+		if !fn(handle, sessions[handle]) {
+			return
+		}
+	}
+}
+
 func (r *StreamRegistry) allocateLocked() (StreamHandle, error) {
 	limit := r.maxHandle()
 	if limit <= 0 {
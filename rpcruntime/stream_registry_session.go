@@ -1,7 +1,9 @@
 package rpcruntime
 
 import (
+	"context"
 	"sync/atomic"
+	"time"
 )
 
 var streamSessions StreamRegistry
@@ -19,24 +21,56 @@ type StreamSession struct {
 	doneCallbackStarted    atomic.Bool
 	activeCallbacks        atomic.Int32
 	stateChanged           chan struct{}
+
+	registry     *ServerRegistry
+	observation  *streamObservation
+	startedAt    time.Time
+	lastActivity atomic.Int64
+	activeOps    atomic.Int32
 }
 
 func newStreamSession(kind ServerKind, session any) *StreamSession {
-	return &StreamSession{
+	s := &StreamSession{
 		Kind:         kind,
 		Session:      session,
 		stateChanged: make(chan struct{}, 1),
+		startedAt:    time.Now(),
 	}
+	s.lastActivity.Store(s.startedAt.UnixNano())
+	return s
 }
 
 func CreateStreamSession(kind ServerKind, session any) (StreamHandle, error) {
+	return CreateStreamSessionContext(context.Background(), kind, session)
+}
+
+// CreateStreamSessionContext registers session like CreateStreamSession, owned
+// by the server registry ctx selects. When ctx comes from an intercepted stream
+// Start, the session takes over the Start metrics and span, which then end when
+// the session is removed.
+func CreateStreamSessionContext(ctx context.Context, kind ServerKind, session any) (StreamHandle, error) {
 	if kind <= ServerKindInvalid || kind > ServerKindGRPCRemote {
 		return 0, ErrInvalidServerKind
 	}
 	if !hasNonZeroSession(session) {
 		return 0, errStreamRegistryZeroSession
 	}
-	return streamSessions.Create(newStreamSession(kind, session))
+	entry := newStreamSession(kind, session)
+	entry.registry = ServerRegistryFromContext(ctx)
+	if observation, _ := ctx.Value(streamObservationKey{}).(*streamObservation); observation.claim() {
+		entry.observation = observation
+		observation.metrics.activeStreams.Add(1)
+	}
+	handle, err := streamSessions.Create(entry)
+	if err != nil {
+		if observation := entry.observation; observation != nil {
+			// Hand the observation back so the failed Start ends it.
+			observation.metrics.activeStreams.Add(-1)
+			observation.claimed.Store(false)
+		}
+		return 0, err
+	}
+	return handle, nil
 }
 
 // LoadStreamSession returns the active stream session without removing its handle.
@@ -106,6 +140,10 @@ func (s *StreamSession) BeginCallback() bool {
 		}
 		return false
 	}
+	if s.observation != nil {
+		s.observation.metrics.callbackDeliveries.Add(1)
+	}
+	s.touch()
 	return true
 }
 
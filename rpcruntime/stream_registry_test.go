@@ -456,3 +456,37 @@ func TestStreamRegistryConcurrentCreateReturnsUniqueNonZeroInt32Handles(t *testi
 		t.Fatalf("Create returned %d handles, want %d", got, want)
 	}
 }
+
+func TestStreamRegistryRangeVisitsEntriesInHandleOrder(t *testing.T) {
+	var registry StreamRegistry
+	var want []StreamHandle
+	for _, name := range []string{"a", "b", "c"} {
+		handle, err := registry.Create(testStreamSession{name: name})
+		if err != nil {
+			t.Fatalf("Create returned error: %v", err)
+		}
+		want = append(want, handle)
+	}
+	registry.Delete(want[1])
+	want = append(want[:1], want[2])
+
+	var got []StreamHandle
+	registry.Range(func(handle StreamHandle, _ any) bool {
+		got = append(got, handle)
+		// Range must not hold the lock while fn runs.
+		registry.Load(handle)
+		return true
+	})
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("Range visited %v, want %v", got, want)
+	}
+
+	calls := 0
+	registry.Range(func(StreamHandle, any) bool {
+		calls++
+		return false
+	})
+	if calls != 1 {
+		t.Fatalf("Range called fn %d times after it returned false, want 1", calls)
+	}
+}
@@ -0,0 +1,209 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// StreamSessionInfo describes one active stream session.
+//
+// ServiceID, Method and Contract are empty for sessions created outside an
+// intercepted stream Start.
+type StreamSessionInfo struct {
+	Handle          StreamHandle
+	Kind            ServerKind
+	ServiceID       ServiceID
+	Method          string
+	Contract        CallContract
+	StartedAt       time.Time
+	LastActivity    time.Time
+	CallbackReceive bool
+}
+
+// touch records activity on the session.
+func (s *StreamSession) touch() {
+	s.lastActivity.Store(time.Now().UnixNano())
+}
+
+// beginOperation marks an intercepted stream operation as in flight. A session
+// with an operation in flight is never idle.
+func (s *StreamSession) beginOperation() {
+	s.activeOps.Add(1)
+	s.touch()
+}
+
+func (s *StreamSession) endOperation() {
+	s.touch()
+	s.activeOps.Add(-1)
+}
+
+func (s *StreamSession) info(handle StreamHandle) StreamSessionInfo {
+	info := StreamSessionInfo{
+		Handle:          handle,
+		Kind:            s.Kind,
+		StartedAt:       s.startedAt,
+		LastActivity:    time.Unix(0, s.lastActivity.Load()),
+		CallbackReceive: s.CallbackReceiveEnabled.Load(),
+	}
+	if s.observation != nil {
+		info.ServiceID = s.observation.call.ServiceID
+		info.Method = s.observation.call.Method
+		info.Contract = s.observation.call.Contract
+	}
+	return info
+}
+
+func (s *StreamSession) idleSince(now time.Time) time.Duration {
+	if s.activeOps.Load() > 0 {
+		return 0
+	}
+	return now.Sub(time.Unix(0, s.lastActivity.Load()))
+}
+
+// ListStreamSessions returns the active stream sessions in handle order.
+func ListStreamSessions() []StreamSessionInfo {
+	var sessions []StreamSessionInfo
+	streamSessions.Range(func(handle StreamHandle, value any) bool {
+		if session, ok := value.(*StreamSession); ok {
+			sessions = append(sessions, session.info(handle))
+		}
+		return true
+	})
+	return sessions
+}
+
+type streamSessionCanceler interface {
+	Cancel(context.Context) error
+}
+
+// CancelStreamSession cancels an active stream session of any method and
+// releases its handle, as the generated Cancel operation would. It runs through
+// the interceptor chain as a Cancel operation. A callback receive stream still
+// delivers its terminal onDone callback. The handle is released even when the
+// session fails to cancel.
+func CancelStreamSession(ctx context.Context, handle StreamHandle) error {
+	entry, err := LoadStreamSession(handle)
+	if err != nil {
+		return err
+	}
+	call := CallInfo{Kind: entry.Kind}
+	if entry.observation != nil {
+		call = entry.observation.call
+	}
+	call.Operation = CallOperationCancel
+	call.Stream = handle
+	return InterceptStream(ctx, call, func(ctx context.Context, _ CallInfo) error {
+		source, ok := entry.Session.(streamSessionCanceler)
+		if !ok {
+			return ErrStreamInvalidHandle
+		}
+		callbackReceive := entry.CallbackReceiveEnabled.Load()
+		if callbackReceive {
+			entry.MarkCanceled()
+		}
+		cancelErr := source.Cancel(ctx)
+		if callbackReceive {
+			entry.WaitDone()
+		}
+		if _, err := RemoveStreamSession(handle); err != nil && cancelErr == nil {
+			return err
+		}
+		return cancelErr
+	})
+}
+
+// CancelIdleStreamSessions cancels every stream session without activity for
+// at least idle and returns the handles it canceled. Activity is any
+// intercepted stream operation or callback receive delivery; a session with an
+// operation in flight is not idle.
+func CancelIdleStreamSessions(ctx context.Context, idle time.Duration) ([]StreamHandle, error) {
+	now := time.Now()
+	var idleHandles []StreamHandle
+	streamSessions.Range(func(handle StreamHandle, value any) bool {
+		if session, ok := value.(*StreamSession); ok && session.idleSince(now) >= idle {
+			idleHandles = append(idleHandles, handle)
+		}
+		return true
+	})
+
+	var canceled []StreamHandle
+	var errs []error
+	for _, handle := range idleHandles {
+		err := CancelStreamSession(ctx, handle)
+		switch {
+		case errors.Is(err, ErrStreamInvalidHandle):
+			// The session finished on its own in the meantime.
+		case err != nil:
+			errs = append(errs, err)
+			canceled = append(canceled, handle)
+		default:
+			canceled = append(canceled, handle)
+		}
+	}
+	return canceled, errors.Join(errs...)
+}
+
+var streamReaper struct {
+	mu   sync.Mutex
+	stop chan struct{}
+}
+
+// SetStreamIdleTimeout starts a background reaper that cancels stream sessions
+// idle for at least timeout, checking every timeout/2. A non-positive timeout
+// stops the reaper.
+func SetStreamIdleTimeout(timeout time.Duration) {
+	streamReaper.mu.Lock()
+	defer streamReaper.mu.Unlock()
+	if streamReaper.stop != nil {
+		close(streamReaper.stop)
+		streamReaper.stop = nil
+	}
+	if timeout <= 0 {
+		return
+	}
+	stop := make(chan struct{})
+	streamReaper.stop = stop
+	go reapIdleStreamSessions(timeout, stop)
+}
+
+func reapIdleStreamSessions(timeout time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(max(timeout/2, time.Millisecond))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			_, _ = CancelIdleStreamSessions(context.Background(), timeout)
+		}
+	}
+}
+
+// EncodeStreamSessions encodes sessions as a rpccgo.runtime.v1.StreamSessionList
+// protobuf message; the schema ships as stream_sessions.proto next to this
+// file.
+func EncodeStreamSessions(sessions []StreamSessionInfo) []byte {
+	var out []byte
+	for _, session := range sessions {
+		var entry []byte
+		entry = appendVarintField(entry, 1, uint64(session.Handle))
+		entry = appendVarintField(entry, 2, uint64(session.Kind))
+		entry = appendStringField(entry, 3, string(session.ServiceID))
+		entry = appendStringField(entry, 4, session.Method)
+		entry = appendVarintField(entry, 5, uint64(session.Contract))
+		entry = appendVarintField(entry, 6, uint64(session.StartedAt.UnixMilli()))
+		entry = appendVarintField(entry, 7, uint64(session.LastActivity.UnixMilli()))
+		entry = appendBoolField(entry, 8, session.CallbackReceive)
+		out = appendMessageField(out, 1, entry)
+	}
+	return out
+}
+
+// EncodePinnedStreamSessions encodes the active stream sessions into a pinned
+// ptr/len payload for the C ABI. Callers must release a non-zero pointer with
+// Release after the ABI consumer is done with it.
+func EncodePinnedStreamSessions() (uintptr, int32, error) {
+	return pinPayload(EncodeStreamSessions(ListStreamSessions()))
+}
@@ -0,0 +1,264 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ygrpc/rpccgo/rpcruntime/internal/runtimev1"
+)
+
+type cancelableTestStream struct {
+	canceled atomic.Int32
+	err      error
+}
+
+func (s *cancelableTestStream) Cancel(context.Context) error {
+	s.canceled.Add(1)
+	return s.err
+}
+
+func startStreamSession(t *testing.T, call CallInfo, kind ServerKind, session any) StreamHandle {
+	t.Helper()
+	var handle StreamHandle
+	call.Operation = CallOperationStart
+	err := InterceptStream(context.Background(), call, func(ctx context.Context, _ CallInfo) error {
+		var err error
+		handle, err = CreateStreamSessionContext(ctx, kind, session)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("InterceptStream Start returned error: %v", err)
+	}
+	return handle
+}
+
+func TestListStreamSessionsDescribesActiveSessions(t *testing.T) {
+	ResetStreamSessionsForTesting()
+	t.Cleanup(ResetStreamSessionsForTesting)
+
+	before := time.Now()
+	call := CallInfo{
+		ServiceID: "rpccgo.test.v1.Greeter",
+		Method:    "rpccgo.test.v1.Greeter.Chat",
+		Contract:  CallContractMessage,
+		Kind:      ServerKindConnect,
+	}
+	traced := startStreamSession(t, call, ServerKindConnect, &cancelableTestStream{})
+	bare, err := CreateStreamSession(ServerKindGoNative, &cancelableTestStream{})
+	if err != nil {
+		t.Fatalf("CreateStreamSession returned error: %v", err)
+	}
+	if _, err := EnableStreamCallbackReceive(bare); err != nil {
+		t.Fatalf("EnableStreamCallbackReceive returned error: %v", err)
+	}
+
+	sessions := ListStreamSessions()
+	if len(sessions) != 2 || sessions[0].Handle != traced || sessions[1].Handle != bare {
+		t.Fatalf("ListStreamSessions = %+v, want handles %d and %d", sessions, traced, bare)
+	}
+	got := sessions[0]
+	if got.Kind != ServerKindConnect || got.ServiceID != call.ServiceID || got.Method != call.Method || got.Contract != CallContractMessage {
+		t.Fatalf("traced session = %+v, want call %+v", got, call)
+	}
+	if got.StartedAt.Before(before) || got.LastActivity.Before(got.StartedAt) || got.CallbackReceive {
+		t.Fatalf("traced session times/callback = %+v", got)
+	}
+	if got := sessions[1]; got.Kind != ServerKindGoNative || got.Method != "" || !got.CallbackReceive {
+		t.Fatalf("bare session = %+v", got)
+	}
+}
+
+func TestStreamOperationsRecordActivity(t *testing.T) {
+	ResetStreamSessionsForTesting()
+	t.Cleanup(ResetStreamSessionsForTesting)
+
+	call := CallInfo{Method: "rpccgo.test.v1.Greeter.Chat"}
+	handle := startStreamSession(t, call, ServerKindGoNative, &cancelableTestStream{})
+	entry, err := LoadStreamSession(handle)
+	if err != nil {
+		t.Fatalf("LoadStreamSession returned error: %v", err)
+	}
+	stale := time.Now().Add(-time.Minute)
+	entry.lastActivity.Store(stale.UnixNano())
+
+	err = runTracedStreamOperation(call, CallOperationSend, handle, false, nil)
+	if err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	if got := ListStreamSessions()[0].LastActivity; !got.After(stale) {
+		t.Fatalf("LastActivity = %v, want after %v", got, stale)
+	}
+
+	entry.lastActivity.Store(stale.UnixNano())
+	call.Operation = CallOperationRecv
+	call.Stream = handle
+	_ = InterceptStream(context.Background(), call, func(context.Context, CallInfo) error {
+		if idle := entry.idleSince(time.Now()); idle != 0 {
+			t.Errorf("session with an operation in flight is idle for %v", idle)
+		}
+		return nil
+	})
+}
+
+func TestCancelStreamSessionCancelsAndReleasesHandle(t *testing.T) {
+	ResetStreamSessionsForTesting()
+	t.Cleanup(ResetStreamSessionsForTesting)
+	ClearInterceptors()
+	t.Cleanup(ClearInterceptors)
+
+	var seen []CallInfo
+	RegisterInterceptors(StreamInterceptorFunc(func(next StreamFunc) StreamFunc {
+		return func(ctx context.Context, call CallInfo) error {
+			seen = append(seen, call)
+			return next(ctx, call)
+		}
+	}))
+	call := CallInfo{ServiceID: "rpccgo.test.v1.Greeter", Method: "rpccgo.test.v1.Greeter.Chat", Contract: CallContractNative, Kind: ServerKindCGONative}
+	session := &cancelableTestStream{err: errors.New("cancel failed")}
+	handle := startStreamSession(t, call, ServerKindCGONative, session)
+
+	if err := CancelStreamSession(context.Background(), handle); !errors.Is(err, session.err) {
+		t.Fatalf("CancelStreamSession error = %v, want %v", err, session.err)
+	}
+	if session.canceled.Load() != 1 {
+		t.Fatalf("Cancel calls = %d, want 1", session.canceled.Load())
+	}
+	if _, err := LoadStreamSession(handle); !errors.Is(err, ErrStreamInvalidHandle) {
+		t.Fatalf("LoadStreamSession after cancel error = %v, want ErrStreamInvalidHandle", err)
+	}
+	want := call
+	want.Operation = CallOperationCancel
+	want.Stream = handle
+	if len(seen) != 2 || seen[1] != want {
+		t.Fatalf("interceptor saw %+v, want Cancel %+v", seen, want)
+	}
+	if err := CancelStreamSession(context.Background(), handle); !errors.Is(err, ErrStreamInvalidHandle) {
+		t.Fatalf("second CancelStreamSession error = %v, want ErrStreamInvalidHandle", err)
+	}
+}
+
+func TestCancelStreamSessionWaitsForCallbackReceiveDone(t *testing.T) {
+	ResetStreamSessionsForTesting()
+	t.Cleanup(ResetStreamSessionsForTesting)
+
+	session := &cancelableTestStream{}
+	handle := startStreamSession(t, CallInfo{}, ServerKindGoNative, session)
+	state, err := EnableStreamCallbackReceive(handle)
+	if err != nil {
+		t.Fatalf("EnableStreamCallbackReceive returned error: %v", err)
+	}
+	// Stand-in for the generated receive loop, which delivers onDone once
+	// the canceled source stops receiving.
+	go func() {
+		for session.canceled.Load() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		if state.BeginCallback() {
+			t.Error("BeginCallback succeeded after cancel")
+		}
+		if state.BeginDoneCallback() {
+			state.EndDoneCallback()
+		}
+	}()
+
+	if err := CancelStreamSession(context.Background(), handle); err != nil {
+		t.Fatalf("CancelStreamSession returned error: %v", err)
+	}
+	if !state.done.Load() {
+		t.Fatal("CancelStreamSession returned before onDone was delivered")
+	}
+}
+
+func TestCancelIdleStreamSessionsCancelsOnlyIdleSessions(t *testing.T) {
+	ResetStreamSessionsForTesting()
+	t.Cleanup(ResetStreamSessionsForTesting)
+
+	idleSession := &cancelableTestStream{}
+	busySession := &cancelableTestStream{}
+	idle := startStreamSession(t, CallInfo{}, ServerKindGoNative, idleSession)
+	busy := startStreamSession(t, CallInfo{}, ServerKindGoNative, busySession)
+	entry, err := LoadStreamSession(idle)
+	if err != nil {
+		t.Fatalf("LoadStreamSession returned error: %v", err)
+	}
+	entry.lastActivity.Store(time.Now().Add(-time.Minute).UnixNano())
+
+	canceled, err := CancelIdleStreamSessions(context.Background(), time.Second)
+	if err != nil {
+		t.Fatalf("CancelIdleStreamSessions returned error: %v", err)
+	}
+	if !reflect.DeepEqual(canceled, []StreamHandle{idle}) {
+		t.Fatalf("canceled = %v, want [%d]", canceled, idle)
+	}
+	if idleSession.canceled.Load() != 1 || busySession.canceled.Load() != 0 {
+		t.Fatalf("Cancel calls idle=%d busy=%d", idleSession.canceled.Load(), busySession.canceled.Load())
+	}
+	if sessions := ListStreamSessions(); len(sessions) != 1 || sessions[0].Handle != busy {
+		t.Fatalf("remaining sessions = %+v, want only %d", sessions, busy)
+	}
+}
+
+func TestStreamIdleTimeoutReapsLeakedSessions(t *testing.T) {
+	ResetStreamSessionsForTesting()
+	t.Cleanup(ResetStreamSessionsForTesting)
+	t.Cleanup(func() { SetStreamIdleTimeout(0) })
+
+	session := &cancelableTestStream{}
+	handle := startStreamSession(t, CallInfo{}, ServerKindGoNative, session)
+	SetStreamIdleTimeout(20 * time.Millisecond)
+	waitForCondition(t, time.Second, func() bool {
+		_, err := LoadStreamSession(handle)
+		return err != nil
+	}, "idle stream session was not reaped")
+	if session.canceled.Load() != 1 {
+		t.Fatalf("Cancel calls = %d, want 1", session.canceled.Load())
+	}
+
+	SetStreamIdleTimeout(0)
+	kept := startStreamSession(t, CallInfo{}, ServerKindGoNative, &cancelableTestStream{})
+	time.Sleep(50 * time.Millisecond)
+	if _, err := LoadStreamSession(kept); err != nil {
+		t.Fatalf("session was reaped after the reaper stopped: %v", err)
+	}
+}
+
+func TestEncodeStreamSessionsMatchesSchema(t *testing.T) {
+	started := time.UnixMilli(1_700_000_000_000)
+	data := EncodeStreamSessions([]StreamSessionInfo{{
+		Handle:          7,
+		Kind:            ServerKindGRPC,
+		ServiceID:       "rpccgo.test.v1.Greeter",
+		Method:          "rpccgo.test.v1.Greeter.Chat",
+		Contract:        CallContractNative,
+		StartedAt:       started,
+		LastActivity:    started.Add(time.Second),
+		CallbackReceive: true,
+	}, {
+		Handle:       8,
+		Kind:         ServerKindGoNative,
+		StartedAt:    started,
+		LastActivity: started,
+	}})
+
+	assertPayloadMatchesSchema(t, data, &runtimev1.StreamSessionList{
+		Sessions: []*runtimev1.StreamSessionInfo{{
+			Handle:             7,
+			ServerKind:         int32(ServerKindGRPC),
+			ServiceId:          "rpccgo.test.v1.Greeter",
+			Method:             "rpccgo.test.v1.Greeter.Chat",
+			Contract:           int32(CallContractNative),
+			StartedAtUnixMs:    1_700_000_000_000,
+			LastActivityUnixMs: 1_700_000_001_000,
+			CallbackReceive:    true,
+		}, {
+			Handle:             8,
+			ServerKind:         int32(ServerKindGoNative),
+			StartedAtUnixMs:    1_700_000_000_000,
+			LastActivityUnixMs: 1_700_000_000_000,
+		}},
+	})
+}
@@ -0,0 +1,250 @@
+package rpcruntime
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TraceParentKey is the request metadata key carrying the W3C trace context.
+const TraceParentKey = "traceparent"
+
+// TraceID is a W3C trace id.
+type TraceID [16]byte
+
+// SpanID is a W3C parent/span id.
+type SpanID [8]byte
+
+// SpanContext identifies a span across process and language boundaries.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+// IsValid reports whether sc has non-zero trace and span ids.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != TraceID{} && sc.SpanID != SpanID{}
+}
+
+// TraceParent formats sc as a version 00 W3C traceparent header value.
+func (sc SpanContext) TraceParent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + hex.EncodeToString(sc.TraceID[:]) + "-" + hex.EncodeToString(sc.SpanID[:]) + "-" + flags
+}
+
+var errInvalidTraceParent = errors.New("rpccgo: traceparent is invalid")
+
+// ParseTraceParent parses a W3C traceparent header value.
+func ParseTraceParent(value string) (SpanContext, error) {
+	// version "-" trace-id "-" parent-id "-" trace-flags
+	if len(value) < 55 || value[2] != '-' || value[35] != '-' || value[52] != '-' {
+		return SpanContext{}, errInvalidTraceParent
+	}
+	version, err := hex.DecodeString(value[:2])
+	if err != nil || version[0] == 0xff || (version[0] == 0 && len(value) != 55) {
+		return SpanContext{}, errInvalidTraceParent
+	}
+	var sc SpanContext
+	if _, err := hex.Decode(sc.TraceID[:], []byte(value[3:35])); err != nil {
+		return SpanContext{}, errInvalidTraceParent
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(value[36:52])); err != nil {
+		return SpanContext{}, errInvalidTraceParent
+	}
+	flags, err := hex.DecodeString(value[53:55])
+	if err != nil || !sc.IsValid() {
+		return SpanContext{}, errInvalidTraceParent
+	}
+	sc.Sampled = flags[0]&1 == 1
+	return sc, nil
+}
+
+// NewSpanContext returns a sampled span context with a fresh span id. It joins
+// the trace of parent when parent is valid and starts a new trace otherwise.
+func NewSpanContext(parent SpanContext) SpanContext {
+	sc := SpanContext{TraceID: parent.TraceID, Sampled: true}
+	if parent.IsValid() {
+		sc.Sampled = parent.Sampled
+	} else {
+		fillRandom(sc.TraceID[:])
+	}
+	fillRandom(sc.SpanID[:])
+	return sc
+}
+
+func fillRandom(b []byte) {
+	for {
+		for i := range b {
+			b[i] = byte(rand.Uint32())
+		}
+		for _, v := range b {
+			if v != 0 {
+				return
+			}
+		}
+	}
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpanContext returns a context whose calls are children of sc.
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// SpanContextFromContext returns the span context of the call carried by ctx.
+// Without one it falls back to the traceparent request metadata, so a C caller
+// can parent rpccgo spans by setting traceparent on its call options.
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if sc, ok := ctx.Value(spanContextKey{}).(SpanContext); ok {
+		return sc
+	}
+	if values := RequestMetadataFromContext(ctx).Get(TraceParentKey); len(values) > 0 {
+		if sc, err := ParseTraceParent(values[0]); err == nil {
+			return sc
+		}
+	}
+	return SpanContext{}
+}
+
+// SpanStart describes a span opened for a unary call or a stream session.
+//
+// Call carries the service id, method, contract and server kind of the call;
+// its Operation is CallOperationUnary or CallOperationStart.
+type SpanStart struct {
+	Name   string
+	Parent SpanContext
+	Call   CallInfo
+}
+
+// Span is an open span returned by a Tracer.
+type Span interface {
+	// SpanContext identifies the span; it is propagated as traceparent.
+	SpanContext() SpanContext
+	// End closes the span. err is nil for calls and sessions that succeeded.
+	End(err error)
+}
+
+// Tracer opens spans for registry-dispatched calls. It is called on the call
+// path and must be safe for concurrent use.
+type Tracer interface {
+	StartSpan(ctx context.Context, start SpanStart) Span
+}
+
+type tracerHolder struct{ tracer Tracer }
+
+var activeTracer atomic.Pointer[tracerHolder]
+
+// SetTracer installs the process-wide tracer. A nil tracer disables tracing.
+func SetTracer(tracer Tracer) {
+	if tracer == nil {
+		activeTracer.Store(nil)
+		return
+	}
+	activeTracer.Store(&tracerHolder{tracer: tracer})
+}
+
+func loadTracer() Tracer {
+	holder := activeTracer.Load()
+	if holder == nil {
+		return nil
+	}
+	return holder.tracer
+}
+
+// startCallSpan opens a span for call and returns a context that carries its
+// span context, with traceparent set in the request metadata so transports
+// forward it.
+func startCallSpan(ctx context.Context, tracer Tracer, call CallInfo) (context.Context, Span) {
+	span := tracer.StartSpan(ctx, SpanStart{
+		Name:   call.Method,
+		Parent: SpanContextFromContext(ctx),
+		Call:   call,
+	})
+	if span == nil {
+		return ctx, nil
+	}
+	sc := span.SpanContext()
+	if !sc.IsValid() {
+		return ctx, span
+	}
+	md := RequestMetadataFromContext(ctx).Copy()
+	if md == nil {
+		md = make(Metadata, 1)
+	}
+	md[TraceParentKey] = []string{sc.TraceParent()}
+	return WithRequestMetadata(ContextWithSpanContext(ctx, sc), md), span
+}
+
+// SpanRecorder is an in-memory Tracer for tests. It records every span once
+// it has ended.
+type SpanRecorder struct {
+	mu    sync.Mutex
+	ended []RecordedSpan
+}
+
+// RecordedSpan is a span captured by SpanRecorder.
+type RecordedSpan struct {
+	SpanStart
+	SpanContext SpanContext
+	StartTime   time.Time
+	EndTime     time.Time
+	Err         error
+}
+
+// NewSpanRecorder returns an empty SpanRecorder.
+func NewSpanRecorder() *SpanRecorder {
+	return &SpanRecorder{}
+}
+
+// StartSpan implements Tracer.
+func (r *SpanRecorder) StartSpan(_ context.Context, start SpanStart) Span {
+	return &recordedSpan{
+		recorder: r,
+		span: RecordedSpan{
+			SpanStart:   start,
+			SpanContext: NewSpanContext(start.Parent),
+			StartTime:   time.Now(),
+		},
+	}
+}
+
+// Ended returns the spans ended so far in end order.
+func (r *SpanRecorder) Ended() []RecordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RecordedSpan(nil), r.ended...)
+}
+
+// Reset forgets every recorded span.
+func (r *SpanRecorder) Reset() {
+	r.mu.Lock()
+	r.ended = nil
+	r.mu.Unlock()
+}
+
+type recordedSpan struct {
+	recorder *SpanRecorder
+	once     sync.Once
+	span     RecordedSpan
+}
+
+func (s *recordedSpan) SpanContext() SpanContext { return s.span.SpanContext }
+
+func (s *recordedSpan) End(err error) {
+	s.once.Do(func() {
+		s.span.EndTime = time.Now()
+		s.span.Err = err
+		s.recorder.mu.Lock()
+		s.recorder.ended = append(s.recorder.ended, s.span)
+		s.recorder.mu.Unlock()
+	})
+}
@@ -0,0 +1,229 @@
+package rpcruntime
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func useSpanRecorder(t *testing.T) *SpanRecorder {
+	t.Helper()
+	recorder := NewSpanRecorder()
+	SetTracer(recorder)
+	t.Cleanup(func() { SetTracer(nil) })
+	return recorder
+}
+
+func TestTraceParentRoundTrips(t *testing.T) {
+	const value = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, err := ParseTraceParent(value)
+	if err != nil {
+		t.Fatalf("ParseTraceParent returned error: %v", err)
+	}
+	if !sc.IsValid() || !sc.Sampled {
+		t.Fatalf("ParseTraceParent = %+v, want valid sampled context", sc)
+	}
+	if got := sc.TraceParent(); got != value {
+		t.Fatalf("TraceParent = %q, want %q", got, value)
+	}
+
+	for _, bad := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"00-4bf92f3577b34da6a3ce929d0e0e473x-00f067aa0ba902b7-01",
+	} {
+		if _, err := ParseTraceParent(bad); err == nil {
+			t.Fatalf("ParseTraceParent(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestNewSpanContextJoinsParentTrace(t *testing.T) {
+	root := NewSpanContext(SpanContext{})
+	if !root.IsValid() || !root.Sampled {
+		t.Fatalf("root span context = %+v", root)
+	}
+	child := NewSpanContext(root)
+	if child.TraceID != root.TraceID || child.SpanID == root.SpanID {
+		t.Fatalf("child span context = %+v, parent %+v", child, root)
+	}
+}
+
+func TestInterceptUnaryWithoutTracerRecordsNothing(t *testing.T) {
+	recorder := NewSpanRecorder()
+	SetTracer(nil)
+	err := InterceptUnary(context.Background(), CallInfo{Operation: CallOperationUnary}, func(ctx context.Context, _ CallInfo) error {
+		if SpanContextFromContext(ctx).IsValid() {
+			t.Fatal("untraced call carries a span context")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("InterceptUnary returned error: %v", err)
+	}
+	if got := recorder.Ended(); len(got) != 0 {
+		t.Fatalf("recorded spans = %v", got)
+	}
+}
+
+func TestInterceptUnaryRecordsSpanAndPropagatesTraceParent(t *testing.T) {
+	ClearInterceptors()
+	t.Cleanup(ClearInterceptors)
+	recorder := useSpanRecorder(t)
+
+	parent := NewSpanContext(SpanContext{})
+	ctx := WithRequestMetadata(context.Background(), Metadata{
+		TraceParentKey:  {parent.TraceParent()},
+		"authorization": {"token"},
+	})
+	call := CallInfo{
+		ServiceID: "rpccgo.test.v1.Greeter",
+		Method:    "rpccgo.test.v1.Greeter.SayHello",
+		Contract:  CallContractNative,
+		Kind:      ServerKindCGONative,
+		Operation: CallOperationUnary,
+	}
+	failure := errors.New("boom")
+	var seen SpanContext
+	var seenMetadata Metadata
+	err := InterceptUnary(ctx, call, func(ctx context.Context, _ CallInfo) error {
+		seen = SpanContextFromContext(ctx)
+		seenMetadata = RequestMetadataFromContext(ctx)
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("InterceptUnary error = %v, want %v", err, failure)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name != call.Method || span.Call != call {
+		t.Fatalf("span = %+v, want call %+v", span.SpanStart, call)
+	}
+	if span.Parent != parent || span.SpanContext.TraceID != parent.TraceID {
+		t.Fatalf("span parent = %+v, context = %+v, want parent %+v", span.Parent, span.SpanContext, parent)
+	}
+	if !errors.Is(span.Err, failure) {
+		t.Fatalf("span error = %v, want %v", span.Err, failure)
+	}
+	if seen != span.SpanContext {
+		t.Fatalf("next saw span context %+v, want %+v", seen, span.SpanContext)
+	}
+	if got := seenMetadata.Get(TraceParentKey); !reflect.DeepEqual(got, []string{span.SpanContext.TraceParent()}) {
+		t.Fatalf("next saw traceparent %v", got)
+	}
+	if got := seenMetadata.Get("authorization"); !reflect.DeepEqual(got, []string{"token"}) {
+		t.Fatalf("next lost request metadata: %v", seenMetadata)
+	}
+	if got := RequestMetadataFromContext(ctx).Get(TraceParentKey); !reflect.DeepEqual(got, []string{parent.TraceParent()}) {
+		t.Fatalf("caller metadata was modified: %v", got)
+	}
+}
+
+func startTracedStream(t *testing.T, call CallInfo) StreamHandle {
+	t.Helper()
+	var handle StreamHandle
+	call.Operation = CallOperationStart
+	err := InterceptStream(context.Background(), call, func(ctx context.Context, _ CallInfo) error {
+		var err error
+		handle, err = CreateStreamSessionContext(ctx, ServerKindGoNative, &testTypedStreamSession{name: "stream"})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("InterceptStream Start returned error: %v", err)
+	}
+	return handle
+}
+
+func runTracedStreamOperation(call CallInfo, op CallOperation, handle StreamHandle, remove bool, result error) error {
+	call.Operation = op
+	call.Stream = handle
+	return InterceptStream(context.Background(), call, func(ctx context.Context, _ CallInfo) error {
+		if remove {
+			if _, err := RemoveStreamSession(handle); err != nil {
+				return err
+			}
+		}
+		return result
+	})
+}
+
+func TestStreamSessionSpanEndsWhenSessionIsRemoved(t *testing.T) {
+	ResetStreamSessionsForTesting()
+	t.Cleanup(ResetStreamSessionsForTesting)
+	recorder := useSpanRecorder(t)
+
+	call := CallInfo{
+		ServiceID: "rpccgo.test.v1.Greeter",
+		Method:    "rpccgo.test.v1.Greeter.Chat",
+		Contract:  CallContractMessage,
+		Kind:      ServerKindGoNative,
+	}
+	handle := startTracedStream(t, call)
+	if got := recorder.Ended(); len(got) != 0 {
+		t.Fatalf("span ended after Start: %v", got)
+	}
+	if err := runTracedStreamOperation(call, CallOperationSend, handle, false, nil); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	if got := recorder.Ended(); len(got) != 0 {
+		t.Fatalf("span ended after Send: %v", got)
+	}
+	if err := runTracedStreamOperation(call, CallOperationFinish, handle, true, io.EOF); !errors.Is(err, io.EOF) {
+		t.Fatalf("Finish error = %v, want io.EOF", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+	if spans[0].Call.Operation != CallOperationStart || spans[0].Call.Method != call.Method || spans[0].Call.Kind != ServerKindGoNative {
+		t.Fatalf("span call = %+v", spans[0].Call)
+	}
+	if spans[0].Err != nil {
+		t.Fatalf("span error = %v, want nil", spans[0].Err)
+	}
+}
+
+func TestStreamSessionSpanRecordsCancel(t *testing.T) {
+	ResetStreamSessionsForTesting()
+	t.Cleanup(ResetStreamSessionsForTesting)
+	recorder := useSpanRecorder(t)
+
+	call := CallInfo{ServiceID: "rpccgo.test.v1.Greeter", Method: "rpccgo.test.v1.Greeter.Chat"}
+	handle := startTracedStream(t, call)
+	if err := runTracedStreamOperation(call, CallOperationCancel, handle, true, nil); err != nil {
+		t.Fatalf("Cancel returned error: %v", err)
+	}
+	spans := recorder.Ended()
+	if len(spans) != 1 || !errors.Is(spans[0].Err, context.Canceled) {
+		t.Fatalf("spans = %+v, want one canceled span", spans)
+	}
+}
+
+func TestStreamStartSpanEndsWhenNoSessionIsCreated(t *testing.T) {
+	ResetStreamSessionsForTesting()
+	t.Cleanup(ResetStreamSessionsForTesting)
+	recorder := useSpanRecorder(t)
+
+	failure := errors.New("start failed")
+	err := InterceptStream(context.Background(), CallInfo{Operation: CallOperationStart}, func(context.Context, CallInfo) error {
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Start error = %v, want %v", err, failure)
+	}
+	spans := recorder.Ended()
+	if len(spans) != 1 || !errors.Is(spans[0].Err, failure) {
+		t.Fatalf("spans = %+v, want one failed span", spans)
+	}
+}